@@ -0,0 +1,13 @@
+// Command gensimdcheck reports the constructs gensimd can't compile in the
+// functions it generates assembly for, e.g. "gensimdcheck ./...".
+package main
+
+import (
+	"github.com/bjwbell/gensimd/gensimdcheck"
+
+	"golang.org/x/tools/go/analysis/singlechecker"
+)
+
+func main() {
+	singlechecker.Main(gensimdcheck.Analyzer)
+}
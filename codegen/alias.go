@@ -0,0 +1,224 @@
+package codegen
+
+import (
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/ssa"
+)
+
+// memObject returns the slice, pointer, or local the address or slice v
+// points into, the x of "&x[i]" and "x[lo:hi]".
+func memObject(v ssa.Value) ssa.Value {
+	for {
+		switch x := v.(type) {
+		case *ssa.IndexAddr:
+			v = x.X
+		case *ssa.Slice:
+			v = x.X
+		default:
+			return v
+		}
+	}
+}
+
+// elemType returns the type of the elements of slice or pointer type t, of a
+// pointer to an array too, otherwise nil.
+func elemType(t types.Type) types.Type {
+	switch t := t.Underlying().(type) {
+	case *types.Slice:
+		return t.Elem()
+	case *types.Pointer:
+		if a, ok := t.Elem().Underlying().(*types.Array); ok {
+			return a.Elem()
+		}
+		return t.Elem()
+	}
+	return nil
+}
+
+// containsType returns whether memory of type t may hold a value of type
+// elem, t is elem or an array or struct with an element or field containing
+// it. Types with identical underlying types are the same memory, e.g. the
+// *simd.I32x4 and *[4]int32 of a conversion.
+func containsType(t, elem types.Type) bool {
+	if types.Identical(t.Underlying(), elem.Underlying()) {
+		return true
+	}
+	switch t := t.Underlying().(type) {
+	case *types.Array:
+		return containsType(t.Elem(), elem)
+	case *types.Struct:
+		for i := 0; i < t.NumFields(); i++ {
+			if containsType(t.Field(i).Type(), elem) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// disjointObjects returns whether the memory reachable from the addresses or
+// slices a and b doesn't overlap. Locals are allocated by the function, so
+// they don't overlap each other or the parameters. Slice and pointer
+// parameters don't overlap with NoAlias set or if neither element type
+// contains the other, e.g. the []float32 and []int32 of a conversion, it's
+// assumed that unsafe isn't used to view the same memory as different types.
+// A []simd.I32x4 and an []int32 may overlap, "v[0][:]".
+func (f *Function) disjointObjects(a, b ssa.Value) bool {
+	a, b = memObject(a), memObject(b)
+	if a == b {
+		return false
+	}
+	_, allocA := a.(*ssa.Alloc)
+	_, allocB := b.(*ssa.Alloc)
+	_, paramA := a.(*ssa.Parameter)
+	_, paramB := b.(*ssa.Parameter)
+	switch {
+	case allocA && (allocB || paramB), allocB && paramA:
+		return true
+	case paramA && paramB:
+		ta, tb := elemType(a.Type()), elemType(b.Type())
+		if ta == nil || tb == nil {
+			return false
+		}
+		return f.NoAlias || !containsType(ta, tb) && !containsType(tb, ta)
+	}
+	return false
+}
+
+// mayAlias returns whether the element addresses a and b may be the same or
+// overlap. Elements of the same slice or array at indexes differing by a
+// constant, e.g. x[i] and x[i+1], don't, see indexOffset.
+func (f *Function) mayAlias(a, b ssa.Value) bool {
+	if f.disjointObjects(a, b) {
+		return false
+	}
+	ea, okA := a.(*ssa.IndexAddr)
+	eb, okB := b.(*ssa.IndexAddr)
+	if okA && okB && ea.X == eb.X {
+		ia, ca := indexOffset(ea.Index)
+		ib, cb := indexOffset(eb.Index)
+		return ia != ib || ca == cb
+	}
+	return true
+}
+
+// memKey identifies the memory at an address, the slice or array, index,
+// and constant offset of an element address "&x[i+c]", otherwise the
+// address. Element addresses are compared by key, those fused into their
+// loads or stores aren't replaced by an equal address, see computeAddrCSE,
+// and equal constants are different values.
+type memKey struct {
+	x, index ssa.Value
+	offset   int64
+	elem     bool
+}
+
+func addrKey(addr ssa.Value) memKey {
+	if elem, ok := addr.(*ssa.IndexAddr); ok {
+		index, offset := indexOffset(elem.Index)
+		return memKey{elem.X, index, offset, true}
+	}
+	return memKey{x: addr}
+}
+
+// computeLoadCSE finds the loads "*addr" of a block reading a value already
+// loaded from addr or stored to it earlier in the block, with no store or
+// call in between that may change it, see mayAlias. They share the
+// identifier of the first load or of the stored value instead of loading it
+// again, store-to-load forwarding.
+func (f *Function) computeLoadCSE() {
+	f.loadCSE = make(map[ssa.Value]ssa.Value)
+	if !f.Optimize {
+		return
+	}
+	type availValue struct {
+		addr, value ssa.Value
+	}
+	for _, block := range f.ssa.Blocks {
+		if f.selectArms[block] || f.switchBlocks[block] {
+			continue
+		}
+		// the values at the addresses read or written in the block so far
+		avail := map[memKey]availValue{}
+		kill := func(changed func(addr ssa.Value) bool) {
+			for k, v := range avail {
+				if changed(v.addr) {
+					delete(avail, k)
+				}
+			}
+		}
+		for _, instr := range block.Instrs {
+			switch instr := instr.(type) {
+			case *ssa.UnOp:
+				if instr.Op != token.MUL || !f.loadCSEValue(instr) {
+					continue
+				}
+				k := addrKey(instr.X)
+				if first, ok := avail[k]; ok && types.Identical(first.value.Type(), instr.Type()) {
+					f.loadCSE[instr] = first.value
+					f.identifiers[instr.Name()] = f.Ident(first.value)
+					continue
+				}
+				avail[k] = availValue{instr.X, instr}
+			case *ssa.Store:
+				kill(func(addr ssa.Value) bool { return f.mayAlias(addr, instr.Addr) })
+				if _, ok := instr.Val.(*ssa.Const); !ok && f.loadCSEValue(instr.Val) {
+					avail[addrKey(instr.Addr)] = availValue{instr.Addr, f.cseValue(instr.Val)}
+				}
+			case *ssa.Call:
+				if builtin, ok := instr.Common().Value.(*ssa.Builtin); ok && builtin.Name() == "len" {
+					continue
+				}
+				_, atomic := atomicCallOp(instr)
+				args := []ssa.Value{}
+				for _, arg := range instr.Common().Args {
+					if isSlice(arg.Type()) || isPointer(arg.Type()) {
+						args = append(args, arg)
+					}
+				}
+				if _, custom := registeredIntrinsic(instr); atomic || custom || instr.Common().StaticCallee() == nil ||
+					len(args) == 0 && !isSimdIntrinsic(instr) {
+					// fences and other calls ordering memory
+					kill(func(ssa.Value) bool { return true })
+					continue
+				}
+				kill(func(a ssa.Value) bool {
+					for _, arg := range args {
+						if !f.disjointObjects(a, arg) {
+							return true
+						}
+					}
+					return false
+				})
+			}
+		}
+	}
+}
+
+// loadCSEValue returns whether v is an integer, bool, float, or simd value
+// fitting in a register, lowered by Instr or a parameter, and not kept in a
+// register by a reduction, whose slot isn't updated, see setReduction.
+func (f *Function) loadCSEValue(v ssa.Value) bool {
+	switch v := v.(type) {
+	case *ssa.Parameter:
+	case ssa.Instruction:
+		if f.fusedInstrs[v] {
+			return false
+		}
+		if load, ok := v.(*ssa.UnOp); ok && f.pipelined[load] != nil {
+			return false
+		}
+	default:
+		return false
+	}
+	if _, ok := f.pins[v.Name()]; ok {
+		return false
+	}
+	t := v.Type()
+	if isXmm(t) {
+		return sizeof(t) <= XmmRegSize
+	}
+	return (isInteger(t) || isBool(t)) && sizeof(t) <= DataRegSize
+}
@@ -0,0 +1,143 @@
+package codegen
+
+import (
+	"fmt"
+	"go/token"
+
+	"golang.org/x/tools/go/ssa"
+)
+
+// computeBitLoops finds the parts of the loop consuming the set bits of a
+// mask, like the mask from simd.MoveMaskU8x16:
+//
+//	for mask != 0 {
+//		i := bits.TrailingZeros(uint(mask))
+//		mask &= mask - 1
+//		...
+//	}
+//
+// "mask & (mask-1)" clears the lowest set bit with a single BLSR if the
+// target has BMI1, the "mask - 1" isn't emitted if it's only used by the and.
+// bits.TrailingZeros of a mask known to be nonzero, because the block is
+// only reached if "mask != 0", is a single TZCNT or BSF without the zero
+// handling.
+func (f *Function) computeBitLoops() {
+	f.clearLowest = make(map[*ssa.BinOp]ssa.Value)
+	f.nonzeroTZ = make(map[*ssa.Call]bool)
+	if !f.Optimize {
+		return
+	}
+	blsr := f.hasTarget(instrTargets[BLSRQ])
+	for _, block := range f.ssa.Blocks {
+		for _, instr := range block.Instrs {
+			switch instr := instr.(type) {
+			case *ssa.BinOp:
+				if f.fusedInstrs[instr] || !blsr {
+					continue
+				}
+				x, sub, ok := matchClearLowest(instr)
+				if !ok {
+					continue
+				}
+				f.clearLowest[instr] = x
+				if len(nonDebugRefs(sub)) == 1 {
+					f.fusedInstrs[sub] = true
+				}
+			case *ssa.Call:
+				if _, ok := isBitsIntrinsic(instr); ok && isNonzero(instr.Common().Args[0], block) {
+					f.nonzeroTZ[instr] = true
+				}
+			}
+		}
+	}
+}
+
+// matchClearLowest returns x and the x - 1 of "x & (x-1)" or "(x-1) & x",
+// x a 4 or 8 byte integer.
+func matchClearLowest(and *ssa.BinOp) (ssa.Value, *ssa.BinOp, bool) {
+	if and.Op != token.AND || !isInteger(and.Type()) {
+		return nil, nil, false
+	}
+	if size := sizeof(and.Type()); size != 4 && size != 8 {
+		return nil, nil, false
+	}
+	for _, xy := range [][2]ssa.Value{{and.X, and.Y}, {and.Y, and.X}} {
+		sub, ok := xy[1].(*ssa.BinOp)
+		if !ok || sub.Op != token.SUB || sub.X != xy[0] || sub.Block() != and.Block() {
+			continue
+		}
+		if c, ok := sub.Y.(*ssa.Const); ok && c.Value != nil && c.Int64() == 1 {
+			return xy[0], sub, true
+		}
+	}
+	return nil, nil, false
+}
+
+// isNonzero returns whether the integer v is known to be nonzero in block,
+// block is dominated by the successor of an if on "x != 0" or "x == 0"
+// reached only if x isn't zero, v is x or x converted to a larger or equal
+// integer type.
+func isNonzero(v ssa.Value, block *ssa.BasicBlock) bool {
+	for {
+		conv, ok := v.(*ssa.Convert)
+		if !ok || !isInteger(conv.X.Type()) || sizeof(conv.X.Type()) > sizeof(conv.Type()) {
+			break
+		}
+		v = conv.X
+	}
+	for dom := block; dom != nil; dom = dom.Idom() {
+		idom := dom.Idom()
+		if idom == nil || len(dom.Preds) != 1 || dom.Preds[0] != idom || len(idom.Instrs) == 0 {
+			continue
+		}
+		instr, ok := idom.Instrs[len(idom.Instrs)-1].(*ssa.If)
+		if !ok {
+			continue
+		}
+		cmp, ok := instr.Cond.(*ssa.BinOp)
+		if !ok || (cmp.Op != token.NEQ && cmp.Op != token.EQL) {
+			continue
+		}
+		x, y := cmp.X, cmp.Y
+		if _, ok := x.(*ssa.Const); ok {
+			x, y = y, x
+		}
+		if c, ok := y.(*ssa.Const); !ok || x != v || c.Value == nil || c.Int64() != 0 {
+			continue
+		}
+		// the true successor for !=, the false one for ==
+		succ := 0
+		if cmp.Op == token.EQL {
+			succ = 1
+		}
+		if idom.Succs[succ] == dom && idom.Succs[0] != idom.Succs[1] {
+			return true
+		}
+	}
+	return false
+}
+
+// ClearLowest computes x & (x-1) with BLSR.
+func (f *Function) ClearLowest(instr *ssa.BinOp, x ssa.Value) (string, *Error) {
+	ctx := context{f, instr}
+	ident := f.Ident(instr)
+	asm, regX, err := f.LoadValue(instr, x, 0, f.sizeof(x))
+	if err != nil {
+		return asm, err
+	}
+	a, regVal := f.allocIdentReg(instr, ident, f.sizeof(instr))
+	asm += a
+	blsr := BLSRQ
+	if f.sizeof(instr) == 4 {
+		blsr = BLSRL
+	}
+	asm += instrRegReg(ctx, blsr, regX, regVal, false)
+	f.freeReg(regX)
+	a, err = f.StoreValue(instr, ident, regVal)
+	f.freeReg(regVal)
+	if err != nil {
+		return asm, err
+	}
+	asm += a
+	return fmt.Sprintf("// BEGIN ssa.BinOp, %v = %v, clears the lowest bit\n", instr.Name(), instr) + asm, nil
+}
@@ -0,0 +1,64 @@
+package codegen
+
+import (
+	"fmt"
+
+	"golang.org/x/tools/go/ssa"
+)
+
+// bitsIntrinsics are the functions of package math/bits computed inline.
+var bitsIntrinsics = map[string]bool{
+	"TrailingZeros":   true,
+	"TrailingZeros8":  true,
+	"TrailingZeros16": true,
+	"TrailingZeros32": true,
+	"TrailingZeros64": true,
+}
+
+// isBitsIntrinsic returns the name of the math/bits function call calls, if
+// it's computed inline.
+func isBitsIntrinsic(call *ssa.Call) (string, bool) {
+	callee := call.Common().StaticCallee()
+	if callee == nil || callee.Pkg == nil || callee.Pkg.Pkg.Path() != "math/bits" {
+		return "", false
+	}
+	return callee.Name(), bitsIntrinsics[callee.Name()]
+}
+
+// BitsIntrinsic computes bits.TrailingZeros with TZCNT if the target has
+// it, otherwise BSF, e.g. for the index of the first bit set in the mask
+// from simd.MoveMaskU8x16.
+func (f *Function) BitsIntrinsic(call *ssa.Call, name string) (string, *Error) {
+	ctx := context{f, call}
+	args := call.Common().Args
+	ident := f.Ident(call)
+	asm, x, err := f.LoadValueSimple(call, args[0])
+	if err != nil {
+		return asm, err
+	}
+	a, result := f.allocIdentReg(call, ident, ident.size())
+	asm += a
+	a, tmp := f.allocReg(call, DATA_REG, DataRegSize)
+	asm += a
+	if !bitsIntrinsics[name] {
+		ice(fmt.Sprintf("unknown bits intrinsic (%v)", name))
+	}
+	tzcnt := f.hasTarget(instrTargets[TZCNTQ])
+	if f.nonzeroTZ[call] {
+		// in a loop over the bits of a mask, see bitloop.go
+		asm += NonzeroTrailingZeros(ctx, f.sizeof(args[0]), tzcnt, x, result)
+	} else {
+		asm += TrailingZeros(ctx, f.sizeof(args[0]), tzcnt, x, result, tmp)
+	}
+	f.freeReg(x)
+	f.freeReg(tmp)
+	a, err = f.StoreValue(call, ident, result)
+	f.freeReg(result)
+	if err != nil {
+		return asm, err
+	}
+	asm += a
+	asm = fmt.Sprintf("// BEGIN bits intrinsic %v\n", call) + asm
+	asm += fmt.Sprintf("// END bits intrinsic %v\n", call)
+	return asm, nil
+}
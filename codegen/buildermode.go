@@ -0,0 +1,63 @@
+package codegen
+
+import (
+	"fmt"
+	"go/token"
+
+	"golang.org/x/tools/go/ssa"
+)
+
+// BuilderMode is the ssa.BuilderMode gensimd builds packages with, callers
+// building the ssa of functions themselves should use it. Functions must
+// be in lifted form, ssa.NaiveForm leaves every local in memory and calls
+// the runtime's defer stack. Debug references, from ssa.GlobalDebug or
+// ssa.Package.SetDebugMode, are optional, the assembly is the same with
+// and without them.
+const BuilderMode = ssa.SanityCheckFunctions | ssa.GlobalDebug
+
+// builderModeMsg returns the error message of fn if it was built with a
+// mode gensimd doesn't support, "" otherwise.
+func builderModeMsg(fn *ssa.Function) string {
+	if fn.Synthetic == "package initializer" {
+		return fmt.Sprintf("package initializer %v unsupported, it runs the initialization of the package's variables and imports", fn.Name())
+	}
+	if naiveForm(fn) {
+		return fmt.Sprintf("%v was built with ssa.NaiveForm, gensimd needs lifted ssa, build it without NaiveForm, e.g. with codegen.BuilderMode", fn.Name())
+	}
+	return ""
+}
+
+// naiveForm returns whether fn was built with ssa.NaiveForm, lifting
+// replaces every local only loaded and stored to with values unless fn
+// recovers from panics.
+func naiveForm(fn *ssa.Function) bool {
+	if fn.Recover != nil {
+		return false
+	}
+	for _, local := range fn.Locals {
+		if liftable(local) {
+			return true
+		}
+	}
+	return false
+}
+
+// liftable returns whether local is only loaded and stored to.
+func liftable(local *ssa.Alloc) bool {
+	for _, ref := range *local.Referrers() {
+		switch ref := ref.(type) {
+		case *ssa.DebugRef:
+		case *ssa.Store:
+			if ref.Val == local {
+				return false
+			}
+		case *ssa.UnOp:
+			if ref.Op != token.MUL {
+				return false
+			}
+		default:
+			return false
+		}
+	}
+	return true
+}
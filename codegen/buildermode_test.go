@@ -0,0 +1,50 @@
+package codegen
+
+import (
+	"strings"
+	"testing"
+
+	"golang.org/x/tools/go/ssa"
+)
+
+// TestBuilderMode generates the same assembly with and without debug
+// references and refuses naive ssa and the package initializer.
+func TestBuilderMode(t *testing.T) {
+	const src = `package src
+
+var table = [4]int{1, 2, 3, 4}
+
+func copyMod(p *[4]int, x int) int {
+	a := *p
+	a[x&3] = x
+	return a[0] + a[3]
+}
+`
+	gen := func(mode ssa.BuilderMode) string {
+		f, err := CreateFunction(buildFuncMode(t, src, "copyMod", mode), DefaultOptions())
+		if err != nil {
+			t.Fatal(err.Err)
+		}
+		asm, err := f.GoAssembly()
+		if err != nil {
+			t.Fatal(err.Err)
+		}
+		return asm
+	}
+	if debug, nodebug := gen(BuilderMode), gen(ssa.SanityCheckFunctions); debug != nodebug {
+		t.Errorf("assembly with debug references\n%v\ndiffers from without\n%v", debug, nodebug)
+	}
+
+	naive := buildFuncMode(t, src, "copyMod", BuilderMode|ssa.NaiveForm)
+	if _, err := CreateFunction(naive, DefaultOptions()); err == nil || !strings.Contains(err.Err.Error(), "NaiveForm") {
+		t.Errorf("CreateFunction of naive ssa returned %v, expected a NaiveForm error", err)
+	}
+	if errs := Unsupported(naive); len(errs) != 1 || !strings.Contains(errs[0].Err.Error(), "NaiveForm") {
+		t.Errorf("Unsupported of naive ssa returned %v, expected one NaiveForm error", errs)
+	}
+
+	init := buildFuncMode(t, src, "init", BuilderMode)
+	if _, err := CreateFunction(init, DefaultOptions()); err == nil || !strings.Contains(err.Err.Error(), "package initializer") {
+		t.Errorf("CreateFunction of init returned %v, expected a package initializer error", err)
+	}
+}
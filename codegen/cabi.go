@@ -0,0 +1,233 @@
+package codegen
+
+import (
+	"fmt"
+	"go/types"
+	"strings"
+)
+
+// cIntRegs are the integer argument registers of the System V AMD64 ABI.
+var cIntRegs = []string{"DI", "SI", "DX", "CX", "R8", "R9"}
+
+// cFloatRegs is the number of floating point argument registers, X0 to X7.
+const cFloatRegs = 8
+
+// cSaved are the registers the System V AMD64 ABI has the callee save,
+// which Go assembly doesn't.
+var cSaved = []string{"BX", "BP", "R12", "R13", "R14", "R15"}
+
+// cArg is a word of the arguments of a C entry point, a parameter or a
+// component of a slice parameter.
+type cArg struct {
+	decl  string // the C parameter declaration, e.g. "int32_t *x"
+	off   int    // the offset of the word in the Go arguments
+	size  uint
+	float bool
+}
+
+// cBasicType returns the C type of the basic type t, false if it has none.
+func cBasicType(t *types.Basic) (string, bool) {
+	switch t.Kind() {
+	case types.Bool:
+		return "_Bool", true
+	case types.Int8:
+		return "int8_t", true
+	case types.Int16:
+		return "int16_t", true
+	case types.Int32:
+		return "int32_t", true
+	case types.Int, types.Int64:
+		return "int64_t", true
+	case types.Uint8:
+		return "uint8_t", true
+	case types.Uint16:
+		return "uint16_t", true
+	case types.Uint32:
+		return "uint32_t", true
+	case types.Uint, types.Uint64:
+		return "uint64_t", true
+	case types.Uintptr:
+		return "uintptr_t", true
+	case types.Float32:
+		return "float", true
+	case types.Float64:
+		return "double", true
+	}
+	return "", false
+}
+
+// cPointerType returns the C type of a pointer to elem, void * unless elem
+// is a basic type.
+func cPointerType(elem types.Type) string {
+	if b, ok := elem.Underlying().(*types.Basic); ok {
+		if ctype, ok := cBasicType(b); ok {
+			return ctype + " *"
+		}
+	}
+	return "void *"
+}
+
+// CName returns the symbol of the C entry point of f, its package name and
+// output name joined by an underscore, e.g. kernels_sum. C symbols share
+// one namespace, the package name keeps them apart.
+func (f *Function) CName() string {
+	return f.ssa.Package().Pkg.Name() + "_" + f.outfname()
+}
+
+// cArgs returns the argument words of the C entry point of f.
+func (f *Function) cArgs() ([]cArg, *Error) {
+	offsets, _ := f.paramOffsets()
+	args := []cArg{}
+	for i, p := range f.ssa.Params {
+		switch t := p.Type().Underlying().(type) {
+		case *types.Basic:
+			if ctype, ok := cBasicType(t); ok {
+				args = append(args, cArg{ctype + " " + p.Name(), offsets[i], sizeof(t), isFloat(t)})
+				continue
+			}
+		case *types.Pointer:
+			args = append(args, cArg{cPointerType(t.Elem()) + p.Name(), offsets[i], sizePtr(), false})
+			continue
+		case *types.Slice:
+			// the data pointer, length, and capacity words
+			args = append(args, cArg{cPointerType(t.Elem()) + p.Name(), offsets[i], sizePtr(), false})
+			args = append(args, cArg{"int64_t " + p.Name() + "_len", offsets[i] + int(sizePtr()), sizeInt(), false})
+			args = append(args, cArg{"int64_t " + p.Name() + "_cap", offsets[i] + 2*int(sizePtr()), sizeInt(), false})
+			continue
+		}
+		msg := "parameter %v of %v has type %v, which has no C equivalent"
+		return nil, ErrorMsg2(fmt.Sprintf(msg, p.Name(), f.outfname(), p.Type()))
+	}
+	return args, nil
+}
+
+// cResult returns the C type of the result of f, "void" if it has none.
+func (f *Function) cResult() (string, *Error) {
+	t := f.retType()
+	if t == nil {
+		return "void", nil
+	}
+	switch u := t.Underlying().(type) {
+	case *types.Basic:
+		if ctype, ok := cBasicType(u); ok {
+			return ctype, nil
+		}
+	case *types.Pointer:
+		return strings.TrimSuffix(cPointerType(u.Elem()), " "), nil
+	}
+	msg := "result of %v has type %v, which has no C equivalent"
+	return "", ErrorMsg2(fmt.Sprintf(msg, f.outfname(), t))
+}
+
+// CEntry returns the assembly of a C callable entry point of f named
+// CName, following the System V AMD64 ABI, and its C declaration.
+// GoAssembly must have succeeded. The entry point saves the registers C
+// callers expect preserved, copies the arguments from their registers and
+// the C stack to the Go arguments, calls f, and returns its result in AX or
+// X0. Slices are passed as their data pointer, length, and capacity.
+//
+// C threads have no goroutine stack to grow, so f must be NoSplit. The
+// entry point itself has no frame the linker counts, its stack use is on
+// the C stack.
+func (f *Function) CEntry() (string, string, *Error) {
+	if !f.opts.NoSplit {
+		msg := "C entry point of %v needs NoSplit, C threads have no goroutine stack to grow"
+		return "", "", ErrorMsg2(fmt.Sprintf(msg, f.outfname()))
+	}
+	if f.opts.OS == "windows" {
+		return "", "", ErrorMsg2("C entry points follow the System V ABI, windows isn't supported")
+	}
+	args, err := f.cArgs()
+	if err != nil {
+		return "", "", err
+	}
+	result, err := f.cResult()
+	if err != nil {
+		return "", "", err
+	}
+
+	// the Go arguments at 0(SP), then the saved registers
+	argsSize := (int(f.argsSize) + 7) / 8 * 8
+	frame := argsSize + 8*len(cSaved)
+	// keep SP 16 byte aligned, it's 8 past alignment at the entry
+	if (frame+8)%16 != 0 {
+		frame += 8
+	}
+	asm := fmt.Sprintf("%-9v    $%v, SP\n", SUBQ, frame)
+	for i, r := range cSaved {
+		asm += fmt.Sprintf("%-9v    %v, %v(SP)\n", MOVQ, r, argsSize+8*i)
+	}
+	ints, floats, stack := 0, 0, 0
+	decls := []string{}
+	for _, arg := range args {
+		decls = append(decls, arg.decl)
+		mov := GetInstr(I_MOV, GetIntegerOpDataType(false, arg.size)).String()
+		if arg.float {
+			mov = MOVSS.String()
+			if arg.size == 8 {
+				mov = MOVSD.String()
+			}
+		}
+		switch {
+		case arg.float && floats < cFloatRegs:
+			asm += fmt.Sprintf("%-9v    X%v, %v(SP)\n", mov, floats, arg.off)
+			floats++
+		case !arg.float && ints < len(cIntRegs):
+			asm += fmt.Sprintf("%-9v    %v, %v(SP)\n", mov, cIntRegs[ints], arg.off)
+			ints++
+		default:
+			// past the return address, in 8 byte slots in order
+			asm += fmt.Sprintf("%-9v    %v(SP), AX\n", MOVQ, frame+8+8*stack)
+			asm += fmt.Sprintf("%-9v    AX, %v(SP)\n", GetInstr(I_MOV, GetIntegerOpDataType(false, arg.size)), arg.off)
+			stack++
+		}
+	}
+	asm += fmt.Sprintf("%-9v    ·%v(SB)\n", "CALL", f.outfname())
+	if t := f.retType(); t != nil {
+		off := f.retOffset()
+		switch {
+		case isFloat(t) && sizeof(t) == 4:
+			asm += fmt.Sprintf("%-9v    %v(SP), X0\n", MOVSS, off)
+		case isFloat(t):
+			asm += fmt.Sprintf("%-9v    %v(SP), X0\n", MOVSD, off)
+		case sizeof(t) == 8:
+			asm += fmt.Sprintf("%-9v    %v(SP), AX\n", MOVQ, off)
+		default:
+			movx := map[uint]Instruction{1: MOVBQZX, 2: MOVWQZX, 4: MOVLQZX}[sizeof(t)]
+			if signed(t) {
+				movx = map[uint]Instruction{1: MOVBQSX, 2: MOVWQSX, 4: MOVLQSX}[sizeof(t)]
+			}
+			asm += fmt.Sprintf("%-9v    %v(SP), AX\n", movx, off)
+		}
+	}
+	for i, r := range cSaved {
+		asm += fmt.Sprintf("%-9v    %v(SP), %v\n", MOVQ, argsSize+8*i, r)
+	}
+	asm += fmt.Sprintf("%-9v    $%v, SP\n", ADDQ, frame)
+	asm += "RET\n"
+
+	text := fmt.Sprintf("// %v is the C entry point of ·%v\n", f.CName(), f.outfname())
+	text += fmt.Sprintf("TEXT %v(SB),NOSPLIT|NOFRAME,$0-0\n", f.CName())
+	text += addIndent(asm, f.opts.Indent)
+	if len(decls) == 0 {
+		decls = append(decls, "void")
+	}
+	decl := fmt.Sprintf("GENSIMD_CABI %v %v(%v);\n", result, f.CName(), strings.Join(decls, ", "))
+	return text, decl, nil
+}
+
+// CHeader returns a C header declaring the C entry points decls, from
+// CEntry, guarded by the macro guard. The declarations are prefixed by the
+// macro GENSIMD_CABI, empty unless defined before the header is included.
+// cgo preambles define it as __attribute__((weak)), cgo links the preamble
+// alone to find its imports.
+func CHeader(guard string, decls []string) string {
+	h := fmt.Sprintf("#ifndef %v\n#define %v\n\n", guard, guard)
+	h += "#include <stdint.h>\n\n"
+	h += "#ifndef GENSIMD_CABI\n#define GENSIMD_CABI\n#endif\n\n"
+	h += "#ifdef __cplusplus\nextern \"C\" {\n#endif\n\n"
+	h += strings.Join(decls, "")
+	h += "\n#ifdef __cplusplus\n}\n#endif\n\n"
+	h += fmt.Sprintf("#endif // %v\n", guard)
+	return h
+}
@@ -0,0 +1,59 @@
+package codegen
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCEntry(t *testing.T) {
+	const src = "package src\n\nfunc scale(x []float32, s float32) {\n\tfor i := range x {\n\t\tx[i] *= s\n\t}\n}\n\n" +
+		"func first(x [4]int32) int32 {\n\treturn x[0]\n}\n"
+	opts := DefaultOptions()
+	f, err := CreateFunction(buildFunc(t, src, "scale"), opts)
+	if err != nil {
+		t.Fatal(err.Err)
+	}
+	if _, err := f.GoAssembly(); err != nil {
+		t.Fatal(err.Err)
+	}
+	if _, _, err := f.CEntry(); err == nil || !strings.Contains(err.Err.Error(), "needs NoSplit") {
+		t.Errorf("C entry point without NoSplit error %v", err)
+	}
+
+	opts.NoSplit = true
+	f, err = CreateFunction(buildFunc(t, src, "scale"), opts)
+	if err != nil {
+		t.Fatal(err.Err)
+	}
+	if _, err := f.GoAssembly(); err != nil {
+		t.Fatal(err.Err)
+	}
+	asm, decl, err := f.CEntry()
+	if err != nil {
+		t.Fatal(err.Err)
+	}
+	expected := "GENSIMD_CABI void src_scale(float *x, int64_t x_len, int64_t x_cap, float s);\n"
+	if decl != expected {
+		t.Errorf("C declaration %q, expected %q", decl, expected)
+	}
+	for _, s := range []string{"TEXT src_scale(SB),NOSPLIT|NOFRAME,$0-0", "·scale(SB)", "MOVSS        X0, 24(SP)"} {
+		if !strings.Contains(asm, s) {
+			t.Errorf("%q not in the C entry point:\n%v", s, asm)
+		}
+	}
+	h := CHeader("SRC_H", []string{decl})
+	if !strings.Contains(h, "#ifndef SRC_H") || !strings.Contains(h, decl) {
+		t.Errorf("C header missing the guard or declaration:\n%v", h)
+	}
+
+	f, err = CreateFunction(buildFunc(t, src, "first"), opts)
+	if err != nil {
+		t.Fatal(err.Err)
+	}
+	if _, err := f.GoAssembly(); err != nil {
+		t.Fatal(err.Err)
+	}
+	if _, _, err := f.CEntry(); err == nil || !strings.Contains(err.Err.Error(), "no C equivalent") {
+		t.Errorf("C entry point of an array parameter error %v", err)
+	}
+}
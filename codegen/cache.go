@@ -0,0 +1,116 @@
+package codegen
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"go/ast"
+	"go/types"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/tools/go/ssa"
+)
+
+// Cache stores the assembly generated for functions in a directory, keyed
+// by a hash of the function's SSA, directives, and options. GoAssembly of a
+// Function with a Cache only generates functions that changed.
+type Cache struct {
+	// Dir is the directory of the entries, created if it doesn't exist
+	Dir string
+	// Version identifies the code generator, entries of other versions
+	// aren't used, e.g. a hash of the gensimd binary
+	Version string
+}
+
+// cacheEntry is the output of GoAssembly for a cache key.
+type cacheEntry struct {
+	// Asm is the assembly with all comments, stripped by GoAssembly
+	Asm       string `json:"asm"`
+	FrameSize uint32 `json:"frameSize"`
+	ArgsSize  int    `json:"argsSize"`
+	Spills    int    `json:"spills"`
+}
+
+// CacheKey returns the hex encoded hash of everything the assembly of f
+// depends on, its SSA without source positions, the directives in its doc
+// comment, the underlying types of its parameters and results, its
+// options, and its passes.
+func (f *Function) CacheKey(version string) string {
+	h := sha256.New()
+	fmt.Fprintf(h, "gensimd %v %q\n", APIVersion, version)
+	var buf bytes.Buffer
+	ssa.WriteFunction(&buf, f.ssa)
+	for _, line := range strings.Split(buf.String(), "\n") {
+		// moving the function in its file doesn't change it
+		if !strings.HasPrefix(line, "# Location:") {
+			fmt.Fprintln(h, line)
+		}
+	}
+	if decl, ok := f.ssa.Syntax().(*ast.FuncDecl); ok && decl.Doc != nil {
+		for _, comment := range decl.Doc.List {
+			fmt.Fprintln(h, comment.Text)
+		}
+	}
+	sig := f.ssa.Signature
+	for _, tuple := range []*types.Tuple{sig.Params(), sig.Results()} {
+		for i := 0; i < tuple.Len(); i++ {
+			fmt.Fprintln(h, types.TypeString(tuple.At(i).Type().Underlying(), nil))
+		}
+	}
+	opts := f.opts
+	fmt.Fprintf(h, "%q %q %q %q %v %v %v %v %v %q %v %v %v\n", opts.OutName, opts.Arch, opts.OS, opts.Target,
+		opts.OptLevel, opts.OptFor, opts.BoundsCheck, opts.Pipeline, opts.Debug, opts.Indent, opts.CommentLevel,
+		opts.NoSplit, opts.StackMargin)
+	fmt.Fprintln(h, f.Debug, f.Optimize, f.NoAlias, f.BlockFreqs[f.ssa.Name()], f.Pinned)
+	fmt.Fprintln(h, f.Passes())
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// path returns the file of the entry of key.
+func (c *Cache) path(key string) string {
+	return filepath.Join(c.Dir, key+".json")
+}
+
+// get returns the entry of key, false if there's none or it can't be read.
+func (c *Cache) get(key string) (cacheEntry, bool) {
+	entry := cacheEntry{}
+	data, err := ioutil.ReadFile(c.path(key))
+	if err != nil || json.Unmarshal(data, &entry) != nil || entry.Asm == "" {
+		return entry, false
+	}
+	return entry, true
+}
+
+// put stores the entry of key, written to a temporary file and renamed so
+// concurrent generators never read a partial entry.
+func (c *Cache) put(key string, entry cacheEntry) *Error {
+	data, err := json.Marshal(entry)
+	if err == nil {
+		err = os.MkdirAll(c.Dir, 0755)
+	}
+	var tmp *os.File
+	if err == nil {
+		tmp, err = ioutil.TempFile(c.Dir, key+".tmp")
+	}
+	if err == nil {
+		_, err = tmp.Write(data)
+		if cerr := tmp.Close(); err == nil {
+			err = cerr
+		}
+		if err == nil {
+			err = os.Rename(tmp.Name(), c.path(key))
+		}
+		if err != nil {
+			os.Remove(tmp.Name())
+		}
+	}
+	if err != nil {
+		return ErrorMsg2(fmt.Sprintf("Couldn't write cache entry in \"%v\", %v", c.Dir, err))
+	}
+	return nil
+}
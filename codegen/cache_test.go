@@ -0,0 +1,86 @@
+package codegen
+
+import (
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"golang.org/x/tools/go/ssa"
+	"golang.org/x/tools/go/ssa/ssautil"
+)
+
+// buildFunc returns the ssa function name of the package source src.
+func buildFunc(t *testing.T, src, name string) *ssa.Function {
+	return buildFuncMode(t, src, name, ssa.SanityCheckFunctions)
+}
+
+// buildFuncMode returns the ssa function name of the package source src
+// built with mode.
+func buildFuncMode(t *testing.T, src, name string, mode ssa.BuilderMode) *ssa.Function {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "src.go", src, parser.ParseComments)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pkg := types.NewPackage("src", "src")
+	conf := &types.Config{Importer: importer.Default(), Sizes: DefaultSizes()}
+	ssapkg, _, err := ssautil.BuildPackage(conf, fset, pkg, []*ast.File{file}, mode)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return ssapkg.Func(name)
+}
+
+func TestCache(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gensimdcache")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	cache := &Cache{Dir: dir, Version: "test"}
+	const src = "package src\n\nfunc add(x, y int) int {\n\treturn x + y\n}\n"
+	gen := func(src string) (*Function, string) {
+		f, err := CreateFunction(buildFunc(t, src, "add"), DefaultOptions())
+		if err != nil {
+			t.Fatal(err.Err)
+		}
+		f.Cache = cache
+		asm, err := f.GoAssembly()
+		if err != nil {
+			t.Fatal(err.Err)
+		}
+		return f, asm
+	}
+	f, asm := gen(src)
+	key := f.CacheKey(cache.Version)
+	entries, _ := filepath.Glob(filepath.Join(dir, "*.json"))
+	if len(entries) != 1 || filepath.Base(entries[0]) != key+".json" {
+		t.Fatalf("cache entries %v, expected %v.json", entries, key)
+	}
+	// a hit returns the cached assembly
+	data, _ := ioutil.ReadFile(entries[0])
+	marked := strings.Replace(string(data), "ADDQ", "ADDQ /* cached */", 1)
+	if err := ioutil.WriteFile(entries[0], []byte(marked), 0644); err != nil {
+		t.Fatal(err)
+	}
+	f2, asm2 := gen("package src\n\n// moved\n\n" + src[len("package src\n\n"):])
+	if !strings.Contains(asm2, "/* cached */") || strings.Replace(asm2, " /* cached */", "", 1) != asm {
+		t.Errorf("moved function not read from the cache:\n%v", asm2)
+	}
+	if stats, _ := f2.Stats(); stats.FrameSize != f.frameSize {
+		t.Errorf("cached frame size %v, expected %v", stats.FrameSize, f.frameSize)
+	}
+	if changed, _ := gen(strings.Replace(src, "x + y", "x - y", 1)); changed.CacheKey(cache.Version) == key {
+		t.Errorf("changed function has the same cache key")
+	}
+	if f.CacheKey("other") == key {
+		t.Errorf("other version has the same cache key")
+	}
+}
@@ -0,0 +1,76 @@
+package codegen
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// WriteCFG writes the basic block graph of f in Graphviz DOT, each block
+// labeled with its index, SSA comment, and the number of instructions
+// generated for it. Blocks generated without instructions, the arms of
+// branchless ifs and the comparisons replaced by jump tables, are dashed.
+// GoAssembly must have succeeded.
+func (f *Function) WriteCFG(w io.Writer) *Error {
+	if f.asm == "" {
+		return ErrorMsg2("WriteCFG requires the assembly, call GoAssembly first")
+	}
+	instrs := f.blockInstrs()
+	dot := fmt.Sprintf("digraph %q {\n", f.outfname())
+	dot += "\tnode [shape=box, fontname=monospace];\n"
+	for _, block := range f.ssa.Blocks {
+		comment := block.Comment
+		if comment == "" {
+			comment = "block"
+		}
+		label := fmt.Sprintf("block%v %v\\n%v instrs", block.Index, comment, instrs[block.Index])
+		style := ""
+		if f.selectArms[block] || f.switchBlocks[block] {
+			style = ", style=dashed"
+		}
+		dot += fmt.Sprintf("\tb%v [label=\"%v\"%v];\n", block.Index, label, style)
+	}
+	for _, block := range f.ssa.Blocks {
+		for i, succ := range block.Succs {
+			label := ""
+			if len(block.Succs) == 2 {
+				label = fmt.Sprintf(" [label=\"%v\"]", i == 0)
+			}
+			dot += fmt.Sprintf("\tb%v -> b%v%v;\n", block.Index, succ.Index, label)
+		}
+	}
+	dot += "}\n"
+	if _, err := io.WriteString(w, dot); err != nil {
+		return ErrorMsg2(fmt.Sprintf("Couldn't write the CFG of \"%v\", %v", f.outfname(), err))
+	}
+	return nil
+}
+
+// blockInstrs returns the number of instructions generated for each block
+// by block index, the instructions before the first block, zeroing the
+// locals, are counted in block 0.
+func (f *Function) blockInstrs() map[int]int {
+	instrs := map[int]int{}
+	block := 0
+	for i, line := range strings.Split(f.asm, "\n") {
+		label := strings.TrimSpace(line)
+		if strings.HasPrefix(label, "block") && strings.HasSuffix(label, ":") {
+			if index, err := strconv.Atoi(label[len("block") : len(label)-1]); err == nil {
+				block = index
+				continue
+			}
+		}
+		// the fault labels and the jump table data and helper follow the
+		// blocks
+		switch {
+		case label == alignFaultLabel+":", label == boundsFaultLabel+":",
+			i > 0 && strings.HasPrefix(label, "TEXT"), strings.HasPrefix(label, "DATA"):
+			block = -1
+		}
+		if _, _, ok := asmInstr(line); ok && block >= 0 {
+			instrs[block]++
+		}
+	}
+	return instrs
+}
@@ -0,0 +1,40 @@
+package codegen
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestWriteCFG(t *testing.T) {
+	const src = "package src\n\nfunc max(x, y int) int {\n\tif x < y {\n\t\tx = y\n\t}\n\treturn x\n}\n"
+	f, err := CreateFunction(buildFunc(t, src, "max"), DefaultOptions())
+	if err != nil {
+		t.Fatal(err.Err)
+	}
+	var buf bytes.Buffer
+	if err := f.WriteCFG(&buf); err == nil {
+		t.Errorf("CFG written before GoAssembly")
+	}
+	if _, err := f.GoAssembly(); err != nil {
+		t.Fatal(err.Err)
+	}
+	if err := f.WriteCFG(&buf); err != nil {
+		t.Fatal(err.Err)
+	}
+	dot := buf.String()
+	for _, expected := range []string{
+		"digraph \"max\" {\n",
+		"\tb1 [label=\"block1 if.then\\n0 instrs\", style=dashed];\n",
+		"\tb0 -> b1 [label=\"true\"];\n",
+		"\tb0 -> b2 [label=\"false\"];\n",
+		"\tb1 -> b2;\n",
+	} {
+		if !strings.Contains(dot, expected) {
+			t.Errorf("CFG missing %q:\n%v", expected, dot)
+		}
+	}
+	if f.blockInstrs()[0] == 0 || f.blockInstrs()[2] == 0 {
+		t.Errorf("blocks without instructions, %v:\n%v", f.blockInstrs(), f.asm)
+	}
+}
@@ -1,9 +1,14 @@
 package codegen
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
+	"go/ast"
+	"go/build/constraint"
+	"go/format"
 	"go/token"
+	"io"
 	"math"
 	"reflect"
 	"strconv"
@@ -20,12 +25,43 @@ type phiInfo struct {
 }
 
 type Function struct {
-	// if Debug is set, debug comments are included in assembly output
+	// if Debug is set, debug checks are included in assembly output, set
+	// from Options.Debug
 	Debug       bool
 	PrintSpills bool
 	Trace       bool
-	Optimize    bool
-	Indent      string
+	// set if Options.OptLevel > 0
+	Optimize bool
+	// if NoAlias is set, slice and pointer parameters are assumed to not
+	// overlap, set by the //gensimd:noalias directive or the -noalias flag
+	NoAlias bool
+	// Aligned maps slice and pointer parameter names to the alignment in
+	// bytes of the memory they point to, set by //gensimd:align directives
+	Aligned map[string]uint
+	// Pinned maps variable names to the registers they're pinned to for the
+	// whole function, set by //gensimd:reg directives, see pin.go
+	Pinned map[string]string
+	// BlockFreqs are optional block execution counts for ordering the
+	// basic blocks, otherwise loop depth is used
+	BlockFreqs BlockFreqs
+	// Cache, if set, is used by GoAssembly to reuse the assembly generated
+	// for an unchanged function, it isn't used with Trace, PrintSpills, or
+	// the dumps
+	Cache *Cache
+	// DumpAfter are the names of passes to write the Assembly after, see
+	// Passes
+	DumpAfter []string
+	// DumpSSA, DumpLiveness, DumpFrames, and DumpPressure write the SSA of
+	// the function, the phi moves and block liveness of its values, its
+	// stack slots and register allocation decisions, and the most values
+	// live at once in each block with the values spilled in it
+	DumpSSA      bool
+	DumpLiveness bool
+	DumpFrames   bool
+	DumpPressure bool
+	// DumpWriter is where the dumps are written, os.Stdout if nil
+	DumpWriter  io.Writer
+	opts        Options
 	identifiers map[string]*identifier
 	jmpLabels   []string
 	outfn       string // output function name
@@ -33,8 +69,86 @@ type Function struct {
 	// map from block index to the successor block indexes that need phi vars set
 	phiInfo map[int]map[int][]phiInfo
 
+	// the block emitted after the current one, jumps to it fall through
+	nextBlock *ssa.BasicBlock
+	// set once every value has its stack slot, see slots.go
+	slotsAssigned bool
+	// the block being lowered and the values spilled by the allocator in
+	// each block, for DumpPressure
+	block       *ssa.BasicBlock
+	blockSpills map[int][]string
+
+	// set if an index is checked, so the boundsfault label is needed
+	boundsChecked bool
+
+	// the generated assembly and its frame and argument sizes, for Layout
+	asm       string
+	frameSize uint32
+	argsSize  int
+	// registers spilled by allocReg and allocTempReg, for Stats
+	spills int
+	// set by Explain to mark the assembly of each ssa instruction with its
+	// source position
+	sourceLines bool
+	// registers assigned to values and spilled, for DumpFrames
+	regDecisions []string
+
+	// loop element addresses computed by pointer increments, see induction.go
+	inductionPtrs    map[*ssa.IndexAddr]*inductionPtr
+	inductionUpdates map[int]map[int][]inductionUpdate
+	inductionIdents  []*identifier
+
+	// loads of the next loop iteration done ahead with Options.Pipeline and
+	// the loops entered on each edge, see pipeline.go
+	pipelined     map[*ssa.UnOp]*pipelinedLoad
+	pipelineInits map[int]map[int][]*pipelinedLoad
+
+	// loop accumulators kept in registers, see reduction.go
+	reductions map[*ssa.Phi]*reduction
+
+	// loop invariant instructions computed on the edges entering their
+	// loops, see hoist.go
+	hoisted map[ssa.Instruction]*ssa.BasicBlock
+	hoists  map[int]map[int][]ssa.Instruction
+
+	// ifs lowered without branches and the blocks they skip, see select.go
+	selects    map[*ssa.If]*selectInfo
+	selectArms map[*ssa.BasicBlock]bool
+
+	// ors of bytes loaded as one integer and the instructions they replace,
+	// see loadfuse.go
+	fusedLoads  map[*ssa.BinOp]*fusedLoad
+	fusedInstrs map[ssa.Instruction]bool
+
+	// the ands clearing the lowest set bit and the trailing zero counts of
+	// nonzero masks, see bitloop.go
+	clearLowest map[*ssa.BinOp]ssa.Value
+	nonzeroTZ   map[*ssa.Call]bool
+
+	// element addresses replaced by an equal dominating address, see cse.go
+	addrCSE map[ssa.Value]ssa.Value
+	// loads replaced by an earlier load or stored value, see alias.go
+	loadCSE map[ssa.Value]ssa.Value
+
+	// switches lowered to jump tables and the comparison blocks they skip,
+	// see switch.go
+	jumpTables   map[*ssa.If]*jumpTable
+	switchBlocks map[*ssa.BasicBlock]bool
+
+	// read only tables of constants the intrinsics load, e.g. the product
+	// tables of a GF(2^8) multiply, emitted after the function
+	constTables []dataSym
+
 	// maps register to false if unused and true if used
 	registers []register
+	// the registers of the values named by Pinned, see pin.go
+	pins map[string]*register
+
+	// the passes run by Func, see pass.go
+	passes []Pass
+
+	// the functions generated for Options.Variants, see variants.go
+	variants []*Function
 
 	ssa *ssa.Function
 }
@@ -52,42 +166,263 @@ func ErrorMsg2(msg string) *Error {
 	return &Error{Err: errors.New(msg), Pos: 0}
 }
 
-func CreateFunction(fn *ssa.Function, outfn string, debug bool, trace bool, optimize bool) (*Function, *Error) {
+// CreateFunction creates the code generator for fn, see Options for the
+// defaults of unset options.
+func CreateFunction(fn *ssa.Function, opts Options) (*Function, *Error) {
 	if fn == nil {
 		return nil, ErrorMsg2("Nil function passed in")
 	}
-	f := Function{ssa: fn, outfn: outfn, Debug: debug, Trace: trace, Optimize: optimize}
-	f.Indent = "        "
+	if msg := builderModeMsg(fn); msg != "" {
+		err := ErrorMsg2(msg)
+		err.Pos = fn.Pos()
+		return nil, err
+	}
+	opts, err := opts.withDefaults()
+	if err != nil {
+		return nil, err
+	}
+	f := Function{ssa: fn, outfn: opts.OutName, opts: opts, Debug: opts.Debug, Optimize: opts.OptLevel > 0}
+	f.NoAlias = hasDirective(fn, NoAliasDirective)
+	aligned, err := alignDirectives(fn)
+	if err != nil {
+		return nil, err
+	}
+	f.Aligned = aligned
+	pinned, err := regDirectives(fn)
+	if err != nil {
+		return nil, err
+	}
+	f.Pinned = pinned
 	f.init()
 	return &f, nil
 }
 
-func AssemblyFilePreamble() string {
-	preamble := "// +build amd64 !noasm !appengine\n\n"
+// NoAliasDirective in a function's doc comment asserts its slice and pointer
+// parameters don't overlap.
+const NoAliasDirective = "//gensimd:noalias"
+
+// hasDirective returns true if the doc comment of fn has the line directive.
+func hasDirective(fn *ssa.Function, directive string) bool {
+	decl, ok := fn.Syntax().(*ast.FuncDecl)
+	if !ok || decl.Doc == nil {
+		return false
+	}
+	for _, comment := range decl.Doc.List {
+		if strings.TrimSpace(comment.Text) == directive {
+			return true
+		}
+	}
+	return false
+}
+
+// AlignDirective in a function's doc comment asserts the memory pointed to by
+// slice or pointer parameters is aligned, e.g. "//gensimd:align 32 dst src".
+const AlignDirective = "//gensimd:align"
+
+// directiveArgs returns the arguments of each line in the doc comment of fn
+// starting with directive.
+func directiveArgs(fn *ssa.Function, directive string) ([][]string, token.Pos) {
+	decl, ok := fn.Syntax().(*ast.FuncDecl)
+	if !ok || decl.Doc == nil {
+		return nil, token.NoPos
+	}
+	var args [][]string
+	for _, comment := range decl.Doc.List {
+		fields := strings.Fields(comment.Text)
+		if len(fields) > 0 && fields[0] == directive {
+			args = append(args, fields[1:])
+		}
+	}
+	return args, decl.Doc.Pos()
+}
+
+// alignDirectives returns the parameter alignments given by the
+// //gensimd:align lines of fn.
+func alignDirectives(fn *ssa.Function) (map[string]uint, *Error) {
+	aligned := map[string]uint{}
+	lines, pos := directiveArgs(fn, AlignDirective)
+	for _, args := range lines {
+		if len(args) < 2 {
+			return nil, &Error{Err: fmt.Errorf("%v needs an alignment and parameter names", AlignDirective), Pos: pos}
+		}
+		n, err := strconv.ParseUint(args[0], 10, 32)
+		if err != nil || n == 0 || n&(n-1) != 0 {
+			return nil, &Error{Err: fmt.Errorf("%v alignment (%v) isn't a power of 2", AlignDirective, args[0]), Pos: pos}
+		}
+		for _, name := range args[1:] {
+			var param *ssa.Parameter
+			for _, p := range fn.Params {
+				if p.Name() == name {
+					param = p
+				}
+			}
+			if param == nil {
+				return nil, &Error{Err: fmt.Errorf("%v unknown parameter (%v)", AlignDirective, name), Pos: pos}
+			}
+			if !isSlice(param.Type()) && !isPointer(param.Type()) {
+				return nil, &Error{Err: fmt.Errorf("%v parameter (%v) isn't a slice or pointer", AlignDirective, name), Pos: param.Pos()}
+			}
+			aligned[name] = uint(n)
+		}
+	}
+	return aligned, nil
+}
+
+// ptrAlign returns the alignment in bytes of the memory pointed to by ident
+// that's known from the //gensimd:align directives, 1 if unknown.
+func (f *Function) ptrAlign(ident *identifier) uint {
+	if ident.param != nil {
+		if n, ok := f.Aligned[ident.param.Name()]; ok {
+			return n
+		}
+		return 1
+	}
+	// pointer to an element, e.g. t1 = &dst[t0]
+	if ident.ptr != nil && ident.ptr.param != nil && isSlice(ident.ptr.typ) {
+		n := f.ptrAlign(ident.ptr)
+		elemSize := sizeofElem(ident.ptr.typ)
+		for elemSize%n != 0 {
+			n /= 2
+		}
+		return n
+	}
+	return 1
+}
+
+// AlignChecks verifies the alignment of the parameters given in
+// //gensimd:align directives, jumping to alignfault if any are misaligned.
+func (f *Function) AlignChecks() string {
+	asm := ""
+	ctx := context{f, nil}
+	fp := getRegister(REG_FP)
+	for _, p := range f.ssa.Params {
+		n, ok := f.Aligned[p.Name()]
+		if !ok || n == 1 {
+			continue
+		}
+		ident := f.identifiers[p.Name()]
+		a, reg := f.allocTempReg(DATA_REG, DataRegSize)
+		asm += a
+		// the data pointer is the first word of a slice
+		asm += MovMemReg(ctx, GetIntegerOpDataType(false, sizePtr()), ident.name, ident.offset, fp, reg, false)
+		asm += fmt.Sprintf("%-9v    $%v, %v\n", TESTQ, n-1, reg.name)
+		asm += fmt.Sprintf("%-9v    %v\n", JNE, alignFaultLabel)
+		f.freeReg(reg)
+	}
+	if asm != "" {
+		asm = "// BEGIN AlignChecks\n" + asm + "// END AlignChecks\n"
+	}
+	return asm
+}
+
+const alignFaultLabel = "alignfault"
+
+// AlignFault panics on a misaligned parameter, reached from AlignChecks. It
+// loads from address 0, which the runtime turns into a recoverable nil
+// pointer dereference panic, assembly outside the runtime can't call
+// runtime.panicmem directly.
+func AlignFault() string {
+	ax := getRegister(REG_AX)
+	asm := alignFaultLabel + ":\n"
+	asm += fmt.Sprintf("%-9v    $0, %v\n", MOVQ, ax.name)
+	asm += fmt.Sprintf("%-9v    (%v), %v\n", MOVQ, ax.name, ax.name)
+	return asm
+}
+
+const boundsFaultLabel = "boundsfault"
+
+// BoundsFault traps on an out of range index, reached from BoundsCheck.
+func BoundsFault() string {
+	return boundsFaultLabel + ":\n" + fmt.Sprintf("%-9v    $3\n", INT)
+}
+
+// DefaultBuildConstraint is the build constraint used for the generated
+// assembly and Go prototype files when none is given.
+const DefaultBuildConstraint = "amd64 && !noasm && !appengine"
+
+// BuildConstraint returns the "//go:build" line and the equivalent
+// "// +build" lines for the constraint expression expr.
+func BuildConstraint(expr string) (string, error) {
+	x, err := constraint.Parse("//go:build " + expr)
+	if err != nil {
+		return "", err
+	}
+	return buildLines(x)
+}
+
+// InverseBuildConstraint returns the build lines for !(expr), the
+// constraint used for the pure Go fallback of the generated assembly.
+func InverseBuildConstraint(expr string) (string, error) {
+	x, err := constraint.Parse("//go:build " + expr)
+	if err != nil {
+		return "", err
+	}
+	return buildLines(&constraint.NotExpr{X: x})
+}
+
+func buildLines(x constraint.Expr) (string, error) {
+	lines := "//go:build " + x.String() + "\n"
+	plusBuild, err := constraint.PlusBuildLines(x)
+	if err != nil {
+		return "", err
+	}
+	for _, line := range plusBuild {
+		lines += line + "\n"
+	}
+	return lines, nil
+}
+
+// AssemblyFilePreamble returns the start of an assembly file, buildLines
+// are the build constraint lines from BuildConstraint.
+func AssemblyFilePreamble(buildLines string) string {
+	preamble := buildLines + "\n"
 	preamble += "#include \"textflag.h\"\n\n"
 	return preamble
 }
 
 func (f *Function) GoAssembly() (string, *Error) {
+	if len(f.opts.Variants) > 0 {
+		return f.variantsAssembly()
+	}
+	key := ""
+	if f.Cache != nil && !f.Trace && !f.PrintSpills && !f.dumps() {
+		key = f.CacheKey(f.Cache.Version)
+		if entry, ok := f.Cache.get(key); ok {
+			f.asm, f.frameSize, f.argsSize, f.spills = entry.Asm, entry.FrameSize, entry.ArgsSize, entry.Spills
+			return stripComments(f.asm, f.opts.Indent, f.opts.CommentLevel), nil
+		}
+	}
+	f.spills = 0
 	asm, err := f.Func()
-	if !f.Debug {
-		asm = stripDebug(asm, f.Indent)
+	if err == nil {
+		err = f.checkTarget(asm)
 	}
+	if err == nil {
+		f.asm = asm
+		if key != "" {
+			err = f.Cache.put(key, cacheEntry{asm, f.frameSize, f.argsSize, f.spills})
+		}
+	}
+	asm = stripComments(asm, f.opts.Indent, f.opts.CommentLevel)
 	return asm, err
 }
 
+// Options returns the output options of f.
+func (f *Function) Options() Options {
+	return f.opts
+}
+
 func (f *Function) Position(pos token.Pos) token.Position {
 	return f.ssa.Prog.Fset.Position(pos)
 }
 
 func (f *Function) Params() (string, *Error) {
-	// offset in bytes from frame pointer (FP)
-	offset := int(0)
+	offsets, _ := f.paramOffsets()
 	asm := ""
-	for _, p := range f.ssa.Params {
+	for i, p := range f.ssa.Params {
 		param := p
 		// TODO alloc reg based on other param types
-		if basic, ok := p.Type().(*types.Basic); ok {
+		if basic, ok := p.Type().Underlying().(*types.Basic); ok {
 			switch basic.Kind() {
 			default:
 				err := ErrorMsg2(fmt.Sprintf("Unsupported param type (%v)", basic))
@@ -103,73 +438,31 @@ func (f *Function) Params() (string, *Error) {
 				break
 			}
 
-		} else {
-
+		} else if types.IsInterface(p.Type()) {
+			err := ErrorMsg2(fmt.Sprintf("Unsupported param type (%v), interface values aren't supported", p.Type()))
+			err.Pos = p.Pos()
+			return "", err
 		}
 		ident := identifier{f: f, name: param.Name(), typ: param.Type(),
-			local: nil, param: param, offset: offset, storage: nil}
+			local: nil, param: param, offset: offsets[i], storage: nil}
 		ident.initStorage(true)
 		f.identifiers[param.Name()] = &ident
-		if ident.align() > ident.size() {
-			offset += int(ident.align())
-		} else {
-			offset += int(ident.size())
-		}
 	}
 	return asm, nil
 }
 
+// Func generates the assembly of the function by running its passes.
 func (f *Function) Func() (string, *Error) {
 	if f.Trace {
 		fmt.Printf("TRACE FUNC - %v\n", f.ssa.Name())
-		fmt.Println("TRACE PARAMS")
 	}
-	params, err := f.Params()
-	if err != nil {
-		return params, err
+	if errs := Unsupported(f.ssa); len(errs) > 0 {
+		return "", errs[0]
 	}
-	if f.Trace {
-		fmt.Println("TRACE {PARAMS}")
-		fmt.Println("TRACE ZeroValues")
-	}
-	zeroRetValue, err := f.ZeroRetValue()
-	if err != nil {
-		return params + zeroRetValue, err
-	}
-	zeroSsaLocals, err := f.ZeroSsaLocals()
-	if err != nil {
-		return params + zeroRetValue + zeroSsaLocals, err
-	}
-	if f.Trace {
-		fmt.Println("TRACE {ZeroValues}")
-		fmt.Println("TRACE ComputePhi")
-	}
-	if err := f.computePhi(); err != nil {
-		return "", err
-	}
-	if f.Trace {
-		fmt.Println("TRACE {ComputePhi}")
-		fmt.Println("TRACE BasicBlocks")
-	}
-	basicblocks, err := f.BasicBlocks()
-	if err != nil {
-		return params + zeroRetValue + zeroSsaLocals + basicblocks, err
+	if f.DumpSSA {
+		f.writeSSA(f.dumpWriter())
 	}
-	if f.Trace {
-		fmt.Println("TRACE {BasicBlocks}")
-	}
-	frameSize := f.localIdentsSize()
-	frameSize = f.align(frameSize)
-	argsSize := f.retOffset() + int(f.retSize())
-	asm := params
-	asm += f.SetStackPointer()
-	asm += zeroRetValue
-	asm += zeroSsaLocals
-	asm += basicblocks
-	asm = f.fixupRets(asm)
-	asm = addIndent(asm, f.Indent)
-	a := fmt.Sprintf("TEXT ·%v(SB),$%v-%v\n%v", f.outfname(), frameSize, argsSize, asm)
-	return a, nil
+	return f.runPasses()
 }
 
 func (f *Function) newJmpLabel() string {
@@ -192,18 +485,126 @@ func (f *Function) stackAlign() uint32 {
 }
 
 func (f *Function) GoProto() (string, string, string) {
-	pkgname := "package " + f.ssa.Package().Pkg.Name() + "\n"
-	imports := "import " + "\"github.com/bjwbell/gensimd/simd\"\n"
-	sig := strings.TrimPrefix(f.ssa.Signature.String(), "func(")
-	// TODO: HACK!
-	if !strings.Contains(sig, "github.com/bjwbell/gensimd") {
-		imports = ""
-	}
-	sig = strings.Replace(sig, "github.com/bjwbell/gensimd/", "", -1)
-	fnproto := "func " + f.outfname() + "(" + sig + "\n"
+	pkgname, imports, fnproto := f.goDecl()
+	if len(f.opts.Variants) > 0 {
+		imports = "import " + "\"github.com/bjwbell/gensimd/simd\"\n"
+		fnproto += f.variantsProto(fnproto)
+	}
+	return pkgname, imports, fnproto
+}
+
+// goDecl returns the package clause, imports, and declaration of the output
+// function.
+func (f *Function) goDecl() (string, string, string) {
+	pkg := f.ssa.Package().Pkg
+	pkgname := "package " + pkg.Name() + "\n"
+	imports := ""
+	// types from the function's package are unqualified, e.g. "type Vec [4]float32"
+	qualifier := func(p *types.Package) string {
+		if p == pkg {
+			return ""
+		}
+		if p.Path() == "github.com/bjwbell/gensimd/simd" {
+			imports = "import " + "\"github.com/bjwbell/gensimd/simd\"\n"
+		}
+		return p.Name()
+	}
+	// the result is unnamed so asmdecl names it like retName
+	sig := f.ssa.Signature
+	if sig.Results().Len() > 0 {
+		res := sig.Results().At(0)
+		results := types.NewTuple(types.NewVar(res.Pos(), res.Pkg(), "", res.Type()))
+		sig = types.NewSignatureType(nil, nil, nil, sig.Params(), results, sig.Variadic())
+	}
+	proto := strings.TrimPrefix(types.TypeString(sig, qualifier), "func(")
+	fnproto := "func " + f.outfname() + "(" + proto + "\n"
 	return pkgname, imports, fnproto
 }
 
+// GoFallback returns the package clause, imports, and a pure Go definition
+// of the output function that calls the original Go function. It's used on
+// platforms excluded by the build constraint of the generated assembly.
+func (f *Function) GoFallback() (string, string, string, *Error) {
+	if f.outfname() == f.ssa.Name() {
+		msg := "fallback for \"%v\" requires a different output function name"
+		return "", "", "", ErrorMsg2(fmt.Sprintf(msg, f.ssa.Name()))
+	}
+	return f.GoFallbackTo(f.ssa.Name())
+}
+
+// GoFallbackTo is GoFallback calling the function named callee instead of
+// the original, e.g. the original renamed so the output function can have
+// its name.
+func (f *Function) GoFallbackTo(callee string) (string, string, string, *Error) {
+	if f.outfname() == callee {
+		msg := "fallback for \"%v\" can't call itself"
+		return "", "", "", ErrorMsg2(fmt.Sprintf(msg, callee))
+	}
+	pkgname, imports, proto := f.goDecl()
+	args := []string{}
+	for _, p := range f.ssa.Params {
+		args = append(args, p.Name())
+	}
+	call := callee + "(" + strings.Join(args, ", ") + ")"
+	if f.retType() != nil {
+		call = "return " + call
+	}
+	fallback := strings.TrimSuffix(proto, "\n") + " { " + call + " }\n"
+	return pkgname, imports, fallback, nil
+}
+
+// GoGeneric returns the package clause, imports, and a copy of the original
+// Go function renamed to the output function name. Unlike GoFallback the
+// copy doesn't depend on the original, so the source file can be excluded
+// from the build and the generic file used as the pure Go implementation.
+func (f *Function) GoGeneric() (string, string, string, *Error) {
+	decl, ok := f.ssa.Syntax().(*ast.FuncDecl)
+	if !ok || decl.Body == nil {
+		msg := "no source for function \"%v\""
+		return "", "", "", ErrorMsg2(fmt.Sprintf(msg, f.ssa.Name()))
+	}
+	pkgname := "package " + f.ssa.Package().Pkg.Name() + "\n"
+
+	// rename the function and any recursive references to it, packages
+	// used only in the signature need importing too
+	renamed := []*ast.Ident{decl.Name}
+	usedPkgs := make(map[string]bool)
+	inspect := func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.Ident:
+			if n.Obj != nil && n.Obj.Decl == decl {
+				renamed = append(renamed, n)
+			}
+		case *ast.SelectorExpr:
+			if x, ok := n.X.(*ast.Ident); ok && x.Obj == nil {
+				usedPkgs[x.Name] = true
+			}
+		}
+		return true
+	}
+	ast.Inspect(decl.Type, inspect)
+	ast.Inspect(decl.Body, inspect)
+	for _, ident := range renamed {
+		ident.Name = f.outfname()
+	}
+	var buf bytes.Buffer
+	err := format.Node(&buf, f.ssa.Prog.Fset, decl)
+	for _, ident := range renamed {
+		ident.Name = f.ssa.Name()
+	}
+	if err != nil {
+		return "", "", "", &Error{Err: err, Pos: decl.Pos()}
+	}
+
+	imports := ""
+	for _, imp := range f.ssa.Package().Pkg.Imports() {
+		if usedPkgs[imp.Name()] {
+			imports += "import \"" + imp.Path() + "\"\n"
+		}
+	}
+	return pkgname, imports, buf.String() + "\n\n", nil
+}
+
 func (f *Function) outfname() string {
 	if f.outfn != "" {
 		return f.outfn
@@ -215,14 +616,9 @@ func (f *Function) ZeroSsaLocals() (string, *Error) {
 
 	asm := "// BEGIN ZeroSsaLocals\n"
 	offset := int(0)
-	locals := f.ssa.Locals
+	locals := stackAllocs(f.ssa)
 	ctx := context{f, nil}
 	for _, local := range locals {
-		if local.Heap {
-			err := ErrorMsg2(fmt.Sprintf("Can't heap alloc local, name: %v", local.Name()))
-			err.Pos = local.Pos()
-			return "", err
-		}
 		sp := getRegister(REG_SP)
 
 		//local values are always addresses, and have pointer types, so the type
@@ -231,7 +627,9 @@ func (f *Function) ZeroSsaLocals() (string, *Error) {
 		typ := local.Type().Underlying().(*types.Pointer).Elem()
 		size := sizeof(typ)
 		localOffset := -(offset + int(size))
-		asm += ZeroMemory(ctx, local.Name(), localOffset, size, sp)
+		if !zeroedAtAlloc(local) && (!f.Optimize || !writtenBeforeRead(local)) {
+			asm += ZeroMemory(ctx, local.Name(), localOffset, size, sp)
+		}
 		ident := identifier{f: f, name: local.Name(), typ: typ, local: local, param: nil, offset: localOffset}
 		ident.initStorage(false)
 		f.identifiers[local.Name()] = &ident
@@ -271,15 +669,30 @@ func (f *Function) newIdent(v ssa.Value) (identifier, *Error) {
 func (f *Function) ZeroRetValue() (string, *Error) {
 	ctx := context{f, nil}
 	asm := "// BEGIN ZeroRetValue\n"
-	asm += ZeroMemory(ctx, retName(), f.retOffset(), f.retSize(), getRegister(REG_FP))
+	if f.retType() != nil && (!f.Optimize || !f.retWrittenOnAllPaths()) {
+		asm += ZeroMemory(ctx, retName(), f.retOffset(), f.retSize(), getRegister(REG_FP))
+	}
 	asm += "// END ZeroRetValue\n"
 	return asm, nil
 }
 
 func (f *Function) BasicBlocks() (string, *Error) {
 	asm := ""
-	for i := 0; i < len(f.ssa.Blocks); i++ {
-		a, err := f.BasicBlock(f.ssa.Blocks[i])
+	order := []*ssa.BasicBlock{}
+	for _, block := range f.blockOrder() {
+		// the empty blocks skipped by selects and the comparisons replaced
+		// by jump tables aren't emitted
+		if !f.selectArms[block] && !f.switchBlocks[block] {
+			order = append(order, block)
+		}
+	}
+	f.blockSpills = map[int][]string{}
+	for i, block := range order {
+		f.nextBlock = nil
+		if i+1 < len(order) {
+			f.nextBlock = order[i+1]
+		}
+		a, err := f.BasicBlock(block)
 		asm += a
 		if err != nil {
 			return asm, err
@@ -289,7 +702,9 @@ func (f *Function) BasicBlocks() (string, *Error) {
 }
 
 func (f *Function) BasicBlock(block *ssa.BasicBlock) (string, *Error) {
+	f.block = block
 	asm := "block" + strconv.Itoa(block.Index) + ":\n"
+	asm += blockComment(block)
 	for i := 0; i < len(block.Instrs); i++ {
 		a, err := f.Instr(block.Instrs[i])
 		asm += a
@@ -306,6 +721,21 @@ func (f *Function) BasicBlock(block *ssa.BasicBlock) (string, *Error) {
 	return asm, nil
 }
 
+// blockComment returns a comment with the ssa comment and predecessors of block.
+func blockComment(block *ssa.BasicBlock) string {
+	comment := "// " + block.Comment
+	if block.Comment == "" {
+		comment = "// block"
+	}
+	if len(block.Preds) > 0 {
+		comment += ", preds"
+		for _, pred := range block.Preds {
+			comment += " block" + strconv.Itoa(pred.Index)
+		}
+	}
+	return comment + "\n"
+}
+
 func (f *Function) Instr(instr ssa.Instruction) (string, *Error) {
 
 	if instr == nil {
@@ -328,6 +758,9 @@ func (f *Function) Instr(instr ssa.Instruction) (string, *Error) {
 			}
 		}
 	}
+	if f.fusedInstrs[instr] || f.hoisted[instr] != nil {
+		return "", nil
+	}
 	switch instr := instr.(type) {
 	default:
 		err = &Error{Err: fmt.Errorf("Unknown ssa instruction (type:%v): %v\n", reflect.TypeOf(instr), instr), Pos: instr.Pos()}
@@ -340,13 +773,13 @@ func (f *Function) Instr(instr ssa.Instruction) (string, *Error) {
 	case *ssa.ChangeInterface:
 		asm, err = errormsg("converting interfaces unsupported")
 	case *ssa.ChangeType:
-		asm, err = errormsg("changing between types unsupported")
+		asm, err = f.ChangeType(instr)
 	case *ssa.Convert:
 		asm, err = f.Convert(instr)
 	case *ssa.DebugRef:
 		// Nothing to do
-	case *ssa.Defer:
-		asm, err = errormsg("defer unsupported")
+	case *ssa.Defer, *ssa.RunDefers:
+		asm, err = errormsg(deferMsg)
 	case *ssa.Extract:
 		asm, err = errormsg("extracting tuple values unsupported")
 	case *ssa.Field:
@@ -383,21 +816,27 @@ func (f *Function) Instr(instr ssa.Instruction) (string, *Error) {
 		asm, err = errormsg("range unsupported")
 	case *ssa.Return:
 		asm, err = f.Return(instr)
-	case *ssa.Select, *ssa.RunDefers, *ssa.Send:
-		asm, err = errormsg("select/send/defer unsupported")
+	case *ssa.Select, *ssa.Send:
+		asm, err = errormsg("select/send unsupported")
 	case *ssa.Slice:
 		asm, err = f.Slice(instr)
 	case *ssa.Store:
 		asm, err = f.Store(instr)
 	case *ssa.TypeAssert:
-		asm, err = errormsg("type assert unsupported")
+		asm, err = f.TypeAssert(instr)
 	case *ssa.UnOp:
 		asm, err = f.UnOp(instr)
 	}
 
+	if err == nil {
+		asm += f.setPins(instr)
+	}
 	if err != nil && !err.Pos.IsValid() {
 		err.Pos = instr.Pos()
 	}
+	if f.sourceLines && asm != "" {
+		asm = f.sourceLineMarker(instr) + asm
+	}
 	return asm, err
 }
 
@@ -476,12 +915,24 @@ func (f *Function) Call(call *ssa.Call) (string, *Error) {
 	if builtin, ok := funct.(*ssa.Builtin); ok {
 		return f.Builtin(call, builtin)
 	}
+	if lower, ok := registeredIntrinsic(call); ok {
+		return f.RegisteredIntrinsic(call, lower)
+	}
+	if op, ok := atomicCallOp(call); ok {
+		return f.AtomicIntrinsic(call, op)
+	}
 	if isSimdIntrinsic(call) {
 		return f.SimdIntrinsic(call)
 	}
 	if sse2instr, ok := isSSE2Intrinsic(call); ok {
 		return f.SSE2Intrinsic(call, sse2instr)
 	}
+	if name, ok := isMathIntrinsic(call); ok {
+		return f.MathIntrinsic(call, name)
+	}
+	if name, ok := isBitsIntrinsic(call); ok {
+		return f.BitsIntrinsic(call, name)
+	}
 	name := "UNKNOWN FUNC NAME"
 	if call.Common().Method != nil {
 		name = call.Common().Method.Name()
@@ -499,10 +950,18 @@ func (f *Function) SimdIntrinsic(call *ssa.Call) (string, *Error) {
 	var err *Error
 
 	args := call.Common().Args
-	x := f.Ident(args[0])
-	y := f.Ident(args[1])
-	result := f.Ident(call)
-	name := call.Common().StaticCallee().Name()
+	var x, y *identifier
+	if len(args) > 0 {
+		x = f.Ident(args[0])
+	}
+	if len(args) > 1 {
+		y = f.Ident(args[1])
+	}
+	var result *identifier
+	if t, ok := call.Type().(*types.Tuple); !ok || t.Len() != 0 {
+		result = f.Ident(call)
+	}
+	name, _ := simdCalleeName(call)
 	if simdinstr, ok := getSimdInstr(name); ok {
 		if result.typ != x.typ {
 			panic(ice(fmt.Sprintf("Simd variable type (%v) and op type (%v)  dont match", result.typ.String(), x.typ.String())))
@@ -525,11 +984,33 @@ func (f *Function) SimdIntrinsic(call *ssa.Call) (string, *Error) {
 	return asm, err
 }
 
-func isSimdIntrinsic(call *ssa.Call) bool {
+// simdCalleeName returns the name of the function called, methods on simd
+// types are mapped to the equivalent function, e.g. x.Add(y) with x of
+// type simd.I32x4 is mapped to simd.AddI32x4.
+func simdCalleeName(call *ssa.Call) (string, bool) {
 	if call.Common() == nil || call.Common().StaticCallee() == nil {
+		return "", false
+	}
+	callee := call.Common().StaticCallee()
+	recv := callee.Signature.Recv()
+	if recv == nil {
+		return callee.Name(), true
+	}
+	// methods declared on array types that map to SIMD types aren't intrinsics
+	if named, ok := recv.Type().(*types.Named); !ok || named.Obj().Pkg() == nil || named.Obj().Pkg().Name() != "simd" {
+		return "", false
+	}
+	if info, ok := simdInfo(recv.Type()); ok {
+		return callee.Name() + info.name, true
+	}
+	return "", false
+}
+
+func isSimdIntrinsic(call *ssa.Call) bool {
+	name, ok := simdCalleeName(call)
+	if !ok {
 		return false
 	}
-	name := call.Common().StaticCallee().Name()
 	if _, ok := getSimdInstr(name); ok {
 		return ok
 	} else {
@@ -551,8 +1032,193 @@ func (f *Function) SSE2Intrinsic(call *ssa.Call, sse2intrinsic Intrinsic) (strin
 	return sse2Intrinsic(f, call, call, sse2intrinsic, args), nil
 }
 
+// TypeAssert refuses type assertions, interface values can't be passed to or
+// created in generated functions so there's no type word to check.
+func (f *Function) TypeAssert(instr *ssa.TypeAssert) (string, *Error) {
+	return "", &Error{Err: errors.New(typeAssertMsg(instr)), Pos: instr.Pos()}
+}
+
+func typeAssertMsg(instr *ssa.TypeAssert) string {
+	x := instr.X.Name()
+	if p, ok := instr.X.(*ssa.Parameter); ok {
+		x = p.Object().Name()
+	}
+	assert := fmt.Sprintf("%v.(%v)", x, instr.AssertedType)
+	if instr.CommaOk {
+		assert = "_, ok := " + assert
+	}
+	msg := "type assertion \"%v\" unsupported, interface values of type %v aren't supported"
+	return fmt.Sprintf(msg, assert, instr.X.Type())
+}
+
+// Slice slices a scratch array in the frame, or a slice of one, the data
+// pointer is the address of element low and the length and capacity are
+// counted from it. The indexes are checked 0 <= low <= high <= cap unsigned,
+// so negative indexes are out of range, unless they're constants of an
+// array the type checker checked.
 func (f *Function) Slice(instr *ssa.Slice) (string, *Error) {
-	return ErrorMsg("slice creation unsupported")
+	if !slicesStack(instr) {
+		return ErrorMsg("slice creation unsupported")
+	}
+	if instr.Max != nil {
+		return ErrorMsg("full slice expressions unsupported")
+	}
+	ctx := context{f, instr}
+	asm := ""
+	xInfo := f.identifiers[instr.X.Name()]
+	assignment := f.Ident(instr)
+	if a, e := f.spillAllIdent(xInfo, instr); e != nil {
+		return a, e
+	} else {
+		asm += a
+	}
+	optypes := GetIntegerOpDataType(false, sizePtr())
+	xType := xInfo.typ.Underlying()
+	elemSize := sizeofElem(xType)
+
+	var base, length, capacity *register
+	if array, ok := xType.(*types.Array); ok {
+		xReg, xOffset, _ := xInfo.Addr()
+		a, reg := f.allocReg(instr, DATA_REG, DataRegSize)
+		asm += a
+		base = reg
+		asm += Lea(ctx, xInfo.name, xOffset, &xReg, base, false)
+		a, length = f.allocReg(instr, DATA_REG, DataRegSize)
+		asm += a
+		asm += MovImmReg(ctx, array.Len(), sizePtr(), length, false)
+		a, capacity = f.allocReg(instr, DATA_REG, DataRegSize)
+		asm += a
+		asm += MovImmReg(ctx, array.Len(), sizePtr(), capacity, false)
+	} else {
+		for _, word := range []struct {
+			reg    **register
+			offset uint
+		}{{&base, 0}, {&length, sliceLenOffset()}, {&capacity, 2 * sizePtr()}} {
+			a, reg, err := f.LoadValue(instr, instr.X, word.offset, sizePtr())
+			if err != nil {
+				return "", err
+			}
+			asm += a
+			*word.reg = reg
+		}
+	}
+
+	var low *register
+	if instr.Low != nil {
+		a, reg, err := f.LoadIndex(instr, instr.Low)
+		if err != nil {
+			return "", err
+		}
+		asm += a
+		low = reg
+	}
+	high := length
+	if instr.High == instr.Low && instr.High != nil {
+		// the length is computed in a copy of the index low is also in
+		a, reg := f.allocReg(instr, DATA_REG, DataRegSize)
+		asm += a
+		asm += MovRegReg(ctx, optypes, low, reg, false)
+		high = reg
+	} else if instr.High != nil {
+		a, reg, err := f.LoadIndex(instr, instr.High)
+		if err != nil {
+			return "", err
+		}
+		asm += a
+		high = reg
+	}
+
+	_, lowCnst := instr.Low.(*ssa.Const)
+	_, highCnst := instr.High.(*ssa.Const)
+	checked := isArray(xType) && (instr.Low == nil || lowCnst) && (instr.High == nil || highCnst)
+	if f.opts.BoundsCheck && !checked {
+		asm += fmt.Sprintf("// BEGIN SliceCheck %v\n", instr)
+		if high != length {
+			asm += CmpRegReg(ctx, optypes, capacity, high)
+			asm += fmt.Sprintf("%-9v    %v\n", JCS, boundsFaultLabel)
+		}
+		if low != nil {
+			asm += CmpRegReg(ctx, optypes, high, low)
+			asm += fmt.Sprintf("%-9v    %v\n", JCS, boundsFaultLabel)
+		}
+		f.boundsChecked = true
+		asm += fmt.Sprintf("// END SliceCheck %v\n", instr)
+	}
+
+	if low != nil {
+		asm += SubRegReg(ctx, optypes, low, high, true)
+		asm += SubRegReg(ctx, optypes, low, capacity, true)
+		if isLeaScale(elemSize) {
+			asm += LeaScaled(ctx, base, low, elemSize, base, true)
+		} else {
+			asm += f.MulIndex(instr, low, elemSize)
+			asm += AddRegReg(ctx, optypes, low, base, true)
+		}
+	}
+	for _, word := range []struct {
+		reg    *register
+		offset uint
+	}{{base, 0}, {high, sliceLenOffset()}, {capacity, 2 * sizePtr()}} {
+		a, err := f.AssignRegIdent(instr, word.reg, assignment, word.offset, sizePtr())
+		if err != nil {
+			return "", err
+		}
+		asm += a
+	}
+
+	f.freeRegs([]*register{base, length, capacity})
+	if high != length {
+		f.freeReg(high)
+	}
+	if low != nil {
+		f.freeReg(low)
+	}
+	asm = fmt.Sprintf("// BEGIN ssa.Slice: %v = %v\n", instr.Name(), instr) + asm
+	asm += fmt.Sprintf("// END ssa.Slice: %v = %v\n", instr.Name(), instr)
+	return asm, nil
+}
+
+// ChangeType converts between types with identical underlying types, e.g.
+// from "type Vec [4]float32" to simd.F32x4 or from "type Sample int16" to
+// int16. The value is copied unchanged.
+func (f *Function) ChangeType(instr *ssa.ChangeType) (string, *Error) {
+	from := instr.X.Type().Underlying()
+	if isSimd(instr.X.Type()) && isSimd(instr.Type()) {
+		return f.changeTypeSimd(instr)
+	}
+	if !isBasic(from) && !isPointer(from) {
+		msg := "changing type from %v to %v unsupported, only basic, pointer, and SIMD types are"
+		return ErrorMsg(fmt.Sprintf(msg, instr.X.Type(), instr.Type()))
+	}
+	asm, reg, err := f.LoadValueSimple(instr, instr.X)
+	if err != nil {
+		return "", err
+	}
+	a, err := f.StoreValue(instr, f.Ident(instr), reg)
+	if err != nil {
+		return "", err
+	}
+	asm += a
+	f.freeReg(reg)
+	asm = fmt.Sprintf("// BEGIN ssa.ChangeType, %v = %v\n", instr.Name(), instr) + asm
+	asm += fmt.Sprintf("// END ssa.ChangeType, %v = %v\n", instr.Name(), instr)
+	return asm, nil
+}
+
+func (f *Function) changeTypeSimd(instr *ssa.ChangeType) (string, *Error) {
+	asm, reg, err := f.LoadSimdValue(instr, instr.X)
+	if err != nil {
+		return "", err
+	}
+	a, err := f.StoreSimd(instr, reg, f.Ident(instr))
+	if err != nil {
+		return "", err
+	}
+	asm += a
+	f.freeReg(reg)
+	asm = fmt.Sprintf("// BEGIN ssa.ChangeType, %v = %v\n", instr.Name(), instr) + asm
+	asm += fmt.Sprintf("// END ssa.ChangeType, %v = %v\n", instr.Name(), instr)
+	return asm, nil
 }
 
 func (f *Function) Convert(instr *ssa.Convert) (string, *Error) {
@@ -633,6 +1299,11 @@ func (f *Function) ConvertFromTo(instr *ssa.Convert, fromOpType, toOpType InstrO
 		}
 
 		asm += f.ConvertUint64ToFloat(instr, tmp, from, to, floatSize)
+	} else if isUint(instr.Type()) && f.sizeof(instr) == 8 && isFloat(instr.X.Type()) {
+		a, xtmp := f.allocReg(instr, XMM_REG, XmmRegSize)
+		asm += a
+		asm += FloatToUint64(ctx, from, to, fromType, tmp, xtmp)
+		f.freeReg(xtmp)
 	} else {
 		asm += ConvertOp(ctx, from, fromType, to, toType, tmp)
 	}
@@ -734,44 +1405,157 @@ func (f *Function) If(instr *ssa.If) (string, *Error) {
 	if tblock == -1 || fblock == -1 {
 		ice("malformed CFG with if stmt")
 	}
-
-	cond, ok := f.identifiers[instr.Cond.Name()]
-	if !ok {
-
-		return ErrorMsg(fmt.Sprintf("If: unhandled case, cond (%v)", instr.Cond))
+	if sel, ok := f.selects[instr]; ok {
+		return f.Select(instr, sel)
 	}
-	a, reg, err := f.LoadIdentSimple(instr, cond)
-	if err != nil {
-		return "", err
+	if jt, ok := f.jumpTables[instr]; ok {
+		return f.JumpTable(instr, jt)
 	}
-	asm += a
 
-	a, err = f.JumpPreamble(instr, instr.Block().Index, fblock)
-	if err != nil {
-		return "", err
+	negate, jblock, nblock := f.ifBranch(instr)
+	if cmp, ok := f.fusedCmp(instr); ok {
+		// the flags are still set from the comparison in BinOp
+		a, err := f.JumpPreamble(instr, instr.Block().Index, jblock)
+		if err != nil {
+			return "", err
+		}
+		asm += a
+		asm += CmpJmp(ctx, GetOpDataType(cmp.X.Type()), cmp.Op, negate, "block"+strconv.Itoa(jblock))
+	} else {
+		cond, ok := f.identifiers[instr.Cond.Name()]
+		if !ok {
+
+			return ErrorMsg(fmt.Sprintf("If: unhandled case, cond (%v)", instr.Cond))
+		}
+		a, reg, err := f.LoadIdentSimple(instr, cond)
+		if err != nil {
+			return "", err
+		}
+		asm += a
+
+		a, err = f.JumpPreamble(instr, instr.Block().Index, jblock)
+		if err != nil {
+			return "", err
+		}
+		asm += a
+		asm += CmpRegImm32(ctx, reg, uint32(0), cond.size())
+		f.freeReg(reg)
+		jcc := JNE
+		if negate {
+			jcc = JEQ
+		}
+		asm += fmt.Sprintf("%-9v    ", jcc) + "block" + strconv.Itoa(jblock) + "\n"
 	}
-	asm += a
-	asm += CmpRegImm32(ctx, reg, uint32(0), cond.size())
-	f.freeReg(reg)
 
-	asm += fmt.Sprintf("%-9v    ", JEQ) + "block" + strconv.Itoa(fblock) + "\n"
-	a, err = f.JumpPreamble(instr, instr.Block().Index, tblock)
+	a, err := f.JumpPreamble(instr, instr.Block().Index, nblock)
 	if err != nil {
 		return "", err
 	}
 	asm += a
-	jmp := "JMP"
-	asm += fmt.Sprintf("%-9v    ", jmp) + "block" + strconv.Itoa(tblock) + "\n"
+	if !f.isNextBlock(nblock) {
+		jmp := "JMP"
+		asm += fmt.Sprintf("%-9v    ", jmp) + "block" + strconv.Itoa(nblock) + "\n"
+	}
 	asm = fmt.Sprintf("// BEGIN ssa.If, %v\n", instr) + asm
 	asm += fmt.Sprintf("// END ssa.If, %v\n", instr)
 
 	return asm, nil
 }
 
+// ifBranch returns the block the conditional jump of instr goes to and the
+// block reached otherwise. The jump is to the false block if negate is set,
+// or to the true block when the false block is next so it falls through.
+func (f *Function) ifBranch(instr *ssa.If) (negate bool, jblock, nblock int) {
+	tblock := instr.Block().Succs[0].Index
+	fblock := instr.Block().Succs[1].Index
+	if f.isNextBlock(fblock) {
+		return false, tblock, fblock
+	}
+	return true, fblock, tblock
+}
+
+// fusedCmp returns the comparison computing the condition of instr if the
+// branch can use the comparison flags directly, instead of the bool result.
+// There mustn't be phi values or induction pointers to set before the
+// conditional jump since they could change the flags.
+func (f *Function) fusedCmp(instr *ssa.If) (*ssa.BinOp, bool) {
+	cmp, ok := f.cmpBeforeIf(instr)
+	if !ok {
+		return nil, false
+	}
+	_, jblock, _ := f.ifBranch(instr)
+	if len(f.phiInfo[instr.Block().Index][jblock]) != 0 || len(f.inductionUpdates[instr.Block().Index][jblock]) != 0 {
+		return nil, false
+	}
+	return cmp, true
+}
+
+// cmpBeforeIf returns the comparison of basic values computing the condition
+// of instr if it's the if's only use and comes right before it, so it can
+// set the flags for the if.
+func (f *Function) cmpBeforeIf(instr *ssa.If) (*ssa.BinOp, bool) {
+	cmp, ok := instr.Cond.(*ssa.BinOp)
+	if !ok || cmp.Block() != instr.Block() {
+		return nil, false
+	}
+	switch cmp.Op {
+	default:
+		return nil, false
+	case token.EQL, token.NEQ, token.LEQ, token.GEQ, token.LSS, token.GTR:
+	}
+	if _, ok := cmp.X.Type().Underlying().(*types.Basic); !ok || isComplex(cmp.X.Type()) {
+		return nil, false
+	}
+	if refs := cmp.Referrers(); refs != nil {
+		for _, ref := range *refs {
+			if _, ok := ref.(*ssa.DebugRef); !ok && ref != instr {
+				return nil, false
+			}
+		}
+	}
+	instrs := instr.Block().Instrs
+	i := len(instrs) - 2
+	for i >= 0 {
+		if _, ok := instrs[i].(*ssa.DebugRef); !ok {
+			break
+		}
+		i--
+	}
+	if i < 0 || instrs[i] != cmp {
+		return nil, false
+	}
+	return cmp, true
+}
+
+// isFusedCmp returns true if the comparison instr is only used by the if
+// ending its block and the if branches on its flags.
+func (f *Function) isFusedCmp(instr *ssa.BinOp) bool {
+	instrs := instr.Block().Instrs
+	ifInstr, ok := instrs[len(instrs)-1].(*ssa.If)
+	if !ok {
+		return false
+	}
+	cmp, ok := f.fusedCmp(ifInstr)
+	return ok && cmp == instr
+}
+
+// isNextBlock returns true if the block with index is emitted after the
+// current block, so jumping to it is unnecessary.
+func (f *Function) isNextBlock(index int) bool {
+	return f.nextBlock != nil && f.nextBlock.Index == index
+}
+
 func (f *Function) JumpPreamble(loc ssa.Instruction, blockIndex, jmpIndex int) (string, *Error) {
-	asm := ""
+	asm, err := f.HoistedInstrs(blockIndex, jmpIndex)
+	if err != nil {
+		return "", err
+	}
 	phiInfos := f.phiInfo[blockIndex][jmpIndex]
 	for _, phiInfo := range phiInfos {
+		// the register of an accumulator already holds its update
+		if f.reductionBackEdge(phiInfo.phi, blockIndex) {
+			continue
+		}
 		ident := f.Ident(phiInfo.phi)
 		ident.spilling = true
 		if a, err := f.StoreValAddr(loc, phiInfo.value, ident); err != nil {
@@ -786,6 +1570,17 @@ func (f *Function) JumpPreamble(loc ssa.Instruction, blockIndex, jmpIndex int) (
 		}
 		ident.spilling = false
 	}
+	asm += f.ReductionInits(loc, blockIndex, jmpIndex)
+	if a, err := f.InductionUpdates(loc, blockIndex, jmpIndex); err != nil {
+		return "", err
+	} else {
+		asm += a
+	}
+	if a, err := f.PipelineInits(loc, blockIndex, jmpIndex); err != nil {
+		return "", err
+	} else {
+		asm += a
+	}
 
 	if a, e := f.spillRegisters(context{f, loc}); e != nil {
 		return a, e
@@ -812,7 +1607,9 @@ func (f *Function) Jump(jmp *ssa.Jump) (string, *Error) {
 		return "", err
 	}
 	asm += a
-	asm += "JMP block" + strconv.Itoa(block) + "\n"
+	if !f.isNextBlock(block) {
+		asm += "JMP block" + strconv.Itoa(block) + "\n"
+	}
 	asm = "// BEGIN ssa.Jump\n" + asm
 	asm += "// END ssa.Jump\n"
 	return asm, nil
@@ -955,6 +1752,10 @@ func (f *Function) StoreValAddr(loc ssa.Instruction, val ssa.Value, addr *identi
 		ice(fmt.Sprintf("invalid addr \"%v\"", addr))
 	}
 
+	if addr.isPointer() && !addr.isSsaLocal() {
+		return f.StoreValPtr(loc, val, addr)
+	}
+
 	asm := ""
 	asm += fmt.Sprintf("// BEGIN StoreValAddr addr name:%v, val name:%v\n", addr.name, val.Name()) + asm
 
@@ -987,27 +1788,99 @@ func (f *Function) StoreValAddr(loc ssa.Instruction, val ssa.Value, addr *identi
 			iterations = size / sizeBasic(types.Int16)
 			datasize = 2
 		}
-		if size > sizeInt() {
-			if size%sizeInt() != 0 {
-				ice(fmt.Sprintf("Size (%v) not multiple of sizeInt (%v)", size, sizeInt()))
-			}
+		// asmdecl names the elements of arrays in the argument frame
+		for _, ident := range []*identifier{addr, f.Ident(val)} {
+			if ident.isParam() || ident.isRetIdent() {
+				if e := argCopySize(ident.typ, uint(datasize)); e < uint(datasize) {
+					datasize, iterations = int(e), size/e
+				}
+			}
+		}
+		if size > sizeInt() {
+			if size%sizeInt() != 0 {
+				ice(fmt.Sprintf("Size (%v) not multiple of sizeInt (%v)", size, sizeInt()))
+			}
+		}
+		for i := 0; i < int(iterations); i++ {
+			offset := uint(i * datasize)
+			a, valReg, err := f.LoadValue(loc, val, offset, uint(datasize))
+			if err != nil {
+				return a, err
+			}
+			asm += a
+			a, err = f.AssignRegIdent(loc, valReg, addr, offset, uint(datasize))
+			if err != nil {
+				return a, err
+			}
+			asm += a
+			f.freeReg(valReg)
+		}
+	}
+	asm += fmt.Sprintf("// END StoreValAddr addr name:%v, val name:%v\n", addr.name, val.Name())
+	return asm, nil
+}
+
+// StoreValPtr stores val to the memory pointed to by ptr, e.g. "*t1 = a"
+// where t1 = &t0[0] for the composite literal t0 = simd.I32x4{a, b, c, d}.
+func (f *Function) StoreValPtr(loc ssa.Instruction, val ssa.Value, ptr *identifier) (string, *Error) {
+	ctx := context{f, loc}
+	asm := fmt.Sprintf("// BEGIN StoreValPtr ptr name:%v, val name:%v\n", ptr.name, val.Name())
+	if isComplex(val.Type()) {
+		return ErrorMsg("complex32/64 unsupported")
+	}
+	// registers caching the pointed to memory are stale after the store
+	if ptr.ptr != nil {
+		asm += ptr.ptr.spillAllRegisters(loc)
+	}
+	valIdent := f.Ident(val)
+	_, inMemory := valIdent.storage.(*memory)
+	var repRegs []*register
+	if inMemory && f.repCopy(val.Type()) {
+		a, regs := f.allocRepRegs(loc)
+		asm += a
+		repRegs = regs
+	}
+	a, ptrReg := ptr.load(ctx)
+	asm += a
+	if repRegs != nil {
+		// the registers caching val are stored before copying its memory
+		asm += valIdent.spillDirtyRegisters(loc)
+		valReg, valOffset, size := valIdent.Addr()
+		asm += MovMemMemRep(ctx, valIdent.name, valOffset, &valReg, "", 0, ptrReg, size)
+	} else if isXmm(val.Type()) {
+		a, valReg, err := f.LoadValueSimple(loc, val)
+		if err != nil {
+			return a, err
+		}
+		asm += a
+		if f.sizeof(val) == XmmRegSize && f.ptrAlign(ptr) >= XmmRegSize {
+			asm += MovRegMemAligned(ctx, GetOpDataType(val.Type()), valReg, "", ptrReg, 0)
+		} else {
+			asm += MovRegMem(ctx, GetOpDataType(val.Type()), valReg, "", ptrReg, 0)
 		}
-		for i := 0; i < int(iterations); i++ {
-			offset := uint(i * datasize)
-			a, valReg, err := f.LoadValue(loc, val, offset, uint(datasize))
-			if err != nil {
-				return a, err
-			}
-			asm += a
-			a, err = f.AssignRegIdent(loc, valReg, addr, offset, uint(datasize))
+		f.freeReg(valReg)
+	} else {
+		size := f.sizeof(val)
+		datasize := size
+		if datasize > DataRegSize {
+			datasize = DataRegSize
+		}
+		if size%datasize != 0 {
+			ice(fmt.Sprintf("Size (%v) not multiple of %v", size, datasize))
+		}
+		for offset := uint(0); offset < size; offset += datasize {
+			a, valReg, err := f.LoadValue(loc, val, offset, datasize)
 			if err != nil {
 				return a, err
 			}
 			asm += a
+			asm += MovRegMem(ctx, GetIntegerOpDataType(false, datasize), valReg, "", ptrReg, int(offset))
 			f.freeReg(valReg)
 		}
 	}
-	asm += fmt.Sprintf("// END StoreValAddr addr name:%v, val name:%v\n", addr.name, val.Name())
+	f.freeReg(ptrReg)
+	f.freeRegs(repRegs)
+	asm += fmt.Sprintf("// END StoreValPtr ptr name:%v, val name:%v\n", ptr.name, val.Name())
 	return asm, nil
 }
 
@@ -1027,29 +1900,50 @@ func (f *Function) Store(instr *ssa.Store) (string, *Error) {
 
 func (f *Function) BinOp(instr *ssa.BinOp) (string, *Error) {
 	ctx := context{f, instr}
+	if f.isSelectCmp(instr) {
+		return fmt.Sprintf("// ssa.BinOp, %v = %v, compared by the select\n", instr.Name(), instr), nil
+	}
+	if f.isSwitchCmp(instr) {
+		return fmt.Sprintf("// ssa.BinOp, %v = %v, compared by the jump table\n", instr.Name(), instr), nil
+	}
+	if load, ok := f.fusedLoads[instr]; ok {
+		return f.FusedLoad(instr, load)
+	}
+	if x, ok := f.clearLowest[instr]; ok {
+		return f.ClearLowest(instr, x)
+	}
 	ident := f.Ident(instr)
 	if ident == nil {
 		return ErrorMsg(fmt.Sprintf("Cannot alloc value: %v", instr))
 	}
 
+	if f.isFusedCmp(instr) {
+		return f.BinOpCmpFlags(instr)
+	}
+	if x, imm, ok := f.immOperand(instr); ok {
+		return f.BinOpImm(instr, x, imm)
+	}
+
 	var regX, regY, regVal *register
 	size := f.sizeof(instr)
 	xIsSigned := signed(instr.X.Type())
 
+	asm, regX, regY, err := f.BinOpLoadXY(instr)
+	if err != nil {
+		return asm, err
+	}
+
 	var a string
-	// comparison op results are size 1 byte, but that's not supported
-	if size == 1 {
+	if f.reuseX(instr, instr.X, regX, regY) {
+		// x dies, compute in place with the two address form
+		regVal = regX
+	} else if size == 1 {
+		// comparison op results are size 1 byte, but that's not supported
 		a, regVal = f.allocIdentReg(instr, ident, 8*size)
 	} else {
 		a, regVal = f.allocIdentReg(instr, ident, size)
 	}
-	asm := a
-	asmload, regX, regY, err := f.BinOpLoadXY(instr)
-	asm += asmload
-
-	if err != nil {
-		return asm, err
-	}
+	asm += a
 
 	size = f.sizeof(instr.X)
 
@@ -1060,7 +1954,15 @@ func (f *Function) BinOp(instr *ssa.BinOp) (string, *Error) {
 		optypes := GetOpDataType(instr.Type())
 		asm += ArithOp(ctx, optypes, instr.Op, regX, regY, regVal)
 	case token.AND, token.OR, token.XOR, token.SHL, token.SHR, token.AND_NOT:
-		asm += BitwiseOp(ctx, instr.Op, xIsSigned, regX, regY, regVal, size)
+		var tmp *register
+		if instr.Op == token.SHL || instr.Op == token.SHR {
+			a, tmp = f.allocTempReg(DATA_REG, DataRegSize)
+			asm += a
+		}
+		asm += BitwiseOp(ctx, instr.Op, xIsSigned, regX, regY, regVal, tmp, size, f.sizeof(instr.Y))
+		if tmp != nil {
+			f.freeReg(tmp)
+		}
 	case token.EQL, token.NEQ, token.LEQ, token.GEQ, token.LSS, token.GTR:
 		if size != f.sizeof(instr.Y) {
 			ice("comparing two different size values")
@@ -1068,7 +1970,9 @@ func (f *Function) BinOp(instr *ssa.BinOp) (string, *Error) {
 		optypes := GetOpDataType(instr.X.Type())
 		asm += CmpOp(ctx, optypes, instr.Op, regX, regY, regVal)
 	}
-	f.freeReg(regX)
+	if regX != regVal {
+		f.freeReg(regX)
+	}
 	f.freeReg(regY)
 
 	addr, ok := f.identifiers[instr.Name()]
@@ -1089,13 +1993,163 @@ func (f *Function) BinOp(instr *ssa.BinOp) (string, *Error) {
 	return asm, nil
 }
 
+// reuseX reports whether the BinOp's result is computed in the register of
+// its operand x, which is taken from x, so the two address instructions need
+// no move of x into the result. x's register is reused if x dies at instr and
+// it isn't y's register, the shift count, or the x of AND_NOT which is
+// overwritten first. y is nil for the immediate forms.
+func (f *Function) reuseX(instr *ssa.BinOp, x ssa.Value, regX, regY *register) bool {
+	switch instr.Op {
+	case token.ADD, token.SUB, token.MUL, token.QUO, token.REM,
+		token.AND, token.OR, token.XOR, token.SHL, token.SHR:
+	default:
+		return false
+	}
+	if !f.Optimize || regX == regY || instr.X == instr.Y || regX.parent == nil {
+		return false
+	}
+	if _, ok := x.(*ssa.Const); ok {
+		return false
+	}
+	if f.sizeof(instr) != f.sizeof(x) {
+		return false
+	}
+	if !unassignRegister(regX, instr) {
+		return false
+	}
+	regX.inUse = true
+	return true
+}
+
+// immOperand returns the non constant operand x and the immediate of a BinOp
+// with an immediate form, an integer op with a constant y, or a commutative
+// op with a constant x. The immediate fits in the sign extended 32 bits of the
+// instruction, or is a shift count.
+func (f *Function) immOperand(instr *ssa.BinOp) (x ssa.Value, imm int64, ok bool) {
+	x, y := instr.X, instr.Y
+	cnst, ok := y.(*ssa.Const)
+	if !ok {
+		switch instr.Op {
+		case token.ADD, token.AND, token.OR, token.XOR:
+			x, y = y, x
+			cnst, ok = y.(*ssa.Const)
+		}
+	}
+	if !ok || cnst.Value == nil || !isInteger(x.Type()) || !isInteger(cnst.Type()) {
+		return nil, 0, false
+	}
+	if _, ok := x.(*ssa.Const); ok {
+		return nil, 0, false
+	}
+	switch instr.Op {
+	case token.SHL, token.SHR:
+		// shift counts are never negative
+		count := cnst.Uint64()
+		if count > math.MaxUint8 {
+			count = math.MaxUint8
+		}
+		return x, int64(count), true
+	case token.ADD, token.SUB, token.AND, token.OR, token.XOR,
+		token.EQL, token.NEQ, token.LEQ, token.GEQ, token.LSS, token.GTR:
+	default:
+		return nil, 0, false
+	}
+	if signed(cnst.Type()) {
+		imm = cnst.Int64()
+	} else {
+		imm = int64(cnst.Uint64())
+	}
+	if f.sizeof(x) == 8 && imm != int64(int32(imm)) {
+		// not representable in the sign extended 32 bit immediate
+		return nil, 0, false
+	}
+	return x, imm, true
+}
+
+// BinOpImm computes the BinOp with the non constant operand x and the
+// immediate of the other operand, see immOperand.
+func (f *Function) BinOpImm(instr *ssa.BinOp, x ssa.Value, imm int64) (string, *Error) {
+	ctx := context{f, instr}
+	ident := f.Ident(instr)
+	asm, regX, err := f.LoadValue(instr, x, 0, f.sizeof(x))
+	if err != nil {
+		return asm, err
+	}
+	regX.inUse = true
+	optypes := GetOpDataType(x.Type())
+
+	var a string
+	var regVal *register
+	switch instr.Op {
+	case token.EQL, token.NEQ, token.LEQ, token.GEQ, token.LSS, token.GTR:
+		// comparison op results are size 1 byte, but that's not supported
+		a, regVal = f.allocIdentReg(instr, ident, 8)
+		asm += a
+		asm += CmpRegImm(ctx, optypes, regX, imm)
+		asm += instrReg(ctx, cmpSetInstr(optypes, instr.Op), regVal, false)
+	default:
+		if f.reuseX(instr, x, regX, nil) {
+			regVal = regX
+		} else {
+			a, regVal = f.allocIdentReg(instr, ident, f.sizeof(instr))
+			asm += a
+		}
+		asm += ImmOp(ctx, optypes, instr.Op, regX, imm, regVal)
+	}
+	if regX != regVal {
+		f.freeReg(regX)
+	}
+
+	a, err = f.StoreValue(instr, ident, regVal)
+	asm += a
+	if err != nil {
+		return asm, err
+	}
+	f.freeReg(regVal)
+
+	asm = fmt.Sprintf("// BEGIN ssa.BinOp, %v = %v\n", instr.Name(), instr) + asm
+	asm += fmt.Sprintf("// END ssa.BinOp, %v = %v\n", instr.Name(), instr)
+	return asm, nil
+}
+
+// BinOpCmpFlags compares X to Y only setting the flags, the bool result
+// isn't stored since the following if branches on the flags.
+func (f *Function) BinOpCmpFlags(instr *ssa.BinOp) (string, *Error) {
+	ctx := context{f, instr}
+	if f.sizeof(instr.X) != f.sizeof(instr.Y) {
+		ice("comparing two different size values")
+	}
+	if x, imm, ok := f.immOperand(instr); ok && x == instr.X {
+		asm, regX, err := f.LoadValue(instr, x, 0, f.sizeof(x))
+		if err != nil {
+			return asm, err
+		}
+		asm += CmpRegImm(ctx, GetOpDataType(x.Type()), regX, imm)
+		f.freeReg(regX)
+		asm = fmt.Sprintf("// BEGIN ssa.BinOp, %v = %v\n", instr.Name(), instr) + asm
+		asm += fmt.Sprintf("// END ssa.BinOp, %v = %v\n", instr.Name(), instr)
+		return asm, nil
+	}
+	asm, regX, regY, err := f.BinOpLoadXY(instr)
+	if err != nil {
+		return asm, err
+	}
+	if isFloat(instr.X.Type()) {
+		asm += FloatCmpRegReg(ctx, GetOpDataType(instr.X.Type()), instr.Op, regX, regY)
+	} else {
+		asm += CmpRegReg(ctx, GetOpDataType(instr.X.Type()), regX, regY)
+	}
+	f.freeReg(regX)
+	f.freeReg(regY)
+	asm = fmt.Sprintf("// BEGIN ssa.BinOp, %v = %v\n", instr.Name(), instr) + asm
+	asm += fmt.Sprintf("// END ssa.BinOp, %v = %v\n", instr.Name(), instr)
+	return asm, nil
+}
+
 func (f *Function) BinOpLoadXY(instr *ssa.BinOp) (asm string, x *register, y *register, err *Error) {
 	if isPointer(instr.Type()) {
 		panic("ptr")
 	}
-	if ident := f.Ident(instr); ident == nil {
-		return "", nil, nil, ErrorMsg2(fmt.Sprintf("Cannot alloc value: %v", instr))
-	}
 	if ident := f.Ident(instr.X); ident == nil {
 		return "", nil, nil, ErrorMsg2(fmt.Sprintf("Cannot alloc value: %v", instr.X))
 	}
@@ -1185,6 +2239,15 @@ func (f *Function) LoadSimd(loc ssa.Instruction, ident *identifier) (string, *re
 	if reg.typ != XMM_REG {
 		a, xmmReg := f.allocReg(loc, XMM_REG, 16)
 		asm += a
+		// shift counts are the whole quadword, the bytes above a smaller
+		// integer are garbage. reg may be ident's own register.
+		if size := sizeof(ident.typ); size < 8 {
+			a, tmp := f.allocReg(loc, DATA_REG, DataRegSize)
+			asm += a
+			asm += MovZeroExtend(context{f, loc}, reg, tmp, size, 8, false)
+			f.freeReg(reg)
+			reg = tmp
+		}
 		asm += MovRegReg(context{f, loc}, OpDataType{OP_XMM, InstrData{}, XMM_F128}, reg, xmmReg, false)
 		f.freeReg(reg)
 		reg = xmmReg
@@ -1274,7 +2337,14 @@ func (f *Function) UnOp(instr *ssa.UnOp) (string, *Error) {
 	case token.SUB: // arithmetic negation e.g. x=>-x
 		asm, err = f.UnOpSub(instr)
 	case token.MUL: //pointer indirection
-		asm, err = f.UnOpPointer(instr)
+		if first, ok := f.loadCSE[instr]; ok {
+			return fmt.Sprintf("// ssa.UnOp, %v = %v, same as %v\n", instr.Name(), instr, first.Name()), nil
+		}
+		if p, ok := f.pipelined[instr]; ok {
+			asm, err = f.PipelinedLoad(instr, p)
+		} else {
+			asm, err = f.UnOpPointer(instr)
+		}
 	}
 	asm = fmt.Sprintf("// BEGIN ssa.UnOp: %v = %v\n", instr.Name(), instr) + asm
 	asm += fmt.Sprintf("// END ssa.UnOp: %v = %v\n", instr.Name(), instr)
@@ -1342,9 +2412,19 @@ func (f *Function) UnOpSub(instr *ssa.UnOp) (string, *Error) {
 		return asm, err
 	}
 
-	asm += ZeroReg(ctx, regSubX)
 	optypes := GetOpDataType(instr.Type())
-	asm += ArithOp(ctx, optypes, token.SUB, regSubX, regX, regVal)
+	if isFloat(instr.Type()) {
+		// flip the sign bit, 0 - x is +0 for x = +0 instead of -0
+		a, tmp := f.allocReg(instr, DATA_REG, DataRegSize)
+		asm += a
+		asm += MovRegReg(ctx, optypes, regX, regVal, false)
+		asm += MovSignMaskReg(ctx, optypes, false, tmp, regSubX)
+		asm += FloatBitwiseOp(ctx, token.XOR, regSubX, regVal)
+		f.freeReg(tmp)
+	} else {
+		asm += ZeroReg(ctx, regSubX)
+		asm += ArithOp(ctx, optypes, token.SUB, regSubX, regX, regVal)
+	}
 	f.freeReg(regX)
 	f.freeReg(regSubX)
 
@@ -1362,7 +2442,7 @@ func (f *Function) UnOpSub(instr *ssa.UnOp) (string, *Error) {
 
 }
 
-//pointer indirection, in assignment such as "z = *x"
+// pointer indirection, in assignment such as "z = *x"
 func (f *Function) UnOpPointer(instr *ssa.UnOp) (string, *Error) {
 	asm := ""
 	assignment := f.Ident(instr)
@@ -1371,31 +2451,54 @@ func (f *Function) UnOpPointer(instr *ssa.UnOp) (string, *Error) {
 	}
 	xName := instr.X.Name()
 	xInfo, okX := f.identifiers[xName]
-	if !xInfo.isSsaLocal() && xInfo.param == nil && xInfo.ptr == nil {
-		panic("unexpected nil ptr")
-	} else if !xInfo.isSsaLocal() && xInfo.param == nil {
+	if !okX {
+		msgstr := "Unknown name for UnOp X (%v), instr \"(%v)\""
+		ice(fmt.Sprintf(msgstr, instr.X, instr))
+	}
+	// registers caching the pointed to memory are stored first, a pointer
+	// loaded from memory, e.g. *p of a **T or *[]T parameter p, points to
+	// the caller's memory which isn't cached
+	if !xInfo.isSsaLocal() && xInfo.param == nil && xInfo.ptr != nil {
 		asm += xInfo.ptr.spillAllRegisters(instr)
 	}
 	// TODO add complex64/128 support
 	if isComplex(instr.Type()) || isComplex(instr.X.Type()) {
 		return ErrorMsg("complex64/complex128 unimplemented")
 	}
-	if !okX {
-		msgstr := "Unknown name for UnOp X (%v), instr \"(%v)\""
-		ice(fmt.Sprintf(msgstr, instr.X, instr))
-	}
 	if xInfo.local == nil && xInfo.param == nil && !xInfo.isPointer() {
 		fmtstr := "in UnOp, X (%v) isn't a pointer, X.type (%v), instr \"(%v)\""
 		msg := fmt.Sprintf(fmtstr, instr.X, instr.X.Type(), instr)
 		ice(msg)
 	}
 	_, _, size := assignment.Addr()
-	if xInfo.isSsaLocal() {
+	if xInfo.isSsaLocal() && size > DataRegSize && !isXmm(instr.Type()) {
+		// a local array or struct, e.g. the copy of a scratch array
+		// ranged over, is copied a chunk at a time
+		src := xInfo.storage.(*memory)
+		dst := assignment.storage.(*memory)
+		dst.removeAliases()
+		asm += copyMemMem(src, dst, transfer{srcOffset: 0, dstOffset: 0, size: size})
+		dst.setInitialized(region{0, size})
+	} else if xInfo.isSsaLocal() {
 		ctx := context{f, instr}
 		a, reg := xInfo.load(ctx)
 		asm += a
 		asm += assignment.newValue(ctx, reg, 0, xInfo.size())
 		f.freeReg(reg)
+	} else if f.repCopy(instr.Type()) {
+		ctx := context{f, instr}
+		a, repRegs := f.allocRepRegs(instr)
+		asm += a
+		src := xInfo.storage.(*memory)
+		dst := assignment.storage.(*memory)
+		dst.removeAliases()
+		a, srcReg := src.load(ctx, src.ownerRegion())
+		asm += a
+		aReg, aOffset, _ := assignment.Addr()
+		asm += MovMemMemRep(ctx, "", 0, srcReg, assignment.name, aOffset, &aReg, size)
+		dst.setInitialized(region{0, size})
+		f.freeReg(srcReg)
+		f.freeRegs(repRegs)
 	} else {
 		var tmpData *register
 		var a1 string
@@ -1423,7 +2526,11 @@ func (f *Function) UnOpPointer(instr *ssa.UnOp) (string, *Error) {
 		a, srcReg := src.load(ctx, src.ownerRegion())
 		asm += a
 		aReg, aOffset, _ := assignment.Addr()
-		asm += MovRegIndirectMem(ctx, dst.optype(), srcReg, assignment.name, aOffset, &aReg, size, tmpAddr, tmpData)
+		if isXmm(instr.Type()) && size == XmmRegSize && f.ptrAlign(xInfo) >= XmmRegSize {
+			asm += MovAlignedIndirectMem(ctx, dst.optype(), srcReg, assignment.name, aOffset, &aReg, tmpData)
+		} else {
+			asm += MovRegIndirectMem(ctx, dst.optype(), srcReg, assignment.name, aOffset, &aReg, size, tmpAddr, tmpData)
+		}
 		dst.setInitialized(region{0, size})
 		f.freeReg(srcReg)
 		f.freeReg(tmpAddr)
@@ -1483,6 +2590,13 @@ func (f *Function) IndexAddr(instr *ssa.IndexAddr) (string, *Error) {
 
 	}
 
+	if ptr, ok := f.inductionPtrs[instr]; ok {
+		return f.InductionIndexAddr(instr, ptr)
+	}
+	if first, ok := f.addrCSE[instr]; ok {
+		return fmt.Sprintf("// ssa.IndexAddr, %v = %v, same as %v\n", instr.Name(), instr, first.Name()), nil
+	}
+
 	asm := ""
 	xInfo := f.identifiers[instr.X.Name()]
 	assignment := f.Ident(instr)
@@ -1497,16 +2611,36 @@ func (f *Function) IndexAddr(instr *ssa.IndexAddr) (string, *Error) {
 	a1, addr := f.allocIdentReg(instr, assignment, assignment.size())
 	asm += a1
 
-	a, idx, err := f.LoadValueSimple(instr, instr.Index)
-	if err != nil {
-		return "", err
+	// an element of an array through a pointer, e.g. a[i][j] of a []simd.F32x4
+	xType := xInfo.typ
+	ptr, isPtr := xType.(*types.Pointer)
+	if isPtr {
+		xType = ptr.Elem()
+	}
+	elemSize := sizeofElem(xType)
+
+	// constant array indexes are checked by the type checker, other constant
+	// indexes are added to the address unless they're checked
+	cnst, isCnst := instr.Index.(*ssa.Const)
+	checked := f.opts.BoundsCheck && (isSlice(xInfo.typ) || !isCnst)
+	var idx *register
+	if !isCnst || checked {
+		a, reg, err := f.LoadIndex(instr, instr.Index)
+		if err != nil {
+			return "", err
+		}
+		asm += a
+		idx = reg
+		if checked {
+			asm += f.BoundsCheck(instr, xInfo, idx)
+		}
+		if !isLeaScale(elemSize) {
+			asm += f.MulIndex(instr, idx, elemSize)
+		}
 	}
-	asm += a
-
-	asm += MulImm32RegReg(ctx, uint32(sizeofElem(xInfo.typ)), idx, idx, true)
 
-	if isSlice(xInfo.typ) {
-		// TODO: add bounds checking
+	if isSlice(xInfo.typ) || isPtr {
+		// the slice's first word and the pointer are the element addresses
 		optypes := GetIntegerOpDataType(false, sizePtr())
 		asm += MovMemReg(ctx, optypes, xInfo.name, xOffset, &xReg, addr, false)
 	} else if isArray(xInfo.typ) {
@@ -1519,8 +2653,19 @@ func (f *Function) IndexAddr(instr *ssa.IndexAddr) (string, *Error) {
 		ice(fmt.Sprintf("indexing non-slice/array variable, type %v", xInfo.typ))
 	}
 
-	optypes := GetIntegerOpDataType(false, idx.size())
-	asm += AddRegReg(ctx, optypes, idx, addr, false)
+	if idx == nil {
+		offset, ok := constIndexOffset(cnst, elemSize)
+		if !ok {
+			msg := "constant index %v of %v overflows the element's address"
+			return ErrorMsg(fmt.Sprintf(msg, cnst.Value, instr.X.Name()))
+		}
+		asm += f.AddOffset(instr, offset, addr)
+	} else if isLeaScale(elemSize) {
+		asm += LeaScaled(ctx, addr, idx, elemSize, addr, false)
+	} else {
+		optypes := GetIntegerOpDataType(false, idx.size())
+		asm += AddRegReg(ctx, optypes, idx, addr, false)
+	}
 
 	a, e := f.StoreValue(instr, assignment, addr)
 	if e != nil {
@@ -1528,7 +2673,9 @@ func (f *Function) IndexAddr(instr *ssa.IndexAddr) (string, *Error) {
 	}
 	asm += a
 
-	f.freeReg(idx)
+	if idx != nil {
+		f.freeReg(idx)
+	}
 	f.freeReg(addr)
 
 	asm = fmt.Sprintf("// BEGIN ssa.IndexAddr: %v = %v\n", instr.Name(), instr) + asm
@@ -1536,13 +2683,132 @@ func (f *Function) IndexAddr(instr *ssa.IndexAddr) (string, *Error) {
 	return asm, nil
 }
 
+// constIndexOffset returns the byte offset of the element cnst of elemSize
+// bytes, false if the index is negative or the offset overflows an int64.
+func constIndexOffset(cnst *ssa.Const, elemSize uint) (int64, bool) {
+	var i uint64
+	if signed(cnst.Type()) {
+		if cnst.Int64() < 0 {
+			return 0, false
+		}
+		i = uint64(cnst.Int64())
+	} else {
+		i = cnst.Uint64()
+	}
+	if i > math.MaxInt64 || elemSize != 0 && i > math.MaxInt64/uint64(elemSize) {
+		return 0, false
+	}
+	return int64(i * uint64(elemSize)), true
+}
+
+// AddOffset adds offset bytes to the address in addr, offsets that don't
+// fit in a 32 bit immediate are moved to a register first.
+func (f *Function) AddOffset(loc ssa.Instruction, offset int64, addr *register) string {
+	ctx := context{f, loc}
+	if offset == 0 {
+		return ""
+	}
+	if offset <= maxDisp {
+		return AddImm32Reg(ctx, uint32(offset), addr, false)
+	}
+	asm, tmp := f.allocTempReg(DATA_REG, DataRegSize)
+	asm += MovImmReg(ctx, offset, sizePtr(), tmp, false)
+	asm += AddRegReg(ctx, GetIntegerOpDataType(false, sizePtr()), tmp, addr, false)
+	f.freeReg(tmp)
+	return asm
+}
+
+// MulIndex multiplies the index in idx by the element size, sizes that
+// don't fit in a 32 bit immediate are moved to a register first.
+func (f *Function) MulIndex(loc ssa.Instruction, idx *register, elemSize uint) string {
+	ctx := context{f, loc}
+	if elemSize <= maxDisp {
+		return MulImm32RegReg(ctx, uint32(elemSize), idx, idx, true)
+	}
+	asm, tmp := f.allocTempReg(DATA_REG, DataRegSize)
+	asm += MovImmReg(ctx, int64(elemSize), sizePtr(), tmp, false)
+	asm += MulRegReg(ctx, GetIntegerOpDataType(false, sizePtr()), tmp, idx, true)
+	f.freeReg(tmp)
+	return asm
+}
+
+// LoadIndex loads the index val into a register as a pointer sized integer,
+// sign extending signed and zero extending unsigned indexes smaller than
+// that. The bytes above a smaller integer in its register are garbage, and
+// a negative index must stay negative to be out of range.
+func (f *Function) LoadIndex(loc ssa.Instruction, val ssa.Value) (string, *register, *Error) {
+	a, idx, err := f.LoadValueSimple(loc, val)
+	if err != nil {
+		return "", nil, err
+	}
+	size := f.sizeof(val)
+	if size >= sizePtr() {
+		return a, idx, nil
+	}
+	asm := a
+	a, tmp := f.allocReg(loc, DATA_REG, DataRegSize)
+	asm += a
+	if signed(val.Type()) {
+		asm += MovSignExtend(context{f, loc}, idx, tmp, size, sizePtr(), false)
+	} else {
+		asm += MovZeroExtend(context{f, loc}, idx, tmp, size, sizePtr(), false)
+	}
+	f.freeReg(idx)
+	return asm, tmp, nil
+}
+
+// BoundsCheck jumps to boundsfault if idx is out of range for x, the index
+// is compared unsigned so negative indexes are out of range.
+func (f *Function) BoundsCheck(loc ssa.Instruction, x *identifier, idx *register) string {
+	ctx := context{f, loc}
+	asm := fmt.Sprintf("// BEGIN BoundsCheck %v[%v]\n", x.name, idx.name)
+	optypes := GetIntegerOpDataType(false, sizePtr())
+	if isSlice(x.typ) {
+		xReg, xOffset, _ := x.Addr()
+		a, length := f.allocTempReg(DATA_REG, DataRegSize)
+		asm += a
+		// the length is the second word of a slice
+		asm += MovMemReg(ctx, optypes, x.name, xOffset+int(sizePtr()), &xReg, length, false)
+		asm += CmpRegReg(ctx, optypes, idx, length)
+		f.freeReg(length)
+	} else {
+		t := x.typ
+		if ptr, ok := t.(*types.Pointer); ok {
+			t = ptr.Elem()
+		}
+		length := sizeof(t) / sizeofElem(t)
+		asm += CmpRegImm32(ctx, idx, uint32(length), sizePtr())
+	}
+	asm += fmt.Sprintf("%-9v    %v\n", JCC, boundsFaultLabel)
+	f.boundsChecked = true
+	asm += fmt.Sprintf("// END BoundsCheck %v[%v]\n", x.name, idx.name)
+	return asm
+}
+
+// LenCheck jumps to boundsfault if the slice x is shorter than n.
+func (f *Function) LenCheck(loc ssa.Instruction, x *identifier, n uint32) string {
+	ctx := context{f, loc}
+	asm := fmt.Sprintf("// BEGIN LenCheck len(%v) >= %v\n", x.name, n)
+	optypes := GetIntegerOpDataType(false, sizePtr())
+	xReg, xOffset, _ := x.Addr()
+	a, length := f.allocTempReg(DATA_REG, DataRegSize)
+	asm += a
+	asm += MovMemReg(ctx, optypes, x.name, xOffset+int(sizePtr()), &xReg, length, false)
+	asm += CmpRegImm32(ctx, length, n, sizePtr())
+	asm += fmt.Sprintf("%-9v    %v\n", JCS, boundsFaultLabel)
+	f.freeReg(length)
+	f.boundsChecked = true
+	asm += fmt.Sprintf("// END LenCheck len(%v) >= %v\n", x.name, n)
+	return asm
+}
+
 func (f *Function) AllocInstr(instr *ssa.Alloc) (string, *Error) {
 	asm := ""
 	if instr == nil {
 		return ErrorMsg("AllocInstr: nil instr")
 
 	}
-	if instr.Heap {
+	if !stackAlloc(instr) {
 		msg := "Heap allocations are unsupported (are all print and log statements removed?), ssa variable: %v, type: %v"
 		msgstr := fmt.Sprintf(msg, instr.Name(), instr.Type())
 		return ErrorMsg(msgstr)
@@ -1555,8 +2821,9 @@ func (f *Function) AllocInstr(instr *ssa.Alloc) (string, *Error) {
 	if info.local == nil {
 		ice(fmt.Sprintf("expected %v to be a local variable", instr.Name()))
 	}
-	if _, ok := info.typ.(*types.Pointer); ok {
-	} else {
+	if zeroedAtAlloc(instr) && (!f.Optimize || !writtenBeforeRead(instr)) {
+		asm += info.spillAllRegisters(instr)
+		asm += zeroMemoryStores(context{f, instr}, info.name, info.offset, info.size(), getRegister(REG_SP))
 	}
 	f.identifiers[instr.Name()] = info
 	return asm, nil
@@ -1564,7 +2831,11 @@ func (f *Function) AllocInstr(instr *ssa.Alloc) (string, *Error) {
 
 func (f *Function) localIdentsSize() uint32 {
 	size := uint32(0)
-	for _, ident := range f.identifiers {
+	for name, ident := range f.identifiers {
+		// addresses replaced by another share its identifier
+		if name != ident.name {
+			continue
+		}
 		if !ident.isConst() && !ident.isParam() && !ident.isRetIdent() {
 			size += uint32(ident.size())
 		}
@@ -1572,12 +2843,64 @@ func (f *Function) localIdentsSize() uint32 {
 	return size
 }
 
+// maxFrameSize is the most bytes of locals and arguments of a function, the
+// largest goroutine stack on 64 bit platforms. Offsets from SP and FP are
+// then always in range of a 32 bit displacement.
+const maxFrameSize = 1 << 30
+
+// checkFrameSize returns an error if the parameters, results, and values of
+// f, each of which may need a stack slot, could exceed maxFrameSize, with
+// the position of the largest value.
+func (f *Function) checkFrameSize() *Error {
+	total := uint64(0)
+	largest, largestSize := ssa.Value(nil), uint64(0)
+	add := func(v ssa.Value, typ types.Type) {
+		// gcSizes handles unsupported types, they're reported by lowering
+		size := uint64(gcSizes.Sizeof(typ))
+		total += size
+		if size > largestSize {
+			largest, largestSize = v, size
+		}
+	}
+	for _, p := range f.ssa.Params {
+		add(p, p.Type())
+	}
+	if f.retType() != nil {
+		add(nil, f.retType())
+	}
+	for _, block := range f.ssa.Blocks {
+		for _, instr := range block.Instrs {
+			v, ok := instr.(ssa.Value)
+			if !ok {
+				continue
+			}
+			if _, tuple := v.Type().(*types.Tuple); tuple {
+				continue
+			}
+			if alloc, ok := v.(*ssa.Alloc); ok {
+				add(v, alloc.Type().Underlying().(*types.Pointer).Elem())
+			}
+			add(v, v.Type())
+		}
+	}
+	if total <= maxFrameSize {
+		return nil
+	}
+	msg := "locals and arguments of up to %v bytes exceed the largest frame, %v bytes"
+	err := ErrorMsg2(fmt.Sprintf(msg, total, maxFrameSize))
+	if largest != nil {
+		err.Pos = largest.Pos()
+	}
+	return err
+}
+
 func (f *Function) init() *Error {
 	for _, r := range registers {
 		f.registers = append(f.registers, r)
 	}
 	f.identifiers = make(map[string]*identifier)
 	f.phiInfo = make(map[int]map[int][]phiInfo)
+	f.passes = defaultPasses()
 	return nil
 }
 
@@ -1644,7 +2967,9 @@ func (f *Function) allocReg(loc ssa.Instruction, t RegType, size uint) (string,
 			parent := reg.parent
 			a := reg.spill(context{f, loc})
 			if a != "" {
-
+				f.spills++
+				f.regDecision(loc, "spill %v of %v", reg.name, parent.owner().name)
+				f.blockSpill(parent.owner())
 				if f.PrintSpills || f.Trace {
 					fmt.Printf("Spilling %v\n", reg.name)
 				}
@@ -1682,6 +3007,9 @@ func (f *Function) allocTempReg(t RegType, size uint) (string, *register) {
 		parent := reg.parent
 		a := reg.spill(context{f, nil})
 		if a != "" {
+			f.spills++
+			f.regDecision(nil, "spill %v of %v", reg.name, parent.owner().name)
+			f.blockSpill(parent.owner())
 			if f.PrintSpills {
 				fmt.Printf("Spilling %v\n", reg.name)
 			}
@@ -1777,6 +3105,9 @@ func (f *Function) regDead(r *register) bool {
 }
 
 func (f *Function) excludeReg(reg *register) bool {
+	if reg.class(f.opts.Arch) == RESERVED || f.pinned(reg) {
+		return true
+	}
 	for _, r := range excludedRegisters {
 		if r.name == reg.name {
 			return true
@@ -1822,21 +3153,93 @@ func (f *Function) zeroReg(r *register) string {
 	return ZeroReg(ctx, r)
 }
 
+// callFn calls the function symbol fn, spilling the caller saved registers
+// holding values before the call, the values are reloaded when next used.
+// Callee saved registers are preserved by the callee.
+func (f *Function) callFn(loc ssa.Instruction, fn string) (string, *Error) {
+	ctx := context{f, loc}
+	asm := ""
+	for i := range f.registers {
+		r := &f.registers[i]
+		if f.pinned(r) && r.class(f.opts.Arch) == CALLER_SAVE {
+			return ErrorMsg(fmt.Sprintf("Register %v is pinned across a call to %v", r.name, fn))
+		}
+		if f.excludeReg(r) || r.class(f.opts.Arch) != CALLER_SAVE {
+			continue
+		}
+		if r.inUse {
+			return ErrorMsg(fmt.Sprintf("Register %v is in use across a call to %v", r.name, fn))
+		}
+		asm += r.spill(ctx)
+	}
+	return asm + CallFn(fn), nil
+}
+
 func (f *Function) freeReg(reg *register) {
 	reg.inUse = false
 }
 
+func (f *Function) freeRegs(regs []*register) {
+	for _, reg := range regs {
+		f.freeReg(reg)
+	}
+}
+
+// repCopy returns true if values of type t are copied with REP MOVSQ, when
+// optimizing for size and they're large enough for its setup to be shorter
+// than copying each 8 bytes.
+func (f *Function) repCopy(t types.Type) bool {
+	size := sizeof(t)
+	ctx := context{f, nil}
+	return ctx.optSize() && !isXmm(t) && size >= repStringSizeOptSize && size%8 == 0
+}
+
+// allocRepRegs reserves SI and DI for a REP string instruction, spilling the
+// values they hold, free them with freeRegs.
+func (f *Function) allocRepRegs(loc ssa.Instruction) (string, []*register) {
+	asm := ""
+	var regs []*register
+	for i := range f.registers {
+		r := &f.registers[i]
+		if r.regconst != REG_SI && r.regconst != REG_DI {
+			continue
+		}
+		if r.inUse {
+			ice(fmt.Sprintf("register %v in use before a REP string instruction", r.name))
+		}
+		asm += r.spill(context{f, loc})
+		r.inUse = true
+		r.dirty = false
+		r.parent = nil
+		regs = append(regs, r)
+	}
+	return asm, regs
+}
+
 // paramsSize returns the size of the parameters in bytes
 func (f *Function) paramsSize() uint {
-	size := uint(0)
+	_, size := f.paramOffsets()
+	return size
+}
+
+// paramOffsets returns the offsets in bytes from the frame pointer (FP) of
+// the parameters and their total size, each parameter aligned like the gc
+// compiler lays out arguments, e.g. the uint32 after an int8 is at 4.
+func (f *Function) paramOffsets() ([]int, uint) {
+	offsets := []int{}
+	size := int64(0)
 	for _, p := range f.ssa.Params {
-		size += sizeof(p.Type())
+		a := gcSizes.Alignof(p.Type())
+		size = (size + a - 1) / a * a
+		offsets = append(offsets, int(size))
+		size += gcSizes.Sizeof(p.Type())
 	}
-	return size
+	return offsets, uint(size)
 }
 
+// retName is the name of the result, asmdecl's name of an unnamed result.
 func retName() string {
-	return "ret0"
+	return "ret"
 }
 
 // retType gives the return type
@@ -1851,14 +3254,20 @@ func (f *Function) retType() types.Type {
 	return results.At(0).Type()
 }
 
-// retSize returns the size of the return value in bytes
+// retSize returns the size of the return value in bytes, 0 if there's none
 func (f *Function) retSize() uint {
+	if f.retType() == nil {
+		return 0
+	}
 	size := sizeof(f.retType())
 	return size
 }
 
 // retOffset returns the offset of the return value in bytes
 func (f *Function) retOffset() int {
+	if f.retType() == nil {
+		return int(f.paramsSize())
+	}
 	align := f.retAlign()
 	// TODO: FIX
 	// HACK!!!
@@ -1899,6 +3308,9 @@ func (f *Function) Ident(v ssa.Value) *identifier {
 		return &ident
 	}
 
+	if instr, ok := v.(ssa.Instruction); ok && f.slotsAssigned && slotType(v.Type()) {
+		ice(fmt.Sprintf("no stack slot assigned to %v = %v", v.Name(), instr))
+	}
 	local, err := f.newIdent(v)
 	if err != nil {
 		return nil
@@ -0,0 +1,594 @@
+package codegen
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// instrCost is the latency of an instruction, the cycles until its result
+// can be used, and its reciprocal throughput, the cycles between
+// independent instructions of its kind.
+type instrCost struct {
+	latency    int
+	throughput float64
+}
+
+// CostModel are the approximate instruction costs of a CPU, for estimating
+// the cycles of a function's loops without running it.
+type CostModel struct {
+	// CPU is the name of the microarchitecture
+	CPU string
+	// Width is the number of instructions issued per cycle
+	Width float64
+	// LoadLatency is the latency of a load from memory, the other inputs
+	// of an instruction with a memory operand don't wait for it
+	LoadLatency int
+	// alu and vec are the costs of the unlisted integer and vector
+	// instructions
+	alu, vec instrCost
+	costs    map[string]instrCost
+}
+
+// costGroup is a cost and the space separated mnemonics having it.
+type costGroup struct {
+	cost      instrCost
+	mnemonics string
+}
+
+func costTable(groups ...costGroup) map[string]instrCost {
+	costs := map[string]instrCost{}
+	for _, g := range groups {
+		for _, m := range strings.Fields(g.mnemonics) {
+			costs[m] = g.cost
+		}
+	}
+	return costs
+}
+
+// the instruction classes of the cost tables, "CVT" and "FMA" stand for the
+// conversions and fused multiply adds
+const (
+	costIMul    = "IMULW IMULL IMULQ IMUL3L IMUL3Q MULB MULW MULL MULQ MULXQ"
+	costDiv32   = "DIVB DIVW DIVL IDIVB IDIVW IDIVL"
+	costDiv64   = "DIVQ IDIVQ"
+	costBitScan = "BSFL BSFQ BSRL BSRQ POPCNTL POPCNTQ LZCNTL LZCNTQ TZCNTL TZCNTQ CRC32B CRC32Q"
+	costPdep    = "PDEPQ PEXTQ"
+	costFAdd    = "ADDSS ADDSD ADDPS ADDPD SUBSS SUBSD SUBPS SUBPD MAXSS MAXSD MAXPS MAXPD MINSS MINSD MINPS MINPD CMPPS CMPPD CMPSS CMPSD"
+	costFMul    = "MULSS MULSD MULPS MULPD FMA"
+	costDivS    = "DIVSS DIVPS"
+	costDivD    = "DIVSD DIVPD"
+	costSqrtS   = "SQRTSS SQRTPS"
+	costSqrtD   = "SQRTSD SQRTPD"
+	costRound   = "ROUNDSS ROUNDSD ROUNDPS ROUNDPD"
+	costPMul    = "PMULLW PMULHW PMULHUW PMULULQ PMADDWL PMADDUBSW VPDPBUSD"
+	costPMulLD  = "PMULLD"
+	costShuffle = "PSHUFB PSHUFD PSHUFHW PSHUFLW SHUFPS SHUFPD UNPCKLPS UNPCKHPS UNPCKLPD UNPCKHPD " +
+		"PUNPCKLBW PUNPCKHBW PUNPCKLWL PUNPCKHWL PUNPCKLLQ PUNPCKHLQ PUNPCKLQDQ PUNPCKHQDQ " +
+		"PALIGNR PACKSSLW PACKSSWB PACKUSWB PSLLO PSRLO INSERTPS PINSRB PINSRW PINSRD PINSRQ " +
+		"PEXTRB PEXTRW PEXTRD PEXTRQ MOVHLPS MOVLHPS"
+	costLane  = "VPERMD VPERMQ VPERM2I128 VPERM2F128 VINSERTI128 VEXTRACTI128 VPBROADCASTB VPBROADCASTW VPBROADCASTD VPBROADCASTQ VBROADCASTSS"
+	costMask  = "MOVMSKPS MOVMSKPD PMOVMSKB PTEST"
+	costCvt   = "CVT VCVTPH2PS VCVTPS2PH"
+	costClmul = "PCLMULQDQ"
+	costDpps  = "DPPS"
+	costCall  = "CALL"
+)
+
+// costModels are the CPU models of Cost, by name.
+var costModels = map[string]*CostModel{
+	"nehalem": {
+		CPU: "nehalem", Width: 4, LoadLatency: 4,
+		alu: instrCost{1, 0.33}, vec: instrCost{1, 0.5},
+		costs: costTable(
+			costGroup{instrCost{3, 1}, costIMul + " " + costBitScan},
+			costGroup{instrCost{26, 11}, costDiv32},
+			costGroup{instrCost{70, 40}, costDiv64},
+			costGroup{instrCost{3, 1}, costFAdd + " " + costRound + " " + costPMul},
+			costGroup{instrCost{5, 1}, costFMul},
+			costGroup{instrCost{14, 11}, costDivS},
+			costGroup{instrCost{22, 21}, costDivD},
+			costGroup{instrCost{18, 18}, costSqrtS},
+			costGroup{instrCost{32, 31}, costSqrtD},
+			costGroup{instrCost{6, 2}, costPMulLD},
+			costGroup{instrCost{1, 1}, costShuffle},
+			costGroup{instrCost{2, 1}, costMask},
+			costGroup{instrCost{4, 1}, costCvt},
+			costGroup{instrCost{12, 8}, costClmul},
+			costGroup{instrCost{11, 2}, costDpps},
+			costGroup{instrCost{5, 2}, costCall},
+		),
+	},
+	"skylake": {
+		CPU: "skylake", Width: 4, LoadLatency: 5,
+		alu: instrCost{1, 0.25}, vec: instrCost{1, 0.33},
+		costs: costTable(
+			costGroup{instrCost{3, 1}, costIMul + " " + costBitScan + " " + costPdep},
+			costGroup{instrCost{26, 6}, costDiv32},
+			costGroup{instrCost{42, 24}, costDiv64},
+			costGroup{instrCost{4, 0.5}, costFAdd + " " + costFMul},
+			costGroup{instrCost{11, 3}, costDivS},
+			costGroup{instrCost{14, 4}, costDivD},
+			costGroup{instrCost{12, 3}, costSqrtS},
+			costGroup{instrCost{18, 6}, costSqrtD},
+			costGroup{instrCost{8, 1}, costRound},
+			costGroup{instrCost{5, 0.5}, costPMul},
+			costGroup{instrCost{10, 1}, costPMulLD},
+			costGroup{instrCost{1, 1}, costShuffle},
+			costGroup{instrCost{3, 1}, costLane + " " + costMask},
+			costGroup{instrCost{5, 1}, costCvt},
+			costGroup{instrCost{7, 1}, costClmul},
+			costGroup{instrCost{13, 1.5}, costDpps},
+			costGroup{instrCost{5, 2}, costCall},
+		),
+	},
+	"icelake": {
+		CPU: "icelake", Width: 5, LoadLatency: 5,
+		alu: instrCost{1, 0.25}, vec: instrCost{1, 0.33},
+		costs: costTable(
+			costGroup{instrCost{3, 1}, costIMul + " " + costBitScan + " " + costPdep},
+			costGroup{instrCost{12, 6}, costDiv32},
+			costGroup{instrCost{15, 10}, costDiv64},
+			costGroup{instrCost{4, 0.5}, costFAdd + " " + costFMul},
+			costGroup{instrCost{11, 3}, costDivS},
+			costGroup{instrCost{13, 4}, costDivD},
+			costGroup{instrCost{12, 3}, costSqrtS},
+			costGroup{instrCost{16, 6}, costSqrtD},
+			costGroup{instrCost{8, 1}, costRound},
+			costGroup{instrCost{5, 0.5}, costPMul},
+			costGroup{instrCost{10, 1}, costPMulLD},
+			costGroup{instrCost{1, 0.5}, costShuffle},
+			costGroup{instrCost{3, 1}, costLane + " " + costMask},
+			costGroup{instrCost{5, 1}, costCvt},
+			costGroup{instrCost{6, 1}, costClmul},
+			costGroup{instrCost{13, 1.5}, costDpps},
+			costGroup{instrCost{5, 2}, costCall},
+		),
+	},
+	"zen2": {
+		CPU: "zen2", Width: 5, LoadLatency: 4,
+		alu: instrCost{1, 0.25}, vec: instrCost{1, 0.25},
+		costs: costTable(
+			costGroup{instrCost{3, 1}, costIMul + " " + costBitScan},
+			costGroup{instrCost{19, 19}, costPdep},
+			costGroup{instrCost{25, 14}, costDiv32},
+			costGroup{instrCost{45, 40}, costDiv64},
+			costGroup{instrCost{3, 0.5}, costFAdd + " " + costFMul},
+			costGroup{instrCost{10, 3}, costDivS},
+			costGroup{instrCost{13, 5}, costDivD},
+			costGroup{instrCost{14, 5}, costSqrtS},
+			costGroup{instrCost{20, 8}, costSqrtD},
+			costGroup{instrCost{3, 1}, costRound + " " + costPMul + " " + costMask},
+			costGroup{instrCost{4, 2}, costPMulLD},
+			costGroup{instrCost{1, 0.5}, costShuffle},
+			costGroup{instrCost{3, 1}, costLane},
+			costGroup{instrCost{4, 1}, costCvt},
+			costGroup{instrCost{4, 2}, costClmul},
+			costGroup{instrCost{15, 4}, costDpps},
+			costGroup{instrCost{5, 2}, costCall},
+		),
+	},
+}
+
+// CostCPUs returns the names of the CPU models of Cost.
+func CostCPUs() []string {
+	names := []string{}
+	for name := range costModels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// TargetCPU returns the CPU model typical of the feature level target, the
+// model Cost uses by default.
+func TargetCPU(target string) string {
+	switch {
+	case targetLevel(target) >= targetLevel(TargetAVX512VNNI):
+		return "icelake"
+	case targetLevel(target) >= targetLevel(TargetAVX):
+		return "skylake"
+	}
+	return "nehalem"
+}
+
+// cost returns the cost of the instruction mnemonic, vector if it has a
+// vector register operand.
+func (m *CostModel) cost(mnemonic string, vector bool) instrCost {
+	if c, ok := m.costs[mnemonic]; ok {
+		return c
+	}
+	// the VEX encodings cost the same as the SSE ones
+	if c, ok := m.costs[strings.TrimPrefix(mnemonic, "V")]; ok {
+		return c
+	}
+	switch {
+	case strings.HasPrefix(mnemonic, "CVT") || strings.HasPrefix(mnemonic, "VCVT"):
+		return m.costs["CVT"]
+	case strings.Contains(mnemonic, "FMADD") || strings.Contains(mnemonic, "FMSUB") ||
+		strings.Contains(mnemonic, "FNMADD") || strings.Contains(mnemonic, "FNMSUB"):
+		return m.costs["FMA"]
+	case vector:
+		return m.vec
+	}
+	return m.alu
+}
+
+// LoopCost is the estimated cost of an iteration of a loop.
+type LoopCost struct {
+	// Header is the block index of the loop header
+	Header int `json:"header"`
+	// Blocks are the indexes of the loop's blocks, inner loops are counted
+	// once
+	Blocks []int `json:"blocks"`
+	// Instrs is the number of instructions of an iteration
+	Instrs int `json:"instrs"`
+	// Cycles is the estimated cycles per iteration, the larger bound
+	Cycles float64 `json:"cycles"`
+	// ThroughputBound is the cycles to issue the instructions of an
+	// iteration, the sum of their reciprocal throughputs or their count
+	// divided by the issue width
+	ThroughputBound float64 `json:"throughputBound"`
+	// LatencyBound is the latency of the longest dependency chain carried
+	// from an iteration to the next, through registers and stack slots
+	LatencyBound float64 `json:"latencyBound"`
+	// CriticalPath are the instructions of that chain
+	CriticalPath []string `json:"criticalPath"`
+}
+
+// CostReport is the estimated cost of a function on a CPU model.
+type CostReport struct {
+	// Name is the assembly function name
+	Name string `json:"name"`
+	CPU  string `json:"cpu"`
+	// Latency is the length in cycles of the critical path of the
+	// function's instructions, each executed once
+	Latency float64    `json:"latency"`
+	Loops   []LoopCost `json:"loops"`
+}
+
+// costIterations is the number of iterations the dependencies of a loop are
+// simulated for, enough for the chains carried over several iterations to
+// reach their rate.
+const costIterations = 8
+
+// Cost returns the estimated cycles per iteration and critical path of
+// each loop of f on the CPU model cpu, one of CostCPUs, or the
+// TargetCPU of f's target if empty. GoAssembly must have succeeded. The
+// estimate is static: every block of a loop is counted each iteration,
+// loads hit the L1 cache, and memory other than stack slots isn't a
+// dependency.
+func (f *Function) Cost(cpu string) (CostReport, *Error) {
+	if f.asm == "" {
+		return CostReport{}, ErrorMsg2("Cost requires the assembly, call GoAssembly first")
+	}
+	if len(f.opts.Variants) > 0 {
+		return CostReport{}, ErrorMsg2(fmt.Sprintf("cost of %v unsupported, it has variants", f.outfname()))
+	}
+	if cpu == "" {
+		cpu = TargetCPU(f.opts.Target)
+	}
+	model, ok := costModels[cpu]
+	if !ok {
+		msg := "Unknown CPU \"%v\", expected one of %v"
+		return CostReport{}, ErrorMsg2(fmt.Sprintf(msg, cpu, strings.Join(CostCPUs(), ", ")))
+	}
+	all, blocks := []costInstr{}, map[int][]costInstr{}
+	order := []int{}
+	block := -1
+	for _, line := range strings.Split(f.asm, "\n") {
+		if label := strings.TrimSpace(line); strings.HasSuffix(label, ":") && !strings.HasPrefix(label, "//") {
+			block = -1
+			if i, err := strconv.Atoi(strings.TrimPrefix(strings.TrimSuffix(label, ":"), "block")); err == nil && strings.HasPrefix(label, "block") {
+				block = i
+				order = append(order, i)
+			}
+			continue
+		}
+		instr, ok := model.parseInstr(line)
+		if !ok {
+			continue
+		}
+		all = append(all, instr)
+		if block >= 0 {
+			blocks[block] = append(blocks[block], instr)
+		}
+	}
+	report := CostReport{Name: f.outfname(), CPU: cpu}
+	report.Latency, _ = simulateCost(all, 1)
+	for _, loop := range naturalLoops(f.ssa) {
+		lc := LoopCost{Header: loop.header.Index}
+		body := []costInstr{}
+		for _, i := range order {
+			if loop.inLoop[i] {
+				lc.Blocks = append(lc.Blocks, i)
+				body = append(body, blocks[i]...)
+			}
+		}
+		lc.Instrs = len(body)
+		for _, instr := range body {
+			lc.ThroughputBound += instr.cost.throughput
+		}
+		if issue := float64(len(body)) / model.Width; issue > lc.ThroughputBound {
+			lc.ThroughputBound = issue
+		}
+		lc.LatencyBound, lc.CriticalPath = simulateCost(body, costIterations)
+		lc.Cycles = lc.ThroughputBound
+		if lc.LatencyBound > lc.Cycles {
+			lc.Cycles = lc.LatencyBound
+		}
+		report.Loops = append(report.Loops, lc)
+	}
+	return report, nil
+}
+
+// costInstr is an instruction and the registers, stack slots, and flags it
+// reads and writes. loads are the address registers or stack slot of its
+// memory operand, the load takes loadLatency after they're ready.
+type costInstr struct {
+	text          string
+	cost          instrCost
+	reads, writes []string
+	loads         []string
+	loadLatency   int
+}
+
+const costFlags = "flags"
+
+var (
+	addrRegs = regexp.MustCompile(`\(([A-Z0-9]+)(\*[0-9])?\)`)
+	regNames = regexp.MustCompile(`^(AX|BX|CX|DX|SI|DI|BP|R[0-9]+|[XYZ][0-9]+|K[0-7])$`)
+)
+
+// normalizeReg returns the register name of op, the X register for the Y
+// and Z registers of the same number, empty if op isn't a register.
+func normalizeReg(op string) string {
+	if !regNames.MatchString(op) {
+		return ""
+	}
+	if op[0] == 'Y' || op[0] == 'Z' {
+		return "X" + op[1:]
+	}
+	return op
+}
+
+// isStackSlot returns true if the memory operand op is an argument or local.
+func isStackSlot(op string) bool {
+	return (strings.HasSuffix(op, "(SP)") || strings.HasSuffix(op, "(FP)")) && strings.Count(op, "(") == 1
+}
+
+// hasPrefix returns true if s has one of the space separated prefixes.
+func hasPrefix(s, prefixes string) bool {
+	for _, prefix := range strings.Fields(prefixes) {
+		if strings.HasPrefix(s, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseInstr returns the instruction on line with its cost and
+// dependencies, false for labels, comments, and directives.
+func (m *CostModel) parseInstr(line string) (costInstr, bool) {
+	mnemonic, ops, ok := asmInstr(line)
+	if !ok {
+		return costInstr{}, false
+	}
+	if len(ops) == 1 && ops[0] == "" {
+		ops = nil
+	}
+	vector := false
+	for _, op := range ops {
+		if r := normalizeReg(op); r != "" && (r[0] == 'X' || r[0] == 'K') {
+			vector = true
+		}
+	}
+	instr := costInstr{text: strings.Join(strings.Fields(line), " "), cost: m.cost(mnemonic, vector)}
+	read := func(names ...string) { instr.reads = append(instr.reads, names...) }
+	write := func(names ...string) { instr.writes = append(instr.writes, names...) }
+	load, store := false, false
+	// operand reads the registers and stack slot of a source operand
+	operand := func(op string) {
+		if r := normalizeReg(op); r != "" {
+			read(r)
+			return
+		}
+		if !strings.Contains(op, "(") || strings.HasPrefix(op, "$") {
+			return
+		}
+		// LEA only computes the address
+		if strings.HasPrefix(mnemonic, "LEA") {
+			for _, match := range addrRegs.FindAllStringSubmatch(op, -1) {
+				if r := normalizeReg(match[1]); r != "" {
+					read(r)
+				}
+			}
+			return
+		}
+		load = true
+		if isStackSlot(op) {
+			instr.loads = append(instr.loads, op)
+			return
+		}
+		for _, match := range addrRegs.FindAllStringSubmatch(op, -1) {
+			if r := normalizeReg(match[1]); r != "" {
+				instr.loads = append(instr.loads, r)
+			}
+		}
+	}
+	switch {
+	case mnemonic == "JMP" || mnemonic == "CALL" || mnemonic == "RET":
+	case strings.HasPrefix(mnemonic, "J"):
+		read(costFlags)
+	case len(ops) == 0:
+		switch mnemonic {
+		case "CQO", "CDQ", "CWD":
+			read("AX")
+			write("DX")
+		case "STOSQ", "MOVSQ":
+			read("CX", "DI", "SI", "AX")
+			write("CX", "DI", "SI")
+		}
+	case len(ops) == 1 && hasPrefix(mnemonic, "MUL IMUL DIV IDIV"):
+		read("AX", "DX")
+		operand(ops[0])
+		write("AX", "DX", costFlags)
+	case len(ops) == 1 && strings.HasPrefix(mnemonic, "SET"):
+		read(costFlags)
+		write(normalizeReg(ops[0]))
+	case len(ops) == 1:
+		// NEG, NOT, INC, DEC, and BSWAP update their operand
+		operand(ops[0])
+		if r := normalizeReg(ops[0]); r != "" {
+			write(r)
+		}
+		if hasPrefix(mnemonic, "NEG INC DEC") {
+			write(costFlags)
+		}
+	default:
+		dst := ops[len(ops)-1]
+		srcs := ops[:len(ops)-1]
+		// zeroing idioms don't depend on the register
+		if len(ops) == 2 && ops[0] == ops[1] && hasPrefix(mnemonic, "XOR SUB PXOR PSUB VPXOR") {
+			instr.cost.latency = 0
+			srcs = nil
+		}
+		for _, src := range srcs {
+			operand(src)
+		}
+		compare := hasPrefix(mnemonic, "CMP TEST BT COMIS UCOMIS PTEST VPTEST") && !hasPrefix(mnemonic, "CMPPS CMPPD CMPSS CMPSD CMPXCHG")
+		if compare || readsDst(mnemonic, ops) {
+			operand(dst)
+		}
+		if r := normalizeReg(dst); r != "" && !compare {
+			write(r)
+		} else if strings.Contains(dst, "(") && !compare {
+			store = true
+			for _, match := range addrRegs.FindAllStringSubmatch(dst, -1) {
+				if r := normalizeReg(match[1]); r != "" {
+					read(r)
+				}
+			}
+			if isStackSlot(dst) {
+				write(dst)
+			}
+		}
+		if hasPrefix(mnemonic, "CMOV ADC SBB") {
+			read(costFlags)
+		}
+		if compare || (!vector && hasPrefix(mnemonic, "ADD SUB AND OR XOR INC DEC NEG SHL SHR SAR SAL ROL ROR RCL RCR IMUL BSF BSR POPCNT LZCNT TZCNT ADC SBB BLSR")) {
+			write(costFlags)
+		}
+	}
+	if load {
+		instr.loadLatency = m.LoadLatency
+	}
+	if load && !store {
+		if instr.cost.throughput < 0.5 {
+			instr.cost.throughput = 0.5
+		}
+	}
+	if store && instr.cost.throughput < 1 {
+		instr.cost.throughput = 1
+	}
+	return instr, true
+}
+
+// readsDst returns true if the instruction mnemonic with operands ops reads
+// its destination, the last operand, besides writing it.
+func readsDst(mnemonic string, ops []string) bool {
+	if hasPrefix(mnemonic, "MOV LEA CVT SET POPCNT LZCNT TZCNT BSF BSR PMOVMSKB PSHUFD PSHUFHW PSHUFLW PEXTR ROUND IMUL3 VCVT VPBROADCAST VBROADCAST") {
+		return false
+	}
+	if strings.Contains(mnemonic, "FMADD") || strings.Contains(mnemonic, "FMSUB") ||
+		strings.Contains(mnemonic, "FNMADD") || strings.Contains(mnemonic, "FNMSUB") {
+		return true
+	}
+	// the VEX three operand forms only write their destination
+	if strings.HasPrefix(mnemonic, "V") && len(ops) >= 3 {
+		return false
+	}
+	return true
+}
+
+// simulateCost simulates the dependencies of instrs repeated iterations
+// times, each instruction starting once its inputs are ready. With one
+// iteration it returns the latency of the critical path. Otherwise it
+// returns the growth per iteration of the latest finishing chain, the
+// latency of the longest chain carried between iterations, and the
+// instructions of the carried chain it depends on.
+func simulateCost(instrs []costInstr, iterations int) (float64, []string) {
+	type node struct{ iteration, index int }
+	ready := map[string]float64{}
+	producer := map[string]node{}
+	end := make([][]float64, iterations)
+	pred := make([][]node, iterations)
+	latest := 0.0
+	for it := 0; it < iterations; it++ {
+		end[it] = make([]float64, len(instrs))
+		pred[it] = make([]node, len(instrs))
+		for i, instr := range instrs {
+			start, from := 0.0, node{-1, -1}
+			for _, r := range instr.reads {
+				if t, ok := ready[r]; ok && t > start {
+					start, from = t, producer[r]
+				}
+			}
+			if instr.loadLatency > 0 {
+				loaded, loadFrom := 0.0, node{-1, -1}
+				for _, r := range instr.loads {
+					if t, ok := ready[r]; ok && t > loaded {
+						loaded, loadFrom = t, producer[r]
+					}
+				}
+				if loaded += float64(instr.loadLatency); loaded > start {
+					start, from = loaded, loadFrom
+				}
+			}
+			end[it][i] = start + float64(instr.cost.latency)
+			pred[it][i] = from
+			for _, w := range instr.writes {
+				ready[w] = end[it][i]
+				producer[w] = node{it, i}
+			}
+			if end[it][i] > latest {
+				latest = end[it][i]
+			}
+		}
+	}
+	if iterations == 1 || len(instrs) == 0 {
+		return latest, nil
+	}
+	// the instruction finishing latest among those whose finish grows the
+	// most per iteration is on the carried chain
+	last := iterations - 1
+	best, growth := -1, 0.0
+	for i := range instrs {
+		g := end[last][i] - end[last-1][i]
+		if best < 0 || g > growth || (g == growth && end[last][i] > end[last][best]) {
+			best, growth = i, g
+		}
+	}
+	if growth <= 0 {
+		return 0, nil
+	}
+	// follow the chain back until an instruction repeats, the chain
+	// between the repeats is the carried one
+	chain := []node{}
+	seen := map[int]int{}
+	for n := (node{last, best}); n.index >= 0; n = pred[n.iteration][n.index] {
+		if j, ok := seen[n.index]; ok {
+			chain = chain[j:]
+			break
+		}
+		seen[n.index] = len(chain)
+		chain = append(chain, n)
+	}
+	path := []string{}
+	for i := len(chain) - 1; i >= 0; i-- {
+		path = append(path, instrs[chain[i].index].text)
+	}
+	return growth, path
+}
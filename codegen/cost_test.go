@@ -0,0 +1,74 @@
+package codegen
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestCost(t *testing.T) {
+	const src = "package src\n\nfunc sum(x []int32) int32 {\n\ts := int32(0)\n\tfor i := 0; i < len(x); i++ {\n\t\ts += x[i]\n\t}\n\treturn s\n}\n"
+	f, err := CreateFunction(buildFunc(t, src, "sum"), DefaultOptions())
+	if err != nil {
+		t.Fatal(err.Err)
+	}
+	if _, err := f.Cost(""); err == nil {
+		t.Errorf("Cost() without assembly succeeded")
+	}
+	// the sum is spilled to a stack slot in the loop, blocks 1 and 2
+	f.asm = `TEXT ·sum(SB),$8-28
+block0:
+        // entry
+        MOVQ         x_base+0(FP), SI
+        MOVQ         x_len+8(FP), CX
+        XORL         AX, AX
+        MOVL         AX, t0-4(SP)
+        XORQ         DX, DX
+block1:
+        // for.loop, preds block0 block2
+        CMPQ         DX, CX
+        JGE          block3
+block2:
+        // for.body, preds block1
+        MOVL         t0-4(SP), AX
+        ADDL         (SI)(DX*4), AX
+        MOVL         AX, t0-4(SP)
+        INCQ         DX
+        JMP          block1
+block3:
+        // for.done, preds block1
+        MOVL         t0-4(SP), AX
+        MOVL         AX, ret+24(FP)
+        RET
+`
+	report, err := f.Cost("skylake")
+	if err != nil {
+		t.Fatal(err.Err)
+	}
+	// the loop issues a load and store a cycle, the carried sum is a load
+	// of 5+1 cycles, the add, and the store
+	expected := CostReport{Name: "sum", CPU: "skylake", Latency: 20, Loops: []LoopCost{{
+		Header: 1, Blocks: []int{1, 2}, Instrs: 7, Cycles: 8, ThroughputBound: 3, LatencyBound: 8,
+		CriticalPath: []string{"MOVL t0-4(SP), AX", "ADDL (SI)(DX*4), AX", "MOVL AX, t0-4(SP)"},
+	}}}
+	if !reflect.DeepEqual(report, expected) {
+		t.Errorf("Cost(skylake) = %+v, expected %+v", report, expected)
+	}
+	if report, err := f.Cost(""); err != nil || report.CPU != TargetCPU(TargetAVX2) {
+		t.Errorf("Cost() of an %v target is on %v, expected %v, error %v", TargetAVX2, report.CPU, TargetCPU(TargetAVX2), err)
+	}
+	if _, err := f.Cost("pentium"); err == nil || !strings.Contains(err.Err.Error(), "Unknown CPU") {
+		t.Errorf("Cost(pentium) error %v", err)
+	}
+}
+
+func TestTargetCPU(t *testing.T) {
+	for target, cpu := range map[string]string{TargetSSE2: "nehalem", TargetSSE41: "nehalem", TargetAVX2: "skylake", TargetAVX512VNNI: "icelake"} {
+		if got := TargetCPU(target); got != cpu {
+			t.Errorf("TargetCPU(%v) = %v, expected %v", target, got, cpu)
+		}
+		if _, ok := costModels[cpu]; !ok {
+			t.Errorf("no cost model of %v", cpu)
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package codegen
+
+import "golang.org/x/tools/go/ssa"
+
+// computeAddrCSE finds the element addresses "&s[i]" already computed with
+// the same s and i by an instruction dominating them, in the same block or
+// a dominating one. They share the identifier of the first address instead
+// of being computed again, saving the instructions and the stack slot.
+func (f *Function) computeAddrCSE() {
+	f.addrCSE = make(map[ssa.Value]ssa.Value)
+	if !f.Optimize || len(f.ssa.Blocks) == 0 {
+		return
+	}
+	type key struct {
+		x, index ssa.Value
+	}
+	// the addresses available in a block are those of its dominators
+	var visit func(block *ssa.BasicBlock, dom map[key]*ssa.IndexAddr)
+	visit = func(block *ssa.BasicBlock, dom map[key]*ssa.IndexAddr) {
+		avail := make(map[key]*ssa.IndexAddr, len(dom))
+		for k, addr := range dom {
+			avail[k] = addr
+		}
+		for _, instr := range block.Instrs {
+			addr, ok := instr.(*ssa.IndexAddr)
+			if !ok || f.fusedInstrs[addr] || f.inductionPtrs[addr] != nil {
+				continue
+			}
+			k := key{addr.X, addr.Index}
+			if first, ok := avail[k]; ok {
+				f.addrCSE[addr] = first
+				f.identifiers[addr.Name()] = f.Ident(first)
+				continue
+			}
+			avail[k] = addr
+		}
+		for _, child := range block.Dominees() {
+			visit(child, avail)
+		}
+	}
+	visit(f.ssa.Blocks[0], nil)
+}
+
+// cseValue returns the address or loaded value v is replaced by, otherwise v.
+func (f *Function) cseValue(v ssa.Value) ssa.Value {
+	if first, ok := f.addrCSE[v]; ok {
+		return first
+	}
+	if first, ok := f.loadCSE[v]; ok {
+		return first
+	}
+	return v
+}
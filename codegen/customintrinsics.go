@@ -0,0 +1,149 @@
+package codegen
+
+import (
+	"fmt"
+	"go/types"
+	"sync"
+
+	"golang.org/x/tools/go/ssa"
+)
+
+// IntrinsicLowering returns the assembly of a call to an intrinsic added
+// with RegisterIntrinsic, using the registers allocated by the methods of
+// call.
+type IntrinsicLowering func(call *IntrinsicCall) (string, error)
+
+// IntrinsicCall is a call being generated by an IntrinsicLowering.
+type IntrinsicCall struct {
+	// Call is the SSA call
+	Call *ssa.Call
+
+	f      *Function
+	asm    string
+	regs   []*register
+	result *register
+}
+
+// registeredIntrinsics are the lowerings added with RegisterIntrinsic, by
+// package path and function name.
+var registeredIntrinsics = struct {
+	sync.RWMutex
+	m map[[2]string]IntrinsicLowering
+}{m: map[[2]string]IntrinsicLowering{}}
+
+// RegisterIntrinsic makes calls to the function funcName of the package
+// pkgPath intrinsics generated by lower, e.g. for vendor specific
+// instructions, instead of unsupported function calls. Registered
+// intrinsics take precedence over the simd, sse2, and math intrinsics.
+// Registering a function again replaces its lowering, a nil lower removes
+// it. It's safe to call concurrently, usually from an init function.
+func RegisterIntrinsic(pkgPath, funcName string, lower IntrinsicLowering) {
+	registeredIntrinsics.Lock()
+	defer registeredIntrinsics.Unlock()
+	key := [2]string{pkgPath, funcName}
+	if lower == nil {
+		delete(registeredIntrinsics.m, key)
+		return
+	}
+	registeredIntrinsics.m[key] = lower
+}
+
+// registeredIntrinsic returns the lowering registered for the function
+// call calls.
+func registeredIntrinsic(call *ssa.Call) (IntrinsicLowering, bool) {
+	callee := call.Common().StaticCallee()
+	if callee == nil || callee.Pkg == nil || callee.Signature.Recv() != nil {
+		return nil, false
+	}
+	registeredIntrinsics.RLock()
+	defer registeredIntrinsics.RUnlock()
+	lower, ok := registeredIntrinsics.m[[2]string{callee.Pkg.Pkg.Path(), callee.Name()}]
+	return lower, ok
+}
+
+// Arg loads argument i into a register and returns its name, e.g. "X2" or
+// "R9". The register holds the argument's value and must not be written.
+func (c *IntrinsicCall) Arg(i int) (string, error) {
+	args := c.Call.Common().Args
+	if i < 0 || i >= len(args) {
+		return "", fmt.Errorf("intrinsic %v has no argument %v", c.Call.Common().StaticCallee().Name(), i)
+	}
+	a, reg, err := c.f.LoadValueSimple(c.Call, args[i])
+	if err != nil {
+		return "", err.Err
+	}
+	c.asm += a
+	c.regs = append(c.regs, reg)
+	return reg.name, nil
+}
+
+// Result returns the name of the register the lowering writes the result
+// to, an XMM register for floats and SIMD types, otherwise a general
+// purpose register. It's stored to the result after the lowering's
+// assembly.
+func (c *IntrinsicCall) Result() (string, error) {
+	if c.result != nil {
+		return c.result.name, nil
+	}
+	if t, ok := c.Call.Type().(*types.Tuple); ok && t.Len() == 0 {
+		return "", fmt.Errorf("intrinsic %v has no result", c.Call.Common().StaticCallee().Name())
+	}
+	ident := c.f.Ident(c.Call)
+	if ident.size() > DataRegSize && !isXmm(ident.typ) {
+		return "", fmt.Errorf("intrinsic %v result of type %v doesn't fit in a register", c.Call.Common().StaticCallee().Name(), ident.typ)
+	}
+	a, reg := c.f.allocIdentReg(c.Call, ident, ident.size())
+	c.asm += a
+	c.asm += reg.modified(context{c.f, c.Call}, false)
+	c.result = reg
+	return reg.name, nil
+}
+
+// Temp returns the name of a scratch register of type t, DATA_REG or
+// XMM_REG, for the lowering's assembly.
+func (c *IntrinsicCall) Temp(t RegType) (string, error) {
+	size := uint(DataRegSize)
+	switch t {
+	default:
+		return "", fmt.Errorf("no scratch registers of type %v", t)
+	case DATA_REG:
+	case XMM_REG:
+		size = XmmRegSize
+	}
+	a, reg := c.f.allocReg(c.Call, t, size)
+	c.asm += a
+	c.asm += reg.modified(context{c.f, c.Call}, false)
+	c.regs = append(c.regs, reg)
+	return reg.name, nil
+}
+
+// HasTarget returns whether the function may use the instructions of the
+// CPU feature level target, one of the Target constants.
+func (c *IntrinsicCall) HasTarget(target string) bool {
+	return c.f.hasTarget(target)
+}
+
+// RegisteredIntrinsic generates call with the lowering registered for it.
+func (f *Function) RegisteredIntrinsic(call *ssa.Call, lower IntrinsicLowering) (string, *Error) {
+	c := &IntrinsicCall{Call: call, f: f}
+	lowered, err := lower(c)
+	f.freeRegs(c.regs)
+	if err != nil {
+		if c.result != nil {
+			f.freeReg(c.result)
+		}
+		return "", &Error{Err: err, Pos: call.Pos()}
+	}
+	asm := c.asm + lowered
+	if c.result != nil {
+		a, err := f.StoreValue(call, f.Ident(call), c.result)
+		f.freeReg(c.result)
+		if err != nil {
+			return asm, err
+		}
+		asm += a
+	}
+	asm = fmt.Sprintf("// BEGIN registered intrinsic %v\n", call) + asm
+	asm += fmt.Sprintf("// END registered intrinsic %v\n", call)
+	return asm, nil
+}
@@ -0,0 +1,56 @@
+package codegen
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestRegisterIntrinsic(t *testing.T) {
+	const src = "package src\n\nfunc popcnt(x uint64) uint64 {\n\treturn 0\n}\n\nfunc count(x uint64) uint64 {\n\treturn popcnt(x) + 1\n}\n"
+	RegisterIntrinsic("src", "popcnt", func(call *IntrinsicCall) (string, error) {
+		x, err := call.Arg(0)
+		if err != nil {
+			return "", err
+		}
+		result, err := call.Result()
+		if err != nil {
+			return "", err
+		}
+		if !call.HasTarget(TargetSSE41) {
+			return "", fmt.Errorf("popcnt needs %v", TargetSSE41)
+		}
+		return fmt.Sprintf("POPCNTQ    %v, %v\n", x, result), nil
+	})
+	defer RegisterIntrinsic("src", "popcnt", nil)
+
+	fn := buildFunc(t, src, "count")
+	if errs := Unsupported(fn); len(errs) > 0 {
+		t.Fatalf("registered intrinsic unsupported, %v", errs[0].Err)
+	}
+	f, err := CreateFunction(fn, DefaultOptions())
+	if err != nil {
+		t.Fatal(err.Err)
+	}
+	asm, err := f.GoAssembly()
+	if err != nil {
+		t.Fatal(err.Err)
+	}
+	if !strings.Contains(asm, "POPCNTQ") {
+		t.Errorf("registered intrinsic not generated:\n%v", asm)
+	}
+
+	opts := DefaultOptions()
+	opts.Target = TargetSSE2
+	if f, err = CreateFunction(fn, opts); err != nil {
+		t.Fatal(err.Err)
+	}
+	if _, err := f.GoAssembly(); err == nil || !strings.Contains(err.Err.Error(), "popcnt needs") {
+		t.Errorf("lowering error %v, expected \"popcnt needs %v\"", err, TargetSSE41)
+	}
+
+	RegisterIntrinsic("src", "popcnt", nil)
+	if errs := Unsupported(fn); len(errs) == 0 {
+		t.Errorf("removed intrinsic still supported")
+	}
+}
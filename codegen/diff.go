@@ -0,0 +1,156 @@
+package codegen
+
+import (
+	"fmt"
+	"strings"
+)
+
+// diffEdit is a line of a diff, kept (' '), removed ('-'), or added ('+').
+type diffEdit struct {
+	op   byte
+	line string
+}
+
+// UnifiedDiff returns the unified diff of the lines of old and new, named
+// oldName and newName in its header, with context unchanged lines around
+// each change, or "" if they're equal. It's for showing how a checked in
+// file differs from the file generated again, the lines are matched by
+// their longest common subsequence after the common prefix and suffix are
+// skipped, so it's quadratic only in the size of the changed region.
+func UnifiedDiff(oldName, newName, old, new string, context int) string {
+	if old == new {
+		return ""
+	}
+	edits := diffLines(splitLines(old), splitLines(new))
+	diff := fmt.Sprintf("--- %v\n+++ %v\n", oldName, newName)
+	for start := 0; start < len(edits); {
+		// the first change at or after start
+		first := start
+		for first < len(edits) && edits[first].op == ' ' {
+			first++
+		}
+		if first == len(edits) {
+			break
+		}
+		// the hunk extends while the changes are at most 2*context lines
+		// apart
+		last := first
+		for i := first + 1; i < len(edits); i++ {
+			if edits[i].op == ' ' {
+				continue
+			}
+			if i-last-1 > 2*context {
+				break
+			}
+			last = i
+		}
+		lo, hi := first-context, last+1+context
+		if lo < 0 {
+			lo = 0
+		}
+		if hi > len(edits) {
+			hi = len(edits)
+		}
+		diff += diffHunk(edits, lo, hi)
+		start = hi
+	}
+	return diff
+}
+
+// diffHunk returns the hunk of edits[lo:hi] with its line numbers.
+func diffHunk(edits []diffEdit, lo, hi int) string {
+	oldStart, newStart := 1, 1
+	for _, e := range edits[:lo] {
+		if e.op != '+' {
+			oldStart++
+		}
+		if e.op != '-' {
+			newStart++
+		}
+	}
+	oldLines, newLines := 0, 0
+	body := ""
+	for _, e := range edits[lo:hi] {
+		if e.op != '+' {
+			oldLines++
+		}
+		if e.op != '-' {
+			newLines++
+		}
+		body += string(e.op) + e.line
+		if !strings.HasSuffix(e.line, "\n") {
+			body += "\n\\ No newline at end of file\n"
+		}
+	}
+	// an empty range starts at the line before it
+	if oldLines == 0 {
+		oldStart--
+	}
+	if newLines == 0 {
+		newStart--
+	}
+	return fmt.Sprintf("@@ -%v,%v +%v,%v @@\n", oldStart, oldLines, newStart, newLines) + body
+}
+
+// splitLines splits s after each newline, the last line has no newline if s
+// doesn't end with one.
+func splitLines(s string) []string {
+	lines := strings.SplitAfter(s, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// diffLines returns the edits turning a into b.
+func diffLines(a, b []string) []diffEdit {
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+	edits := []diffEdit{}
+	for _, line := range a[:prefix] {
+		edits = append(edits, diffEdit{' ', line})
+	}
+	midA, midB := a[prefix:len(a)-suffix], b[prefix:len(b)-suffix]
+	// lcs[i][j] is the length of the longest common subsequence of midA[i:]
+	// and midB[j:]
+	lcs := make([][]int, len(midA)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(midB)+1)
+	}
+	for i := len(midA) - 1; i >= 0; i-- {
+		for j := len(midB) - 1; j >= 0; j-- {
+			if midA[i] == midB[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+	i, j := 0, 0
+	for i < len(midA) || j < len(midB) {
+		switch {
+		case i < len(midA) && j < len(midB) && midA[i] == midB[j]:
+			edits = append(edits, diffEdit{' ', midA[i]})
+			i++
+			j++
+		case j == len(midB) || i < len(midA) && lcs[i+1][j] >= lcs[i][j+1]:
+			edits = append(edits, diffEdit{'-', midA[i]})
+			i++
+		default:
+			edits = append(edits, diffEdit{'+', midB[j]})
+			j++
+		}
+	}
+	for _, line := range a[len(a)-suffix:] {
+		edits = append(edits, diffEdit{' ', line})
+	}
+	return edits
+}
@@ -0,0 +1,46 @@
+package codegen
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestUnifiedDiff(t *testing.T) {
+	lines := func(n int) []string {
+		l := []string{}
+		for i := 1; i <= n; i++ {
+			l = append(l, "MOVQ $"+strings.Repeat("1", i)+", AX\n")
+		}
+		return l
+	}
+	old := lines(20)
+	changed := append([]string{}, old...)
+	changed[1] = "MOVQ $2, AX\n"
+	changed[17] = "ADDQ $1, AX\n"
+	tests := []struct {
+		name     string
+		old, new string
+		expected string
+	}{
+		{"equal", "a\nb\n", "a\nb\n", ""},
+		{"two hunks", strings.Join(old, ""), strings.Join(changed, ""),
+			"--- old\n+++ new\n" +
+				"@@ -1,5 +1,5 @@\n " + old[0] + "-" + old[1] + "+" + changed[1] + " " + old[2] + " " + old[3] + " " + old[4] +
+				"@@ -15,6 +15,6 @@\n " + old[14] + " " + old[15] + " " + old[16] + "-" + old[17] + "+" + changed[17] + " " + old[18] + " " + old[19]},
+		{"joined hunk", "a\nb\nc\nd\ne\n", "a\nB\nc\nd\nE\n",
+			"--- old\n+++ new\n@@ -1,5 +1,5 @@\n a\n-b\n+B\n c\n d\n-e\n+E\n"},
+		{"added to empty", "", "a\nb\n",
+			"--- old\n+++ new\n@@ -0,0 +1,2 @@\n+a\n+b\n"},
+		{"removed all", "a\n", "",
+			"--- old\n+++ new\n@@ -1,1 +0,0 @@\n-a\n"},
+		{"no newline", "a\nb", "a\nb\n",
+			"--- old\n+++ new\n@@ -1,2 +1,2 @@\n a\n-b\n\\ No newline at end of file\n+b\n"},
+		{"inserted", "a\nc\n", "a\nb\nc\n",
+			"--- old\n+++ new\n@@ -1,2 +1,3 @@\n a\n+b\n c\n"},
+	}
+	for _, test := range tests {
+		if got := UnifiedDiff("old", "new", test.old, test.new, 3); got != test.expected {
+			t.Errorf("%v diff:\n%v\nexpected:\n%v", test.name, got, test.expected)
+		}
+	}
+}
@@ -0,0 +1,134 @@
+package codegen
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	"golang.org/x/tools/go/ssa"
+)
+
+// dumps returns whether any dumps are written.
+func (f *Function) dumps() bool {
+	return len(f.DumpAfter) > 0 || f.DumpSSA || f.DumpLiveness || f.DumpFrames || f.DumpPressure
+}
+
+// dumpWriter returns where dumps are written.
+func (f *Function) dumpWriter() io.Writer {
+	if f.DumpWriter != nil {
+		return f.DumpWriter
+	}
+	return os.Stdout
+}
+
+// dumpPass writes the dumps due after the pass name.
+func (f *Function) dumpPass(name string, a *Assembly) {
+	w := f.dumpWriter()
+	for _, after := range f.DumpAfter {
+		if after == name {
+			fmt.Fprintf(w, "// DUMP %v after %v\n%v", f.outfname(), name, a)
+		}
+	}
+	switch name {
+	case PassLower:
+		if f.DumpLiveness {
+			f.writeLiveness(w)
+		}
+		if f.DumpPressure {
+			f.writePressure(w)
+		}
+	case PassFrame:
+		if f.DumpFrames {
+			f.writeFrame(w, a)
+		}
+	}
+}
+
+// writeSSA writes the SSA of the function.
+func (f *Function) writeSSA(w io.Writer) {
+	fmt.Fprintf(w, "// DUMP %v ssa\n", f.outfname())
+	var buf bytes.Buffer
+	ssa.WriteFunction(&buf, f.ssa)
+	w.Write(buf.Bytes())
+}
+
+// writeLiveness writes the phi moves of each block edge, and the values
+// lowered with the blocks using them, values used in one block are kept in
+// registers and the others are spilled at block ends.
+func (f *Function) writeLiveness(w io.Writer) {
+	fmt.Fprintf(w, "// DUMP %v liveness\n", f.outfname())
+	for _, b := range f.ssa.Blocks {
+		succs := []int{}
+		for succ := range f.phiInfo[b.Index] {
+			succs = append(succs, succ)
+		}
+		sort.Ints(succs)
+		for _, succ := range succs {
+			for _, info := range f.phiInfo[b.Index][succ] {
+				fmt.Fprintf(w, "phi b%v -> b%v: %v = %v\n", b.Index, succ, info.phi.Name(), info.value.Name())
+			}
+		}
+	}
+	for _, ident := range f.sortedIdents() {
+		if ident.isConst() || ident.ssaValue() == nil {
+			continue
+		}
+		blocks := ""
+		for _, b := range getBlocks(ident) {
+			blocks += fmt.Sprintf(" b%v", b.Index)
+		}
+		local := "across blocks"
+		if ident.isBlockLocal() {
+			local = "block local"
+		}
+		fmt.Fprintf(w, "value %v: %v, used in%v\n", ident.name, local, blocks)
+	}
+}
+
+// writeFrame writes the FP or SP slot of each identifier and the register
+// allocation decisions.
+func (f *Function) writeFrame(w io.Writer, a *Assembly) {
+	fmt.Fprintf(w, "// DUMP %v frame $%v-%v\n", f.outfname(), a.FrameSize, a.ArgsSize)
+	for _, ident := range f.sortedIdents() {
+		if ident.isConst() {
+			continue
+		}
+		reg, offset, size := ident.Addr()
+		fmt.Fprintf(w, "slot %v: %v+%v(%v) size %v type %v\n", ident.name, ident.name, offset, reg.name, size, ident.typ)
+	}
+	for _, decision := range f.regDecisions {
+		fmt.Fprintf(w, "reg %v\n", decision)
+	}
+}
+
+// sortedIdents returns the identifiers, excluding ones replaced by another,
+// by name.
+func (f *Function) sortedIdents() []*identifier {
+	idents := []*identifier{}
+	for name, ident := range f.identifiers {
+		if name == ident.name {
+			idents = append(idents, ident)
+		}
+	}
+	sort.Slice(idents, func(i, j int) bool { return idents[i].name < idents[j].name })
+	return idents
+}
+
+// regDecision records a register assignment or spill at loc for
+// DumpFrames.
+func (f *Function) regDecision(loc ssa.Instruction, format string, args ...interface{}) {
+	if !f.DumpFrames {
+		return
+	}
+	decision := fmt.Sprintf(format, args...)
+	if loc != nil {
+		if v, ok := loc.(ssa.Value); ok {
+			decision += fmt.Sprintf(", at %v = %v", v.Name(), loc)
+		} else {
+			decision += fmt.Sprintf(", at %v", loc)
+		}
+	}
+	f.regDecisions = append(f.regDecisions, decision)
+}
@@ -0,0 +1,85 @@
+package codegen
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestDumps(t *testing.T) {
+	const src = "package src\n\nfunc max(x, y int) int {\n\tif x < y {\n\t\tx = y\n\t}\n\treturn x\n}\n"
+	opts := DefaultOptions()
+	opts.OptLevel = 0
+	f, err := CreateFunction(buildFunc(t, src, "max"), opts)
+	if err != nil {
+		t.Fatal(err.Err)
+	}
+	var buf bytes.Buffer
+	f.DumpWriter = &buf
+	f.DumpSSA = true
+	f.DumpLiveness = true
+	f.DumpFrames = true
+	f.DumpAfter = []string{PassZero}
+	if _, err := f.GoAssembly(); err != nil {
+		t.Fatal(err.Err)
+	}
+	dump := buf.String()
+	for _, expected := range []string{
+		"// DUMP max ssa\n", "func max(x int, y int) int:",
+		"// DUMP max after zero\n",
+		"// DUMP max liveness\n", "phi b1 -> b2: ", "phi b0 -> b2: ",
+		"// DUMP max frame $", "slot x: x+0(FP) size 8 type int\n", "slot y: y+8(FP) size 8 type int\n", "-> ",
+	} {
+		if !strings.Contains(dump, expected) {
+			t.Errorf("dump missing %q:\n%v", expected, dump)
+		}
+	}
+	if strings.Index(dump, "after zero") > strings.Index(dump, "liveness") {
+		t.Errorf("dumps out of pass order:\n%v", dump)
+	}
+}
+
+func TestDumpPressure(t *testing.T) {
+	const dot = "package src\n\nfunc dot(x, y []float32) float32 {\n\ts := float32(0)\n\tfor i := range x {\n\t\ts += x[i] * y[i]\n\t}\n\treturn s\n}\n"
+	// wide keeps 18 products live, more than the xmm registers
+	wide, sum := "package src\n\nfunc wide(x []float64) float64 {\n", "0"
+	for i := 0; i < 18; i++ {
+		wide += fmt.Sprintf("\tx%v := x[%v] * x[%v]\n", i, i, 17-i)
+		sum += fmt.Sprintf(" + x%v", i)
+	}
+	wide += "\treturn " + sum + "\n}\n"
+	for _, test := range []struct {
+		src, fn  string
+		expected []string
+	}{
+		{dot, "dot", []string{
+			// the accumulator s is kept in X0 and len(x) in BX
+			"// DUMP dot pressure, 10 integer and 14 xmm registers\n",
+			"b0 entry: 3 live (3 integer, 0 xmm) at t0 = len(x)\n",
+			"b1 rangeindex.loop: 6 live (5 integer, 1 xmm) at t3 = t2 + 1:int\n",
+			"b2 rangeindex.body: 8 live (5 integer, 3 xmm) at t8 = *t7\n",
+			"b3 rangeindex.done: 1 live (0 integer, 1 xmm) at return t1\n",
+		}},
+		{wide, "wide", []string{"b0 entry: 20 live (2 integer, 18 xmm) at ", ", spilled t"}},
+	} {
+		f, err := CreateFunction(buildFunc(t, test.src, test.fn), DefaultOptions())
+		if err != nil {
+			t.Fatal(err.Err)
+		}
+		var buf bytes.Buffer
+		f.DumpWriter = &buf
+		f.DumpPressure = true
+		if _, err := f.GoAssembly(); err != nil {
+			t.Fatal(err.Err)
+		}
+		for _, expected := range test.expected {
+			if !strings.Contains(buf.String(), expected) {
+				t.Errorf("%v pressure dump missing %q:\n%v", test.fn, expected, buf.String())
+			}
+		}
+		if test.fn == "dot" && strings.Contains(buf.String(), "spilled") {
+			t.Errorf("dot pressure dump has spills:\n%v", buf.String())
+		}
+	}
+}
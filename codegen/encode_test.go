@@ -0,0 +1,232 @@
+package codegen
+
+import (
+	"fmt"
+	"go/token"
+	"go/types"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// emitterCase is the assembly of an emitter function with some arguments.
+type emitterCase struct {
+	name string
+	asm  string
+}
+
+var (
+	intKinds   = []types.BasicKind{types.Int8, types.Int16, types.Int32, types.Int64, types.Uint8, types.Uint16, types.Uint32, types.Uint64}
+	floatKinds = []types.BasicKind{types.Float32, types.Float64}
+)
+
+// emitterCases returns the assembly of each emitter function for each
+// integer and float size, and the operand kinds it supports.
+func emitterCases() []emitterCase {
+	ctx := context{}
+	r8, r9, r10 := getRegister(REG_R8), getRegister(REG_R9), getRegister(REG_R10)
+	x0, x1, x2 := getRegister(REG_X0), getRegister(REG_X1), getRegister(REG_X2)
+	sp := getRegister(REG_SP)
+	cases := []emitterCase{}
+	add := func(name, asm string) {
+		cases = append(cases, emitterCase{name, asm})
+	}
+
+	arith := []token.Token{token.ADD, token.SUB, token.MUL, token.QUO, token.REM}
+	bitwise := []token.Token{token.AND, token.OR, token.XOR, token.SHL, token.SHR, token.AND_NOT}
+	cmps := []token.Token{token.EQL, token.NEQ, token.LSS, token.LEQ, token.GTR, token.GEQ}
+	for _, kind := range intKinds {
+		t := types.Typ[kind]
+		dt := GetOpDataType(t)
+		size := dt.size
+		add("MovRegReg "+t.Name(), MovRegReg(ctx, dt, r8, r9, false))
+		add("MovRegMem "+t.Name(), MovRegMem(ctx, dt, r8, "x", sp, -16))
+		add("MovMemReg "+t.Name(), MovMemReg(ctx, dt, "x", -16, sp, r8, false))
+		add("MovImmReg "+t.Name(), MovImmReg(ctx, -3, size, r8, false))
+		add("CMovCCRegReg "+t.Name(), CMovCCRegReg(ctx, r8, r9, size, false))
+		add("NotReg "+t.Name(), NotReg(ctx, r8, size, false))
+		add("CmpRegImm32 "+t.Name(), CmpRegImm32(ctx, r8, 7, size))
+		add("XorImm32Reg "+t.Name(), XorImm32Reg(ctx, 7, r8, size, false))
+		add("NegReg "+t.Name(), NegReg(ctx, r8, size, false))
+		for _, count := range []uint8{1, 3} {
+			add(fmt.Sprintf("ShiftImm8Reg %v left %v", t.Name(), count), ShiftImm8Reg(ctx, dt.signed, SHIFT_LEFT, count, r8))
+			add(fmt.Sprintf("ShiftImm8Reg %v right %v", t.Name(), count), ShiftImm8Reg(ctx, dt.signed, SHIFT_RIGHT, count, r8))
+		}
+		for _, op := range arith {
+			if op == token.REM && size == 1 {
+				// 8 bit remainders are in AH, ArithOp has no register for them
+				continue
+			}
+			add(fmt.Sprintf("ArithOp %v %v", t.Name(), op), ArithOp(ctx, dt, op, r8, r9, r10))
+		}
+		for _, op := range bitwise {
+			add(fmt.Sprintf("BitwiseOp %v %v", t.Name(), op), BitwiseOp(ctx, op, dt.signed, r8, r9, r10, getRegister(REG_R11), size, size))
+		}
+		for _, countSize := range []uint{1, 2, 8} {
+			add(fmt.Sprintf("ShiftRegReg %v count size %v", t.Name(), countSize), ShiftRegReg(ctx, dt.signed, SHIFT_RIGHT, r8, r9, getRegister(REG_R11), size, countSize, false))
+			if size == 4 || size == 8 {
+				for _, direction := range []int{SHIFT_LEFT, SHIFT_RIGHT} {
+					add(fmt.Sprintf("ShiftXRegReg %v %v count size %v", t.Name(), direction, countSize), ShiftXRegReg(ctx, dt.signed, direction, r8, r9, r10, getRegister(REG_R11), size, countSize))
+				}
+			}
+		}
+		for _, op := range cmps {
+			add(fmt.Sprintf("CmpOp %v %v", t.Name(), op), CmpOp(ctx, dt, op, r8, r9, r10))
+			add(fmt.Sprintf("CMovOpRegReg %v %v", t.Name(), op), CMovOpRegReg(ctx, dt, op, r8, r9, size))
+		}
+		for _, to := range append(intKinds, floatKinds...) {
+			totype := GetOpDataType(types.Typ[to])
+			dst := r9
+			if isFloatOp(totype) {
+				dst = x0
+			}
+			add(fmt.Sprintf("ConvertOp %v to %v", t.Name(), types.Typ[to].Name()), ConvertOp(ctx, r8, dt, dst, totype, r10))
+		}
+	}
+	for _, size := range []uint{4, 8} {
+		r11 := getRegister(REG_R11)
+		add(fmt.Sprintf("AtomicAddRegMem %v", size), AtomicAddRegMem(ctx, size, r8, r9, r10))
+		add(fmt.Sprintf("AtomicStoreRegMem %v", size), AtomicStoreRegMem(ctx, size, r8, r9, r10))
+		add(fmt.Sprintf("AtomicCasRegMem %v", size), AtomicCasRegMem(ctx, size, r8, r10, r9, r11))
+	}
+	for _, kind := range floatKinds {
+		t := types.Typ[kind]
+		dt := GetOpDataType(t)
+		add("MovRegReg "+t.Name(), MovRegReg(ctx, dt, x0, x1, false))
+		add("MovRegMem "+t.Name(), MovRegMem(ctx, dt, x0, "x", sp, -16))
+		add("MovMemReg "+t.Name(), MovMemReg(ctx, dt, "x", -16, sp, x0, false))
+		add("MovImmFloatReg "+t.Name(), MovImmFloatReg(ctx, 1.5, kind == types.Float32, r8, x0, false))
+		for _, c := range []float64{0, 1.5, -0.1, 1e+30, 3e-40} {
+			add(fmt.Sprintf("MovFloatConstReg %v %v", t.Name(), c), MovFloatConstReg(ctx, c, kind == types.Float32, x0, false))
+		}
+		for _, op := range arith[:4] {
+			add(fmt.Sprintf("ArithOp %v %v", t.Name(), op), ArithOp(ctx, dt, op, x0, x1, x2))
+		}
+		for _, op := range cmps {
+			add(fmt.Sprintf("CmpOp %v %v", t.Name(), op), CmpOp(ctx, dt, op, x0, x1, r10))
+			for _, negate := range []bool{false, true} {
+				jmp := FloatCmpRegReg(ctx, dt, op, x0, x1) + CmpJmp(ctx, dt, op, negate, "target") + "target:\n"
+				add(fmt.Sprintf("CmpJmp %v %v negate %v", t.Name(), op, negate), jmp)
+			}
+		}
+		add("FloatToUint64 "+t.Name(), FloatToUint64(ctx, x0, r8, dt, r9, x1))
+		add("FloatMaxMin max "+t.Name(), FloatMaxMin(ctx, dt, true, x0, x1))
+		add("FloatMaxMin min "+t.Name(), FloatMaxMin(ctx, dt, false, x0, x1))
+		for _, op := range cmps[:4] {
+			add(fmt.Sprintf("FloatCmpMask %v %v", t.Name(), op), FloatCmpMask(ctx, dt, op, false, x0, x1))
+			add(fmt.Sprintf("FloatCmpMask %v !%v", t.Name(), op), FloatCmpMask(ctx, dt, op, true, x0, x1))
+		}
+		add("MovSignMaskReg sign "+t.Name(), MovSignMaskReg(ctx, dt, false, r8, x0))
+		add("MovSignMaskReg abs "+t.Name(), MovSignMaskReg(ctx, dt, true, r8, x0))
+		for _, to := range append(intKinds, floatKinds...) {
+			if to == kind {
+				// ssa has no conversions to the same type
+				continue
+			}
+			totype := GetOpDataType(types.Typ[to])
+			dst := r9
+			if isFloatOp(totype) {
+				dst = x1
+			}
+			add(fmt.Sprintf("ConvertOp %v to %v", t.Name(), types.Typ[to].Name()), ConvertOp(ctx, x0, dt, dst, totype, r10))
+		}
+	}
+	for _, size := range []uint{1, 8, 24, 40, 256, 264} {
+		add(fmt.Sprintf("ZeroMemory %v", size), ZeroMemory(ctx, "x", -512, size, sp))
+	}
+	sizeCtx := context{f: &Function{opts: Options{OptFor: OptSize}}}
+	for _, size := range []uint{24, 32, 40} {
+		add(fmt.Sprintf("ZeroMemory size %v", size), ZeroMemory(sizeCtx, "x", -512, size, sp))
+	}
+	add("MovMemMemRep", MovMemMemRep(ctx, "", 0, r8, "x", -512, sp, 64))
+	add("ZeroReg", ZeroReg(ctx, r8)+ZeroReg(ctx, x0))
+	add("ZeroReg size", ZeroReg(sizeCtx, r8)+ZeroReg(sizeCtx, getRegister(REG_BX)))
+	for _, imm := range []int64{1, -1} {
+		for _, op := range []token.Token{token.ADD, token.SUB} {
+			for _, kind := range intKinds[:4] {
+				dt := GetOpDataType(types.Typ[kind])
+				add(fmt.Sprintf("ImmOp size %v %v %v", op, imm, kind), ImmOp(sizeCtx, dt, op, r8, imm, r9))
+			}
+		}
+	}
+	add("Lea", Lea(ctx, "x", -16, sp, r8, false))
+	for _, scale := range []uint{1, 2, 4, 8} {
+		add(fmt.Sprintf("LeaScaled %v", scale), LeaScaled(ctx, r8, r9, scale, r10, false))
+	}
+	add("AddImm32Reg", AddImm32Reg(ctx, 16, r8, false))
+	add("SubImm32Reg", SubImm32Reg(ctx, 16, r8, false))
+	add("MulImm32RegReg", MulImm32RegReg(ctx, 24, r8, r9, false))
+	add("XorImm64Reg", XorImm64Reg(ctx, -1, r8, 8, false))
+	for _, size := range []uint{4, 8} {
+		for _, movbe := range []bool{false, true} {
+			for _, swap := range []bool{false, true} {
+				name := fmt.Sprintf("%v swap %v movbe %v", size, swap, movbe)
+				add("MovByteOrderMemReg "+name, MovByteOrderMemReg(ctx, size, swap, movbe, r8, r9))
+				add("MovByteOrderRegMem "+name, MovByteOrderRegMem(ctx, size, swap, movbe, r8, r9, r10))
+			}
+		}
+	}
+	for _, op := range []token.Token{token.AND, token.OR, token.XOR, token.AND_NOT} {
+		add(fmt.Sprintf("FloatBitwiseOp %v", op), FloatBitwiseOp(ctx, op, x0, x1))
+	}
+	add("JumpTableIndex", JumpTableIndex(ctx, "table", r8, r9))
+	add("JumpTableStubs", JumpTableStubs(ctx, "·helper(SB)", r8, []string{"stub0", "stub1"})+"stub0:\nstub1:\n")
+	return cases
+}
+
+// asmLine matches the file and line of a Go assembler error.
+var asmLine = regexp.MustCompile(`\.s:([0-9]+)`)
+
+// TestEmitterAssembles checks the Go assembler accepts the assembly of every
+// emitter function, each case is a function of its own.
+func TestEmitterAssembles(t *testing.T) {
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("go command not found")
+	}
+	cases := emitterCases()
+	asm := "#include \"textflag.h\"\n\n"
+	// the case of each line of asm
+	lineCase := []string{"", "", ""}
+	for i, c := range cases {
+		fn := fmt.Sprintf("TEXT ·case%v(SB),NOSPLIT,$512-0\n", i) + c.asm + Ret()
+		asm += fn
+		for range strings.Split(strings.TrimSuffix(fn, "\n"), "\n") {
+			lineCase = append(lineCase, c.name)
+		}
+	}
+	dir, err := ioutil.TempDir("", "encode")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	sfile := filepath.Join(dir, "emitter_amd64.s")
+	if err := ioutil.WriteFile(sfile, []byte(asm), 0644); err != nil {
+		t.Fatal(err)
+	}
+	goroot, err := exec.Command("go", "env", "GOROOT").Output()
+	if err != nil {
+		t.Fatal(err)
+	}
+	include := filepath.Join(strings.TrimSpace(string(goroot)), "pkg", "include")
+	cmd := exec.Command("go", "tool", "asm", "-p", "emitter", "-I", include, "-o", filepath.Join(dir, "emitter.o"), sfile)
+	cmd.Env = append(os.Environ(), "GOARCH=amd64")
+	out, err := cmd.CombinedOutput()
+	if err == nil {
+		return
+	}
+	for _, line := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+		name := ""
+		if m := asmLine.FindStringSubmatch(line); m != nil {
+			if n, _ := strconv.Atoi(m[1]); n < len(lineCase) {
+				name = lineCase[n]
+			}
+		}
+		t.Errorf("%v: %v", name, line)
+	}
+	t.Fatalf("assembling the emitter cases failed, %v", err)
+}
@@ -0,0 +1,109 @@
+package codegen
+
+import (
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/ssa"
+)
+
+// stackAlloc returns whether alloc is in the frame, either a local or a
+// scratch array, e.g. "var tmp [64]byte", go/ssa allocates on the heap
+// because it's sliced but whose address and slices don't outlive the
+// function. They're only indexed, loaded, stored to, sliced again, passed
+// to len or to simd and sse2 intrinsics, which don't keep their arguments.
+func stackAlloc(alloc *ssa.Alloc) bool {
+	if !alloc.Heap {
+		return true
+	}
+	if _, ok := alloc.Type().Underlying().(*types.Pointer).Elem().Underlying().(*types.Array); !ok {
+		return false
+	}
+	return addrStays(alloc)
+}
+
+// stackAllocs returns the allocations of fn in its frame, its locals then
+// the scratch arrays in block and instruction order.
+func stackAllocs(fn *ssa.Function) []*ssa.Alloc {
+	allocs := append([]*ssa.Alloc{}, fn.Locals...)
+	for _, block := range fn.Blocks {
+		for _, instr := range block.Instrs {
+			if alloc, ok := instr.(*ssa.Alloc); ok && alloc.Heap && stackAlloc(alloc) {
+				allocs = append(allocs, alloc)
+			}
+		}
+	}
+	return allocs
+}
+
+// addrStays returns whether the address addr, of an array or an element of
+// one, is only loaded from, stored to, indexed, or sliced.
+func addrStays(addr ssa.Value) bool {
+	for _, ref := range *addr.Referrers() {
+		switch ref := ref.(type) {
+		case *ssa.DebugRef:
+		case *ssa.UnOp:
+			if ref.Op != token.MUL {
+				return false
+			}
+		case *ssa.Store:
+			if ref.Val == addr {
+				return false
+			}
+		case *ssa.IndexAddr:
+			if !addrStays(ref) {
+				return false
+			}
+		case *ssa.Slice:
+			if !sliceStays(ref) {
+				return false
+			}
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// sliceStays returns whether the slice s of a scratch array is only
+// indexed, sliced again, or passed to len or to simd and sse2 intrinsics.
+func sliceStays(s *ssa.Slice) bool {
+	for _, ref := range *s.Referrers() {
+		switch ref := ref.(type) {
+		case *ssa.DebugRef:
+		case *ssa.IndexAddr:
+			if !addrStays(ref) {
+				return false
+			}
+		case *ssa.Slice:
+			if !sliceStays(ref) {
+				return false
+			}
+		case *ssa.Call:
+			if ref.Common().Value == s {
+				return false
+			}
+			if builtin, ok := ref.Common().Value.(*ssa.Builtin); ok && builtin.Name() == "len" {
+				continue
+			}
+			if _, ok := isSSE2Intrinsic(ref); !ok && !isSimdIntrinsic(ref) {
+				return false
+			}
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// slicesStack returns whether s slices a scratch array in the frame, or a
+// slice of one.
+func slicesStack(s *ssa.Slice) bool {
+	switch x := s.X.(type) {
+	case *ssa.Alloc:
+		return stackAlloc(x)
+	case *ssa.Slice:
+		return slicesStack(x)
+	}
+	return false
+}
@@ -0,0 +1,110 @@
+package codegen
+
+import (
+	"fmt"
+	"go/token"
+	"io/ioutil"
+	"strconv"
+	"strings"
+
+	"golang.org/x/tools/go/ssa"
+)
+
+// sourceLinePrefix starts the comments marking the assembly of an ssa
+// instruction with its source position, with Explain.
+const sourceLinePrefix = "// LINE "
+
+// sourceLineMarker returns the comment marking the assembly of instr with
+// its source position, empty if it has none.
+func (f *Function) sourceLineMarker(instr ssa.Instruction) string {
+	pos := f.Position(instr.Pos())
+	if !pos.IsValid() {
+		return ""
+	}
+	return sourceLinePrefix + positionLine(pos) + "\n"
+}
+
+// Explain returns the assembly of f interleaved with the Go source lines it's
+// generated from, like go tool objdump -S. Each source line is a comment
+// with its position before the assembly of its ssa instructions, assembly
+// without a position, e.g. the prologue, follows the function's TEXT line
+// or the previous source line. Explain generates f again instead of using
+// Cache, and doesn't support Options.Variants.
+func (f *Function) Explain() (string, *Error) {
+	if len(f.opts.Variants) > 0 {
+		return "", ErrorMsg2(fmt.Sprintf("explaining %v unsupported, it has variants", f.outfname()))
+	}
+	f.sourceLines = true
+	defer func() { f.sourceLines = false }()
+	f.spills = 0
+	asm, err := f.Func()
+	if err != nil {
+		return "", err
+	}
+	f.asm = asm
+	return f.interleaveSource(asm), nil
+}
+
+// interleaveSource replaces the source position markers of asm with the
+// source lines, each once per run of assembly, and strips the comments above
+// the comment level.
+func (f *Function) interleaveSource(asm string) string {
+	indent := f.opts.Indent
+	marker := indent + sourceLinePrefix
+	sources := map[string][]string{}
+	listing, chunk, last, source := "", "", "", ""
+	// flush adds the source line and the assembly after it, a source line
+	// without assembly left after stripping the comments is dropped
+	flush := func() {
+		if stripped := stripComments(strings.TrimSuffix(chunk, "\n"), indent, f.opts.CommentLevel); stripped != "" {
+			listing += source + stripped + "\n"
+			source = ""
+		}
+		chunk = ""
+	}
+	for _, line := range strings.SplitAfter(asm, "\n") {
+		if !strings.HasPrefix(line, marker) {
+			chunk += line
+			continue
+		}
+		position := strings.TrimSpace(strings.TrimPrefix(line, marker))
+		if position == last {
+			continue
+		}
+		flush()
+		last = position
+		source = indent + "// " + position + f.sourceText(sources, position) + "\n"
+	}
+	flush()
+	return listing
+}
+
+// sourceText returns ": " and the trimmed source line at position,
+// file:line, empty if the file can't be read. sources caches the lines of
+// the files read.
+func (f *Function) sourceText(sources map[string][]string, position string) string {
+	i := strings.LastIndex(position, ":")
+	filename := position[:i]
+	line, _ := strconv.Atoi(position[i+1:])
+	lines, ok := sources[filename]
+	if !ok {
+		if data, err := ioutil.ReadFile(filename); err == nil {
+			lines = strings.Split(string(data), "\n")
+		}
+		sources[filename] = lines
+	}
+	if line < 1 || line > len(lines) {
+		return ""
+	}
+	text := strings.TrimSpace(lines[line-1])
+	if text == "" {
+		return ""
+	}
+	return ": " + text
+}
+
+// positionLine returns the file:line form of pos used by the source position
+// markers.
+func positionLine(pos token.Position) string {
+	return pos.Filename + ":" + strconv.Itoa(pos.Line)
+}
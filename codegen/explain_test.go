@@ -0,0 +1,52 @@
+package codegen
+
+import (
+	"strings"
+	"testing"
+)
+
+// instructions returns the lines of asm that aren't comments.
+func instructions(asm string) []string {
+	lines := []string{}
+	for _, line := range strings.Split(asm, "\n") {
+		if !strings.HasPrefix(strings.TrimSpace(line), "//") {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+func TestExplain(t *testing.T) {
+	const src = "package src\n\nfunc sum(x []int32) int32 {\n\ts := int32(0)\n\tfor i := 0; i < len(x); i++ {\n\t\ts += x[i]\n\t}\n\treturn s\n}\n"
+	for _, level := range []CommentLevel{CommentNone, CommentBlocks, CommentInstructions} {
+		opts := DefaultOptions()
+		opts.CommentLevel = level
+		f, err := CreateFunction(buildFunc(t, src, "sum"), opts)
+		if err != nil {
+			t.Fatal(err.Err)
+		}
+		listing, err := f.Explain()
+		if err != nil {
+			t.Fatal(err.Err)
+		}
+		for _, line := range []string{"// src.go:5", "// src.go:6", "// src.go:8"} {
+			if !strings.Contains(listing, line) {
+				t.Errorf("%q not in the listing at comment level %v:\n%v", line, level, listing)
+			}
+		}
+		if strings.Contains(listing, sourceLinePrefix) {
+			t.Errorf("source position marker in the listing:\n%v", listing)
+		}
+		f, err = CreateFunction(buildFunc(t, src, "sum"), opts)
+		if err != nil {
+			t.Fatal(err.Err)
+		}
+		asm, err := f.GoAssembly()
+		if err != nil {
+			t.Fatal(err.Err)
+		}
+		if got, expected := strings.Join(instructions(listing), "\n"), strings.Join(instructions(asm), "\n"); got != expected {
+			t.Errorf("listing instructions at comment level %v:\n%v\nexpected:\n%v", level, got, expected)
+		}
+	}
+}
@@ -0,0 +1,136 @@
+package codegen
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// maxDataAlign is the largest alignment the linker gives a data symbol,
+// symbols are aligned to their size rounded down to a power of two up to it.
+const maxDataAlign = 32
+
+// File is a Go assembly file of generated functions and the static data
+// they reference, e.g. shuffle masks and lookup tables.
+type File struct {
+	buildLines string
+	funcs      []string
+	data       []dataSym
+}
+
+type dataSym struct {
+	name  string
+	bytes []byte
+}
+
+// NewFile returns an empty assembly file, buildLines are the build
+// constraint lines from BuildConstraint.
+func NewFile(buildLines string) *File {
+	return &File{buildLines: buildLines}
+}
+
+// AddFunc adds the assembly of a function, from GoAssembly or Compile.
+func (file *File) AddFunc(asm string) {
+	file.funcs = append(file.funcs, asm)
+}
+
+// AddData adds read only data aligned to align bytes, it's referenced in
+// assembly as DataRef(name). The data is zero padded to a multiple of align
+// since the linker aligns symbols by size, align must be a power of two up
+// to 32.
+func (file *File) AddData(name string, bytes []byte, align int) *Error {
+	if !isDataName(name) {
+		return ErrorMsg2(fmt.Sprintf("Invalid data name \"%v\"", name))
+	}
+	for _, sym := range file.data {
+		if sym.name == name {
+			return ErrorMsg2(fmt.Sprintf("Data \"%v\" already added", name))
+		}
+	}
+	if align < 1 || align > maxDataAlign || align&(align-1) != 0 {
+		msg := "Invalid alignment (%v) of data \"%v\", expected a power of two up to %v"
+		return ErrorMsg2(fmt.Sprintf(msg, align, name, maxDataAlign))
+	}
+	if len(bytes) == 0 {
+		return ErrorMsg2(fmt.Sprintf("Empty data \"%v\"", name))
+	}
+	padded := make([]byte, (len(bytes)+align-1)/align*align)
+	copy(padded, bytes)
+	file.data = append(file.data, dataSym{name: name, bytes: padded})
+	return nil
+}
+
+// DataRef returns the assembly operand of the data name added by AddData,
+// e.g. "MOVOU " + DataRef("mask") + ", X0".
+func DataRef(name string) string {
+	return name + "<>(SB)"
+}
+
+// isDataName returns true if name is a valid Go identifier.
+func isDataName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for i, c := range name {
+		letter := c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+		digit := c >= '0' && c <= '9'
+		if !letter && !(digit && i > 0) {
+			return false
+		}
+	}
+	return true
+}
+
+// dataAsm generates the DATA and GLOBL records of sym, in 8 byte chunks
+// followed by 4, 2, and 1 byte chunks for the remainder.
+func (sym dataSym) dataAsm() string {
+	asm := ""
+	offset := 0
+	for _, chunk := range []int{8, 4, 2, 1} {
+		for ; len(sym.bytes)-offset >= chunk; offset += chunk {
+			value := uint64(0)
+			for i := chunk - 1; i >= 0; i-- {
+				value = value<<8 | uint64(sym.bytes[offset+i])
+			}
+			asm += fmt.Sprintf("DATA %v<>+%v(SB)/%v, $0x%0*x\n", sym.name, offset, chunk, 2*chunk, value)
+		}
+	}
+	asm += fmt.Sprintf("GLOBL %v, RODATA|NOPTR, $%v\n", DataRef(sym.name), len(sym.bytes))
+	return asm
+}
+
+// constTable returns the name of a read only data symbol of f holding
+// bytes, identical tables share a symbol.
+func (f *Function) constTable(bytes []byte) string {
+	for _, sym := range f.constTables {
+		if string(sym.bytes) == string(bytes) {
+			return sym.name
+		}
+	}
+	name := f.outfname() + "_const" + strconv.Itoa(len(f.constTables))
+	f.constTables = append(f.constTables, dataSym{name: name, bytes: bytes})
+	return name
+}
+
+// constTablesAsm returns the data of the constant tables, nothing if f has
+// none.
+func (f *Function) constTablesAsm() string {
+	if len(f.constTables) == 0 {
+		return ""
+	}
+	asm := ""
+	for _, sym := range f.constTables {
+		asm += "\n" + sym.dataAsm()
+	}
+	return asm + "\n"
+}
+
+// String returns the assembly file, the preamble followed by the data and
+// the functions.
+func (file *File) String() string {
+	asm := AssemblyFilePreamble(file.buildLines)
+	for _, sym := range file.data {
+		asm += sym.dataAsm() + "\n"
+	}
+	return asm + strings.Join(file.funcs, "")
+}
@@ -0,0 +1,67 @@
+package codegen
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestAddData checks the DATA and GLOBL lines of the data added to a file,
+// padded to a multiple of the alignment, and the errors of invalid data.
+func TestAddData(t *testing.T) {
+	file := NewFile("")
+	if err := file.AddData("mask", []byte{1, 2, 3, 4, 5}, 8); err != nil {
+		t.Fatal(err.Err)
+	}
+	if err := file.AddData("odd", []byte{0xaa, 0xbb, 0xcc}, 1); err != nil {
+		t.Fatal(err.Err)
+	}
+	table := make([]byte, 17)
+	for i := range table {
+		table[i] = byte(i + 1)
+	}
+	if err := file.AddData("table", table, 16); err != nil {
+		t.Fatal(err.Err)
+	}
+	file.AddFunc("TEXT ·f(SB),$0\n        RET\n")
+	expected := `DATA mask<>+0(SB)/8, $0x0000000504030201
+GLOBL mask<>(SB), RODATA|NOPTR, $8
+
+DATA odd<>+0(SB)/2, $0xbbaa
+DATA odd<>+2(SB)/1, $0xcc
+GLOBL odd<>(SB), RODATA|NOPTR, $3
+
+DATA table<>+0(SB)/8, $0x0807060504030201
+DATA table<>+8(SB)/8, $0x100f0e0d0c0b0a09
+DATA table<>+16(SB)/8, $0x0000000000000011
+DATA table<>+24(SB)/8, $0x0000000000000000
+GLOBL table<>(SB), RODATA|NOPTR, $32
+
+TEXT ·f(SB),$0
+        RET
+`
+	asm := file.String()
+	if !strings.HasSuffix(asm, expected) {
+		t.Errorf("File.String() =\n%v\nexpected it to end with\n%v", asm, expected)
+	}
+	if ref := DataRef("mask"); ref != "mask<>(SB)" {
+		t.Errorf("DataRef(mask) = %v, expected mask<>(SB)", ref)
+	}
+
+	for _, test := range []struct {
+		name  string
+		bytes []byte
+		align int
+		err   string
+	}{
+		{"mask", []byte{1}, 1, "Data \"mask\" already added"},
+		{"1mask", []byte{1}, 1, "Invalid data name \"1mask\""},
+		{"bad", []byte{1}, 3, "Invalid alignment (3)"},
+		{"bad", []byte{1}, 64, "Invalid alignment (64)"},
+		{"bad", []byte{1}, 0, "Invalid alignment (0)"},
+		{"bad", nil, 1, "Empty data \"bad\""},
+	} {
+		if err := file.AddData(test.name, test.bytes, test.align); err == nil || !strings.Contains(err.Err.Error(), test.err) {
+			t.Errorf("AddData(%v, %v, %v) error %v, expected %q", test.name, test.bytes, test.align, err, test.err)
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package codegen
+
+import (
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"strings"
+	"testing"
+
+	"golang.org/x/tools/go/ssa"
+	"golang.org/x/tools/go/ssa/ssautil"
+)
+
+func TestGoGeneric(t *testing.T) {
+	// simd is only used in the signature, the source importer type checks
+	// it without an installed simd package
+	const src = "package src\n\nimport \"github.com/bjwbell/gensimd/simd\"\n\n" +
+		"func first(x simd.I32x4) int32 {\n\treturn 1\n}\n"
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "src.go", src, parser.ParseComments)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pkg := types.NewPackage("src", "src")
+	conf := &types.Config{Importer: importer.ForCompiler(fset, "source", nil), Sizes: DefaultSizes()}
+	ssapkg, _, err := ssautil.BuildPackage(conf, fset, pkg, []*ast.File{file}, ssa.SanityCheckFunctions)
+	if err != nil {
+		t.Fatal(err)
+	}
+	opts := DefaultOptions()
+	opts.OutName = "firstGeneric"
+	f, cerr := CreateFunction(ssapkg.Func("first"), opts)
+	if cerr != nil {
+		t.Fatal(cerr.Err)
+	}
+	pkgname, imports, generic, cerr := f.GoGeneric()
+	if cerr != nil {
+		t.Fatal(cerr.Err)
+	}
+	if pkgname != "package src\n" {
+		t.Errorf("package clause %q, expected %q", pkgname, "package src\n")
+	}
+	if expected := "import \"github.com/bjwbell/gensimd/simd\"\n"; imports != expected {
+		t.Errorf("imports %q, expected %q", imports, expected)
+	}
+	if !strings.HasPrefix(generic, "func firstGeneric(x simd.I32x4) int32 {") {
+		t.Errorf("generic copy:\n%v", generic)
+	}
+}
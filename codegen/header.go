@@ -0,0 +1,82 @@
+package codegen
+
+import "strings"
+
+// generatedLine is the first line of the header, marking the file as
+// generated for tools following the Go convention.
+const generatedLine = "// Code generated by gensimd. DO NOT EDIT."
+
+// the prefixes of the header's fields
+const (
+	headerVersion  = "// gensimd version: "
+	headerCommand  = "// gensimd command: "
+	headerSource   = "// gensimd source: "
+	headerTarget   = "// gensimd target: "
+	headerVariants = "// gensimd variants: "
+)
+
+// Header is the provenance of a generated file, written as line comments at
+// the top of the assembly and Go files so they can be audited and checked
+// against their source, e.g. by gensimd -verify:
+//
+//	// Code generated by gensimd. DO NOT EDIT.
+//	// gensimd version: v0.1.0 (1a2b3c4d5e6f)
+//	// gensimd command: gensimd -f sum.go -fn sum -o sum_amd64.s
+//	// gensimd source: sum.go sha256:9f86d081884c7d65...
+//	// gensimd target: avx2
+type Header struct {
+	Version    string   // the gensimd version, a module version and VCS revision
+	Command    string   // the command line generating the file
+	Source     string   // the input file, or the -pkg package directory
+	SourceHash string   // "sha256:" and the hex SHA-256 of the source
+	Target     string   // the -target feature level
+	Variants   []string // the -variants feature levels, if any
+}
+
+// String returns the header's comment lines followed by a blank line,
+// separating it from the build constraint.
+func (h Header) String() string {
+	s := generatedLine + "\n"
+	s += headerVersion + h.Version + "\n"
+	s += headerCommand + h.Command + "\n"
+	s += headerSource + h.Source + " " + h.SourceHash + "\n"
+	s += headerTarget + h.Target + "\n"
+	if len(h.Variants) > 0 {
+		s += headerVariants + strings.Join(h.Variants, ",") + "\n"
+	}
+	return s + "\n"
+}
+
+// ParseHeader returns the header at the top of the generated file src,
+// false if src doesn't start with one.
+func ParseHeader(src string) (Header, bool) {
+	lines := strings.Split(src, "\n")
+	if len(lines) == 0 || strings.TrimSpace(lines[0]) != generatedLine {
+		return Header{}, false
+	}
+	h := Header{}
+	for _, line := range lines[1:] {
+		line = strings.TrimRight(line, "\r")
+		switch {
+		case strings.HasPrefix(line, headerVersion):
+			h.Version = strings.TrimPrefix(line, headerVersion)
+		case strings.HasPrefix(line, headerCommand):
+			h.Command = strings.TrimPrefix(line, headerCommand)
+		case strings.HasPrefix(line, headerSource):
+			// the source path may have spaces, the hash doesn't
+			source := strings.TrimPrefix(line, headerSource)
+			if i := strings.LastIndex(source, " "); i >= 0 {
+				h.Source, h.SourceHash = source[:i], source[i+1:]
+			} else {
+				h.Source = source
+			}
+		case strings.HasPrefix(line, headerTarget):
+			h.Target = strings.TrimPrefix(line, headerTarget)
+		case strings.HasPrefix(line, headerVariants):
+			h.Variants = strings.Split(strings.TrimPrefix(line, headerVariants), ",")
+		default:
+			return h, true
+		}
+	}
+	return h, true
+}
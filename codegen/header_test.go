@@ -0,0 +1,32 @@
+package codegen
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestHeader(t *testing.T) {
+	h := Header{
+		Version:    "v0.1.0 (1a2b3c4d5e6f)",
+		Command:    `gensimd -f sum.go -fn "sum, dot" -o sum_amd64.s`,
+		Source:     "my kernels/sum.go",
+		SourceHash: "sha256:9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08",
+		Target:     TargetAVX2,
+		Variants:   []string{TargetSSE2, TargetAVX2},
+	}
+	src := h.String() + "//go:build amd64\n\n#include \"textflag.h\"\n"
+	got, ok := ParseHeader(src)
+	if !ok {
+		t.Fatalf("ParseHeader didn't find the header of:\n%v", src)
+	}
+	if !reflect.DeepEqual(got, h) {
+		t.Errorf("ParseHeader = %+v, expected %+v", got, h)
+	}
+	if _, ok := ParseHeader("//go:build amd64\n\npackage sum\n"); ok {
+		t.Errorf("ParseHeader found a header in a file without one")
+	}
+	h.Variants = nil
+	if got, _ := ParseHeader(h.String()); !reflect.DeepEqual(got, h) {
+		t.Errorf("ParseHeader without variants = %+v, expected %+v", got, h)
+	}
+}
@@ -0,0 +1,227 @@
+package codegen
+
+import (
+	"fmt"
+	"go/token"
+	"sort"
+	"strings"
+
+	"golang.org/x/tools/go/ssa"
+)
+
+// maxInvariants is the most loop invariant values of each register type kept
+// in registers, after the accumulators, see maxReductions.
+const maxInvariants = 2
+
+// hoistLoop is a natural loop with one entry edge, a jump from entry to the
+// header, the instructions hoisted out of it are computed on.
+type hoistLoop struct {
+	naturalLoop
+	entry *ssa.BasicBlock
+	// depth is the number of loops containing the header, 1 for an outer loop
+	depth int64
+}
+
+// hoistLoops returns the loops of the function entered by a jump.
+func (f *Function) hoistLoops() []*hoistLoop {
+	loops := []*hoistLoop{}
+	depths := loopDepths(f.ssa)
+	for _, loop := range naturalLoops(f.ssa) {
+		header := loop.header
+		if len(header.Preds) != 2 {
+			continue
+		}
+		entry := header.Preds[0]
+		if entry == loop.latch {
+			entry = header.Preds[1]
+		}
+		if header.Dominates(entry) {
+			continue
+		}
+		if _, ok := entry.Instrs[len(entry.Instrs)-1].(*ssa.Jump); !ok {
+			continue
+		}
+		loops = append(loops, &hoistLoop{loop, entry, depths[header.Index]})
+	}
+	return loops
+}
+
+// computeHoists moves the loop invariant lengths, splats, and integer
+// arithmetic of loops to the edge entering the loop, so they're computed once
+// instead of every iteration, and keeps the invariant values read in the
+// loops in registers.
+func (f *Function) computeHoists() {
+	f.hoisted = make(map[ssa.Instruction]*ssa.BasicBlock)
+	f.hoists = make(map[int]map[int][]ssa.Instruction)
+	if !f.Optimize {
+		return
+	}
+	loops := f.hoistLoops()
+	// outer loops first, an instruction invariant in a loop nest leaves all of it
+	sort.SliceStable(loops, func(i, j int) bool { return loops[i].depth < loops[j].depth })
+	for _, l := range loops {
+		for _, block := range f.ssa.DomPreorder() {
+			if !l.inLoop[block.Index] || f.selectArms[block] {
+				continue
+			}
+			for _, instr := range block.Instrs {
+				if f.hoisted[instr] != nil || !f.hoistable(instr, l) {
+					continue
+				}
+				f.hoisted[instr] = l.entry
+				if f.hoists[l.entry.Index] == nil {
+					f.hoists[l.entry.Index] = make(map[int][]ssa.Instruction)
+				}
+				f.hoists[l.entry.Index][l.header.Index] = append(f.hoists[l.entry.Index][l.header.Index], instr)
+			}
+		}
+	}
+	// inner loops first, they run the most iterations
+	sort.SliceStable(loops, func(i, j int) bool { return loops[i].depth > loops[j].depth })
+	f.pinInvariants(loops)
+}
+
+// hoistable returns whether instr of loop l computes a loop invariant value
+// without side effects or traps, so it can be computed before the loop even
+// if the loop body never runs.
+func (f *Function) hoistable(instr ssa.Instruction, l *hoistLoop) bool {
+	switch instr := instr.(type) {
+	case *ssa.BinOp:
+		if !isInteger(instr.Type()) || sizeof(instr.Type()) > DataRegSize {
+			return false
+		}
+		switch instr.Op {
+		case token.ADD, token.SUB, token.MUL, token.AND, token.OR, token.XOR, token.AND_NOT, token.SHL, token.SHR:
+		default:
+			return false
+		}
+	case *ssa.Call:
+		if builtin, ok := instr.Common().Value.(*ssa.Builtin); ok {
+			if builtin.Name() != "len" {
+				return false
+			}
+		} else if _, ok := registeredIntrinsic(instr); ok || !isSimdIntrinsic(instr) {
+			return false
+		} else if name, _ := simdCalleeName(instr); !strings.HasPrefix(name, "Splat") {
+			return false
+		}
+	default:
+		return false
+	}
+	if f.fusedInstrs[instr] {
+		return false
+	}
+	if _, ok := f.pins[instr.(ssa.Value).Name()]; ok {
+		return false
+	}
+	for _, op := range instr.Operands(nil) {
+		if !f.invariant(*op, l) {
+			return false
+		}
+		if opInstr, ok := (*op).(ssa.Instruction); ok && f.fusedInstrs[opInstr] {
+			return false
+		}
+	}
+	return true
+}
+
+// invariant returns whether v has the same value in every iteration of loop
+// l, it's defined before the loop or hoisted out of it.
+func (f *Function) invariant(v ssa.Value, l *hoistLoop) bool {
+	switch v := v.(type) {
+	case *ssa.Const, *ssa.Parameter, *ssa.Builtin, *ssa.Function:
+		return true
+	case ssa.Instruction:
+		block := v.Block()
+		if entry := f.hoisted[v]; entry != nil {
+			block = entry
+		}
+		return !l.inLoop[block.Index]
+	}
+	return false
+}
+
+// pinInvariants pins the loop invariant integer, float, and simd values read
+// in the loops to spare registers, like //gensimd:reg, so they aren't loaded
+// from their stack slots every iteration.
+func (f *Function) pinInvariants(loops []*hoistLoop) {
+	count := map[RegType]int{}
+	for _, l := range loops {
+		for _, block := range f.ssa.DomPreorder() {
+			if !l.inLoop[block.Index] {
+				continue
+			}
+			for _, instr := range block.Instrs {
+				switch instr.(type) {
+				case *ssa.DebugRef, *ssa.Phi:
+					continue
+				}
+				if f.fusedInstrs[instr] || f.hoisted[instr] != nil {
+					continue
+				}
+				for _, op := range instr.Operands(nil) {
+					v := *op
+					if v == nil || !f.invariant(v, l) || !f.pinnableInvariant(v) {
+						continue
+					}
+					t := regType(v.Type())
+					if count[t] == maxInvariants {
+						continue
+					}
+					reg := f.spareRegister(t)
+					if reg == nil {
+						continue
+					}
+					count[t]++
+					f.pins[v.Name()] = reg
+				}
+			}
+		}
+	}
+}
+
+// pinnableInvariant returns whether v is a parameter or the value of an
+// instruction lowered by Instr, fitting in one register, and not pinned yet.
+func (f *Function) pinnableInvariant(v ssa.Value) bool {
+	switch v := v.(type) {
+	case *ssa.Parameter:
+	case ssa.Instruction:
+		if f.fusedInstrs[v] || f.selectArms[v.Block()] {
+			return false
+		}
+		if phi, ok := v.(*ssa.Phi); ok && f.reductions[phi] != nil {
+			return false
+		}
+	default:
+		return false
+	}
+	if _, ok := f.pins[v.Name()]; ok {
+		return false
+	}
+	t := v.Type()
+	if isXmm(t) {
+		return sizeof(t) <= XmmRegSize
+	}
+	return isInteger(t) && sizeof(t) <= DataRegSize
+}
+
+// HoistedInstrs computes the values of the loop entered on the edge from
+// block blockIndex to block jmpIndex hoisted out of it.
+func (f *Function) HoistedInstrs(blockIndex, jmpIndex int) (string, *Error) {
+	asm := ""
+	for _, instr := range f.hoists[blockIndex][jmpIndex] {
+		entry := f.hoisted[instr]
+		delete(f.hoisted, instr)
+		a, err := f.Instr(instr)
+		f.hoisted[instr] = entry
+		if err != nil {
+			return "", err
+		}
+		asm += a
+	}
+	if asm != "" {
+		asm = fmt.Sprintf("// BEGIN HoistedInstrs block%v -> block%v\n", blockIndex, jmpIndex) + asm +
+			fmt.Sprintf("// END HoistedInstrs block%v -> block%v\n", blockIndex, jmpIndex)
+	}
+	return asm, nil
+}
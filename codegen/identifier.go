@@ -124,9 +124,10 @@ func (ident *identifier) newValue(ctx context, reg *register, offset uint, size
 		if reg.parent != nil {
 			parentName = reg.parent.owner().name
 		}
-		fmt.Printf(ident.f.Indent+"New value %v (offset=%v, size=%v) -> %v (d=%v, p=%v)\n",
+		fmt.Printf(ident.f.opts.Indent+"New value %v (offset=%v, size=%v) -> %v (d=%v, p=%v)\n",
 			ident.name, offset, size, reg.name, reg.dirty, parentName)
 	}
+	ident.f.regDecision(ctx.loc, "%v (offset=%v, size=%v) -> %v", ident.name, offset, size, reg.name)
 	if ident.spilling {
 		asm = ident.storage.storeAndSpill(ctx, reg, chunk)
 	} else {
@@ -137,7 +138,7 @@ func (ident *identifier) newValue(ctx context, reg *register, offset uint, size
 		if reg.parent != nil {
 			parentName = reg.parent.owner().name
 		}
-		fmt.Printf(ident.f.Indent+"New value {%v (offset=%v, size=%v) -> %v (d=%v, p=%v)}\n",
+		fmt.Printf(ident.f.opts.Indent+"New value {%v (offset=%v, size=%v) -> %v (d=%v, p=%v)}\n",
 			ident.name, offset, size, reg.name, reg.dirty, parentName)
 	}
 	return asm
@@ -157,7 +158,7 @@ func (ident *identifier) storeAndSpill(reg *register, offset uint, size uint) st
 		if reg.parent != nil {
 			parentName = reg.parent.owner().name
 		}
-		fmt.Printf(ident.f.Indent+"New value %v (offset=%v, size=%v) -> %v (d=%v, p=%v)\n",
+		fmt.Printf(ident.f.opts.Indent+"New value %v (offset=%v, size=%v) -> %v (d=%v, p=%v)\n",
 			ident.name, offset, size, reg.name, reg.dirty, parentName)
 	}
 	asm = ident.storage.storeAndSpill(ctx, reg, chunk)
@@ -166,7 +167,7 @@ func (ident *identifier) storeAndSpill(reg *register, offset uint, size uint) st
 		if reg.parent != nil {
 			parentName = reg.parent.owner().name
 		}
-		fmt.Printf(ident.f.Indent+"New value {%v (offset=%v, size=%v) -> %v (d=%v, p=%v)}\n",
+		fmt.Printf(ident.f.opts.Indent+"New value {%v (offset=%v, size=%v) -> %v (d=%v, p=%v)}\n",
 			ident.name, offset, size, reg.name, reg.dirty, parentName)
 	}
 	return asm
@@ -235,10 +236,14 @@ func (ident *identifier) isRetIdent() bool {
 }
 
 func (ident *identifier) isBlockLocal() bool {
+	// identifiers without an ssa value, e.g. induction pointers, and values
+	// hoisted out of loops, computed on the edge entering the loop, span blocks
 	if ident.isSsaLocal() ||
 		ident.isParam() ||
 		ident.isPhi() ||
-		ident.isRetIdent() {
+		ident.isRetIdent() ||
+		ident.ssaValue() == nil ||
+		ident.isHoisted() {
 
 		return false
 	} else {
@@ -247,6 +252,12 @@ func (ident *identifier) isBlockLocal() bool {
 	}
 }
 
+// isHoisted returns whether the value of ident is hoisted out of its loop.
+func (ident *identifier) isHoisted() bool {
+	instr, ok := ident.ssaValue().(ssa.Instruction)
+	return ok && ident.f.hoisted[instr] != nil
+}
+
 func (ident *identifier) isPointer() bool {
 	_, ok := ident.typ.(*types.Pointer)
 	return ok
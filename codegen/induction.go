@@ -0,0 +1,238 @@
+package codegen
+
+import (
+	"fmt"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/ssa"
+)
+
+// inductionPtr is a pointer walking a slice in a loop, it's &slice[phi]
+// for the loop index phi so each iteration adds a constant instead of
+// recomputing the element address.
+type inductionPtr struct {
+	ident *identifier
+	slice *ssa.Parameter
+	phi   *ssa.Phi
+}
+
+// inductionUpdate sets ptr on a phi edge, to &slice[init] when entering the
+// loop, otherwise by adding step bytes on the back edge.
+type inductionUpdate struct {
+	ptr  *inductionPtr
+	init ssa.Value
+	step int64
+}
+
+// computeInductionPtrs finds the element addresses "&s[i]" of slice
+// parameters indexed by a loop index "i" incremented by a constant.
+func (f *Function) computeInductionPtrs() {
+	f.inductionPtrs = make(map[*ssa.IndexAddr]*inductionPtr)
+	f.inductionUpdates = make(map[int]map[int][]inductionUpdate)
+	// the pointers skip IndexAddr and so its bounds checks
+	if !f.Optimize || f.opts.BoundsCheck {
+		return
+	}
+	type key struct {
+		slice *ssa.Parameter
+		phi   *ssa.Phi
+	}
+	ptrs := map[key]*inductionPtr{}
+	for _, block := range f.ssa.Blocks {
+		for _, instr := range block.Instrs {
+			indexAddr, ok := instr.(*ssa.IndexAddr)
+			if !ok || f.fusedInstrs[indexAddr] {
+				continue
+			}
+			slice, ok := indexAddr.X.(*ssa.Parameter)
+			if !ok || !isSlice(slice.Type()) {
+				continue
+			}
+			phi, ok := indexAddr.Index.(*ssa.Phi)
+			if !ok || sizeof(phi.Type()) != DataRegSize {
+				continue
+			}
+			k := key{slice, phi}
+			if ptr, ok := ptrs[k]; ok {
+				f.inductionPtrs[indexAddr] = ptr
+				continue
+			}
+			updates, ok := f.inductionSteps(phi, sizeofElem(slice.Type()))
+			if !ok {
+				continue
+			}
+			ptr := &inductionPtr{slice: slice, phi: phi}
+			ptr.ident = f.newInductionIdent(indexAddr.Type())
+			ptrs[k] = ptr
+			f.inductionPtrs[indexAddr] = ptr
+			header := phi.Block().Index
+			for i, update := range updates {
+				pred := phi.Block().Preds[i].Index
+				if f.inductionUpdates[pred] == nil {
+					f.inductionUpdates[pred] = make(map[int][]inductionUpdate)
+				}
+				update.ptr = ptr
+				f.inductionUpdates[pred][header] = append(f.inductionUpdates[pred][header], update)
+			}
+		}
+	}
+}
+
+// inductionSteps returns the pointer update for each edge of phi, phi must be
+// a loop index, "i + c" on the back edges for a constant c.
+func (f *Function) inductionSteps(phi *ssa.Phi, elemSize uint) ([]inductionUpdate, bool) {
+	header := phi.Block()
+	updates := make([]inductionUpdate, len(phi.Edges))
+	backEdge := false
+	for i, edge := range phi.Edges {
+		pred := header.Preds[i]
+		if !header.Dominates(pred) {
+			updates[i] = inductionUpdate{init: edge}
+			continue
+		}
+		c, ok := phiIncrement(phi, edge)
+		if !ok {
+			return nil, false
+		}
+		step := c * int64(elemSize)
+		if step != int64(int32(step)) {
+			return nil, false
+		}
+		updates[i] = inductionUpdate{step: step}
+		backEdge = true
+	}
+	return updates, backEdge
+}
+
+// phiIncrement returns c if v is "phi + c" for a constant c.
+func phiIncrement(phi *ssa.Phi, v ssa.Value) (int64, bool) {
+	add, ok := v.(*ssa.BinOp)
+	if !ok || add.Op != token.ADD {
+		return 0, false
+	}
+	var cnst *ssa.Const
+	if add.X == phi {
+		cnst, ok = add.Y.(*ssa.Const)
+	} else if add.Y == phi {
+		cnst, ok = add.X.(*ssa.Const)
+	} else {
+		return 0, false
+	}
+	if !ok || cnst.Value == nil || !isInteger(cnst.Type()) {
+		return 0, false
+	}
+	return cnst.Int64(), true
+}
+
+// newInductionIdent allocates the stack slot of an induction pointer.
+func (f *Function) newInductionIdent(typ types.Type) *identifier {
+	name := fmt.Sprintf("ivptr%v", len(f.inductionIdents))
+	ident := &identifier{
+		f:      f,
+		name:   name,
+		typ:    typ,
+		offset: -int(f.localIdentsSize()) - int(sizeof(typ))}
+	ident.initStorage(false)
+	f.identifiers[name] = ident
+	f.inductionIdents = append(f.inductionIdents, ident)
+	return ident
+}
+
+// InductionUpdates sets the induction pointers on the edge from block
+// blockIndex to block jmpIndex.
+func (f *Function) InductionUpdates(loc ssa.Instruction, blockIndex, jmpIndex int) (string, *Error) {
+	asm := ""
+	for _, update := range f.inductionUpdates[blockIndex][jmpIndex] {
+		var a string
+		var err *Error
+		if update.init != nil {
+			a, err = f.inductionInit(loc, update.ptr, update.init)
+		} else {
+			a, err = f.inductionStep(loc, update.ptr, update.step)
+		}
+		if err != nil {
+			return "", err
+		}
+		asm += a
+		if a, err := f.spillAllIdent(update.ptr.ident, loc); err != nil {
+			return "", err
+		} else {
+			asm += a
+		}
+	}
+	return asm, nil
+}
+
+// inductionInit sets ptr to &slice[init].
+func (f *Function) inductionInit(loc ssa.Instruction, ptr *inductionPtr, init ssa.Value) (string, *Error) {
+	ctx := context{f, loc}
+	asm := fmt.Sprintf("// BEGIN inductionInit %v = &%v[%v]\n", ptr.ident.name, ptr.slice.Name(), init.Name())
+	a, addr := f.allocIdentReg(loc, ptr.ident, ptr.ident.size())
+	asm += a
+	a, idx, err := f.LoadValueSimple(loc, init)
+	if err != nil {
+		return "", err
+	}
+	asm += a
+	sliceInfo := f.identifiers[ptr.slice.Name()]
+	sliceReg, sliceOffset, _ := sliceInfo.Addr()
+	optypes := GetIntegerOpDataType(false, sizePtr())
+	asm += MovMemReg(ctx, optypes, sliceInfo.name, sliceOffset, &sliceReg, addr, false)
+	elemSize := sizeofElem(sliceInfo.typ)
+	if isLeaScale(elemSize) {
+		asm += LeaScaled(ctx, addr, idx, elemSize, addr, false)
+	} else {
+		a, tmp := f.allocTempReg(DATA_REG, DataRegSize)
+		asm += a
+		asm += MulImm32RegReg(ctx, uint32(elemSize), idx, tmp, false)
+		asm += AddRegReg(ctx, optypes, tmp, addr, false)
+		f.freeReg(tmp)
+	}
+	a, err = f.StoreValue(loc, ptr.ident, addr)
+	if err != nil {
+		return "", err
+	}
+	asm += a
+	f.freeReg(idx)
+	f.freeReg(addr)
+	asm += fmt.Sprintf("// END inductionInit %v = &%v[%v]\n", ptr.ident.name, ptr.slice.Name(), init.Name())
+	return asm, nil
+}
+
+// inductionStep adds step bytes to ptr, with LEAQ so the flags are unchanged.
+func (f *Function) inductionStep(loc ssa.Instruction, ptr *inductionPtr, step int64) (string, *Error) {
+	ctx := context{f, loc}
+	asm := fmt.Sprintf("// BEGIN inductionStep %v += %v\n", ptr.ident.name, step)
+	a, reg, err := f.LoadIdentSimple(loc, ptr.ident)
+	if err != nil {
+		return "", err
+	}
+	asm += a
+	// reg is ptr's register, modifying it modifies ptr
+	asm += reg.modified(ctx, false)
+	asm += fmt.Sprintf("%-9v    %v(%v), %v\n", LEAQ, step, reg.name, reg.name)
+	f.freeReg(reg)
+	asm += fmt.Sprintf("// END inductionStep %v += %v\n", ptr.ident.name, step)
+	return asm, nil
+}
+
+// InductionIndexAddr copies the induction pointer walking instr.X to instr.
+func (f *Function) InductionIndexAddr(instr *ssa.IndexAddr, ptr *inductionPtr) (string, *Error) {
+	asm := fmt.Sprintf("// BEGIN ssa.IndexAddr: %v = %v, %v\n", instr.Name(), instr, ptr.ident.name)
+	assignment := f.Ident(instr)
+	assignment.ptr = f.identifiers[ptr.slice.Name()]
+	a, reg, err := f.LoadIdentSimple(instr, ptr.ident)
+	if err != nil {
+		return "", err
+	}
+	asm += a
+	a, err = f.StoreValue(instr, assignment, reg)
+	if err != nil {
+		return "", err
+	}
+	asm += a
+	f.freeReg(reg)
+	asm += fmt.Sprintf("// END ssa.IndexAddr: %v = %v, %v\n", instr.Name(), instr, ptr.ident.name)
+	return asm, nil
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"go/token"
 	"math"
+	"strconv"
 	"strings"
 )
 
@@ -115,6 +116,8 @@ const (
 	I_ADD
 	I_AND
 	I_CMP
+	I_DEC
+	I_INC
 
 	// convert f32/64 to a uint8/int8, ..., uint64/int64
 	I_CVT_FLOAT2INT
@@ -175,6 +178,8 @@ const (
 var Insts = []DataInstruction{
 	{I_ADD, ADDB, ADDW, ADDL, ADDQ, NONE},
 	{I_SUB, SUBB, SUBW, SUBL, SUBQ, NONE},
+	{I_INC, INCB, INCW, INCL, INCQ, NONE},
+	{I_DEC, DECB, DECW, DECL, DECQ, NONE},
 	{I_MOV, MOVB, MOVW, MOVL, MOVQ, MOVOU},
 
 	// byte register sign extend to xxx register
@@ -375,9 +380,59 @@ func GetInstr(tinst InstructionType, datatype OpDataType) Instruction {
 	}
 }
 
-// ZeroMemory generates "MOVQ $0, name+offset(REG)" instructions,
-// size is in bytes
+const (
+	// zeroRepStosSize is the size in bytes from which ZeroMemory uses REP STOSQ
+	zeroRepStosSize = 256
+	// repStringSizeOptSize is the size in bytes from which copies and zeroing
+	// use REP MOVSQ and REP STOSQ when optimizing for size, their setup is
+	// shorter than the MOVs of 32 bytes
+	repStringSizeOptSize = 32
+)
+
+// ZeroMemory zeroes size bytes at name+offset(REG), with MOVQ $0 stores
+// for small sizes, XORPS and MOVUPS stores for 16 byte chunks, and REP STOSQ
+// for large sizes, from 32 bytes when optimizing for size. It uses X15, AX,
+// CX, and DI so it's only for the function preamble where the registers are
+// all free. On plan9 XMM registers aren't used.
 func ZeroMemory(ctx context, name string, offset int, size uint, reg *register) string {
+	asm := ""
+	repSize := uint(zeroRepStosSize)
+	if ctx.optSize() {
+		repSize = repStringSizeOptSize
+	}
+	if size >= repSize && size%8 == 0 {
+		di := getRegister(REG_DI)
+		cx := getRegister(REG_CX)
+		ax := getRegister(REG_AX)
+		asm += Lea(ctx, name, offset, reg, di, false)
+		asm += fmt.Sprintf("%-9v    $%v, %v\n", MOVQ, size/8, cx.name)
+		asm += ZeroReg(ctx, ax)
+		asm += fmt.Sprintf("%-9v\n", REP)
+		asm += fmt.Sprintf("%-9v\n", STOSQ)
+		return asm
+	}
+	return zeroMemoryStores(ctx, name, offset, size, reg)
+}
+
+// zeroMemoryStores zeroes size bytes at name+offset(REG) like ZeroMemory
+// without REP STOSQ, it only uses X15, which is reserved, so it's also for
+// the body of the function.
+func zeroMemoryStores(ctx context, name string, offset int, size uint, reg *register) string {
+	asm := ""
+	if size >= 2*XmmRegSize && (ctx.f == nil || ctx.f.opts.OS != "plan9") {
+		x15 := getRegister(REG_X15)
+		asm += instrRegReg(ctx, XORPS, x15, x15, false)
+		for ; size >= XmmRegSize; size -= XmmRegSize {
+			asm += instrRegMem(ctx, MOVUPS, x15, reg, name, offset, false)
+			offset += XmmRegSize
+		}
+	}
+	return asm + zeroMemoryChunks(ctx, name, offset, size, reg)
+}
+
+// zeroMemoryChunks generates "MOVQ $0, name+offset(REG)" instructions,
+// size is in bytes
+func zeroMemoryChunks(ctx context, name string, offset int, size uint, reg *register) string {
 	chunk := uint(1)
 	if size%8 == 0 {
 		chunk = 8
@@ -434,7 +489,25 @@ func instrRegReg(ctx context, instr Instruction, src, dst *register, spill bool)
 	return asm
 }
 
+// maxDisp is the largest displacement of a memory operand, a signed 32 bit
+// integer. checkFrameSize limits frames so offsets from SP and FP fit, and
+// offsets from pointers are inside a value.
+const maxDisp = math.MaxInt32
+
+// checkDisp panics if offset doesn't fit in a displacement, rather than
+// generating an operand the assembler truncates.
+func checkDisp(offset int) {
+	if offset > maxDisp || offset < -maxDisp-1 {
+		ice(fmt.Sprintf("offset (%v) doesn't fit in a 32 bit displacement", offset))
+	}
+}
+
 func instrRegMem(ctx context, instr Instruction, src, dst *register, dstName string, dstOffset int, spill bool) string {
+	checkDisp(dstOffset)
+	if dst.regconst == REG_FP {
+		instr = fpMove(instr)
+		dstName = ctx.f.fpName(instr, dstName, dstOffset)
+	}
 	info, ok := instrTable[instr]
 	if !ok {
 		ice(fmt.Sprintf("couldn't look up instruction (%v) information", instr))
@@ -454,6 +527,11 @@ func instrRegMem(ctx context, instr Instruction, src, dst *register, dstName str
 }
 
 func instrMemReg(ctx context, instr Instruction, srcName string, srcOffset int, src, dst *register, spill bool) string {
+	checkDisp(srcOffset)
+	if src.regconst == REG_FP {
+		instr = fpMove(instr)
+		srcName = ctx.f.fpName(instr, srcName, srcOffset)
+	}
 	info, ok := instrTable[instr]
 	if !ok {
 		ice(fmt.Sprintf("couldn't look up instruction (%v) information", instr))
@@ -500,6 +578,10 @@ func instrImmReg(ctx context, instr Instruction, imm int64, size uint, dst *regi
 
 // instrImmReg outputs instr with imm, reg after converting imm to int8/16/32/64 if size=1/2/4/8.
 func instrImmMem(ctx context, instr Instruction, imm int64, dst *register, dstName string, dstOffset int) string {
+	checkDisp(dstOffset)
+	if dst.regconst == REG_FP {
+		dstName = ctx.f.fpName(instr, dstName, dstOffset)
+	}
 	asm := fmt.Sprintf("%-9v    $%v, %v+%v(%v)\n", instr, imm, dstName, dstOffset, dst.name)
 	return strings.Replace(asm, "+-", "-", -1)
 }
@@ -602,6 +684,10 @@ func ZeroReg(ctx context, reg *register) string {
 
 	} else {
 		dt = OpDataType{OP_DATA, InstrData{signed: false, size: reg.width / 8}, XMM_INVALID}
+		if ctx.optSize() && dt.size == 8 {
+			// XORL zero extends and needs no REX prefix for AX-DI
+			dt.size = 4
+		}
 	}
 	return instrRegReg(ctx, GetInstr(I_XOR, dt), reg, reg, false)
 }
@@ -623,13 +709,48 @@ func MovRegReg(ctx context, datatype OpDataType, src, dst *register, spill bool)
 }
 
 func MovRegMem(ctx context, datatype OpDataType, src *register, dstName string, dst *register, dstOffset int) string {
-	var mov Instruction
+	mov := movInstr(datatype)
+	return instrRegMem(ctx, mov, src, dst, dstName, dstOffset, false)
+}
+
+// movInstr returns the move of datatype, MOVOU for the packed integer types
+// whose xmm variants alias the scalar moves, e.g. MOVSS for U8x16.
+func movInstr(datatype OpDataType) Instruction {
 	if datatype.op == OP_PACKED {
-		mov = GetInstr(I_PMOV, datatype)
-	} else {
-		mov = GetInstr(I_MOV, datatype)
+		return GetInstr(I_PMOV, datatype)
 	}
-	return instrRegMem(ctx, mov, src, dst, dstName, dstOffset, false)
+	return GetInstr(I_MOV, datatype)
+}
+
+// AlignedMov returns the aligned form of the unaligned xmm move mov, e.g.
+// MOVAPS for MOVUPS, the memory operand must be 16 byte aligned.
+func AlignedMov(mov Instruction) Instruction {
+	switch mov {
+	case MOVUPS:
+		return MOVAPS
+	case MOVUPD:
+		return MOVAPD
+	case MOVOU:
+		return MOVO
+	}
+	return mov
+}
+
+// MovRegMemAligned is MovRegMem with an aligned xmm move.
+func MovRegMemAligned(ctx context, datatype OpDataType, src *register, dstName string, dst *register, dstOffset int) string {
+	mov := movInstr(datatype)
+	return instrRegMem(ctx, AlignedMov(mov), src, dst, dstName, dstOffset, false)
+}
+
+// MovAlignedIndirectMem copies the 16 bytes at the aligned address in src to dstName+dstOffset(dst).
+func MovAlignedIndirectMem(ctx context, datatype OpDataType, src *register, dstName string, dstOffset int, dst *register, tmpData *register) string {
+	if tmpData.typ != XMM_REG {
+		ice("aligned indirect move needs an xmm register")
+	}
+	mov := movInstr(datatype)
+	asm := instrMemReg(ctx, AlignedMov(mov), "", 0, src, tmpData, false)
+	asm += instrRegMem(ctx, mov, tmpData, dst, dstName, dstOffset, false)
+	return asm
 }
 
 func MovRegIndirectMem(ctx context, datatype OpDataType, src *register, dstName string, dstOffset int, dst *register, size uint, tmpAddr, tmpData *register) string {
@@ -645,7 +766,7 @@ func MovRegIndirectMem(ctx context, datatype OpDataType, src *register, dstName
 	addrdatatype := OpDataType{OP_DATA,
 		InstrData{signed: false, size: sizePtr()}, XMM_INVALID}
 
-	mov := GetInstr(I_MOV, datatype)
+	mov := movInstr(datatype)
 	movaddr := GetInstr(I_MOV, addrdatatype)
 	asm := ""
 	var count uint
@@ -707,6 +828,24 @@ func MovMemMem(ctx context, optype InstrOpType, srcName string, srcOffset int, s
 	return asm
 }
 
+// MovMemMemRep copies size bytes from srcName+srcOffset(src) to
+// dstName+dstOffset(dst) with REP MOVSQ, size is a multiple of 8. It sets SI,
+// DI, and CX, the caller reserves SI and DI, CX is never allocated.
+func MovMemMemRep(ctx context, srcName string, srcOffset int, src *register, dstName string, dstOffset int, dst *register, size uint) string {
+	if size%8 != 0 {
+		ice(fmt.Sprintf("Invalid size (%v), not a multiple of 8", size))
+	}
+	si := getRegister(REG_SI)
+	di := getRegister(REG_DI)
+	cx := getRegister(REG_CX)
+	asm := Lea(ctx, srcName, srcOffset, src, si, false)
+	asm += Lea(ctx, dstName, dstOffset, dst, di, false)
+	asm += fmt.Sprintf("%-9v    $%v, %v\n", MOVQ, size/8, cx.name)
+	asm += fmt.Sprintf("%-9v\n", REP)
+	asm += fmt.Sprintf("%-9v\n", MOVSQ)
+	return asm
+}
+
 func MovMemReg(ctx context, datatype OpDataType, srcName string, srcOffset int, src, dst *register, spill bool) string {
 	var mov Instruction
 	if datatype.op == OP_PACKED {
@@ -744,7 +883,7 @@ func MovMemIndirectMem(ctx context, datatype OpDataType, srcName string, srcOffs
 	addrdatatype := OpDataType{OP_DATA,
 		InstrData{signed: false, size: sizePtr()}, XMM_INVALID}
 
-	mov := GetInstr(I_MOV, datatype)
+	mov := movInstr(datatype)
 	movaddr := GetInstr(I_MOV, addrdatatype)
 	asm := ""
 	var count uint
@@ -840,6 +979,32 @@ func MovImmf64Reg(ctx context, f64 float64, tmp, dst *register, spill bool) stri
 	return MovImmFloatReg(ctx, f64, false, tmp, dst, spill)
 }
 
+// MovFloatConstReg loads the float constant f64 into dst. Zero is zeroed,
+// other constants are read from the assembler's constant pool, for
+// "MOVSD $(1.5), X0" the assembler emits the read only symbol
+// $f64.3ff8000000000000 and loads from it.
+func MovFloatConstReg(ctx context, f64 float64, isf32 bool, dst *register, spill bool) string {
+	if dst.typ != XMM_REG {
+		ice("Unexpected non xmm register")
+	}
+	if f64 == 0 && !math.Signbit(f64) {
+		return ZeroReg(ctx, dst)
+	}
+	mov, bitsize := MOVSD, 64
+	if isf32 {
+		mov, bitsize = MOVSS, 32
+		f64 = float64(float32(f64))
+	}
+	lit := strconv.FormatFloat(f64, 'g', -1, bitsize)
+	if !strings.ContainsAny(lit, ".e") {
+		// the assembler takes "2" for an integer
+		lit += ".0"
+	}
+	asm := dst.modified(ctx, spill)
+	asm += fmt.Sprintf("%-9v    $(%v), %v\n", mov, lit, dst.name)
+	return asm
+}
+
 func MovImm8Reg(ctx context, imm8 int8, dst *register, spill bool) string {
 	return MovImmReg(ctx, int64(imm8), 1, dst, spill)
 }
@@ -875,6 +1040,25 @@ func Lea(ctx context, srcName string, srcOffset int, src, dst *register, spill b
 	return instrMemReg(ctx, lea, srcName, srcOffset, src, dst, spill)
 }
 
+// LeaScaled computes base + index*scale with scaled index addressing,
+// "LEAQ (base)(index*scale), dst", scale must be 1, 2, 4, or 8.
+func LeaScaled(ctx context, base, index *register, scale uint, dst *register, spill bool) string {
+	if base.width != 64 || index.width != 64 || dst.width != 64 {
+		ice("Invalid register width")
+	}
+	if !isLeaScale(scale) {
+		ice(fmt.Sprintf("Invalid scale (%v)", scale))
+	}
+	asm := dst.modified(ctx, spill)
+	asm += fmt.Sprintf("%-9v    (%v)(%v*%v), %v\n", LEAQ, base.name, index.name, scale, dst.name)
+	return asm
+}
+
+// isLeaScale returns true if scale can be used for scaled index addressing.
+func isLeaScale(scale uint) bool {
+	return scale == 1 || scale == 2 || scale == 4 || scale == 8
+}
+
 func AddImm32Reg(ctx context, imm32 uint32, dst *register, spill bool) string {
 	if dst.width < 32 {
 		ice("Invalid register width")
@@ -964,12 +1148,26 @@ func DivRegReg(ctx context, signed bool, datatype InstrOpType, dividend, divisor
 	// rdx:rax are the upper and lower parts of the dividend respectively,
 	// and rdx:rax are the implicit destination of DIVQ
 	asm = ""
-	asm += ZeroReg(ctx, rax)
-	if size > 1 {
-		asm += ZeroReg(ctx, rdx)
-	}
 	dt := OpDataType{datatype, InstrData{signed: signed, size: size}, XMM_INVALID}
-	asm += MovRegReg(ctx, dt, dividend, rax, false)
+	if signed {
+		// IDIV needs the dividend sign extended into rdx:rax, for 8 bit
+		// dividends into ax
+		if size < 8 {
+			asm += MovSignExtend(ctx, dividend, rax, size, 8, false)
+		} else {
+			asm += MovRegReg(ctx, dt, dividend, rax, false)
+		}
+		if size > 1 {
+			asm += MovRegReg(ctx, GetIntegerOpDataType(false, 8), rax, rdx, false)
+			asm += ShiftImm8Reg(ctx, true, SHIFT_RIGHT, 63, rdx)
+		}
+	} else {
+		asm += ZeroReg(ctx, rax)
+		if size > 1 {
+			asm += ZeroReg(ctx, rdx)
+		}
+		asm += MovRegReg(ctx, dt, dividend, rax, false)
+	}
 
 	// the low order part of the result is stored in rax and the high order part
 	// is stored in rdx
@@ -980,6 +1178,23 @@ func DivRegReg(ctx context, signed bool, datatype InstrOpType, dividend, divisor
 		tinstr = I_DIV
 	}
 
+	if signed {
+		// Go defines x / -1 as -x and x % -1 as 0, IDIV faults on the
+		// overflowing quotient of the most negative x divided by -1
+		div := ctx.newLabel("div")
+		done := ctx.newLabel("divdone")
+		asm += CmpRegImm(ctx, dt, divisor, -1)
+		asm += fmt.Sprintf("%-9v    %v\n", JNE, div)
+		asm += NegReg(ctx, rax, 8, false)
+		if size > 1 {
+			asm += ZeroReg(ctx, rdx)
+		}
+		asm += fmt.Sprintf("%-9v    %v\n", "JMP", done)
+		asm += div + ":\n"
+		asm += instrReg(ctx, GetInstr(tinstr, dt), divisor, false)
+		asm += done + ":\n"
+		return asm, rax, rdx
+	}
 	asm += instrReg(ctx, GetInstr(tinstr, dt), divisor, false)
 	return asm, rax, rdx
 }
@@ -996,23 +1211,31 @@ func DivFloatRegReg(ctx context, datatype OpDataType, dividend, divisor *registe
 	return instrRegReg(ctx, GetInstr(I_DIV, datatype), divisor, dividend, true)
 }
 
+// ArithOp computes x op y into result. The x86 instructions are two address,
+// the destination is also the first source, so x is moved into result unless
+// result is x. result may be y only if op is commutative or an integer
+// division.
 func ArithOp(ctx context, datatype OpDataType, op token.Token, x, y, result *register) string {
 	if x.width != y.width || x.width != result.width {
 		ice("Invalid register width")
 	}
+	intDiv := (op == token.QUO || op == token.REM) && datatype.op == OP_DATA
+	if result == y && result != x && op != token.ADD && op != token.MUL && !intDiv {
+		ice(fmt.Sprintf("result register (%v) can't alias y for op (%v)", result.name, op))
+	}
 	asm := ""
 	switch op {
 	default:
 		ice(fmt.Sprintf("Unknown Op token (%v)", op))
 	case token.ADD:
-		asm += MovRegReg(ctx, datatype, x, result, false)
-		asm += AddRegReg(ctx, datatype, y, result, false)
+		src := twoAddress(ctx, datatype, x, y, result, &asm)
+		asm += AddRegReg(ctx, datatype, src, result, false)
 	case token.SUB:
-		asm += MovRegReg(ctx, datatype, x, result, false)
+		asm += movTwoAddress(ctx, datatype, x, result)
 		asm += SubRegReg(ctx, datatype, y, result, false)
 	case token.MUL:
-		asm += MovRegReg(ctx, datatype, x, result, false)
-		asm += MulRegReg(ctx, datatype, y, result, false)
+		src := twoAddress(ctx, datatype, x, y, result, &asm)
+		asm += MulRegReg(ctx, datatype, src, result, false)
 	case token.QUO, token.REM:
 		if datatype.op == OP_DATA {
 			// the quotient is stored in rax and
@@ -1028,13 +1251,33 @@ func ArithOp(ctx context, datatype OpDataType, op token.Token, x, y, result *reg
 		} else {
 			// assume quotient operation,
 			// since modulus isn't defined for floats
-			asm += MovRegReg(ctx, datatype, x, result, false)
+			asm += movTwoAddress(ctx, datatype, x, result)
 			asm += DivFloatRegReg(ctx, datatype, result, y, false)
 		}
 	}
 	return asm
 }
 
+// movTwoAddress moves x into the destination result of a two address
+// instruction, unless result is x.
+func movTwoAddress(ctx context, datatype OpDataType, x, result *register) string {
+	if x == result {
+		return ""
+	}
+	return MovRegReg(ctx, datatype, x, result, false)
+}
+
+// twoAddress sets up result as the destination of the commutative two
+// address instruction x op y and returns its source operand. If result is x
+// or y it's the destination as is, otherwise x is moved into it.
+func twoAddress(ctx context, datatype OpDataType, x, y, result *register, asm *string) *register {
+	if result == y {
+		return x
+	}
+	*asm += movTwoAddress(ctx, datatype, x, result)
+	return y
+}
+
 // AndRegReg AND's the src register by the dst register and stores
 // the result in the dst register.
 func AndRegReg(ctx context, src, dst *register, size uint, spill bool) string {
@@ -1102,6 +1345,44 @@ func MovZeroExtend(ctx context, src, dst *register, srcSize, dstSize uint, spill
 	return instrRegReg(ctx, GetInstr(movzx, dt), src, dst, spill)
 }
 
+// TrailingZeros sets dst to the number of trailing zero bits of the size
+// byte integer in src, 8*size if it's zero, with TZCNT if tzcnt is true
+// otherwise BSF, which leaves its destination unchanged for zero. Smaller
+// integers are zero extended with the bit after them set, so they're never
+// zero, tmp is only used for 8 byte integers without TZCNT.
+func TrailingZeros(ctx context, size uint, tzcnt bool, src, dst, tmp *register) string {
+	bsf := BSFQ
+	if tzcnt {
+		bsf = TZCNTQ
+	}
+	if size < 8 {
+		asm := MovZeroExtend(ctx, src, dst, size, 8, false)
+		asm += instrImmReg(ctx, BTSQ, int64(8*size), 1, dst, false)
+		return asm + instrRegReg(ctx, bsf, dst, dst, false)
+	}
+	if tzcnt {
+		return instrRegReg(ctx, TZCNTQ, src, dst, false)
+	}
+	asm := MovImmReg(ctx, 64, 8, dst, false)
+	asm += instrRegReg(ctx, BSFQ, src, tmp, false)
+	asm += instrRegReg(ctx, CMOVQNE, tmp, dst, false)
+	return asm
+}
+
+// NonzeroTrailingZeros is TrailingZeros of a nonzero src, a single TZCNT or
+// BSF after zero extending smaller integers.
+func NonzeroTrailingZeros(ctx context, size uint, tzcnt bool, src, dst *register) string {
+	bsf := BSFQ
+	if tzcnt {
+		bsf = TZCNTQ
+	}
+	if size < 8 {
+		asm := MovZeroExtend(ctx, src, dst, size, 8, false)
+		return asm + instrRegReg(ctx, bsf, dst, dst, false)
+	}
+	return instrRegReg(ctx, bsf, src, dst, false)
+}
+
 func MovSignExtend(ctx context, src, dst *register, srcSize, dstSize uint, spill bool) string {
 	var movsx InstructionType
 	switch srcSize {
@@ -1122,7 +1403,7 @@ func MovSignExtend(ctx context, src, dst *register, srcSize, dstSize uint, spill
 // ShiftRegReg shifts src by shiftReg amount and stores the result in src.
 // The tmp reg is used for intermediates (if shifting right 64 times then SHR
 // isn't used directly)
-func ShiftRegReg(ctx context, signed bool, direction int, src, shiftReg, tmp *register, size uint, spill bool) string {
+func ShiftRegReg(ctx context, signed bool, direction int, src, shiftReg, tmp *register, size, countSize uint, spill bool) string {
 	cl := getRegister(REG_CL)
 	cx := getRegister(REG_CX)
 	regCl := cx
@@ -1154,9 +1435,9 @@ func ShiftRegReg(ctx context, signed bool, direction int, src, shiftReg, tmp *re
 	asm += MovRegReg(ctx, instrdata, shiftReg, cx, false)
 
 	asm += MovImm32Reg(ctx, completeShift, tmp, false)
-	// compare only first byte of shift reg,
-	// since useful shift can be at most 64
-	asm += CmpRegImm32(ctx, shiftReg, maxShift, 1)
+	// compare the whole count, e.g. 256 shifts out every bit though its low
+	// byte is 0
+	asm += CmpRegImm32(ctx, shiftReg, maxShift, countSize)
 	asm += CMovCCRegReg(ctx, tmp, cx, size, false)
 
 	var zerosize uint = 1
@@ -1165,10 +1446,8 @@ func ShiftRegReg(ctx context, signed bool, direction int, src, shiftReg, tmp *re
 
 	if maxShift == 64 || maxShift == 32 {
 		asm += MovImm32Reg(ctx, 1, tmp, false)
-		// compare only first byte of shift reg,
-		// since useful shift can be at most 64
 		asm += XorRegReg(ctx, cx, cx, false)
-		asm += CmpRegImm32(ctx, shiftReg, maxShift, 1)
+		asm += CmpRegImm32(ctx, shiftReg, maxShift, countSize)
 		asm += CMovCCRegReg(ctx, tmp, cx, size, false)
 		var zerosize uint = 1
 		asm += MovZeroExtend(ctx, cl, cx, zerosize, cx.width/8, false)
@@ -1177,6 +1456,39 @@ func ShiftRegReg(ctx context, signed bool, direction int, src, shiftReg, tmp *re
 	return asm
 }
 
+// ShiftXRegReg shifts the 4 or 8 byte src by count into dst with the BMI2
+// instructions SHLX, SHRX, and SARX, which take the count in any register
+// instead of CL. They mask the count like SHL and SHR, counts of at least the
+// width give zero, or the sign bits for SARX whose count is clamped in tmp.
+// tmp must differ from src, count, and dst, dst may be src.
+func ShiftXRegReg(ctx context, signed bool, direction int, src, count, dst, tmp *register, size, countSize uint) string {
+	shifts := map[uint][3]Instruction{4: {SHLXL, SHRXL, SARXL}, 8: {SHLXQ, SHRXQ, SARXQ}}
+	instrs, ok := shifts[size]
+	if !ok {
+		ice(fmt.Sprintf("Invalid BMI2 shift size (%v)", size))
+	}
+	maxShift := 8 * uint32(size)
+	asm := ""
+	if signed && direction == SHIFT_RIGHT {
+		asm += MovImm32Reg(ctx, int32(maxShift-1), tmp, false)
+		asm += CmpRegImm32(ctx, count, maxShift, countSize)
+		asm += instrRegReg(ctx, CMOVQCS, count, tmp, false)
+		asm += dst.modified(ctx, false)
+		return asm + fmt.Sprintf("%-9v    %v, %v, %v\n", instrs[2], tmp.name, src.name, dst.name)
+	}
+	shift := instrs[0]
+	if direction == SHIFT_RIGHT {
+		shift = instrs[1]
+	}
+	// tmp is zeroed before the compare, XOR sets the flags
+	asm += ZeroReg(ctx, tmp)
+	asm += CmpRegImm32(ctx, count, maxShift, countSize)
+	asm += dst.modified(ctx, false)
+	asm += fmt.Sprintf("%-9v    %v, %v, %v\n", shift, count.name, src.name, dst.name)
+	asm += CMovCCRegReg(ctx, tmp, dst, size, false)
+	return asm
+}
+
 func ShiftImm8Reg(ctx context, signed bool, direction int, count uint8, reg *register) string {
 	var opcode InstructionType
 	if direction == SHIFT_LEFT {
@@ -1208,7 +1520,11 @@ func AndNotRegReg(ctx context, src, dst *register, size uint, spill bool) string
 	return asm
 }
 
-func BitwiseOp(ctx context, op token.Token, signed bool, x, y, result *register, size uint) string {
+// BitwiseOp computes x op y into result, tmp is a scratch register for shifts
+// that must differ from x, y, and result. result may be x or y except for
+// AND_NOT, where it may only be y, and shifts, where it may only be x.
+// countSize is the size of y for shifts, the count can be any unsigned type.
+func BitwiseOp(ctx context, op token.Token, signed bool, x, y, result, tmp *register, size, countSize uint) string {
 	if x.width != y.width || x.width != result.width {
 		ice("Invalid register width")
 	}
@@ -1218,29 +1534,110 @@ func BitwiseOp(ctx context, op token.Token, signed bool, x, y, result *register,
 	default:
 		ice(fmt.Sprintf("Unknown Op token (%v)", op))
 	case token.AND:
-		asm = MovRegReg(ctx, instrdata, y, result, false)
-		asm += AndRegReg(ctx, x, result, size, false)
+		src := twoAddress(ctx, instrdata, y, x, result, &asm)
+		asm += AndRegReg(ctx, src, result, size, false)
 	case token.OR:
-		asm = MovRegReg(ctx, instrdata, y, result, false)
-		asm += OrRegReg(ctx, x, result, false)
+		src := twoAddress(ctx, instrdata, y, x, result, &asm)
+		asm += OrRegReg(ctx, src, result, false)
 	case token.XOR:
-		asm = MovRegReg(ctx, instrdata, y, result, false)
-		asm += XorRegReg(ctx, x, result, false)
-	case token.SHL:
-		asm = MovRegReg(ctx, instrdata, x, result, false)
-		tmp := x
-		asm += ShiftRegReg(ctx, signed, SHIFT_LEFT, result, y, tmp, size, false)
-	case token.SHR:
-		asm = MovRegReg(ctx, instrdata, x, result, false)
-		tmp := x
-		asm += ShiftRegReg(ctx, signed, SHIFT_RIGHT, result, y, tmp, size, false)
+		src := twoAddress(ctx, instrdata, y, x, result, &asm)
+		asm += XorRegReg(ctx, src, result, false)
+	case token.SHL, token.SHR:
+		if result == y {
+			ice(fmt.Sprintf("result register (%v) can't alias the shift count", result.name))
+		}
+		if tmp == x || tmp == y || tmp == result {
+			ice(fmt.Sprintf("shift scratch register (%v) aliases an operand", tmp.name))
+		}
+		direction := SHIFT_LEFT
+		if op == token.SHR {
+			direction = SHIFT_RIGHT
+		}
+		if (size == 4 || size == 8) && ctx.hasInstr(SHLXQ) {
+			return ShiftXRegReg(ctx, signed, direction, x, y, result, tmp, size, countSize)
+		}
+		asm = movTwoAddress(ctx, instrdata, x, result)
+		asm += ShiftRegReg(ctx, signed, direction, result, y, tmp, size, countSize, false)
 	case token.AND_NOT:
-		asm = MovRegReg(ctx, instrdata, y, result, false)
+		if result == x {
+			ice(fmt.Sprintf("result register (%v) can't alias x for op (%v)", result.name, op))
+		}
+		asm = movTwoAddress(ctx, instrdata, y, result)
 		asm += AndNotRegReg(ctx, x, result, size, false)
 	}
 	return asm
 }
 
+// ImmOp computes x op imm into result with the immediate form of the
+// integer ops ADD, SUB, AND, OR, XOR, SHL, and SHR. imm must be a sign
+// extended 32 bit immediate, or the shift count. result may be x.
+func ImmOp(ctx context, datatype OpDataType, op token.Token, x *register, imm int64, result *register) string {
+	if x.width != result.width {
+		ice("Invalid register width")
+	}
+	var tinstr InstructionType
+	switch op {
+	default:
+		ice(fmt.Sprintf("No immediate form for op (%v)", op))
+	case token.ADD:
+		tinstr = I_ADD
+	case token.SUB:
+		tinstr = I_SUB
+	case token.AND:
+		tinstr = I_AND
+	case token.OR:
+		tinstr = I_OR
+	case token.XOR:
+		tinstr = I_XOR
+	case token.SHL, token.SHR:
+		return movTwoAddress(ctx, datatype, x, result) + ShiftImm(ctx, datatype, op, imm, result)
+	}
+	asm := movTwoAddress(ctx, datatype, x, result)
+	if ctx.optSize() && (tinstr == I_ADD || tinstr == I_SUB) && (imm == 1 || imm == -1) {
+		// INC and DEC are a byte shorter but leave the carry flag unchanged
+		if (tinstr == I_ADD) == (imm == 1) {
+			tinstr = I_INC
+		} else {
+			tinstr = I_DEC
+		}
+		return asm + instrReg(ctx, GetInstr(tinstr, datatype), result, false)
+	}
+	return asm + instrImmReg(ctx, GetInstr(tinstr, datatype), imm, datatype.size, result, false)
+}
+
+// ShiftImm shifts reg by count with Go's semantics, counts of at least the
+// width shift out every bit instead of being masked like SHL and SHR do.
+func ShiftImm(ctx context, datatype OpDataType, op token.Token, count int64, reg *register) string {
+	width := int64(8 * datatype.size)
+	tinstr := I_SHL
+	if op == token.SHR && datatype.signed {
+		tinstr = I_SAR
+		if count >= width {
+			// all sign bits
+			count = width - 1
+		}
+	} else if op == token.SHR {
+		tinstr = I_SHR
+	}
+	if count >= width {
+		return ZeroReg(ctx, reg)
+	}
+	return instrImmReg(ctx, GetInstr(tinstr, datatype), count, 1, reg, false)
+}
+
+// CmpRegImm compares r to the sign extended 32 bit immediate imm.
+func CmpRegImm(ctx context, datatype OpDataType, r *register, imm int64) string {
+	switch datatype.size {
+	case 1:
+		imm = int64(int8(imm))
+	case 2:
+		imm = int64(int16(imm))
+	case 4:
+		imm = int64(int32(imm))
+	}
+	return fmt.Sprintf("%-9v    %v, $%v\n", GetInstr(I_CMP, datatype), r.name, imm)
+}
+
 func CmpRegReg(ctx context, odt OpDataType, x, y *register) string {
 	if x.width != y.width {
 		ice("Invalid register width")
@@ -1263,59 +1660,338 @@ func CmpOp(ctx context, data OpDataType, op token.Token, x *register, y *registe
 		ice(fmt.Sprintf("Invalid register width, x.width (%v), y.width (%v), result.width (%v)", x.width, y.width, result.width))
 	}
 	asm := ""
-	asm += CmpRegReg(ctx, data, x, y)
+	if data.op != OP_XMM {
+		asm += CmpRegReg(ctx, data, x, y)
+		asm += instrReg(ctx, cmpSetInstr(data, op), result, false)
+		return asm
+	}
+	asm += FloatCmpRegReg(ctx, data, op, x, y)
+	asm += instrReg(ctx, cmpSetInstr(data, op), result, false)
+	if op == token.EQL || op == token.NEQ {
+		// unordered operands set ZF and PF, so NaN isn't equal to anything
+		done := ctx.newLabel("cmpdone")
+		asm += fmt.Sprintf("%-9v    %v\n", JPC, done)
+		if op == token.EQL {
+			asm += MovImm32Reg(ctx, 0, result, false)
+		} else {
+			asm += MovImm32Reg(ctx, 1, result, false)
+		}
+		asm += done + ":\n"
+	}
+	return asm
+}
+
+// FloatCmpRegReg compares the floats x and y for op. UCOMISS sets ZF, PF,
+// and CF for unordered operands, so the operands of GTR and GEQ are swapped
+// to use the flags of LSS and LEQ, which like Go are false if either is NaN.
+func FloatCmpRegReg(ctx context, data OpDataType, op token.Token, x, y *register) string {
+	if op == token.GTR || op == token.GEQ {
+		x, y = y, x
+	}
+	return CmpRegReg(ctx, data, x, y)
+}
+
+// cmpSetInstr returns the SETXX instruction storing the op comparison flag.
+// Float comparisons are from FloatCmpRegReg, UCOMISS compares its second
+// operand to its first in Go assembly.
+func cmpSetInstr(data OpDataType, op token.Token) Instruction {
 	switch op {
 	default:
 		ice(fmt.Sprintf("Unknown Op token (%v)", op))
 	case token.EQL:
-		asm += instrReg(ctx, SETEQ, result, false)
+		return SETEQ
 	case token.NEQ:
-		asm += instrReg(ctx, SETNE, result, false)
+		return SETNE
 	case token.LEQ:
-		// for some reason the SETXX are flipped for xmm compares
 		if data.op == OP_XMM {
-			asm += instrReg(ctx, SETCC, result, false)
-		} else {
-			if data.signed {
-				asm += instrReg(ctx, SETLE, result, false)
-			} else {
-				asm += instrReg(ctx, SETLS, result, false)
-			}
+			return SETCC
+		}
+		if data.signed {
+			return SETLE
 		}
+		return SETLS
 	case token.GEQ:
-		// for some reason the SETXX are flipped for xmm compares
+		// y <= x with the operands swapped
 		if data.op == OP_XMM {
-			asm += instrReg(ctx, SETLS, result, false)
-		} else {
-			if data.signed {
-				asm += instrReg(ctx, SETGE, result, false)
-			} else {
-				asm += instrReg(ctx, SETCC, result, false)
-			}
+			return SETCC
+		}
+		if data.signed {
+			return SETGE
 		}
+		return SETCC
 	case token.LSS:
-		// for some reason the SETXX are flipped for xmm compares
 		if data.op == OP_XMM {
-			asm += instrReg(ctx, SETHI, result, false)
-		} else {
-			if data.signed {
-				asm += instrReg(ctx, SETLT, result, false)
-			} else {
-				asm += instrReg(ctx, SETCS, result, false)
-			}
+			return SETHI
+		}
+		if data.signed {
+			return SETLT
 		}
+		return SETCS
 	case token.GTR:
-		// for some reason the SETXX are flipped for xmm compares
+		// y < x with the operands swapped
 		if data.op == OP_XMM {
-			asm += instrReg(ctx, SETCS, result, false)
-		} else {
-			if data.signed {
-				asm += instrReg(ctx, SETGT, result, false)
-			} else {
-				asm += instrReg(ctx, SETHI, result, false)
-			}
+			return SETHI
+		}
+		if data.signed {
+			return SETGT
 		}
+		return SETHI
+	}
+	return NONE
+}
+
+// setJmps maps SETXX instructions to the conditional jump on the same flags
+// and the conditional jump on the inverted flags.
+var setJmps = map[Instruction][2]Instruction{
+	SETEQ: {JEQ, JNE},
+	SETNE: {JNE, JEQ},
+	SETLE: {JLE, JGT},
+	SETGT: {JGT, JLE},
+	SETGE: {JGE, JLT},
+	SETLT: {JLT, JGE},
+	SETLS: {JLS, JHI},
+	SETHI: {JHI, JLS},
+	SETCC: {JCC, JCS},
+	SETCS: {JCS, JCC},
+}
+
+// setCMovs maps SETXX instructions to the 32 and 64 bit conditional moves
+// on the same flags.
+var setCMovs = map[Instruction][2]Instruction{
+	SETEQ: {CMOVLEQ, CMOVQEQ},
+	SETNE: {CMOVLNE, CMOVQNE},
+	SETLE: {CMOVLLE, CMOVQLE},
+	SETGT: {CMOVLGT, CMOVQGT},
+	SETGE: {CMOVLGE, CMOVQGE},
+	SETLT: {CMOVLLT, CMOVQLT},
+	SETLS: {CMOVLLS, CMOVQLS},
+	SETHI: {CMOVLHI, CMOVQHI},
+	SETCC: {CMOVLCC, CMOVQCC},
+	SETCS: {CMOVLCS, CMOVQCS},
+}
+
+// CMovOpRegReg moves src to dst if the op comparison of the preceding
+// CmpRegReg is true, values smaller than 8 bytes are moved with 32 bits.
+func CMovOpRegReg(ctx context, data OpDataType, op token.Token, src, dst *register, size uint) string {
+	cmovs := setCMovs[cmpSetInstr(data, op)]
+	if size == 8 {
+		return instrRegReg(ctx, cmovs[1], src, dst, false)
+	}
+	return instrRegReg(ctx, cmovs[0], src, dst, false)
+}
+
+// FloatMaxMin sets dst to the max, or min, of dst and src, src if either
+// is NaN or both are zero.
+func FloatMaxMin(ctx context, data OpDataType, max bool, src, dst *register) string {
+	instr := MINSS
+	if max {
+		instr = MAXSS
+	}
+	if data.xmmvariant == XMM_F64 {
+		instr = MINSD
+		if max {
+			instr = MAXSD
+		}
+	}
+	return instrRegReg(ctx, instr, src, dst, false)
+}
+
+// NegReg negates the integer in reg.
+func NegReg(ctx context, reg *register, size uint, spill bool) string {
+	var neg Instruction
+	switch size {
+	default:
+		ice(fmt.Sprintf("invalid size (%v)", size))
+	case 1:
+		neg = NEGB
+	case 2:
+		neg = NEGW
+	case 4:
+		neg = NEGL
+	case 8:
+		neg = NEGQ
+	}
+	return instrReg(ctx, neg, reg, spill)
+}
+
+// floatCmpPredicates are the CMPSS/CMPSD predicates of the comparisons,
+// there are none for GTR and GEQ, swap the operands of LSS and LEQ.
+var floatCmpPredicates = map[token.Token]uint8{
+	token.EQL: 0,
+	token.LSS: 1,
+	token.LEQ: 2,
+	token.NEQ: 4,
+}
+
+// FloatCmpMask sets dst to all ones if "dst op src" is true, otherwise to
+// zero. If negate is set the mask is of the negated comparison, which is
+// true for NaNs like Go's !(x < y).
+func FloatCmpMask(ctx context, data OpDataType, op token.Token, negate bool, src, dst *register) string {
+	pred, ok := floatCmpPredicates[op]
+	if !ok {
+		ice(fmt.Sprintf("unexpected comparison (%v)", op))
+	}
+	if negate {
+		// the NEQ, NLT and NLE predicates
+		pred ^= 4
+	}
+	cmp := CMPSS
+	if data.xmmvariant == XMM_F64 {
+		cmp = CMPSD
+	}
+	asm := dst.modified(ctx, false)
+	asm += fmt.Sprintf("%-9v    %v, %v, $%v\n", cmp, src.name, dst.name, pred)
+	return asm
+}
+
+// MovSignMaskReg loads the sign bit of a float of type data into dst, or
+// if abs is set every bit except the sign. The mask goes through the data
+// register tmp.
+func MovSignMaskReg(ctx context, data OpDataType, abs bool, tmp, dst *register) string {
+	mask := uint64(1) << 63
+	if data.xmmvariant == XMM_F32 {
+		mask = 1 << 31
+	}
+	if abs {
+		mask = mask - 1
+	}
+	asm := MovImmReg(ctx, int64(mask), 8, tmp, false)
+	asm += instrRegReg(ctx, MOVQ, tmp, dst, false)
+	return asm
+}
+
+// FloatBitwiseOp ands, ors or xors the bits of the floats in src and dst
+// into dst, AND_NOT sets dst to src &^ dst.
+func FloatBitwiseOp(ctx context, op token.Token, src, dst *register) string {
+	var instr Instruction
+	switch op {
+	default:
+		ice(fmt.Sprintf("unexpected op (%v)", op))
+	case token.AND:
+		instr = ANDPS
+	case token.OR:
+		instr = ORPS
+	case token.XOR:
+		instr = XORPS
+	case token.AND_NOT:
+		instr = ANDNPS
+	}
+	return instrRegReg(ctx, instr, src, dst, false)
+}
+
+// MovByteOrderMemReg loads the 4 or 8 byte integer at (src) into dst. If
+// swap is set the bytes are reversed, with MOVBE if movbe is set otherwise
+// with BSWAP after the load.
+func MovByteOrderMemReg(ctx context, size uint, swap, movbe bool, src, dst *register) string {
+	mov, bswap, movbeInstr := byteOrderInstrs(size)
+	if swap && movbe {
+		return instrMemReg(ctx, movbeInstr, "", 0, src, dst, false)
+	}
+	asm := instrMemReg(ctx, mov, "", 0, src, dst, false)
+	if swap {
+		asm += instrReg(ctx, bswap, dst, false)
+	}
+	return asm
+}
+
+// MovByteOrderRegMem stores the 4 or 8 byte integer in src to (dst) like
+// MovByteOrderMemReg, without MOVBE src is reversed in tmp.
+func MovByteOrderRegMem(ctx context, size uint, swap, movbe bool, src, dst, tmp *register) string {
+	mov, bswap, movbeInstr := byteOrderInstrs(size)
+	if swap && movbe {
+		return instrRegMem(ctx, movbeInstr, src, dst, "", 0, false)
+	}
+	if !swap {
+		return instrRegMem(ctx, mov, src, dst, "", 0, false)
+	}
+	asm := instrRegReg(ctx, MOVQ, src, tmp, false)
+	asm += instrReg(ctx, bswap, tmp, false)
+	asm += instrRegMem(ctx, mov, tmp, dst, "", 0, false)
+	return asm
+}
+
+// atomicInstrs returns the exchange and add, exchange, and compare and
+// exchange instructions of size, 4 or 8, bytes.
+func atomicInstrs(size uint) (xadd, xchg, cmpxchg Instruction) {
+	switch size {
+	default:
+		ice(fmt.Sprintf("invalid size (%v)", size))
+	case 4:
+		return XADDL, XCHGL, CMPXCHGL
+	case 8:
+		return XADDQ, XCHGQ, CMPXCHGQ
 	}
+	return
+}
+
+// AtomicAddRegMem atomically adds delta to the integer of size bytes at
+// ptr, like atomic.AddUint64 result is the new value.
+func AtomicAddRegMem(ctx context, size uint, delta, ptr, result *register) string {
+	xadd, _, _ := atomicInstrs(size)
+	dt := GetIntegerOpDataType(false, size)
+	asm := MovRegReg(ctx, dt, delta, result, false)
+	asm += fmt.Sprintf("%v\n", LOCK)
+	asm += instrRegMem(ctx, xadd, result, ptr, "", 0, false)
+	asm += AddRegReg(ctx, dt, delta, result, false)
+	return asm
+}
+
+// AtomicStoreRegMem stores v to the integer of size bytes at ptr with
+// XCHG, which orders it after the preceding loads and stores like Go's
+// atomic stores. tmp is a scratch register.
+func AtomicStoreRegMem(ctx context, size uint, v, ptr, tmp *register) string {
+	_, xchg, _ := atomicInstrs(size)
+	asm := MovRegReg(ctx, GetIntegerOpDataType(false, size), v, tmp, false)
+	asm += instrRegMem(ctx, xchg, tmp, ptr, "", 0, false)
+	return asm
+}
+
+// AtomicCasRegMem sets the integer of size bytes at ptr to val if it's
+// old, result is set to whether it was swapped. AX is clobbered.
+func AtomicCasRegMem(ctx context, size uint, old, val, ptr, result *register) string {
+	_, _, cmpxchg := atomicInstrs(size)
+	rax := getRegister(REG_AX)
+	asm := MovRegReg(ctx, GetIntegerOpDataType(false, size), old, rax, false)
+	asm += fmt.Sprintf("%v\n", LOCK)
+	asm += instrRegMem(ctx, cmpxchg, val, ptr, "", 0, false)
+	asm += instrReg(ctx, SETEQ, result, false)
+	return asm
+}
+
+func byteOrderInstrs(size uint) (mov, bswap, movbe Instruction) {
+	switch size {
+	default:
+		ice(fmt.Sprintf("invalid size (%v)", size))
+	case 4:
+		return MOVL, BSWAPL, MOVBELL
+	case 8:
+		return MOVQ, BSWAPQ, MOVBEQQ
+	}
+	return
+}
+
+// CmpJmp returns the conditional jumps to label taken after CmpRegReg, or
+// FloatCmpRegReg, if the op comparison is true, or if negate is set, if it's
+// false. Floats that are NaN are unequal, so with unordered operands, PF
+// set, EQL isn't taken and NEQ is.
+func CmpJmp(ctx context, data OpDataType, op token.Token, negate bool, label string) string {
+	jmps := setJmps[cmpSetInstr(data, op)]
+	jcc := jmps[0]
+	if negate {
+		jcc = jmps[1]
+	}
+	if data.op != OP_XMM || (op != token.EQL && op != token.NEQ) {
+		return fmt.Sprintf("%-9v    %v\n", jcc, label)
+	}
+	if jcc == JNE {
+		asm := fmt.Sprintf("%-9v    %v\n", JNE, label)
+		asm += fmt.Sprintf("%-9v    %v\n", JPS, label)
+		return asm
+	}
+	skip := ctx.newLabel("unordered")
+	asm := fmt.Sprintf("%-9v    %v\n", JPS, skip)
+	asm += fmt.Sprintf("%-9v    %v\n", JEQ, label)
+	asm += skip + ":\n"
 	return asm
 }
 
@@ -1374,39 +2050,134 @@ func IntegerToFloat(ctx context, from, to *register, ftype, totype OpDataType, t
 	tosize := XmmInstrDataSize(totype.xmmvariant)
 	fromsize := ftype.size
 	fromreg := from
+	asm := ""
 	// no direct conversion from int8/int16 to float32/float64
 	if ftype.size < 4 {
 		fromsize = 4
 		fromreg = tmp
 		if ftype.signed {
-			MovSignExtend(ctx, from, tmp, ftype.size, fromsize, false)
+			asm += MovSignExtend(ctx, from, tmp, ftype.size, fromsize, false)
 		} else {
-			MovZeroExtend(ctx, from, tmp, ftype.size, fromsize, false)
+			asm += MovZeroExtend(ctx, from, tmp, ftype.size, fromsize, false)
 		}
 	} else if ftype.size == 4 && !ftype.signed {
 		fromsize = 8
 		fromreg = tmp
-		MovZeroExtend(ctx, from, tmp, ftype.size, fromsize, false)
+		asm += MovZeroExtend(ctx, from, tmp, ftype.size, fromsize, false)
 	}
 	cvt := GetConvertInstruction(I_CVT_INT2FLOAT, fromsize, tosize)
-	return instrRegReg(ctx, cvt, fromreg, to, false)
+	return asm + instrRegReg(ctx, cvt, fromreg, to, false)
 }
 
 func FloatToInteger(ctx context, from, to *register, ftype, totype OpDataType) string {
 	// From Go Spec:
 	// When converting a floating-point number to an integer,
 	// the fraction is discarded (truncation towards zero).
+	// Out of range values are implementation defined, like the gc compiler
+	// they're truncated to 32 bits for 1, 2, and 4 byte signed integers, the
+	// 8 and 16 bit ones keep the low bits, and to 64 bits for uint32 so
+	// values up to 2^32-1 fit.
 	fromsize := XmmInstrDataSize(ftype.xmmvariant)
-	cvt := GetConvertInstruction(I_CVT_FLOAT2INT, fromsize, totype.size)
+	tosize := totype.size
+	if tosize == 4 && !totype.signed {
+		tosize = 8
+	}
+	cvt := GetConvertInstruction(I_CVT_FLOAT2INT, fromsize, tosize)
 	return instrRegReg(ctx, cvt, from, to, false)
 }
 
+// FloatToUint64 truncates the float from to the uint64 to like the gc
+// compiler, values below 2^63, including negative ones, are converted as
+// int64s, others have 2^63 subtracted before converting, and then the top
+// bit set. Values that don't fit, and NaN, are 0x8000000000000000. tmp is a
+// scratch register and xtmp a scratch xmm register.
+func FloatToUint64(ctx context, from, to *register, ftype OpDataType, tmp, xtmp *register) string {
+	const cutoff = 1 << 63
+	fromsize := XmmInstrDataSize(ftype.xmmvariant)
+	cvt := GetConvertInstruction(I_CVT_FLOAT2INT, fromsize, 8)
+	small := ctx.newLabel("small")
+	done := ctx.newLabel("cvtdone")
+	asm := MovFloatConstReg(ctx, cutoff, fromsize == 4, xtmp, false)
+	asm += FloatCmpRegReg(ctx, ftype, token.LSS, from, xtmp)
+	asm += CmpJmp(ctx, ftype, token.LSS, false, small)
+	asm += MovRegReg(ctx, ftype, from, xtmp, false)
+	asm += fmt.Sprintf("%-9v    $(%v), %v\n", GetInstr(I_SUB, ftype), strconv.FormatFloat(cutoff, 'e', -1, 64), xtmp.name)
+	asm += instrRegReg(ctx, cvt, xtmp, to, false)
+	asm += MovImmReg(ctx, math.MinInt64, 8, tmp, false)
+	asm += OrRegReg(ctx, tmp, to, false)
+	asm += fmt.Sprintf("%-9v    %v\n", "JMP", done)
+	asm += small + ":\n"
+	asm += instrRegReg(ctx, cvt, from, to, false)
+	asm += done + ":\n"
+	return asm
+}
+
 func FloatToFloat(ctx context, from, to *register, ftype, totype OpDataType) string {
 	fromsize := XmmInstrDataSize(ftype.xmmvariant)
-	cvt := GetConvertInstruction(I_CVT_FLOAT2FLOAT, fromsize, totype.size)
+	tosize := XmmInstrDataSize(totype.xmmvariant)
+	cvt := GetConvertInstruction(I_CVT_FLOAT2FLOAT, fromsize, tosize)
 	return instrRegReg(ctx, cvt, from, to, false)
 }
 
 func Ret() string {
 	return fmt.Sprintf("RET\n")
 }
+
+// CallFn calls the function symbol fn, e.g. "·f(SB)".
+func CallFn(fn string) string {
+	return fmt.Sprintf("%-9v    %v\n", "CALL", fn)
+}
+
+// JumpTableIndex loads the stub index of idx from the byte table name into
+// idx, tmp holds the table's address.
+func JumpTableIndex(ctx context, name string, idx, tmp *register) string {
+	if idx.width != 64 || tmp.width != 64 {
+		ice("Invalid register width")
+	}
+	asm := tmp.modified(ctx, false)
+	asm += fmt.Sprintf("%-9v    %v, %v\n", LEAQ, DataRef(name), tmp.name)
+	asm += idx.modified(ctx, false)
+	asm += fmt.Sprintf("%-9v    (%v)(%v*1), %v\n", MOVBQZX, tmp.name, idx.name, idx.name)
+	return asm
+}
+
+// MovDataReg loads the 16 bytes of the data symbol name into the xmm
+// register dst.
+func MovDataReg(ctx context, name string, dst *register) string {
+	if dst.typ != XMM_REG {
+		ice("Invalid register type")
+	}
+	asm := dst.modified(ctx, false)
+	asm += fmt.Sprintf("%-9v    %v, %v\n", MOVOU, DataRef(name), dst.name)
+	return asm
+}
+
+// JumpTableStubs jumps to labels[idx] through the stubs following the call
+// to helper, from JumpTableHelper. Each stub is a JMP aligned to 8 bytes,
+// the stub index is passed at 0(SP).
+func JumpTableStubs(ctx context, helper string, idx *register, labels []string) string {
+	asm := fmt.Sprintf("%-9v    %v, 0(SP)\n", MOVQ, idx.name)
+	asm += CallFn(helper)
+	for _, label := range labels {
+		asm += fmt.Sprintf("%-9v    $8\n", "PCALIGN")
+		asm += fmt.Sprintf("%-9v    %v\n", "JMP", label)
+	}
+	return asm
+}
+
+// JumpTableHelper returns the function name jumping to the stub index at
+// 8(SP) of the stubs after its call, by replacing its return address. The
+// return address rounded up to 8 bytes is the first stub.
+func JumpTableHelper(name, indent string) string {
+	asm := fmt.Sprintf("%-9v    %v\n", PUSHQ, "AX")
+	asm += fmt.Sprintf("%-9v    8(SP), AX\n", MOVQ)
+	asm += fmt.Sprintf("%-9v    $7, AX\n", ADDQ)
+	asm += fmt.Sprintf("%-9v    $-8, AX\n", ANDQ)
+	asm += fmt.Sprintf("%-9v    AX, 8(SP)\n", MOVQ)
+	asm += fmt.Sprintf("%-9v    16(SP), AX\n", MOVQ)
+	asm += fmt.Sprintf("%-9v    $3, AX\n", SHLQ)
+	asm += fmt.Sprintf("%-9v    AX, 8(SP)\n", ADDQ)
+	asm += fmt.Sprintf("%-9v    %v\n", POPQ, "AX")
+	asm += Ret()
+	return fmt.Sprintf("TEXT %v,NOSPLIT|NOFRAME,$0-0\n", name) + addIndent(asm, indent)
+}
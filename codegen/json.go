@@ -0,0 +1,60 @@
+package codegen
+
+// JSONOutput is the document of the gensimd command's -json mode, the
+// results of generating the functions of a file for build systems. Marshal
+// it with encoding/json.
+type JSONOutput struct {
+	// APIVersion is the version of the Options, Result, and JSON contract
+	APIVersion int `json:"apiVersion"`
+	// File is the input Go file
+	File string `json:"file"`
+	// OK is false if any function failed
+	OK        bool           `json:"ok"`
+	Functions []JSONFunction `json:"functions"`
+	// Asm is the assembly file of the functions, empty unless OK
+	Asm string `json:"asm,omitempty"`
+}
+
+// JSONFunction is the result of generating a function in a JSONOutput.
+type JSONFunction struct {
+	// Name is the Go function name, OutName the assembly function name
+	Name    string `json:"name"`
+	OutName string `json:"outName"`
+	// Asm is the function's assembly, starting with its TEXT line
+	Asm string `json:"asm,omitempty"`
+	// Decl is the Go declaration of the assembly function
+	Decl string `json:"decl,omitempty"`
+	// Features are the CPU features the function requires, Target constants
+	Features    []string         `json:"features,omitempty"`
+	Stats       *Stats           `json:"stats,omitempty"`
+	Diagnostics []JSONDiagnostic `json:"diagnostics,omitempty"`
+}
+
+// JSONDiagnostic is a Diagnostic of a JSONFunction.
+type JSONDiagnostic struct {
+	// Pos is "file:line:column", empty if unknown
+	Pos string `json:"pos,omitempty"`
+	Msg string `json:"msg"`
+}
+
+// NewJSONFunction returns the JSON result of generating the Go function
+// name as outName, result is from Compile or filled in the same way.
+func NewJSONFunction(name, outName string, result Result) JSONFunction {
+	fn := JSONFunction{Name: name, OutName: outName}
+	for _, d := range result.Diagnostics {
+		jd := JSONDiagnostic{Msg: d.Msg}
+		if d.Pos.IsValid() {
+			jd.Pos = d.Pos.String()
+		}
+		fn.Diagnostics = append(fn.Diagnostics, jd)
+	}
+	if len(result.Diagnostics) > 0 || result.Asm == "" {
+		return fn
+	}
+	fn.Asm = result.Asm
+	fn.Decl = result.Decl
+	fn.Features = result.Layout.Features
+	stats := result.Stats
+	fn.Stats = &stats
+	return fn
+}
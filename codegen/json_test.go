@@ -0,0 +1,36 @@
+package codegen
+
+import (
+	"encoding/json"
+	"go/token"
+	"testing"
+)
+
+func TestNewJSONFunction(t *testing.T) {
+	result := Result{
+		Asm:    "TEXT ·adds(SB),$0-24\n",
+		Decl:   "func adds(x, y int) int",
+		Layout: Layout{Features: []string{TargetSSE2}},
+		Stats:  Stats{Name: "adds", Instrs: 4, Cycles: 12},
+	}
+	doc, err := json.Marshal(NewJSONFunction("add", "adds", result))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `{"name":"add","outName":"adds","asm":"TEXT ·adds(SB),$0-24\n","decl":"func adds(x, y int) int",` +
+		`"features":["sse2"],"stats":{"name":"adds","instrs":4,"cycles":12,"frameSize":0,"spills":0,"vectorPercent":0}}`
+	if string(doc) != expected {
+		t.Errorf("got %v, expected %v", string(doc), expected)
+	}
+
+	pos := token.Position{Filename: "add.go", Line: 3, Column: 2}
+	failed := Result{Asm: "TEXT", Diagnostics: []Diagnostic{{pos, "unsupported"}, {Msg: "no position"}}}
+	doc, err = json.Marshal(NewJSONFunction("add", "adds", failed))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected = `{"name":"add","outName":"adds","diagnostics":[{"pos":"add.go:3:2","msg":"unsupported"},{"msg":"no position"}]}`
+	if string(doc) != expected {
+		t.Errorf("got %v, expected %v", string(doc), expected)
+	}
+}
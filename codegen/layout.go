@@ -0,0 +1,179 @@
+package codegen
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"golang.org/x/tools/go/ssa"
+)
+
+// BlockFreqs maps function names to block indexes to execution counts.
+type BlockFreqs map[string]map[int]uint64
+
+// ReadBlockFreqs reads a block frequency hint file, each line is
+// "funcname blockindex count", blank lines and lines starting with # are skipped.
+func ReadBlockFreqs(r io.Reader) (BlockFreqs, error) {
+	freqs := BlockFreqs{}
+	scanner := bufio.NewScanner(r)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		fields := strings.Fields(text)
+		if len(fields) != 3 {
+			return nil, fmt.Errorf("line %v: expected \"funcname blockindex count\"", line)
+		}
+		index, err := strconv.Atoi(fields[1])
+		if err != nil || index < 0 {
+			return nil, fmt.Errorf("line %v: invalid block index (%v)", line, fields[1])
+		}
+		count, err := strconv.ParseUint(fields[2], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("line %v: invalid count (%v)", line, fields[2])
+		}
+		if freqs[fields[0]] == nil {
+			freqs[fields[0]] = map[int]uint64{}
+		}
+		freqs[fields[0]][index] = count
+	}
+	return freqs, scanner.Err()
+}
+
+// blockOrder returns the order to emit the basic blocks in, the likely
+// successor of each block follows it and cold blocks are moved to the end.
+// Without optimizations the blocks are in index order.
+func (f *Function) blockOrder() []*ssa.BasicBlock {
+	blocks := f.ssa.Blocks
+	if !f.Optimize || len(blocks) == 0 {
+		return blocks
+	}
+	weights := f.blockWeights()
+	placed := make([]bool, len(blocks))
+	order := make([]*ssa.BasicBlock, 0, len(blocks))
+	next := blocks[0]
+	for next != nil {
+		order = append(order, next)
+		placed[next.Index] = true
+		b := next
+		next = nil
+		// continue the chain with the heaviest unplaced successor
+		for _, succ := range b.Succs {
+			if placed[succ.Index] || weights[succ.Index] < 0 {
+				continue
+			}
+			if next == nil || weights[succ.Index] > weights[next.Index] {
+				next = succ
+			}
+		}
+		if next != nil {
+			continue
+		}
+		// start a new chain with the first unplaced warm block
+		for _, blk := range blocks {
+			if !placed[blk.Index] && weights[blk.Index] >= 0 {
+				next = blk
+				break
+			}
+		}
+	}
+	// cold blocks last, in index order
+	for _, blk := range blocks {
+		if !placed[blk.Index] {
+			order = append(order, blk)
+		}
+	}
+	return order
+}
+
+// blockWeights returns the estimated execution frequency of each block
+// indexed by block index, cold blocks have a negative weight.
+// Without a frequency hint a block's weight is its loop depth.
+func (f *Function) blockWeights() []int64 {
+	blocks := f.ssa.Blocks
+	weights := make([]int64, len(blocks))
+	freqs, hint := f.BlockFreqs[f.ssa.Name()]
+	if !hint {
+		weights = loopDepths(f.ssa)
+	}
+	for _, blk := range blocks {
+		if hint {
+			if count, ok := freqs[blk.Index]; ok && count > 0 {
+				weights[blk.Index] = int64(count)
+			} else {
+				weights[blk.Index] = -1
+			}
+		}
+		if isColdBlock(blk) {
+			weights[blk.Index] = -1
+		}
+	}
+	// the entry block is always first
+	if weights[0] < 0 {
+		weights[0] = 0
+	}
+	return weights
+}
+
+// isColdBlock returns true if blk ends in a panic.
+func isColdBlock(blk *ssa.BasicBlock) bool {
+	if len(blk.Instrs) == 0 {
+		return false
+	}
+	_, ok := blk.Instrs[len(blk.Instrs)-1].(*ssa.Panic)
+	return ok
+}
+
+// naturalLoop is the blocks of a loop found from a back edge.
+type naturalLoop struct {
+	header, latch *ssa.BasicBlock
+	// inLoop is indexed by block index
+	inLoop []bool
+}
+
+// naturalLoops returns the loop of each back edge of fn, an edge from a
+// block to a block dominating it.
+func naturalLoops(fn *ssa.Function) []naturalLoop {
+	loops := []naturalLoop{}
+	for _, latch := range fn.Blocks {
+		for _, header := range latch.Succs {
+			if !header.Dominates(latch) {
+				continue
+			}
+			// the loop body is the blocks reaching latch without passing header
+			inLoop := make([]bool, len(fn.Blocks))
+			inLoop[header.Index] = true
+			work := []*ssa.BasicBlock{latch}
+			for len(work) > 0 {
+				blk := work[len(work)-1]
+				work = work[:len(work)-1]
+				if inLoop[blk.Index] {
+					continue
+				}
+				inLoop[blk.Index] = true
+				work = append(work, blk.Preds...)
+			}
+			loops = append(loops, naturalLoop{header, latch, inLoop})
+		}
+	}
+	return loops
+}
+
+// loopDepths returns the number of loops containing each block, indexed by
+// block index.
+func loopDepths(fn *ssa.Function) []int64 {
+	depths := make([]int64, len(fn.Blocks))
+	for _, loop := range naturalLoops(fn) {
+		for i, in := range loop.inLoop {
+			if in {
+				depths[i]++
+			}
+		}
+	}
+	return depths
+}
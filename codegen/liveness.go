@@ -35,10 +35,9 @@ func aliveTest(ident *identifier, loc ssa.Instruction, after bool) bool {
 			}
 		}
 		if start {
-			ops := i.Operands(nil)
-			for _, op := range ops {
+			for _, op := range ident.f.instrOperands(i) {
 				// instruction at or after loc uses ident as an operand
-				if op != nil && *op == value {
+				if op != nil && ident.f.cseValue(*op) == value {
 					return true
 				}
 			}
@@ -65,7 +64,16 @@ func getBlocks(ident *identifier) []*ssa.BasicBlock {
 }
 
 func inBlock(ident *identifier, b *ssa.BasicBlock) bool {
+	// a value used only by other blocks, e.g. the len(x) of a range loop
+	// test, spans its defining block too
+	if instr, ok := ident.ssaValue().(ssa.Instruction); ok && instr.Block() == b {
+		return true
+	}
 	for _, i := range b.Instrs {
+		// debug references generate no code
+		if _, ok := i.(*ssa.DebugRef); ok {
+			continue
+		}
 		if ident.isRetIdent() {
 			if _, ok := i.(*ssa.Return); ok {
 				return true
@@ -73,8 +81,8 @@ func inBlock(ident *identifier, b *ssa.BasicBlock) bool {
 		}
 		ops := i.Operands(nil)
 		for _, op := range ops {
-			if op != nil {
-				if (*op).Name() == ident.name {
+			if op != nil && *op != nil {
+				if ident.f.cseValue(*op).Name() == ident.name {
 					return true
 				}
 			}
@@ -82,3 +90,28 @@ func inBlock(ident *identifier, b *ssa.BasicBlock) bool {
 	}
 	return false
 }
+
+// instrOperands returns the operands of i, with the values read by the
+// instructions lowering it in place of others. Debug references generate
+// no code and read nothing, so the assembly is the same with and without
+// them.
+func (f *Function) instrOperands(i ssa.Instruction) []*ssa.Value {
+	if _, ok := i.(*ssa.DebugRef); ok {
+		return nil
+	}
+	ops := i.Operands(nil)
+	if or, ok := i.(*ssa.BinOp); ok {
+		// a fused load reads its slice and index instead of the
+		// instructions it replaces
+		if load, ok := f.fusedLoads[or]; ok {
+			ops = append(ops, &load.slice, &load.base)
+		}
+	}
+	if ifInstr, ok := i.(*ssa.If); ok {
+		// a jump table reads the switch operand instead of the comparison
+		if jt, ok := f.jumpTables[ifInstr]; ok {
+			ops = append(ops, &jt.sw.X)
+		}
+	}
+	return ops
+}
@@ -0,0 +1,292 @@
+package codegen
+
+import (
+	"fmt"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/ssa"
+)
+
+// fusedLoad is a 4 or 8 byte integer or'ed together from consecutive bytes
+// of a []byte, like "uint32(b[0]) | uint32(b[1])<<8 | uint32(b[2])<<16 |
+// uint32(b[3])<<24", loaded by a single MOVL/MOVQ. The bytes start at
+// b[base+offset], base is nil for a constant index.
+type fusedLoad struct {
+	slice     ssa.Value
+	base      ssa.Value
+	offset    int64
+	size      uint
+	bigEndian bool
+}
+
+// fusedByte is a byte of a fusedLoad, b[index+offset] shifted left by shift
+// bits.
+type fusedByte struct {
+	slice  ssa.Value
+	index  ssa.Value
+	offset int64
+	shift  int64
+}
+
+// computeFusedLoads finds the ors of shifted bytes loaded as one integer
+// and the instructions computing them, which aren't emitted.
+func (f *Function) computeFusedLoads() {
+	f.fusedLoads = make(map[*ssa.BinOp]*fusedLoad)
+	f.fusedInstrs = make(map[ssa.Instruction]bool)
+	if !f.Optimize {
+		return
+	}
+	for _, block := range f.ssa.Blocks {
+		// the outermost or of a chain is after the others
+		for i := len(block.Instrs) - 1; i >= 0; i-- {
+			or, ok := block.Instrs[i].(*ssa.BinOp)
+			if !ok || or.Op != token.OR || f.fusedInstrs[or] {
+				continue
+			}
+			load, parts, ok := matchFusedLoad(or)
+			if !ok || spansBarrier(or, parts) {
+				continue
+			}
+			f.fusedLoads[or] = load
+			for _, part := range parts {
+				f.fusedInstrs[part] = true
+			}
+		}
+	}
+}
+
+// matchFusedLoad returns the load of or and the instructions it replaces.
+func matchFusedLoad(or *ssa.BinOp) (*fusedLoad, []ssa.Instruction, bool) {
+	t := or.Type()
+	if !isInteger(t) {
+		return nil, nil, false
+	}
+	size := sizeof(t)
+	if size != 4 && size != 8 {
+		return nil, nil, false
+	}
+	var bytes []fusedByte
+	var parts []ssa.Instruction
+	if !fusedBytes(or, or, &bytes, &parts) || uint(len(bytes)) != size {
+		return nil, nil, false
+	}
+	load := &fusedLoad{slice: bytes[0].slice, base: bytes[0].index, offset: bytes[0].offset, size: size}
+	for _, b := range bytes {
+		if b.slice != load.slice || b.index != load.base {
+			return nil, nil, false
+		}
+		if b.index != nil && sizeof(b.index.Type()) != DataRegSize {
+			return nil, nil, false
+		}
+		if b.offset < load.offset {
+			load.offset = b.offset
+		}
+	}
+	// the byte shifted by 8*k is at offset k, or size-1-k for big endian
+	seen := make([]bool, size)
+	for i := range []bool{false, true} {
+		load.bigEndian = i == 1
+		for k := range seen {
+			seen[k] = false
+		}
+		ok := true
+		for _, b := range bytes {
+			k := b.shift / 8
+			if b.shift%8 != 0 || k < 0 || k >= int64(size) || seen[k] {
+				return nil, nil, false
+			}
+			seen[k] = true
+			pos := k
+			if load.bigEndian {
+				pos = int64(size) - 1 - k
+			}
+			if b.offset-load.offset != pos {
+				ok = false
+			}
+		}
+		if ok {
+			if !noStoresBetween(parts, or) {
+				return nil, nil, false
+			}
+			return load, parts, true
+		}
+	}
+	return nil, nil, false
+}
+
+// fusedBytes appends the shifted bytes or'ed together by v to bytes and the
+// instructions computing them to parts. Every instruction except root must
+// only be used by the next.
+func fusedBytes(root *ssa.BinOp, v ssa.Value, bytes *[]fusedByte, parts *[]ssa.Instruction) bool {
+	instr, ok := v.(ssa.Instruction)
+	if !ok || instr.Block() != root.Block() {
+		return false
+	}
+	if v != root {
+		if len(nonDebugRefs(v)) != 1 {
+			return false
+		}
+		*parts = append(*parts, instr)
+	}
+	if or, ok := v.(*ssa.BinOp); ok && or.Op == token.OR {
+		return fusedBytes(root, or.X, bytes, parts) && fusedBytes(root, or.Y, bytes, parts)
+	}
+	if v == root {
+		return false
+	}
+	b := fusedByte{}
+	if shl, ok := v.(*ssa.BinOp); ok && shl.Op == token.SHL {
+		count, ok := shl.Y.(*ssa.Const)
+		if !ok {
+			return false
+		}
+		b.shift = count.Int64()
+		v = shl.X
+		if instr, ok = v.(ssa.Instruction); !ok || instr.Block() != root.Block() || len(nonDebugRefs(v)) != 1 {
+			return false
+		}
+		*parts = append(*parts, instr)
+	}
+	// uint64(*&b[i])
+	conv, ok := v.(*ssa.Convert)
+	if !ok || !isUint8(conv.X.Type()) {
+		return false
+	}
+	load, ok := conv.X.(*ssa.UnOp)
+	if !ok || load.Op != token.MUL || load.Block() != root.Block() || len(nonDebugRefs(load)) != 1 {
+		return false
+	}
+	addr, ok := load.X.(*ssa.IndexAddr)
+	if !ok || addr.Block() != root.Block() || !isSlice(addr.X.Type()) || len(nonDebugRefs(addr)) != 1 {
+		return false
+	}
+	*parts = append(*parts, conv, load, addr)
+	b.slice = addr.X
+	b.index, b.offset = indexOffset(addr.Index)
+	*bytes = append(*bytes, b)
+	return true
+}
+
+// indexOffset splits index into a value and a constant, the value is nil
+// for a constant index.
+func indexOffset(index ssa.Value) (ssa.Value, int64) {
+	if c, ok := index.(*ssa.Const); ok {
+		return nil, c.Int64()
+	}
+	if add, ok := index.(*ssa.BinOp); ok && add.Op == token.ADD {
+		if c, ok := add.Y.(*ssa.Const); ok {
+			return add.X, c.Int64()
+		}
+		if c, ok := add.X.(*ssa.Const); ok {
+			return add.Y, c.Int64()
+		}
+	}
+	return index, 0
+}
+
+// noStoresBetween returns true if no instruction between the first of parts
+// and root can write memory, the bytes are read at root.
+func noStoresBetween(parts []ssa.Instruction, root *ssa.BinOp) bool {
+	inParts := make(map[ssa.Instruction]bool)
+	for _, part := range parts {
+		inParts[part] = true
+	}
+	started := false
+	for _, instr := range root.Block().Instrs {
+		if instr == root {
+			return true
+		}
+		if inParts[instr] {
+			started = true
+		}
+		if !started {
+			continue
+		}
+		switch instr.(type) {
+		case *ssa.Store, *ssa.Call, *ssa.MapUpdate, *ssa.Send, *ssa.Go, *ssa.Defer:
+			return false
+		}
+	}
+	return false
+}
+
+// nonDebugRefs returns the referrers of v except debug references.
+func nonDebugRefs(v ssa.Value) []ssa.Instruction {
+	refs := v.Referrers()
+	if refs == nil {
+		return nil
+	}
+	var instrs []ssa.Instruction
+	for _, ref := range *refs {
+		if _, ok := ref.(*ssa.DebugRef); !ok {
+			instrs = append(instrs, ref)
+		}
+	}
+	return instrs
+}
+
+func isUint8(t types.Type) bool {
+	b, ok := t.Underlying().(*types.Basic)
+	return ok && b.Kind() == types.Uint8
+}
+
+// FusedLoad loads the integer of instr from its bytes, checking the first
+// and last index are in range if bounds checks are on.
+func (f *Function) FusedLoad(instr *ssa.BinOp, load *fusedLoad) (string, *Error) {
+	ctx := context{f, instr}
+	asm := ""
+	slice := f.Ident(load.slice)
+	// the index of the first byte, nil if it's the constant load.offset
+	var idx *register
+	if load.base != nil {
+		a, reg, err := f.LoadValue(instr, load.base, 0, f.sizeof(load.base))
+		if err != nil {
+			return "", err
+		}
+		asm += a
+		a, idx = f.allocReg(instr, DATA_REG, DataRegSize)
+		asm += a
+		asm += MovRegReg(ctx, GetIntegerOpDataType(false, DataRegSize), reg, idx, false)
+		f.freeReg(reg)
+		if load.offset != 0 {
+			asm += ImmOp(ctx, GetIntegerOpDataType(true, DataRegSize), token.ADD, idx, load.offset, idx)
+		}
+	}
+	if f.opts.BoundsCheck {
+		if idx != nil {
+			// the first and last index, idx is restored after
+			optypes := GetIntegerOpDataType(true, DataRegSize)
+			asm += f.BoundsCheck(instr, slice, idx)
+			asm += ImmOp(ctx, optypes, token.ADD, idx, int64(load.size-1), idx)
+			asm += f.BoundsCheck(instr, slice, idx)
+			asm += ImmOp(ctx, optypes, token.SUB, idx, int64(load.size-1), idx)
+		} else {
+			asm += f.LenCheck(instr, slice, uint32(load.offset)+uint32(load.size))
+		}
+	}
+	xReg, xOffset, _ := slice.Addr()
+	a, addr := f.allocReg(instr, DATA_REG, DataRegSize)
+	asm += a
+	asm += MovMemReg(ctx, GetIntegerOpDataType(false, sizePtr()), slice.name, xOffset, &xReg, addr, false)
+	if idx != nil {
+		asm += AddRegReg(ctx, GetIntegerOpDataType(false, DataRegSize), idx, addr, false)
+		f.freeReg(idx)
+	} else if load.offset != 0 {
+		asm += AddImm32Reg(ctx, uint32(load.offset), addr, false)
+	}
+	ident := f.Ident(instr)
+	a, dst := f.allocIdentReg(instr, ident, DataRegSize)
+	asm += a
+	asm += MovByteOrderMemReg(ctx, load.size, load.bigEndian, f.hasTarget(instrTargets[MOVBELL]), addr, dst)
+	f.freeReg(addr)
+	a, err := f.StoreValue(instr, ident, dst)
+	f.freeReg(dst)
+	if err != nil {
+		return "", err
+	}
+	asm += a
+	asm = fmt.Sprintf("// BEGIN fused load, %v = %v\n", instr.Name(), instr) + asm
+	asm += fmt.Sprintf("// END fused load, %v = %v\n", instr.Name(), instr)
+	return asm, nil
+}
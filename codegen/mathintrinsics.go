@@ -0,0 +1,77 @@
+package codegen
+
+import (
+	"fmt"
+	"go/token"
+
+	"golang.org/x/tools/go/ssa"
+)
+
+// mathIntrinsics are the functions of package math computed inline by
+// masking the sign bit.
+var mathIntrinsics = map[string]bool{
+	"Abs":      true,
+	"Copysign": true,
+}
+
+// isMathIntrinsic returns the name of the math function call calls, if it's
+// computed inline.
+func isMathIntrinsic(call *ssa.Call) (string, bool) {
+	callee := call.Common().StaticCallee()
+	if callee == nil || callee.Pkg == nil || callee.Pkg.Pkg.Path() != "math" {
+		return "", false
+	}
+	return callee.Name(), mathIntrinsics[callee.Name()]
+}
+
+// MathIntrinsic computes math.Abs(x) as x &^ sign and math.Copysign(x, y)
+// as x &^ sign | y & sign.
+func (f *Function) MathIntrinsic(call *ssa.Call, name string) (string, *Error) {
+	ctx := context{f, call}
+	args := call.Common().Args
+	ident := f.Ident(call)
+	data := GetOpDataType(call.Type())
+	asm, x, err := f.LoadValueSimple(call, args[0])
+	if err != nil {
+		return asm, err
+	}
+	a, result := f.allocIdentReg(call, ident, ident.size())
+	asm += a
+	a, tmp := f.allocReg(call, DATA_REG, DataRegSize)
+	asm += a
+	a, mask := f.allocReg(call, XMM_REG, ident.size())
+	asm += a
+	asm += MovRegReg(ctx, data, x, result, false)
+	f.freeReg(x)
+	switch name {
+	default:
+		ice(fmt.Sprintf("unknown math intrinsic (%v)", name))
+	case "Abs":
+		asm += MovSignMaskReg(ctx, data, true, tmp, mask)
+		asm += FloatBitwiseOp(ctx, token.AND, mask, result)
+	case "Copysign":
+		a, y, err := f.LoadValueSimple(call, args[1])
+		if err != nil {
+			return asm, err
+		}
+		asm += a
+		asm += MovSignMaskReg(ctx, data, false, tmp, mask)
+		asm += FloatBitwiseOp(ctx, token.AND_NOT, result, mask)
+		asm += MovRegReg(ctx, data, mask, result, false)
+		asm += MovSignMaskReg(ctx, data, false, tmp, mask)
+		asm += FloatBitwiseOp(ctx, token.AND, y, mask)
+		asm += FloatBitwiseOp(ctx, token.OR, mask, result)
+		f.freeReg(y)
+	}
+	f.freeReg(tmp)
+	f.freeReg(mask)
+	a, err = f.StoreValue(call, ident, result)
+	f.freeReg(result)
+	if err != nil {
+		return asm, err
+	}
+	asm += a
+	asm = fmt.Sprintf("// BEGIN math intrinsic %v\n", call) + asm
+	asm += fmt.Sprintf("// END math intrinsic %v\n", call)
+	return asm, nil
+}
@@ -721,6 +721,69 @@ const (
 	FCOMIP
 	FUCOMI
 	FUCOMIP
+
+	// AVX
+	VMASKMOVPS
+
+	// SSE4.1
+	DPPS
+	PMAXSD
+	PMINSD
+
+	// AVX2
+	VPSLLVD
+	VPSRAVD
+	VPSRLVD
+
+	// MOVBE, x86-64-v3 along with AVX2
+	MOVBELL
+	MOVBEQQ
+
+	// BMI1, x86-64-v3 along with AVX2
+	TZCNTQ
+
+	// F16C, x86-64-v3 along with AVX2
+	VCVTPH2PS
+	VCVTPS2PH
+
+	// SSSE3
+	PMADDUBSW
+
+	// AVX512 VNNI
+	VPDPBUSD
+
+	// RDRAND and RDSEED, set CF if a random number was returned
+	RDRANDQ
+	RDSEEDQ
+
+	// BMI1, x86-64-v3 along with AVX2
+	BLSRL
+	BLSRQ
+
+	// BMI2, x86-64-v3 along with AVX2
+	SHLXL
+	SHLXQ
+	SHRXL
+	SHRXQ
+	SARXL
+	SARXQ
+	PEXTQ
+	PDEPQ
+	MULXQ
+
+	// SSE4.1
+	PCMPEQQ
+
+	// SSE4.2
+	PCMPGTQ
+
+	// AVX512F with AVX512VL
+	KMOVW
+	VPCOMPRESSD
+
+	// AVX, 256 bit moves and clearing the upper halves of the ymm registers
+	VMOVDQU
+	VZEROUPPER
 	LAST
 )
 
@@ -785,6 +848,10 @@ var instrTable = map[Instruction]InstrInfo{
 	ANDQ:  {Flags: SizeQ | LeftRead | RightRdwr | SetCarry},
 	ANDW:  {Flags: SizeW | LeftRead | RightRdwr | SetCarry},
 	//CALL:      {Flags: RightAddr | Call | KillCarry},
+	BSWAPL:    {Flags: SizeL | RightRdwr},
+	BSWAPQ:    {Flags: SizeQ | RightRdwr},
+	BSFQ:      {Flags: SizeQ | LeftRead | RightWrite},
+	BTSQ:      {Flags: SizeQ | LeftRead | RightRdwr},
 	CDQ:       {Flags: OK, Use: REG_AX, Set: REG_AX | REG_DX},
 	CWD:       {Flags: OK, Use: REG_AX, Set: REG_AX | REG_DX},
 	CLD:       {Flags: OK},
@@ -792,9 +859,29 @@ var instrTable = map[Instruction]InstrInfo{
 	CMOVQCC:   {Flags: SizeQ | LeftRead | RightWrite | Move},
 	CMOVLCC:   {Flags: SizeQ | LeftRead | RightWrite | Move},
 	CMOVWCC:   {Flags: SizeQ | LeftRead | RightWrite | Move},
+	CMOVLEQ:   {Flags: SizeL | LeftRead | RightWrite | Move},
+	CMOVLNE:   {Flags: SizeL | LeftRead | RightWrite | Move},
+	CMOVLLT:   {Flags: SizeL | LeftRead | RightWrite | Move},
+	CMOVLLE:   {Flags: SizeL | LeftRead | RightWrite | Move},
+	CMOVLGT:   {Flags: SizeL | LeftRead | RightWrite | Move},
+	CMOVLGE:   {Flags: SizeL | LeftRead | RightWrite | Move},
+	CMOVLCS:   {Flags: SizeL | LeftRead | RightWrite | Move},
+	CMOVLHI:   {Flags: SizeL | LeftRead | RightWrite | Move},
+	CMOVLLS:   {Flags: SizeL | LeftRead | RightWrite | Move},
+	CMOVQEQ:   {Flags: SizeQ | LeftRead | RightWrite | Move},
+	CMOVQNE:   {Flags: SizeQ | LeftRead | RightWrite | Move},
+	CMOVQLT:   {Flags: SizeQ | LeftRead | RightWrite | Move},
+	CMOVQLE:   {Flags: SizeQ | LeftRead | RightWrite | Move},
+	CMOVQGT:   {Flags: SizeQ | LeftRead | RightWrite | Move},
+	CMOVQGE:   {Flags: SizeQ | LeftRead | RightWrite | Move},
+	CMOVQCS:   {Flags: SizeQ | LeftRead | RightWrite | Move},
+	CMOVQHI:   {Flags: SizeQ | LeftRead | RightWrite | Move},
+	CMOVQLS:   {Flags: SizeQ | LeftRead | RightWrite | Move},
 	CMPB:      {Flags: SizeB | LeftRead | RightRead | SetCarry},
 	CMPL:      {Flags: SizeL | LeftRead | RightRead | SetCarry},
 	CMPW:      {Flags: SizeW | LeftRead | RightRead | SetCarry},
+	CMPXCHGL:  {Flags: SizeL | LeftRead | RightRdwr | SetCarry, Use: REG_AX, Set: REG_AX},
+	CMPXCHGQ:  {Flags: SizeQ | LeftRead | RightRdwr | SetCarry, Use: REG_AX, Set: REG_AX},
 	CMPQ:      {Flags: SizeQ | LeftRead | RightRead | SetCarry},
 	COMISD:    {Flags: SizeD | LeftRead | RightRead | SetCarry},
 	COMISS:    {Flags: SizeF | LeftRead | RightRead | SetCarry},
@@ -810,9 +897,12 @@ var instrTable = map[Instruction]InstrInfo{
 	CVTTSS2SL: {Flags: SizeL | LeftRead | RightWrite | Conv},
 	CVTTSS2SQ: {Flags: SizeQ | LeftRead | RightWrite | Conv},
 	CVTTSD2SQ: {Flags: SizeQ | LeftRead | RightWrite | Conv},
+	CVTPL2PS:  {Flags: SizeO | LeftRead | RightWrite | Conv},
+	CVTTPS2PL: {Flags: SizeO | LeftRead | RightWrite | Conv},
 	DECB:      {Flags: SizeB | RightRdwr},
 	DECL:      {Flags: SizeL | RightRdwr},
 	DECW:      {Flags: SizeW | RightRdwr},
+	DECQ:      {Flags: SizeQ | RightRdwr},
 	DIVB:      {Flags: SizeB | LeftRead | SetCarry, Use: REG_AX, Set: REG_AX},
 	DIVL:      {Flags: SizeL | LeftRead | SetCarry, Use: REG_AX | REG_DX, Set: REG_AX | REG_DX},
 	DIVQ:      {Flags: SizeQ | LeftRead | SetCarry, Use: REG_AX | REG_DX, Set: REG_AX | REG_DX},
@@ -873,6 +963,7 @@ var instrTable = map[Instruction]InstrInfo{
 	INCB:   {Flags: SizeB | RightRdwr},
 	INCL:   {Flags: SizeL | RightRdwr},
 	INCW:   {Flags: SizeW | RightRdwr},
+	INCQ:   {Flags: SizeQ | RightRdwr},
 	JCC:    {Flags: Cjmp | UseCarry},
 	JCS:    {Flags: Cjmp | UseCarry},
 	JEQ:    {Flags: Cjmp | UseCarry},
@@ -921,6 +1012,7 @@ var instrTable = map[Instruction]InstrInfo{
 
 	// We use MOVAPD as a faster synonym for MOVSD.
 	MOVAPD:    {Flags: SizeD | LeftRead | RightWrite | Move},
+	MOVAPS:    {Flags: SizeF | LeftRead | RightWrite | Move},
 	MULB:      {Flags: SizeB | LeftRead | SetCarry, Use: REG_AX, Set: REG_AX},
 	MULL:      {Flags: SizeL | LeftRead | SetCarry, Use: REG_AX, Set: REG_AX | REG_DX},
 	MULQ:      {Flags: SizeQ | LeftRead | SetCarry, Use: REG_AX, Set: REG_AX | REG_DX},
@@ -932,6 +1024,7 @@ var instrTable = map[Instruction]InstrInfo{
 	NEGB:      {Flags: SizeB | RightRdwr | SetCarry},
 	NEGL:      {Flags: SizeL | RightRdwr | SetCarry},
 	NEGW:      {Flags: SizeW | RightRdwr | SetCarry},
+	NEGQ:      {Flags: SizeQ | RightRdwr | SetCarry},
 	NOTB:      {Flags: SizeB | RightRdwr},
 	NOTL:      {Flags: SizeL | RightRdwr},
 	NOTW:      {Flags: SizeW | RightRdwr},
@@ -939,6 +1032,7 @@ var instrTable = map[Instruction]InstrInfo{
 	ORL:       {Flags: SizeL | LeftRead | RightRdwr | SetCarry},
 	ORQ:       {Flags: SizeQ | LeftRead | RightRdwr | SetCarry},
 	ORW:       {Flags: SizeW | LeftRead | RightRdwr | SetCarry},
+	PACKSSLW:  {Flags: SizeO | LeftRead | RightRdwr},
 	PADDB:     {Flags: SizeO | LeftRead | RightRdwr | SetCarry},
 	PADDL:     {Flags: SizeO | LeftRead | RightRdwr | SetCarry},
 	PADDW:     {Flags: SizeO | LeftRead | RightRdwr | SetCarry},
@@ -951,17 +1045,22 @@ var instrTable = map[Instruction]InstrInfo{
 	PSLLL:     {Flags: SizeO | LeftRead | RightRdwr},
 	PSLLQ:     {Flags: SizeO | LeftRead | RightRdwr},
 	PSLLW:     {Flags: SizeO | LeftRead | RightRdwr},
+	PSLLO:     {Flags: SizeO | LeftRead | RightRdwr},
 	PSRAW:     {Flags: SizeO | LeftRead | RightRdwr},
 	PSRAL:     {Flags: SizeO | LeftRead | RightRdwr},
 	PSRLW:     {Flags: SizeO | LeftRead | RightRdwr},
 	PSRLL:     {Flags: SizeO | LeftRead | RightRdwr},
+	PSRLQ:     {Flags: SizeO | LeftRead | RightRdwr},
 	PSRLO:     {Flags: SizeO | LeftRead | RightRdwr},
 	PSHUFD:    {Flags: SizeO | LeftRead | RightRdwr},
 	PSUBB:     {Flags: SizeO | LeftRead | RightRdwr},
 	PSUBW:     {Flags: SizeO | LeftRead | RightRdwr},
 	PSUBL:     {Flags: SizeO | LeftRead | RightRdwr},
 	PSUBQ:     {Flags: SizeO | LeftRead | RightRdwr},
+	PUNPCKHLQ: {Flags: SizeO | LeftRead | RightRdwr},
+	PUNPCKHWL: {Flags: SizeO | LeftRead | RightRdwr},
 	PUNPCKLLQ: {Flags: SizeO | LeftRead | RightRdwr},
+	PUNPCKLWL: {Flags: SizeO | LeftRead | RightRdwr},
 	PUSHL:     {Flags: SizeL | LeftRead},
 	RCLB:      {Flags: SizeB | LeftRead | RightRdwr | ShiftCX | SetCarry | UseCarry},
 	RCLL:      {Flags: SizeL | LeftRead | RightRdwr | ShiftCX | SetCarry | UseCarry},
@@ -1034,10 +1133,116 @@ var instrTable = map[Instruction]InstrInfo{
 	XCHGB:   {Flags: SizeB | LeftRdwr | RightRdwr},
 	XCHGL:   {Flags: SizeL | LeftRdwr | RightRdwr},
 	XCHGW:   {Flags: SizeW | LeftRdwr | RightRdwr},
+	XCHGQ:   {Flags: SizeQ | LeftRdwr | RightRdwr},
+	XADDL:   {Flags: SizeL | LeftRdwr | RightRdwr | SetCarry},
+	XADDQ:   {Flags: SizeQ | LeftRdwr | RightRdwr | SetCarry},
 	XORB:    {Flags: SizeB | LeftRead | RightRdwr | SetCarry},
 	XORL:    {Flags: SizeL | LeftRead | RightRdwr | SetCarry},
 	XORW:    {Flags: SizeW | LeftRead | RightRdwr | SetCarry},
 	XORQ:    {Flags: SizeQ | LeftRead | RightRdwr | SetCarry},
 	XORPD:   {Flags: SizeD | LeftRead | RightRdwr | SetCarry},
 	XORPS:   {Flags: SizeF | LeftRead | RightRdwr | SetCarry},
+
+	ANDNPS:     {Flags: SizeO | LeftRead | RightRdwr},
+	ANDPS:      {Flags: SizeO | LeftRead | RightRdwr},
+	CMPSD:      {Flags: SizeD | LeftRead | RightRdwr},
+	CMPSS:      {Flags: SizeF | LeftRead | RightRdwr},
+	MAXPD:      {Flags: SizeO | LeftRead | RightRdwr},
+	MAXPS:      {Flags: SizeO | LeftRead | RightRdwr},
+	MAXSD:      {Flags: SizeD | LeftRead | RightRdwr},
+	MAXSS:      {Flags: SizeF | LeftRead | RightRdwr},
+	MINPD:      {Flags: SizeO | LeftRead | RightRdwr},
+	MINPS:      {Flags: SizeO | LeftRead | RightRdwr},
+	MINSD:      {Flags: SizeD | LeftRead | RightRdwr},
+	MINSS:      {Flags: SizeF | LeftRead | RightRdwr},
+	MOVMSKPD:   {Flags: SizeO | LeftRead | RightWrite},
+	ORPS:       {Flags: SizeO | LeftRead | RightRdwr},
+	PACKUSWB:   {Flags: SizeO | LeftRead | RightRdwr},
+	PAND:       {Flags: SizeO | LeftRead | RightRdwr},
+	PANDN:      {Flags: SizeO | LeftRead | RightRdwr},
+	PCMPEQB:    {Flags: SizeO | LeftRead | RightRdwr},
+	PCMPEQL:    {Flags: SizeO | LeftRead | RightRdwr},
+	PMADDWL:    {Flags: SizeO | LeftRead | RightRdwr},
+	PMAXSW:     {Flags: SizeO | LeftRead | RightRdwr},
+	PMAXUB:     {Flags: SizeO | LeftRead | RightRdwr},
+	PMINSW:     {Flags: SizeO | LeftRead | RightRdwr},
+	PMINUB:     {Flags: SizeO | LeftRead | RightRdwr},
+	PMOVMSKB:   {Flags: SizeO | LeftRead | RightWrite},
+	POR:        {Flags: SizeO | LeftRead | RightRdwr},
+	PSADBW:     {Flags: SizeO | LeftRead | RightRdwr},
+	PSHUFB:     {Flags: SizeO | LeftRead | RightRdwr},
+	PSHUFL:     {Flags: SizeO | LeftRead | RightWrite},
+	PUNPCKHBW:  {Flags: SizeO | LeftRead | RightRdwr},
+	PUNPCKLBW:  {Flags: SizeO | LeftRead | RightRdwr},
+	PUNPCKLQDQ: {Flags: SizeO | LeftRead | RightRdwr},
+	PXOR:       {Flags: SizeO | LeftRead | RightRdwr},
+	SHUFPD:     {Flags: SizeO | LeftRead | RightRdwr},
+	SHUFPS:     {Flags: SizeO | LeftRead | RightRdwr},
+	UNPCKHPS:   {Flags: SizeO | LeftRead | RightRdwr},
+	UNPCKLPS:   {Flags: SizeO | LeftRead | RightRdwr},
+
+	// AVX, the mask is the middle operand
+	VMASKMOVPS: {Flags: SizeO | LeftRead | RightWrite},
+
+	// SSE4.1
+	DPPS:   {Flags: SizeO | LeftRead | RightRdwr},
+	PMAXSD: {Flags: SizeO | LeftRead | RightRdwr},
+	PMINSD: {Flags: SizeO | LeftRead | RightRdwr},
+
+	// AVX2, the shift counts are the first operand
+	VPSLLVD: {Flags: SizeO | LeftRead | RightWrite},
+	VPSRAVD: {Flags: SizeO | LeftRead | RightWrite},
+	VPSRLVD: {Flags: SizeO | LeftRead | RightWrite},
+
+	// MOVBE, loads and stores with the bytes reversed
+	MOVBELL: {Flags: SizeL | LeftRead | RightWrite | Move},
+	MOVBEQQ: {Flags: SizeQ | LeftRead | RightWrite | Move},
+
+	// BMI1, TZCNT of zero is the operand size unlike BSF
+	TZCNTQ: {Flags: SizeQ | LeftRead | RightWrite},
+
+	// F16C, half precision floats in the low 64 bits of the xmm register
+	VCVTPH2PS: {Flags: SizeO | LeftRead | RightWrite},
+	VCVTPS2PH: {Flags: SizeO | LeftRead | RightWrite},
+
+	// SSSE3, the unsigned bytes are the destination operand
+	PMADDUBSW: {Flags: SizeO | LeftRead | RightRdwr},
+
+	// AVX512 VNNI, the sums are added to the destination operand
+	VPDPBUSD: {Flags: SizeO | LeftRead | RightRdwr},
+
+	// CF is clear if no random number was available
+	RDRANDQ: {Flags: SizeQ | RightWrite | SetCarry},
+	RDSEEDQ: {Flags: SizeQ | RightWrite | SetCarry},
+
+	// BMI1, the source with its lowest set bit cleared
+	BLSRL: {Flags: SizeL | LeftRead | RightWrite | SetCarry},
+	BLSRQ: {Flags: SizeQ | LeftRead | RightWrite | SetCarry},
+
+	// BMI2, three operands, the count, mask, or multiplier comes first and
+	// the flags are unchanged except by MULX
+	SHLXL: {Flags: SizeL | LeftRead | RightWrite},
+	SHLXQ: {Flags: SizeQ | LeftRead | RightWrite},
+	SHRXL: {Flags: SizeL | LeftRead | RightWrite},
+	SHRXQ: {Flags: SizeQ | LeftRead | RightWrite},
+	SARXL: {Flags: SizeL | LeftRead | RightWrite},
+	SARXQ: {Flags: SizeQ | LeftRead | RightWrite},
+	PEXTQ: {Flags: SizeQ | LeftRead | RightWrite},
+	PDEPQ: {Flags: SizeQ | LeftRead | RightWrite},
+	MULXQ: {Flags: SizeQ | LeftRead | RightWrite, Use: REG_DX},
+
+	// SSE4.1 and SSE4.2, the 64 bit element compares
+	PCMPEQQ: {Flags: SizeO | LeftRead | RightRdwr},
+	PCMPGTQ: {Flags: SizeO | LeftRead | RightRdwr},
+
+	// AVX512, the mask register is the middle operand of VPCOMPRESSD, the
+	// elements not stored are zeroed with .Z
+	KMOVW:       {Flags: SizeW | LeftRead | RightWrite | Move},
+	VPCOMPRESSD: {Flags: SizeO | LeftRead | RightWrite},
+
+	// AVX, the 256 bit values of the F32x8 masked loads and stores are moved
+	// between memory and the ymm registers, VZEROUPPER after them avoids the
+	// AVX to SSE transition penalty
+	VMOVDQU:    {Flags: LeftRead | RightWrite | Move},
+	VZEROUPPER: {Flags: OK},
 }
@@ -0,0 +1,423 @@
+package codegen
+
+import (
+	"fmt"
+	"go/token"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/ssa"
+)
+
+// APIVersion is the version of the Options, Result, and Compile contract.
+// Fields are only added to Options and Result, the version is incremented
+// for any incompatible change.
+const APIVersion = 1
+
+// CommentLevel is how much of the assembly output is commented.
+type CommentLevel int
+
+const (
+	// CommentNone strips all comments
+	CommentNone CommentLevel = iota
+	// CommentBlocks comments each basic block with its ssa comment and
+	// predecessors, along with the comments of multi-instruction sequences
+	CommentBlocks
+	// CommentInstructions also wraps the assembly of each ssa instruction and
+	// code generation step in BEGIN/END comments
+	CommentInstructions
+)
+
+// ParseCommentLevel parses "none", "blocks", or "instructions".
+func ParseCommentLevel(s string) (CommentLevel, error) {
+	switch s {
+	case "none":
+		return CommentNone, nil
+	case "blocks":
+		return CommentBlocks, nil
+	case "instructions":
+		return CommentInstructions, nil
+	}
+	return CommentNone, fmt.Errorf("invalid comment level \"%v\", expected none, blocks, or instructions", s)
+}
+
+// OptFor is what optimized code is generated for, Options.OptFor.
+type OptFor int
+
+const (
+	// OptSpeed unrolls copies and zeroing and picks the fastest instructions
+	OptSpeed OptFor = iota
+	// OptSize prefers shorter encodings and REP string instructions for
+	// copies and zeroing, e.g. for firmware where text size matters
+	OptSize
+)
+
+// ParseOptFor parses "speed" or "size".
+func ParseOptFor(s string) (OptFor, error) {
+	switch s {
+	case "speed":
+		return OptSpeed, nil
+	case "size":
+		return OptSize, nil
+	}
+	return OptSpeed, fmt.Errorf("invalid optimization goal \"%v\", expected speed or size", s)
+}
+
+// DefaultIndent is the indentation of instructions used if Options.Indent is empty.
+const DefaultIndent = "        "
+
+// DefaultArch is the only supported Options.Arch.
+const DefaultArch = "amd64"
+
+// operatingSystems are the GOOS values of the amd64 port, the valid Options.OS.
+var operatingSystems = []string{"android", "darwin", "dragonfly", "freebsd", "illumos", "ios",
+	"linux", "netbsd", "openbsd", "plan9", "solaris", "windows"}
+
+// Archs returns the GOARCH values gensimd generates assembly for.
+func Archs() []string {
+	return []string{DefaultArch}
+}
+
+// OperatingSystems returns the GOOS values of the port of arch, the valid
+// Options.OS of its assembly, nil if arch isn't supported.
+func OperatingSystems(arch string) []string {
+	if arch != DefaultArch {
+		return nil
+	}
+	return append([]string{}, operatingSystems...)
+}
+
+// OSBuildConstraint returns the build constraint expression expr restricted
+// to the GOOS os, e.g. "(amd64 && !noasm) && windows", expr if os is empty.
+func OSBuildConstraint(expr, os string) string {
+	if os == "" {
+		return expr
+	}
+	return "(" + expr + ") && " + os
+}
+
+// CPU feature levels for Options.Target, each level includes the ones before it.
+const (
+	TargetSSE2  = "sse2"
+	TargetSSSE3 = "ssse3"
+	TargetSSE41 = "sse4.1"
+	TargetAVX   = "avx"
+	TargetAVX2  = "avx2"
+	// TargetAVX512VNNI is AVX512 VNNI with AVX512VL, the Go assembler only
+	// has the EVEX encoding of VPDPBUSD
+	TargetAVX512VNNI = "avx512vnni"
+)
+
+var targets = []string{TargetSSE2, TargetSSSE3, TargetSSE41, TargetAVX, TargetAVX2, TargetAVX512VNNI}
+
+// instrTargets maps the instructions above SSE2 to the lowest target having them.
+var instrTargets = map[Instruction]string{
+	PSHUFB:      TargetSSSE3,
+	PMADDUBSW:   TargetSSSE3,
+	DPPS:        TargetSSE41,
+	PMAXSD:      TargetSSE41,
+	PMINSD:      TargetSSE41,
+	VMASKMOVPS:  TargetAVX,
+	VMOVDQU:     TargetAVX,
+	VZEROUPPER:  TargetAVX,
+	VPSLLVD:     TargetAVX2,
+	VPSRAVD:     TargetAVX2,
+	VPSRLVD:     TargetAVX2,
+	MOVBELL:     TargetAVX2,
+	MOVBEQQ:     TargetAVX2,
+	TZCNTQ:      TargetAVX2,
+	BLSRL:       TargetAVX2,
+	BLSRQ:       TargetAVX2,
+	SHLXL:       TargetAVX2,
+	SHLXQ:       TargetAVX2,
+	SHRXL:       TargetAVX2,
+	SHRXQ:       TargetAVX2,
+	SARXL:       TargetAVX2,
+	SARXQ:       TargetAVX2,
+	PEXTQ:       TargetAVX2,
+	PDEPQ:       TargetAVX2,
+	MULXQ:       TargetAVX2,
+	VCVTPH2PS:   TargetAVX2,
+	VCVTPS2PH:   TargetAVX2,
+	PCMPEQQ:     TargetSSE41,
+	PCMPGTQ:     TargetAVX, // SSE4.2, which every AVX CPU has
+	VPDPBUSD:    TargetAVX512VNNI,
+	KMOVW:       TargetAVX512VNNI,
+	VPCOMPRESSD: TargetAVX512VNNI,
+}
+
+// targetLevel returns the index of target in targets, or -1 if it's invalid.
+func targetLevel(target string) int {
+	for i, t := range targets {
+		if t == target {
+			return i
+		}
+	}
+	return -1
+}
+
+// hasTarget returns true if f's target includes target.
+func (f *Function) hasTarget(target string) bool {
+	return targetLevel(f.opts.Target) >= targetLevel(target)
+}
+
+// optSize returns true if the function of ctx is optimized for size, a ctx
+// without a function is optimized for speed.
+func (ctx context) optSize() bool {
+	return ctx.f != nil && ctx.f.opts.OptFor == OptSize
+}
+
+// hasInstr returns true if the target of the function of ctx has instr, a
+// ctx without a function only has the SSE2 instructions.
+func (ctx context) hasInstr(instr Instruction) bool {
+	target, ok := instrTargets[instr]
+	return !ok || ctx.f != nil && ctx.f.hasTarget(target)
+}
+
+// newLabel returns a new jump label of the function of ctx, or name if ctx
+// has no function, e.g. when testing an emitter alone.
+func (ctx context) newLabel(name string) string {
+	if ctx.f == nil {
+		return name
+	}
+	return ctx.f.newJmpLabel()
+}
+
+// knownOS returns true if os is a GOOS of the amd64 port.
+func knownOS(os string) bool {
+	for _, o := range operatingSystems {
+		if o == os {
+			return true
+		}
+	}
+	return false
+}
+
+// DefaultSizes returns the amd64 type sizes gensimd lays out memory with.
+func DefaultSizes() types.Sizes {
+	return &types.StdSizes{WordSize: 8, MaxAlign: 8}
+}
+
+// NoSplitStackLimit is the stack in bytes the linker allows a chain of
+// NOSPLIT functions to use, StackNosplitBase of the Go toolchain. It's
+// doubled in race builds, the smaller limit is always checked.
+const NoSplitStackLimit = 800
+
+// Options configure code generation of a Function. Start from
+// DefaultOptions, the zero value of OptLevel and CommentLevel disable
+// optimizations and comments.
+type Options struct {
+	// OutName is the name of the assembly function, the Go function name if empty
+	OutName string
+	// Arch is the GOARCH of the assembly, only "amd64" is supported, the
+	// default if empty
+	Arch string
+	// OS is the GOOS the assembly is for, any OS if empty, restrict the build
+	// constraint with OSBuildConstraint. The generated leaf functions don't
+	// access thread local storage and the Go assembler reserves the same
+	// registers on every OS, on plan9 memory is zeroed without SSE registers
+	// like the Go compiler does, floating point isn't allowed in note handlers.
+	OS string
+	// Target is the highest CPU feature level the assembly may use, one of
+	// the Target constants, TargetAVX2 if empty. Intrinsics needing a higher
+	// level are an error.
+	Target string
+	// Variants are targets to generate a variant of the function for, named
+	// by VariantName, e.g. sum_sse2 and sum_avx2, instead of Target. The
+	// output function is then a stub jumping to the highest variant the CPU
+	// supports, its declaration from GoProto selects it when the package is
+	// initialized. The lowest should be TargetSSE2, it's used if the CPU
+	// supports none.
+	Variants []string
+	// NoSplit marks the function NOSPLIT, omitting the stack bound check the
+	// assembler adds to functions with a frame. Its stack use, see
+	// stackUsage, must fit in NoSplitStackLimit less StackMargin, GoAssembly
+	// fails otherwise instead of the linker.
+	NoSplit bool
+	// StackMargin are bytes of NoSplitStackLimit left for NOSPLIT callers of
+	// the function with NoSplit
+	StackMargin int
+	// OptLevel 0 disables optimizations, 1 enables them
+	OptLevel int
+	// OptFor is whether the code is optimized for speed or size, OptSpeed if
+	// zero, it applies with any OptLevel
+	OptFor OptFor
+	// BoundsCheck checks the index of slice and array element accesses,
+	// an out of range index traps with INT $3
+	BoundsCheck bool
+	// Pipeline software pipelines the element loads of loops with a one
+	// block body counted up to the length of the slice, loading the element
+	// of the next iteration while the current one is computed, see
+	// pipeline.go. It needs OptLevel 1 and isn't done with BoundsCheck.
+	Pipeline bool
+	// Debug adds debug checks, e.g. of //gensimd:align parameters
+	Debug bool
+	// Indent is the indentation of instructions, labels aren't indented,
+	// DefaultIndent if empty
+	Indent       string
+	CommentLevel CommentLevel
+	// Sizes are the type sizes the function was type checked with, they must
+	// match DefaultSizes which is used if nil
+	Sizes types.Sizes
+}
+
+// DefaultOptions returns the options used by the gensimd command without flags.
+func DefaultOptions() Options {
+	return Options{
+		Arch:         DefaultArch,
+		Target:       TargetAVX2,
+		OptLevel:     1,
+		Indent:       DefaultIndent,
+		CommentLevel: CommentBlocks,
+		Sizes:        DefaultSizes(),
+	}
+}
+
+// withDefaults returns opts with the empty fields set to their defaults, or
+// an error for invalid options.
+func (opts Options) withDefaults() (Options, *Error) {
+	if opts.Arch == "" {
+		opts.Arch = DefaultArch
+	}
+	if opts.Target == "" {
+		opts.Target = TargetAVX2
+	}
+	if opts.Indent == "" {
+		opts.Indent = DefaultIndent
+	}
+	if opts.Sizes == nil {
+		opts.Sizes = DefaultSizes()
+	}
+	if opts.Arch != DefaultArch {
+		return opts, ErrorMsg2(fmt.Sprintf("Unsupported arch \"%v\", only %v is supported", opts.Arch, DefaultArch))
+	}
+	if opts.OS != "" && !knownOS(opts.OS) {
+		msg := "Unsupported OS \"%v\", expected one of %v"
+		return opts, ErrorMsg2(fmt.Sprintf(msg, opts.OS, strings.Join(operatingSystems, ", ")))
+	}
+	if targetLevel(opts.Target) < 0 {
+		msg := "Invalid target \"%v\", expected one of %v"
+		return opts, ErrorMsg2(fmt.Sprintf(msg, opts.Target, strings.Join(targets, ", ")))
+	}
+	if opts.StackMargin < 0 || opts.StackMargin >= NoSplitStackLimit {
+		msg := "Invalid stack margin (%v), expected 0 to %v bytes"
+		return opts, ErrorMsg2(fmt.Sprintf(msg, opts.StackMargin, NoSplitStackLimit-1))
+	}
+	if err := checkVariants(opts.Variants); err != nil {
+		return opts, err
+	}
+	if opts.OptLevel < 0 || opts.OptLevel > 1 {
+		return opts, ErrorMsg2(fmt.Sprintf("Invalid optimization level (%v), expected 0 or 1", opts.OptLevel))
+	}
+	if opts.OptFor != OptSpeed && opts.OptFor != OptSize {
+		return opts, ErrorMsg2(fmt.Sprintf("Invalid optimization goal (%v), expected OptSpeed or OptSize", opts.OptFor))
+	}
+	if opts.CommentLevel < CommentNone || opts.CommentLevel > CommentInstructions {
+		return opts, ErrorMsg2(fmt.Sprintf("Invalid comment level (%v)", opts.CommentLevel))
+	}
+	for _, t := range []types.Type{types.Typ[types.Int], types.Typ[types.Uintptr], types.Typ[types.Int64]} {
+		if opts.Sizes.Sizeof(t) != 8 || opts.Sizes.Alignof(t) != 8 {
+			return opts, ErrorMsg2(fmt.Sprintf("Unsupported sizes, %v must be 8 bytes and 8 byte aligned", t))
+		}
+	}
+	return opts, nil
+}
+
+// checkTarget returns an error if asm has instructions above f's target.
+func (f *Function) checkTarget(asm string) *Error {
+	level := targetLevel(f.opts.Target)
+	for _, instr := range asmInstrs(asm) {
+		if target, ok := instrTargets[instr]; ok && targetLevel(target) > level {
+			msg := "%v requires %v, above the target %v"
+			return ErrorMsg2(fmt.Sprintf(msg, instr, target, f.opts.Target))
+		}
+	}
+	return nil
+}
+
+// asmInstrs returns the distinct instructions of asm in the order they
+// first appear, labels, comments, and unknown mnemonics are skipped. The
+// zeroing suffix of AVX512 instructions, ".Z", is ignored.
+func asmInstrs(asm string) []Instruction {
+	names := map[string]Instruction{}
+	for instr := range instrTable {
+		names[instr.String()] = instr
+	}
+	seen := map[Instruction]bool{}
+	instrs := []Instruction{}
+	for _, line := range strings.Split(asm, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		instr, ok := names[strings.TrimSuffix(fields[0], ".Z")]
+		if ok && !seen[instr] {
+			seen[instr] = true
+			instrs = append(instrs, instr)
+		}
+	}
+	return instrs
+}
+
+// Diagnostic is a problem found generating the assembly of a function.
+type Diagnostic struct {
+	Pos token.Position
+	Msg string
+}
+
+func (d Diagnostic) String() string {
+	if d.Pos.IsValid() {
+		return fmt.Sprintf("%v: %v", d.Pos, d.Msg)
+	}
+	return d.Msg
+}
+
+// Result is the output of Compile.
+type Result struct {
+	// Asm is the Go assembly of the function, starting with its TEXT line
+	Asm string
+	// Decl is the Go declaration of the assembly function, e.g.
+	// "func addf32s(x, y []float32) int"
+	Decl string
+	// Diagnostics are the problems found, empty if Compile succeeded
+	Diagnostics []Diagnostic
+	// Layout is the frame, arguments, registers, and CPU features of Asm
+	Layout Layout
+	// Stats are the instruction count, cycle estimate, and spills of Asm
+	Stats Stats
+}
+
+// Compile generates the Go assembly of fn with opts. On failure the error
+// is also in the diagnostics of the result, along with the other
+// unsupported constructs of fn found by Unsupported.
+func Compile(fn *ssa.Function, opts Options) (Result, *Error) {
+	result := Result{}
+	f, err := CreateFunction(fn, opts)
+	if err == nil {
+		if errs := Unsupported(fn); len(errs) > 0 {
+			for _, e := range errs {
+				result.Diagnostics = append(result.Diagnostics, NewDiagnostic(fn, e))
+			}
+			return result, errs[0]
+		}
+		result.Asm, err = f.GoAssembly()
+	}
+	if err != nil {
+		result.Diagnostics = append(result.Diagnostics, NewDiagnostic(fn, err))
+		return result, err
+	}
+	_, _, proto := f.GoProto()
+	result.Decl = strings.TrimSpace(proto)
+	result.Layout, _ = f.Layout()
+	result.Stats, _ = f.Stats()
+	return result, nil
+}
+
+// NewDiagnostic returns err as a diagnostic with its position in fn's source.
+func NewDiagnostic(fn *ssa.Function, err *Error) Diagnostic {
+	d := Diagnostic{Msg: err.Err.Error()}
+	if fn != nil && err.Pos.IsValid() {
+		d.Pos = fn.Prog.Fset.Position(err.Pos)
+	}
+	return d
+}
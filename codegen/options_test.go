@@ -0,0 +1,157 @@
+package codegen
+
+import (
+	"go/build/constraint"
+	"strings"
+	"testing"
+)
+
+// TestCompile checks the output of Compile with options other than the
+// defaults, and the diagnostics of invalid options.
+func TestCompile(t *testing.T) {
+	const src = `package src
+
+func sum(x []int32) int32 {
+	s := int32(0)
+	for i := range x {
+		s += x[i]
+	}
+	return s
+}
+`
+	fn := buildFuncMode(t, src, "sum", BuilderMode)
+	opts := DefaultOptions()
+	opts.OutName = "sumSSE2"
+	opts.Target = TargetSSE2
+	opts.NoSplit = true
+	opts.OptFor = OptSize
+	opts.Indent = "\t"
+	opts.CommentLevel = CommentNone
+	result, err := Compile(fn, opts)
+	if err != nil {
+		t.Fatal(err.Err)
+	}
+	if expected := "func sumSSE2(x []int32) int32"; result.Decl != expected {
+		t.Errorf("Decl = %q, expected %q", result.Decl, expected)
+	}
+	lines := strings.Split(strings.TrimSpace(result.Asm), "\n")
+	if expected := "TEXT ·sumSSE2(SB),NOSPLIT,$"; !strings.HasPrefix(lines[0], expected) {
+		t.Errorf("TEXT line %q, expected the prefix %q", lines[0], expected)
+	}
+	for _, line := range lines[1:] {
+		if strings.HasPrefix(line, "block") {
+			continue
+		}
+		if !strings.HasPrefix(line, "\t") || strings.HasPrefix(line, "\t ") {
+			t.Errorf("line %q isn't indented with a tab", line)
+		}
+		if strings.Contains(line, "//") {
+			t.Errorf("line %q has a comment with CommentNone", line)
+		}
+	}
+	// OptSize increments the index with INCQ instead of ADDQ $1
+	if !strings.Contains(result.Asm, "INCQ") || strings.Contains(result.Asm, "$1, R") {
+		t.Errorf("expected INCQ with OptSize, got\n%v", result.Asm)
+	}
+	if result.Layout.Name != "sumSSE2" || len(result.Layout.Features) != 1 || result.Layout.Features[0] != TargetSSE2 {
+		t.Errorf("Layout = %+v, expected sumSSE2 needing only %v", result.Layout, TargetSSE2)
+	}
+	if len(result.Diagnostics) != 0 {
+		t.Errorf("Diagnostics = %v, expected none", result.Diagnostics)
+	}
+
+	for _, test := range []struct {
+		set func(*Options)
+		err string
+	}{
+		{func(o *Options) { o.Target = "avx3" }, "Invalid target \"avx3\""},
+		{func(o *Options) { o.OptLevel = 2 }, "Invalid optimization level (2)"},
+		{func(o *Options) { o.OS = "dos" }, "Unsupported OS \"dos\""},
+	} {
+		opts := DefaultOptions()
+		test.set(&opts)
+		result, err := Compile(fn, opts)
+		if err == nil || !strings.Contains(err.Err.Error(), test.err) {
+			t.Errorf("Compile error %v, expected %q", err, test.err)
+			continue
+		}
+		if result.Asm != "" || len(result.Diagnostics) != 1 || result.Diagnostics[0].Msg != err.Err.Error() {
+			t.Errorf("Compile result %+v, expected only the diagnostic %q", result, err.Err)
+		}
+	}
+}
+
+// TestOSBuildConstraint checks the build lines of the assembly and of the
+// fallback for each OS, with the default and a user build constraint.
+func TestOSBuildConstraint(t *testing.T) {
+	for _, expr := range []string{DefaultBuildConstraint, "amd64 || arm64"} {
+		if got := OSBuildConstraint(expr, ""); got != expr {
+			t.Errorf("OSBuildConstraint(%q, \"\") = %q, expected %q", expr, got, expr)
+		}
+		for _, os := range OperatingSystems(DefaultArch) {
+			goBuild := map[string]string{
+				DefaultBuildConstraint: "//go:build amd64 && !noasm && !appengine && " + os,
+				"amd64 || arm64":       "//go:build (amd64 || arm64) && " + os,
+			}[expr]
+			plusBuild := map[string]string{
+				DefaultBuildConstraint: "// +build amd64,!noasm,!appengine," + os,
+				"amd64 || arm64":       "// +build amd64 arm64\n// +build " + os,
+			}[expr]
+			c := OSBuildConstraint(expr, os)
+			lines, err := BuildConstraint(c)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if expected := goBuild + "\n" + plusBuild + "\n"; lines != expected {
+				t.Errorf("BuildConstraint(%q) = %q, expected %q", c, lines, expected)
+			}
+			fallback, err := InverseBuildConstraint(c)
+			if err != nil {
+				t.Fatal(err)
+			}
+			// the assembly builds on os, and the fallback on any other
+			for _, goos := range []string{os, otherOS(os)} {
+				tags := func(tag string) bool { return tag == DefaultArch || tag == goos }
+				asm, _ := constraint.Parse(strings.Split(lines, "\n")[0])
+				inverse, _ := constraint.Parse(strings.Split(fallback, "\n")[0])
+				if asm.Eval(tags) != (goos == os) || inverse.Eval(tags) == (goos == os) {
+					t.Errorf("%q and %q for GOOS %v, expected the assembly only for %v", lines, fallback, goos, os)
+				}
+			}
+		}
+	}
+}
+
+// TestOptionsOS checks Options.OS is accepted for each OS, and memory is
+// only zeroed with SSE registers off plan9.
+func TestOptionsOS(t *testing.T) {
+	const src = `package src
+
+func f(i int) int64 {
+	var a [4]int64
+	a[i&3] = 1
+	return a[0] + a[3]
+}
+`
+	fn := buildFuncMode(t, src, "f", BuilderMode)
+	for _, os := range OperatingSystems(DefaultArch) {
+		opts := DefaultOptions()
+		opts.OS = os
+		result, err := Compile(fn, opts)
+		if err != nil {
+			t.Errorf("Compile with OS %v error %v", os, err.Err)
+			continue
+		}
+		if sse := strings.Contains(result.Asm, "MOVUPS"); sse != (os != "plan9") {
+			t.Errorf("Compile with OS %v zeroes with MOVUPS %v, expected %v:\n%v", os, sse, os != "plan9", result.Asm)
+		}
+	}
+}
+
+// otherOS returns an OS other than os.
+func otherOS(os string) string {
+	if os == "linux" {
+		return "windows"
+	}
+	return "linux"
+}
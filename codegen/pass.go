@@ -0,0 +1,303 @@
+package codegen
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Pass is a step of generating the assembly of a function. Passes run in
+// order on the function and its Assembly, the built in ones are named by the
+// Pass constants. Custom passes are added with Function.InsertPass, e.g. a
+// peephole optimizer after PassLower.
+type Pass struct {
+	Name string
+	Run  func(f *Function, a *Assembly) *Error
+}
+
+// The built in passes in the order they run.
+const (
+	// PassParams lays out the parameters and results at their FP offsets,
+	// loads the //gensimd:reg parameters, and with Debug checks the
+	// //gensimd:align parameters
+	PassParams = "params"
+	// PassZero zeroes the result and the locals read before they're written
+	PassZero = "zero"
+	// PassPhi records the phi values each block edge sets
+	PassPhi = "phi"
+	// PassLoadFuse finds ors of byte loads done as one load, see loadfuse.go
+	PassLoadFuse = "loadfuse"
+	// PassBitLoop finds the bit clears and trailing zero counts of loops
+	// over the set bits of a mask, see bitloop.go
+	PassBitLoop = "bitloop"
+	// PassPipeline finds the loads of the next loop iteration done ahead
+	// with Options.Pipeline, see pipeline.go
+	PassPipeline = "pipeline"
+	// PassReduction finds the loop accumulators kept in registers, see
+	// reduction.go
+	PassReduction = "reduction"
+	// PassInduction finds loop element addresses computed by pointer
+	// increments, see induction.go
+	PassInduction = "induction"
+	// PassCSE finds element addresses equal to a dominating one, see cse.go
+	PassCSE = "cse"
+	// PassSelect finds ifs lowered without branches, see select.go
+	PassSelect = "select"
+	// PassSwitch finds switches lowered to jump tables, see switch.go
+	PassSwitch = "switch"
+	// PassLoadCSE finds loads of values already loaded or stored in their
+	// block, see alias.go
+	PassLoadCSE = "loadcse"
+	// PassHoist finds the loop invariant instructions computed before their
+	// loops and the invariant values kept in registers, see hoist.go
+	PassHoist = "hoist"
+	// PassSlots assigns every value its stack slot, see slots.go
+	PassSlots = "slots"
+	// PassLower generates the instructions of the basic blocks, allocating
+	// and spilling registers as it goes
+	PassLower = "lower"
+	// PassFrame computes the frame and argument sizes
+	PassFrame = "frame"
+	// PassEmit assembles the sections into the TEXT symbol
+	PassEmit = "emit"
+)
+
+// Assembly is the assembly of a function as the passes build it.
+type Assembly struct {
+	// Params are the parameter layout comments
+	Params string
+	// AlignChecks are the //gensimd:align checks, with Debug
+	AlignChecks string
+	// Pins load the parameters pinned by //gensimd:reg, or kept in registers
+	// in loops, see hoist.go, into their registers
+	Pins string
+	// Zero zeroes the result and locals
+	Zero string
+	// Blocks are the instructions of the basic blocks
+	Blocks string
+	// FrameSize and ArgsSize are the sizes in the TEXT directive
+	FrameSize uint32
+	ArgsSize  int
+	// Text is the complete assembly, set by PassEmit
+	Text string
+}
+
+// String returns the sections of a, for dumps between passes.
+func (a *Assembly) String() string {
+	s := ""
+	section := func(name, asm string) {
+		if asm != "" {
+			s += "// " + name + "\n" + asm
+			if !strings.HasSuffix(asm, "\n") {
+				s += "\n"
+			}
+		}
+	}
+	if a.Text != "" {
+		section("text", a.Text)
+		return s
+	}
+	section("params", a.Params)
+	section("align checks", a.AlignChecks)
+	section("pins", a.Pins)
+	section("zero", a.Zero)
+	section("blocks", a.Blocks)
+	if a.FrameSize != 0 || a.ArgsSize != 0 {
+		s += fmt.Sprintf("// frame $%v-%v\n", a.FrameSize, a.ArgsSize)
+	}
+	return s
+}
+
+// defaultPasses returns the built in passes.
+func defaultPasses() []Pass {
+	return []Pass{
+		{PassParams, paramsPass},
+		{PassZero, zeroPass},
+		{PassPhi, func(f *Function, a *Assembly) *Error { return f.computePhi() }},
+		{PassLoadFuse, func(f *Function, a *Assembly) *Error { f.computeFusedLoads(); return nil }},
+		{PassBitLoop, func(f *Function, a *Assembly) *Error { f.computeBitLoops(); return nil }},
+		{PassPipeline, func(f *Function, a *Assembly) *Error { f.computePipelinedLoads(); return nil }},
+		{PassReduction, func(f *Function, a *Assembly) *Error { f.computeReductions(); return nil }},
+		{PassInduction, func(f *Function, a *Assembly) *Error { f.computeInductionPtrs(); return nil }},
+		{PassCSE, func(f *Function, a *Assembly) *Error { f.computeAddrCSE(); return nil }},
+		{PassSelect, func(f *Function, a *Assembly) *Error { f.computeSelects(); return nil }},
+		{PassSwitch, func(f *Function, a *Assembly) *Error { f.computeJumpTables(); return nil }},
+		{PassLoadCSE, func(f *Function, a *Assembly) *Error { f.computeLoadCSE(); return nil }},
+		{PassHoist, func(f *Function, a *Assembly) *Error { f.computeHoists(); a.Pins = f.LoadPins(); return nil }},
+		{PassSlots, func(f *Function, a *Assembly) *Error { f.assignSlots(); return nil }},
+		{PassLower, lowerPass},
+		{PassFrame, framePass},
+		{PassEmit, emitPass},
+	}
+}
+
+// Passes returns the names of the passes GoAssembly runs, in order.
+func (f *Function) Passes() []string {
+	names := []string{}
+	for _, p := range f.passes {
+		names = append(names, p.Name)
+	}
+	return names
+}
+
+// InsertPass adds p after the pass named after, or before all passes if
+// after is "". Pass names must be unique.
+func (f *Function) InsertPass(after string, p Pass) *Error {
+	if p.Name == "" || p.Run == nil {
+		return ErrorMsg2("pass needs a name and a Run function")
+	}
+	i := 0
+	if after != "" {
+		i = -1
+		for j, pass := range f.passes {
+			if pass.Name == after {
+				i = j + 1
+			}
+		}
+		if i == -1 {
+			return ErrorMsg2(fmt.Sprintf("no pass \"%v\" to insert \"%v\" after, passes are %v", after, p.Name, strings.Join(f.Passes(), ", ")))
+		}
+	}
+	for _, pass := range f.passes {
+		if pass.Name == p.Name {
+			return ErrorMsg2(fmt.Sprintf("pass \"%v\" already exists", p.Name))
+		}
+	}
+	f.passes = append(f.passes[:i], append([]Pass{p}, f.passes[i:]...)...)
+	return nil
+}
+
+// checkDumpAfter returns an error if DumpAfter names an unknown pass.
+func (f *Function) checkDumpAfter() *Error {
+	for _, name := range f.DumpAfter {
+		found := false
+		for _, p := range f.passes {
+			found = found || p.Name == name
+		}
+		if !found {
+			return ErrorMsg2(fmt.Sprintf("no pass \"%v\" to dump after, passes are %v", name, strings.Join(f.Passes(), ", ")))
+		}
+	}
+	return nil
+}
+
+// runPasses runs the passes, writing the Assembly after the passes in
+// DumpAfter and the other dumps.
+func (f *Function) runPasses() (string, *Error) {
+	if err := f.checkDumpAfter(); err != nil {
+		return "", err
+	}
+	a := &Assembly{}
+	for _, p := range f.passes {
+		if f.Trace {
+			fmt.Printf("TRACE %v\n", strings.ToUpper(p.Name))
+		}
+		if err := p.Run(f, a); err != nil {
+			return a.Text, err
+		}
+		if f.Trace {
+			fmt.Printf("TRACE {%v}\n", strings.ToUpper(p.Name))
+		}
+		f.dumpPass(p.Name, a)
+	}
+	return a.Text, nil
+}
+
+func paramsPass(f *Function, a *Assembly) *Error {
+	if err := f.checkFrameSize(); err != nil {
+		return err
+	}
+	if err := f.pinValues(); err != nil {
+		return err
+	}
+	params, err := f.Params()
+	a.Params = params
+	if err != nil {
+		return err
+	}
+	// registers are all free before the first block
+	if f.Debug {
+		a.AlignChecks = f.AlignChecks()
+	}
+	a.Pins = f.LoadPins()
+	return nil
+}
+
+func zeroPass(f *Function, a *Assembly) *Error {
+	zeroRetValue, err := f.ZeroRetValue()
+	a.Zero = zeroRetValue
+	if err != nil {
+		return err
+	}
+	zeroSsaLocals, err := f.ZeroSsaLocals()
+	a.Zero += zeroSsaLocals
+	return err
+}
+
+func lowerPass(f *Function, a *Assembly) *Error {
+	f.constTables = nil
+	basicblocks, err := f.BasicBlocks()
+	a.Blocks = basicblocks
+	return err
+}
+
+func framePass(f *Function, a *Assembly) *Error {
+	frameSize := f.localIdentsSize()
+	// align always rounds up to a non empty frame, with a frame the assembler
+	// saves and restores BP, the only callee saved register on amd64
+	frameSize = f.align(frameSize)
+	if len(f.jumpTables) > 0 {
+		// the stub index passed to the jump table helper at 0(SP)
+		frameSize += 8
+	}
+	a.FrameSize = frameSize
+	a.ArgsSize = f.retOffset() + int(f.retSize())
+	if f.opts.NoSplit {
+		limit := NoSplitStackLimit - f.opts.StackMargin
+		if usage := f.stackUsage(frameSize); usage > limit {
+			msg := "NOSPLIT stack use of %v bytes, with a frame of %v bytes, exceeds the limit of %v bytes less a margin of %v, generate \"%v\" without NoSplit"
+			return ErrorMsg2(fmt.Sprintf(msg, usage, frameSize, NoSplitStackLimit, f.opts.StackMargin, f.outfname()))
+		}
+	}
+	return nil
+}
+
+// stackUsage returns the bytes of stack f uses with a frame of frameSize
+// bytes, its return address, saved BP, and frame, and the return address
+// and saved AX of the jump table helper it calls.
+func (f *Function) stackUsage(frameSize uint32) int {
+	usage := 8 + int(frameSize)
+	if frameSize > 0 {
+		usage += 8
+	}
+	if len(f.jumpTables) > 0 {
+		usage += 16
+	}
+	return usage
+}
+
+func emitPass(f *Function, a *Assembly) *Error {
+	asm := a.Params
+	asm += f.SetStackPointer()
+	asm += a.AlignChecks
+	asm += a.Pins
+	asm += a.Zero
+	asm += a.Blocks
+	if a.AlignChecks != "" {
+		asm += AlignFault()
+	}
+	if f.boundsChecked {
+		asm += BoundsFault()
+	}
+	asm = f.fixupRets(asm)
+	asm = addIndent(asm, f.opts.Indent)
+	f.frameSize = a.FrameSize
+	f.argsSize = a.ArgsSize
+	flags := ""
+	if f.opts.NoSplit {
+		flags = "NOSPLIT,"
+	}
+	a.Text = fmt.Sprintf("TEXT ·%v(SB),%v$%v-%v\n%v", f.outfname(), flags, a.FrameSize, a.ArgsSize, asm)
+	a.Text += f.jumpTablesAsm()
+	a.Text += f.constTablesAsm()
+	return nil
+}
@@ -0,0 +1,165 @@
+package codegen
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"golang.org/x/tools/go/ssa"
+)
+
+func TestInsertPass(t *testing.T) {
+	const src = "package src\n\nfunc add(x, y int) int {\n\treturn x + y\n}\n"
+	f, err := CreateFunction(buildFunc(t, src, "add"), DefaultOptions())
+	if err != nil {
+		t.Fatal(err.Err)
+	}
+	blocks := ""
+	err = f.InsertPass(PassLower, Pass{"peephole", func(f *Function, a *Assembly) *Error {
+		blocks = a.Blocks
+		a.Blocks = strings.Replace(a.Blocks, "ADDQ", "ADDQ /* peephole */", 1)
+		return nil
+	}})
+	if err != nil {
+		t.Fatal(err.Err)
+	}
+	expected := []string{PassParams, PassZero, PassPhi, PassLoadFuse, PassBitLoop, PassPipeline, PassReduction, PassInduction,
+		PassCSE, PassSelect, PassSwitch, PassLoadCSE, PassHoist, PassSlots, PassLower, "peephole", PassFrame, PassEmit}
+	if !reflect.DeepEqual(f.Passes(), expected) {
+		t.Errorf("passes %v, expected %v", f.Passes(), expected)
+	}
+	if err := f.InsertPass("regalloc", Pass{"other", paramsPass}); err == nil {
+		t.Errorf("inserted a pass after an unknown pass")
+	}
+	if err := f.InsertPass("", Pass{"peephole", paramsPass}); err == nil {
+		t.Errorf("inserted a pass with a duplicate name")
+	}
+	asm, err := f.GoAssembly()
+	if err != nil {
+		t.Fatal(err.Err)
+	}
+	if !strings.Contains(blocks, "ADDQ") || !strings.Contains(asm, "ADDQ /* peephole */") {
+		t.Errorf("custom pass didn't run on the lowered blocks:\n%v", asm)
+	}
+
+	if f, err = CreateFunction(buildFunc(t, src, "add"), DefaultOptions()); err != nil {
+		t.Fatal(err.Err)
+	}
+	f.DumpAfter = []string{"regalloc"}
+	if _, err := f.GoAssembly(); err == nil || !strings.Contains(err.Err.Error(), "no pass \"regalloc\"") {
+		t.Errorf("dump after unknown pass error %v", err)
+	}
+}
+
+func TestNoSplit(t *testing.T) {
+	const src = "package src\n\nfunc add(x, y int) int {\n\treturn x + y\n}\n\n" +
+		"func big(x int) int {\n\tvar a [128]int\n\ta[x&127] = x\n\treturn a[(x+1)&127]\n}\n"
+	opts := DefaultOptions()
+	opts.NoSplit = true
+	f, err := CreateFunction(buildFunc(t, src, "add"), opts)
+	if err != nil {
+		t.Fatal(err.Err)
+	}
+	asm, err := f.GoAssembly()
+	if err != nil {
+		t.Fatal(err.Err)
+	}
+	if !strings.HasPrefix(asm, "TEXT ·add(SB),NOSPLIT,$") {
+		t.Errorf("NoSplit function not NOSPLIT:\n%v", asm)
+	}
+
+	if f, err = CreateFunction(buildFunc(t, src, "big"), opts); err != nil {
+		t.Fatal(err.Err)
+	}
+	if _, err := f.GoAssembly(); err == nil || !strings.Contains(err.Err.Error(), "exceeds the limit of 800 bytes") {
+		t.Errorf("NoSplit frame over the stack limit error %v", err)
+	}
+
+	opts.StackMargin = NoSplitStackLimit - 16
+	if f, err = CreateFunction(buildFunc(t, src, "add"), opts); err != nil {
+		t.Fatal(err.Err)
+	}
+	if _, err := f.GoAssembly(); err == nil || !strings.Contains(err.Err.Error(), "less a margin of 784") {
+		t.Errorf("NoSplit frame over the stack limit less the margin error %v", err)
+	}
+	opts.StackMargin = NoSplitStackLimit
+	if _, err := CreateFunction(buildFunc(t, src, "add"), opts); err == nil {
+		t.Errorf("stack margin of the whole limit accepted")
+	}
+}
+
+func TestFrameSizeLimit(t *testing.T) {
+	const src = "package src\n\nfunc huge(i int) int64 {\n\tvar a [1 << 28]int64\n\ta[i&1] = 1\n\treturn a[0]\n}\n\n" +
+		"func hugeParam(a [1 << 28]int64) int64 {\n\treturn a[1]\n}\n"
+	for _, name := range []string{"huge", "hugeParam"} {
+		f, err := CreateFunction(buildFunc(t, src, name), DefaultOptions())
+		if err != nil {
+			t.Fatal(err.Err)
+		}
+		if _, err := f.GoAssembly(); err == nil || !strings.Contains(err.Err.Error(), "exceed the largest frame") {
+			t.Errorf("%v frame over the limit error %v", name, err)
+		}
+	}
+}
+
+func TestConstIndexOverflow(t *testing.T) {
+	const src = "package src\n\nfunc wrap(x []int64) int64 {\n\treturn x[1<<61]\n}\n\n" +
+		"func fits(x []int64) int64 {\n\treturn x[1<<60-1]\n}\n"
+	f, err := CreateFunction(buildFunc(t, src, "wrap"), DefaultOptions())
+	if err != nil {
+		t.Fatal(err.Err)
+	}
+	if _, err := f.GoAssembly(); err == nil || !strings.Contains(err.Err.Error(), "overflows the element's address") {
+		t.Errorf("constant index overflowing an int64 error %v", err)
+	}
+	f, err = CreateFunction(buildFunc(t, src, "fits"), DefaultOptions())
+	if err != nil {
+		t.Fatal(err.Err)
+	}
+	asm, err := f.GoAssembly()
+	if err != nil {
+		t.Fatal(err.Err)
+	}
+	// the offset is moved to a register, not truncated to a displacement
+	if !strings.Contains(asm, "$9223372036854775800") {
+		t.Errorf("offset 1<<63-8 not in the assembly:\n%v", asm)
+	}
+}
+
+func TestAssignSlots(t *testing.T) {
+	// the inner loop blocks are emitted before the outer ones
+	const src = "package src\n\nfunc f(x []int, n int) int {\n\ts := 0\n\tfor j := 0; j < n; j++ {\n\t\tfor i := range x {\n\t\t\ts += x[i] * j\n\t\t}\n\t}\n\treturn s\n}\n"
+	f, err := CreateFunction(buildFunc(t, src, "f"), DefaultOptions())
+	if err != nil {
+		t.Fatal(err.Err)
+	}
+	slots := map[string]int{}
+	err = f.InsertPass(PassSlots, Pass{"record", func(f *Function, a *Assembly) *Error {
+		for name, ident := range f.identifiers {
+			slots[name] = ident.offset
+		}
+		return nil
+	}})
+	if err != nil {
+		t.Fatal(err.Err)
+	}
+	if _, err := f.GoAssembly(); err != nil {
+		t.Fatal(err.Err)
+	}
+	for _, b := range f.ssa.Blocks {
+		for _, instr := range b.Instrs {
+			if v, ok := instr.(ssa.Value); ok && slotType(v.Type()) {
+				if _, ok := slots[v.Name()]; !ok {
+					t.Errorf("%v = %v has no slot before lowering", v.Name(), v)
+				}
+			}
+		}
+	}
+	for name, ident := range f.identifiers {
+		if offset, ok := slots[name]; !ok && !ident.isConst() {
+			t.Errorf("slot of %v created while lowering", name)
+		} else if ok && offset != ident.offset {
+			t.Errorf("slot of %v moved from %v to %v while lowering", name, offset, ident.offset)
+		}
+	}
+}
@@ -0,0 +1,297 @@
+package codegen
+
+import (
+	"fmt"
+	"go/token"
+	"go/types"
+	"sort"
+
+	"golang.org/x/tools/go/ssa"
+)
+
+// RegDirective in a function's doc comment pins a variable to a register for
+// the whole function, e.g. "//gensimd:reg k X5" for a vector of round
+// constants. The register is never allocated to other values, the variable is
+// copied to it when it's assigned or stored to, and read from it instead of
+// from memory.
+const RegDirective = "//gensimd:reg"
+
+// regDirectives returns the registers given by the //gensimd:reg lines of fn.
+func regDirectives(fn *ssa.Function) (map[string]string, *Error) {
+	pinned := map[string]string{}
+	lines, pos := directiveArgs(fn, RegDirective)
+	for _, args := range lines {
+		if len(args) != 2 {
+			return nil, &Error{Err: fmt.Errorf("%v needs a variable and a register", RegDirective), Pos: pos}
+		}
+		if _, ok := pinned[args[0]]; ok {
+			return nil, &Error{Err: fmt.Errorf("%v variable (%v) is pinned twice", RegDirective, args[0]), Pos: pos}
+		}
+		pinned[args[0]] = args[1]
+	}
+	return pinned, nil
+}
+
+// pinRegister returns the register named name that a value can be pinned to,
+// all except the implicit operands, the reserved registers, and SI and DI
+// the string moves use.
+func (f *Function) pinRegister(name string) (*register, bool) {
+	for i := range f.registers {
+		r := &f.registers[i]
+		if r.name != name {
+			continue
+		}
+		if r.class(f.opts.Arch) == RESERVED || r.width != 64 && r.typ == DATA_REG {
+			return nil, false
+		}
+		for _, x := range excludedRegisters {
+			if x.name == name {
+				return nil, false
+			}
+		}
+		if r.regconst == REG_SI || r.regconst == REG_DI {
+			return nil, false
+		}
+		return r, true
+	}
+	return nil, false
+}
+
+// pinnedValue returns the value of the parameter or local variable name, or
+// the local's allocation if it's in memory, e.g. a vector composite literal.
+// Locals need the debug references of the ssa builder mode BuilderMode.
+func (f *Function) pinnedValue(name string) (ssa.Value, string) {
+	for _, p := range f.ssa.Params {
+		if p.Name() == name {
+			return p, ""
+		}
+	}
+	refs := []*ssa.DebugRef{}
+	var alloc *ssa.Alloc
+	for _, block := range f.ssa.Blocks {
+		for _, instr := range block.Instrs {
+			ref, ok := instr.(*ssa.DebugRef)
+			if !ok || ref.Object() == nil || ref.Object().Name() != name {
+				continue
+			}
+			if ref.IsAddr {
+				a, ok := ref.X.(*ssa.Alloc)
+				if !ok || a.Heap || !pinnableAlloc(a) || alloc != nil && alloc != a {
+					return nil, "has its address taken"
+				}
+				alloc = a
+			}
+			refs = append(refs, ref)
+		}
+	}
+	var val ssa.Value
+	if alloc != nil {
+		val = alloc
+	}
+	for _, ref := range refs {
+		// the loads of a local in memory are its uses, not assignments
+		if load, ok := ref.X.(*ssa.UnOp); ok && alloc != nil && load.Op == token.MUL && load.X == alloc {
+			continue
+		}
+		if val != nil && val != ref.X {
+			return nil, "is assigned more than once"
+		}
+		val = ref.X
+	}
+	if val == nil {
+		return nil, "isn't a parameter or local variable"
+	}
+	if _, ok := val.(*ssa.Const); ok {
+		return nil, "is a constant"
+	}
+	if _, ok := val.(ssa.Instruction); !ok {
+		return nil, "isn't a parameter or local variable"
+	}
+	return val, ""
+}
+
+// pinnableAlloc returns whether the local alloc is only loaded, stored to,
+// and has its elements loaded and stored to, so every store is seen by
+// setPins.
+func pinnableAlloc(alloc *ssa.Alloc) bool {
+	for _, ref := range *alloc.Referrers() {
+		switch ref := ref.(type) {
+		case *ssa.DebugRef:
+		case *ssa.UnOp:
+			if ref.Op != token.MUL {
+				return false
+			}
+		case *ssa.Store:
+			if ref.Val == alloc {
+				return false
+			}
+		case *ssa.IndexAddr:
+			for _, ref := range *ref.Referrers() {
+				switch ref := ref.(type) {
+				case *ssa.DebugRef:
+				case *ssa.UnOp:
+					if ref.Op != token.MUL {
+						return false
+					}
+				case *ssa.Store:
+					if _, ok := ref.Val.(*ssa.IndexAddr); ok {
+						return false
+					}
+				default:
+					return false
+				}
+			}
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// pinnedType returns the type of the pinned value val, the type of the local
+// if val is its allocation.
+func pinnedType(val ssa.Value) types.Type {
+	if alloc, ok := val.(*ssa.Alloc); ok {
+		return alloc.Type().Underlying().(*types.Pointer).Elem()
+	}
+	return val.Type()
+}
+
+// pinValues resolves the variables of Pinned to the values and registers
+// they're pinned to.
+func (f *Function) pinValues() *Error {
+	f.pins = map[string]*register{}
+	pos := f.ssa.Pos()
+	if decl := f.ssa.Syntax(); decl != nil {
+		pos = decl.Pos()
+	}
+	names := []string{}
+	for name := range f.Pinned {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	pinnedTo := map[string]string{}
+	for _, name := range names {
+		regName := f.Pinned[name]
+		reg, ok := f.pinRegister(regName)
+		if !ok {
+			return &Error{Err: fmt.Errorf("%v variable (%v) can't be pinned to register (%v)", RegDirective, name, regName), Pos: pos}
+		}
+		if other, ok := pinnedTo[regName]; ok {
+			return &Error{Err: fmt.Errorf("%v variables (%v) and (%v) are pinned to the same register (%v)", RegDirective, other, name, regName), Pos: pos}
+		}
+		pinnedTo[regName] = name
+		val, msg := f.pinnedValue(name)
+		if val == nil {
+			return &Error{Err: fmt.Errorf("%v variable (%v) %v", RegDirective, name, msg), Pos: pos}
+		}
+		t := pinnedType(val)
+		if !isXmm(t) && !isInteger(t) {
+			return &Error{Err: fmt.Errorf("%v variable (%v) isn't an integer, float, or simd value", RegDirective, name), Pos: val.Pos()}
+		}
+		if regType(t) != reg.typ {
+			return &Error{Err: fmt.Errorf("%v variable (%v) of type %v doesn't fit register (%v)", RegDirective, name, t, regName), Pos: val.Pos()}
+		}
+		f.pins[val.Name()] = reg
+	}
+	return nil
+}
+
+// pinned returns whether reg is pinned to a value.
+func (f *Function) pinned(reg *register) bool {
+	for _, r := range f.pins {
+		if r.name == reg.name {
+			return true
+		}
+	}
+	return false
+}
+
+// LoadPins loads the pinned parameters into their registers.
+func (f *Function) LoadPins() string {
+	asm := ""
+	ctx := context{f, nil}
+	fp := getRegister(REG_FP)
+	for _, p := range f.ssa.Params {
+		reg, ok := f.pins[p.Name()]
+		if !ok {
+			continue
+		}
+		ident := f.identifiers[p.Name()]
+		m := ident.storage.(*memory)
+		asm += MovMemReg(ctx, m.optype(), ident.name, ident.offset, fp, reg, false)
+	}
+	if asm != "" {
+		asm = "// BEGIN LoadPins\n" + asm + "// END LoadPins\n"
+	}
+	return asm
+}
+
+// setPins copies the pinned value instr defines, or the pinned local it
+// stores to, to the register it's pinned to.
+func (f *Function) setPins(instr ssa.Instruction) string {
+	switch instr := instr.(type) {
+	case *ssa.Phi:
+		// an accumulator's register is set on the loop edges
+		if _, ok := f.reductions[instr]; ok {
+			return ""
+		}
+	case *ssa.BinOp:
+		if f.isReductionUpdate(instr) {
+			return f.setReduction(instr)
+		}
+	}
+	if v, ok := instr.(ssa.Value); ok {
+		if _, ok := f.pins[v.Name()]; ok {
+			return f.setPin(instr, v)
+		}
+	}
+	if store, ok := instr.(*ssa.Store); ok {
+		addr := store.Addr
+		if elem, ok := addr.(*ssa.IndexAddr); ok {
+			addr = elem.X
+		}
+		if _, ok := f.pins[addr.Name()]; ok {
+			return f.setPin(instr, addr)
+		}
+	}
+	return ""
+}
+
+// setPin copies v to the register it's pinned to, from the register holding
+// v or else from its memory, after storing the registers holding parts of it.
+func (f *Function) setPin(instr ssa.Instruction, v ssa.Value) string {
+	reg := f.pins[v.Name()]
+	ctx := context{f, instr}
+	ident := f.Ident(v)
+	m, ok := ident.storage.(*memory)
+	if !ok {
+		ice(fmt.Sprintf("pinned value %v has no memory", v.Name()))
+	}
+	chunk := ident.storageRegion()
+	optype := m.optype()
+	optype.size = chunk.size
+	asm := fmt.Sprintf("// BEGIN setPin %v, %v\n", v.Name(), reg.name)
+	if r := m.fetch(chunk); r != nil {
+		asm += MovRegReg(ctx, optype, r, reg, false)
+	} else {
+		asm += m.spillRegisters(ctx, false)
+		asm += MovMemReg(ctx, optype, m.name(), m.offset(), m.reg(), reg, false)
+	}
+	asm += fmt.Sprintf("// END setPin %v, %v\n", v.Name(), reg.name)
+	return asm
+}
+
+// loadPin copies the value of m from the register it's pinned to, instead of
+// loading it from memory, to a new register that aliases m. Without a
+// register holding it the value is in memory, so the copy isn't stored back.
+func (m *memory) loadPin(ctx context, chunk region, pin *register) (string, *register) {
+	m.setInitialized(chunk)
+	asm, r := ctx.f.allocIdentReg(ctx.loc, m.owner(), chunk.size)
+	m.addAlias(ctx, alias{r, chunk})
+	optype := m.optype()
+	optype.size = chunk.size
+	asm += MovRegReg(ctx, optype, pin, r, false)
+	r.dirty = false
+	return asm, r
+}
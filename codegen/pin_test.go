@@ -0,0 +1,64 @@
+package codegen
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestPin checks a pinned register is only written where the pinned value is
+// set, and the errors of invalid //gensimd:reg directives.
+func TestPin(t *testing.T) {
+	const src = `package src
+
+func sum(x []int, n int) int {
+	m := n*3 + 1
+	s := 0
+	for i := range x {
+		s += x[i]*m + n
+	}
+	return s
+}
+`
+	gen := func(directives string) (string, *Error) {
+		f, err := CreateFunction(buildFuncMode(t, strings.Replace(src, "func sum", directives+"func sum", 1), "sum", BuilderMode), DefaultOptions())
+		if err != nil {
+			return "", err
+		}
+		return f.GoAssembly()
+	}
+	asm, err := gen("//gensimd:reg n R12\n//gensimd:reg m R13\n")
+	if err != nil {
+		t.Fatal(err.Err)
+	}
+	writes := map[string]int{}
+	for _, line := range strings.Split(asm, "\n") {
+		for _, reg := range []string{"R12", "R13"} {
+			if strings.HasSuffix(strings.TrimSpace(line), ", "+reg) {
+				writes[reg]++
+			}
+		}
+	}
+	if !strings.Contains(asm, "n+24(FP), R12") || writes["R12"] != 1 || writes["R13"] != 1 {
+		t.Errorf("expected R12 and R13 written once, by the pinned n and m, got\n%v", asm)
+	}
+
+	for _, test := range []struct {
+		directives, err string
+	}{
+		{"//gensimd:reg n\n", "needs a variable and a register"},
+		{"//gensimd:reg n R12\n//gensimd:reg n R13\n", "variable (n) is pinned twice"},
+		{"//gensimd:reg k R12\n", "variable (k) isn't a parameter or local variable"},
+		{"//gensimd:reg s R12\n", "variable (s) is assigned more than once"},
+		{"//gensimd:reg x R12\n", "variable (x) isn't an integer, float, or simd value"},
+		{"//gensimd:reg n X3\n", "variable (n) of type int doesn't fit register (X3)"},
+		{"//gensimd:reg n R12\n//gensimd:reg m R12\n", "variables (m) and (n) are pinned to the same register (R12)"},
+		{"//gensimd:reg n AX\n", "can't be pinned to register (AX)"},
+		{"//gensimd:reg n SI\n", "can't be pinned to register (SI)"},
+		{"//gensimd:reg n R14\n", "can't be pinned to register (R14)"},
+		{"//gensimd:reg n R16\n", "can't be pinned to register (R16)"},
+	} {
+		if _, err := gen(test.directives); err == nil || !strings.Contains(err.Err.Error(), test.err) {
+			t.Errorf("%q: got error %v, expected %q", test.directives, err, test.err)
+		}
+	}
+}
@@ -0,0 +1,319 @@
+package codegen
+
+import (
+	"fmt"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/ssa"
+)
+
+// pipelinedLoad is a load "*&s[i]" of the element of a slice parameter s in
+// the one block body of a counted loop "i < len(s)", software pipelined with
+// Options.Pipeline. The element of the next iteration, s[i+step], is loaded
+// into the stack slot ident right after the current one is read from it, so
+// the load overlaps the computation of the current iteration. The element of
+// the first iteration, s[init+offset], is loaded on the edge entering the
+// loop. The loads are skipped past the end of s.
+type pipelinedLoad struct {
+	ident  *identifier
+	slice  *ssa.Parameter
+	index  ssa.Value
+	step   int64
+	init   ssa.Value
+	offset int64
+}
+
+// computePipelinedLoads finds the loads of the loops that are software
+// pipelined.
+func (f *Function) computePipelinedLoads() {
+	f.pipelined = make(map[*ssa.UnOp]*pipelinedLoad)
+	f.pipelineInits = make(map[int]map[int][]*pipelinedLoad)
+	// the loads skip IndexAddr and so its bounds checks
+	if !f.opts.Pipeline || !f.Optimize || f.opts.BoundsCheck {
+		return
+	}
+	for _, header := range f.ssa.Blocks {
+		ifInstr, ok := header.Instrs[len(header.Instrs)-1].(*ssa.If)
+		if !ok || len(header.Preds) != 2 {
+			continue
+		}
+		body := header.Succs[0]
+		entry, back := header.Preds[0], header.Preds[1]
+		if header.Dominates(entry) {
+			entry, back = back, entry
+		}
+		// a loop of the header and one block, entered by a jump
+		if back != body || len(body.Preds) != 1 || len(body.Succs) != 1 || header.Dominates(entry) {
+			continue
+		}
+		if _, ok := entry.Instrs[len(entry.Instrs)-1].(*ssa.Jump); !ok {
+			continue
+		}
+		cond, ok := ifInstr.Cond.(*ssa.BinOp)
+		if !ok || cond.Op != token.LSS {
+			continue
+		}
+		for _, instr := range body.Instrs {
+			load, ok := instr.(*ssa.UnOp)
+			if !ok || load.Op != token.MUL {
+				continue
+			}
+			addr, ok := load.X.(*ssa.IndexAddr)
+			if !ok || addr.Block() != body || addr.Index != cond.X || !pipelineAddr(addr, load) {
+				continue
+			}
+			slice, ok := addr.X.(*ssa.Parameter)
+			if !ok || !isSlice(slice.Type()) || !isLenOf(cond.Y, slice) || !pipelineType(load.Type()) {
+				continue
+			}
+			if !f.pipelineSafe(header, addr) || !f.pipelineSafe(body, addr) || storesBefore(body, load, slice) {
+				continue
+			}
+			p, ok := loopIndex(header, addr.Index, entry, back)
+			if !ok {
+				continue
+			}
+			p.slice = slice
+			p.ident = f.newPipelineIdent(load.Type())
+			f.pipelined[load] = p
+			f.fusedInstrs[addr] = true
+			if f.pipelineInits[entry.Index] == nil {
+				f.pipelineInits[entry.Index] = make(map[int][]*pipelinedLoad)
+			}
+			f.pipelineInits[entry.Index][header.Index] = append(f.pipelineInits[entry.Index][header.Index], p)
+		}
+	}
+}
+
+// loopIndex returns the step and the first value of the loop index, either
+// the phi of header "i" incremented by a constant on the back edge, or "i + c"
+// of the phi "i" of a range loop, the value of the phi on the back edge.
+func loopIndex(header *ssa.BasicBlock, index ssa.Value, entry, back *ssa.BasicBlock) (*pipelinedLoad, bool) {
+	edge := func(phi *ssa.Phi, pred *ssa.BasicBlock) ssa.Value {
+		for i, p := range header.Preds {
+			if p == pred {
+				return phi.Edges[i]
+			}
+		}
+		return nil
+	}
+	if phi, ok := index.(*ssa.Phi); ok && phi.Block() == header {
+		c, ok := phiIncrement(phi, edge(phi, back))
+		return &pipelinedLoad{index: index, step: c, init: edge(phi, entry)}, ok && c > 0 && c == int64(int32(c))
+	}
+	add, ok := index.(*ssa.BinOp)
+	if !ok || add.Block() != header {
+		return nil, false
+	}
+	phi, ok := add.X.(*ssa.Phi)
+	if !ok || phi.Block() != header || edge(phi, back) != add {
+		return nil, false
+	}
+	c, ok := phiIncrement(phi, add)
+	return &pipelinedLoad{index: index, step: c, init: edge(phi, entry), offset: c}, ok && c > 0 && c == int64(int32(c))
+}
+
+// pipelineAddr returns whether the element address addr is only used by load.
+func pipelineAddr(addr *ssa.IndexAddr, load *ssa.UnOp) bool {
+	for _, ref := range *addr.Referrers() {
+		if _, ok := ref.(*ssa.DebugRef); !ok && ref != load {
+			return false
+		}
+	}
+	return true
+}
+
+// pipelineType returns whether the elements of type t are loaded into one
+// register.
+func pipelineType(t types.Type) bool {
+	if isXmm(t) {
+		return sizeof(t) <= XmmRegSize
+	}
+	return (isInteger(t) || isBool(t)) && sizeof(t) <= DataRegSize
+}
+
+// isLenOf returns whether v is "len(s)".
+func isLenOf(v ssa.Value, s ssa.Value) bool {
+	call, ok := v.(*ssa.Call)
+	if !ok {
+		return false
+	}
+	builtin, ok := call.Common().Value.(*ssa.Builtin)
+	return ok && builtin.Name() == "len" && call.Common().Args[0] == s
+}
+
+// pipelineSafe returns whether the stores of block don't change the elements
+// loaded ahead from the element addresses like addr. It only stores to
+// locals, to the element at addr, to the elements of other slice parameters
+// if they don't overlap, see disjointObjects, and calls len or intrinsics
+// taking no slices or pointers.
+func (f *Function) pipelineSafe(block *ssa.BasicBlock, addr *ssa.IndexAddr) bool {
+	for _, instr := range block.Instrs {
+		switch instr := instr.(type) {
+		case *ssa.Store:
+			switch dst := instr.Addr.(type) {
+			case *ssa.Alloc:
+				if dst.Heap {
+					return false
+				}
+			case *ssa.IndexAddr:
+				if alloc, ok := dst.X.(*ssa.Alloc); ok && !alloc.Heap {
+					continue
+				}
+				if _, ok := dst.X.(*ssa.Parameter); !ok || !isSlice(dst.X.Type()) {
+					return false
+				}
+				if dst.X == addr.X && dst.Index != addr.Index || dst.X != addr.X && !f.disjointObjects(dst.X, addr.X) {
+					return false
+				}
+			default:
+				return false
+			}
+		case *ssa.Call:
+			if builtin, ok := instr.Common().Value.(*ssa.Builtin); ok && builtin.Name() == "len" {
+				continue
+			}
+			if instr.Common().StaticCallee() == nil {
+				return false
+			}
+			for _, arg := range instr.Common().Args {
+				if isSlice(arg.Type()) || isPointer(arg.Type()) {
+					return false
+				}
+			}
+		}
+	}
+	return true
+}
+
+// storesBefore returns whether block stores to an element of slice before load.
+func storesBefore(block *ssa.BasicBlock, load *ssa.UnOp, slice *ssa.Parameter) bool {
+	for _, instr := range block.Instrs {
+		if instr == load {
+			return false
+		}
+		if store, ok := instr.(*ssa.Store); ok {
+			if addr, ok := store.Addr.(*ssa.IndexAddr); ok && addr.X == slice {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// newPipelineIdent allocates the stack slot of the element loaded ahead.
+func (f *Function) newPipelineIdent(typ types.Type) *identifier {
+	name := fmt.Sprintf("pipe%v", len(f.pipelined))
+	ident := &identifier{
+		f:      f,
+		name:   name,
+		typ:    typ,
+		offset: -int(f.localIdentsSize()) - int(sizeof(typ))}
+	ident.initStorage(false)
+	f.identifiers[name] = ident
+	return ident
+}
+
+// PipelineInits loads the first element of the pipelined loads of the loop
+// entered on the edge from block blockIndex to block jmpIndex.
+func (f *Function) PipelineInits(loc ssa.Instruction, blockIndex, jmpIndex int) (string, *Error) {
+	asm := ""
+	for _, p := range f.pipelineInits[blockIndex][jmpIndex] {
+		a, idx, err := f.LoadIndex(loc, p.init)
+		if err != nil {
+			return "", err
+		}
+		asm += fmt.Sprintf("// BEGIN PipelineInit %v = %v[%v+%v]\n", p.ident.name, p.slice.Name(), p.init.Name(), p.offset)
+		asm += a
+		a, err = f.pipelineLoad(loc, p, idx, p.offset)
+		if err != nil {
+			return "", err
+		}
+		asm += a
+		asm += fmt.Sprintf("// END PipelineInit %v = %v[%v+%v]\n", p.ident.name, p.slice.Name(), p.init.Name(), p.offset)
+	}
+	return asm, nil
+}
+
+// PipelinedLoad reads the element of the current iteration loaded ahead into
+// the stack slot, then loads the element of the next iteration into it.
+func (f *Function) PipelinedLoad(instr *ssa.UnOp, p *pipelinedLoad) (string, *Error) {
+	ctx := context{f, instr}
+	assignment := f.Ident(instr)
+	asm := fmt.Sprintf("// BEGIN PipelinedLoad %v = %v\n", instr.Name(), p.ident.name)
+	a, reg := f.allocIdentReg(instr, assignment, assignment.size())
+	asm += a
+	sp := getRegister(REG_SP)
+	optype := GetOpDataType(instr.Type())
+	asm += MovMemReg(ctx, optype, p.ident.name, p.ident.offset, sp, reg, false)
+	asm += assignment.newValue(ctx, reg, 0, assignment.size())
+	f.freeReg(reg)
+	a, idx, err := f.LoadIndex(instr, p.index)
+	if err != nil {
+		return "", err
+	}
+	asm += a
+	a, err = f.pipelineLoad(instr, p, idx, p.step)
+	if err != nil {
+		return "", err
+	}
+	asm += a
+	asm += fmt.Sprintf("// END PipelinedLoad %v = %v\n", instr.Name(), p.ident.name)
+	return asm, nil
+}
+
+// pipelineLoad loads the element of p's slice at the index in idx plus add
+// into its stack slot, skipping the load past the end of the slice. idx may
+// be the register of a value, it's copied before it's changed. Registers are
+// allocated before the branch, so the allocator state is the same after it,
+// the values they hold are spilled as at loc, which is needed for their
+// liveness.
+func (f *Function) pipelineLoad(loc ssa.Instruction, p *pipelinedLoad, idx *register, add int64) (string, *Error) {
+	if loc == nil {
+		return ErrorMsg("pipelineLoad: nil instr")
+	}
+	ctx := context{f, loc}
+	asm := ""
+	a, next := f.allocReg(loc, DATA_REG, DataRegSize)
+	asm += a
+	a, length := f.allocReg(loc, DATA_REG, DataRegSize)
+	asm += a
+	dataSize := uint(DataRegSize)
+	if isXmm(p.ident.typ) {
+		dataSize = XmmRegSize
+	}
+	a, data := f.allocReg(loc, regType(p.ident.typ), dataSize)
+	asm += a
+	optypes := GetIntegerOpDataType(false, sizePtr())
+	asm += MovRegReg(ctx, optypes, idx, next, false)
+	f.freeReg(idx)
+	if add != 0 {
+		asm += AddImm32Reg(ctx, uint32(add), next, false)
+	}
+	sliceInfo := f.identifiers[p.slice.Name()]
+	sliceReg, sliceOffset, _ := sliceInfo.Addr()
+	// the length is the second word of a slice, an index past it or
+	// negative, compared unsigned, skips the load
+	asm += MovMemReg(ctx, optypes, sliceInfo.name, sliceOffset+int(sizePtr()), &sliceReg, length, false)
+	asm += CmpRegReg(ctx, optypes, next, length)
+	skip := f.newJmpLabel()
+	asm += fmt.Sprintf("%-9v    %v\n", JCC, skip)
+	asm += MovMemReg(ctx, optypes, sliceInfo.name, sliceOffset, &sliceReg, length, false)
+	elemSize := sizeofElem(p.slice.Type())
+	if isLeaScale(elemSize) {
+		asm += LeaScaled(ctx, length, next, elemSize, length, false)
+	} else {
+		asm += MulImm32RegReg(ctx, uint32(elemSize), next, next, false)
+		asm += AddRegReg(ctx, optypes, next, length, false)
+	}
+	optype := GetOpDataType(p.ident.typ)
+	asm += MovMemReg(ctx, optype, "", 0, length, data, false)
+	sp := getRegister(REG_SP)
+	asm += MovRegMem(ctx, optype, data, p.ident.name, sp, p.ident.offset)
+	asm += skip + ":\n"
+	f.freeReg(next)
+	f.freeReg(length)
+	f.freeReg(data)
+	return asm, nil
+}
@@ -0,0 +1,213 @@
+package codegen
+
+import (
+	"fmt"
+	"io"
+
+	"golang.org/x/tools/go/ssa"
+)
+
+// blockPressure is the register pressure of a basic block, the most values
+// live at once and the values the allocator spilled lowering it.
+type blockPressure struct {
+	block *ssa.BasicBlock
+	// live values, those in integer and xmm registers, and the instruction
+	// they're live at, nil for the end of the block
+	live, ints, xmms int
+	at               ssa.Instruction
+	spilled          []string
+}
+
+// liveSet maps identifier names to the live identifiers.
+type liveSet map[string]*identifier
+
+// valueIdent returns the identifier of v, or nil for constants and values
+// without one.
+func (f *Function) valueIdent(v ssa.Value) *identifier {
+	if v == nil {
+		return nil
+	}
+	ident, ok := f.identifiers[f.cseValue(v).Name()]
+	if !ok || ident.isConst() {
+		return nil
+	}
+	return ident
+}
+
+// use adds the operands of instr to live, phi operands are live at the end
+// of the predecessors instead and debug references generate no code.
+func (f *Function) use(live liveSet, instr ssa.Instruction) {
+	switch instr.(type) {
+	case *ssa.Phi, *ssa.DebugRef:
+		return
+	}
+	for _, op := range f.instrOperands(instr) {
+		if op == nil {
+			continue
+		}
+		if ident := f.valueIdent(*op); ident != nil {
+			live[ident.name] = ident
+		}
+	}
+}
+
+// def returns the identifier instr defines, or nil.
+func (f *Function) def(instr ssa.Instruction) *identifier {
+	if v, ok := instr.(ssa.Value); ok {
+		return f.valueIdent(v)
+	}
+	return nil
+}
+
+// liveOut returns the identifiers live at the end of each block, computed
+// backwards from the uses of each value until nothing changes.
+func (f *Function) liveOut() map[*ssa.BasicBlock]liveSet {
+	out := map[*ssa.BasicBlock]liveSet{}
+	in := map[*ssa.BasicBlock]liveSet{}
+	for _, b := range f.ssa.Blocks {
+		out[b], in[b] = liveSet{}, liveSet{}
+	}
+	for changed := true; changed; {
+		changed = false
+		for i := len(f.ssa.Blocks) - 1; i >= 0; i-- {
+			b := f.ssa.Blocks[i]
+			for _, succ := range b.Succs {
+				for name, ident := range in[succ] {
+					if _, ok := out[b][name]; !ok {
+						out[b][name], changed = ident, true
+					}
+				}
+				for _, instr := range succ.Instrs {
+					phi, ok := instr.(*ssa.Phi)
+					if !ok {
+						break
+					}
+					for j, pred := range succ.Preds {
+						if pred != b {
+							continue
+						}
+						if ident := f.valueIdent(phi.Edges[j]); ident != nil {
+							if _, ok := out[b][ident.name]; !ok {
+								out[b][ident.name], changed = ident, true
+							}
+						}
+					}
+				}
+			}
+			live := liveSet{}
+			for name, ident := range out[b] {
+				live[name] = ident
+			}
+			for j := len(b.Instrs) - 1; j >= 0; j-- {
+				if ident := f.def(b.Instrs[j]); ident != nil {
+					delete(live, ident.name)
+				}
+				f.use(live, b.Instrs[j])
+			}
+			for name, ident := range live {
+				if _, ok := in[b][name]; !ok {
+					in[b][name], changed = ident, true
+				}
+			}
+		}
+	}
+	return out
+}
+
+// pressure returns the register pressure of each block, the values live
+// across an instruction are its operands, its result, and the values used
+// after it.
+func (f *Function) pressure() []blockPressure {
+	out := f.liveOut()
+	pressures := []blockPressure{}
+	for _, b := range f.ssa.Blocks {
+		p := blockPressure{block: b, spilled: f.blockSpills[b.Index]}
+		live := liveSet{}
+		for name, ident := range out[b] {
+			live[name] = ident
+		}
+		p.measure(live, nil)
+		for j := len(b.Instrs) - 1; j >= 0; j-- {
+			instr := b.Instrs[j]
+			if _, ok := instr.(*ssa.DebugRef); ok {
+				continue
+			}
+			f.use(live, instr)
+			ident := f.def(instr)
+			if ident != nil {
+				live[ident.name] = ident
+			}
+			p.measure(live, instr)
+			if ident != nil {
+				delete(live, ident.name)
+			}
+		}
+		pressures = append(pressures, p)
+	}
+	return pressures
+}
+
+// measure records the values of live at instr if there are more than the
+// most so far, keeping the earliest instruction of ties.
+func (p *blockPressure) measure(live liveSet, instr ssa.Instruction) {
+	if len(live) == 0 || len(live) < p.live {
+		return
+	}
+	p.live, p.ints, p.xmms, p.at = len(live), 0, 0, instr
+	for _, ident := range live {
+		if regType(ident.typ) == XMM_REG {
+			p.xmms++
+		} else {
+			p.ints++
+		}
+	}
+}
+
+// blockSpill records a spill of ident by the allocator lowering the
+// current block, for DumpPressure.
+func (f *Function) blockSpill(ident *identifier) {
+	if !f.DumpPressure || f.block == nil {
+		return
+	}
+	for _, name := range f.blockSpills[f.block.Index] {
+		if name == ident.name {
+			return
+		}
+	}
+	f.blockSpills[f.block.Index] = append(f.blockSpills[f.block.Index], ident.name)
+}
+
+// allocatable returns the number of registers of type t the allocator
+// assigns to values.
+func (f *Function) allocatable(t RegType) int {
+	n := 0
+	for i := range f.registers {
+		if r := &f.registers[i]; r.typ == t && !f.excludeReg(r) {
+			n++
+		}
+	}
+	return n
+}
+
+// writePressure writes the most values live at once in each block, split
+// by register type, where they're live, and the values spilled lowering
+// the block.
+func (f *Function) writePressure(w io.Writer) {
+	fmt.Fprintf(w, "// DUMP %v pressure, %v integer and %v xmm registers\n", f.outfname(), f.allocatable(DATA_REG), f.allocatable(XMM_REG))
+	for _, p := range f.pressure() {
+		at := "end"
+		if v, ok := p.at.(ssa.Value); ok && f.def(p.at) != nil {
+			at = fmt.Sprintf("%v = %v", v.Name(), p.at)
+		} else if p.at != nil {
+			at = p.at.String()
+		}
+		fmt.Fprintf(w, "b%v %v: %v live (%v integer, %v xmm) at %v", p.block.Index, p.block.Comment, p.live, p.ints, p.xmms, at)
+		if len(p.spilled) > 0 {
+			fmt.Fprintf(w, ", spilled")
+			for _, name := range p.spilled {
+				fmt.Fprintf(w, " %v", name)
+			}
+		}
+		fmt.Fprintf(w, "\n")
+	}
+}
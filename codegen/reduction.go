@@ -0,0 +1,193 @@
+package codegen
+
+import (
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/ssa"
+)
+
+// maxReductions is the most accumulators of each register type kept in
+// registers, the rest of the function needs enough registers left.
+const maxReductions = 3
+
+// reduction is an accumulator "s = phi(init, s')" of a loop header updated
+// once per iteration by "s' = s op x", e.g. "sum += x[i]". Both values are
+// pinned to reg, so the accumulator stays in it across the whole loop instead
+// of being stored to the phi's slot and reloaded every iteration. The phi
+// move of the back edge is skipped, the update is copied to reg, and reg is
+// set from init on the edge entering the loop. After the loop the phi is read
+// from reg, its slot isn't written again.
+type reduction struct {
+	phi    *ssa.Phi
+	update *ssa.BinOp
+	reg    *register
+	entry  int
+	back   int
+}
+
+// computeReductions finds the accumulators of the loops and pins them.
+func (f *Function) computeReductions() {
+	f.reductions = make(map[*ssa.Phi]*reduction)
+	if !f.Optimize {
+		return
+	}
+	count := map[RegType]int{}
+	for _, loop := range naturalLoops(f.ssa) {
+		header := loop.header
+		if len(header.Preds) != 2 {
+			continue
+		}
+		entry, back := 0, 1
+		if header.Preds[entry] == loop.latch {
+			entry, back = back, entry
+		}
+		if header.Dominates(header.Preds[entry]) {
+			continue
+		}
+		for _, instr := range header.Instrs {
+			phi, ok := instr.(*ssa.Phi)
+			if !ok {
+				break
+			}
+			update, ok := phi.Edges[back].(*ssa.BinOp)
+			if !ok || !loop.inLoop[update.Block().Index] || !reductionOp(phi, update) || !reductionUses(phi, update, loop.inLoop) {
+				continue
+			}
+			t := regType(phi.Type())
+			if count[t] == maxReductions {
+				continue
+			}
+			reg := f.spareRegister(t)
+			if reg == nil {
+				continue
+			}
+			count[t]++
+			f.pins[phi.Name()] = reg
+			f.pins[update.Name()] = reg
+			f.reductions[phi] = &reduction{
+				phi:    phi,
+				update: update,
+				reg:    reg,
+				entry:  header.Preds[entry].Index,
+				back:   header.Preds[back].Index}
+		}
+	}
+}
+
+// reductionOp returns whether update is "phi op x" or "x op phi" of a
+// commutative op, of an integer or float phi.
+func reductionOp(phi *ssa.Phi, update *ssa.BinOp) bool {
+	t := phi.Type()
+	switch {
+	case isFloat(t) && !isComplex(t):
+		switch update.Op {
+		case token.ADD, token.SUB, token.MUL:
+		default:
+			return false
+		}
+	case isInteger(t) && sizeof(t) <= DataRegSize:
+		switch update.Op {
+		case token.ADD, token.SUB, token.MUL, token.AND, token.OR, token.XOR:
+		default:
+			return false
+		}
+	default:
+		return false
+	}
+	if !types.Identical(update.Type(), t) {
+		return false
+	}
+	return update.X == phi || update.Y == phi && update.Op != token.SUB
+}
+
+// reductionUses returns whether the update is only used by phi and phi is
+// only used by the update in the loop, or in its header before the update,
+// or after the loop, so no use in the loop sees the register changed by the
+// update before the back edge.
+func reductionUses(phi *ssa.Phi, update *ssa.BinOp, inLoop []bool) bool {
+	for _, ref := range *update.Referrers() {
+		if _, ok := ref.(*ssa.DebugRef); !ok && ref != phi {
+			return false
+		}
+	}
+	for _, ref := range *phi.Referrers() {
+		switch ref.(type) {
+		case *ssa.DebugRef:
+			continue
+		case *ssa.Phi:
+			if inLoop[ref.Block().Index] {
+				return false
+			}
+			continue
+		}
+		if ref == update || !inLoop[ref.Block().Index] {
+			continue
+		}
+		if ref.Block() != phi.Block() || update.Block() == phi.Block() {
+			return false
+		}
+	}
+	return true
+}
+
+// spareRegister returns the first register of type t not pinned yet that a
+// value can be pinned to, the allocator uses the last registers first. BP is
+// left for the frame pointer.
+func (f *Function) spareRegister(t RegType) *register {
+	for i := range f.registers {
+		r := &f.registers[i]
+		if r.typ != t || r.regconst == REG_BP || f.pinned(r) {
+			continue
+		}
+		if _, ok := f.pinRegister(r.name); ok {
+			return r
+		}
+	}
+	return nil
+}
+
+// ReductionInits sets the registers of the accumulators of the loop entered
+// on the edge from block blockIndex to block jmpIndex, after the phi moves.
+func (f *Function) ReductionInits(loc ssa.Instruction, blockIndex, jmpIndex int) string {
+	asm := ""
+	for _, phiInfo := range f.phiInfo[blockIndex][jmpIndex] {
+		if r, ok := f.reductions[phiInfo.phi]; ok && r.entry == blockIndex {
+			asm += f.setPin(loc, r.phi)
+		}
+	}
+	return asm
+}
+
+// reductionBackEdge returns whether phi is an accumulator, its phi move on
+// the back edge from block blockIndex is skipped.
+func (f *Function) reductionBackEdge(phi *ssa.Phi, blockIndex int) bool {
+	r, ok := f.reductions[phi]
+	return ok && r.back == blockIndex
+}
+
+// isReductionUpdate returns whether instr updates an accumulator.
+func (f *Function) isReductionUpdate(instr *ssa.BinOp) bool {
+	for _, r := range f.reductions {
+		if r.update == instr {
+			return true
+		}
+	}
+	return false
+}
+
+// setReduction copies the update of an accumulator to its register. The
+// update is only read by the phi move of the back edge, which is skipped, so
+// the register holding it isn't stored to its slot, the slot is marked
+// initialized instead.
+func (f *Function) setReduction(update *ssa.BinOp) string {
+	asm := f.setPin(update, update)
+	ident := f.Ident(update)
+	m := ident.storage.(*memory)
+	chunk := ident.storageRegion()
+	if r := m.fetch(chunk); r != nil {
+		r.dirty = false
+	}
+	m.setInitialized(chunk)
+	return asm
+}
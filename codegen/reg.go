@@ -28,7 +28,7 @@ func (r *register) size() uint {
 func (r *register) modified(ctx context, spill bool) string {
 	if r.parent != nil {
 		if r.parent.owner().f.Trace {
-			fmt.Printf(r.parent.owner().f.Indent+"Modified %v (inUse %v, spill %v, old dirty %v)\n",
+			fmt.Printf(r.parent.owner().f.opts.Indent+"Modified %v (inUse %v, spill %v, old dirty %v)\n",
 				r.name, r.inUse, spill, r.dirty)
 		}
 	}
@@ -188,6 +188,51 @@ var excludedRegisters = []register{
 	{"FP", false, REG_FP, FpReg, 64, QuadSize, false, nil},
 }
 
+// RegClass is the calling convention class of a register.
+type RegClass int
+
+const (
+	// calls may clobber the register, values live across a call are spilled
+	CALLER_SAVE = RegClass(iota)
+	// calls preserve the register, a function using it must restore it
+	CALLEE_SAVE
+	// reserved by the Go runtime or assembler, never allocated
+	RESERVED
+)
+
+func (c RegClass) String() string {
+	switch c {
+	case CALLER_SAVE:
+		return "CALLER_SAVE"
+	case CALLEE_SAVE:
+		return "CALLEE_SAVE"
+	case RESERVED:
+		return "RESERVED"
+	}
+	panic("Invalid regclass")
+}
+
+// regClasses are the register classes of each target, registers not listed
+// are CALLER_SAVE. On amd64 the Go ABIs have no callee saved registers
+// except BP, the frame pointer, which the assembler saves and restores in
+// functions with a frame. The register ABI keeps the g pointer in R14 and
+// zero in X15, they're reserved so calls from the assembly don't need to
+// restore them, and so X15 is free as a scratch register when zeroing.
+var regClasses = map[string]map[Reg]RegClass{
+	"amd64": {
+		REG_BP:  CALLEE_SAVE,
+		REG_SP:  RESERVED,
+		REG_FP:  RESERVED,
+		REG_R14: RESERVED,
+		REG_X15: RESERVED,
+	},
+}
+
+// class returns the register class of r for arch.
+func (r *register) class(arch string) RegClass {
+	return regClasses[arch][r.regconst]
+}
+
 func getRegister(reg Reg) *register {
 	for _, r := range registers {
 		if r.regconst == reg {
@@ -0,0 +1,405 @@
+package codegen
+
+import (
+	"fmt"
+	"go/token"
+	"go/types"
+	"strconv"
+
+	"golang.org/x/tools/go/ssa"
+)
+
+// maxSelectPhis is the most phis set by a select, each needs two registers.
+const maxSelectPhis = 3
+
+// selectInfo is an if choosing the phi values of the block it joins, like
+// "if a < b { a = b }" or "if c { v = x } else { v = y }", where the blocks
+// between the if and the join are empty or only negate values, like "if x < 0
+// { x = -x }". The phis are set with conditional moves, or MAXSS/MINSS and
+// sign masks for floats, instead of branches.
+type selectInfo struct {
+	cmp  *ssa.BinOp
+	join *ssa.BasicBlock
+	phis []selectPhi
+}
+
+// selectPhi is a phi set to t if the comparison is true, otherwise to f.
+// For floats the comparison is f < t for max and t < f for min, unless t is
+// -f or f is -t.
+type selectPhi struct {
+	phi        *ssa.Phi
+	t, f       ssa.Value
+	max        bool
+	tneg, fneg bool
+}
+
+// computeSelects finds the ifs lowered as selects and the empty blocks they
+// skip.
+func (f *Function) computeSelects() {
+	f.selects = make(map[*ssa.If]*selectInfo)
+	f.selectArms = make(map[*ssa.BasicBlock]bool)
+	if !f.Optimize {
+		return
+	}
+	for _, block := range f.ssa.Blocks {
+		if len(block.Instrs) == 0 {
+			continue
+		}
+		instr, ok := block.Instrs[len(block.Instrs)-1].(*ssa.If)
+		if !ok {
+			continue
+		}
+		sel, arms, ok := f.matchSelect(instr)
+		if !ok {
+			continue
+		}
+		f.selects[instr] = sel
+		for _, arm := range arms {
+			f.selectArms[arm] = true
+		}
+	}
+}
+
+// matchSelect returns the select of instr and the empty blocks between
+// instr and the join block.
+func (f *Function) matchSelect(instr *ssa.If) (*selectInfo, []*ssa.BasicBlock, bool) {
+	cmp, ok := f.cmpBeforeIf(instr)
+	if !ok {
+		return nil, nil, false
+	}
+	block := instr.Block()
+	tblock, fblock := block.Succs[0], block.Succs[1]
+	// the predecessors of the join reached if the comparison is true or false
+	var join, tpred, fpred *ssa.BasicBlock
+	var arms []*ssa.BasicBlock
+	switch {
+	case f.isSelectArm(block, tblock) && f.isSelectArm(block, fblock) && tblock.Succs[0] == fblock.Succs[0]:
+		join, tpred, fpred = tblock.Succs[0], tblock, fblock
+		arms = []*ssa.BasicBlock{tblock, fblock}
+	case f.isSelectArm(block, tblock) && tblock.Succs[0] == fblock:
+		join, tpred, fpred = fblock, tblock, block
+		arms = []*ssa.BasicBlock{tblock}
+	case f.isSelectArm(block, fblock) && fblock.Succs[0] == tblock:
+		join, tpred, fpred = tblock, block, fblock
+		arms = []*ssa.BasicBlock{fblock}
+	default:
+		return nil, nil, false
+	}
+	if join == block || len(join.Preds) != 2 {
+		return nil, nil, false
+	}
+	if len(f.inductionUpdates[tpred.Index][join.Index]) != 0 || len(f.inductionUpdates[fpred.Index][join.Index]) != 0 {
+		return nil, nil, false
+	}
+	tedge, fedge := 0, 1
+	if join.Preds[0] == fpred {
+		tedge, fedge = 1, 0
+	}
+	if join.Preds[tedge] != tpred || join.Preds[fedge] != fpred {
+		return nil, nil, false
+	}
+	sel := &selectInfo{cmp: cmp, join: join}
+	float := isFloat(cmp.X.Type())
+	for _, instr := range join.Instrs {
+		phi, ok := instr.(*ssa.Phi)
+		if !ok {
+			continue
+		}
+		sp := selectPhi{phi: phi, t: phi.Edges[tedge], f: phi.Edges[fedge]}
+		sp.tneg = isArmNeg(block, tpred, sp.t, sp.f)
+		sp.fneg = isArmNeg(block, fpred, sp.f, sp.t)
+		switch {
+		case sp.tneg || sp.fneg:
+			// ints are negated before the comparison, floats are xored with
+			// the sign bit of a mask of the same size
+			if float {
+				if !types.Identical(phi.Type(), cmp.X.Type()) {
+					return nil, nil, false
+				}
+			} else if !isInteger(phi.Type()) {
+				return nil, nil, false
+			}
+		case isArmValue(block, tpred, sp.t) || isArmValue(block, fpred, sp.f):
+			return nil, nil, false
+		case float:
+			if sp.max, ok = floatMaxMin(cmp, sp.t, sp.f); !ok {
+				return nil, nil, false
+			}
+		case !isInteger(phi.Type()) && !isBool(phi.Type()):
+			return nil, nil, false
+		}
+		sel.phis = append(sel.phis, sp)
+	}
+	if len(sel.phis) == 0 || len(sel.phis) > maxSelectPhis {
+		return nil, nil, false
+	}
+	return sel, arms, true
+}
+
+// isSelectArm returns true if block only jumps from the if block to the
+// join block, after negating values.
+func (f *Function) isSelectArm(ifBlock, block *ssa.BasicBlock) bool {
+	if len(block.Preds) != 1 || block.Preds[0] != ifBlock || len(block.Succs) != 1 {
+		return false
+	}
+	for _, instr := range block.Instrs[:len(block.Instrs)-1] {
+		switch instr := instr.(type) {
+		case *ssa.DebugRef:
+		case *ssa.UnOp:
+			if instr.Op != token.SUB {
+				return false
+			}
+		default:
+			return false
+		}
+	}
+	_, ok := block.Instrs[len(block.Instrs)-1].(*ssa.Jump)
+	return ok
+}
+
+// isArmValue returns true if v is computed in the arm pred of ifBlock.
+func isArmValue(ifBlock, pred *ssa.BasicBlock, v ssa.Value) bool {
+	instr, ok := v.(ssa.Instruction)
+	return ok && pred != ifBlock && instr.Block() == pred
+}
+
+// isArmNeg returns true if v is -x computed in the arm pred of ifBlock.
+func isArmNeg(ifBlock, pred *ssa.BasicBlock, v, x ssa.Value) bool {
+	neg, ok := v.(*ssa.UnOp)
+	return ok && neg.Op == token.SUB && neg.X == x && isArmValue(ifBlock, pred, v)
+}
+
+// floatMaxMin returns whether t if cmp else f is max(t, f) or min(t, f) with
+// the semantics of MAXSS/MINSS, the second operand f is chosen if the
+// comparison is false, including for NaNs and zeros of either sign. Only
+// the strict comparisons have them.
+func floatMaxMin(cmp *ssa.BinOp, t, f ssa.Value) (max, ok bool) {
+	if !isFloat(t.Type()) || !types.Identical(t.Type(), cmp.X.Type()) {
+		return false, false
+	}
+	// normalize to p < q
+	p, q := cmp.X, cmp.Y
+	switch cmp.Op {
+	default:
+		return false, false
+	case token.LSS:
+	case token.GTR:
+		p, q = q, p
+	}
+	if sameValue(t, q) && sameValue(f, p) {
+		// p < q ? q : p
+		return true, true
+	}
+	if sameValue(t, p) && sameValue(f, q) {
+		// p < q ? p : q
+		return false, true
+	}
+	return false, false
+}
+
+// sameValue returns true if x and y are the same value or equal constants
+// of the same type.
+func sameValue(x, y ssa.Value) bool {
+	if x == y {
+		return true
+	}
+	cx, okx := x.(*ssa.Const)
+	cy, oky := y.(*ssa.Const)
+	if !okx || !oky || cx.Value == nil || cy.Value == nil {
+		return false
+	}
+	return types.Identical(cx.Type(), cy.Type()) && cx.Value.ExactString() == cy.Value.ExactString()
+}
+
+// isSelectCmp returns true if the comparison instr is computed by the select
+// ending its block.
+func (f *Function) isSelectCmp(instr *ssa.BinOp) bool {
+	instrs := instr.Block().Instrs
+	ifInstr, ok := instrs[len(instrs)-1].(*ssa.If)
+	if !ok {
+		return false
+	}
+	sel, ok := f.selects[ifInstr]
+	return ok && sel.cmp == instr
+}
+
+// Select sets the phis of sel's join block and jumps to it. The values are
+// loaded, and negated, before the comparison so loading constants can't
+// change the flags.
+func (f *Function) Select(instr *ssa.If, sel *selectInfo) (string, *Error) {
+	ctx := context{f, instr}
+	asm := ""
+	float := isFloat(sel.cmp.X.Type())
+	dsts := make([]*register, len(sel.phis))
+	srcs := make([]*register, len(sel.phis))
+	for i, sp := range sel.phis {
+		if sp.tneg || sp.fneg {
+			var a string
+			var err *Error
+			if float {
+				a, dsts[i], srcs[i], err = f.selectFloatNeg(instr, sel.cmp, sp)
+			} else {
+				a, dsts[i], srcs[i], err = f.selectIntNeg(instr, sp)
+			}
+			if err != nil {
+				return "", err
+			}
+			asm += a
+			continue
+		}
+		// the result starts as the value chosen if the comparison is false,
+		// t for floats since MAXSS/MINSS choose their source operand
+		first, second := sp.f, sp.t
+		if float {
+			first, second = sp.t, sp.f
+		}
+		a, reg, err := f.LoadValue(instr, first, 0, f.sizeof(first))
+		if err != nil {
+			return "", err
+		}
+		asm += a
+		ident := f.Ident(sp.phi)
+		size := uint(DataRegSize)
+		if float {
+			size = ident.size()
+		}
+		a, dsts[i] = f.allocIdentReg(instr, ident, size)
+		asm += a
+		asm += MovRegReg(ctx, GetOpDataType(sp.phi.Type()), reg, dsts[i], false)
+		f.freeReg(reg)
+		a, srcs[i], err = f.LoadValue(instr, second, 0, f.sizeof(second))
+		if err != nil {
+			return "", err
+		}
+		asm += a
+	}
+	if !float {
+		a, err := f.BinOpCmpFlags(sel.cmp)
+		if err != nil {
+			return "", err
+		}
+		asm += a
+	}
+	for i, sp := range sel.phis {
+		if float && (sp.tneg || sp.fneg) {
+			asm += FloatBitwiseOp(ctx, token.XOR, srcs[i], dsts[i])
+		} else if float {
+			asm += FloatMaxMin(ctx, GetOpDataType(sp.phi.Type()), sp.max, srcs[i], dsts[i])
+		} else {
+			asm += CMovOpRegReg(ctx, GetOpDataType(sel.cmp.X.Type()), sel.cmp.Op, srcs[i], dsts[i], f.Ident(sp.phi).size())
+		}
+		f.freeReg(srcs[i])
+	}
+	for i, sp := range sel.phis {
+		ident := f.Ident(sp.phi)
+		a, err := f.StoreValue(instr, ident, dsts[i])
+		if err != nil {
+			return "", err
+		}
+		asm += a
+		a, err = f.spillAllIdent(ident, instr)
+		if err != nil {
+			return "", err
+		}
+		asm += a
+		f.freeReg(dsts[i])
+	}
+	a, err := f.spillRegisters(ctx)
+	if err != nil {
+		return "", err
+	}
+	asm += a
+	if !f.isNextBlock(sel.join.Index) {
+		asm += fmt.Sprintf("%-9v    ", "JMP") + "block" + strconv.Itoa(sel.join.Index) + "\n"
+	}
+	asm = fmt.Sprintf("// BEGIN select ssa.If, %v\n", instr) + asm
+	asm += fmt.Sprintf("// END select ssa.If, %v\n", instr)
+	return asm, nil
+}
+
+// selectIntNeg loads the value of the phi if the comparison is false into
+// dst and the value if it's true into src, one is the negation of the other.
+func (f *Function) selectIntNeg(instr *ssa.If, sp selectPhi) (asm string, dst, src *register, err *Error) {
+	ctx := context{f, instr}
+	x := sp.f
+	if sp.fneg {
+		x = sp.t
+	}
+	a, reg, err := f.LoadValue(instr, x, 0, f.sizeof(x))
+	if err != nil {
+		return "", nil, nil, err
+	}
+	asm += a
+	ident := f.Ident(sp.phi)
+	a, dst = f.allocIdentReg(instr, ident, DataRegSize)
+	asm += a
+	a, src = f.allocReg(instr, DATA_REG, DataRegSize)
+	asm += a
+	data := GetOpDataType(sp.phi.Type())
+	asm += MovRegReg(ctx, data, reg, dst, false)
+	asm += MovRegReg(ctx, data, reg, src, false)
+	f.freeReg(reg)
+	if sp.tneg {
+		asm += NegReg(ctx, src, ident.size(), false)
+	} else {
+		asm += NegReg(ctx, dst, ident.size(), false)
+	}
+	return asm, dst, src, nil
+}
+
+// selectFloatNeg loads x, the value of the phi that isn't negated, into dst
+// and the sign bit into src if the phi is -x, otherwise zero. The phi is
+// dst xor src.
+func (f *Function) selectFloatNeg(instr *ssa.If, cmp *ssa.BinOp, sp selectPhi) (asm string, dst, src *register, err *Error) {
+	ctx := context{f, instr}
+	x := sp.f
+	if sp.fneg {
+		x = sp.t
+	}
+	a, reg, err := f.LoadValue(instr, x, 0, f.sizeof(x))
+	if err != nil {
+		return "", nil, nil, err
+	}
+	asm += a
+	ident := f.Ident(sp.phi)
+	a, dst = f.allocIdentReg(instr, ident, ident.size())
+	asm += a
+	data := GetOpDataType(sp.phi.Type())
+	asm += MovRegReg(ctx, data, reg, dst, false)
+	f.freeReg(reg)
+
+	// the mask of the comparison, or if f is -t its negation
+	op, p, q := cmp.Op, cmp.X, cmp.Y
+	switch op {
+	case token.GTR:
+		op, p, q = token.LSS, q, p
+	case token.GEQ:
+		op, p, q = token.LEQ, q, p
+	}
+	a, reg, err = f.LoadValue(instr, p, 0, f.sizeof(p))
+	if err != nil {
+		return "", nil, nil, err
+	}
+	asm += a
+	a, src = f.allocReg(instr, XMM_REG, ident.size())
+	asm += a
+	asm += MovRegReg(ctx, data, reg, src, false)
+	f.freeReg(reg)
+	a, reg, err = f.LoadValue(instr, q, 0, f.sizeof(q))
+	if err != nil {
+		return "", nil, nil, err
+	}
+	asm += a
+	asm += FloatCmpMask(ctx, data, op, sp.fneg, reg, src)
+	f.freeReg(reg)
+
+	a, tmp := f.allocReg(instr, DATA_REG, DataRegSize)
+	asm += a
+	a, sign := f.allocReg(instr, XMM_REG, ident.size())
+	asm += a
+	asm += MovSignMaskReg(ctx, data, false, tmp, sign)
+	asm += FloatBitwiseOp(ctx, token.AND, sign, src)
+	f.freeReg(tmp)
+	f.freeReg(sign)
+	return asm, dst, src, nil
+}
@@ -2,7 +2,10 @@ package codegen
 
 import (
 	"fmt"
+	exact "go/constant"
+	"strings"
 
+	"github.com/bjwbell/gensimd/simd"
 	"golang.org/x/tools/go/ssa"
 )
 
@@ -34,6 +37,8 @@ var simdToGoAsm = map[SimdInstr]InstructionType{
 	ShrU32x4: I_PSRL,
 	AddU64x2: I_PADD,
 	SubU64x2: I_PSUB,
+	ShlU64x2: I_PSLL,
+	ShrU64x2: I_PSRL,
 	AddF32x4: I_ADD,
 	SubF32x4: I_SUB,
 	MulF32x4: I_MUL,
@@ -79,6 +84,8 @@ const (
 	ShuffleU32x4
 	AddU64x2
 	SubU64x2
+	ShlU64x2
+	ShrU64x2
 	AddF32x4
 	SubF32x4
 	MulF32x4
@@ -102,11 +109,117 @@ func getSimdInstr(name string) (InstructionType, bool) {
 type intrinsic func(f *Function, loc ssa.Instruction, x, y, result *identifier) (string, *Error)
 
 var intrinsics = map[string]intrinsic{
-	"MulI32x4":     mulI32x4,
-	"ShuffleI32x4": shufU32x4,
-	"MulU32x4":     mulI32x4, //TODO: FIX
-	"ShrU16x8":     shrU16x8,
-	"ShuffleU32x4": shufU32x4,
+	"MulI32x4":      mulI32x4,
+	"ShuffleI32x4":  shufU32x4,
+	"MulU32x4":      mulI32x4, //TODO: FIX
+	"ShrU16x8":      shrU16x8,
+	"ShuffleU32x4":  shufU32x4,
+	"I32x4ToF32x4":  cvtI32x4ToF32x4,
+	"F32x4ToI32x4":  cvtF32x4ToI32x4,
+	"PackI32x4":     packI32x4,
+	"UnpackLoI16x8": unpackLoI16x8,
+	"UnpackHiI16x8": unpackHiI16x8,
+	"F16ToF32x4":    cvtF16ToF32x4,
+	"F32ToF16x4":    cvtF32ToF16x4,
+
+	"MaskedLoadF32x4":  maskedLoadF32x4,
+	"MaskedStoreF32x4": maskedStoreF32x4,
+	"MaskedLoadF32x8":  maskedLoadF32x8,
+	"MaskedStoreF32x8": maskedStoreF32x8,
+
+	"InterleaveLoF32x4":     interleaveLoX4,
+	"InterleaveHiF32x4":     interleaveHiX4,
+	"InterleaveLoI32x4":     interleaveLoX4,
+	"InterleaveHiI32x4":     interleaveHiX4,
+	"DeinterleaveEvenF32x4": deinterleaveEvenX4,
+	"DeinterleaveOddF32x4":  deinterleaveOddX4,
+	"DeinterleaveEvenI32x4": deinterleaveEvenX4,
+	"DeinterleaveOddI32x4":  deinterleaveOddX4,
+
+	"SplatF32x4": splatOp(SHUFPS),
+	"SplatF64x2": splatOp(SHUFPD),
+
+	"DotF32x4":        dotF32x4,
+	"SumAbsDiffU8x16": sumAbsDiffU8x16,
+	"MAddI16x8":       maddI16x8,
+	"DotU8I8x16":      dotU8I8x16,
+
+	"MaxF32x4": maxMinOp(MAXPS),
+	"MinF32x4": maxMinOp(MINPS),
+	"MaxF64x2": maxMinOp(MAXPD),
+	"MinF64x2": maxMinOp(MINPD),
+	"MaxI16x8": maxMinOp(PMAXSW),
+	"MinI16x8": maxMinOp(PMINSW),
+	"MaxU8x16": maxMinOp(PMAXUB),
+	"MinU8x16": maxMinOp(PMINUB),
+	"MaxI32x4": maxMinOp(PMAXSD),
+	"MinI32x4": maxMinOp(PMINSD),
+
+	"LoadU32LE":  byteOrderLoad(false),
+	"LoadU32BE":  byteOrderLoad(true),
+	"LoadU64LE":  byteOrderLoad(false),
+	"LoadU64BE":  byteOrderLoad(true),
+	"StoreU32LE": byteOrderStore(false),
+	"StoreU32BE": byteOrderStore(true),
+	"StoreU64LE": byteOrderStore(false),
+	"StoreU64BE": byteOrderStore(true),
+
+	"PopCountU8x16": popCountU8x16,
+	"AndNotU8x16":   andNot,
+	"AndNotI32x4":   andNot,
+	"AndNotU32x4":   andNot,
+	"AndNotU64x2":   andNot,
+	"AndU8x16":      andOp,
+	"OrU8x16":       orOp,
+	"XorU8x16":      xorOp,
+	"OrU64x2":       orOp,
+	"XorU64x2":      xorOp,
+	"ShlVarU32x4":   shlVarX4,
+	"ShlVarI32x4":   shlVarX4,
+	"ShrVarU32x4":   shrVarU32x4,
+	"ShrVarI32x4":   shrVarI32x4,
+
+	"LoadU8x16":     vectorLoad(1),
+	"StoreU8x16":    vectorStore(1),
+	"SplatU8x16":    splatU8x16,
+	"CmpEqU8x16":    cmpEqU8x16,
+	"MoveMaskU8x16": moveMaskOp(PMOVMSKB),
+
+	"LoadI64x2":     vectorLoad(8),
+	"SplatI64x2":    splatI64x2,
+	"OrI64x2":       orOp,
+	"CmpEqI64x2":    cmpEqI64x2,
+	"CmpGtI64x2":    cmpGtI64x2,
+	"MoveMaskI64x2": moveMaskOp(MOVMSKPD),
+	"SplatI32x4":    splatI32x4,
+	"StoreI32x4":    vectorStore(4),
+	"CompressI32x4": compressI32x4,
+
+	"PrefixSumI32x4":   prefixSumOp(4, false),
+	"PrefixSumI64x2":   prefixSumOp(8, false),
+	"DeltaDecodeI32x4": prefixSumOp(4, true),
+	"DeltaDecodeI64x2": prefixSumOp(8, true),
+
+	"ShuffleVarU8x16":  shuffleVarU8x16,
+	"MulGF8U8x16":      mulGF8U8x16,
+	"MulGF8TableU8x16": mulGF8TableU8x16,
+
+	"LookupU8x16":       lookupU8x16,
+	"InRangeU8x16":      inRangeU8x16,
+	"ShrU8x16":          shrU8x16,
+	"InterleaveLoU8x16": interleaveLoU8x16,
+	"InterleaveHiU8x16": interleaveHiU8x16,
+	"PackNibblesU8x16":  packNibblesU8x16,
+
+	"Pext64":  bitsOp(PEXTQ),
+	"Pdep64":  bitsOp(PDEPQ),
+	"MulHi64": mulHi64,
+
+	"RdRand64": randOp(RDRANDQ),
+	"RdSeed64": randOp(RDSEEDQ),
+
+	"Fence":           fence,
+	"CompilerBarrier": compilerBarrier,
 }
 
 func packedOp(f *Function, loc ssa.Instruction, instrtype InstructionType, optypes XmmData, x, y, result *identifier) (string, *Error) {
@@ -280,7 +393,8 @@ func shrU16x8(f *Function, loc ssa.Instruction, x, count, result *identifier) (s
 				wordReg,
 				countReg,
 				tmp,
-				wordReg.size(), true)
+				wordReg.size(),
+				count.size(), true)
 
 			asm += instrImm8RegReg(ctx, f, PINSRW, i, wordReg, reg, true)
 
@@ -340,3 +454,1314 @@ func shufU32x4(f *Function, loc ssa.Instruction, x, result, order *identifier) (
 
 	return asm, nil
 }
+
+// conversions between SIMD types
+
+// unaryPackedOp computes result = instr(x)
+func unaryPackedOp(f *Function, loc ssa.Instruction, instr Instruction, x, result *identifier) (string, *Error) {
+	ctx := context{f, loc}
+	asm, src, err := f.LoadSimd(loc, x)
+	if err != nil {
+		return "", err
+	}
+	a, dst := f.allocReg(loc, XMM_REG, XmmRegSize)
+	asm += a
+	asm += instrRegReg(ctx, instr, src, dst, false)
+	a, err = f.StoreSimd(loc, dst, result)
+	if err != nil {
+		return "", err
+	}
+	asm += a
+	f.freeReg(src)
+	f.freeReg(dst)
+	return asm, nil
+}
+
+func cvtI32x4ToF32x4(f *Function, loc ssa.Instruction, x, y, result *identifier) (string, *Error) {
+	// rounds using the MXCSR rounding mode, round to nearest even by default
+	return unaryPackedOp(f, loc, CVTPL2PS, x, result)
+}
+
+func cvtF32x4ToI32x4(f *Function, loc ssa.Instruction, x, y, result *identifier) (string, *Error) {
+	// truncates, NaN and out of range values are converted to math.MinInt32
+	return unaryPackedOp(f, loc, CVTTPS2PL, x, result)
+}
+
+func packI32x4(f *Function, loc ssa.Instruction, x, y, result *identifier) (string, *Error) {
+	// signed saturation of x into the low four and y into the high four int16s
+	return binaryPackedOp(f, loc, PACKSSLW, x, y, result)
+}
+
+// binaryPackedOp computes result = instr(x, y) for instructions that
+// overwrite their destination (x) operand, x is copied so it isn't modified
+func binaryPackedOp(f *Function, loc ssa.Instruction, instr Instruction, x, y, result *identifier) (string, *Error) {
+	return binaryPackedOpImm8(f, loc, instr, false, 0, x, y, result)
+}
+
+func binaryPackedOpImm8(f *Function, loc ssa.Instruction, instr Instruction, hasImm8 bool, imm8 uint8, x, y, result *identifier) (string, *Error) {
+	ctx := context{f, loc}
+	asm, regx, err := f.LoadSimd(loc, x)
+	if err != nil {
+		return "", err
+	}
+	b, regy, err := f.LoadSimd(loc, y)
+	if err != nil {
+		return "", err
+	}
+	asm += b
+	a, dst := f.allocReg(loc, XMM_REG, XmmRegSize)
+	asm += a
+	asm += MovRegReg(ctx, OpDataType{op: OP_PACKED, xmmvariant: XMM_F128}, regx, dst, false)
+	if hasImm8 {
+		asm += instrImm8RegReg(ctx, f, instr, imm8, regy, dst, false)
+	} else {
+		asm += instrRegReg(ctx, instr, regy, dst, false)
+	}
+	a, err = f.StoreSimd(loc, dst, result)
+	if err != nil {
+		return "", err
+	}
+	asm += a
+	f.freeReg(regx)
+	f.freeReg(regy)
+	f.freeReg(dst)
+	return asm, nil
+}
+
+func unpackLoI16x8(f *Function, loc ssa.Instruction, x, y, result *identifier) (string, *Error) {
+	return unpackI16x8(f, loc, PUNPCKLWL, x, result)
+}
+
+func unpackHiI16x8(f *Function, loc ssa.Instruction, x, y, result *identifier) (string, *Error) {
+	return unpackI16x8(f, loc, PUNPCKHWL, x, result)
+}
+
+// unpackI16x8 sign extends four int16s of x to int32s, SSE2 has no PMOVSXWD
+// so the int16s are interleaved with themselves and shifted right arithmetic
+func unpackI16x8(f *Function, loc ssa.Instruction, punpck Instruction, x, result *identifier) (string, *Error) {
+	ctx := context{f, loc}
+	asm, src, err := f.LoadSimd(loc, x)
+	if err != nil {
+		return "", err
+	}
+	a, dst := f.allocReg(loc, XMM_REG, XmmRegSize)
+	asm += a
+	asm += MovRegReg(ctx, OpDataType{op: OP_PACKED, xmmvariant: XMM_F128}, src, dst, false)
+	asm += instrRegReg(ctx, punpck, dst, dst, false)
+	asm += instrImm8Reg(ctx, f, PSRAL, 16, dst, false)
+	a, err = f.StoreSimd(loc, dst, result)
+	if err != nil {
+		return "", err
+	}
+	asm += a
+	f.freeReg(src)
+	f.freeReg(dst)
+	return asm, nil
+}
+
+// the half precision conversions are F16C instructions, in the avx2 target
+
+func cvtF16ToF32x4(f *Function, loc ssa.Instruction, x, y, result *identifier) (string, *Error) {
+	return unaryPackedOp(f, loc, VCVTPH2PS, x, result)
+}
+
+func cvtF32ToF16x4(f *Function, loc ssa.Instruction, x, y, result *identifier) (string, *Error) {
+	// rounding control 0 rounds to nearest even, the high 64 bits are zeroed
+	ctx := context{f, loc}
+	asm, src, err := f.LoadSimd(loc, x)
+	if err != nil {
+		return "", err
+	}
+	a, dst := f.allocReg(loc, XMM_REG, XmmRegSize)
+	asm += a
+	asm += instrImm8RegReg(ctx, f, VCVTPS2PH, 0, src, dst, false)
+	a, err = f.StoreSimd(loc, dst, result)
+	if err != nil {
+		return "", err
+	}
+	asm += a
+	f.freeReg(src)
+	f.freeReg(dst)
+	return asm, nil
+}
+
+// masked loads and stores, these use the 128 bit AVX VMASKMOVPS so
+// callers must check simd.AVX()
+
+func maskedLoadF32x4(f *Function, loc ssa.Instruction, p, mask, result *identifier) (string, *Error) {
+	ctx := context{f, loc}
+	asm, ptr, err := f.LoadIdent(loc, p, 0, sizePtr())
+	if err != nil {
+		return "", err
+	}
+	a, regmask, err := f.LoadSimd(loc, mask)
+	if err != nil {
+		return "", err
+	}
+	asm += a
+	a, dst := f.allocReg(loc, XMM_REG, XmmRegSize)
+	asm += a
+	asm += dst.modified(ctx, false)
+	asm += fmt.Sprintf("%-9v    (%v), %v, %v\n", VMASKMOVPS, ptr.name, regmask.name, dst.name)
+	a, err = f.StoreSimd(loc, dst, result)
+	if err != nil {
+		return "", err
+	}
+	asm += a
+	f.freeReg(ptr)
+	f.freeReg(regmask)
+	f.freeReg(dst)
+	return asm, nil
+}
+
+func maskedStoreF32x4(f *Function, loc ssa.Instruction, p, mask, result *identifier) (string, *Error) {
+	// no result, the value to store is the third argument
+	x := f.Ident(loc.(*ssa.Call).Common().Args[2])
+	asm, ptr, err := f.LoadIdent(loc, p, 0, sizePtr())
+	if err != nil {
+		return "", err
+	}
+	a, regmask, err := f.LoadSimd(loc, mask)
+	if err != nil {
+		return "", err
+	}
+	asm += a
+	a, regx, err := f.LoadSimd(loc, x)
+	if err != nil {
+		return "", err
+	}
+	asm += a
+	asm += fmt.Sprintf("%-9v    %v, %v, (%v)\n", VMASKMOVPS, regx.name, regmask.name, ptr.name)
+	f.freeReg(ptr)
+	f.freeReg(regmask)
+	f.freeReg(regx)
+	return asm, nil
+}
+
+// ymmOperand returns the memory operand of the 32 byte value ident after
+// storing any of its chunks held in registers, 256 bit values aren't kept in
+// registers between instructions.
+func (f *Function) ymmOperand(loc ssa.Instruction, ident *identifier) (string, string, *Error) {
+	if ident.cnst != nil {
+		msg := "constant 256 bit value (%v) isn't supported, pass it as a parameter"
+		return "", "", &Error{Err: fmt.Errorf(msg, ident.cnst), Pos: loc.Pos()}
+	}
+	asm := ident.spillDirtyRegisters(loc)
+	reg, offset, _ := ident.Addr()
+	operand := fmt.Sprintf("%v+%v(%v)", ident.name, offset, reg.name)
+	return asm, strings.Replace(operand, "+-", "-", -1), nil
+}
+
+// ymm returns the name of the ymm register whose low half is the xmm
+// register r.
+func ymm(r *register) string {
+	return "Y" + strings.TrimPrefix(r.name, "X")
+}
+
+// the 256 bit masked loads and stores, the F32x8 and I32x8 values stay in
+// memory, each is moved into a ymm register with VMOVDQU, and VZEROUPPER
+// clears the upper halves of the ymm registers before the following SSE
+// instructions
+
+func maskedLoadF32x8(f *Function, loc ssa.Instruction, p, mask, result *identifier) (string, *Error) {
+	asm, ptr, err := f.LoadIdent(loc, p, 0, sizePtr())
+	if err != nil {
+		return "", err
+	}
+	a, maskmem, err := f.ymmOperand(loc, mask)
+	if err != nil {
+		return "", err
+	}
+	asm += a
+	// the result is written in memory, drop any registers holding it
+	asm += result.spillAllRegisters(loc)
+	_, dstmem, err := f.ymmOperand(loc, result)
+	if err != nil {
+		return "", err
+	}
+	a, regmask := f.allocReg(loc, XMM_REG, XmmRegSize)
+	asm += a
+	a, dst := f.allocReg(loc, XMM_REG, XmmRegSize)
+	asm += a
+	asm += fmt.Sprintf("%-9v    %v, %v\n", VMOVDQU, maskmem, ymm(regmask))
+	asm += fmt.Sprintf("%-9v    (%v), %v, %v\n", VMASKMOVPS, ptr.name, ymm(regmask), ymm(dst))
+	asm += fmt.Sprintf("%-9v    %v, %v\n", VMOVDQU, ymm(dst), dstmem)
+	asm += fmt.Sprintf("%-9v\n", VZEROUPPER)
+	result.storage.(*memory).setInitialized(result.storageRegion())
+	f.freeReg(ptr)
+	f.freeReg(regmask)
+	f.freeReg(dst)
+	return asm, nil
+}
+
+func maskedStoreF32x8(f *Function, loc ssa.Instruction, p, mask, result *identifier) (string, *Error) {
+	// no result, the value to store is the third argument
+	x := f.Ident(loc.(*ssa.Call).Common().Args[2])
+	asm, ptr, err := f.LoadIdent(loc, p, 0, sizePtr())
+	if err != nil {
+		return "", err
+	}
+	a, maskmem, err := f.ymmOperand(loc, mask)
+	if err != nil {
+		return "", err
+	}
+	asm += a
+	a, xmem, err := f.ymmOperand(loc, x)
+	if err != nil {
+		return "", err
+	}
+	asm += a
+	a, regmask := f.allocReg(loc, XMM_REG, XmmRegSize)
+	asm += a
+	a, regx := f.allocReg(loc, XMM_REG, XmmRegSize)
+	asm += a
+	asm += fmt.Sprintf("%-9v    %v, %v\n", VMOVDQU, maskmem, ymm(regmask))
+	asm += fmt.Sprintf("%-9v    %v, %v\n", VMOVDQU, xmem, ymm(regx))
+	asm += fmt.Sprintf("%-9v    %v, %v, (%v)\n", VMASKMOVPS, ymm(regx), ymm(regmask), ptr.name)
+	asm += fmt.Sprintf("%-9v\n", VZEROUPPER)
+	f.freeReg(ptr)
+	f.freeReg(regmask)
+	f.freeReg(regx)
+	return asm, nil
+}
+
+// interleaving of 32 bit elements, the instructions only move bits so the
+// same ones are used for float32s and int32s
+
+func interleaveLoX4(f *Function, loc ssa.Instruction, x, y, result *identifier) (string, *Error) {
+	return binaryPackedOp(f, loc, UNPCKLPS, x, y, result)
+}
+
+func interleaveHiX4(f *Function, loc ssa.Instruction, x, y, result *identifier) (string, *Error) {
+	return binaryPackedOp(f, loc, UNPCKHPS, x, y, result)
+}
+
+func deinterleaveEvenX4(f *Function, loc ssa.Instruction, x, y, result *identifier) (string, *Error) {
+	// elements 0, 2 of x then 0, 2 of y
+	return binaryPackedOpImm8(f, loc, SHUFPS, true, 0x88, x, y, result)
+}
+
+func deinterleaveOddX4(f *Function, loc ssa.Instruction, x, y, result *identifier) (string, *Error) {
+	// elements 1, 3 of x then 1, 3 of y
+	return binaryPackedOpImm8(f, loc, SHUFPS, true, 0xdd, x, y, result)
+}
+
+// splatOp returns the intrinsic copying the float in the low element of
+// x to every element of the result with the shuffle instr and order 0.
+func splatOp(instr Instruction) intrinsic {
+	return func(f *Function, loc ssa.Instruction, x, _, result *identifier) (string, *Error) {
+		ctx := context{f, loc}
+		asm, regx, err := f.LoadSimd(loc, x)
+		if err != nil {
+			return "", err
+		}
+		a, dst := f.allocReg(loc, XMM_REG, XmmRegSize)
+		asm += a
+		asm += MovRegReg(ctx, OpDataType{op: OP_PACKED, xmmvariant: XMM_F128}, regx, dst, false)
+		asm += instrImm8RegReg(ctx, f, instr, 0, dst, dst, false)
+		a, err = f.StoreSimd(loc, dst, result)
+		if err != nil {
+			return "", err
+		}
+		asm += a
+		f.freeReg(regx)
+		f.freeReg(dst)
+		return asm, nil
+	}
+}
+
+func dotF32x4(f *Function, loc ssa.Instruction, x, y, result *identifier) (string, *Error) {
+	// SSE4.1, multiply all four elements and put the sum in the first element
+	return binaryPackedOpImm8(f, loc, DPPS, true, 0xf1, x, y, result)
+}
+
+func sumAbsDiffU8x16(f *Function, loc ssa.Instruction, x, y, result *identifier) (string, *Error) {
+	return binaryPackedOp(f, loc, PSADBW, x, y, result)
+}
+
+func maddI16x8(f *Function, loc ssa.Instruction, x, y, result *identifier) (string, *Error) {
+	return binaryPackedOp(f, loc, PMADDWL, x, y, result)
+}
+
+func dotU8I8x16(f *Function, loc ssa.Instruction, x, y, result *identifier) (string, *Error) {
+	ctx := context{f, loc}
+	packed := OpDataType{op: OP_PACKED, xmmvariant: XMM_F128}
+	asm, regx, err := f.LoadSimd(loc, x)
+	if err != nil {
+		return "", err
+	}
+	a, regy, err := f.LoadSimd(loc, y)
+	if err != nil {
+		return "", err
+	}
+	asm += a
+	a, dst := f.allocReg(loc, XMM_REG, XmmRegSize)
+	asm += a
+	if f.hasTarget(TargetAVX512VNNI) {
+		asm += instrRegReg(ctx, PXOR, dst, dst, false)
+		asm += dst.modified(ctx, false)
+		asm += fmt.Sprintf("%-9v    %v, %v, %v\n", VPDPBUSD, regy.name, regx.name, dst.name)
+	} else {
+		// SSSE3, PMADDUBSW saturates the sums of pairs of products to
+		// int16, so the low seven bits and the high bit of x are multiplied
+		// separately, then PMADDWL by ones sums the pairs into int32s
+		a, hi := f.allocReg(loc, XMM_REG, XmmRegSize)
+		asm += a
+		a, tmp := f.allocReg(loc, XMM_REG, XmmRegSize)
+		asm += a
+		asm += MovDataReg(ctx, f.constTable(splatBytes(0x7f)), tmp)
+		asm += MovRegReg(ctx, packed, regx, dst, false)
+		asm += instrRegReg(ctx, PAND, tmp, dst, false)
+		asm += MovRegReg(ctx, packed, tmp, hi, false)
+		asm += instrRegReg(ctx, PANDN, regx, hi, false)
+		asm += instrRegReg(ctx, PMADDUBSW, regy, dst, false)
+		asm += instrRegReg(ctx, PMADDUBSW, regy, hi, false)
+		asm += MovDataReg(ctx, f.constTable([]byte{1, 0, 1, 0, 1, 0, 1, 0, 1, 0, 1, 0, 1, 0, 1, 0}), tmp)
+		asm += instrRegReg(ctx, PMADDWL, tmp, dst, false)
+		asm += instrRegReg(ctx, PMADDWL, tmp, hi, false)
+		asm += instrRegReg(ctx, PADDL, hi, dst, false)
+		f.freeReg(hi)
+		f.freeReg(tmp)
+	}
+	a, err = f.StoreSimd(loc, dst, result)
+	if err != nil {
+		return "", err
+	}
+	asm += a
+	f.freeReg(regx)
+	f.freeReg(regy)
+	f.freeReg(dst)
+	return asm, nil
+}
+
+// maxMinOp returns the intrinsic for the element wise max or min instr,
+// the float versions return y if x or y is NaN or both are zero, like the
+// Go "if x > y { return x }; return y"
+func maxMinOp(instr Instruction) intrinsic {
+	return func(f *Function, loc ssa.Instruction, x, y, result *identifier) (string, *Error) {
+		return binaryPackedOp(f, loc, instr, x, y, result)
+	}
+}
+
+// byte order loads and stores, the []byte is only checked to be long enough
+// with bounds checks on, big endian values are reversed by MOVBE if the
+// target has it otherwise by BSWAP
+
+// byteOrderLoad returns the intrinsic loading a uint32 or uint64 from the
+// start of a []byte.
+func byteOrderLoad(bigEndian bool) intrinsic {
+	return func(f *Function, loc ssa.Instruction, b, _, result *identifier) (string, *Error) {
+		ctx := context{f, loc}
+		size := result.size()
+		asm := ""
+		if f.opts.BoundsCheck {
+			asm += f.LenCheck(loc, b, uint32(size))
+		}
+		a, ptr, err := f.LoadIdent(loc, b, 0, sizePtr())
+		if err != nil {
+			return "", err
+		}
+		asm += a
+		a, dst := f.allocIdentReg(loc, result, DataRegSize)
+		asm += a
+		asm += MovByteOrderMemReg(ctx, size, bigEndian, f.hasTarget(instrTargets[MOVBELL]), ptr, dst)
+		f.freeReg(ptr)
+		a, err = f.StoreValue(loc, result, dst)
+		f.freeReg(dst)
+		if err != nil {
+			return "", err
+		}
+		return asm + a, nil
+	}
+}
+
+// byteOrderStore returns the intrinsic storing a uint32 or uint64 to the
+// start of a []byte.
+func byteOrderStore(bigEndian bool) intrinsic {
+	return func(f *Function, loc ssa.Instruction, b, v, _ *identifier) (string, *Error) {
+		ctx := context{f, loc}
+		size := v.size()
+		asm := ""
+		if f.opts.BoundsCheck {
+			asm += f.LenCheck(loc, b, uint32(size))
+		}
+		a, ptr, err := f.LoadIdent(loc, b, 0, sizePtr())
+		if err != nil {
+			return "", err
+		}
+		asm += a
+		a, src, err := f.LoadIdent(loc, v, 0, size)
+		if err != nil {
+			return "", err
+		}
+		asm += a
+		a, tmp := f.allocReg(loc, DATA_REG, DataRegSize)
+		asm += a
+		asm += MovByteOrderRegMem(ctx, size, bigEndian, f.hasTarget(instrTargets[MOVBELL]), src, ptr, tmp)
+		f.freeReg(ptr)
+		f.freeReg(src)
+		f.freeReg(tmp)
+		return asm, nil
+	}
+}
+
+// byte comparisons and mask extraction, see simd_movemask.go
+
+// vectorLoad returns the intrinsic loading the 16 bytes of a slice of
+// elements of size scale starting at element i.
+func vectorLoad(scale int) intrinsic {
+	return func(f *Function, loc ssa.Instruction, b, i, result *identifier) (string, *Error) {
+		ctx := context{f, loc}
+		asm, ptr, err := f.LoadIdent(loc, b, 0, sizePtr())
+		if err != nil {
+			return "", err
+		}
+		a, idx, err := f.LoadIdent(loc, i, 0, sizePtr())
+		if err != nil {
+			return "", err
+		}
+		asm += a
+		if f.opts.BoundsCheck {
+			// the last element loaded must be in b
+			a, last := f.allocReg(loc, DATA_REG, DataRegSize)
+			asm += a
+			asm += Lea(ctx, "", 16/scale-1, idx, last, false)
+			asm += f.BoundsCheck(loc, b, last)
+			f.freeReg(last)
+		}
+		a, dst := f.allocReg(loc, XMM_REG, XmmRegSize)
+		asm += a
+		asm += dst.modified(ctx, false)
+		asm += fmt.Sprintf("%-9v    (%v)(%v*%v), %v\n", MOVOU, ptr.name, idx.name, scale, dst.name)
+		f.freeReg(ptr)
+		f.freeReg(idx)
+		a, err = f.StoreSimd(loc, dst, result)
+		f.freeReg(dst)
+		if err != nil {
+			return "", err
+		}
+		return asm + a, nil
+	}
+}
+
+// vectorStore returns the intrinsic storing a vector to the 16 bytes of a
+// slice of elements of size scale starting at element i.
+func vectorStore(scale int) intrinsic {
+	return func(f *Function, loc ssa.Instruction, b, i, _ *identifier) (string, *Error) {
+		ctx := context{f, loc}
+		x := f.Ident(loc.(*ssa.Call).Common().Args[2])
+		asm, ptr, err := f.LoadIdent(loc, b, 0, sizePtr())
+		if err != nil {
+			return "", err
+		}
+		a, idx, err := f.LoadIdent(loc, i, 0, sizePtr())
+		if err != nil {
+			return "", err
+		}
+		asm += a
+		if f.opts.BoundsCheck {
+			// the last element stored must be in b
+			a, last := f.allocReg(loc, DATA_REG, DataRegSize)
+			asm += a
+			asm += Lea(ctx, "", 16/scale-1, idx, last, false)
+			asm += f.BoundsCheck(loc, b, last)
+			f.freeReg(last)
+		}
+		a, src, err := f.LoadSimd(loc, x)
+		if err != nil {
+			return "", err
+		}
+		asm += a
+		asm += fmt.Sprintf("%-9v    %v, (%v)(%v*%v)\n", MOVOU, src.name, ptr.name, idx.name, scale)
+		f.freeReg(ptr)
+		f.freeReg(idx)
+		f.freeReg(src)
+		return asm, nil
+	}
+}
+
+func splatU8x16(f *Function, loc ssa.Instruction, x, _, result *identifier) (string, *Error) {
+	// x*0x01010101 is four copies of x, PSHUFL copies them to all the
+	// dwords
+	ctx := context{f, loc}
+	asm, src, err := f.LoadIdent(loc, x, 0, 1)
+	if err != nil {
+		return "", err
+	}
+	a, tmp := f.allocReg(loc, DATA_REG, DataRegSize)
+	asm += a
+	asm += MovZeroExtend(ctx, src, tmp, 1, 8, false)
+	f.freeReg(src)
+	asm += MulImm32RegReg(ctx, 0x01010101, tmp, tmp, false)
+	a, dst := f.allocReg(loc, XMM_REG, XmmRegSize)
+	asm += a
+	asm += MovRegReg(ctx, OpDataType{OP_XMM, InstrData{}, XMM_F128}, tmp, dst, false)
+	f.freeReg(tmp)
+	asm += instrImm8RegReg(ctx, f, PSHUFL, 0, dst, dst, false)
+	a, err = f.StoreSimd(loc, dst, result)
+	f.freeReg(dst)
+	if err != nil {
+		return "", err
+	}
+	return asm + a, nil
+}
+
+func cmpEqU8x16(f *Function, loc ssa.Instruction, x, y, result *identifier) (string, *Error) {
+	return binaryPackedOp(f, loc, PCMPEQB, x, y, result)
+}
+
+// moveMaskOp returns the intrinsic extracting the high bits of the elements
+// with the movemask instr, PMOVMSKB or MOVMSKPD, both zero extend the mask
+// to the 64 bit register.
+func moveMaskOp(instr Instruction) intrinsic {
+	return func(f *Function, loc ssa.Instruction, x, _, result *identifier) (string, *Error) {
+		ctx := context{f, loc}
+		asm, src, err := f.LoadSimd(loc, x)
+		if err != nil {
+			return "", err
+		}
+		a, dst := f.allocIdentReg(loc, result, DataRegSize)
+		asm += a
+		asm += instrRegReg(ctx, instr, src, dst, false)
+		f.freeReg(src)
+		a, err = f.StoreValue(loc, result, dst)
+		f.freeReg(dst)
+		if err != nil {
+			return "", err
+		}
+		return asm + a, nil
+	}
+}
+
+// column filters, see simd_filter.go
+
+func splatI64x2(f *Function, loc ssa.Instruction, x, _, result *identifier) (string, *Error) {
+	ctx := context{f, loc}
+	asm, src, err := f.LoadIdent(loc, x, 0, 8)
+	if err != nil {
+		return "", err
+	}
+	a, dst := f.allocReg(loc, XMM_REG, XmmRegSize)
+	asm += a
+	asm += MovRegReg(ctx, OpDataType{OP_XMM, InstrData{}, XMM_F128}, src, dst, false)
+	f.freeReg(src)
+	asm += instrRegReg(ctx, PUNPCKLQDQ, dst, dst, false)
+	a, err = f.StoreSimd(loc, dst, result)
+	f.freeReg(dst)
+	if err != nil {
+		return "", err
+	}
+	return asm + a, nil
+}
+
+func splatI32x4(f *Function, loc ssa.Instruction, x, _, result *identifier) (string, *Error) {
+	ctx := context{f, loc}
+	asm, src, err := f.LoadIdent(loc, x, 0, 4)
+	if err != nil {
+		return "", err
+	}
+	a, tmp := f.allocReg(loc, DATA_REG, DataRegSize)
+	asm += a
+	asm += MovZeroExtend(ctx, src, tmp, 4, 8, false)
+	f.freeReg(src)
+	a, dst := f.allocReg(loc, XMM_REG, XmmRegSize)
+	asm += a
+	asm += MovRegReg(ctx, OpDataType{OP_XMM, InstrData{}, XMM_F128}, tmp, dst, false)
+	f.freeReg(tmp)
+	asm += instrImm8RegReg(ctx, f, PSHUFL, 0, dst, dst, false)
+	a, err = f.StoreSimd(loc, dst, result)
+	f.freeReg(dst)
+	if err != nil {
+		return "", err
+	}
+	return asm + a, nil
+}
+
+func cmpEqI64x2(f *Function, loc ssa.Instruction, x, y, result *identifier) (string, *Error) {
+	ctx := context{f, loc}
+	if ctx.hasInstr(PCMPEQQ) {
+		return binaryPackedOp(f, loc, PCMPEQQ, x, y, result)
+	}
+	// SSE2, the elements are equal if both their dwords are, the swapped
+	// dword compares are and'ed
+	asm, regx, err := f.LoadSimd(loc, x)
+	if err != nil {
+		return "", err
+	}
+	a, regy, err := f.LoadSimd(loc, y)
+	if err != nil {
+		return "", err
+	}
+	asm += a
+	a, dst := f.allocReg(loc, XMM_REG, XmmRegSize)
+	asm += a
+	a, tmp := f.allocReg(loc, XMM_REG, XmmRegSize)
+	asm += a
+	asm += MovRegReg(ctx, OpDataType{op: OP_PACKED, xmmvariant: XMM_F128}, regx, dst, false)
+	asm += instrRegReg(ctx, PCMPEQL, regy, dst, false)
+	asm += instrImm8RegReg(ctx, f, PSHUFL, 0xb1, dst, tmp, false)
+	asm += instrRegReg(ctx, PAND, tmp, dst, false)
+	a, err = f.StoreSimd(loc, dst, result)
+	if err != nil {
+		return "", err
+	}
+	asm += a
+	f.freeReg(regx)
+	f.freeReg(regy)
+	f.freeReg(dst)
+	f.freeReg(tmp)
+	return asm, nil
+}
+
+func cmpGtI64x2(f *Function, loc ssa.Instruction, x, y, result *identifier) (string, *Error) {
+	ctx := context{f, loc}
+	if ctx.hasInstr(PCMPGTQ) {
+		return binaryPackedOp(f, loc, PCMPGTQ, x, y, result)
+	}
+	// x > y if y - x is negative, its sign is flipped if the subtraction
+	// overflowed, x and y have different signs and y - x the sign of x,
+	// (x ^ y) & ((y - x) ^ y), then the sign is copied to both dwords
+	packed := OpDataType{op: OP_PACKED, xmmvariant: XMM_F128}
+	asm, regx, err := f.LoadSimd(loc, x)
+	if err != nil {
+		return "", err
+	}
+	a, regy, err := f.LoadSimd(loc, y)
+	if err != nil {
+		return "", err
+	}
+	asm += a
+	a, dst := f.allocReg(loc, XMM_REG, XmmRegSize)
+	asm += a
+	a, tmp1 := f.allocReg(loc, XMM_REG, XmmRegSize)
+	asm += a
+	a, tmp2 := f.allocReg(loc, XMM_REG, XmmRegSize)
+	asm += a
+	asm += MovRegReg(ctx, packed, regy, dst, false)
+	asm += instrRegReg(ctx, PSUBQ, regx, dst, false)
+	asm += MovRegReg(ctx, packed, regx, tmp1, false)
+	asm += instrRegReg(ctx, PXOR, regy, tmp1, false)
+	asm += MovRegReg(ctx, packed, dst, tmp2, false)
+	asm += instrRegReg(ctx, PXOR, regy, tmp2, false)
+	asm += instrRegReg(ctx, PAND, tmp2, tmp1, false)
+	asm += instrRegReg(ctx, PXOR, tmp1, dst, false)
+	asm += instrImm8Reg(ctx, f, PSRAL, 31, dst, false)
+	asm += instrImm8RegReg(ctx, f, PSHUFL, 0xf5, dst, dst, false)
+	a, err = f.StoreSimd(loc, dst, result)
+	if err != nil {
+		return "", err
+	}
+	asm += a
+	f.freeReg(regx)
+	f.freeReg(regy)
+	f.freeReg(dst)
+	f.freeReg(tmp1)
+	f.freeReg(tmp2)
+	return asm, nil
+}
+
+// compressTable returns the PSHUFB shuffles packing the elements of an I32x4
+// selected by each 4 bit mask into the low elements, the rest are zeroed.
+func compressTable() []byte {
+	table := make([]byte, 16*16)
+	for mask := 0; mask < 16; mask++ {
+		shuf := table[16*mask : 16*mask+16]
+		for i := range shuf {
+			shuf[i] = 0x80
+		}
+		n := 0
+		for i := 0; i < 4; i++ {
+			if mask&(1<<uint(i)) == 0 {
+				continue
+			}
+			for j := 0; j < 4; j++ {
+				shuf[4*n+j] = byte(4*i + j)
+			}
+			n++
+		}
+	}
+	return table
+}
+
+func compressI32x4(f *Function, loc ssa.Instruction, x, mask, result *identifier) (string, *Error) {
+	ctx := context{f, loc}
+	asm, regx, err := f.LoadSimd(loc, x)
+	if err != nil {
+		return "", err
+	}
+	a, regmask, err := f.LoadIdent(loc, mask, 0, 8)
+	if err != nil {
+		return "", err
+	}
+	asm += a
+	a, dst := f.allocReg(loc, XMM_REG, XmmRegSize)
+	asm += a
+	if f.hasTarget(instrTargets[VPCOMPRESSD]) {
+		// K1 isn't allocated, the Go ABI doesn't use the mask registers
+		asm += fmt.Sprintf("%-9v    %v, K1\n", KMOVW, regmask.name)
+		asm += dst.modified(ctx, false)
+		asm += fmt.Sprintf("%-9v    %v, K1, %v\n", VPCOMPRESSD.String()+".Z", regx.name, dst.name)
+	} else {
+		// the shuffle for the mask is the 16 bytes at mask*16 in the table
+		q := GetIntegerOpDataType(false, 8)
+		a, idx := f.allocReg(loc, DATA_REG, DataRegSize)
+		asm += a
+		a, base := f.allocReg(loc, DATA_REG, DataRegSize)
+		asm += a
+		a, shuf := f.allocReg(loc, XMM_REG, XmmRegSize)
+		asm += a
+		asm += MovRegReg(ctx, q, regmask, idx, false)
+		asm += instrImmReg(ctx, ANDQ, 15, 8, idx, false)
+		asm += ShiftImm8Reg(ctx, false, SHIFT_LEFT, 4, idx)
+		asm += base.modified(ctx, false)
+		asm += fmt.Sprintf("%-9v    %v, %v\n", LEAQ, DataRef(f.constTable(compressTable())), base.name)
+		asm += shuf.modified(ctx, false)
+		asm += fmt.Sprintf("%-9v    (%v)(%v*1), %v\n", MOVOU, base.name, idx.name, shuf.name)
+		asm += MovRegReg(ctx, OpDataType{op: OP_PACKED, xmmvariant: XMM_F128}, regx, dst, false)
+		asm += instrRegReg(ctx, PSHUFB, shuf, dst, false)
+		f.freeReg(idx)
+		f.freeReg(base)
+		f.freeReg(shuf)
+	}
+	a, err = f.StoreSimd(loc, dst, result)
+	if err != nil {
+		return "", err
+	}
+	asm += a
+	f.freeReg(regx)
+	f.freeReg(regmask)
+	f.freeReg(dst)
+	return asm, nil
+}
+
+// prefix sums and delta decoding, see simd_delta.go
+
+// prefixSumOp returns the intrinsic of the inclusive prefix sums of the
+// elements of size 4 or 8 bytes, plus the last element of the previous
+// vector if delta is true. The vector is added to itself shifted by 1, 2, ...
+// elements with PSLLDQ, log2 of the number of elements steps.
+func prefixSumOp(size int, delta bool) intrinsic {
+	return func(f *Function, loc ssa.Instruction, x, prev, result *identifier) (string, *Error) {
+		ctx := context{f, loc}
+		packed := OpDataType{op: OP_PACKED, xmmvariant: XMM_F128}
+		add, last := PADDL, uint8(0xff)
+		if size == 8 {
+			add, last = PADDQ, 0xee
+		}
+		asm, regx, err := f.LoadSimd(loc, x)
+		if err != nil {
+			return "", err
+		}
+		a, dst := f.allocReg(loc, XMM_REG, XmmRegSize)
+		asm += a
+		a, tmp := f.allocReg(loc, XMM_REG, XmmRegSize)
+		asm += a
+		asm += MovRegReg(ctx, packed, regx, dst, false)
+		f.freeReg(regx)
+		for shift := size; shift < 16; shift *= 2 {
+			asm += MovRegReg(ctx, packed, dst, tmp, false)
+			asm += instrImm8Reg(ctx, f, PSLLO, uint8(shift), tmp, false)
+			asm += instrRegReg(ctx, add, tmp, dst, false)
+		}
+		if delta {
+			a, regprev, err := f.LoadSimd(loc, prev)
+			if err != nil {
+				return "", err
+			}
+			asm += a
+			asm += instrImm8RegReg(ctx, f, PSHUFL, last, regprev, tmp, false)
+			asm += instrRegReg(ctx, add, tmp, dst, false)
+			f.freeReg(regprev)
+		}
+		f.freeReg(tmp)
+		a, err = f.StoreSimd(loc, dst, result)
+		f.freeReg(dst)
+		if err != nil {
+			return "", err
+		}
+		return asm + a, nil
+	}
+}
+
+// bit manipulation
+
+// xmmConst loads the 128 bit constant {lo, hi} into an xmm register
+func xmmConst(f *Function, loc ssa.Instruction, lo, hi uint64) (string, *register) {
+	ctx := context{f, loc}
+	asm, tmp := f.allocReg(loc, DATA_REG, DataRegSize)
+	a, dst := f.allocReg(loc, XMM_REG, XmmRegSize)
+	asm += a
+	a, dsthi := f.allocReg(loc, XMM_REG, XmmRegSize)
+	asm += a
+	movq := OpDataType{OP_XMM, InstrData{}, XMM_F128}
+	asm += MovImmReg(ctx, int64(lo), 8, tmp, false)
+	asm += MovRegReg(ctx, movq, tmp, dst, false)
+	asm += MovImmReg(ctx, int64(hi), 8, tmp, false)
+	asm += MovRegReg(ctx, movq, tmp, dsthi, false)
+	asm += instrRegReg(ctx, PUNPCKLQDQ, dsthi, dst, false)
+	f.freeReg(tmp)
+	f.freeReg(dsthi)
+	return asm, dst
+}
+
+func popCountU8x16(f *Function, loc ssa.Instruction, x, y, result *identifier) (string, *Error) {
+	// SSSE3, the count of each nibble is looked up with PSHUFB
+	ctx := context{f, loc}
+	packed := OpDataType{op: OP_PACKED, xmmvariant: XMM_F128}
+	asm, src, err := f.LoadSimd(loc, x)
+	if err != nil {
+		return "", err
+	}
+	a, lut := xmmConst(f, loc, 0x0302020102010100, 0x0403030203020201)
+	asm += a
+	a, mask := xmmConst(f, loc, 0x0f0f0f0f0f0f0f0f, 0x0f0f0f0f0f0f0f0f)
+	asm += a
+	a, lo := f.allocReg(loc, XMM_REG, XmmRegSize)
+	asm += a
+	a, hi := f.allocReg(loc, XMM_REG, XmmRegSize)
+	asm += a
+	asm += MovRegReg(ctx, packed, src, lo, false)
+	asm += instrRegReg(ctx, PAND, mask, lo, false)
+	asm += MovRegReg(ctx, packed, src, hi, false)
+	asm += instrImm8Reg(ctx, f, PSRLW, 4, hi, false)
+	asm += instrRegReg(ctx, PAND, mask, hi, false)
+	asm += MovRegReg(ctx, packed, lut, mask, false)
+	asm += instrRegReg(ctx, PSHUFB, lo, lut, false)
+	asm += instrRegReg(ctx, PSHUFB, hi, mask, false)
+	asm += instrRegReg(ctx, PADDB, mask, lut, false)
+	a, err = f.StoreSimd(loc, lut, result)
+	if err != nil {
+		return "", err
+	}
+	asm += a
+	f.freeReg(src)
+	f.freeReg(lut)
+	f.freeReg(mask)
+	f.freeReg(lo)
+	f.freeReg(hi)
+	return asm, nil
+}
+
+func shuffleVarU8x16(f *Function, loc ssa.Instruction, x, indices, result *identifier) (string, *Error) {
+	// SSSE3, PSHUFB shuffles its destination operand, x
+	return binaryPackedOp(f, loc, PSHUFB, x, indices, result)
+}
+
+func mulGF8U8x16(f *Function, loc ssa.Instruction, x, c, result *identifier) (string, *Error) {
+	if c.cnst == nil {
+		return ErrorMsg("MulGF8U8x16 the multiplier operand must be a constant, use MulGF8TableU8x16")
+	}
+	lo, hi := simd.GF8MulTables(uint8(c.cnst.Uint64()))
+	ctx := context{f, loc}
+	asm, reglo := f.allocReg(loc, XMM_REG, XmmRegSize)
+	asm += MovDataReg(ctx, f.constTable(lo[:]), reglo)
+	a, reghi := f.allocReg(loc, XMM_REG, XmmRegSize)
+	asm += a
+	asm += MovDataReg(ctx, f.constTable(hi[:]), reghi)
+	a, err := gf8Mul(f, loc, x, reglo, reghi, result)
+	asm += a
+	f.freeReg(reglo)
+	f.freeReg(reghi)
+	return asm, err
+}
+
+func mulGF8TableU8x16(f *Function, loc ssa.Instruction, x, lo, result *identifier) (string, *Error) {
+	// the tables are copied, the lookups overwrite them
+	hi := f.Ident(loc.(*ssa.Call).Common().Args[2])
+	ctx := context{f, loc}
+	packed := OpDataType{op: OP_PACKED, xmmvariant: XMM_F128}
+	asm := ""
+	var tables [2]*register
+	for i, table := range []*identifier{lo, hi} {
+		a, reg, err := f.LoadSimd(loc, table)
+		if err != nil {
+			return "", err
+		}
+		asm += a
+		a, tables[i] = f.allocReg(loc, XMM_REG, XmmRegSize)
+		asm += a
+		asm += MovRegReg(ctx, packed, reg, tables[i], false)
+		f.freeReg(reg)
+	}
+	a, err := gf8Mul(f, loc, x, tables[0], tables[1], result)
+	asm += a
+	f.freeReg(tables[0])
+	f.freeReg(tables[1])
+	return asm, err
+}
+
+// gf8Mul stores to result the GF(2^8) product of x looked up in the tables
+// lo and hi of the products of the low and high nibbles, lo and hi are
+// overwritten.
+func gf8Mul(f *Function, loc ssa.Instruction, x *identifier, lo, hi *register, result *identifier) (string, *Error) {
+	ctx := context{f, loc}
+	packed := OpDataType{op: OP_PACKED, xmmvariant: XMM_F128}
+	asm, src, err := f.LoadSimd(loc, x)
+	if err != nil {
+		return "", err
+	}
+	nibble := [16]byte{}
+	for i := range nibble {
+		nibble[i] = 0x0f
+	}
+	a, mask := f.allocReg(loc, XMM_REG, XmmRegSize)
+	asm += a
+	asm += MovDataReg(ctx, f.constTable(nibble[:]), mask)
+	a, shr := f.allocReg(loc, XMM_REG, XmmRegSize)
+	asm += a
+	asm += MovRegReg(ctx, packed, src, shr, false)
+	asm += instrImm8Reg(ctx, f, PSRLW, 4, shr, false)
+	asm += instrRegReg(ctx, PAND, mask, shr, false)
+	asm += instrRegReg(ctx, PAND, src, mask, false)
+	asm += instrRegReg(ctx, PSHUFB, mask, lo, false)
+	asm += instrRegReg(ctx, PSHUFB, shr, hi, false)
+	asm += instrRegReg(ctx, PXOR, hi, lo, false)
+	a, err = f.StoreSimd(loc, lo, result)
+	if err != nil {
+		return "", err
+	}
+	asm += a
+	f.freeReg(src)
+	f.freeReg(mask)
+	f.freeReg(shr)
+	return asm, nil
+}
+
+func andNot(f *Function, loc ssa.Instruction, x, y, result *identifier) (string, *Error) {
+	// PANDN complements its destination operand, so y is the destination
+	return binaryPackedOp(f, loc, PANDN, y, x, result)
+}
+
+func andOp(f *Function, loc ssa.Instruction, x, y, result *identifier) (string, *Error) {
+	return binaryPackedOp(f, loc, PAND, x, y, result)
+}
+
+func orOp(f *Function, loc ssa.Instruction, x, y, result *identifier) (string, *Error) {
+	return binaryPackedOp(f, loc, POR, x, y, result)
+}
+
+func xorOp(f *Function, loc ssa.Instruction, x, y, result *identifier) (string, *Error) {
+	return binaryPackedOp(f, loc, PXOR, x, y, result)
+}
+
+// byte classification, table lookups, and nibble packing, see simd_codec.go
+
+// splatBytes returns 16 copies of b, for constant tables
+func splatBytes(b uint8) []byte {
+	bytes := make([]byte, 16)
+	for i := range bytes {
+		bytes[i] = b
+	}
+	return bytes
+}
+
+func lookupU8x16(f *Function, loc ssa.Instruction, x, table, result *identifier) (string, *Error) {
+	// SSSE3, PSHUFB shuffles the constant table by the elements of x
+	if table.cnst == nil || table.cnst.Value == nil || table.cnst.Value.Kind() != exact.String ||
+		len(exact.StringVal(table.cnst.Value)) != 16 {
+		return ErrorMsg("LookupU8x16 the table operand must be a constant string of 16 bytes")
+	}
+	ctx := context{f, loc}
+	asm, src, err := f.LoadSimd(loc, x)
+	if err != nil {
+		return "", err
+	}
+	a, dst := f.allocReg(loc, XMM_REG, XmmRegSize)
+	asm += a
+	asm += MovDataReg(ctx, f.constTable([]byte(exact.StringVal(table.cnst.Value))), dst)
+	asm += instrRegReg(ctx, PSHUFB, src, dst, false)
+	a, err = f.StoreSimd(loc, dst, result)
+	if err != nil {
+		return "", err
+	}
+	asm += a
+	f.freeReg(src)
+	f.freeReg(dst)
+	return asm, nil
+}
+
+func inRangeU8x16(f *Function, loc ssa.Instruction, x, lo, result *identifier) (string, *Error) {
+	// x-lo wraps around below lo, so x is in range if min(x-lo, hi-lo) is
+	// x-lo
+	hi := f.Ident(loc.(*ssa.Call).Common().Args[2])
+	if lo.cnst == nil || hi.cnst == nil {
+		return ErrorMsg("InRangeU8x16 the lo and hi operands must be constants")
+	}
+	ctx := context{f, loc}
+	l, h := uint8(lo.cnst.Uint64()), uint8(hi.cnst.Uint64())
+	asm, src, err := f.LoadSimd(loc, x)
+	if err != nil {
+		return "", err
+	}
+	a, dst := f.allocReg(loc, XMM_REG, XmmRegSize)
+	asm += a
+	if l > h {
+		asm += instrRegReg(ctx, PXOR, dst, dst, false)
+	} else {
+		a, tmp := f.allocReg(loc, XMM_REG, XmmRegSize)
+		asm += a
+		asm += MovRegReg(ctx, OpDataType{op: OP_PACKED, xmmvariant: XMM_F128}, src, dst, false)
+		if l != 0 {
+			asm += MovDataReg(ctx, f.constTable(splatBytes(l)), tmp)
+			asm += instrRegReg(ctx, PSUBB, tmp, dst, false)
+		}
+		asm += MovDataReg(ctx, f.constTable(splatBytes(h-l)), tmp)
+		asm += instrRegReg(ctx, PMINUB, dst, tmp, false)
+		asm += instrRegReg(ctx, PCMPEQB, tmp, dst, false)
+		f.freeReg(tmp)
+	}
+	a, err = f.StoreSimd(loc, dst, result)
+	if err != nil {
+		return "", err
+	}
+	asm += a
+	f.freeReg(src)
+	f.freeReg(dst)
+	return asm, nil
+}
+
+func shrU8x16(f *Function, loc ssa.Instruction, x, shift, result *identifier) (string, *Error) {
+	// SSE2 has no byte shifts, the words are shifted and the bits shifted
+	// in from the next byte are cleared
+	if shift.cnst == nil {
+		return ErrorMsg("ShrU8x16 the shift operand must be a constant")
+	}
+	ctx := context{f, loc}
+	s := shift.cnst.Uint64()
+	asm, src, err := f.LoadSimd(loc, x)
+	if err != nil {
+		return "", err
+	}
+	a, dst := f.allocReg(loc, XMM_REG, XmmRegSize)
+	asm += a
+	if s >= 8 {
+		asm += instrRegReg(ctx, PXOR, dst, dst, false)
+	} else {
+		a, mask := f.allocReg(loc, XMM_REG, XmmRegSize)
+		asm += a
+		asm += MovDataReg(ctx, f.constTable(splatBytes(0xff>>s)), mask)
+		asm += MovRegReg(ctx, OpDataType{op: OP_PACKED, xmmvariant: XMM_F128}, src, dst, false)
+		asm += instrImm8Reg(ctx, f, PSRLW, uint8(s), dst, false)
+		asm += instrRegReg(ctx, PAND, mask, dst, false)
+		f.freeReg(mask)
+	}
+	a, err = f.StoreSimd(loc, dst, result)
+	if err != nil {
+		return "", err
+	}
+	asm += a
+	f.freeReg(src)
+	f.freeReg(dst)
+	return asm, nil
+}
+
+func interleaveLoU8x16(f *Function, loc ssa.Instruction, x, y, result *identifier) (string, *Error) {
+	return binaryPackedOp(f, loc, PUNPCKLBW, x, y, result)
+}
+
+func interleaveHiU8x16(f *Function, loc ssa.Instruction, x, y, result *identifier) (string, *Error) {
+	return binaryPackedOp(f, loc, PUNPCKHBW, x, y, result)
+}
+
+func packNibblesU8x16(f *Function, loc ssa.Instruction, x, y, result *identifier) (string, *Error) {
+	// each word of nibbles hi | lo<<8 is combined into hi<<4 | lo, then
+	// the words of x and y are packed into bytes with PACKUSWB
+	ctx := context{f, loc}
+	packed := OpDataType{op: OP_PACKED, xmmvariant: XMM_F128}
+	asm, nibble := f.allocReg(loc, XMM_REG, XmmRegSize)
+	asm += MovDataReg(ctx, f.constTable(splatBytes(0x0f)), nibble)
+	a, word := f.allocReg(loc, XMM_REG, XmmRegSize)
+	asm += a
+	asm += MovDataReg(ctx, f.constTable([]byte{
+		0xff, 0, 0xff, 0, 0xff, 0, 0xff, 0, 0xff, 0, 0xff, 0, 0xff, 0, 0xff, 0}), word)
+	a, tmp := f.allocReg(loc, XMM_REG, XmmRegSize)
+	asm += a
+	var regs [2]*register
+	for i, v := range []*identifier{x, y} {
+		a, src, err := f.LoadSimd(loc, v)
+		if err != nil {
+			return "", err
+		}
+		asm += a
+		a, regs[i] = f.allocReg(loc, XMM_REG, XmmRegSize)
+		asm += a
+		dst := regs[i]
+		asm += MovRegReg(ctx, packed, src, dst, false)
+		f.freeReg(src)
+		asm += instrRegReg(ctx, PAND, nibble, dst, false)
+		asm += MovRegReg(ctx, packed, dst, tmp, false)
+		asm += instrImm8Reg(ctx, f, PSLLW, 4, dst, false)
+		asm += instrImm8Reg(ctx, f, PSRLW, 8, tmp, false)
+		asm += instrRegReg(ctx, POR, tmp, dst, false)
+		asm += instrRegReg(ctx, PAND, word, dst, false)
+	}
+	asm += instrRegReg(ctx, PACKUSWB, regs[1], regs[0], false)
+	a, err := f.StoreSimd(loc, regs[0], result)
+	if err != nil {
+		return "", err
+	}
+	asm += a
+	f.freeReg(nibble)
+	f.freeReg(word)
+	f.freeReg(tmp)
+	f.freeReg(regs[0])
+	f.freeReg(regs[1])
+	return asm, nil
+}
+
+func shlVarX4(f *Function, loc ssa.Instruction, x, counts, result *identifier) (string, *Error) {
+	return avx2ShiftVar(f, loc, VPSLLVD, x, counts, result)
+}
+
+func shrVarU32x4(f *Function, loc ssa.Instruction, x, counts, result *identifier) (string, *Error) {
+	return avx2ShiftVar(f, loc, VPSRLVD, x, counts, result)
+}
+
+func shrVarI32x4(f *Function, loc ssa.Instruction, x, counts, result *identifier) (string, *Error) {
+	return avx2ShiftVar(f, loc, VPSRAVD, x, counts, result)
+}
+
+// avx2ShiftVar shifts each element of x by the corresponding element of counts
+func avx2ShiftVar(f *Function, loc ssa.Instruction, instr Instruction, x, counts, result *identifier) (string, *Error) {
+	ctx := context{f, loc}
+	asm, regx, err := f.LoadSimd(loc, x)
+	if err != nil {
+		return "", err
+	}
+	a, regcounts, err := f.LoadSimd(loc, counts)
+	if err != nil {
+		return "", err
+	}
+	asm += a
+	a, dst := f.allocReg(loc, XMM_REG, XmmRegSize)
+	asm += a
+	asm += dst.modified(ctx, false)
+	asm += fmt.Sprintf("%-9v    %v, %v, %v\n", instr, regcounts.name, regx.name, dst.name)
+	a, err = f.StoreSimd(loc, dst, result)
+	if err != nil {
+		return "", err
+	}
+	asm += a
+	f.freeReg(regx)
+	f.freeReg(regcounts)
+	f.freeReg(dst)
+	return asm, nil
+}
+
+// hardware random numbers, see simd_rand.go
+
+// randOp is RdRand64 or RdSeed64, instr is retried up to 10 times while it
+// clears CF, the random number or 0 is stored to *v and CF to the result.
+// DEC doesn't change CF.
+func randOp(instr Instruction) intrinsic {
+	return func(f *Function, loc ssa.Instruction, v, _, result *identifier) (string, *Error) {
+		ctx := context{f, loc}
+		asm, ptr, err := f.LoadIdent(loc, v, 0, sizePtr())
+		if err != nil {
+			return "", err
+		}
+		a, tmp := f.allocReg(loc, DATA_REG, DataRegSize)
+		asm += a
+		a, count := f.allocReg(loc, DATA_REG, DataRegSize)
+		asm += a
+		a, dst := f.allocIdentReg(loc, result, DataRegSize)
+		asm += a
+		retry := ctx.newLabel("retry")
+		done := ctx.newLabel("randdone")
+		// SETCS only writes the low byte
+		asm += ZeroReg(ctx, dst)
+		asm += MovImmReg(ctx, 10, 8, count, false)
+		asm += retry + ":\n"
+		asm += instrReg(ctx, instr, tmp, false)
+		asm += fmt.Sprintf("%-9v    %v\n", JCS, done)
+		if instr == RDSEEDQ {
+			// the entropy source refills slowly
+			asm += fmt.Sprintf("%v\n", PAUSE)
+		}
+		asm += instrReg(ctx, DECQ, count, false)
+		asm += fmt.Sprintf("%-9v    %v\n", JNE, retry)
+		asm += done + ":\n"
+		asm += instrReg(ctx, SETCS, dst, false)
+		asm += MovRegMem(ctx, GetIntegerOpDataType(false, 8), tmp, "", ptr, 0)
+		f.freeReg(ptr)
+		f.freeReg(tmp)
+		f.freeReg(count)
+		a, err = f.StoreValue(loc, result, dst)
+		f.freeReg(dst)
+		if err != nil {
+			return "", err
+		}
+		return asm + a, nil
+	}
+}
+
+// BMI2 bit manipulation, see simd_bits.go
+
+// bitsOp is Pext64 or Pdep64, translated to PEXT or PDEP of x and mask.
+func bitsOp(instr Instruction) intrinsic {
+	return func(f *Function, loc ssa.Instruction, x, mask, result *identifier) (string, *Error) {
+		ctx := context{f, loc}
+		asm, src, err := f.LoadIdent(loc, x, 0, 8)
+		if err != nil {
+			return "", err
+		}
+		a, regmask, err := f.LoadIdent(loc, mask, 0, 8)
+		if err != nil {
+			return "", err
+		}
+		asm += a
+		a, dst := f.allocIdentReg(loc, result, DataRegSize)
+		asm += a
+		asm += dst.modified(ctx, false)
+		asm += fmt.Sprintf("%-9v    %v, %v, %v\n", instr, regmask.name, src.name, dst.name)
+		f.freeReg(src)
+		f.freeReg(regmask)
+		a, err = f.StoreValue(loc, result, dst)
+		f.freeReg(dst)
+		if err != nil {
+			return "", err
+		}
+		return asm + a, nil
+	}
+}
+
+// mulHi64 is MulHi64, MULX if the target has BMI2 otherwise MUL, both
+// multiply by DX and MUL also sets AX.
+func mulHi64(f *Function, loc ssa.Instruction, x, y, result *identifier) (string, *Error) {
+	ctx := context{f, loc}
+	asm, regx, err := f.LoadIdent(loc, x, 0, 8)
+	if err != nil {
+		return "", err
+	}
+	a, regy, err := f.LoadIdent(loc, y, 0, 8)
+	if err != nil {
+		return "", err
+	}
+	asm += a
+	a, dst := f.allocIdentReg(loc, result, DataRegSize)
+	asm += a
+	q := GetIntegerOpDataType(false, 8)
+	if f.hasTarget(instrTargets[MULXQ]) {
+		rdx := getRegister(REG_DX)
+		a, lo := f.allocReg(loc, DATA_REG, DataRegSize)
+		asm += a
+		asm += MovRegReg(ctx, q, regx, rdx, false)
+		asm += lo.modified(ctx, false)
+		asm += dst.modified(ctx, false)
+		asm += fmt.Sprintf("%-9v    %v, %v, %v\n", MULXQ, regy.name, lo.name, dst.name)
+		f.freeReg(lo)
+	} else {
+		rax, rdx := getRegister(REG_AX), getRegister(REG_DX)
+		asm += MovRegReg(ctx, q, regx, rax, false)
+		asm += fmt.Sprintf("%-9v    %v\n", MULQ, regy.name)
+		asm += MovRegReg(ctx, q, rdx, dst, false)
+	}
+	f.freeReg(regx)
+	f.freeReg(regy)
+	a, err = f.StoreValue(loc, result, dst)
+	f.freeReg(dst)
+	if err != nil {
+		return "", err
+	}
+	return asm + a, nil
+}
@@ -116,6 +116,29 @@ func simdInfo(t types.Type) (simdtype, bool) {
 			return simdType, true
 		}
 	}
+	// the SSE2 types M128, M128i and M128d are arrays too
+	if isSSE2(named) {
+		return simdtype{}, false
+	}
+	return simdArrayInfo(named)
+}
+
+// simdArrayInfo maps named array types the size of an xmm register,
+// e.g. "type Vec [4]float32", to the SIMD type with the same elements.
+func simdArrayInfo(named *types.Named) (simdtype, bool) {
+	array, ok := named.Underlying().(*types.Array)
+	if !ok {
+		return simdtype{}, false
+	}
+	elem, ok := array.Elem().Underlying().(*types.Basic)
+	if !ok || elem.Info()&types.IsNumeric == 0 || elem.Info()&types.IsComplex != 0 || elem.Kind() == types.Uintptr {
+		return simdtype{}, false
+	}
+	for _, simdType := range simdTypes() {
+		if int64(simdType.t.Len()) == array.Len() && simdType.t.Elem() == reflectBasic(elem.Kind()) {
+			return simdType, true
+		}
+	}
 	return simdtype{}, false
 }
 
@@ -182,6 +205,11 @@ func sizeofElem(t types.Type) uint {
 	return sizeof(e)
 }
 
+// gcSizes lays out types like the gc compiler on amd64, with padding
+// between struct fields and after the last one, and a trailing zero size
+// field padded so its address is inside the struct.
+var gcSizes = types.SizesFor("gc", DefaultArch)
+
 func sizeof(t types.Type) uint {
 
 	switch t := t.(type) {
@@ -197,24 +225,41 @@ func sizeof(t types.Type) uint {
 		return sizeSlice(t)
 	case *types.Array:
 		return sizeArray(t)
+	case *types.Struct:
+		return uint(gcSizes.Sizeof(t))
 	case *types.Named:
 		if sse2, ok := sse2Info(t); ok {
 			return sse2.size
 		} else if info, ok := simdInfo(t); ok {
 			return info.size
-		} else {
-			panic(ice(fmt.Sprintf("unknown named type \"%v\"", t.String())))
 		}
+		switch t.Underlying().(type) {
+		case *types.Basic, *types.Array, *types.Struct, *types.Pointer, *types.Slice:
+			// e.g. "type Sample int16" or "type Point struct{ X, Y float32 }"
+			return sizeof(t.Underlying())
+		}
+		panic(ice(fmt.Sprintf("unknown named type \"%v\"", t.String())))
 	}
 	panic(ice(fmt.Sprintf("unknown type: %v", t)))
 }
 
+// sizeArray returns the size of t, its length times the size of its
+// elements, which are padded to their alignment.
 func sizeArray(t *types.Array) uint {
-	return uint(reflectType(t).Size())
+	return uint(gcSizes.Sizeof(t))
 }
 
 func sizeSlice(t *types.Slice) uint {
-	return uint(reflectType(t).Size())
+	return uint(gcSizes.Sizeof(t))
+}
+
+// fieldOffset returns the offset in bytes of field i of the struct t.
+func fieldOffset(t *types.Struct, i int) uint {
+	fields := make([]*types.Var, t.NumFields())
+	for j := range fields {
+		fields[j] = t.Field(j)
+	}
+	return uint(gcSizes.Offsetsof(fields)[i])
 }
 
 func sizeInt() uint {
@@ -238,16 +283,19 @@ func align(t types.Type) uint {
 	switch t := t.(type) {
 	case *types.Tuple:
 		return alignTuple(t)
-	case *types.Array, *types.Basic, *types.Pointer, *types.Slice:
-		return uint(reflectType(t).Align())
+	case *types.Array, *types.Basic, *types.Pointer, *types.Slice, *types.Struct:
+		return uint(gcSizes.Alignof(t))
 	case *types.Named:
 		if sse2, ok := sse2Info(t); ok {
 			return sse2.align
 		} else if info, ok := simdInfo(t); ok {
 			return info.align
-		} else {
-			panic(ice(fmt.Sprintf("unknown named type \"%v\"", t.String())))
 		}
+		switch t.Underlying().(type) {
+		case *types.Basic, *types.Array, *types.Struct, *types.Pointer, *types.Slice:
+			return align(t.Underlying())
+		}
+		panic(ice(fmt.Sprintf("unknown named type \"%v\"", t.String())))
 	}
 	panic(ice(fmt.Sprintf("unknown type (%v)", t)))
 }
@@ -260,7 +308,7 @@ func alignTuple(tup *types.Tuple) uint {
 
 func signed(t types.Type) bool {
 
-	switch t := t.(type) {
+	switch t := t.Underlying().(type) {
 	case *types.Basic:
 		return signedBasic(t.Kind())
 	}
@@ -282,7 +330,7 @@ func signedBasic(b types.BasicKind) bool {
 }
 
 func isUint(t types.Type) bool {
-	if t, ok := t.(*types.Basic); ok {
+	if t, ok := t.Underlying().(*types.Basic); ok {
 		switch t.Kind() {
 		case types.Uint, types.Uint8, types.Uint16, types.Uint32, types.Uint64:
 			return true
@@ -291,7 +339,7 @@ func isUint(t types.Type) bool {
 	return false
 }
 func isInt(t types.Type) bool {
-	if t, ok := t.(*types.Basic); ok {
+	if t, ok := t.Underlying().(*types.Basic); ok {
 		switch t.Kind() {
 		case types.Int, types.Int8, types.Int16, types.Int32, types.Int64:
 			return true
@@ -323,14 +371,14 @@ func isComplex(t types.Type) bool {
 }
 
 func isBasicKind(t types.Type, basickind types.BasicKind) bool {
-	if t, ok := t.(*types.Basic); ok {
+	if t, ok := t.Underlying().(*types.Basic); ok {
 		return t.Kind() == basickind
 	}
 	return false
 }
 
 func isBasic(t types.Type) bool {
-	_, ok := t.(*types.Basic)
+	_, ok := t.Underlying().(*types.Basic)
 	return ok
 }
 
@@ -380,6 +428,9 @@ func reflectType(t types.Type) reflect.Type {
 		if sse2, ok := sse2Info(t); ok {
 			return sse2.t
 		}
+		if isBasic(t) {
+			return reflectType(t.Underlying())
+		}
 	}
 	ice(fmt.Sprintf("error unknown type:\"%v\"", t))
 	panic("")
@@ -0,0 +1,103 @@
+package codegen
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"reflect"
+	"testing"
+)
+
+const layoutSrc = `package src
+
+type Pair struct {
+	A int8
+	B int64
+}
+
+type Padded struct {
+	A int8
+	B int32
+	C int8
+}
+
+type Nested struct {
+	P  [3]Padded
+	F  float32
+	S  []int16
+	Pt *Pair
+}
+
+type Empty struct{}
+
+type TrailingEmpty struct {
+	X int32
+	E Empty
+}
+
+type Bools [5]bool
+type Pairs [3]Pair
+`
+
+type Pair struct {
+	A int8
+	B int64
+}
+
+type Padded struct {
+	A int8
+	B int32
+	C int8
+}
+
+type Nested struct {
+	P  [3]Padded
+	F  float32
+	S  []int16
+	Pt *Pair
+}
+
+type Empty struct{}
+
+type TrailingEmpty struct {
+	X int32
+	E Empty
+}
+
+type Bools [5]bool
+type Pairs [3]Pair
+
+// TestSizeof checks the sizes, alignments, and field offsets of types
+// against reflect on the host, which lays them out like the gc compiler.
+func TestSizeof(t *testing.T) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "src.go", layoutSrc, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pkg, err := (&types.Config{}).Check("src", fset, []*ast.File{file}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cases := []interface{}{Pair{}, Padded{}, Nested{}, Empty{}, TrailingEmpty{}, Bools{}, Pairs{}}
+	for _, c := range cases {
+		rt := reflect.TypeOf(c)
+		typ := pkg.Scope().Lookup(rt.Name()).Type()
+		if size := sizeof(typ); size != uint(rt.Size()) {
+			t.Errorf("sizeof(%v) = %v, expected %v", rt.Name(), size, rt.Size())
+		}
+		if a := align(typ); a != uint(rt.Align()) {
+			t.Errorf("align(%v) = %v, expected %v", rt.Name(), a, rt.Align())
+		}
+		st, ok := typ.Underlying().(*types.Struct)
+		if !ok {
+			continue
+		}
+		for i := 0; i < st.NumFields(); i++ {
+			if off := fieldOffset(st, i); off != uint(rt.Field(i).Offset) {
+				t.Errorf("offset of %v.%v = %v, expected %v", rt.Name(), rt.Field(i).Name, off, rt.Field(i).Offset)
+			}
+		}
+	}
+}
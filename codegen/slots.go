@@ -0,0 +1,66 @@
+package codegen
+
+import (
+	"go/types"
+
+	"golang.org/x/tools/go/ssa"
+)
+
+// assignSlots gives every value lowering stores its stack slot, in block
+// and instruction order, and the result its FP slot, before any block is
+// lowered. Slots aren't created while lowering, so a value has the same
+// slot in every block whichever block is emitted first.
+func (f *Function) assignSlots() {
+	if f.retType() != nil {
+		f.retIdent()
+	}
+	for _, block := range f.ssa.Blocks {
+		for _, instr := range block.Instrs {
+			if v, ok := instr.(ssa.Value); ok && f.needsSlot(v) {
+				f.Ident(v)
+			}
+		}
+	}
+	f.slotsAssigned = true
+}
+
+// needsSlot returns whether lowering stores v, values replaced by a fused
+// instruction, comparisons done by a select or jump table, the values of
+// blocks that aren't emitted, and calls without results aren't stored.
+func (f *Function) needsSlot(v ssa.Value) bool {
+	instr := v.(ssa.Instruction)
+	if f.fusedInstrs[instr] || f.selectArms[instr.Block()] || f.switchBlocks[instr.Block()] {
+		return false
+	}
+	if cmp, ok := v.(*ssa.BinOp); ok && (f.isSelectCmp(cmp) || f.isSwitchCmp(cmp)) {
+		return false
+	}
+	return slotType(v.Type())
+}
+
+// slotType returns whether values of type t have a stack slot, tuples,
+// e.g. the results of calls without results, and unsupported types don't.
+func slotType(t types.Type) bool {
+	switch t := t.(type) {
+	case *types.Basic:
+		switch t.Kind() {
+		case types.Bool, types.Int, types.Int8, types.Int16, types.Int32, types.Int64,
+			types.Uint, types.Uint8, types.Uint16, types.Uint32, types.Uint64, types.Float32, types.Float64:
+			return true
+		}
+	case *types.Pointer, *types.Slice, *types.Array, *types.Struct:
+		return true
+	case *types.Named:
+		if _, ok := sse2Info(t); ok {
+			return true
+		}
+		if _, ok := simdInfo(t); ok {
+			return true
+		}
+		switch t.Underlying().(type) {
+		case *types.Basic, *types.Array, *types.Struct, *types.Pointer, *types.Slice:
+			return slotType(t.Underlying())
+		}
+	}
+	return false
+}
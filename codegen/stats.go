@@ -0,0 +1,130 @@
+package codegen
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Stats are measures of the code generated for a function, for tracking
+// the quality of the generated code across gensimd versions.
+type Stats struct {
+	// Name is the assembly function name
+	Name string `json:"name"`
+	// Instrs is the number of instructions, including those of the jump
+	// table helper, labels and directives aren't counted
+	Instrs int `json:"instrs"`
+	// Cycles is a static estimate of the latency of the instructions, each
+	// counted once, loops aren't taken into account
+	Cycles int `json:"cycles"`
+	// FrameSize is the size of the stack frame in the TEXT line
+	FrameSize uint32 `json:"frameSize"`
+	// Spills is the number of registers spilled to free them for another value
+	Spills int `json:"spills"`
+	// VectorPercent is the percentage of the instructions operating on XMM
+	// registers, scalar float instructions like ADDSS aren't counted
+	VectorPercent float64 `json:"vectorPercent"`
+}
+
+// instrCycles are the approximate latencies of instructions taking more
+// than a cycle, by mnemonic.
+var instrCycles = map[string]int{
+	"IMULB": 3, "IMULW": 3, "IMULL": 3, "IMULQ": 3, "IMUL3Q": 3,
+	"MULB": 3, "MULW": 3, "MULL": 3, "MULQ": 3,
+	"DIVB": 26, "DIVW": 26, "DIVL": 26, "DIVQ": 40,
+	"IDIVB": 26, "IDIVW": 26, "IDIVL": 26, "IDIVQ": 40,
+	"BSFL": 3, "BSFQ": 3, "BSRL": 3, "BSRQ": 3, "CRC32B": 3, "CRC32Q": 3,
+	"ADDSS": 4, "ADDSD": 4, "ADDPS": 4, "ADDPD": 4,
+	"SUBSS": 4, "SUBSD": 4, "SUBPS": 4, "SUBPD": 4,
+	"MULSS": 4, "MULSD": 4, "MULPS": 4, "MULPD": 4,
+	"DIVSS": 11, "DIVPS": 11, "DIVSD": 14, "DIVPD": 14,
+	"SQRTSS": 12, "SQRTPS": 12, "SQRTSD": 18, "SQRTPD": 18,
+	"MAXSS": 4, "MAXSD": 4, "MAXPS": 4, "MAXPD": 4,
+	"MINSS": 4, "MINSD": 4, "MINPS": 4, "MINPD": 4,
+	"ROUNDSS": 8, "ROUNDSD": 8, "ROUNDPS": 8, "ROUNDPD": 8,
+	"DPPS": 9, "PMULLW": 5, "PMULHW": 5, "PMULHUW": 5, "PMULULQ": 5, "PMADDWL": 5,
+	"PCLMULQDQ": 7, "VMASKMOVPS": 8, "MOVMSKPS": 3, "MOVMSKPD": 3, "PMOVMSKB": 3,
+	"CALL": 5,
+}
+
+const (
+	// memCycles is the latency of an L1 cache hit, added for memory operands
+	memCycles = 4
+	// repCycles is the startup latency of REP MOVSQ and REP STOSQ, each
+	// iteration adds a cycle
+	repCycles = 35
+)
+
+// Stats returns measures of f's assembly, GoAssembly must have succeeded.
+func (f *Function) Stats() (Stats, *Error) {
+	if f.asm == "" {
+		return Stats{}, ErrorMsg2("Stats requires the assembly, call GoAssembly first")
+	}
+	stats := Stats{Name: f.outfname(), FrameSize: f.frameSize, Spills: f.spills}
+	vector := 0
+	// the count of a REP instruction, moved to CX before it
+	count := 0
+	for _, line := range strings.Split(f.asm, "\n") {
+		mnemonic, ops, ok := asmInstr(line)
+		if !ok {
+			continue
+		}
+		stats.Instrs++
+		cycles, ok := instrCycles[mnemonic]
+		if !ok {
+			cycles = 1
+		}
+		switch mnemonic {
+		case MOVSQ.String(), STOSQ.String():
+			cycles = repCycles + count
+		case MOVQ.String():
+			if len(ops) == 2 && ops[1] == "CX" && strings.HasPrefix(ops[0], "$") {
+				count, _ = strconv.Atoi(ops[0][1:])
+			}
+		}
+		for _, op := range ops {
+			if strings.Contains(op, "(") && !strings.HasPrefix(op, "$") {
+				cycles += memCycles
+				break
+			}
+		}
+		stats.Cycles += cycles
+		if isVectorInstr(mnemonic, ops) {
+			vector++
+		}
+	}
+	if stats.Instrs > 0 {
+		stats.VectorPercent = 100 * float64(vector) / float64(stats.Instrs)
+	}
+	return stats, nil
+}
+
+// asmInstr returns the mnemonic and operands of the instruction on line,
+// false for labels, comments, directives, and the REP prefix.
+func asmInstr(line string) (string, []string, bool) {
+	fields := strings.Fields(line)
+	if len(fields) == 0 || strings.HasPrefix(fields[0], "//") || strings.HasPrefix(fields[0], "#") ||
+		strings.HasSuffix(fields[0], ":") {
+		return "", nil, false
+	}
+	mnemonic := fields[0]
+	switch mnemonic {
+	case "TEXT", "DATA", "GLOBL", "PCALIGN", REP.String():
+		return "", nil, false
+	}
+	return mnemonic, strings.Split(strings.Join(fields[1:], ""), ","), true
+}
+
+// isVectorInstr returns true if the instruction mnemonic has an XMM register
+// operand and isn't a scalar float instruction.
+func isVectorInstr(mnemonic string, ops []string) bool {
+	if strings.HasSuffix(mnemonic, "SS") || strings.HasSuffix(mnemonic, "SD") ||
+		strings.Contains(mnemonic, "SS2") || strings.Contains(mnemonic, "SD2") {
+		return false
+	}
+	for _, op := range ops {
+		if len(op) > 1 && op[0] == 'X' && op[1] >= '0' && op[1] <= '9' {
+			return true
+		}
+	}
+	return false
+}
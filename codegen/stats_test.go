@@ -0,0 +1,31 @@
+package codegen
+
+import "testing"
+
+func TestStats(t *testing.T) {
+	f := &Function{outfn: "fn", frameSize: 24, spills: 2, asm: `TEXT ·fn(SB),$24-16
+block0:
+        // entry
+        MOVQ         x+0(FP), R8
+        IMULQ        R8, R8
+        MOVQ         $4, CX
+        REP
+        STOSQ
+        MOVUPS       (R8), X0
+        ADDPS        X0, X0
+        ADDSS        X0, X0
+        RET
+`}
+	stats, err := f.Stats()
+	if err != nil {
+		t.Fatal(err.Err)
+	}
+	// MOVQ load 1+4, IMULQ 3, MOVQ 1, REP STOSQ 35+4, MOVUPS 1+4, ADDPS 4, ADDSS 4, RET 1
+	expected := Stats{Name: "fn", Instrs: 8, Cycles: 62, FrameSize: 24, Spills: 2, VectorPercent: 25}
+	if stats != expected {
+		t.Errorf("Stats() = %+v, expected %+v", stats, expected)
+	}
+	if _, err := (&Function{}).Stats(); err == nil {
+		t.Errorf("Stats() without assembly succeeded")
+	}
+}
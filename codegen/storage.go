@@ -87,7 +87,7 @@ func (a *aliaser) addAlias(ctx context, newAlias alias) bool {
 	newAlias.dst.parent = a.src
 	identName := a.src.owner().name
 	if ctx.f.Trace {
-		fmt.Printf(ctx.f.Indent+"Alias: %v -> %v\n", identName, newAlias.String())
+		fmt.Printf(ctx.f.opts.Indent+"Alias: %v -> %v\n", identName, newAlias.String())
 
 	}
 	new := true
@@ -96,7 +96,7 @@ func (a *aliaser) addAlias(ctx context, newAlias alias) bool {
 			new = false
 		}
 		if ctx.f.Trace {
-			fmt.Printf(ctx.f.Indent+"     : %v -> %v\n", identName, alias.dst.name)
+			fmt.Printf(ctx.f.opts.Indent+"     : %v -> %v\n", identName, alias.dst.name)
 		}
 	}
 	if new {
@@ -117,7 +117,7 @@ func (a *aliaser) removeAlias(ctx context, r *register) bool {
 	removed := false
 	identName := a.src.owner().name
 	if ctx.f.Trace {
-		fmt.Printf(ctx.f.Indent+"Alias: %v -/ %v (d=%v)\n", identName, r.name, r.dirty)
+		fmt.Printf(ctx.f.opts.Indent+"Alias: %v -/ %v (d=%v)\n", identName, r.name, r.dirty)
 
 	}
 	for _, alias := range a.aliases {
@@ -126,7 +126,7 @@ func (a *aliaser) removeAlias(ctx context, r *register) bool {
 		} else {
 			aliases = append(aliases, alias)
 			if ctx.f.Trace {
-				fmt.Printf(ctx.f.Indent+"     : %v -> %v\n", identName, alias.String())
+				fmt.Printf(ctx.f.opts.Indent+"     : %v -> %v\n", identName, alias.String())
 			}
 		}
 	}
@@ -437,6 +437,8 @@ func (m *memory) load(ctx context, chunk region) (string, *register) {
 	if r := m.fetch(chunk); r != nil {
 		r.inUse = true
 		return "", r
+	} else if pin, ok := ctx.f.pins[m.name()]; ok && chunk == m.ownerRegion() {
+		return m.loadPin(ctx, chunk, pin)
 	} else {
 		return m.loadNew(ctx, chunk)
 	}
@@ -532,7 +534,7 @@ func (m *memory) spillRegister(ctx context, r *register, force bool) string {
 		// case 0 - do nothing, ident is dead
 		if ctx.f.Trace {
 			ident := m.owner()
-			fmt.Printf(ident.f.Indent+"not spilling %v, %v dead\n",
+			fmt.Printf(ident.f.opts.Indent+"not spilling %v, %v dead\n",
 				r.name, ident.name)
 		}
 
@@ -570,6 +572,15 @@ func copyMemMem(src, dst *memory, chunk transfer) string {
 		iterations = size / sizeBasic(types.Int16)
 		datasize = 2
 	}
+	// asmdecl names the elements of arrays in the argument frame, not the
+	// words of them
+	for _, m := range []*memory{src, dst} {
+		if m.reg().regconst == REG_FP {
+			if e := argCopySize(m.owner().typ, datasize); e < datasize {
+				datasize, iterations = e, size/e
+			}
+		}
+	}
 
 	if size > sizeInt() {
 		if size%sizeInt() != 0 {
@@ -688,16 +699,7 @@ func (cnst *constant) loadNew(ctx context, chunk region) (string, *register) {
 		if r.typ != XMM_REG {
 			ice("can't load float const into non xmm register")
 		}
-		a, tmp := ctx.f.allocTempReg(DATA_REG, 8)
-		asm += a
-		if isFloat32(cnst.Type()) {
-			a = MovImmf32Reg(ctx, float32(cnst.Float64()), tmp, r, false)
-			asm += a
-		} else {
-			a = MovImmf64Reg(ctx, cnst.Float64(), tmp, r, false)
-			asm += a
-		}
-		ctx.f.freeReg(tmp)
+		asm += MovFloatConstReg(ctx, cnst.Float64(), isFloat32(cnst.Type()), r, false)
 
 	} else if isComplex(cnst.Type()) {
 		ice("complex64/128 unsupported")
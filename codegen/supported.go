@@ -0,0 +1,196 @@
+package codegen
+
+import (
+	"errors"
+	"fmt"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/ssa"
+)
+
+// deferMsg is the error of functions with defer statements. Deferred calls
+// need the runtime's defer records and a frame the runtime can unwind,
+// generated functions are leaf assembly without either.
+const deferMsg = "defer unsupported, generated functions are leaf assembly without the runtime support to run deferred calls"
+
+// Unsupported walks the ssa of fn once and returns an error for each
+// construct gensimd can't generate assembly for, e.g. goroutines, channels,
+// closures, and maps, in block and instruction order. It generates no
+// assembly so all of them are reported instead of only the first.
+func Unsupported(fn *ssa.Function) []*Error {
+	errs := []*Error{}
+	add := func(pos token.Pos, msg string) {
+		errs = append(errs, &Error{Err: errors.New(msg), Pos: pos})
+	}
+	// the rest of the ssa of a function built in another mode is noise
+	if msg := builderModeMsg(fn); msg != "" {
+		add(fn.Pos(), msg)
+		return errs
+	}
+	for _, p := range fn.Params {
+		if types.IsInterface(p.Type()) {
+			add(p.Pos(), fmt.Sprintf("Unsupported param type (%v), interface values aren't supported", p.Type()))
+		}
+	}
+	hasDefer := false
+	for _, block := range fn.Blocks {
+		for _, instr := range block.Instrs {
+			if _, ok := instr.(*ssa.Defer); ok {
+				hasDefer = true
+			}
+			if msg := unsupportedMsg(instr); msg != "" {
+				pos := instr.Pos()
+				// closures of go and defer statements have no position, use the func literal's
+				if closure, ok := instr.(*ssa.MakeClosure); ok && !pos.IsValid() {
+					pos = closure.Fn.Pos()
+				}
+				add(pos, msg)
+			}
+		}
+	}
+	// RunDefers has no position of its own, use the function's
+	if !hasDefer {
+		for _, block := range fn.Blocks {
+			for _, instr := range block.Instrs {
+				if _, ok := instr.(*ssa.RunDefers); ok {
+					add(fn.Pos(), deferMsg)
+				}
+			}
+		}
+	}
+	return errs
+}
+
+// unsupportedMsg returns the error message of instr if it's never supported,
+// "" if its assembly may be generated.
+func unsupportedMsg(instr ssa.Instruction) string {
+	switch instr := instr.(type) {
+	case *ssa.Alloc:
+		if !stackAlloc(instr) {
+			msg := "Heap allocations are unsupported (are all print and log statements removed?), ssa variable: %v, type: %v"
+			return fmt.Sprintf(msg, instr.Name(), instr.Type())
+		}
+	case *ssa.Call:
+		return unsupportedCallMsg(instr)
+	case *ssa.ChangeInterface:
+		return "converting interfaces unsupported"
+	case *ssa.Defer:
+		return deferMsg
+	case *ssa.Extract:
+		return "extracting tuple values unsupported"
+	case *ssa.Field, *ssa.FieldAddr:
+		return "field access unimplemented"
+	case *ssa.Go:
+		return "go keyword unsupported"
+	case *ssa.Lookup:
+		return "maps unsupported"
+	case *ssa.MakeChan:
+		return "channels unsupported"
+	case *ssa.MakeClosure:
+		return "closures unsupported"
+	case *ssa.MakeInterface, *ssa.MakeMap, *ssa.MakeSlice:
+		return "make slice/map/interface unsupported"
+	case *ssa.MapUpdate:
+		return "map update unsupported"
+	case *ssa.Next:
+		return "map/string iterators unsupported"
+	case *ssa.Panic:
+		return "panic unimplemented"
+	case *ssa.Range:
+		return "range unsupported"
+	case *ssa.Select, *ssa.Send:
+		return "select/send unsupported"
+	case *ssa.Slice:
+		if !slicesStack(instr) {
+			return "slice creation unsupported, only slices of local arrays are"
+		}
+	case *ssa.Store:
+		return pointerStoreMsg(instr)
+	case *ssa.TypeAssert:
+		return typeAssertMsg(instr)
+	case *ssa.UnOp:
+		if instr.Op == token.ARROW {
+			return "channel receive unsupported"
+		}
+	}
+	return ""
+}
+
+// unsupportedCallMsg returns the error message of call unless it's to len
+// or a simd/sse2/math/bits or registered intrinsic.
+func unsupportedCallMsg(call *ssa.Call) string {
+	if builtin, ok := call.Common().Value.(*ssa.Builtin); ok {
+		if builtin.Name() == "len" {
+			return ""
+		}
+		return fmt.Sprintf("builtin (%v) not supported", builtin.Name())
+	}
+	if _, ok := registeredIntrinsic(call); ok {
+		return ""
+	}
+	if _, ok := atomicCallOp(call); ok {
+		return ""
+	}
+	if isSimdIntrinsic(call) {
+		return ""
+	}
+	if _, ok := isSSE2Intrinsic(call); ok {
+		return ""
+	}
+	if _, ok := isMathIntrinsic(call); ok {
+		return ""
+	}
+	if _, ok := isBitsIntrinsic(call); ok {
+		return ""
+	}
+	return fmt.Sprintf("function calls are not supported, description (%v)", call.Common().Description())
+}
+
+// pointerStoreMsg returns the error message of a store of a value with
+// pointers to memory that may be on the heap, "" if the value has no
+// pointers or is stored to a local variable. The garbage collector needs a
+// write barrier for each pointer stored to the heap, even nil, and assembly
+// outside the runtime can't call runtime.gcWriteBarrier.
+func pointerStoreMsg(store *ssa.Store) string {
+	t := store.Val.Type()
+	if !hasPointers(t) || onStack(store.Addr) {
+		return ""
+	}
+	msg := "storing pointers unsupported, the store of %v (type %v) through %v may be to the heap and needs a garbage collector write barrier"
+	return fmt.Sprintf(msg, store.Val.Name(), t, store.Addr.Name())
+}
+
+// hasPointers returns whether values of type t hold pointers the garbage
+// collector scans, e.g. the data pointers of slices and strings.
+func hasPointers(t types.Type) bool {
+	switch t := t.Underlying().(type) {
+	case *types.Basic:
+		return t.Kind() == types.String || t.Kind() == types.UnsafePointer || t.Kind() == types.UntypedNil
+	case *types.Array:
+		return t.Len() > 0 && hasPointers(t.Elem())
+	case *types.Struct:
+		for i := 0; i < t.NumFields(); i++ {
+			if hasPointers(t.Field(i).Type()) {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+// onStack returns whether addr is the address of a local variable, or of an
+// element or field of one, which is in the frame.
+func onStack(addr ssa.Value) bool {
+	switch addr := addr.(type) {
+	case *ssa.Alloc:
+		return stackAlloc(addr)
+	case *ssa.IndexAddr:
+		// the elements of a slice are elsewhere
+		return !isSlice(addr.X.Type().Underlying()) && onStack(addr.X)
+	case *ssa.FieldAddr:
+		return onStack(addr.X)
+	}
+	return false
+}
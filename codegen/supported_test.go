@@ -0,0 +1,54 @@
+package codegen
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestUnsupported checks the unsupported constructs of a function are all
+// reported, in source order, by Compile before any assembly is generated.
+func TestUnsupported(t *testing.T) {
+	const src = `package src
+
+func f(m map[int]int, c chan int, x int) int {
+	y := m[x]
+	c <- y
+	go func() {}()
+	return y
+}
+`
+	fn := buildFuncMode(t, src, "f", BuilderMode)
+	expected := []struct {
+		line int
+		msg  string
+	}{
+		{4, "maps unsupported"},
+		{5, "select/send unsupported"},
+		{6, "go keyword unsupported"},
+	}
+	errs := Unsupported(fn)
+	if len(errs) != len(expected) {
+		t.Fatalf("Unsupported() = %v errors, expected %v", len(errs), len(expected))
+	}
+	for i, err := range errs {
+		if !strings.Contains(err.Err.Error(), expected[i].msg) {
+			t.Errorf("Unsupported() error %v = %q, expected %q", i, err.Err, expected[i].msg)
+		}
+	}
+
+	result, err := Compile(fn, DefaultOptions())
+	if err == nil || err.Err.Error() != errs[0].Err.Error() {
+		t.Fatalf("Compile error %v, expected the first unsupported construct %q", err, errs[0].Err)
+	}
+	if result.Asm != "" || result.Stats.Instrs != 0 {
+		t.Errorf("Compile generated assembly for a function with unsupported constructs:\n%v", result.Asm)
+	}
+	if len(result.Diagnostics) != len(expected) {
+		t.Fatalf("Compile diagnostics %v, expected %v", result.Diagnostics, len(expected))
+	}
+	for i, d := range result.Diagnostics {
+		if d.Pos.Line != expected[i].line || !strings.Contains(d.Msg, expected[i].msg) {
+			t.Errorf("diagnostic %v = %v, expected line %v: %v", i, d, expected[i].line, expected[i].msg)
+		}
+	}
+}
@@ -0,0 +1,248 @@
+package codegen
+
+import (
+	"fmt"
+	"go/token"
+	"math"
+	"strconv"
+	"strings"
+
+	"golang.org/x/tools/go/ssa"
+	"golang.org/x/tools/go/ssa/ssautil"
+)
+
+const (
+	// jumpTableMinCases is the fewest case values lowered to a jump table,
+	// fewer are compared faster than the call jumping to the stub
+	jumpTableMinCases = 8
+	// jumpTableMaxSpan is the most values from the smallest to the largest
+	// case a table covers, the stub index of each value is a byte
+	jumpTableMaxSpan = 256
+	// at least 1 in jumpTableMinDensity values of the span must be a case
+	jumpTableMinDensity = 4
+)
+
+// jumpTable is a dense switch on an integer, an if chain comparing x to
+// constants, lowered to a computed jump. The value x-min indexes a read only
+// table of bytes, the stub jumped to for each value. The stubs are 8 byte
+// aligned JMPs to the blocks after the chain, or to the code setting the
+// phis of the edge to the block.
+type jumpTable struct {
+	sw  ssautil.Switch
+	min int64
+	// edges[i] is the edge taken for the value min+i, dflt is taken for
+	// values outside the table
+	edges []jumpEdge
+	dflt  jumpEdge
+	// table is the stub index of each value, set by JumpTable, and name
+	// its data symbol
+	table []byte
+	name  string
+}
+
+// jumpEdge is the edge from a comparison block of a switch to its successor.
+type jumpEdge struct {
+	from, to *ssa.BasicBlock
+}
+
+// computeJumpTables finds the switches lowered to jump tables and the
+// comparison blocks they skip, the blocks after the first.
+func (f *Function) computeJumpTables() {
+	f.jumpTables = make(map[*ssa.If]*jumpTable)
+	f.switchBlocks = make(map[*ssa.BasicBlock]bool)
+	if !f.Optimize {
+		return
+	}
+	for _, sw := range ssautil.Switches(f.ssa) {
+		jt, ok := f.matchJumpTable(sw)
+		if !ok {
+			continue
+		}
+		jt.name = f.outfname() + "_switch" + strconv.Itoa(len(f.jumpTables))
+		instrs := sw.Start.Instrs
+		f.jumpTables[instrs[len(instrs)-1].(*ssa.If)] = jt
+		for _, c := range sw.ConstCases[1:] {
+			f.switchBlocks[c.Block] = true
+		}
+	}
+}
+
+// matchJumpTable returns the jump table of sw if its cases are dense
+// integer constants.
+func (f *Function) matchJumpTable(sw ssautil.Switch) (*jumpTable, bool) {
+	if len(sw.ConstCases) < jumpTableMinCases || !isInteger(sw.X.Type()) {
+		return nil, false
+	}
+	if _, ok := sw.X.(*ssa.Const); ok {
+		return nil, false
+	}
+	signed := GetOpDataType(sw.X.Type()).signed
+	// the values are compared as unsigned keys in the same order
+	key := func(c *ssa.Const) uint64 {
+		if signed {
+			return uint64(c.Int64()) + 1<<63
+		}
+		return c.Uint64()
+	}
+	edges := make(map[uint64]jumpEdge)
+	var lo, hi uint64
+	for i, c := range sw.ConstCases {
+		instrs := c.Block.Instrs
+		ifInstr := instrs[len(instrs)-1].(*ssa.If)
+		if _, ok := f.selects[ifInstr]; ok || len(nonDebugRefs(ifInstr.Cond)) != 1 {
+			return nil, false
+		}
+		k := key(c.Value)
+		if _, ok := edges[k]; ok {
+			// the first of duplicate comparisons is taken
+			continue
+		}
+		edges[k] = jumpEdge{c.Block, c.Body}
+		if i == 0 || k < lo {
+			lo = k
+		}
+		if i == 0 || k > hi {
+			hi = k
+		}
+	}
+	if len(edges) < jumpTableMinCases || hi-lo >= jumpTableMaxSpan {
+		return nil, false
+	}
+	span := int(hi-lo) + 1
+	if jumpTableMinDensity*len(edges) < span {
+		return nil, false
+	}
+	last := sw.ConstCases[len(sw.ConstCases)-1].Block
+	jt := &jumpTable{sw: sw, min: int64(lo), edges: make([]jumpEdge, span), dflt: jumpEdge{last, sw.Default}}
+	if signed {
+		jt.min = int64(lo - 1<<63)
+	}
+	if jt.min < math.MinInt32 || jt.min > math.MaxInt32 {
+		return nil, false
+	}
+	for i := range jt.edges {
+		edge, ok := edges[lo+uint64(i)]
+		if !ok {
+			edge = jt.dflt
+		}
+		jt.edges[i] = edge
+	}
+	return jt, true
+}
+
+// isSwitchCmp returns true if the comparison instr is replaced by the jump
+// table ending its block.
+func (f *Function) isSwitchCmp(instr *ssa.BinOp) bool {
+	instrs := instr.Block().Instrs
+	ifInstr, ok := instrs[len(instrs)-1].(*ssa.If)
+	if !ok {
+		return false
+	}
+	_, ok = f.jumpTables[ifInstr]
+	return ok && ifInstr.Cond == instr
+}
+
+// JumpTable jumps to the stub of x-min in the table, or to the default edge
+// if x is outside of it. The registers are spilled first so every edge
+// starts with the same registers.
+func (f *Function) JumpTable(instr *ssa.If, jt *jumpTable) (string, *Error) {
+	ctx := context{f, instr}
+	asm, err := f.spillRegisters(ctx)
+	if err != nil {
+		return "", err
+	}
+	a, x, err := f.LoadValueSimple(instr, jt.sw.X)
+	if err != nil {
+		return "", err
+	}
+	asm += a
+	a, idx := f.allocReg(instr, DATA_REG, DataRegSize)
+	asm += a
+	xtype := GetOpDataType(jt.sw.X.Type())
+	idxtype := GetIntegerOpDataType(xtype.signed, DataRegSize)
+	asm += IntegerToInteger(ctx, x, idx, xtype, idxtype)
+	f.freeReg(x)
+	if jt.min != 0 {
+		asm += ImmOp(ctx, idxtype, token.SUB, idx, jt.min, idx)
+	}
+	// the edges setting phis or induction pointers are jumped to through a
+	// label before their code, the others jump to the block directly
+	edges := append([]jumpEdge{jt.dflt}, jt.edges...)
+	labels := make(map[jumpEdge]string)
+	preambles := ""
+	for _, edge := range edges {
+		if _, ok := labels[edge]; ok {
+			continue
+		}
+		a, err := f.JumpPreamble(instr, edge.from.Index, edge.to.Index)
+		if err != nil {
+			return "", err
+		}
+		labels[edge] = "block" + strconv.Itoa(edge.to.Index)
+		if !isEmptyAsm(a) {
+			labels[edge] = f.newJmpLabel()
+			preambles += labels[edge] + ":\n" + a
+			preambles += fmt.Sprintf("%-9v    ", "JMP") + "block" + strconv.Itoa(edge.to.Index) + "\n"
+		}
+	}
+	// a stub per label
+	var stubs []string
+	stubIndex := make(map[string]int)
+	jt.table = make([]byte, len(jt.edges))
+	for i, edge := range jt.edges {
+		label := labels[edge]
+		if _, ok := stubIndex[label]; !ok {
+			stubIndex[label] = len(stubs)
+			stubs = append(stubs, label)
+		}
+		jt.table[i] = byte(stubIndex[label])
+	}
+	asm += CmpRegImm32(ctx, idx, uint32(len(jt.table)), DataRegSize)
+	asm += fmt.Sprintf("%-9v    %v\n", JCC, labels[jt.dflt])
+	a, tmp := f.allocReg(instr, DATA_REG, DataRegSize)
+	asm += a
+	asm += JumpTableIndex(ctx, jt.name, idx, tmp)
+	f.freeReg(tmp)
+	asm += JumpTableStubs(ctx, f.jumpTableHelper(), idx, stubs)
+	f.freeReg(idx)
+	asm += preambles
+	asm = fmt.Sprintf("// BEGIN jump table ssa.If, %v\n", instr) + asm
+	asm += fmt.Sprintf("// END jump table ssa.If, %v\n", instr)
+	return asm, nil
+}
+
+// isEmptyAsm returns true if asm only has comments.
+func isEmptyAsm(asm string) bool {
+	for _, line := range strings.Split(asm, "\n") {
+		if line = strings.TrimSpace(line); line != "" && !strings.HasPrefix(line, "//") {
+			return false
+		}
+	}
+	return true
+}
+
+// jumpTableHelper returns the symbol of the function jumping to the stubs
+// after its call.
+func (f *Function) jumpTableHelper() string {
+	return f.outfname() + "_jumptable<>(SB)"
+}
+
+// jumpTablesAsm returns the data of the jump tables and the helper jumping
+// to their stubs, nothing if f has none.
+func (f *Function) jumpTablesAsm() string {
+	if len(f.jumpTables) == 0 {
+		return ""
+	}
+	asm := ""
+	for _, block := range f.ssa.Blocks {
+		if len(block.Instrs) == 0 {
+			continue
+		}
+		if instr, ok := block.Instrs[len(block.Instrs)-1].(*ssa.If); ok {
+			if jt, ok := f.jumpTables[instr]; ok {
+				asm += "\n" + dataSym{name: jt.name, bytes: jt.table}.dataAsm()
+			}
+		}
+	}
+	return asm + "\n" + JumpTableHelper(f.jumpTableHelper(), f.opts.Indent)
+}
@@ -0,0 +1,174 @@
+package codegen
+
+import (
+	"fmt"
+	"go/token"
+	"go/types"
+	"strings"
+)
+
+// Slot is a parameter or result of an assembly function.
+type Slot struct {
+	Name string
+	Type types.Type
+	// Offset is the offset in bytes from FP
+	Offset int
+	Size   uint
+}
+
+// Layout describes a generated assembly function, for tools generating
+// wrappers or documentation without parsing the assembly.
+type Layout struct {
+	// Name is the assembly function name
+	Name    string
+	Params  []Slot
+	Results []Slot
+	// FrameSize and ArgsSize are the sizes in the TEXT line, "$FrameSize-ArgsSize"
+	FrameSize uint32
+	ArgsSize  int
+	// Clobbers are the registers the function writes, e.g. "AX" and "X15"
+	Clobbers []string
+	// Features are the CPU features the function requires, Target constants
+	// in increasing order, TargetSSE2 is always required
+	Features []string
+}
+
+// Layout returns the layout of f's assembly, GoAssembly must have succeeded.
+// With Options.Variants it's the layout of the dispatch stub, the clobbers
+// are those of every variant and the features those of the lowest.
+func (f *Function) Layout() (Layout, *Error) {
+	if f.asm == "" {
+		return Layout{}, ErrorMsg2("Layout requires the assembly, call GoAssembly first")
+	}
+	if f.variants != nil {
+		layout, err := f.variants[0].Layout()
+		layout.Name = f.outfname()
+		layout.FrameSize = 0
+		layout.Clobbers = asmClobbers(f.asm)
+		return layout, err
+	}
+	layout := Layout{
+		Name:      f.outfname(),
+		FrameSize: f.frameSize,
+		ArgsSize:  f.argsSize,
+		Clobbers:  asmClobbers(f.asm),
+		Features:  asmFeatures(f.asm),
+	}
+	for _, p := range f.ssa.Params {
+		ident := f.identifiers[p.Name()]
+		layout.Params = append(layout.Params, Slot{p.Name(), p.Type(), ident.offset, ident.size()})
+	}
+	if f.retType() != nil {
+		layout.Results = append(layout.Results, Slot{retName(), f.retType(), f.retOffset(), f.retSize()})
+	}
+	return layout, nil
+}
+
+// asmFeatures returns the targets needed by the instructions of asm.
+func asmFeatures(asm string) []string {
+	needed := map[string]bool{TargetSSE2: true}
+	for _, instr := range asmInstrs(asm) {
+		if target, ok := instrTargets[instr]; ok {
+			needed[target] = true
+		}
+	}
+	features := []string{}
+	for _, target := range targets {
+		if needed[target] {
+			features = append(features, target)
+		}
+	}
+	return features
+}
+
+// asmClobbers returns the registers written by the instructions of asm in
+// register order. The destination is the last operand, instructions whose
+// flags don't mark it written only read it, and registers set implicitly,
+// e.g. by REP STOSQ, REP MOVSQ, or DIVQ, are included.
+func asmClobbers(asm string) []string {
+	names := map[string]Instruction{}
+	for instr := range instrTable {
+		names[instr.String()] = instr
+	}
+	written := map[Reg]bool{}
+	for _, line := range strings.Split(asm, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "//") {
+			continue
+		}
+		instr, known := names[fields[0]]
+		info := instrTable[instr]
+		if known {
+			for _, r := range registers {
+				if info.Set&r.regconst != 0 {
+					written[r.regconst] = true
+				}
+			}
+		}
+		if fields[0] == STOSQ.String() {
+			written[REG_DI] = true
+			written[REG_CX] = true
+		}
+		if fields[0] == MOVSQ.String() {
+			written[REG_SI] = true
+			written[REG_DI] = true
+			written[REG_CX] = true
+		}
+		if len(fields) < 2 {
+			continue
+		}
+		ops := strings.Split(strings.Join(fields[1:], ""), ",")
+		dst := ops[len(ops)-1]
+		if known && info.Flags&(RightWrite|RightRdwr) == 0 && info.Flags&Move == 0 {
+			continue
+		}
+		for _, r := range registers {
+			if r.typ != DATA_REG && r.typ != XMM_REG {
+				continue
+			}
+			if dst == r.name {
+				written[r.regconst] = true
+			}
+		}
+	}
+	clobbers := []string{}
+	for _, r := range registers {
+		if written[r.regconst] && (r.typ == DATA_REG || r.typ == XMM_REG) && r.width >= 64 {
+			clobbers = append(clobbers, r.name)
+		}
+	}
+	return clobbers
+}
+
+// TargetSuffix returns the suffix of functions generated for target, e.g.
+// "AVX2" for TargetAVX2 and "SSE41" for TargetSSE41, for naming variants
+// like sumAVX2.
+func TargetSuffix(target string) string {
+	return strings.ToUpper(strings.Replace(target, ".", "", -1))
+}
+
+// CheckOutNames returns an error for each assembly function name of
+// outNames that isn't a Go identifier, is generated twice, or is declared
+// by a Go symbol in declared, the positions of the package's symbols by
+// name. The assembly is linked by name, so these are otherwise found when
+// building the package, as duplicate or missing definitions.
+func CheckOutNames(outNames []string, declared map[string]token.Position) []*Error {
+	errs := []*Error{}
+	generated := map[string]bool{}
+	for _, name := range outNames {
+		if !token.IsIdentifier(name) {
+			errs = append(errs, ErrorMsg2(fmt.Sprintf("output function name \"%v\" isn't a Go identifier", name)))
+			continue
+		}
+		if generated[name] {
+			errs = append(errs, ErrorMsg2(fmt.Sprintf("output function name \"%v\" generated twice", name)))
+			continue
+		}
+		generated[name] = true
+		if position, ok := declared[name]; ok {
+			msg := "output function name \"%v\" clashes with the Go symbol declared at %v"
+			errs = append(errs, ErrorMsg2(fmt.Sprintf(msg, name, position)))
+		}
+	}
+	return errs
+}
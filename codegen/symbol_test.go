@@ -0,0 +1,72 @@
+package codegen
+
+import (
+	"fmt"
+	"go/token"
+	"strings"
+	"testing"
+)
+
+func TestCheckOutNames(t *testing.T) {
+	declared := map[string]token.Position{"sum": {Filename: "sum.go", Line: 3, Column: 6}}
+	if errs := CheckOutNames([]string{"sumAVX2", "dotAVX2"}, declared); len(errs) > 0 {
+		t.Errorf("CheckOutNames() = %v, expected no errors", errs[0].Err)
+	}
+	cases := []struct {
+		names    []string
+		expected string
+	}{
+		{[]string{"sum"}, "clashes with the Go symbol declared at sum.go:3:6"},
+		{[]string{"dot", "dot"}, "\"dot\" generated twice"},
+		{[]string{"dot-avx"}, "isn't a Go identifier"},
+	}
+	for _, c := range cases {
+		errs := CheckOutNames(c.names, declared)
+		if len(errs) != 1 || !strings.Contains(errs[0].Err.Error(), c.expected) {
+			t.Errorf("CheckOutNames(%v) = %v, expected one error containing %q", c.names, errs, c.expected)
+		}
+	}
+	if suffix := TargetSuffix(TargetSSE41); suffix != "SSE41" {
+		t.Errorf("TargetSuffix(%v) = %v, expected SSE41", TargetSSE41, suffix)
+	}
+}
+
+// TestLayout checks the offsets and sizes of the parameters and result, each
+// parameter is aligned to its type and the result to the word size.
+func TestLayout(t *testing.T) {
+	const src = `package src
+
+func f(a int8, d [2]uint32, x []float32, b int16, c float64, p *int32) int64 {
+	return int64(a) + int64(len(x)) + int64(b) + int64(c) + int64(*p) + int64(d[0])
+}
+`
+	result, err := Compile(buildFunc(t, src, "f"), DefaultOptions())
+	if err != nil {
+		t.Fatal(err.Err)
+	}
+	layout := result.Layout
+	type slot struct {
+		name   string
+		offset int
+		size   uint
+	}
+	expected := []slot{{"a", 0, 1}, {"d", 4, 8}, {"x", 16, 24}, {"b", 40, 2}, {"c", 48, 8}, {"p", 56, 8}, {"ret", 64, 8}}
+	got := []slot{}
+	for _, s := range append(layout.Params, layout.Results...) {
+		got = append(got, slot{s.Name, s.Offset, s.Size})
+	}
+	if len(got) != len(expected) {
+		t.Fatalf("Layout slots %v, expected %v", got, expected)
+	}
+	for i := range got {
+		if got[i] != expected[i] {
+			t.Errorf("Layout slot %v = %+v, expected %+v", i, got[i], expected[i])
+		}
+	}
+	if layout.ArgsSize != 72 {
+		t.Errorf("Layout ArgsSize = %v, expected 72", layout.ArgsSize)
+	}
+	if text := fmt.Sprintf("TEXT ·f(SB),$%v-%v", layout.FrameSize, layout.ArgsSize); !strings.HasPrefix(result.Asm, text) {
+		t.Errorf("Layout %+v doesn't match the TEXT line of\n%v", layout, result.Asm)
+	}
+}
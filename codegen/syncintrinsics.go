@@ -0,0 +1,198 @@
+package codegen
+
+import (
+	"fmt"
+	"go/types"
+
+	"golang.org/x/tools/go/ssa"
+)
+
+// atomicOp is an atomic operation on the uint32 or uint64, or int32 or
+// int64, pointed to by the first argument.
+type atomicOp int
+
+const (
+	atomicAdd atomicOp = iota
+	atomicLoad
+	atomicStore
+	atomicCas
+)
+
+// syncAtomics are the functions of package sync/atomic computed inline.
+var syncAtomics = map[string]atomicOp{
+	"AddInt32":             atomicAdd,
+	"AddInt64":             atomicAdd,
+	"AddUint32":            atomicAdd,
+	"AddUint64":            atomicAdd,
+	"LoadInt32":            atomicLoad,
+	"LoadInt64":            atomicLoad,
+	"LoadUint32":           atomicLoad,
+	"LoadUint64":           atomicLoad,
+	"StoreInt32":           atomicStore,
+	"StoreInt64":           atomicStore,
+	"StoreUint32":          atomicStore,
+	"StoreUint64":          atomicStore,
+	"CompareAndSwapInt32":  atomicCas,
+	"CompareAndSwapInt64":  atomicCas,
+	"CompareAndSwapUint32": atomicCas,
+	"CompareAndSwapUint64": atomicCas,
+}
+
+// simdAtomics are the simd atomic functions, the same as the sync/atomic
+// ones.
+var simdAtomics = map[string]atomicOp{
+	"AtomicAddUint32":      atomicAdd,
+	"AtomicAddUint64":      atomicAdd,
+	"AtomicLoadUint32":     atomicLoad,
+	"AtomicLoadUint64":     atomicLoad,
+	"AtomicStoreUint32":    atomicStore,
+	"AtomicStoreUint64":    atomicStore,
+	"CompareAndSwapUint32": atomicCas,
+	"CompareAndSwapUint64": atomicCas,
+}
+
+// atomicCallOp returns the operation of call if it's a sync/atomic or simd
+// atomic function computed inline.
+func atomicCallOp(call *ssa.Call) (atomicOp, bool) {
+	callee := call.Common().StaticCallee()
+	if callee == nil || callee.Pkg == nil || callee.Signature.Recv() != nil {
+		return 0, false
+	}
+	var op atomicOp
+	var ok bool
+	switch callee.Pkg.Pkg.Path() {
+	case "sync/atomic":
+		op, ok = syncAtomics[callee.Name()]
+	case "github.com/bjwbell/gensimd/simd":
+		op, ok = simdAtomics[callee.Name()]
+	}
+	return op, ok
+}
+
+// AtomicIntrinsic computes the sync/atomic or simd atomic function call
+// with LOCK XADD, XCHG, and LOCK CMPXCHG, loads are plain MOVs on amd64.
+func (f *Function) AtomicIntrinsic(call *ssa.Call, op atomicOp) (string, *Error) {
+	ctx := context{f, call}
+	args := call.Common().Args
+	size := sizeof(args[0].Type().Underlying().(*types.Pointer).Elem())
+	asm, ptr, err := f.LoadValueSimple(call, args[0])
+	if err != nil {
+		return asm, err
+	}
+	regs := []*register{ptr}
+	load := func(v ssa.Value) *register {
+		if err != nil {
+			return nil
+		}
+		a, reg, e := f.LoadValueSimple(call, v)
+		asm += a
+		err = e
+		regs = append(regs, reg)
+		return reg
+	}
+	var result *register
+	switch op {
+	default:
+		ice(fmt.Sprintf("unknown atomic op (%v)", op))
+	case atomicLoad:
+		var a string
+		a, result = f.allocIdentReg(call, f.Ident(call), DataRegSize)
+		asm += a
+		asm += MovMemReg(ctx, GetIntegerOpDataType(false, size), "", 0, ptr, result, false)
+	case atomicAdd:
+		delta := load(args[1])
+		if err != nil {
+			break
+		}
+		var a string
+		a, result = f.allocIdentReg(call, f.Ident(call), DataRegSize)
+		asm += a
+		asm += AtomicAddRegMem(ctx, size, delta, ptr, result)
+	case atomicStore:
+		v := load(args[1])
+		if err != nil {
+			break
+		}
+		a, tmp := f.allocReg(call, DATA_REG, DataRegSize)
+		asm += a
+		asm += AtomicStoreRegMem(ctx, size, v, ptr, tmp)
+		regs = append(regs, tmp)
+	case atomicCas:
+		old, val := load(args[1]), load(args[2])
+		if err != nil {
+			break
+		}
+		var a string
+		a, result = f.allocIdentReg(call, f.Ident(call), DataRegSize)
+		asm += a
+		asm += AtomicCasRegMem(ctx, size, old, val, ptr, result)
+	}
+	for _, reg := range regs {
+		if reg != nil {
+			f.freeReg(reg)
+		}
+	}
+	if err != nil {
+		return asm, err
+	}
+	if result != nil {
+		a, err := f.StoreValue(call, f.Ident(call), result)
+		f.freeReg(result)
+		if err != nil {
+			return asm, err
+		}
+		asm += a
+	}
+	asm = fmt.Sprintf("// BEGIN atomic intrinsic %v\n", call) + asm
+	asm += fmt.Sprintf("// END atomic intrinsic %v\n", call)
+	return asm, nil
+}
+
+// fence is simd.Fence, an MFENCE orders all loads and stores.
+func fence(f *Function, loc ssa.Instruction, x, y, result *identifier) (string, *Error) {
+	return fmt.Sprintf("%v\n", MFENCE), nil
+}
+
+// compilerBarrier is simd.CompilerBarrier. Loads and stores are emitted in
+// SSA order, so only the byte loads fused into one load mustn't span it,
+// see isBarrier.
+func compilerBarrier(f *Function, loc ssa.Instruction, x, y, result *identifier) (string, *Error) {
+	return "// compiler barrier\n", nil
+}
+
+// isBarrier returns whether instr is a call to simd.Fence,
+// simd.CompilerBarrier, or an atomic function.
+func isBarrier(instr ssa.Instruction) bool {
+	call, ok := instr.(*ssa.Call)
+	if !ok {
+		return false
+	}
+	if _, ok := atomicCallOp(call); ok {
+		return true
+	}
+	if !isSimdIntrinsic(call) {
+		return false
+	}
+	name, _ := simdCalleeName(call)
+	return name == "Fence" || name == "CompilerBarrier"
+}
+
+// spansBarrier returns whether a barrier is between the first of parts and
+// last, all in last's block.
+func spansBarrier(last ssa.Instruction, parts []ssa.Instruction) bool {
+	first := map[ssa.Instruction]bool{}
+	for _, part := range parts {
+		first[part] = true
+	}
+	started := false
+	for _, instr := range last.Block().Instrs {
+		if instr == last {
+			return false
+		}
+		started = started || first[instr]
+		if started && isBarrier(instr) {
+			return true
+		}
+	}
+	return false
+}
@@ -77,12 +77,20 @@ func addIndent(assembly, indent string) string {
 	return indented
 }
 
-func stripDebug(assembly, indent string) string {
+// stripComments removes the comments above level from assembly.
+func stripComments(assembly, indent string, level CommentLevel) string {
+	if level >= CommentInstructions {
+		return assembly
+	}
 	lines := strings.Split(assembly, "\n")
 	stripped := ""
+	comment := indent + "//"
 	begin := indent + "// BEGIN"
 	end := indent + "// END"
 	for _, line := range lines {
+		if level == CommentNone && strings.HasPrefix(line, comment) {
+			continue
+		}
 		// skip debug comments
 		if strings.HasPrefix(line, begin) || strings.HasPrefix(line, end) {
 			continue
@@ -0,0 +1,119 @@
+package codegen
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// VariantName returns the name of the variant of the assembly function
+// outName generated for target, e.g. "sum_avx2" for TargetAVX2.
+func VariantName(outName, target string) string {
+	return outName + "_" + strings.ToLower(TargetSuffix(target))
+}
+
+// VariantIndexName returns the name of the Go variable indexing the variant
+// the dispatch stub of outName jumps to, e.g. "sum_variant".
+func VariantIndexName(outName string) string {
+	return outName + "_variant"
+}
+
+// sortedVariants returns targets from the lowest to the highest level.
+func sortedVariants(targets []string) []string {
+	sorted := append([]string{}, targets...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return targetLevel(sorted[i]) < targetLevel(sorted[j])
+	})
+	return sorted
+}
+
+// checkVariants returns an error if variants has an invalid or repeated
+// target.
+func checkVariants(variants []string) *Error {
+	seen := map[string]bool{}
+	for _, target := range variants {
+		if targetLevel(target) < 0 {
+			msg := "Invalid variant target \"%v\", expected one of %v"
+			return ErrorMsg2(fmt.Sprintf(msg, target, strings.Join(targets, ", ")))
+		}
+		if seen[target] {
+			return ErrorMsg2(fmt.Sprintf("Variant target \"%v\" repeated", target))
+		}
+		seen[target] = true
+	}
+	return nil
+}
+
+// createVariants creates a function with f's settings for each variant of
+// f, in increasing target order.
+func (f *Function) createVariants() ([]*Function, *Error) {
+	variants := []*Function{}
+	for _, target := range sortedVariants(f.opts.Variants) {
+		opts := f.opts
+		opts.OutName = VariantName(f.outfname(), target)
+		opts.Target = target
+		opts.Variants = nil
+		v, err := CreateFunction(f.ssa, opts)
+		if err != nil {
+			return nil, err
+		}
+		v.Debug, v.PrintSpills, v.Trace, v.Optimize = f.Debug, f.PrintSpills, f.Trace, f.Optimize
+		v.NoAlias, v.Aligned, v.Pinned, v.BlockFreqs, v.Cache = f.NoAlias, f.Aligned, f.Pinned, f.BlockFreqs, f.Cache
+		v.DumpAfter, v.DumpSSA, v.DumpLiveness, v.DumpFrames, v.DumpPressure, v.DumpWriter = f.DumpAfter, f.DumpSSA, f.DumpLiveness, f.DumpFrames, f.DumpPressure, f.DumpWriter
+		v.passes = append([]Pass{}, f.passes...)
+		variants = append(variants, v)
+	}
+	return variants, nil
+}
+
+// variantsAssembly generates the variants of f and the dispatch stub named
+// f's output name. The stub jumps to the variant indexed by the Go variable
+// VariantIndexName, through a table of their addresses, without a frame so
+// the variant gets the stub's arguments. The variable is declared by
+// GoProto, 0 is the lowest variant.
+func (f *Function) variantsAssembly() (string, *Error) {
+	variants, err := f.createVariants()
+	if err != nil {
+		return "", err
+	}
+	asm := ""
+	for _, v := range variants {
+		a, err := v.GoAssembly()
+		if err != nil {
+			return asm, err
+		}
+		asm += a + "\n"
+	}
+	table := f.outfname() + "_variants"
+	for i, v := range variants {
+		asm += fmt.Sprintf("DATA %v<>+%v(SB)/8, $·%v(SB)\n", table, 8*i, v.outfname())
+	}
+	asm += fmt.Sprintf("GLOBL %v<>(SB), RODATA, $%v\n\n", table, 8*len(variants))
+	stub := fmt.Sprintf("// jump to the variant indexed by %v\n", VariantIndexName(f.outfname()))
+	stub += fmt.Sprintf("%-9v    ·%v(SB), AX\n", MOVQ, VariantIndexName(f.outfname()))
+	stub += fmt.Sprintf("%-9v    %v<>(SB), CX\n", LEAQ, table)
+	stub += fmt.Sprintf("%-9v    (CX)(AX*8), AX\n", MOVQ)
+	stub += fmt.Sprintf("%-9v    AX\n", "JMP")
+	f.variants = variants
+	f.frameSize = 0
+	f.argsSize = variants[0].argsSize
+	asm += fmt.Sprintf("TEXT ·%v(SB),NOSPLIT|NOFRAME,$0-%v\n", f.outfname(), f.argsSize)
+	asm += stripComments(addIndent(stub, f.opts.Indent), f.opts.Indent, f.opts.CommentLevel)
+	f.asm = asm
+	return asm, nil
+}
+
+// variantsProto returns the declarations of the variants of f, from proto
+// the declaration of f, and of the variable indexing the one the dispatch
+// stub jumps to, the highest the CPU supports.
+func (f *Function) variantsProto(proto string) string {
+	protos := ""
+	targets := []string{}
+	for _, target := range sortedVariants(f.opts.Variants) {
+		protos += strings.Replace(proto, "func "+f.outfname()+"(", "func "+VariantName(f.outfname(), target)+"(", 1)
+		targets = append(targets, fmt.Sprintf("%q", target))
+	}
+	protos += fmt.Sprintf("\n// %v indexes the variant of %v the CPU supports\n", VariantIndexName(f.outfname()), f.outfname())
+	protos += fmt.Sprintf("var %v = simd.Variant(%v)\n", VariantIndexName(f.outfname()), strings.Join(targets, ", "))
+	return protos
+}
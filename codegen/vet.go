@@ -0,0 +1,236 @@
+package codegen
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"strconv"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/asmdecl"
+)
+
+// argSlot is a parameter or result, or a component of one, named like the
+// asmdecl vet check names them, e.g. "x_len" for the length of slice x.
+type argSlot struct {
+	name string
+	// off is the offset from FP
+	off  int
+	size int
+	// composite is set for arrays and structs, only an operand without a
+	// size, e.g. of MOVUPS or LEAQ, may refer to them as a whole
+	composite bool
+}
+
+// fpName returns the name of the FP operand of instr at offset of the
+// parameter or result name, the innermost component of it at offset with
+// the operand size, e.g. "x_len" for the 8 bytes at 8 of slice x, or name
+// if there's no component at offset the size of the operand so asmdecl
+// reports it.
+func (f *Function) fpName(instr Instruction, name string, offset int) string {
+	if f == nil {
+		return name
+	}
+	ident, ok := f.identifiers[name]
+	if !ok || !(ident.isParam() || ident.isRetIdent()) {
+		return name
+	}
+	size := asmOperandSize(instr)
+	for _, slot := range appendArgSlots(nil, gcSizes, name, ident.typ, ident.offset) {
+		if slot.off != offset {
+			continue
+		}
+		if size == 0 {
+			return slot.name
+		}
+		if slot.size == size && !slot.composite {
+			return slot.name
+		}
+	}
+	return name
+}
+
+// fpMove returns the move for a 16 byte value in the argument frame,
+// MOVUPS for the 16 byte moves asmdecl checks, MOVOU and MOVUPD, which it
+// rejects for arrays. The aligned moves are replaced too since arguments
+// are only 8 byte aligned.
+func fpMove(instr Instruction) Instruction {
+	switch instr {
+	case MOVO, MOVOU, MOVAPS, MOVAPD, MOVUPD:
+		return MOVUPS
+	}
+	return instr
+}
+
+// argCopySize returns the size of the chunks of a copy of a value of type t
+// to or from the argument frame, the element size of arrays if it's below
+// size, otherwise size.
+func argCopySize(t types.Type, size uint) uint {
+	for {
+		a, ok := t.Underlying().(*types.Array)
+		if !ok {
+			break
+		}
+		t = a.Elem()
+	}
+	if elem := uint(gcSizes.Sizeof(t)); elem < size {
+		return elem
+	}
+	return size
+}
+
+// asmOperandSize returns the size in bytes asmdecl infers for the memory
+// operand of instr, 0 if it doesn't check the size, e.g. for MOVUPS, and
+// for LEAQ whose operand is only an address. Zero and sign extending moves,
+// e.g. MOVLQZX, aren't checked either, their source size is returned.
+func asmOperandSize(instr Instruction) int {
+	op := instr.String()
+	switch {
+	case instr == LEAQ:
+		return 0
+	case strings.HasPrefix(op, "MOV") && (strings.HasSuffix(op, "ZX") || strings.HasSuffix(op, "SX")) && len(op) == 7:
+		return map[byte]int{'B': 1, 'W': 2, 'L': 4}[op[3]]
+	case strings.HasPrefix(op, "P") && strings.HasSuffix(op, "RD"):
+		return 4
+	case strings.HasSuffix(op, "SD"):
+		return 8
+	case strings.HasSuffix(op, "SS"):
+		return 4
+	case op == "MOVO" || op == "MOVOU":
+		return 16
+	case strings.HasPrefix(op, "SET"):
+		return 1
+	}
+	switch op[len(op)-1] {
+	case 'B':
+		return 1
+	case 'W':
+		return 2
+	case 'L':
+		return 4
+	case 'D', 'Q':
+		return 8
+	}
+	return 0
+}
+
+// Vet checks the assembly of f against its Go declaration with the asmdecl
+// vet check, returning a diagnostic for each wrong argument size, parameter
+// or result offset, and operand size. GoAssembly must have succeeded. The
+// diagnostic positions are lines of the assembly GoAssembly returned, in a
+// file named "<OutName>_amd64.s".
+// The assembly is checked as generated, its FP operands are named by fpName.
+func (f *Function) Vet() ([]Diagnostic, *Error) {
+	if f.asm == "" {
+		return nil, ErrorMsg2("Vet requires the assembly, call GoAssembly first")
+	}
+	if f.variants != nil {
+		// the dispatch stub has no arguments to check
+		diagnostics := []Diagnostic{}
+		for _, v := range f.variants {
+			d, err := v.Vet()
+			if err != nil {
+				return nil, err
+			}
+			diagnostics = append(diagnostics, d...)
+		}
+		return diagnostics, nil
+	}
+	sizes := types.SizesFor("gc", DefaultArch)
+	asm := stripComments(f.asm, f.opts.Indent, f.opts.CommentLevel)
+
+	fset := token.NewFileSet()
+	pkgname, _, proto := f.GoProto()
+	file, err := parser.ParseFile(fset, f.outfname()+".go", pkgname+proto, 0)
+	if err != nil {
+		return nil, ErrorMsg2(fmt.Sprintf("Vet couldn't parse the declaration \"%v\", %v", strings.TrimSpace(proto), err))
+	}
+	info := &types.Info{Types: map[ast.Expr]types.TypeAndValue{}}
+	decl := file.Decls[0].(*ast.FuncDecl)
+	setFieldTypes(info, decl.Type.Params, f.ssa.Signature.Params())
+	setFieldTypes(info, decl.Type.Results, f.ssa.Signature.Results())
+
+	asmName := f.outfname() + "_" + DefaultArch + ".s"
+	diagnostics := []Diagnostic{}
+	pkg := f.ssa.Package().Pkg
+	pass := &analysis.Pass{
+		Analyzer:   asmdecl.Analyzer,
+		Fset:       fset,
+		Files:      []*ast.File{file},
+		OtherFiles: []string{asmName},
+		Pkg:        types.NewPackage(pkg.Path(), pkg.Name()),
+		TypesInfo:  info,
+		TypesSizes: sizes,
+		ResultOf:   map[*analysis.Analyzer]interface{}{},
+		ReadFile: func(string) ([]byte, error) {
+			return []byte(asm), nil
+		},
+		Report: func(d analysis.Diagnostic) {
+			diagnostics = append(diagnostics, Diagnostic{Pos: fset.Position(d.Pos), Msg: d.Message})
+		},
+	}
+	if _, err := asmdecl.Analyzer.Run(pass); err != nil {
+		return nil, ErrorMsg2(fmt.Sprintf("Vet error, %v", err))
+	}
+	return diagnostics, nil
+}
+
+// appendArgSlots appends the slot of name at off followed by its components.
+func appendArgSlots(slots []argSlot, sizes types.Sizes, name string, t types.Type, off int) []argSlot {
+	composite := false
+	switch t.Underlying().(type) {
+	case *types.Array, *types.Struct:
+		composite = true
+	}
+	slots = append(slots, argSlot{name, off, int(sizes.Sizeof(t)), composite})
+	word := int(sizes.Sizeof(types.Typ[types.Uintptr]))
+	switch u := t.Underlying().(type) {
+	case *types.Basic:
+		switch {
+		case u.Info()&types.IsString != 0:
+			slots = append(slots, argSlot{name + "_base", off, word, false}, argSlot{name + "_len", off + word, word, false})
+		case u.Info()&types.IsComplex != 0:
+			half := int(sizes.Sizeof(u)) / 2
+			slots = append(slots, argSlot{name + "_real", off, half, false}, argSlot{name + "_imag", off + half, half, false})
+		}
+	case *types.Slice:
+		slots = append(slots, argSlot{name + "_base", off, word, false}, argSlot{name + "_len", off + word, word, false},
+			argSlot{name + "_cap", off + 2*word, word, false})
+	case *types.Array:
+		elem := int(sizes.Sizeof(u.Elem()))
+		for i := 0; i < int(u.Len()); i++ {
+			slots = appendArgSlots(slots, sizes, name+"_"+strconv.Itoa(i), u.Elem(), off+i*elem)
+		}
+	case *types.Struct:
+		fields := make([]*types.Var, u.NumFields())
+		for i := range fields {
+			fields[i] = u.Field(i)
+		}
+		offsets := sizes.Offsetsof(fields)
+		for i, field := range fields {
+			slots = appendArgSlots(slots, sizes, name+"_"+field.Name(), field.Type(), off+int(offsets[i]))
+		}
+	}
+	return slots
+}
+
+// setFieldTypes sets the types of the parameter or result fields of a
+// declaration parsed from GoProto to the types of tuple, each name of a
+// field is one element of tuple.
+func setFieldTypes(info *types.Info, fields *ast.FieldList, tuple *types.Tuple) {
+	if fields == nil {
+		return
+	}
+	i := 0
+	for _, field := range fields.List {
+		info.Types[field.Type] = types.TypeAndValue{Type: tuple.At(i).Type()}
+		if len(field.Names) == 0 {
+			i++
+		} else {
+			i += len(field.Names)
+		}
+	}
+}
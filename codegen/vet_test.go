@@ -0,0 +1,60 @@
+package codegen
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestVet checks the FP operands are generated with asmdecl's names so the
+// assembly vets as is, and that a wrong name or offset is reported.
+func TestVet(t *testing.T) {
+	const src = `package src
+
+func f(x []int32, a [4]int32, i int) int32 {
+	return x[i] + a[1] + int32(len(x))
+}
+`
+	fn, err := CreateFunction(buildFunc(t, src, "f"), DefaultOptions())
+	if err != nil {
+		t.Fatal(err.Err)
+	}
+	asm, err := fn.GoAssembly()
+	if err != nil {
+		t.Fatal(err.Err)
+	}
+	for _, operand := range []string{"a_0+24(FP)", "x_base+0(FP)", "x_len+8(FP)", "a_1+28(FP)", "i+40(FP)", "ret+48(FP)"} {
+		if !strings.Contains(asm, operand) {
+			t.Errorf("expected the operand %v in\n%v", operand, asm)
+		}
+	}
+	diagnostics, err := fn.Vet()
+	if err != nil {
+		t.Fatal(err.Err)
+	}
+	if len(diagnostics) != 0 {
+		t.Errorf("Vet() = %v, expected no diagnostics", diagnostics)
+	}
+
+	for _, test := range []struct {
+		old, new, msg string
+	}{
+		// the length read at the offset of the capacity, and named as it
+		{"x_len+8(FP)", "x_len+16(FP)", "invalid offset x_len+16(FP); expected x_len+8(FP)"},
+		{"x_len+8(FP)", "x_cap+8(FP)", "invalid offset x_cap+8(FP); expected x_cap+16(FP)"},
+		{"ret+48(FP)", "ret0+48(FP)", "unknown variable ret0"},
+		{"MOVLQZX      a_1+28(FP)", "MOVQ         a_1+28(FP)", "invalid MOVQ of a_1+28(FP); int32 is 4-byte value"},
+	} {
+		fn.asm = strings.Replace(asm, test.old, test.new, 1)
+		diagnostics, err := fn.Vet()
+		if err != nil {
+			t.Fatal(err.Err)
+		}
+		found := false
+		for _, d := range diagnostics {
+			found = found || strings.Contains(d.Msg, test.msg)
+		}
+		if !found {
+			t.Errorf("Vet() of %v replaced by %v = %v, expected %q", test.old, test.new, diagnostics, test.msg)
+		}
+	}
+}
@@ -0,0 +1,124 @@
+package codegen
+
+import (
+	"go/types"
+
+	"golang.org/x/tools/go/ssa"
+)
+
+// writtenBeforeRead returns true if every byte of local is stored to in the
+// block of local before it's read, so zeroing it in the prologue is useless.
+// A local is written by storing to it or by storing to each of its array
+// elements or struct fields, e.g. the composite literal "simd.I32x4{a, b, c, d}".
+func writtenBeforeRead(local *ssa.Alloc) bool {
+	typ := local.Type().Underlying().(*types.Pointer).Elem()
+	elems := -1
+	switch t := typ.Underlying().(type) {
+	case *types.Array:
+		elems = int(t.Len())
+	case *types.Struct:
+		elems = t.NumFields()
+	}
+	// element addresses only stored to, indexed by the address
+	elemAddrs := map[ssa.Value]int{}
+	written := map[int]bool{}
+	block := local.Block()
+	start := -1
+	for i, instr := range block.Instrs {
+		if instr == local {
+			start = i + 1
+			break
+		}
+	}
+	if start < 0 {
+		return false
+	}
+	for _, instr := range block.Instrs[start:] {
+		switch instr := instr.(type) {
+		case *ssa.DebugRef:
+			continue
+		case *ssa.Store:
+			if instr.Val == local {
+				return false
+			}
+			if instr.Addr == local {
+				return true
+			}
+			if elem, ok := elemAddrs[instr.Addr]; ok {
+				written[elem] = true
+				if len(written) == elems {
+					return true
+				}
+				continue
+			}
+		case *ssa.IndexAddr:
+			if instr.X == local {
+				cnst, ok := instr.Index.(*ssa.Const)
+				if !ok || !onlyStoredTo(instr) {
+					return false
+				}
+				elemAddrs[instr] = int(cnst.Int64())
+				continue
+			}
+		case *ssa.FieldAddr:
+			if instr.X == local {
+				if !onlyStoredTo(instr) {
+					return false
+				}
+				elemAddrs[instr] = instr.Field
+				continue
+			}
+		}
+		for _, op := range instr.Operands(nil) {
+			if *op == local {
+				return false
+			}
+		}
+	}
+	return false
+}
+
+// zeroedAtAlloc returns true if local is zeroed where it's allocated instead
+// of in the prologue. Go zeroes a local each time its allocation runs, one
+// outside the entry block may run more than once, e.g. "var tmp [4]byte" in
+// a loop body.
+func zeroedAtAlloc(local *ssa.Alloc) bool {
+	return local.Block() != local.Parent().Blocks[0]
+}
+
+// onlyStoredTo returns true if the address addr is only used to store to.
+func onlyStoredTo(addr ssa.Value) bool {
+	for _, ref := range *addr.Referrers() {
+		switch ref := ref.(type) {
+		case *ssa.DebugRef:
+		case *ssa.Store:
+			if ref.Addr != addr || ref.Val == addr {
+				return false
+			}
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// retWrittenOnAllPaths returns true if every path returning from f stores the
+// whole return value, so zeroing it in the prologue is useless. Paths ending
+// in a panic don't return.
+func (f *Function) retWrittenOnAllPaths() bool {
+	for _, block := range f.ssa.Blocks {
+		if len(block.Succs) != 0 || len(block.Instrs) == 0 {
+			continue
+		}
+		switch instr := block.Instrs[len(block.Instrs)-1].(type) {
+		case *ssa.Return:
+			if len(instr.Results) != 1 || sizeof(instr.Results[0].Type()) != f.retSize() {
+				return false
+			}
+		case *ssa.Panic:
+		default:
+			return false
+		}
+	}
+	return true
+}
@@ -1,252 +1,252 @@
-// +build amd64 !noasm !appengine
+// Code generated by gensimd. DO NOT EDIT.
+// gensimd version: devel
+// gensimd command: gensimd -f distsq_simd.go -fn distsq -goprotofile distsq_simd_proto.go -o distsq_amd64.s -outfn distsq
+// gensimd source: distsq_simd.go sha256:31748972d65df366ccb196cfc0fc103c5932d52b79071a49bccfdda7e8fdcc7a
+// gensimd target: avx2
+
+//go:build amd64 && !noasm && !appengine
+// +build amd64,!noasm,!appengine
 
 #include "textflag.h"
 
-TEXT ·distsq(SB),$384-52
-        MOVL         $0, ret0+48(FP)
-        MOVQ         $0, t26-16(SP)
-        MOVQ         $0, t26-8(SP)
+TEXT ·distsq(SB),$416-52
 block0:
-        MOVQ         x+8(FP), R15
-        MOVQ         R15, R14
-        MOVQ         y+32(FP), R13
-        MOVQ         R13, R12
-        CMPQ         R14, R12
-        SETNE        R11
-        MOVB         R11, t2-33(SP)
-        CMPB         R11, $0
+        // entry
+        MOVQ         x_len+8(FP), R15
+        MOVQ         R15, R13
+        MOVQ         y_len+32(FP), R12
+        MOVQ         R12, R11
+        CMPQ         R13, R11
         JEQ          block2
-        JMP          block1
 block1:
+        // if.then, preds block0
         MOVL         $-1, R15
-        MOVL         R15, ret0+48(FP)
+        MOVL         R15, ret+48(FP)
         RET
 block2:
-        MOVQ         x+8(FP), R14
-        MOVQ         R14, R13
-        MOVL         $2147483647, R12
-        MOVL         R12, t4-45(SP)
-        MOVQ         $0, R11
-        MOVQ         R11, t5-53(SP)
-        MOVQ         R13, t3-41(SP)
-        JMP block5
+        // if.done, preds block0
+        MOVQ         x_len+8(FP), R13
+        MOVQ         R13, R12
+        MOVQ         R12, BX
+        MOVL         $2147483647, R11
+        MOVL         R11, t4-77(SP)
+        MOVQ         $0, R10
+        MOVQ         R10, t5-85(SP)
+        MOVQ         x_base+0(FP), R9
+        IMUL3Q       $16, R10, BP
+        ADDQ         BP, R9
+        MOVQ         R9, ivptr1-32(SP)
+        MOVQ         y_base+24(FP), R9
+        IMUL3Q       $16, R10, BP
+        ADDQ         BP, R9
+        MOVQ         R9, ivptr3-48(SP)
+        MOVQ         R12, t3-73(SP)
 block3:
-        MOVLQZX      t4-45(SP), R15
-        MOVL         R15, t9-57(SP)
-        MOVQ         $0, R14
-        MOVQ         R14, t10-65(SP)
-        JMP block8
+        // for.loop, preds block2 block9
+        MOVQ         t5-85(SP), R8
+        MOVQ         R8, R15
+        MOVQ         BX, R13
+        CMPQ         R15, R13
+        JGE          block5
 block4:
-        MOVLQZX      t4-45(SP), R15
-        MOVL         R15, ret0+48(FP)
-        RET
-block5:
-        MOVQ         t5-53(SP), R14
-        MOVQ         t3-41(SP), R13
-        CMPQ         R14, R13
-        SETLT        R15
-        MOVB         R15, t6-66(SP)
-        CMPB         R15, $0
-        JEQ          block4
-        JMP          block3
+        // for.body, preds block3
+        MOVLQZX      t4-77(SP), R15
+        MOVL         R15, t7-90(SP)
+        MOVQ         $0, R13
+        MOVQ         R13, t8-98(SP)
+        MOVQ         x_base+0(FP), R12
+        IMUL3Q       $16, R13, R11
+        ADDQ         R11, R12
+        MOVQ         R12, ivptr0-24(SP)
+        MOVQ         y_base+24(FP), R12
+        IMUL3Q       $16, R13, R11
+        ADDQ         R11, R12
+        MOVQ         R12, ivptr2-40(SP)
 block6:
-        MOVQ         t5-53(SP), R14
-        MOVQ         t10-65(SP), R13
-        CMPQ         R14, R13
-        SETEQ        R15
-        MOVB         R15, t7-67(SP)
-        CMPB         R15, $0
-        JEQ          block10
-        MOVLQZX      t9-57(SP), R15
-        MOVL         R15, t12-71(SP)
-        JMP          block9
+        // for.loop, preds block4 block8
+        MOVQ         t8-98(SP), R15
+        MOVQ         BX, R13
+        CMPQ         R15, R13
+        JGE          block9
 block7:
-        MOVQ         t5-53(SP), R14
-        MOVQ         $1, R13
-        MOVQ         R14, R15
-        ADDQ         R13, R15
-        MOVLQZX      t9-57(SP), R12
-        MOVL         R12, t4-45(SP)
-        MOVQ         R15, t5-53(SP)
-        MOVQ         R15, t8-79(SP)
-        JMP block5
+        // for.body, preds block6
+        MOVQ         R8, R15
+        MOVQ         t8-98(SP), R13
+        CMPQ         R15, R13
+        JNE          block10
+        MOVLQZX      t7-90(SP), R15
+        MOVL         R15, t11-104(SP)
 block8:
-        MOVQ         t10-65(SP), R14
-        MOVQ         t3-41(SP), R13
-        CMPQ         R14, R13
-        SETLT        R15
-        MOVB         R15, t11-80(SP)
-        CMPB         R15, $0
-        JEQ          block7
-        JMP          block6
+        // for.post, preds block7 block16 block17
+        MOVQ         t8-98(SP), R15
+        MOVQ         R15, R13
+        ADDQ         $1, R13
+        MOVLQZX      t11-104(SP), R12
+        MOVL         R12, t7-90(SP)
+        MOVQ         R13, t8-98(SP)
+        MOVQ         ivptr0-24(SP), R15
+        LEAQ         16(R15), R15
+        MOVQ         R15, ivptr0-24(SP)
+        MOVQ         ivptr2-40(SP), R15
+        LEAQ         16(R15), R15
+        MOVQ         R15, ivptr2-40(SP)
+        MOVQ         R13, t12-112(SP)
+        JMP block6
+block5:
+        // for.done, preds block3
+        MOVLQZX      t4-77(SP), R15
+        MOVL         R15, ret+48(FP)
+        RET
 block9:
-        MOVQ         t10-65(SP), R14
-        MOVQ         $1, R13
-        MOVQ         R14, R15
-        ADDQ         R13, R15
-        MOVLQZX      t12-71(SP), R12
-        MOVL         R12, t9-57(SP)
-        MOVQ         R15, t10-65(SP)
-        MOVQ         R15, t13-88(SP)
-        JMP block8
+        // for.done, preds block6
+        MOVQ         R8, R15
+        MOVQ         R15, R13
+        ADDQ         $1, R13
+        MOVLQZX      t7-90(SP), R12
+        MOVL         R12, t4-77(SP)
+        MOVQ         R13, t5-85(SP)
+        MOVQ         ivptr1-32(SP), R15
+        LEAQ         16(R15), R15
+        MOVQ         R15, ivptr1-32(SP)
+        MOVQ         ivptr3-48(SP), R15
+        LEAQ         16(R15), R15
+        MOVQ         R15, ivptr3-48(SP)
+        MOVQ         R13, t13-120(SP)
+        JMP block3
 block10:
-        MOVQ         t10-65(SP), R14
-        IMUL3Q       $16, R14, R14
-        MOVQ         x+0(FP), R15
-        ADDQ         R14, R15
-        MOVQ         R15, R14
-        MOVUPS       (R14), X15
-        MOVUPS       X15, t15-112(SP)
-        MOVQ         t5-53(SP), R13
-        IMUL3Q       $16, R13, R13
-        MOVQ         x+0(FP), R14
-        ADDQ         R13, R14
-        MOVQ         R14, R13
-        MOVUPS       (R13), X15
-        MOVUPS       X15, t17-136(SP)
-        MOVOU        t17-136(SP), X15
-        MOVOU        t15-112(SP), X14
-        PSUBL        X15, X14
-        MOVQ         t10-65(SP), R12
-        IMUL3Q       $16, R12, R12
-        MOVQ         y+24(FP), R13
-        ADDQ         R12, R13
+        // if.done, preds block7
+        MOVQ         ivptr0-24(SP), R15
+        MOVQ         R15, R13
         MOVQ         R13, R12
-        MOVUPS       (R12), X13
-        MOVUPS       X13, t20-176(SP)
-        MOVQ         t5-53(SP), R11
-        IMUL3Q       $16, R11, R11
-        MOVQ         y+24(FP), R12
-        ADDQ         R11, R12
+        MOVOU        (R12), X14
+        MOVOU        X14, t15-144(SP)
+        MOVQ         ivptr1-32(SP), R12
         MOVQ         R12, R11
-        MOVUPS       (R11), X13
-        MOVUPS       X13, t22-200(SP)
-        MOVOU        t22-200(SP), X13
-        MOVOU        t20-176(SP), X12
-        PSUBL        X13, X12
-        MOVO         X14, X11
-        PMULULQ      X14, X11
-        MOVOU        X14, t18-152(SP)
-        PSRLO        $4, X14
-        MOVO         X14, X10
-        PMULULQ      X14, X10
-        PSHUFD       $8, X11, X9
+        MOVQ         R11, R10
+        MOVOU        (R10), X14
+        MOVOU        X14, t17-168(SP)
+        MOVOU        t17-168(SP), X14
+        MOVOU        t15-144(SP), X13
+        PSUBL        X14, X13
+        MOVQ         ivptr2-40(SP), R10
+        MOVQ         R10, R9
+        MOVQ         R9, BP
+        MOVOU        (BP), X12
+        MOVOU        X12, t20-208(SP)
+        MOVQ         ivptr3-48(SP), BP
+        MOVQ         BP, DI
+        MOVQ         DI, SI
+        MOVOU        (SI), X12
+        MOVOU        X12, t22-232(SP)
+        MOVOU        t22-232(SP), X12
+        MOVOU        t20-208(SP), X11
+        PSUBL        X12, X11
+        MOVO         X13, X10
+        PMULULQ      X13, X10
+        MOVOU        X13, t18-184(SP)
+        PSRLO        $4, X13
+        MOVO         X13, X9
+        PMULULQ      X13, X9
         PSHUFD       $8, X10, X8
-        PUNPCKLLQ    X8, X9
-        MOVO         X12, X14
-        PMULULQ      X12, X14
-        MOVOU        X12, t23-216(SP)
-        PSRLO        $4, X12
-        MOVO         X12, X11
-        PMULULQ      X12, X11
-        PSHUFD       $8, X14, X10
-        PSHUFD       $8, X11, X8
-        PUNPCKLLQ    X8, X10
-        MOVOU        X9, t24-232(SP)
-        PADDL        X10, X9
-        MOVO         X9, X14
-        MOVOU        X14, t26-16(SP)
-        MOVQ         $0, R10
-        IMUL3Q       $4, R10, R10
-        LEAQ         t26-16(SP), R11
-        ADDQ         R10, R11
-        MOVL         (R11), R10
-        MOVL         R10, t29-276(SP)
-        MOVLQZX      t29-276(SP), R9
-        MOVLQZX      t9-57(SP), R8
-        CMPL         R9, R8
-        SETLT        R10
-        MOVL         R8, t33-281(SP)
-        MOVB         R10, t30-277(SP)
-        CMPB         R10, $0
+        PSHUFD       $8, X9, X7
+        PUNPCKLLQ    X7, X8
+        MOVO         X11, X13
+        PMULULQ      X11, X13
+        MOVOU        X11, t23-248(SP)
+        PSRLO        $4, X11
+        MOVO         X11, X10
+        PMULULQ      X11, X10
+        PSHUFD       $8, X13, X9
+        PSHUFD       $8, X10, X7
+        PUNPCKLLQ    X7, X9
+        MOVOU        X8, t24-264(SP)
+        PADDL        X9, X8
+        MOVOU        X8, t26-16(SP)
+        LEAQ         t26-16(SP), SI
+        MOVQ         SI, t28-304(SP)
+        MOVQ         t28-304(SP), BP
+        MOVL         (BP), SI
+        MOVL         SI, t29-308(SP)
+        MOVLQZX      t29-308(SP), R9
+        MOVLQZX      t7-90(SP), R10
+        CMPL         R9, R10
+        SETLT        DI
+        MOVL         R10, t33-325(SP)
+        MOVB         DI, t30-309(SP)
+        CMPB         DI, $0
         JEQ          block12
-        JMP          block11
 block11:
-        MOVQ         $0, R14
-        IMUL3Q       $4, R14, R14
+        // if.then, preds block10
         LEAQ         t26-16(SP), R15
-        ADDQ         R14, R15
-        MOVL         (R15), R14
-        MOVL         R14, t32-293(SP)
-        MOVLQZX      t32-293(SP), R14
-        MOVL         R14, t33-281(SP)
-        JMP block12
+        MOVL         (R15), R13
+        MOVL         R13, t32-321(SP)
+        MOVLQZX      t32-321(SP), R13
+        MOVL         R13, t33-325(SP)
 block12:
-        MOVQ         $1, R14
-        IMUL3Q       $4, R14, R14
+        // if.done, preds block10 block11
         LEAQ         t26-16(SP), R15
-        ADDQ         R14, R15
-        MOVL         (R15), R14
-        MOVL         R14, t35-305(SP)
-        MOVLQZX      t35-305(SP), R13
-        MOVLQZX      t33-281(SP), R12
+        ADDQ         $4, R15
+        MOVL         (R15), R13
+        MOVL         R13, t35-337(SP)
+        MOVLQZX      t35-337(SP), R13
+        MOVLQZX      t33-325(SP), R12
         CMPL         R13, R12
-        SETLT        R14
-        MOVL         R12, t39-310(SP)
-        MOVB         R14, t36-306(SP)
-        CMPB         R14, $0
+        SETLT        R11
+        MOVL         R12, t39-354(SP)
+        MOVB         R11, t36-338(SP)
+        CMPB         R11, $0
         JEQ          block14
-        JMP          block13
 block13:
-        MOVQ         $1, R14
-        IMUL3Q       $4, R14, R14
+        // if.then, preds block12
         LEAQ         t26-16(SP), R15
-        ADDQ         R14, R15
-        MOVL         (R15), R14
-        MOVL         R14, t38-322(SP)
-        MOVLQZX      t38-322(SP), R14
-        MOVL         R14, t39-310(SP)
-        JMP block14
+        ADDQ         $4, R15
+        MOVL         (R15), R13
+        MOVL         R13, t38-350(SP)
+        MOVLQZX      t38-350(SP), R13
+        MOVL         R13, t39-354(SP)
 block14:
-        MOVQ         $2, R14
-        IMUL3Q       $4, R14, R14
+        // if.done, preds block12 block13
         LEAQ         t26-16(SP), R15
-        ADDQ         R14, R15
-        MOVL         (R15), R14
-        MOVL         R14, t41-334(SP)
-        MOVLQZX      t41-334(SP), R13
-        MOVLQZX      t39-310(SP), R12
+        ADDQ         $8, R15
+        MOVL         (R15), R13
+        MOVL         R13, t41-366(SP)
+        MOVLQZX      t41-366(SP), R13
+        MOVLQZX      t39-354(SP), R12
         CMPL         R13, R12
-        SETLT        R14
-        MOVL         R12, t45-339(SP)
-        MOVB         R14, t42-335(SP)
-        CMPB         R14, $0
+        SETLT        R11
+        MOVL         R12, t45-383(SP)
+        MOVB         R11, t42-367(SP)
+        CMPB         R11, $0
         JEQ          block16
-        JMP          block15
 block15:
-        MOVQ         $2, R14
-        IMUL3Q       $4, R14, R14
+        // if.then, preds block14
         LEAQ         t26-16(SP), R15
-        ADDQ         R14, R15
-        MOVL         (R15), R14
-        MOVL         R14, t44-351(SP)
-        MOVLQZX      t44-351(SP), R14
-        MOVL         R14, t45-339(SP)
-        JMP block16
+        ADDQ         $8, R15
+        MOVL         (R15), R13
+        MOVL         R13, t44-379(SP)
+        MOVLQZX      t44-379(SP), R13
+        MOVL         R13, t45-383(SP)
 block16:
-        MOVQ         $3, R14
-        IMUL3Q       $4, R14, R14
+        // if.done, preds block14 block15
         LEAQ         t26-16(SP), R15
-        ADDQ         R14, R15
-        MOVL         (R15), R14
-        MOVL         R14, t47-363(SP)
-        MOVLQZX      t47-363(SP), R13
-        MOVLQZX      t45-339(SP), R12
+        ADDQ         $12, R15
+        MOVL         (R15), R13
+        MOVL         R13, t47-395(SP)
+        MOVLQZX      t47-395(SP), R13
+        MOVLQZX      t45-383(SP), R12
         CMPL         R13, R12
-        SETLT        R14
-        MOVL         R12, t12-71(SP)
-        MOVB         R14, t48-364(SP)
-        CMPB         R14, $0
-        JEQ          block9
-        JMP          block17
+        SETLT        R11
+        MOVL         R12, t11-104(SP)
+        MOVB         R11, t48-396(SP)
+        CMPB         R11, $0
+        JEQ          block8
 block17:
-        MOVQ         $3, R14
-        IMUL3Q       $4, R14, R14
+        // if.then, preds block16
         LEAQ         t26-16(SP), R15
-        ADDQ         R14, R15
-        MOVL         (R15), R14
-        MOVL         R14, t50-376(SP)
-        MOVLQZX      t50-376(SP), R14
-        MOVL         R14, t12-71(SP)
-        JMP block9
+        ADDQ         $12, R15
+        MOVL         (R15), R13
+        MOVL         R13, t50-408(SP)
+        MOVLQZX      t50-408(SP), R13
+        MOVL         R13, t11-104(SP)
+        JMP block8
 
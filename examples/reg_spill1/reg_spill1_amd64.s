@@ -1,45 +1,40 @@
-// +build amd64 !noasm !appengine
+// Code generated by gensimd. DO NOT EDIT.
+// gensimd version: devel
+// gensimd command: gensimd -f reg_spill1_simd.go -fn regspill1 -goprotofile reg_spill1_simd_proto.go -o reg_spill1_amd64.s -outfn regspill1
+// gensimd source: reg_spill1_simd.go sha256:378f0d994c1d1585132b888daea3c025e84009ec07a5e42ede24836484390e1e
+// gensimd target: avx2
+
+//go:build amd64 && !noasm && !appengine
+// +build amd64,!noasm,!appengine
 
 #include "textflag.h"
 
 TEXT ·regspill1(SB),$40-12
-        MOVL         $0, ret0+8(FP)
 block0:
-        MOVLQZX      x+0(FP), R14
-        MOVL         R14, R15
-        ADDL         R14, R15
+        // entry
+        MOVLQZX      x+0(FP), R15
+        MOVL         R15, R13
+        ADDL         R15, R13
         MOVLQZX      y+4(FP), R12
-        MOVL         R12, R13
-        ADDL         R12, R13
-        MOVL         R15, R11
-        MOVL         R11, AX
-        IMULL        R15
-        MOVL         AX, R11
+        MOVL         R12, R11
+        ADDL         R12, R11
         MOVL         R13, R10
         MOVL         R10, AX
         IMULL        R13
         MOVL         AX, R10
         MOVL         R11, R9
-        ADDL         R10, R9
-        MOVL         R15, R8
-        SUBL         R13, R8
-        MOVL         R8, t5-24(SP)
-        MOVL         R9, t4-20(SP)
-        MOVL         $2, R9
-        MOVLQZX      t5-24(SP), R10
-        MOVL         R9, R8
-        MOVL         R8, AX
-        IMULL        R10
-        MOVL         AX, R8
-        MOVL         R8, t6-28(SP)
-        MOVLQZX      t6-28(SP), R9
-        MOVL         R9, R8
-        ADDL         R13, R8
-        MOVL         R8, t7-32(SP)
-        MOVLQZX      t4-20(SP), R9
-        MOVLQZX      t7-32(SP), R10
-        MOVL         R9, R8
-        ADDL         R10, R8
-        MOVL         R8, ret0+8(FP)
+        MOVL         R9, AX
+        IMULL        R11
+        MOVL         AX, R9
+        ADDL         R9, R10
+        SUBL         R11, R13
+        MOVL         $2, R8
+        MOVL         R8, R9
+        MOVL         R9, AX
+        IMULL        R13
+        MOVL         AX, R9
+        ADDL         R11, R9
+        ADDL         R9, R10
+        MOVL         R10, ret+8(FP)
         RET
 
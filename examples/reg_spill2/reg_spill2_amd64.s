@@ -1,531 +1,360 @@
-// +build amd64 !noasm !appengine
+// Code generated by gensimd. DO NOT EDIT.
+// gensimd version: devel
+// gensimd command: gensimd -f reg_spill2_simd.go -fn regspill2 -goprotofile reg_spill2_simd_proto.go -o reg_spill2_amd64.s -outfn regspill2
+// gensimd source: reg_spill2_simd.go sha256:05972c88db79fff578d24dd76be54d679b8ccc06ed625fd08470df278733acd8
+// gensimd target: avx2
+
+//go:build amd64 && !noasm && !appengine
+// +build amd64,!noasm,!appengine
 
 #include "textflag.h"
 
-TEXT ·regspill2(SB),$1184-52
-        MOVL         $0, ret0+48(FP)
-        MOVQ         $0, t3-16(SP)
-        MOVQ         $0, t3-8(SP)
-        MOVQ         $0, t9-32(SP)
-        MOVQ         $0, t9-24(SP)
-        MOVQ         $0, t21-48(SP)
-        MOVQ         $0, t21-40(SP)
-        MOVQ         $0, t23-64(SP)
-        MOVQ         $0, t23-56(SP)
-        MOVQ         $0, t27-80(SP)
-        MOVQ         $0, t27-72(SP)
-        MOVQ         $0, t35-96(SP)
-        MOVQ         $0, t35-88(SP)
-        MOVQ         $0, t37-112(SP)
-        MOVQ         $0, t37-104(SP)
-        MOVQ         $0, t41-128(SP)
-        MOVQ         $0, t41-120(SP)
-        MOVQ         $0, t49-144(SP)
-        MOVQ         $0, t49-136(SP)
+TEXT ·regspill2(SB),$928-52
 block0:
-        MOVQ         x+8(FP), R15
-        MOVQ         R15, R14
-        MOVQ         y+32(FP), R13
-        MOVQ         R13, R12
-        CMPQ         R14, R12
-        SETNE        R11
-        MOVB         R11, t2-161(SP)
-        CMPB         R11, $0
+        // entry
+        MOVQ         x_len+8(FP), R15
+        MOVQ         R15, R13
+        MOVQ         y_len+32(FP), R12
+        MOVQ         R12, R11
+        CMPQ         R13, R11
         JEQ          block2
-        JMP          block1
 block1:
+        // if.then, preds block0
         MOVL         $-1, R15
-        MOVL         R15, ret0+48(FP)
+        MOVL         R15, ret+48(FP)
         RET
 block2:
-        MOVQ         $1, R13
-        IMUL3Q       $16, R13, R13
-        MOVQ         x+0(FP), R14
-        ADDQ         R13, R14
-        MOVQ         R14, R13
-        MOVUPS       (R13), X15
-        MOVUPS       X15, t5-185(SP)
-        MOVQ         $0, R12
-        IMUL3Q       $16, R12, R12
-        MOVQ         x+0(FP), R13
-        ADDQ         R12, R13
+        // if.done, preds block0
+        MOVQ         x_base+0(FP), R13
+        ADDQ         $16, R13
         MOVQ         R13, R12
-        MOVUPS       (R12), X15
-        MOVUPS       X15, t7-209(SP)
-        MOVOU        t7-209(SP), X15
-        MOVOU        t5-185(SP), X14
-        PSUBL        X15, X14
-        MOVO         X14, X13
-        MOVQ         $1, R11
-        IMUL3Q       $16, R11, R11
-        MOVQ         y+24(FP), R12
-        ADDQ         R11, R12
+        MOVOU        (R12), X14
+        MOVOU        X14, t5-281(SP)
+        MOVQ         x_base+0(FP), R12
         MOVQ         R12, R11
-        MOVUPS       (R11), X12
-        MOVUPS       X12, t11-249(SP)
-        MOVQ         $0, R10
-        IMUL3Q       $16, R10, R10
-        MOVQ         y+24(FP), R11
-        ADDQ         R10, R11
+        MOVOU        (R11), X14
+        MOVOU        X14, t7-305(SP)
+        MOVOU        t7-305(SP), X14
+        MOVOU        t5-281(SP), X13
+        PSUBL        X14, X13
+        MOVO         X13, X12
+        MOVQ         y_base+24(FP), R11
+        ADDQ         $16, R11
         MOVQ         R11, R10
-        MOVUPS       (R10), X12
-        MOVUPS       X12, t13-273(SP)
-        MOVOU        t13-273(SP), X12
-        MOVOU        t11-249(SP), X11
-        PSUBL        X12, X11
-        MOVO         X11, X10
-        MOVO         X13, X9
+        MOVOU        (R10), X11
+        MOVOU        X11, t11-329(SP)
+        MOVQ         y_base+24(FP), R10
+        MOVQ         R10, R9
+        MOVOU        (R9), X11
+        MOVOU        X11, t13-353(SP)
+        MOVOU        t13-353(SP), X11
+        MOVOU        t11-329(SP), X10
+        PSUBL        X11, X10
+        MOVO         X10, X9
+        // ssa.UnOp, t15 = *t3, same as t8
+        // ssa.UnOp, t16 = *t3, same as t8
         MOVO         X13, X8
-        MOVO         X8, X7
-        PMULULQ      X9, X7
-        MOVOU        X9, t15-305(SP)
-        PSRLO        $4, X9
-        MOVOU        X8, t16-321(SP)
-        PSRLO        $4, X8
-        MOVO         X8, X6
-        PMULULQ      X9, X6
+        PMULULQ      X13, X8
+        MOVOU        X13, t8-160(SP)
+        PSRLO        $4, X13
+        MOVO         X13, X7
+        PMULULQ      X13, X7
+        PSHUFD       $8, X8, X6
         PSHUFD       $8, X7, X5
-        PSHUFD       $8, X6, X4
-        PUNPCKLLQ    X4, X5
-        MOVO         X10, X9
+        PUNPCKLLQ    X5, X6
+        // ssa.UnOp, t18 = *t9, same as t14
+        // ssa.UnOp, t19 = *t9, same as t14
+        MOVO         X10, X13
+        PMULULQ      X10, X13
+        MOVOU        X10, t14-176(SP)
+        PSRLO        $4, X10
         MOVO         X10, X8
-        MOVO         X8, X7
-        PMULULQ      X9, X7
-        MOVOU        X9, t18-353(SP)
-        PSRLO        $4, X9
-        MOVOU        X8, t19-369(SP)
-        PSRLO        $4, X8
-        MOVO         X8, X6
-        PMULULQ      X9, X6
-        PSHUFD       $8, X7, X4
-        PSHUFD       $8, X6, X3
-        PUNPCKLLQ    X3, X4
-        MOVOU        X5, t17-337(SP)
-        PADDL        X4, X5
-        MOVO         X5, X9
-        MOVO         X13, X8
-        MOVO         X10, X7
-        MOVOU        X8, t24-417(SP)
-        PSUBL        X7, X8
-        MOVO         X8, X6
-        MOVOU        t17-337(SP), X3
-        PSUBL        X4, X3
-        MOVO         X3, X2
-        MOVO         X6, X1
-        MOVO         X6, X0
-        MOVO         X0, X3
-        PMULULQ      X1, X3
-        MOVOU        X1, t29-481(SP)
-        PSRLO        $4, X1
-        MOVOU        X0, t30-497(SP)
-        PSRLO        $4, X0
-        MOVO         X0, X4
-        PMULULQ      X1, X4
-        PSHUFD       $8, X3, X5
-        MOVOU        X6, t23-64(SP)
-        PSHUFD       $8, X4, X6
-        PUNPCKLLQ    X6, X5
-        MOVO         X2, X6
-        MOVO         X2, X4
-        MOVO         X4, X3
-        PMULULQ      X6, X3
-        MOVOU        X6, t32-529(SP)
-        PSRLO        $4, X6
-        MOVOU        X4, t33-545(SP)
-        PSRLO        $4, X4
-        MOVO         X4, X1
-        PMULULQ      X6, X1
+        PMULULQ      X10, X8
+        PSHUFD       $8, X13, X7
+        PSHUFD       $8, X8, X5
+        PUNPCKLLQ    X5, X7
+        MOVOU        X6, t17-369(SP)
+        PADDL        X7, X6
+        // ssa.UnOp, t24 = *t3, same as t8
+        // ssa.UnOp, t25 = *t9, same as t14
+        MOVOU        t14-176(SP), X13
+        MOVOU        t8-160(SP), X10
+        PSUBL        X13, X10
+        MOVO         X10, X8
+        MOVOU        t17-369(SP), X5
+        PSUBL        X7, X5
+        MOVO         X5, X4
+        // ssa.UnOp, t29 = *t23, same as t26
+        // ssa.UnOp, t30 = *t23, same as t26
+        MOVO         X10, X3
+        PMULULQ      X10, X3
+        MOVOU        X10, t26-192(SP)
+        PSRLO        $4, X10
+        MOVO         X10, X2
+        PMULULQ      X10, X2
+        PSHUFD       $8, X3, X1
+        PSHUFD       $8, X2, X0
+        PUNPCKLLQ    X0, X1
+        // ssa.UnOp, t32 = *t27, same as t28
+        // ssa.UnOp, t33 = *t27, same as t28
+        MOVO         X5, X10
+        PMULULQ      X5, X10
+        MOVOU        X5, t28-208(SP)
+        PSRLO        $4, X5
+        MOVO         X5, X3
+        PMULULQ      X5, X3
+        PSHUFD       $8, X10, X2
         PSHUFD       $8, X3, X0
-        MOVOU        X2, t27-80(SP)
+        PUNPCKLLQ    X0, X2
+        MOVOU        X1, t31-417(SP)
+        PADDL        X2, X1
+        // ssa.UnOp, t38 = *t23, same as t26
+        // ssa.UnOp, t39 = *t27, same as t28
+        MOVOU        t28-208(SP), X10
+        MOVOU        t26-192(SP), X5
+        PSUBL        X10, X5
+        MOVO         X5, X3
+        MOVOU        t31-417(SP), X0
+        PSUBL        X2, X0
+        MOVOU        X0, t41-128(SP)
+        // ssa.UnOp, t43 = *t37, same as t40
+        // ssa.UnOp, t44 = *t37, same as t40
+        MOVOU        X0, t42-240(SP)
+        MOVO         X5, X0
+        PMULULQ      X5, X0
+        MOVOU        X5, t40-224(SP)
+        PSRLO        $4, X5
+        MOVOU        X1, t35-96(SP)
+        MOVO         X5, X1
+        PMULULQ      X5, X1
+        PSHUFD       $8, X0, X2
+        MOVOU        X6, t21-48(SP)
+        PSHUFD       $8, X1, X6
+        PUNPCKLLQ    X6, X2
+        // ssa.UnOp, t46 = *t41, same as t42
+        // ssa.UnOp, t47 = *t41, same as t42
+        MOVOU        t42-240(SP), X5
+        MOVO         X5, X6
+        PMULULQ      X5, X6
+        PSRLO        $4, X5
+        MOVO         X5, X1
+        PMULULQ      X5, X1
+        PSHUFD       $8, X6, X0
+        MOVOU        X2, t45-465(SP)
         PSHUFD       $8, X1, X2
         PUNPCKLLQ    X2, X0
-        MOVOU        X5, t31-513(SP)
-        PADDL        X0, X5
-        MOVO         X5, X6
-        MOVOU        t23-64(SP), X4
-        MOVO         X4, X3
-        MOVOU        t27-80(SP), X2
-        MOVO         X2, X1
-        MOVOU        X3, t38-593(SP)
-        PSUBL        X1, X3
-        MOVOU        X3, t37-112(SP)
-        MOVOU        t31-513(SP), X1
-        PSUBL        X0, X1
-        MOVOU        X1, t41-128(SP)
-        MOVOU        t37-112(SP), X0
-        MOVOU        X0, t43-657(SP)
-        MOVOU        X0, t44-673(SP)
-        MOVOU        t43-657(SP), X1
-        MOVOU        t44-673(SP), X2
-        MOVO         X2, X0
-        PMULULQ      X1, X0
-        PSRLO        $4, X1
-        PSRLO        $4, X2
-        MOVO         X2, X3
-        PMULULQ      X1, X3
-        PSHUFD       $8, X0, X4
-        PSHUFD       $8, X3, X5
-        PUNPCKLLQ    X5, X4
-        MOVOU        t41-128(SP), X5
-        MOVO         X5, X3
-        MOVO         X5, X2
-        MOVO         X2, X1
-        PMULULQ      X3, X1
-        MOVOU        X3, t46-705(SP)
-        PSRLO        $4, X3
-        MOVOU        X2, t47-721(SP)
-        PSRLO        $4, X2
-        MOVO         X2, X0
-        PMULULQ      X3, X0
-        MOVOU        X4, t45-689(SP)
-        PSHUFD       $8, X1, X4
-        PSHUFD       $8, X0, X5
-        PUNPCKLLQ    X5, X4
-        MOVOU        t45-689(SP), X5
-        PADDL        X4, X5
-        MOVO         X5, X3
-        MOVOU        X13, t3-16(SP)
-        MOVQ         $0, R9
-        IMUL3Q       $4, R9, R9
-        LEAQ         t3-16(SP), R10
-        ADDQ         R9, R10
-        MOVL         (R10), R9
-        MOVL         R9, t52-765(SP)
-        MOVQ         $1, R8
-        IMUL3Q       $4, R8, R8
+        MOVOU        t45-465(SP), X6
+        PADDL        X0, X6
+        MOVOU        X12, t3-16(SP)
         LEAQ         t3-16(SP), R9
-        ADDQ         R8, R9
         MOVL         (R9), R8
-        MOVL         R8, t54-777(SP)
-        MOVLQZX      t52-765(SP), R9
-        MOVLQZX      t54-777(SP), R10
-        MOVL         R9, R8
-        ADDL         R10, R8
-        MOVQ         $2, BX
-        IMUL3Q       $4, BX, BX
+        MOVL         R8, t52-509(SP)
+        LEAQ         t3-16(SP), R8
+        ADDQ         $4, R8
+        MOVL         (R8), BP
+        MOVL         BP, t54-521(SP)
+        MOVLQZX      t52-509(SP), R8
+        MOVLQZX      t54-521(SP), R9
+        ADDL         R9, R8
         LEAQ         t3-16(SP), BP
-        ADDQ         BX, BP
+        ADDQ         $8, BP
         MOVL         (BP), BX
-        MOVL         BX, t57-793(SP)
-        MOVL         R8, t55-781(SP)
-        MOVLQZX      t55-781(SP), R9
-        MOVLQZX      t57-793(SP), R10
-        MOVL         R9, R8
-        ADDL         R10, R8
-        MOVQ         $3, DI
-        IMUL3Q       $4, DI, DI
+        MOVL         BX, t57-537(SP)
+        MOVLQZX      t57-537(SP), R9
+        ADDL         R9, R8
         LEAQ         t3-16(SP), BX
-        ADDQ         DI, BX
+        ADDQ         $12, BX
         MOVL         (BX), DI
-        MOVL         DI, t60-809(SP)
-        MOVL         R8, t58-797(SP)
-        MOVLQZX      t58-797(SP), R9
-        MOVLQZX      t60-809(SP), R10
-        MOVL         R9, R8
-        ADDL         R10, R8
-        MOVQ         $0, SI
-        IMUL3Q       $4, SI, SI
+        MOVL         DI, t60-553(SP)
+        MOVLQZX      t60-553(SP), R9
+        ADDL         R9, R8
+        MOVOU        X8, t23-64(SP)
         LEAQ         t23-64(SP), DI
-        ADDQ         SI, DI
-        MOVQ         DI, t62-821(SP)
-        MOVQ         t62-821(SP), BX
+        MOVQ         DI, t62-565(SP)
+        MOVQ         t62-565(SP), BX
         MOVL         (BX), SI
-        MOVL         SI, t63-825(SP)
-        MOVL         R8, t61-813(SP)
-        MOVLQZX      t61-813(SP), R9
-        MOVLQZX      t63-825(SP), R10
-        MOVL         R9, R8
-        ADDL         R10, R8
-        MOVQ         $1, SI
-        IMUL3Q       $4, SI, SI
+        MOVL         SI, t63-569(SP)
+        MOVLQZX      t63-569(SP), R9
+        ADDL         R9, R8
         LEAQ         t23-64(SP), DI
-        ADDQ         SI, DI
-        MOVQ         DI, t65-837(SP)
-        MOVQ         t65-837(SP), BX
+        ADDQ         $4, DI
+        MOVQ         DI, t65-581(SP)
+        MOVQ         t65-581(SP), BX
         MOVL         (BX), SI
-        MOVL         SI, t66-841(SP)
-        MOVL         R8, t64-829(SP)
-        MOVLQZX      t64-829(SP), R9
-        MOVLQZX      t66-841(SP), R10
-        MOVL         R9, R8
-        ADDL         R10, R8
-        MOVQ         $2, SI
-        IMUL3Q       $4, SI, SI
+        MOVL         SI, t66-585(SP)
+        MOVLQZX      t66-585(SP), R9
+        ADDL         R9, R8
         LEAQ         t23-64(SP), DI
-        ADDQ         SI, DI
-        MOVQ         DI, t68-853(SP)
-        MOVQ         t68-853(SP), BX
+        ADDQ         $8, DI
+        MOVQ         DI, t68-597(SP)
+        MOVQ         t68-597(SP), BX
         MOVL         (BX), SI
-        MOVL         SI, t69-857(SP)
-        MOVL         R8, t67-845(SP)
-        MOVLQZX      t67-845(SP), R9
-        MOVLQZX      t69-857(SP), R10
-        MOVL         R9, R8
-        ADDL         R10, R8
-        MOVQ         $3, SI
-        IMUL3Q       $4, SI, SI
+        MOVL         SI, t69-601(SP)
+        MOVLQZX      t69-601(SP), R9
+        ADDL         R9, R8
         LEAQ         t23-64(SP), DI
-        ADDQ         SI, DI
-        MOVQ         DI, t71-869(SP)
-        MOVQ         t71-869(SP), BX
+        ADDQ         $12, DI
+        MOVQ         DI, t71-613(SP)
+        MOVQ         t71-613(SP), BX
         MOVL         (BX), SI
-        MOVL         SI, t72-873(SP)
-        MOVL         R8, t70-861(SP)
-        MOVLQZX      t70-861(SP), R9
-        MOVLQZX      t72-873(SP), R10
-        MOVL         R9, R8
-        ADDL         R10, R8
-        MOVQ         $0, SI
-        IMUL3Q       $4, SI, SI
+        MOVL         SI, t72-617(SP)
+        MOVLQZX      t72-617(SP), R9
+        ADDL         R9, R8
+        MOVOU        X3, t37-112(SP)
         LEAQ         t37-112(SP), DI
-        ADDQ         SI, DI
-        MOVQ         DI, t74-885(SP)
-        MOVQ         t74-885(SP), BX
+        MOVQ         DI, t74-629(SP)
+        MOVQ         t74-629(SP), BX
         MOVL         (BX), SI
-        MOVL         SI, t75-889(SP)
-        MOVL         R8, t73-877(SP)
-        MOVLQZX      t73-877(SP), R9
-        MOVLQZX      t75-889(SP), R10
-        MOVL         R9, R8
-        ADDL         R10, R8
-        MOVQ         $1, SI
-        IMUL3Q       $4, SI, SI
+        MOVL         SI, t75-633(SP)
+        MOVLQZX      t75-633(SP), R9
+        ADDL         R9, R8
         LEAQ         t37-112(SP), DI
-        ADDQ         SI, DI
-        MOVQ         DI, t77-901(SP)
-        MOVQ         t77-901(SP), BX
+        ADDQ         $4, DI
+        MOVQ         DI, t77-645(SP)
+        MOVQ         t77-645(SP), BX
         MOVL         (BX), SI
-        MOVL         SI, t78-905(SP)
-        MOVL         R8, t76-893(SP)
-        MOVLQZX      t76-893(SP), R9
-        MOVLQZX      t78-905(SP), R10
-        MOVL         R9, R8
-        ADDL         R10, R8
-        MOVQ         $2, SI
-        IMUL3Q       $4, SI, SI
+        MOVL         SI, t78-649(SP)
+        MOVLQZX      t78-649(SP), R9
+        ADDL         R9, R8
         LEAQ         t37-112(SP), DI
-        ADDQ         SI, DI
-        MOVQ         DI, t80-917(SP)
-        MOVQ         t80-917(SP), BX
+        ADDQ         $8, DI
+        MOVQ         DI, t80-661(SP)
+        MOVQ         t80-661(SP), BX
         MOVL         (BX), SI
-        MOVL         SI, t81-921(SP)
-        MOVL         R8, t79-909(SP)
-        MOVLQZX      t79-909(SP), R9
-        MOVLQZX      t81-921(SP), R10
-        MOVL         R9, R8
-        ADDL         R10, R8
-        MOVQ         $3, SI
-        IMUL3Q       $4, SI, SI
+        MOVL         SI, t81-665(SP)
+        MOVLQZX      t81-665(SP), R9
+        ADDL         R9, R8
         LEAQ         t37-112(SP), DI
-        ADDQ         SI, DI
-        MOVQ         DI, t83-933(SP)
-        MOVQ         t83-933(SP), BX
+        ADDQ         $12, DI
+        MOVQ         DI, t83-677(SP)
+        MOVQ         t83-677(SP), BX
         MOVL         (BX), SI
-        MOVL         SI, t84-937(SP)
-        MOVL         R8, t82-925(SP)
-        MOVLQZX      t82-925(SP), R9
-        MOVLQZX      t84-937(SP), R10
-        MOVL         R9, R8
-        ADDL         R10, R8
-        MOVOU        X10, t9-32(SP)
-        MOVQ         $0, SI
-        IMUL3Q       $4, SI, SI
+        MOVL         SI, t84-681(SP)
+        MOVLQZX      t84-681(SP), R9
+        ADDL         R9, R8
+        MOVOU        X9, t9-32(SP)
         LEAQ         t9-32(SP), DI
-        ADDQ         SI, DI
-        MOVQ         DI, t86-949(SP)
-        MOVQ         t86-949(SP), BX
+        MOVQ         DI, t86-693(SP)
+        MOVQ         t86-693(SP), BX
         MOVL         (BX), SI
-        MOVL         SI, t87-953(SP)
-        MOVQ         $1, SI
-        IMUL3Q       $4, SI, SI
+        MOVL         SI, t87-697(SP)
         LEAQ         t9-32(SP), DI
-        ADDQ         SI, DI
-        MOVQ         DI, t88-961(SP)
-        MOVQ         t88-961(SP), BX
+        ADDQ         $4, DI
+        MOVQ         DI, t88-705(SP)
+        MOVQ         t88-705(SP), BX
         MOVL         (BX), SI
-        MOVL         SI, t89-965(SP)
-        MOVL         R8, t85-941(SP)
-        MOVLQZX      t87-953(SP), R9
-        MOVLQZX      t89-965(SP), R10
-        MOVL         R9, R8
-        ADDL         R10, R8
-        MOVQ         $2, SI
-        IMUL3Q       $4, SI, SI
+        MOVL         SI, t89-709(SP)
+        MOVL         R8, t85-685(SP)
+        MOVLQZX      t87-697(SP), R8
+        MOVLQZX      t89-709(SP), R9
+        ADDL         R9, R8
         LEAQ         t9-32(SP), DI
-        ADDQ         SI, DI
-        MOVQ         DI, t91-977(SP)
-        MOVQ         t91-977(SP), BX
+        ADDQ         $8, DI
+        MOVQ         DI, t91-721(SP)
+        MOVQ         t91-721(SP), BX
         MOVL         (BX), SI
-        MOVL         SI, t92-981(SP)
-        MOVL         R8, t90-969(SP)
-        MOVLQZX      t90-969(SP), R9
-        MOVLQZX      t92-981(SP), R10
-        MOVL         R9, R8
-        ADDL         R10, R8
-        MOVQ         $3, SI
-        IMUL3Q       $4, SI, SI
+        MOVL         SI, t92-725(SP)
+        MOVLQZX      t92-725(SP), R9
+        ADDL         R9, R8
         LEAQ         t9-32(SP), DI
-        ADDQ         SI, DI
-        MOVQ         DI, t94-993(SP)
-        MOVQ         t94-993(SP), BX
+        ADDQ         $12, DI
+        MOVQ         DI, t94-737(SP)
+        MOVQ         t94-737(SP), BX
         MOVL         (BX), SI
-        MOVL         SI, t95-997(SP)
-        MOVL         R8, t93-985(SP)
-        MOVLQZX      t93-985(SP), R9
-        MOVLQZX      t95-997(SP), R10
-        MOVL         R9, R8
-        ADDL         R10, R8
-        MOVQ         $0, SI
-        IMUL3Q       $4, SI, SI
+        MOVL         SI, t95-741(SP)
+        MOVLQZX      t95-741(SP), R9
+        ADDL         R9, R8
+        MOVOU        X4, t27-80(SP)
         LEAQ         t27-80(SP), DI
-        ADDQ         SI, DI
-        MOVQ         DI, t97-1009(SP)
-        MOVQ         t97-1009(SP), BX
+        MOVQ         DI, t97-753(SP)
+        MOVQ         t97-753(SP), BX
         MOVL         (BX), SI
-        MOVL         SI, t98-1013(SP)
-        MOVL         R8, t96-1001(SP)
-        MOVLQZX      t96-1001(SP), R9
-        MOVLQZX      t98-1013(SP), R10
-        MOVL         R9, R8
-        ADDL         R10, R8
-        MOVQ         $1, SI
-        IMUL3Q       $4, SI, SI
+        MOVL         SI, t98-757(SP)
+        MOVLQZX      t98-757(SP), R9
+        ADDL         R9, R8
         LEAQ         t27-80(SP), DI
-        ADDQ         SI, DI
-        MOVQ         DI, t100-1025(SP)
-        MOVQ         t100-1025(SP), BX
+        ADDQ         $4, DI
+        MOVQ         DI, t100-769(SP)
+        MOVQ         t100-769(SP), BX
         MOVL         (BX), SI
-        MOVL         SI, t101-1029(SP)
-        MOVL         R8, t99-1017(SP)
-        MOVLQZX      t99-1017(SP), R9
-        MOVLQZX      t101-1029(SP), R10
-        MOVL         R9, R8
-        ADDL         R10, R8
-        MOVQ         $2, SI
-        IMUL3Q       $4, SI, SI
+        MOVL         SI, t101-773(SP)
+        MOVLQZX      t101-773(SP), R9
+        ADDL         R9, R8
         LEAQ         t27-80(SP), DI
-        ADDQ         SI, DI
-        MOVQ         DI, t103-1041(SP)
-        MOVQ         t103-1041(SP), BX
+        ADDQ         $8, DI
+        MOVQ         DI, t103-785(SP)
+        MOVQ         t103-785(SP), BX
         MOVL         (BX), SI
-        MOVL         SI, t104-1045(SP)
-        MOVL         R8, t102-1033(SP)
-        MOVLQZX      t102-1033(SP), R9
-        MOVLQZX      t104-1045(SP), R10
-        MOVL         R9, R8
-        ADDL         R10, R8
-        MOVQ         $3, SI
-        IMUL3Q       $4, SI, SI
+        MOVL         SI, t104-789(SP)
+        MOVLQZX      t104-789(SP), R9
+        ADDL         R9, R8
         LEAQ         t27-80(SP), DI
-        ADDQ         SI, DI
-        MOVQ         DI, t106-1057(SP)
-        MOVQ         t106-1057(SP), BX
+        ADDQ         $12, DI
+        MOVQ         DI, t106-801(SP)
+        MOVQ         t106-801(SP), BX
         MOVL         (BX), SI
-        MOVL         SI, t107-1061(SP)
-        MOVL         R8, t105-1049(SP)
-        MOVLQZX      t105-1049(SP), R9
-        MOVLQZX      t107-1061(SP), R10
-        MOVL         R9, R8
-        ADDL         R10, R8
-        MOVQ         $0, SI
-        IMUL3Q       $4, SI, SI
+        MOVL         SI, t107-805(SP)
+        MOVLQZX      t107-805(SP), R9
+        ADDL         R9, R8
         LEAQ         t41-128(SP), DI
-        ADDQ         SI, DI
-        MOVQ         DI, t109-1073(SP)
-        MOVQ         t109-1073(SP), BX
+        MOVQ         DI, t109-817(SP)
+        MOVQ         t109-817(SP), BX
         MOVL         (BX), SI
-        MOVL         SI, t110-1077(SP)
-        MOVL         R8, t108-1065(SP)
-        MOVLQZX      t108-1065(SP), R9
-        MOVLQZX      t110-1077(SP), R10
-        MOVL         R9, R8
-        ADDL         R10, R8
-        MOVQ         $1, SI
-        IMUL3Q       $4, SI, SI
+        MOVL         SI, t110-821(SP)
+        MOVLQZX      t110-821(SP), R9
+        ADDL         R9, R8
         LEAQ         t41-128(SP), DI
-        ADDQ         SI, DI
-        MOVQ         DI, t112-1089(SP)
-        MOVQ         t112-1089(SP), BX
+        ADDQ         $4, DI
+        MOVQ         DI, t112-833(SP)
+        MOVQ         t112-833(SP), BX
         MOVL         (BX), SI
-        MOVL         SI, t113-1093(SP)
-        MOVL         R8, t111-1081(SP)
-        MOVLQZX      t111-1081(SP), R9
-        MOVLQZX      t113-1093(SP), R10
-        MOVL         R9, R8
-        ADDL         R10, R8
-        MOVQ         $2, SI
-        IMUL3Q       $4, SI, SI
+        MOVL         SI, t113-837(SP)
+        MOVLQZX      t113-837(SP), R9
+        ADDL         R9, R8
         LEAQ         t41-128(SP), DI
-        ADDQ         SI, DI
-        MOVQ         DI, t115-1105(SP)
-        MOVQ         t115-1105(SP), BX
+        ADDQ         $8, DI
+        MOVQ         DI, t115-849(SP)
+        MOVQ         t115-849(SP), BX
         MOVL         (BX), SI
-        MOVL         SI, t116-1109(SP)
-        MOVL         R8, t114-1097(SP)
-        MOVLQZX      t114-1097(SP), R9
-        MOVLQZX      t116-1109(SP), R10
-        MOVL         R9, R8
-        ADDL         R10, R8
-        MOVQ         $3, SI
-        IMUL3Q       $4, SI, SI
+        MOVL         SI, t116-853(SP)
+        MOVLQZX      t116-853(SP), R9
+        ADDL         R9, R8
         LEAQ         t41-128(SP), DI
-        ADDQ         SI, DI
-        MOVQ         DI, t118-1121(SP)
-        MOVQ         t118-1121(SP), BX
+        ADDQ         $12, DI
+        MOVQ         DI, t118-865(SP)
+        MOVQ         t118-865(SP), BX
         MOVL         (BX), SI
-        MOVL         SI, t119-1125(SP)
-        MOVL         R8, t117-1113(SP)
-        MOVLQZX      t117-1113(SP), R9
-        MOVLQZX      t119-1125(SP), R10
-        MOVL         R9, R8
-        ADDL         R10, R8
-        MOVL         R8, t120-1129(SP)
-        MOVLQZX      t85-941(SP), R9
-        MOVLQZX      t120-1129(SP), R10
-        MOVL         R9, R8
-        ADDL         R10, R8
-        MOVOU        X9, t21-48(SP)
-        MOVQ         $0, SI
-        IMUL3Q       $4, SI, SI
+        MOVL         SI, t119-869(SP)
+        MOVLQZX      t119-869(SP), R9
+        ADDL         R9, R8
+        MOVL         R8, t120-873(SP)
+        MOVLQZX      t85-685(SP), R8
+        MOVLQZX      t120-873(SP), R9
+        ADDL         R9, R8
         LEAQ         t21-48(SP), DI
-        ADDQ         SI, DI
-        MOVQ         DI, t122-1141(SP)
-        MOVQ         t122-1141(SP), BX
+        MOVQ         DI, t122-885(SP)
+        MOVQ         t122-885(SP), BX
         MOVL         (BX), SI
-        MOVL         SI, t123-1145(SP)
-        MOVL         R8, t121-1133(SP)
-        MOVLQZX      t121-1133(SP), R9
-        MOVLQZX      t123-1145(SP), R10
-        MOVL         R9, R8
-        ADDL         R10, R8
-        MOVOU        X6, t35-96(SP)
-        MOVQ         $1, SI
-        IMUL3Q       $4, SI, SI
+        MOVL         SI, t123-889(SP)
+        MOVLQZX      t123-889(SP), R9
+        ADDL         R9, R8
         LEAQ         t35-96(SP), DI
-        ADDQ         SI, DI
-        MOVQ         DI, t125-1157(SP)
-        MOVQ         t125-1157(SP), BX
+        ADDQ         $4, DI
+        MOVQ         DI, t125-901(SP)
+        MOVQ         t125-901(SP), BX
         MOVL         (BX), SI
-        MOVL         SI, t126-1161(SP)
-        MOVL         R8, t124-1149(SP)
-        MOVLQZX      t124-1149(SP), R9
-        MOVLQZX      t126-1161(SP), R10
-        MOVL         R9, R8
-        ADDL         R10, R8
-        MOVOU        X3, t49-144(SP)
-        MOVQ         $2, SI
-        IMUL3Q       $4, SI, SI
+        MOVL         SI, t126-905(SP)
+        MOVLQZX      t126-905(SP), R9
+        ADDL         R9, R8
+        MOVOU        X6, t49-144(SP)
         LEAQ         t49-144(SP), DI
-        ADDQ         SI, DI
-        MOVQ         DI, t128-1173(SP)
-        MOVQ         t128-1173(SP), BX
+        ADDQ         $8, DI
+        MOVQ         DI, t128-917(SP)
+        MOVQ         t128-917(SP), BX
         MOVL         (BX), SI
-        MOVL         SI, t129-1177(SP)
-        MOVL         R8, t127-1165(SP)
-        MOVLQZX      t127-1165(SP), R9
-        MOVLQZX      t129-1177(SP), R10
-        MOVL         R9, R8
-        ADDL         R10, R8
-        MOVL         R8, ret0+48(FP)
+        MOVL         SI, t129-921(SP)
+        MOVLQZX      t129-921(SP), R9
+        ADDL         R9, R8
+        MOVL         R8, ret+48(FP)
         RET
 
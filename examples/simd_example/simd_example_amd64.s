@@ -1,104 +1,104 @@
-// +build amd64 !noasm !appengine
+// Code generated by gensimd. DO NOT EDIT.
+// gensimd version: devel
+// gensimd command: gensimd -f simd_example_other.go -fn "addi32x4, subi32x4, muli32x4, shli32x4, shri32x4, addf32x4, subf32x4, mulf32x4, divf32x4" -o simd_example_amd64.s -outfn "addi32x4, subi32x4, muli32x4, shli32x4, shri32x4, addf32x4, subf32x4, mulf32x4, divf32x4"
+// gensimd source: simd_example_other.go sha256:c8b8cef0058db82ccc8b0809a95f7cc0eb2883bcf8c3673c6ad7ee8e100eaa9d
+// gensimd target: avx2
+
+//go:build amd64 && !noasm && !appengine
+// +build amd64,!noasm,!appengine
 
 #include "textflag.h"
 
 TEXT ·addi32x4(SB),$24-48
-        MOVQ         $0, ret0+32(FP)
-        MOVQ         $0, ret0+40(FP)
 block0:
-        MOVOU        y+16(FP), X15
-        MOVOU        x+0(FP), X14
-        PADDL        X15, X14
-        MOVOU        X14, ret0+32(FP)
+        // entry
+        MOVUPS       y+16(FP), X14
+        MOVUPS       x+0(FP), X13
+        PADDL        X14, X13
+        MOVUPS       X13, ret+32(FP)
         RET
 
 TEXT ·subi32x4(SB),$24-48
-        MOVQ         $0, ret0+32(FP)
-        MOVQ         $0, ret0+40(FP)
 block0:
-        MOVOU        y+16(FP), X15
-        MOVOU        x+0(FP), X14
-        PSUBL        X15, X14
-        MOVOU        X14, ret0+32(FP)
+        // entry
+        MOVUPS       y+16(FP), X14
+        MOVUPS       x+0(FP), X13
+        PSUBL        X14, X13
+        MOVUPS       X13, ret+32(FP)
         RET
 
 TEXT ·muli32x4(SB),$24-48
-        MOVQ         $0, ret0+32(FP)
-        MOVQ         $0, ret0+40(FP)
 block0:
-        MOVOU        x+0(FP), X14
-        MOVOU        y+16(FP), X13
-        MOVO         X13, X15
-        PMULULQ      X14, X15
-        PSRLO        $4, X14
+        // entry
+        MOVUPS       x+0(FP), X13
+        MOVUPS       y+16(FP), X12
+        MOVO         X12, X14
+        PMULULQ      X13, X14
         PSRLO        $4, X13
-        MOVO         X13, X12
-        PMULULQ      X14, X12
-        PSHUFD       $8, X15, X11
-        PSHUFD       $8, X12, X10
-        PUNPCKLLQ    X10, X11
-        MOVOU        X11, ret0+32(FP)
+        PSRLO        $4, X12
+        MOVO         X12, X11
+        PMULULQ      X13, X11
+        PSHUFD       $8, X14, X10
+        PSHUFD       $8, X11, X9
+        PUNPCKLLQ    X9, X10
+        MOVUPS       X10, ret+32(FP)
         RET
 
 TEXT ·shli32x4(SB),$24-40
-        MOVQ         $0, ret0+24(FP)
-        MOVQ         $0, ret0+32(FP)
 block0:
+        // entry
         MOVBQZX      shift+16(FP), R15
-        MOVQ         R15, X15
-        MOVOU        x+0(FP), X14
-        PSLLL        X15, X14
-        MOVOU        X14, ret0+24(FP)
+        MOVBQZX      R15, R13
+        MOVQ         R13, X14
+        MOVUPS       x+0(FP), X13
+        PSLLL        X14, X13
+        MOVUPS       X13, ret+24(FP)
         RET
 
 TEXT ·shri32x4(SB),$24-40
-        MOVQ         $0, ret0+24(FP)
-        MOVQ         $0, ret0+32(FP)
 block0:
+        // entry
         MOVBQZX      shift+16(FP), R15
-        MOVQ         R15, X15
-        MOVOU        x+0(FP), X14
-        PSRAL        X15, X14
-        MOVOU        X14, ret0+24(FP)
+        MOVBQZX      R15, R13
+        MOVQ         R13, X14
+        MOVUPS       x+0(FP), X13
+        PSRAL        X14, X13
+        MOVUPS       X13, ret+24(FP)
         RET
 
 TEXT ·addf32x4(SB),$24-48
-        MOVQ         $0, ret0+32(FP)
-        MOVQ         $0, ret0+40(FP)
 block0:
-        MOVUPS       y+16(FP), X15
-        MOVUPS       x+0(FP), X14
-        ADDPS        X15, X14
-        MOVUPS       X14, ret0+32(FP)
+        // entry
+        MOVUPS       y+16(FP), X14
+        MOVUPS       x+0(FP), X13
+        ADDPS        X14, X13
+        MOVUPS       X13, ret+32(FP)
         RET
 
 TEXT ·subf32x4(SB),$24-48
-        MOVQ         $0, ret0+32(FP)
-        MOVQ         $0, ret0+40(FP)
 block0:
-        MOVUPS       y+16(FP), X15
-        MOVUPS       x+0(FP), X14
-        SUBPS        X15, X14
-        MOVUPS       X14, ret0+32(FP)
+        // entry
+        MOVUPS       y+16(FP), X14
+        MOVUPS       x+0(FP), X13
+        SUBPS        X14, X13
+        MOVUPS       X13, ret+32(FP)
         RET
 
 TEXT ·mulf32x4(SB),$24-48
-        MOVQ         $0, ret0+32(FP)
-        MOVQ         $0, ret0+40(FP)
 block0:
-        MOVUPS       y+16(FP), X15
-        MOVUPS       x+0(FP), X14
-        MULPS        X15, X14
-        MOVUPS       X14, ret0+32(FP)
+        // entry
+        MOVUPS       y+16(FP), X14
+        MOVUPS       x+0(FP), X13
+        MULPS        X14, X13
+        MOVUPS       X13, ret+32(FP)
         RET
 
 TEXT ·divf32x4(SB),$24-48
-        MOVQ         $0, ret0+32(FP)
-        MOVQ         $0, ret0+40(FP)
 block0:
-        MOVUPS       y+16(FP), X15
-        MOVUPS       x+0(FP), X14
-        DIVPS        X15, X14
-        MOVUPS       X14, ret0+32(FP)
+        // entry
+        MOVUPS       y+16(FP), X14
+        MOVUPS       x+0(FP), X13
+        DIVPS        X14, X13
+        MOVUPS       X13, ret+32(FP)
         RET
 
@@ -1,15 +1,21 @@
-// +build amd64 !noasm !appengine
+// Code generated by gensimd. DO NOT EDIT.
+// gensimd version: devel
+// gensimd command: gensimd -f sse2_example_other.go -fn addpd -o sse2_example_amd64.s -outfn addpd
+// gensimd source: sse2_example_other.go sha256:94ec094679d09c1dcdf3f5eb3ffe9191d62a354bfb02c8b44bd24cb7c86e31e6
+// gensimd target: avx2
+
+//go:build amd64 && !noasm && !appengine
+// +build amd64,!noasm,!appengine
 
 #include "textflag.h"
 
 TEXT ·addpd(SB),$24-48
-        MOVQ         $0, ret0+32(FP)
-        MOVQ         $0, ret0+40(FP)
 block0:
-        MOVUPD       x+0(FP), X15
-        MOVUPD       y+16(FP), X14
-        ADDPD        X15, X14
-        MOVO         X14, X13
-        MOVUPD       X13, ret0+32(FP)
+        // entry
+        MOVUPS       x+0(FP), X14
+        MOVUPS       y+16(FP), X13
+        ADDPD        X14, X13
+        MOVO         X13, X12
+        MOVUPS       X12, ret+32(FP)
         RET
 
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/bjwbell/gensimd/codegen"
+)
+
+// explaining is set by "gensimd explain file.s", the functions of the
+// command recorded in the file's header are printed interleaved with their
+// source lines instead of being written.
+var explaining bool
+
+// explainArgs returns the arguments of the gensimd command recorded in the
+// header of the generated assembly file args[0], for "gensimd explain", and
+// changes to the directory the command ran in, the file's directory less
+// the directory of its -o path.
+func explainArgs(args []string) []string {
+	log.SetFlags(log.Lshortfile)
+	if len(args) != 1 {
+		log.Fatalf("Error usage: gensimd explain file.s\n")
+	}
+	filename := args[0]
+	src, err := ioutil.ReadFile(filename)
+	if err != nil {
+		log.Fatalf("Cannot read file \"%v\", error \"%v\"\n", filename, err)
+	}
+	header, ok := codegen.ParseHeader(string(src))
+	if !ok || header.Command == "" {
+		log.Fatalf("Error \"%v\" has no gensimd header, generate it again to explain it\n", filename)
+	}
+	cmd, err := splitCommand(header.Command)
+	if err != nil || len(cmd) == 0 || cmd[0] != "gensimd" {
+		log.Fatalf("Error parsing the command \"%v\" of \"%v\"\n", header.Command, filename)
+	}
+	cmd = cmd[1:]
+	output, hasFile := "", false
+	for i, arg := range cmd {
+		switch arg {
+		case "-pkg":
+			log.Fatalf("Error \"%v\" was generated with -pkg, explain the functions with -f instead\n", filename)
+		case "-o":
+			if i+1 < len(cmd) {
+				output = cmd[i+1]
+			}
+		case "-f":
+			hasFile = true
+		}
+	}
+	if !hasFile {
+		// the command ran by go generate with $GOFILE
+		cmd = append(cmd, "-f", header.Source)
+	}
+
+	dir, err := filepath.Abs(filepath.Dir(filename))
+	if err != nil {
+		log.Fatalf("Error %v\n", err)
+	}
+	if outDir := filepath.Dir(filepath.Clean(output)); outDir != "." && !filepath.IsAbs(outDir) {
+		dir = strings.TrimSuffix(dir, string(filepath.Separator)+outDir)
+	}
+	if err := os.Chdir(dir); err != nil {
+		log.Fatalf("Error %v\n", err)
+	}
+	if hash := sourceHash(header.Source); hash != header.SourceHash {
+		log.Printf("\"%v\" changed since \"%v\" was generated, explaining its current functions\n", header.Source, filename)
+	}
+	explaining = true
+	return append([]string{os.Args[0]}, cmd...)
+}
+
+// splitCommand splits the command line of a header, from commandLine, into
+// its arguments.
+func splitCommand(cmd string) ([]string, error) {
+	args := []string{}
+	for cmd = strings.TrimSpace(cmd); cmd != ""; cmd = strings.TrimSpace(cmd) {
+		if cmd[0] != '"' {
+			arg := strings.Fields(cmd)[0]
+			args = append(args, arg)
+			cmd = cmd[len(arg):]
+			continue
+		}
+		quoted, err := strconv.QuotedPrefix(cmd)
+		if err != nil {
+			return nil, err
+		}
+		arg, err := strconv.Unquote(quoted)
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, arg)
+		cmd = cmd[len(quoted):]
+	}
+	return args, nil
+}
+
+// explainFunction prints the assembly of fn interleaved with its source
+// lines.
+func explainFunction(fn *codegen.Function) {
+	listing, err := fn.Explain()
+	if err != nil {
+		if position := fn.Position(err.Pos); position.IsValid() {
+			log.Fatalf("Error explaining fn asm, %v, \"%v\"\n", position, err.Err)
+		}
+		log.Fatalf("Error explaining fn asm: \"%v\"\n", err.Err)
+	}
+	os.Stdout.WriteString(listing)
+}
@@ -3,19 +3,24 @@ package main
 //go:generate stringer -type=Instruction,InstrOpType,InstructionType,SimdInstr,XmmData codegen
 
 import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/json"
 	"flag"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"log"
 	"os"
+	"path/filepath"
+	"strconv"
 	"strings"
+	"text/tabwriter"
 
 	"github.com/bjwbell/gensimd/codegen"
-	"github.com/bjwbell/gensimd/simd"
 
-	"go/build"
-
-	"go/types"
+	"go/parser"
+	"go/token"
 
 	"golang.org/x/tools/go/loader"
 	"golang.org/x/tools/go/ssa"
@@ -47,32 +52,165 @@ func fileName(pathName string) string {
 }
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "explain" {
+		os.Args = explainArgs(os.Args[2:])
+	}
 	var ssaDump = flag.Bool("ssa", false, "dump ssa representation")
-	var debug = flag.Bool("debug", false, "include debug comments in assembly")
+	var debug = flag.Bool("debug", false, "include debug comments and checks in assembly")
+	var comments = flag.String("comments", "", "comment level of the assembly, none, blocks, or instructions (default blocks, instructions with -debug)")
 	var trace = flag.Bool("trace", false, "trace of assembly generation to stdout")
 	var printSpills = flag.Bool("spills", false, "print each register spill")
 	var disableOptimizations = flag.Bool("N", false, "disable optimizations")
+	var optFor = flag.String("optfor", "speed", "optimize for speed or size, size uses shorter instructions and REP MOVSQ/STOSQ instead of unrolled copies and zeroing")
 	var output = flag.String("o", "", "Go assembly output file")
 	var f = flag.String("f", "", "input file with function definitions")
 	var flagFn = flag.String("fn", "", "comma separated list of function names")
 	var flagOutFn = flag.String("outfn", "", "comma separated list of output function names")
+	var variants = flag.String("variants", "", "comma separated list of CPU feature levels to generate a variant of each function for, e.g. sse2,avx2, the function jumps to the highest the CPU supports")
+	var suffix = flag.String("suffix", "", "suffix appended to the output function names, \"target\" for the -target feature level, e.g. sumAVX2")
 	var goprotofile = flag.String("goprotofile", "", "output file for SIMD function prototype(s)")
+	var buildConstraint = flag.String("build", codegen.DefaultBuildConstraint, "build constraint for the assembly and prototype(s)")
+	var fallbackfile = flag.String("fallback", "", "output file for pure Go fallback(s), built with the inverse build constraint")
+	var cabifile = flag.String("cabi", "", "output file for a C header declaring a C callable, System V ABI, entry point of each function, added to the assembly, needs -nosplit")
+	var genericfile = flag.String("generic", "", "output file for renamed copies of the Go function(s), built with the inverse build constraint")
+	var blockfreq = flag.String("blockfreq", "", "block frequency hint file, lines of \"funcname blockindex count\"")
+	var noalias = flag.Bool("noalias", false, "assume slice and pointer parameters don't overlap, like "+codegen.NoAliasDirective+" on every function")
+	var target = flag.String("target", codegen.TargetAVX2, "highest CPU feature level the assembly may use, sse2, ssse3, sse4.1, avx, avx2, or avx512vnni")
+	var nosplit = flag.Bool("nosplit", false, "mark the functions NOSPLIT, omitting the stack bound check, their stack use must fit in the linker's NOSPLIT limit less -stackmargin")
+	var stackMargin = flag.Int("stackmargin", 0, "bytes of the NOSPLIT stack limit left for NOSPLIT callers of the functions, with -nosplit")
+	var boundsCheck = flag.Bool("boundscheck", false, "check slice and array indexes, out of range indexes trap")
+	var pipeline = flag.Bool("pipeline", false, "software pipeline the element loads of loops counted to the length of a slice, loading the next iteration's elements during the current one")
+	var goos = flag.String("os", "", "GOOS the assembly is for, added to the build constraint (default any OS)")
+	var vet = flag.Bool("vet", false, "check the assembly against its Go declaration with the asmdecl vet check")
+	var cacheDir = flag.String("cache", "", "directory caching the assembly of each function, only changed functions are generated again")
+	var jsonMode = flag.Bool("json", false, "print a JSON document of the assembly, declarations, diagnostics, stats, and CPU features of the functions instead of text")
+	var printCost = flag.Bool("cost", false, "print the estimated cycles per iteration, throughput and latency bounds, and critical dependency chain of each loop of the functions on the -cpu model")
+	var cpu = flag.String("cpu", "", "CPU model of -cost, "+strings.Join(codegen.CostCPUs(), ", ")+" (default the typical CPU of -target)")
+	var printStats = flag.Bool("stats", false, "print a table of the instruction count, estimated cycles, frame size, spills, and vector instruction percentage of each function")
+	var dumpAfter = flag.String("dump-after", "", "comma separated list of passes to print the assembly after, params, zero, phi, loadfuse, bitloop, pipeline, reduction, induction, cse, select, switch, loadcse, hoist, lower, frame, or emit")
+	var dumpSSA = flag.Bool("dump-ssa", false, "print the ssa of each function before generating it")
+	var dumpLiveness = flag.Bool("dump-liveness", false, "print the phi moves of each block edge and the blocks using each value")
+	var dumpFrames = flag.Bool("dump-frames", false, "print the stack slot of each value and the register assignments and spills")
+	var dumpPressure = flag.Bool("dump-pressure", false, "print the most values live at once in each block, by register type, and the values spilled in it")
+	var cfgfile = flag.String("cfg", "", "output file for Graphviz DOT graphs of the basic blocks of the function(s), with the instruction count of each block")
+	var watchMode = flag.Bool("watch", false, "generate again each time the input file or block frequency file is saved, until interrupted")
+	var pkgDir = flag.String("pkg", "", "package directory to generate every exported function of, into a copy of the package in the -o directory")
+	var verify = flag.Bool("verify", false, "generate the output files again and compare them with the files on disk instead of writing them, print a unified diff of each one that differs and exit with status 1")
 
 	flag.Parse()
 
 	optimize := !*disableOptimizations
 
+	opts := codegen.DefaultOptions()
+	if !optimize {
+		opts.OptLevel = 0
+	}
+	opts.Target = *target
+	if *variants != "" {
+		for _, v := range strings.Split(*variants, ",") {
+			opts.Variants = append(opts.Variants, strings.TrimSpace(v))
+		}
+	}
+	goal, err := codegen.ParseOptFor(*optFor)
+	if err != nil {
+		log.Fatalf("Error %v\n", err)
+	}
+	opts.OptFor = goal
+	opts.OS = *goos
+	opts.BoundsCheck = *boundsCheck
+	opts.Pipeline = *pipeline
+	opts.NoSplit = *nosplit
+	opts.StackMargin = *stackMargin
+	opts.Debug = *debug
+	if *debug {
+		opts.CommentLevel = codegen.CommentInstructions
+	}
+	if *comments != "" {
+		level, err := codegen.ParseCommentLevel(*comments)
+		if err != nil {
+			log.Fatalf("Error %v\n", err)
+		}
+		opts.CommentLevel = level
+	}
+
+	var cache *codegen.Cache
+	if *cacheDir != "" {
+		cache = &codegen.Cache{Dir: *cacheDir, Version: executableHash()}
+	}
+
+	var blockFreqs codegen.BlockFreqs
+	if *blockfreq != "" {
+		r, err := os.Open(*blockfreq)
+		if err != nil {
+			log.Fatalf("Error opening block frequency file \"%v\", error msg \"%v\"\n", *blockfreq, err)
+		}
+		blockFreqs, err = codegen.ReadBlockFreqs(r)
+		r.Close()
+		if err != nil {
+			log.Fatalf("Error reading block frequency file \"%v\", error msg \"%v\"\n", *blockfreq, err)
+		}
+	}
+
+	spills := os.ExpandEnv("$GENSIMDSPILLS")
+	if spills != "" {
+		*printSpills = spills == "1"
+	}
+	// configure sets the generation options of each function
+	configure := func(fn *codegen.Function) {
+		fn.Trace = *trace
+		fn.PrintSpills = *printSpills
+		fn.BlockFreqs = blockFreqs
+		fn.Cache = cache
+		fn.DumpSSA = *dumpSSA
+		fn.DumpLiveness = *dumpLiveness
+		fn.DumpFrames = *dumpFrames
+		fn.DumpPressure = *dumpPressure
+		if *jsonMode {
+			// stdout is the JSON document
+			fn.DumpWriter = os.Stderr
+		}
+		if *dumpAfter != "" {
+			fn.DumpAfter = strings.Split(*dumpAfter, ",")
+		}
+		if *noalias {
+			fn.NoAlias = true
+		}
+	}
+
 	file := os.ExpandEnv("$GOFILE")
 	log.SetFlags(log.Lshortfile)
+	out := &outputs{verify: *verify}
+	out.header = codegen.Header{Version: gensimdVersion(), Command: commandLine(), Target: opts.Target, Variants: opts.Variants}
+	if *verify && *output == "" {
+		log.Fatalf("Error -verify needs the -o output file to compare with\n")
+	}
+	if *pkgDir != "" {
+		if *output == "" {
+			log.Fatalf("Error -pkg needs an -o output directory\n")
+		}
+		if *jsonMode || *flagFn != "" {
+			log.Fatalf("Error -pkg generates every exported function, -fn and -json aren't supported with it\n")
+		}
+		if *cabifile != "" {
+			log.Fatalf("Error -cabi isn't supported with -pkg\n")
+		}
+		generatePackage(*pkgDir, *output, codegen.OSBuildConstraint(*buildConstraint, opts.OS), opts, configure, out)
+		out.finish()
+		return
+	}
 	if *f != "" {
 		file = *f
 	}
+	out.header.Source, out.header.SourceHash = file, sourceHash(file)
 	if *flagFn == "" {
 		log.Fatalf("Error no function name(s) provided")
 	}
-	spills := os.ExpandEnv("$GENSIMDSPILLS")
-	if spills != "" {
-		*printSpills = spills == "1"
+	if *watchMode {
+		if *verify {
+			log.Fatalf("Error -verify doesn't write the output files, it isn't supported with -watch\n")
+		}
+		watch(watchedFiles(file, *blockfreq), withoutFlag(os.Args[1:], "watch"))
+		return
 	}
 	fnnames := strings.Split(*flagFn, ",")
 	outFns := []string{}
@@ -90,9 +228,37 @@ func main() {
 	for i := range fnnames {
 		fnnames[i] = strings.TrimSpace(fnnames[i])
 		outFns[i] = strings.TrimSpace(outFns[i])
+		if *suffix == "target" {
+			outFns[i] += codegen.TargetSuffix(*target)
+		} else {
+			outFns[i] += *suffix
+		}
+	}
+	declared := map[string]token.Position{}
+	if *output != "" {
+		// the assembly is linked into the package of the output file
+		declared, err = packageSymbols(targetContext(opts), filepath.Dir(*output), *output, *goprotofile, *fallbackfile, *genericfile)
+		if err != nil {
+			log.Fatalf("Error reading the package of \"%v\", error msg \"%v\"\n", *output, err)
+		}
+	}
+	symbols := append([]string{}, outFns...)
+	for _, outfn := range outFns {
+		for _, v := range opts.Variants {
+			symbols = append(symbols, codegen.VariantName(outfn, v))
+		}
+		if len(opts.Variants) > 0 {
+			symbols = append(symbols, codegen.VariantIndexName(outfn))
+		}
+	}
+	if errs := codegen.CheckOutNames(symbols, declared); len(errs) > 0 {
+		for _, err := range errs {
+			log.Printf("Error %v\n", err.Err)
+		}
+		log.Fatalf("Error %v invalid output function name(s)\n", len(errs))
 	}
 
-	parsed, err := simd.ParseFile(file)
+	parsed, err := ParseFile(file)
 	if err != nil {
 		msg := "Error parsing file \"%v\", error msg \"%v\"\n"
 		log.Fatalf(msg, file, err)
@@ -100,18 +266,11 @@ func main() {
 
 	filePkgName := parsed.Pkg.Name()
 	filePkgPath := parsed.Pkg.Path()
-	conf := loader.Config{Build: &build.Default}
-
-	// Choose types.Sizes from conf.Build.
-	var wordSize int64 = 8
-	switch conf.Build.GOARCH {
-	case "386", "arm":
-		panic("SIMD invalid for x86 and arm")
-	}
-	conf.TypeChecker.Sizes = &types.StdSizes{
-		MaxAlign: 8,
-		WordSize: wordSize,
-	}
+	// comments are kept for the //gensimd: directives, imports are loaded
+	// with the target's build constraints, not the host's
+	conf := loader.Config{Build: targetContext(opts), ParserMode: parser.ParseComments}
+	// type check with the sizes the assembly lays out memory with
+	conf.TypeChecker.Sizes = opts.Sizes
 
 	// Use the initial file from the command line/$GOFILE.
 	conf.CreateFromFilenames(filePath(file), file)
@@ -123,9 +282,9 @@ func main() {
 	}
 
 	// Create and build SSA-form program representation.
-	builderMode := ssa.SanityCheckFunctions | ssa.GlobalDebug
+	builderMode := codegen.BuilderMode
 	if *ssaDump {
-		builderMode = ssa.PrintFunctions
+		builderMode |= ssa.PrintFunctions
 	}
 	prog := ssautil.CreateProgram(iprog, builderMode)
 	if prog == nil {
@@ -137,10 +296,35 @@ func main() {
 		prog.Package(info.Pkg).Build()
 	}
 
-	assembly := codegen.AssemblyFilePreamble()
+	*buildConstraint = codegen.OSBuildConstraint(*buildConstraint, opts.OS)
+	buildLines, err := codegen.BuildConstraint(*buildConstraint)
+	if err != nil {
+		log.Fatalf("Error parsing build constraint \"%v\", error msg \"%v\"\n", *buildConstraint, err)
+	}
+	fallbackBuildLines, err := codegen.InverseBuildConstraint(*buildConstraint)
+	if err != nil {
+		log.Fatalf("Error parsing build constraint \"%v\", error msg \"%v\"\n", *buildConstraint, err)
+	}
+
+	asmFile := codegen.NewFile(buildLines)
+	stats := []codegen.Stats{}
+	costs := []codegen.CostReport{}
+	jsonOut := codegen.JSONOutput{APIVersion: codegen.APIVersion, File: file, OK: true}
+	// jsonFail records a function that failed in -json mode
+	jsonFail := func(fnname, outfn string, diagnostics ...codegen.Diagnostic) {
+		jsonOut.OK = false
+		result := codegen.Result{Diagnostics: diagnostics}
+		jsonOut.Functions = append(jsonOut.Functions, codegen.NewJSONFunction(fnname, outfn, result))
+	}
 	goprotos := ""
+	cdecls := []string{}
+	cfgs := ""
+	fallbacks := ""
+	generics := ""
+	genericImports := ""
 	protoPkgName := ""
 	protoImports := ""
+	fallbackImports := ""
 	foundpkg := false
 	for _, pkg := range prog.AllPackages() {
 		if pkg.Pkg.Path() == filePkgPath && pkg.Pkg.Name() == filePkgName {
@@ -150,16 +334,47 @@ func main() {
 				outfn := outFns[i]
 				if fn := pkg.Func(fnname); fn == nil {
 					msg := "Func \"%v\" not found in package \"%v\""
+					if *jsonMode {
+						jsonFail(fnname, outfn, codegen.Diagnostic{Msg: fmt.Sprintf(msg, fnname, filePkgName)})
+						continue
+					}
 					log.Fatalf(msg, fnname, filePkgName)
 				} else {
-					dbg := *debug
-					fn, err := codegen.CreateFunction(fn, outfn, dbg, *trace, optimize)
-					fn.PrintSpills = *printSpills
-					if err != nil {
+					ssaFn := fn
+					if errs := codegen.Unsupported(fn); len(errs) > 0 && *jsonMode {
+						diagnostics := []codegen.Diagnostic{}
+						for _, err := range errs {
+							diagnostics = append(diagnostics, codegen.NewDiagnostic(fn, err))
+						}
+						jsonFail(fnname, outfn, diagnostics...)
+						continue
+					} else if len(errs) > 0 {
+						for _, err := range errs {
+							if position := fn.Prog.Fset.Position(err.Pos); position.IsValid() {
+								log.Printf("Error unsupported, %v, \"%v\"\n", position, err.Err)
+							} else {
+								log.Printf("Error unsupported, \"%v\"\n", err.Err)
+							}
+						}
+						log.Fatalf("Error %v unsupported construct(s) in \"%v\"\n", len(errs), fnname)
+					}
+					opts.OutName = outfn
+					fn, err := codegen.CreateFunction(fn, opts)
+					if err != nil && *jsonMode {
+						jsonFail(fnname, outfn, codegen.NewDiagnostic(ssaFn, err))
+						continue
+					} else if err != nil {
 						msg := "codegen error msg \"%v\""
-						log.Fatalf(msg, err)
+						log.Fatalf(msg, err.Err)
+					}
+					configure(fn)
+					if explaining {
+						explainFunction(fn)
+						continue
 					}
-					if asm, err := fn.GoAssembly(); err != nil {
+					if asm, err := fn.GoAssembly(); err != nil && *jsonMode {
+						jsonFail(fnname, outfn, codegen.NewDiagnostic(ssaFn, err))
+					} else if err != nil {
 						msg := "Error creating fn asm: \"%v\"\n"
 						msgp := "Error creating fn asm, %v, \"%v\"\n"
 						position := fn.Position(err.Pos)
@@ -169,7 +384,51 @@ func main() {
 							log.Fatalf(msg, err.Err)
 						}
 					} else {
-						if *output == "" {
+						if *vet {
+							diagnostics, err := fn.Vet()
+							if err != nil {
+								log.Fatalf("Error vetting fn asm, \"%v\"\n", err.Err)
+							}
+							if len(diagnostics) > 0 && *jsonMode {
+								jsonFail(fnname, outfn, diagnostics...)
+								continue
+							}
+							for _, d := range diagnostics {
+								log.Printf("Error vet, %v\n", d)
+							}
+							if len(diagnostics) > 0 {
+								log.Fatalf("Error %v vet problem(s) in the asm of \"%v\"\n", len(diagnostics), fnname)
+							}
+						}
+						if *cfgfile != "" {
+							var buf bytes.Buffer
+							if err := fn.WriteCFG(&buf); err != nil {
+								log.Fatalf("Error writing CFG, \"%v\"\n", err.Err)
+							}
+							cfgs += buf.String()
+						}
+						if *jsonMode {
+							result := codegen.Result{Asm: asm}
+							_, _, proto := fn.GoProto()
+							result.Decl = strings.TrimSpace(proto)
+							result.Layout, _ = fn.Layout()
+							result.Stats, _ = fn.Stats()
+							jsonOut.Functions = append(jsonOut.Functions, codegen.NewJSONFunction(fnname, outfn, result))
+						} else if *printStats {
+							s, err := fn.Stats()
+							if err != nil {
+								log.Fatalf("Error computing stats, \"%v\"\n", err.Err)
+							}
+							stats = append(stats, s)
+						}
+						if *printCost && !*jsonMode {
+							report, err := fn.Cost(*cpu)
+							if err != nil {
+								log.Fatalf("Error estimating cost, \"%v\"\n", err.Err)
+							}
+							costs = append(costs, report)
+						}
+						if *output == "" && !*jsonMode {
 							fmt.Println(asm)
 						} else {
 							if *goprotofile != "" {
@@ -182,7 +441,43 @@ func main() {
 									protoImports = imports
 								}
 							}
-							assembly += asm
+							if *fallbackfile != "" {
+								pkg, imports, fallback, err := fn.GoFallback()
+								if err != nil {
+									log.Fatalf("Error creating fallback, \"%v\"\n", err.Err)
+								}
+								fallbacks += fallback
+								if protoPkgName == "" {
+									protoPkgName = pkg + "\n"
+								}
+								if fallbackImports == "" {
+									fallbackImports = imports
+								}
+							}
+							if *genericfile != "" {
+								pkg, imports, generic, err := fn.GoGeneric()
+								if err != nil {
+									log.Fatalf("Error copying function, \"%v\"\n", err.Err)
+								}
+								generics += generic
+								if protoPkgName == "" {
+									protoPkgName = pkg + "\n"
+								}
+								for _, imp := range strings.SplitAfter(imports, "\n") {
+									if !strings.Contains(genericImports, imp) {
+										genericImports += imp
+									}
+								}
+							}
+							asmFile.AddFunc(asm)
+							if *cabifile != "" {
+								centry, cdecl, err := fn.CEntry()
+								if err != nil {
+									log.Fatalf("Error creating C entry point, \"%v\"\n", err.Err)
+								}
+								asmFile.AddFunc(centry)
+								cdecls = append(cdecls, cdecl)
+							}
 						}
 					}
 				}
@@ -195,14 +490,103 @@ func main() {
 		panic(fmt.Sprintf(msg, filePkgName))
 	}
 
-	writeFile(*output, assembly)
+	if explaining {
+		return
+	}
+	if *printStats && !*jsonMode {
+		printStatsTable(os.Stdout, stats)
+	}
+	if *printCost && !*jsonMode {
+		printCostReports(os.Stdout, costs)
+	}
+	if *jsonMode {
+		if jsonOut.OK {
+			jsonOut.Asm = asmFile.String()
+		}
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		// keep && in build constraints readable
+		enc.SetEscapeHTML(false)
+		if err := enc.Encode(jsonOut); err != nil {
+			log.Fatalf("Error encoding JSON, \"%v\"\n", err)
+		}
+		if !jsonOut.OK {
+			os.Exit(1)
+		}
+		if *output == "" {
+			return
+		}
+	}
+	out.write(*output, asmFile.String())
+	if *cfgfile != "" {
+		out.write(*cfgfile, cfgs)
+	}
 	if *goprotofile != "" {
-		writeFile(*goprotofile, protoPkgName+"\n"+protoImports+"\n"+goprotos)
+		out.write(*goprotofile, buildLines+"\n"+protoPkgName+"\n"+protoImports+"\n"+goprotos)
+	}
+	if *fallbackfile != "" {
+		out.write(*fallbackfile, fallbackBuildLines+"\n"+protoPkgName+"\n"+fallbackImports+"\n"+fallbacks)
+	}
+	if *cabifile != "" {
+		out.write(*cabifile, codegen.CHeader(cHeaderGuard(*cabifile), cdecls))
+	}
+	if *genericfile != "" {
+		out.write(*genericfile, fallbackBuildLines+"\n"+protoPkgName+"\n"+genericImports+"\n"+generics)
 	}
+	out.finish()
 }
 
-func writeFile(filename, contents string) {
-	if err := ioutil.WriteFile(filename, []byte(contents), 0644); err != nil {
-		log.Fatalf("Cannot write to file \"%v\", error \"%v\"\n", filename, err)
+// cHeaderGuard returns the include guard macro of the C header filename,
+// e.g. SUM_AMD64_H for sum_amd64.h.
+func cHeaderGuard(filename string) string {
+	guard := []rune{}
+	for _, c := range strings.ToUpper(filepath.Base(filename)) {
+		if (c < 'A' || c > 'Z') && (c < '0' || c > '9') {
+			c = '_'
+		}
+		guard = append(guard, c)
+	}
+	return string(guard)
+}
+
+// printCostReports prints the estimated cost of each function and its loops.
+func printCostReports(w io.Writer, reports []codegen.CostReport) {
+	for _, r := range reports {
+		fmt.Fprintf(w, "%v on %v, critical path %v cycles\n", r.Name, r.CPU, r.Latency)
+		for _, loop := range r.Loops {
+			blocks := []string{}
+			for _, b := range loop.Blocks {
+				blocks = append(blocks, strconv.Itoa(b))
+			}
+			fmt.Fprintf(w, "  loop at block%v (blocks %v), %v instrs: %.2f cycles/iter, throughput bound %.2f, latency bound %.2f\n",
+				loop.Header, strings.Join(blocks, " "), loop.Instrs, loop.Cycles, loop.ThroughputBound, loop.LatencyBound)
+			if len(loop.CriticalPath) > 0 {
+				fmt.Fprintf(w, "    critical path: %v\n", strings.Join(loop.CriticalPath, " -> "))
+			}
+		}
+	}
+}
+
+// printStatsTable prints a row of stats per function with aligned columns.
+func printStatsTable(w io.Writer, stats []codegen.Stats) {
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+	fmt.Fprintf(tw, "function\tinstrs\tcycles\tframe\tspills\tvector %%\n")
+	for _, s := range stats {
+		fmt.Fprintf(tw, "%v\t%v\t%v\t%v\t%v\t%.1f\n", s.Name, s.Instrs, s.Cycles, s.FrameSize, s.Spills, s.VectorPercent)
+	}
+	tw.Flush()
+}
+
+// executableHash returns the hash of the gensimd binary, the version of its
+// cache entries.
+func executableHash() string {
+	exe, err := os.Executable()
+	if err != nil {
+		log.Fatalf("Error finding the gensimd executable for the cache, \"%v\"\n", err)
+	}
+	data, err := ioutil.ReadFile(exe)
+	if err != nil {
+		log.Fatalf("Error reading the gensimd executable for the cache, \"%v\"\n", err)
 	}
+	return fmt.Sprintf("%x", sha256.Sum256(data))
 }
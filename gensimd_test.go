@@ -0,0 +1,167 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+var (
+	buildOnce sync.Once
+	binDir    string
+	buildErr  error
+)
+
+func TestMain(m *testing.M) {
+	code := m.Run()
+	if binDir != "" {
+		os.RemoveAll(binDir)
+	}
+	os.Exit(code)
+}
+
+// gensimd runs the gensimd command built from this package in dir with args
+// and env added to the environment, it returns the combined output and
+// whether it succeeded.
+func gensimd(t *testing.T, dir string, env []string, args ...string) (string, bool) {
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("go command not found")
+	}
+	buildOnce.Do(func() {
+		if binDir, buildErr = ioutil.TempDir("", "gensimd"); buildErr != nil {
+			return
+		}
+		out, err := exec.Command("go", "build", "-o", filepath.Join(binDir, "gensimd"), ".").CombinedOutput()
+		if err != nil {
+			buildErr = fmt.Errorf("go build: %v\n%s", err, out)
+		}
+	})
+	if buildErr != nil {
+		t.Fatal(buildErr)
+	}
+	cmd := exec.Command(filepath.Join(binDir, "gensimd"), args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), env...)
+	out, err := cmd.CombinedOutput()
+	return string(out), err == nil
+}
+
+// readFile returns the contents of the file name in dir.
+func readFile(t *testing.T, dir, name string) string {
+	b, err := ioutil.ReadFile(filepath.Join(dir, name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(b)
+}
+
+// TestPackage generates the package testdata/codec with -pkg, checks each
+// exported function is in the assembly, the prototypes, and the fallbacks,
+// and that the flags generating single functions are rejected.
+func TestPackage(t *testing.T) {
+	outDir, err := ioutil.TempDir("", "codec")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(outDir)
+	src, err := filepath.Abs(filepath.Join("testdata", "codec"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out, ok := gensimd(t, outDir, nil, "-pkg", src, "-o", outDir); !ok {
+		t.Fatalf("gensimd -pkg failed:\n%v", out)
+	}
+	asm := readFile(t, outDir, "gensimd_amd64.s")
+	protos := readFile(t, outDir, "gensimd_amd64.go")
+	fallbacks := readFile(t, outDir, "gensimd_fallback.go")
+	renamed := readFile(t, outDir, "codec.go")
+	for _, fn := range []struct {
+		name, proto, fallback, generic string
+	}{
+		{"Add", "func Add(a int32, b int32) int32\n", "func Add(a int32, b int32) int32 { return addGeneric(a, b) }\n", "func addGeneric(a, b int32) int32 {"},
+		{"Scale", "func Scale(x []int32, k int32)\n", "func Scale(x []int32, k int32) { scaleGeneric(x, k) }\n", "func scaleGeneric(x []int32, k int32) {"},
+	} {
+		if !strings.Contains(asm, "TEXT ·"+fn.name+"(SB)") {
+			t.Errorf("%v isn't in the assembly:\n%v", fn.name, asm)
+		}
+		if !strings.Contains(protos, fn.proto) {
+			t.Errorf("the prototypes don't declare %q:\n%v", fn.proto, protos)
+		}
+		if !strings.Contains(fallbacks, fn.fallback) {
+			t.Errorf("the fallbacks don't have %q:\n%v", fn.fallback, fallbacks)
+		}
+		if !strings.Contains(renamed, fn.generic) || strings.Contains(renamed, "func "+fn.name+"(") {
+			t.Errorf("%v isn't renamed %q in the copy of the package:\n%v", fn.name, fn.generic, renamed)
+		}
+	}
+	// unexported functions are copied, not generated
+	if strings.Contains(asm, "clamp") || !strings.Contains(renamed, "func clamp(a int32) int32 {") {
+		t.Errorf("clamp is generated or renamed:\n%v\n%v", asm, renamed)
+	}
+
+	for _, test := range []struct {
+		args []string
+		msg  string
+	}{
+		{[]string{"-fn", "Add"}, "-fn and -json aren't supported with it"},
+		{[]string{"-json"}, "-fn and -json aren't supported with it"},
+		{[]string{"-cabi", filepath.Join(outDir, "codec.h")}, "-cabi isn't supported with -pkg"},
+		{nil, "-pkg needs an -o output directory"},
+	} {
+		args := append([]string{"-pkg", src}, test.args...)
+		if test.args != nil {
+			args = append(args, "-o", outDir)
+		}
+		if out, ok := gensimd(t, outDir, nil, args...); ok || !strings.Contains(out, test.msg) {
+			t.Errorf("gensimd %v succeeded %v with output %q, expected the error %q", strings.Join(args, " "), ok, out, test.msg)
+		}
+	}
+}
+
+// TestTargetBuildConstraint generates the package testdata/target, whose
+// Add is in an amd64 only file, from a darwin/arm64 host for each -target,
+// and checks the header and build constraint lines of the generated files.
+func TestTargetBuildConstraint(t *testing.T) {
+	src, err := filepath.Abs(filepath.Join("testdata", "target"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	host := []string{"GOOS=darwin", "GOARCH=arm64"}
+	for _, target := range []string{"sse2", "ssse3", "sse4.1", "avx", "avx2", "avx512vnni"} {
+		for _, goos := range []string{"", "linux"} {
+			outDir, err := ioutil.TempDir("", "target")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer os.RemoveAll(outDir)
+			args := []string{"-pkg", src, "-o", outDir, "-target", target}
+			constraint := "amd64 && !noasm && !appengine"
+			if goos != "" {
+				args = append(args, "-os", goos)
+				constraint += " && " + goos
+			}
+			if out, ok := gensimd(t, outDir, host, args...); !ok {
+				t.Errorf("gensimd %v failed:\n%v", strings.Join(args, " "), out)
+				continue
+			}
+			for file, goBuild := range map[string]string{
+				"gensimd_amd64.s":     "//go:build " + constraint,
+				"gensimd_amd64.go":    "//go:build " + constraint,
+				"gensimd_fallback.go": "//go:build !(" + constraint + ")",
+			} {
+				lines := strings.SplitN(readFile(t, outDir, file), "\n", 8)
+				if len(lines) < 8 || lines[4] != "// gensimd target: "+target || lines[6] != goBuild {
+					t.Errorf("-target %v -os %q: %v starts\n%v\nexpected the target line of %v and %q", target, goos, file, strings.Join(lines[:len(lines)-1], "\n"), target, goBuild)
+				}
+			}
+			if asm := readFile(t, outDir, "gensimd_amd64.s"); !strings.Contains(asm, "TEXT ·Add(SB)") || !strings.Contains(asm, "TEXT ·Sub(SB)") {
+				t.Errorf("-target %v -os %q: the amd64 only Add or Sub isn't generated:\n%v", target, goos, asm)
+			}
+		}
+	}
+}
@@ -0,0 +1,170 @@
+// Package gensimdcheck defines an Analyzer reporting the constructs gensimd
+// can't compile in the functions it generates assembly for, so editors and
+// CI flag them before the generation step runs.
+package gensimdcheck
+
+import (
+	"go/ast"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/bjwbell/gensimd/codegen"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/buildssa"
+)
+
+const doc = `check functions generated by gensimd use only supported constructs
+
+A function is generated by gensimd if it's named by the -fn flag of a
+"//go:generate gensimd" line whose -f flag is the function's file, or if its
+doc comment has a //gensimd: directive, e.g. //gensimd:noalias. Each
+construct gensimd can't compile, e.g. go statements, channels, closures,
+and function calls other than to simd intrinsics, is reported at its
+position. The -f file must be in the analyzed build, e.g. files constrained
+to "!amd64" need GOARCH set accordingly.`
+
+// Analyzer reports the unsupported constructs of functions generated by gensimd.
+var Analyzer = &analysis.Analyzer{
+	Name:     "gensimdcheck",
+	Doc:      doc,
+	Requires: []*analysis.Analyzer{buildssa.Analyzer},
+	Run:      run,
+}
+
+func run(pass *analysis.Pass) (interface{}, error) {
+	generated := generatedFuncs(pass)
+	for _, fn := range pass.ResultOf[buildssa.Analyzer].(*buildssa.SSA).SrcFuncs {
+		decl, ok := fn.Syntax().(*ast.FuncDecl)
+		if !ok || !generated[decl] {
+			continue
+		}
+		for _, err := range codegen.Unsupported(fn) {
+			pos := err.Pos
+			if !pos.IsValid() {
+				pos = decl.Name.Pos()
+			}
+			pass.Reportf(pos, "gensimd %v: %v", fn.Name(), err.Err)
+		}
+	}
+	return nil, nil
+}
+
+// generatedFuncs returns the function declarations of pass that gensimd
+// generates assembly for.
+func generatedFuncs(pass *analysis.Pass) map[*ast.FuncDecl]bool {
+	// function names by the path of their file
+	names := map[string]map[string]bool{}
+	for _, file := range pass.Files {
+		filename := pass.Fset.File(file.Pos()).Name()
+		for _, group := range file.Comments {
+			for _, comment := range group.List {
+				fns, src, ok := generateArgs(comment.Text)
+				if !ok {
+					continue
+				}
+				src = strings.Replace(src, "$GOFILE", filepath.Base(filename), -1)
+				src = filepath.Join(filepath.Dir(filename), src)
+				if names[src] == nil {
+					names[src] = map[string]bool{}
+				}
+				for _, fn := range fns {
+					names[src][fn] = true
+				}
+			}
+		}
+	}
+	generated := map[*ast.FuncDecl]bool{}
+	for _, file := range pass.Files {
+		filename := filepath.Clean(pass.Fset.File(file.Pos()).Name())
+		for _, d := range file.Decls {
+			decl, ok := d.(*ast.FuncDecl)
+			if !ok || decl.Recv != nil {
+				continue
+			}
+			if names[filename][decl.Name.Name] || hasGensimdDirective(decl) {
+				generated[decl] = true
+			}
+		}
+	}
+	return generated
+}
+
+// hasGensimdDirective returns true if the doc comment of decl has a
+// //gensimd: directive.
+func hasGensimdDirective(decl *ast.FuncDecl) bool {
+	if decl.Doc == nil {
+		return false
+	}
+	for _, comment := range decl.Doc.List {
+		if strings.HasPrefix(comment.Text, "//gensimd:") {
+			return true
+		}
+	}
+	return false
+}
+
+// generateArgs returns the -fn function names and the -f file of the
+// go:generate comment running gensimd, ok is false for other comments.
+func generateArgs(comment string) (fns []string, file string, ok bool) {
+	if !strings.HasPrefix(comment, "//go:generate ") {
+		return nil, "", false
+	}
+	args := splitArgs(strings.TrimPrefix(comment, "//go:generate "))
+	if len(args) == 0 || filepath.Base(args[0]) != "gensimd" {
+		return nil, "", false
+	}
+	for i := 1; i < len(args); i++ {
+		name := strings.TrimLeft(args[i], "-")
+		value := ""
+		if eq := strings.Index(name, "="); eq >= 0 {
+			name, value = name[:eq], name[eq+1:]
+		} else if (name == "fn" || name == "f") && i+1 < len(args) {
+			i++
+			value = args[i]
+		}
+		switch name {
+		case "fn":
+			for _, fn := range strings.Split(value, ",") {
+				fns = append(fns, strings.TrimSpace(fn))
+			}
+		case "f":
+			file = value
+		}
+	}
+	return fns, file, file != "" && len(fns) > 0
+}
+
+// splitArgs splits the arguments of a go:generate line like go generate,
+// at spaces except in double quoted Go strings.
+func splitArgs(line string) []string {
+	var args []string
+	for {
+		line = strings.TrimLeft(line, " \t")
+		if line == "" {
+			return args
+		}
+		end := strings.IndexAny(line, " \t")
+		if line[0] == '"' {
+			for end = 1; end < len(line) && line[end] != '"'; end++ {
+				if line[end] == '\\' {
+					end++
+				}
+			}
+			end++
+			if end > len(line) {
+				end = len(line)
+			}
+		}
+		if end < 0 {
+			end = len(line)
+		}
+		arg := line[:end]
+		if unquoted, err := strconv.Unquote(arg); err == nil {
+			arg = unquoted
+		}
+		args = append(args, arg)
+		line = line[end:]
+	}
+}
@@ -0,0 +1,13 @@
+package gensimdcheck_test
+
+import (
+	"testing"
+
+	"github.com/bjwbell/gensimd/gensimdcheck"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestAnalyzer(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), gensimdcheck.Analyzer, "a")
+}
@@ -0,0 +1,42 @@
+package a
+
+//go:generate gensimd -fn "sum, spawn" -outfn "sums, spawns" -f "$GOFILE" -o "a_amd64.s"
+
+func sum(x []int32) int32 {
+	s := int32(0)
+	for i := 0; i < len(x); i++ {
+		s += x[i]
+	}
+	return s
+}
+
+func spawn(x int) int {
+	c := make(chan int, 1) // want `gensimd spawn: channels unsupported`
+	c <- x                 // want `gensimd spawn: select/send unsupported`
+	return <-c             // want `gensimd spawn: channel receive unsupported`
+}
+
+//gensimd:noalias
+func closure(x int) int { // want `gensimd closure: Heap allocations are unsupported`
+	f := func() int { return x } // want `gensimd closure: closures unsupported`
+	return f()                   // want `gensimd closure: function calls are not supported`
+}
+
+// notGenerated isn't generated by gensimd so it isn't checked.
+func notGenerated(x int) int {
+	go func() {}()
+	return x
+}
+
+//go:generate gensimd -fn "setPtr, swapLocal" -outfn "setPtrs, swapLocals" -f "$GOFILE" -o "ptr_amd64.s"
+
+func setPtr(dst []*int32, p *int32) {
+	dst[0] = p // want `gensimd setPtr: storing pointers unsupported, the store of p \(type \*int32\) through t0 may be to the heap`
+}
+
+// swapLocal only stores pointers to its local array.
+func swapLocal(p, q *int32) int32 {
+	a := [2]*int32{p, q}
+	a[0], a[1] = a[1], a[0]
+	return *a[0]
+}
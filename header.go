@@ -0,0 +1,95 @@
+package main
+
+import (
+	"crypto/sha256"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"path/filepath"
+	"runtime/debug"
+	"strconv"
+	"strings"
+)
+
+// gensimdVersion returns the module version and VCS revision of the gensimd
+// binary, "devel" for the version of a binary built in its source tree.
+func gensimdVersion() string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return "devel"
+	}
+	version := info.Main.Version
+	if version == "" || version == "(devel)" {
+		version = "devel"
+	}
+	revision, modified := "", false
+	for _, setting := range info.Settings {
+		switch setting.Key {
+		case "vcs.revision":
+			revision = setting.Value
+		case "vcs.modified":
+			modified = setting.Value == "true"
+		}
+	}
+	if len(revision) > 12 {
+		revision = revision[:12]
+	}
+	if revision != "" && modified {
+		revision += "-dirty"
+	}
+	if revision != "" {
+		version += " (" + revision + ")"
+	}
+	return version
+}
+
+// isDiagnosticFlag returns whether the flag name only prints information or
+// changes how the files are generated, not what's generated, so it's left
+// out of the command line in the header.
+func isDiagnosticFlag(name string) bool {
+	switch name {
+	case "verify", "cache", "watch", "trace", "spills", "stats", "cost", "cpu", "json", "ssa",
+		"dump-after", "dump-ssa", "dump-liveness", "dump-frames", "dump-pressure":
+		return true
+	}
+	return false
+}
+
+// commandLine returns the gensimd command line of the flags set, in name
+// order, without the diagnostic flags.
+func commandLine() string {
+	cmd := "gensimd"
+	flag.Visit(func(f *flag.Flag) {
+		if isDiagnosticFlag(f.Name) {
+			return
+		}
+		value := f.Value.String()
+		if b, ok := f.Value.(interface{ IsBoolFlag() bool }); ok && b.IsBoolFlag() && value == "true" {
+			cmd += " -" + f.Name
+			return
+		}
+		if value == "" || strings.ContainsAny(value, " \t\"'\\$") {
+			value = strconv.Quote(value)
+		}
+		cmd += " -" + f.Name + " " + value
+	})
+	return cmd
+}
+
+// sourceHash returns "sha256:" and the hex SHA-256 of the contents of
+// files, each preceded by its base name if there's more than one.
+func sourceHash(files ...string) string {
+	h := sha256.New()
+	for _, file := range files {
+		data, err := ioutil.ReadFile(file)
+		if err != nil {
+			log.Fatalf("Error reading \"%v\" to hash it, \"%v\"\n", file, err)
+		}
+		if len(files) > 1 {
+			fmt.Fprintf(h, "%v\n", filepath.Base(file))
+		}
+		h.Write(data)
+	}
+	return fmt.Sprintf("sha256:%x", h.Sum(nil))
+}
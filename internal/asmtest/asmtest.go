@@ -0,0 +1,359 @@
+// Package asmtest runs generated assembly in tests. It generates the
+// assembly of Go functions, builds it with the Go toolchain into a plugin
+// along with the Go declarations, and loads the plugin, so tests call the
+// generated functions in process without a go generate step.
+//
+// Plugins need cgo and linux, darwin, or freebsd on amd64, tests are
+// skipped elsewhere.
+package asmtest
+
+import (
+	"fmt"
+	"go/build"
+	"go/parser"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"plugin"
+	"reflect"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/bjwbell/gensimd/codegen"
+
+	"golang.org/x/tools/go/loader"
+	"golang.org/x/tools/go/ssa"
+	"golang.org/x/tools/go/ssa/ssautil"
+)
+
+// Kernels are the generated functions loaded from a plugin, by Go function name.
+type Kernels map[string]reflect.Value
+
+// modulePath is the import path of gensimd.
+const modulePath = "github.com/bjwbell/gensimd"
+
+// kernelsVar is the plugin variable mapping the function names to the functions.
+const kernelsVar = "Kernels"
+
+// hostOnlyPkgs are the packages a plugin mustn't link. The test binary links
+// them through codegen, a plugin linking them too adds its own itabs for the
+// go/ast node types, and once the runtime grows its itab table the host's type
+// switches on ast nodes can go wrong, e.g. ast.Walk panicking on a valid node.
+// The packages the kernels import, like simd, must not import them.
+var hostOnlyPkgs = map[string]bool{"go/ast": true, "go/parser": true, "go/types": true}
+
+// plugins counts the plugins built, each gets a unique plugin path.
+var plugins struct {
+	sync.Mutex
+	n int
+}
+
+// Build generates the assembly of the functions fnnames in the Go file
+// filename with opts, builds it into a plugin, and loads it. The generated
+// functions have the names of the Go functions, opts.OutName is ignored.
+func Build(t testing.TB, filename string, opts codegen.Options, fnnames ...string) Kernels {
+	t.Helper()
+	skipUnsupported(t)
+	fns := loadFuncs(t, filename, opts, fnnames)
+
+	dir, err := ioutil.TempDir("", "asmtest")
+	if err != nil {
+		t.Fatalf("asmtest: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	asm := codegen.NewFile("")
+	protos := []string{}
+	imports := map[string]bool{}
+	for _, fn := range fns {
+		opts.OutName = fn.Name()
+		f, err := codegen.CreateFunction(fn, opts)
+		if err != nil {
+			t.Fatalf("asmtest: %v", err.Err)
+		}
+		fnasm, err := f.GoAssembly()
+		if err != nil {
+			t.Fatalf("asmtest: generating %v failed, %v: %v", fn.Name(), f.Position(err.Pos), err.Err)
+		}
+		asm.AddFunc(fnasm)
+		_, imp, proto := f.GoProto()
+		protos = append(protos, proto)
+		imports[imp] = true
+	}
+	writeFile(t, filepath.Join(dir, "kernels_amd64.s"), asm.String())
+	writeFile(t, filepath.Join(dir, "kernels.go"), pluginSource(fnnames, protos, imports))
+
+	plugins.Lock()
+	plugins.n++
+	pluginPath := fmt.Sprintf("asmtest%v", plugins.n)
+	plugins.Unlock()
+	so := filepath.Join(dir, pluginPath+".so")
+	env := pluginEnv(t, dir, pluginPath)
+	checkPluginDeps(t, dir, env)
+	cmd := exec.Command("go", "build", "-buildmode=plugin", "-ldflags=-pluginpath="+pluginPath, "-o", so)
+	cmd.Dir = dir
+	cmd.Env = env
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("asmtest: building plugin failed, %v\n%s", err, out)
+	}
+	p, err := plugin.Open(so)
+	if err != nil {
+		t.Fatalf("asmtest: %v", err)
+	}
+	sym, err := p.Lookup(kernelsVar)
+	if err != nil {
+		t.Fatalf("asmtest: %v", err)
+	}
+	kernels := Kernels{}
+	for name, fn := range *sym.(*map[string]interface{}) {
+		kernels[name] = reflect.ValueOf(fn)
+	}
+	return kernels
+}
+
+// RunC generates the functions fnnames of filename with their C entry
+// points, see codegen.Function.CEntry, and builds and runs a cgo program
+// whose preamble is csrc after the C header of the entry points. csrc must
+// define the C function run, main calls it. RunC returns the output of the
+// program. opts must have NoSplit set.
+func RunC(t testing.TB, filename string, opts codegen.Options, csrc string, fnnames ...string) string {
+	t.Helper()
+	skipUnsupported(t)
+	fns := loadFuncs(t, filename, opts, fnnames)
+
+	dir, err := ioutil.TempDir("", "asmtest")
+	if err != nil {
+		t.Fatalf("asmtest: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	asm := codegen.NewFile("")
+	protos := []string{}
+	imports := map[string]bool{}
+	decls := []string{}
+	for _, fn := range fns {
+		opts.OutName = fn.Name()
+		f, err := codegen.CreateFunction(fn, opts)
+		if err != nil {
+			t.Fatalf("asmtest: %v", err.Err)
+		}
+		fnasm, err := f.GoAssembly()
+		if err != nil {
+			t.Fatalf("asmtest: generating %v failed, %v: %v", fn.Name(), f.Position(err.Pos), err.Err)
+		}
+		centry, decl, err := f.CEntry()
+		if err != nil {
+			t.Fatalf("asmtest: C entry point of %v failed, %v", fn.Name(), err.Err)
+		}
+		asm.AddFunc(fnasm)
+		asm.AddFunc(centry)
+		decls = append(decls, decl)
+		_, imp, proto := f.GoProto()
+		protos = append(protos, proto)
+		imports[imp] = true
+	}
+	// cgo packages can't have Go assembly, the generated functions are in
+	// the package kernels
+	kdir := filepath.Join(dir, "kernels")
+	if err := os.Mkdir(kdir, 0755); err != nil {
+		t.Fatalf("asmtest: %v", err)
+	}
+	writeFile(t, filepath.Join(kdir, "kernels_amd64.s"), asm.String())
+	writeFile(t, filepath.Join(kdir, "kernels.go"), kernelsSource(protos, imports))
+	writeFile(t, filepath.Join(dir, "kernels.h"), codegen.CHeader("KERNELS_H", decls))
+
+	plugins.Lock()
+	plugins.n++
+	modPath := fmt.Sprintf("asmtest%v", plugins.n)
+	plugins.Unlock()
+	env := pluginEnv(t, dir, modPath)
+	kernelsPath := "./kernels"
+	if _, err := os.Stat(filepath.Join(dir, "go.mod")); err == nil {
+		kernelsPath = modPath + "/kernels"
+	}
+	writeFile(t, filepath.Join(dir, "main.go"), cmainSource(csrc, kernelsPath))
+	exe := filepath.Join(dir, modPath)
+	// the entry points are local symbols of the Go object, only the
+	// internal linker resolves the C references to them
+	cmd := exec.Command("go", "build", "-ldflags=-linkmode=internal", "-o", exe)
+	cmd.Dir = dir
+	cmd.Env = env
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("asmtest: building C program failed, %v\n%s", err, out)
+	}
+	out, err := exec.Command(exe).CombinedOutput()
+	if err != nil {
+		t.Fatalf("asmtest: running C program failed, %v\n%s", err, out)
+	}
+	return string(out)
+}
+
+// Call calls the generated function name with args and returns its results.
+func (kernels Kernels) Call(t testing.TB, name string, args ...interface{}) []interface{} {
+	t.Helper()
+	fn, ok := kernels[name]
+	if !ok {
+		t.Fatalf("asmtest: no generated function %v", name)
+	}
+	if fn.Type().NumIn() != len(args) {
+		t.Fatalf("asmtest: %v takes %v arguments, got %v", name, fn.Type().NumIn(), len(args))
+	}
+	in := make([]reflect.Value, len(args))
+	for i, arg := range args {
+		in[i] = reflect.ValueOf(arg)
+		if in[i].Type() != fn.Type().In(i) {
+			t.Fatalf("asmtest: argument %v of %v is %v, expected %v", i, name, in[i].Type(), fn.Type().In(i))
+		}
+	}
+	results := []interface{}{}
+	for _, out := range fn.Call(in) {
+		results = append(results, out.Interface())
+	}
+	return results
+}
+
+// skipUnsupported skips the test if plugins can't be built.
+func skipUnsupported(t testing.TB) {
+	t.Helper()
+	switch runtime.GOOS {
+	case "linux", "darwin", "freebsd":
+	default:
+		t.Skipf("asmtest: plugins unsupported on %v", runtime.GOOS)
+	}
+	if runtime.GOARCH != codegen.DefaultArch {
+		t.Skipf("asmtest: generated assembly is %v, not %v", codegen.DefaultArch, runtime.GOARCH)
+	}
+	if !build.Default.CgoEnabled {
+		t.Skip("asmtest: plugins need cgo")
+	}
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("asmtest: go command not found")
+	}
+}
+
+// loadFuncs type checks filename with opts.Sizes and returns the ssa of
+// fnnames, of every function declared in filename if fnnames is nil.
+func loadFuncs(t testing.TB, filename string, opts codegen.Options, fnnames []string) []*ssa.Function {
+	t.Helper()
+	conf := loader.Config{Build: &build.Default, ParserMode: parser.ParseComments}
+	conf.TypeChecker.Sizes = opts.Sizes
+	if conf.TypeChecker.Sizes == nil {
+		conf.TypeChecker.Sizes = codegen.DefaultSizes()
+	}
+	conf.CreateFromFilenames(filepath.Dir(filename), filename)
+	iprog, err := conf.Load()
+	if err != nil {
+		t.Fatalf("asmtest: loading %v failed, %v", filename, err)
+	}
+	prog := ssautil.CreateProgram(iprog, codegen.BuilderMode)
+	pkg := prog.Package(iprog.Created[0].Pkg)
+	pkg.Build()
+	if fnnames == nil {
+		return fileFuncs(pkg)
+	}
+	fns := []*ssa.Function{}
+	for _, name := range fnnames {
+		fn := pkg.Func(name)
+		if fn == nil {
+			t.Fatalf("asmtest: func %v not found in %v", name, filename)
+		}
+		fns = append(fns, fn)
+	}
+	return fns
+}
+
+// pluginSource returns the plugin's Go file, the prototypes of the
+// generated functions and the Kernels variable.
+func pluginSource(fnnames, protos []string, imports map[string]bool) string {
+	src := "package main\n\n"
+	sorted := []string{}
+	for imp := range imports {
+		sorted = append(sorted, imp)
+	}
+	sort.Strings(sorted)
+	src += strings.Join(sorted, "")
+	src += "\n" + strings.Join(protos, "") + "\n"
+	src += "var " + kernelsVar + " = map[string]interface{}{\n"
+	for _, name := range fnnames {
+		src += fmt.Sprintf("\t%q: %v,\n", name, name)
+	}
+	return src + "}\n"
+}
+
+// kernelsSource returns the Go file of the package of the generated
+// functions of a RunC program.
+func kernelsSource(protos []string, imports map[string]bool) string {
+	src := "package kernels\n\n"
+	sorted := []string{}
+	for imp := range imports {
+		sorted = append(sorted, imp)
+	}
+	sort.Strings(sorted)
+	src += strings.Join(sorted, "")
+	return src + "\n" + strings.Join(protos, "")
+}
+
+// cmainSource returns the Go file of a RunC program, csrc in its cgo
+// preamble after the header of the C entry points, the import of the
+// generated functions at kernelsPath, and main calling the C function run.
+// The entry points are declared weak, cgo checks the preamble links without
+// them.
+func cmainSource(csrc, kernelsPath string) string {
+	src := "package main\n\n"
+	src += "/*\n#include <stdio.h>\n#define GENSIMD_CABI __attribute__((weak))\n#include \"kernels.h\"\n\n"
+	src += csrc + "\n*/\nimport \"C\"\n\n"
+	src += fmt.Sprintf("import _ %q\n\n", kernelsPath)
+	return src + "func main() {\n\tC.run()\n\tC.fflush(C.stdout)\n}\n"
+}
+
+// pluginEnv returns the environment building the plugin in dir with the
+// gensimd packages the test is built with. In module mode dir is made a
+// module replacing gensimd with the test's module, otherwise the packages are
+// imported from GOPATH.
+func pluginEnv(t testing.TB, dir, pluginPath string) []string {
+	t.Helper()
+	out, err := exec.Command("go", "env", "GOMOD").Output()
+	if err != nil {
+		t.Fatalf("asmtest: go env GOMOD failed, %v", err)
+	}
+	gomod := strings.TrimSpace(string(out))
+	if gomod == "" || gomod == os.DevNull {
+		return append(os.Environ(), "GO111MODULE=off")
+	}
+	root := filepath.Dir(gomod)
+	mod := fmt.Sprintf("module %v\n\nrequire %v v0.0.0\n\nreplace %v => %v\n", pluginPath, modulePath, modulePath, root)
+	writeFile(t, filepath.Join(dir, "go.mod"), mod)
+	if sum, err := ioutil.ReadFile(filepath.Join(root, "go.sum")); err == nil {
+		writeFile(t, filepath.Join(dir, "go.sum"), string(sum))
+	}
+	return append(os.Environ(), "GOFLAGS=-mod=mod")
+}
+
+// checkPluginDeps fails the test if the plugin in dir, built with env, links
+// any of hostOnlyPkgs.
+func checkPluginDeps(t testing.TB, dir string, env []string) {
+	t.Helper()
+	cmd := exec.Command("go", "list", "-deps", ".")
+	cmd.Dir = dir
+	cmd.Env = env
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("asmtest: listing the plugin's packages failed, %v\n%s", err, out)
+	}
+	for _, pkg := range strings.Fields(string(out)) {
+		if hostOnlyPkgs[pkg] {
+			t.Fatalf("asmtest: the plugin links %v, the packages of the kernels mustn't import it", pkg)
+		}
+	}
+}
+
+func writeFile(t testing.TB, filename, contents string) {
+	t.Helper()
+	if err := ioutil.WriteFile(filename, []byte(contents), 0644); err != nil {
+		t.Fatalf("asmtest: %v", err)
+	}
+}
@@ -0,0 +1,22 @@
+package asmtest
+
+import (
+	"testing"
+
+	"github.com/bjwbell/gensimd/codegen"
+	"github.com/bjwbell/gensimd/simd"
+)
+
+func TestBuild(t *testing.T) {
+	kernels := Build(t, "testdata/kernels.go", codegen.DefaultOptions(), "sum", "addi32x4")
+
+	x := []int32{1, 2, 3, 4, 5}
+	if got := kernels.Call(t, "sum", x)[0].(int32); got != 15 {
+		t.Errorf("sum(%v) = %v, expected 15", x, got)
+	}
+	a, b := simd.I32x4{1, 2, 3, 4}, simd.I32x4{10, 20, 30, 40}
+	expected := simd.I32x4{11, 22, 33, 44}
+	if got := kernels.Call(t, "addi32x4", a, b)[0].(simd.I32x4); got != expected {
+		t.Errorf("addi32x4(%v, %v) = %v, expected %v", a, b, got, expected)
+	}
+}
@@ -0,0 +1,107 @@
+package asmtest
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/bjwbell/gensimd/codegen"
+
+	"golang.org/x/tools/go/ssa"
+)
+
+// Target is a GOOS and GOARCH generated assembly is cross assembled for.
+type Target struct {
+	GOOS, GOARCH string
+}
+
+func (target Target) String() string {
+	return target.GOOS + "/" + target.GOARCH
+}
+
+// Targets returns every supported GOARCH with each GOOS of its port, the
+// matrix cross generated tests generate and assemble for.
+func Targets() []Target {
+	targets := []Target{}
+	for _, arch := range codegen.Archs() {
+		for _, goos := range codegen.OperatingSystems(arch) {
+			targets = append(targets, Target{GOOS: goos, GOARCH: arch})
+		}
+	}
+	return targets
+}
+
+// Assemble generates the functions fnnames of filename with opts for each
+// target and assembles them with go tool asm for the target's GOOS and
+// GOARCH, every function of filename codegen.Unsupported doesn't refuse if
+// fnnames is empty. Nothing is run, so the encoding of the assembly is
+// checked on any host. The generated functions have the names of the Go
+// functions.
+func Assemble(t testing.TB, targets []Target, filename string, opts codegen.Options, fnnames ...string) {
+	t.Helper()
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("asmtest: go command not found")
+	}
+	var fns []*ssa.Function
+	if len(fnnames) == 0 {
+		for _, fn := range loadFuncs(t, filename, opts, nil) {
+			if len(codegen.Unsupported(fn)) == 0 {
+				fns = append(fns, fn)
+			}
+		}
+	} else {
+		fns = loadFuncs(t, filename, opts, fnnames)
+	}
+	goroot, err := exec.Command("go", "env", "GOROOT").Output()
+	if err != nil {
+		t.Fatalf("asmtest: go env GOROOT failed, %v", err)
+	}
+	include := filepath.Join(strings.TrimSpace(string(goroot)), "pkg", "include")
+	dir, err := ioutil.TempDir("", "asmtest")
+	if err != nil {
+		t.Fatalf("asmtest: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	for _, target := range targets {
+		opts.Arch, opts.OS = target.GOARCH, target.GOOS
+		asm := codegen.NewFile("")
+		for _, fn := range fns {
+			opts.OutName = fn.Name()
+			f, err := codegen.CreateFunction(fn, opts)
+			if err != nil {
+				t.Fatalf("asmtest: %v: %v", target, err.Err)
+			}
+			fnasm, err := f.GoAssembly()
+			if err != nil {
+				t.Fatalf("asmtest: %v: generating %v failed, %v: %v", target, fn.Name(), f.Position(err.Pos), err.Err)
+			}
+			asm.AddFunc(fnasm)
+		}
+		sfile := filepath.Join(dir, fmt.Sprintf("kernels_%v_%v.s", target.GOOS, target.GOARCH))
+		writeFile(t, sfile, asm.String())
+		cmd := exec.Command("go", "tool", "asm", "-p", "kernels", "-I", include, "-o", strings.TrimSuffix(sfile, ".s")+".o", sfile)
+		cmd.Env = append(os.Environ(), "GOOS="+target.GOOS, "GOARCH="+target.GOARCH)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Errorf("asmtest: %v: assembling %v failed, %v\n%s", target, filename, err, out)
+		}
+	}
+}
+
+// fileFuncs returns the functions declared in pkg in source order, not the
+// package initializer or other synthetic ones.
+func fileFuncs(pkg *ssa.Package) []*ssa.Function {
+	fns := []*ssa.Function{}
+	for _, member := range pkg.Members {
+		if fn, ok := member.(*ssa.Function); ok && fn.Synthetic == "" {
+			fns = append(fns, fn)
+		}
+	}
+	sort.Slice(fns, func(i, j int) bool { return fns[i].Pos() < fns[j].Pos() })
+	return fns
+}
@@ -0,0 +1,15 @@
+package kernels
+
+import "github.com/bjwbell/gensimd/simd"
+
+func sum(x []int32) int32 {
+	s := int32(0)
+	for i := 0; i < len(x); i++ {
+		s += x[i]
+	}
+	return s
+}
+
+func addi32x4(x, y simd.I32x4) simd.I32x4 {
+	return simd.AddI32x4(x, y)
+}
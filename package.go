@@ -0,0 +1,188 @@
+package main
+
+import (
+	"bytes"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/types"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/bjwbell/gensimd/codegen"
+
+	"golang.org/x/tools/go/loader"
+	"golang.org/x/tools/go/ssa"
+	"golang.org/x/tools/go/ssa/ssautil"
+)
+
+// genericSuffix is added to the unexported name of each exported function
+// in the copy of a -pkg package, the fallback calls it.
+const genericSuffix = "Generic"
+
+// generatePackage generates every exported function of the package in dir
+// into a copy of the package in outDir, which has the same API with the
+// functions in assembly. The copy's Go files are the package's with each
+// exported function F renamed fGeneric, the assembly and prototypes of F
+// are built with constraint, and fallbacks calling fGeneric with the
+// inverse constraint. configure sets the options of each function, out
+// writes the files.
+func generatePackage(dir, outDir, constraint string, opts codegen.Options, configure func(*codegen.Function), out *outputs) {
+	if abs(dir) == abs(outDir) {
+		log.Fatalf("Error -pkg output directory \"%v\" is the package directory, the package would be overwritten\n", outDir)
+	}
+	// the target's files, e.g. the amd64 only ones, whatever the host
+	ctxt := targetContext(opts)
+	bpkg, err := ctxt.ImportDir(dir, 0)
+	if err != nil {
+		log.Fatalf("Error reading package \"%v\", error msg \"%v\"\n", dir, err)
+	}
+	files := []string{}
+	for _, name := range bpkg.GoFiles {
+		files = append(files, filepath.Join(dir, name))
+	}
+	out.header.Source, out.header.SourceHash = dir, sourceHash(files...)
+	conf := loader.Config{Build: ctxt, ParserMode: parser.ParseComments}
+	conf.TypeChecker.Sizes = opts.Sizes
+	conf.CreateFromFilenames(bpkg.ImportPath, files...)
+	iprog, err := conf.Load()
+	if err != nil {
+		log.Fatalf("conf.Load, error msg \"%v\"", err)
+	}
+	prog := ssautil.CreateProgram(iprog, codegen.BuilderMode)
+	info := iprog.Created[0]
+	pkg := prog.Package(info.Pkg)
+	pkg.Build()
+
+	names := []string{}
+	for name, member := range pkg.Members {
+		if _, ok := member.(*ssa.Function); ok && ast.IsExported(name) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	if len(names) == 0 {
+		log.Fatalf("Error no exported functions in package \"%v\"\n", dir)
+	}
+	unsupported := 0
+	for _, name := range names {
+		fn := pkg.Func(name)
+		for _, err := range codegen.Unsupported(fn) {
+			unsupported++
+			if position := prog.Fset.Position(err.Pos); position.IsValid() {
+				log.Printf("Error unsupported, %v, \"%v\"\n", position, err.Err)
+			} else {
+				log.Printf("Error unsupported in \"%v\", \"%v\"\n", name, err.Err)
+			}
+		}
+	}
+	if unsupported > 0 {
+		log.Fatalf("Error %v unsupported construct(s) in package \"%v\", every exported function must be supported\n", unsupported, dir)
+	}
+
+	buildLines, err := codegen.BuildConstraint(constraint)
+	if err != nil {
+		log.Fatalf("Error parsing build constraint \"%v\", error msg \"%v\"\n", constraint, err)
+	}
+	fallbackBuildLines, err := codegen.InverseBuildConstraint(constraint)
+	if err != nil {
+		log.Fatalf("Error parsing build constraint \"%v\", error msg \"%v\"\n", constraint, err)
+	}
+	asmFile := codegen.NewFile(buildLines)
+	pkgClause, imports, protos, fallbacks := "", "", "", ""
+	fallbackImports := ""
+	renames := map[types.Object]string{}
+	for _, name := range names {
+		ssaFn := pkg.Func(name)
+		generic := genericName(name)
+		if info.Pkg.Scope().Lookup(generic) != nil {
+			log.Fatalf("Error can't rename \"%v\" to \"%v\", it's already declared in package \"%v\"\n", name, generic, dir)
+		}
+		renames[ssaFn.Object()] = generic
+		opts.OutName = name
+		fn, err := codegen.CreateFunction(ssaFn, opts)
+		if err != nil {
+			log.Fatalf("codegen error msg \"%v\"", err.Err)
+		}
+		configure(fn)
+		asm, err := fn.GoAssembly()
+		if err != nil {
+			if position := fn.Position(err.Pos); position.IsValid() {
+				log.Fatalf("Error creating fn asm, %v, \"%v\"\n", position, err.Err)
+			}
+			log.Fatalf("Error creating fn asm: \"%v\"\n", err.Err)
+		}
+		asmFile.AddFunc(asm)
+		clause, imps, proto := fn.GoProto()
+		protos += proto
+		_, fallbackImps, fallback, err := fn.GoFallbackTo(generic)
+		if err != nil {
+			log.Fatalf("Error creating fallback, \"%v\"\n", err.Err)
+		}
+		fallbacks += fallback
+		pkgClause = clause
+		if !strings.Contains(imports, imps) {
+			imports += imps
+		}
+		if !strings.Contains(fallbackImports, fallbackImps) {
+			fallbackImports += fallbackImps
+		}
+	}
+
+	if !out.verify {
+		if err := os.MkdirAll(outDir, 0755); err != nil {
+			log.Fatalf("Error creating -pkg output directory \"%v\", error msg \"%v\"\n", outDir, err)
+		}
+	}
+	for _, file := range info.Files {
+		filename := prog.Fset.Position(file.Pos()).Filename
+		out.write(filepath.Join(outDir, filepath.Base(filename)), renamedSource(prog, info, file, renames))
+	}
+	out.write(filepath.Join(outDir, "gensimd_amd64.s"), asmFile.String())
+	out.write(filepath.Join(outDir, "gensimd_amd64.go"), buildLines+"\n"+pkgClause+"\n"+imports+"\n"+protos)
+	out.write(filepath.Join(outDir, "gensimd_fallback.go"), fallbackBuildLines+"\n"+pkgClause+"\n"+fallbackImports+"\n"+fallbacks)
+}
+
+// renamedSource returns the source of file with the declarations of and
+// references to the objects of renames renamed.
+func renamedSource(prog *ssa.Program, info *loader.PackageInfo, file *ast.File, renames map[types.Object]string) string {
+	renamed := map[*ast.Ident]string{}
+	ast.Inspect(file, func(n ast.Node) bool {
+		if ident, ok := n.(*ast.Ident); ok {
+			if name, ok := renames[info.ObjectOf(ident)]; ok {
+				renamed[ident] = ident.Name
+				ident.Name = name
+			}
+		}
+		return true
+	})
+	var buf bytes.Buffer
+	err := format.Node(&buf, prog.Fset, file)
+	for ident, name := range renamed {
+		ident.Name = name
+	}
+	if err != nil {
+		log.Fatalf("Error formatting \"%v\", error msg \"%v\"\n", prog.Fset.Position(file.Pos()).Filename, err)
+	}
+	return buf.String()
+}
+
+// genericName returns the name of the Go version of the exported function
+// name in a -pkg package, e.g. "addGeneric" for "Add".
+func genericName(name string) string {
+	r, size := utf8.DecodeRuneInString(name)
+	return string(unicode.ToLower(r)) + name[size:] + genericSuffix
+}
+
+// abs returns the absolute path of dir, or dir if it has none.
+func abs(dir string) string {
+	if a, err := filepath.Abs(dir); err == nil {
+		return a
+	}
+	return dir
+}
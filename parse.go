@@ -1,4 +1,4 @@
-package simd
+package main
 
 import (
 	"errors"
@@ -12,6 +12,27 @@ import (
 	"strings"
 )
 
+type Error struct {
+	Err error
+	Pos token.Pos
+}
+
+// File holds a single parsed file and associated data.
+type File struct {
+	pathName string
+	ast      *ast.File // Parsed AST.
+	fs       *token.FileSet
+	Info     *types.Info
+	Pkg      *types.Package
+}
+
+func (f *File) ErrorLocation(err *Error) string {
+	if err == nil {
+		return ""
+	}
+	return f.fs.Position(err.Pos).String()
+}
+
 // check type-checks the package. The package must be OK to proceed.
 func (f *File) Check() {
 	// TODO
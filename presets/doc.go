@@ -0,0 +1,7 @@
+// Package presets has kernels generated by gensimd, e.g. Memset32 and
+// SumInt64, ready to use. They're generated with -pkg from their Go source
+// in ref, which the fallbacks call on other architectures, and the tests
+// check the generator against the Go source.
+package presets
+
+//go:generate gensimd -pkg "ref" -o "."
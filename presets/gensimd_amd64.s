@@ -0,0 +1,2510 @@
+// Code generated by gensimd. DO NOT EDIT.
+// gensimd version: devel
+// gensimd command: gensimd -o . -pkg ref
+// gensimd source: ref sha256:1b94b12a9f87f9569740351175fe66cefd4251de0cec5a0c9ab54c55cd915627
+// gensimd target: avx2
+
+//go:build amd64 && !noasm && !appengine
+// +build amd64,!noasm,!appengine
+
+#include "textflag.h"
+
+TEXT ·ByteReverse(SB),$96-56
+block0:
+        // entry
+        MOVQ         src_len+32(FP), R15
+        MOVQ         R15, R13
+        MOVQ         dst_len+8(FP), R12
+        MOVQ         R12, R11
+        CMPQ         R11, R13
+        SETLT        R10
+        MOVQ         R13, t4-41(SP)
+        MOVB         R10, t2-25(SP)
+        MOVQ         R13, t0-16(SP)
+        CMPB         R10, $0
+        JEQ          block2
+block1:
+        // if.then, preds block0
+        MOVQ         dst_len+8(FP), R15
+        MOVQ         R15, R13
+        MOVQ         R13, t4-41(SP)
+        MOVQ         R13, t3-33(SP)
+block2:
+        // if.done, preds block0 block1
+        MOVQ         t4-41(SP), BX
+        MOVQ         BX, R15
+        MOVQ         R15, R13
+        SUBQ         $1, R13
+        MOVQ         R13, R8
+        MOVQ         $0, R12
+        MOVQ         R12, t5-49(SP)
+        MOVQ         dst_base+0(FP), R11
+        LEAQ         (R11)(R12*1), R11
+        MOVQ         R11, ivptr0-8(SP)
+        MOVQ         R13, t7-58(SP)
+block3:
+        // for.loop, preds block2 block4
+        MOVQ         t5-49(SP), R15
+        MOVQ         BX, R13
+        CMPQ         R15, R13
+        JGE          block5
+block4:
+        // for.body, preds block3
+        MOVQ         R8, R15
+        MOVQ         t5-49(SP), R13
+        MOVQ         R15, R12
+        SUBQ         R13, R12
+        MOVQ         src_base+24(FP), R11
+        LEAQ         (R11)(R12*1), R11
+        MOVB         (R11), R10
+        MOVB         R10, t10-75(SP)
+        MOVQ         ivptr0-8(SP), R10
+        MOVQ         R10, R9
+        MOVBQZX      t10-75(SP), R10
+        MOVB         R10, (R9)
+        MOVQ         R13, BP
+        ADDQ         $1, BP
+        MOVQ         BP, t5-49(SP)
+        MOVQ         ivptr0-8(SP), R13
+        LEAQ         1(R13), R13
+        MOVQ         R13, ivptr0-8(SP)
+        MOVQ         BP, t12-91(SP)
+        JMP block3
+block5:
+        // for.done, preds block3
+        MOVQ         BX, R15
+        MOVQ         R15, ret+48(FP)
+        RET
+
+TEXT ·FilterRangeInt64(SB),$376-72
+block0:
+        // entry
+        MOVQ         col_len+32(FP), R15
+        MOVQ         R15, R13
+        MOVQ         bitmap_len+8(FP), R12
+        MOVQ         R12, R11
+        MOVQ         $64, R10
+        MOVQ         R10, BP
+        MOVQ         BP, AX
+        IMULQ        R11
+        MOVQ         AX, BP
+        CMPQ         BP, R13
+        SETLT        DI
+        MOVQ         R13, t6-49(SP)
+        MOVB         DI, t3-25(SP)
+        MOVQ         R13, t0-8(SP)
+        CMPB         DI, $0
+        JEQ          block2
+block1:
+        // if.then, preds block0
+        MOVQ         bitmap_len+8(FP), R15
+        MOVQ         R15, R13
+        MOVQ         $64, R12
+        MOVQ         R12, R11
+        MOVQ         R11, AX
+        IMULQ        R13
+        MOVQ         AX, R11
+        MOVQ         R11, t6-49(SP)
+        MOVQ         R11, t5-41(SP)
+block2:
+        // if.done, preds block0 block1
+        MOVQ         t6-49(SP), R9
+        MOVQ         lo+48(FP), R15
+        MOVQ         R15, X14
+        PUNPCKLQDQ    X14, X14
+        MOVO         X14, X0
+        MOVQ         hi+56(FP), R13
+        MOVQ         R13, X13
+        PUNPCKLQDQ    X13, X13
+        MOVO         X13, X1
+        MOVQ         $0, R12
+        MOVQ         R12, t10-90(SP)
+        MOVOU        X13, t8-81(SP)
+        MOVOU        X14, t7-65(SP)
+block5:
+        // for.loop, preds block2 block8
+        MOVQ         t10-90(SP), R8
+        MOVQ         R8, R15
+        MOVQ         R15, R13
+        ADDQ         $64, R13
+        MOVQ         R9, R12
+        CMPQ         R13, R12
+        JGT          block4
+block3:
+        // for.body, preds block5
+        MOVQ         $0, R15
+        MOVQ         R15, t13-107(SP)
+        MOVQ         $0, R13
+        MOVQ         R13, t14-115(SP)
+        MOVQ         t13-107(SP), BX
+block6:
+        // for.loop, preds block3 block7
+        MOVQ         t14-115(SP), R15
+        CMPQ         R15, $64
+        JGE          block8
+block7:
+        // for.body, preds block6
+        MOVQ         R8, R15
+        MOVQ         t14-115(SP), R13
+        MOVQ         R15, R12
+        ADDQ         R13, R12
+        MOVQ         col_base+24(FP), R11
+        MOVOU        (R11)(R12*8), X14
+        MOVO         X0, X13
+        MOVO         X13, X12
+        PCMPGTQ      X14, X12
+        MOVO         X1, X11
+        MOVO         X14, X10
+        PCMPGTQ      X11, X10
+        MOVO         X12, X9
+        POR          X10, X9
+        MOVMSKPD     X9, R10
+        XORQ         $3, R10
+        MOVQ         R10, BP
+        MOVQ         R13, DI
+        XORQ         SI, SI
+        CMPQ         DI, $64
+        SHLXQ        DI, BP, BP
+        CMOVQCC      SI, BP
+        MOVQ         BX, SI
+        MOVQ         BP, DI
+        ORQ          SI, DI
+        MOVQ         DI, BX
+        MOVQ         R13, SI
+        ADDQ         $2, SI
+        MOVQ         SI, t14-115(SP)
+        MOVQ         SI, t27-244(SP)
+        JMP block6
+block4:
+        // for.done, preds block5
+        MOVQ         R8, R15
+        MOVQ         R9, R13
+        CMPQ         R15, R13
+        JGE          block10
+block9:
+        // if.then, preds block4
+        MOVQ         $0, R15
+        MOVQ         R15, t31-276(SP)
+        MOVQ         $0, R13
+        MOVQ         R13, t32-284(SP)
+block11:
+        // for.loop, preds block9 block15
+        MOVQ         R8, R15
+        MOVQ         t32-284(SP), R13
+        MOVQ         R15, R12
+        ADDQ         R13, R12
+        MOVQ         R9, R11
+        CMPQ         R12, R11
+        JGE          block13
+block12:
+        // for.body, preds block11
+        MOVQ         R8, R15
+        MOVQ         t32-284(SP), R13
+        MOVQ         R15, R12
+        ADDQ         R13, R12
+        MOVQ         col_base+24(FP), R11
+        LEAQ         (R11)(R12*8), R11
+        MOVQ         (R11), R10
+        MOVQ         R10, t37-317(SP)
+        MOVQ         lo+48(FP), R10
+        MOVQ         t37-317(SP), BP
+        CMPQ         R10, BP
+        SETLE        DI
+        MOVQ         t31-276(SP), SI
+        MOVQ         SI, t44-366(SP)
+        MOVB         DI, t38-318(SP)
+        CMPB         DI, $0
+        JEQ          block15
+block16:
+        // cond.true, preds block12
+        MOVQ         t37-317(SP), R15
+        MOVQ         hi+56(FP), R13
+        CMPQ         R15, R13
+        SETLE        R12
+        MOVQ         t31-276(SP), R11
+        MOVQ         R11, t44-366(SP)
+        MOVB         R12, t46-375(SP)
+        CMPB         R12, $0
+        JEQ          block15
+block14:
+        // if.then, preds block16
+        MOVQ         t32-284(SP), R15
+        MOVQ         R15, R13
+        MOVQ         $1, R12
+        XORQ         R10, R10
+        CMPQ         R13, $64
+        SHLXQ        R13, R12, R11
+        CMOVQCC      R10, R11
+        MOVQ         t31-276(SP), R10
+        MOVQ         R11, BP
+        ORQ          R10, BP
+        MOVQ         BP, t44-366(SP)
+        MOVQ         BP, t43-358(SP)
+block15:
+        // if.done, preds block12 block16 block14
+        MOVQ         t32-284(SP), R15
+        MOVQ         R15, R13
+        ADDQ         $1, R13
+        MOVQ         t44-366(SP), R12
+        MOVQ         R12, t31-276(SP)
+        MOVQ         R13, t32-284(SP)
+        MOVQ         R13, t45-374(SP)
+        JMP block11
+block8:
+        // for.done, preds block6
+        MOVQ         R8, R15
+        MOVQ         R15, R13
+        SARQ         $6, R13
+        MOVQ         bitmap_base+0(FP), R12
+        LEAQ         (R12)(R13*8), R12
+        MOVQ         BX, R11
+        MOVQ         R11, (R12)
+        MOVQ         R15, R10
+        ADDQ         $64, R10
+        MOVQ         R10, t10-90(SP)
+        MOVQ         R10, t30-268(SP)
+        JMP block5
+block10:
+        // if.done, preds block4 block13
+        MOVQ         R9, R15
+        MOVQ         R15, ret+64(FP)
+        RET
+block13:
+        // for.done, preds block11
+        MOVQ         R8, R15
+        MOVQ         R15, R13
+        SARQ         $6, R13
+        MOVQ         bitmap_base+0(FP), R12
+        LEAQ         (R12)(R13*8), R12
+        MOVQ         t31-276(SP), R11
+        MOVQ         R11, (R12)
+        JMP block10
+
+TEXT ·HexDecode(SB),$600-56
+block0:
+        // entry
+        MOVQ         src_len+32(FP), R15
+        MOVQ         R15, R13
+        MOVQ         $2, R12
+        MOVQ         R13, AX
+        MOVQ         AX, DX
+        SARQ         $63, DX
+        CMPQ         R12, $-1
+        JNE          lbl1
+        NEGQ         AX
+        XORQ         DX, DX
+        JMP          lbl2
+lbl1:
+        IDIVQ        R12
+lbl2:
+        MOVQ         AX, R13
+        MOVQ         dst_len+8(FP), R11
+        MOVQ         R11, R10
+        CMPQ         R10, R13
+        SETLT        R9
+        MOVQ         R13, t5-49(SP)
+        MOVB         R9, t3-33(SP)
+        MOVQ         R13, t1-24(SP)
+        CMPB         R9, $0
+        JEQ          block2
+block1:
+        // if.then, preds block0
+        MOVQ         dst_len+8(FP), R15
+        MOVQ         R15, R13
+        MOVQ         R13, t5-49(SP)
+        MOVQ         R13, t4-41(SP)
+block2:
+        // if.done, preds block0 block1
+        MOVQ         t5-49(SP), BX
+        MOVB         $48, R15
+        MOVBQZX      R15, R13
+        IMUL3Q       $16843009, R13, R13
+        MOVQ         R13, X14
+        PSHUFL       $0, X14, X14
+        MOVO         X14, X1
+        MOVB         $87, R13
+        MOVBQZX      R13, R12
+        IMUL3Q       $16843009, R12, R12
+        MOVQ         R12, X13
+        PSHUFL       $0, X13, X13
+        MOVB         $32, R12
+        MOVBQZX      R12, R11
+        IMUL3Q       $16843009, R11, R11
+        MOVQ         R11, X12
+        PSHUFL       $0, X12, X12
+        MOVO         X12, X0
+        MOVQ         $0, R11
+        MOVQ         R11, t25-314(SP)
+        MOVOU        X12, t8-97(SP)
+        MOVOU        X13, t7-81(SP)
+        MOVOU        X14, t6-65(SP)
+block4:
+        // for.loop, preds block2 block5
+        MOVQ         t25-314(SP), R15
+        MOVQ         R15, R13
+        ADDQ         $16, R13
+        MOVQ         BX, R12
+        CMPQ         R13, R12
+        SETLE        R11
+        MOVQ         R15, t49-558(SP)
+        MOVQ         dst_base+0(FP), R10
+        LEAQ         (R10)(R15*1), R10
+        MOVQ         R10, ivptr0-8(SP)
+        MOVB         R11, t27-323(SP)
+        CMPB         R11, $0
+        JEQ          block8
+block3:
+        // for.body, preds block4
+        MOVQ         $2, R15
+        MOVQ         t25-314(SP), R13
+        MOVQ         R15, R12
+        MOVQ         R12, AX
+        IMULQ        R13
+        MOVQ         AX, R12
+        MOVQ         src_base+24(FP), R11
+        MOVOU        (R11)(R12*1), X14
+        MOVQ         R15, R10
+        MOVQ         R10, AX
+        IMULQ        R13
+        MOVQ         AX, R10
+        ADDQ         $16, R10
+        MOVOU        (R11)(R10*1), X13
+        MOVO         X0, X12
+        MOVO         X14, X11
+        POR          X12, X11
+        MOVO         X13, X10
+        POR          X12, X10
+        MOVO         X14, X9
+        MOVOU        HexDecode_const0<>(SB), X8
+        PSUBB        X8, X9
+        MOVOU        HexDecode_const1<>(SB), X8
+        PMINUB       X9, X8
+        PCMPEQB      X8, X9
+        MOVO         X13, X8
+        MOVOU        HexDecode_const0<>(SB), X7
+        PSUBB        X7, X8
+        MOVOU        HexDecode_const1<>(SB), X7
+        PMINUB       X8, X7
+        PCMPEQB      X7, X8
+        MOVO         X11, X7
+        MOVOU        HexDecode_const2<>(SB), X6
+        PSUBB        X6, X7
+        MOVOU        HexDecode_const3<>(SB), X6
+        PMINUB       X7, X6
+        PCMPEQB      X6, X7
+        MOVO         X10, X6
+        MOVOU        HexDecode_const2<>(SB), X5
+        PSUBB        X5, X6
+        MOVOU        HexDecode_const3<>(SB), X5
+        PMINUB       X6, X5
+        PCMPEQB      X5, X6
+        MOVO         X9, X5
+        POR          X7, X5
+        MOVO         X8, X4
+        POR          X6, X4
+        MOVO         X5, X3
+        PAND         X4, X3
+        PMOVMSKB     X3, R9
+        CMPQ         R9, $65535
+        MOVOU        X6, t19-249(SP)
+        MOVOU        X7, t18-233(SP)
+        MOVOU        X8, t17-217(SP)
+        MOVOU        X9, t16-201(SP)
+        MOVOU        X10, t15-185(SP)
+        MOVOU        X11, t14-169(SP)
+        MOVOU        X13, t13-153(SP)
+        MOVOU        X14, t10-121(SP)
+        JEQ          block5
+        MOVQ         t25-314(SP), R15
+        MOVQ         R15, t49-558(SP)
+        MOVQ         dst_base+0(FP), R13
+        LEAQ         (R13)(R15*1), R13
+        MOVQ         R13, ivptr0-8(SP)
+block8:
+        // for.loop, preds block4 block17 block3
+        MOVQ         t49-558(SP), R15
+        MOVQ         BX, R13
+        CMPQ         R15, R13
+        JGE          block7
+block6:
+        // for.body, preds block8
+        MOVQ         $2, R15
+        MOVQ         t49-558(SP), R13
+        MOVQ         R15, R12
+        MOVQ         R12, AX
+        IMULQ        R13
+        MOVQ         AX, R12
+        MOVQ         src_base+24(FP), R11
+        LEAQ         (R11)(R12*1), R11
+        MOVB         (R11), R10
+        MOVB         R10, t43-524(SP)
+        MOVQ         R15, R10
+        MOVQ         R10, AX
+        IMULQ        R13
+        MOVQ         AX, R10
+        ADDQ         $1, R10
+        MOVQ         src_base+24(FP), R9
+        LEAQ         (R9)(R10*1), R9
+        MOVB         (R9), R8
+        MOVB         R8, t47-549(SP)
+        MOVBQZX      t43-524(SP), R8
+        CMPB         R8, $48
+        JCS          block11
+block12:
+        // cond.true, preds block6
+        MOVBQZX      t43-524(SP), R15
+        CMPB         R15, $57
+        JHI          block11
+block9:
+        // if.then, preds block12
+        MOVBQZX      t43-524(SP), R15
+        MOVB         R15, R13
+        SUBB         $48, R13
+        MOVB         R13, t52-561(SP)
+        MOVB         R13, t51-560(SP)
+block10:
+        // if.done, preds block9 block13
+        MOVBQZX      t47-549(SP), R15
+        CMPB         R15, $48
+        JCS          block18
+block19:
+        // cond.true, preds block10
+        MOVBQZX      t47-549(SP), R15
+        CMPB         R15, $57
+        JHI          block18
+block16:
+        // if.then, preds block19
+        MOVBQZX      t47-549(SP), R15
+        MOVB         R15, R13
+        SUBB         $48, R13
+        MOVB         R13, t63-572(SP)
+        MOVB         R13, t62-571(SP)
+block17:
+        // if.done, preds block16 block20
+        MOVBQZX      t52-561(SP), R15
+        MOVB         R15, R13
+        SHLB         $4, R13
+        MOVBQZX      t63-572(SP), R12
+        ORQ          R12, R13
+        MOVQ         ivptr0-8(SP), R11
+        MOVQ         R11, R10
+        MOVB         R13, (R10)
+        MOVQ         t49-558(SP), R9
+        MOVQ         R9, R8
+        ADDQ         $1, R8
+        MOVQ         R8, t49-558(SP)
+        LEAQ         1(R11), R11
+        MOVQ         R11, ivptr0-8(SP)
+        MOVQ         R8, t67-590(SP)
+        JMP block8
+block5:
+        // if.done, preds block3
+        MOVO         X1, X14
+        MOVOU        t10-121(SP), X13
+        PSUBB        X14, X13
+        MOVOU        t16-201(SP), X12
+        MOVO         X13, X11
+        PAND         X12, X11
+        MOVOU        t7-81(SP), X10
+        MOVOU        t14-169(SP), X9
+        PSUBB        X10, X9
+        MOVOU        t18-233(SP), X8
+        MOVO         X9, X7
+        PAND         X8, X7
+        MOVO         X11, X6
+        POR          X7, X6
+        MOVOU        t13-153(SP), X5
+        PSUBB        X14, X5
+        MOVOU        t17-217(SP), X4
+        MOVO         X5, X3
+        PAND         X4, X3
+        MOVOU        t15-185(SP), X2
+        PSUBB        X10, X2
+        MOVOU        X3, t34-435(SP)
+        MOVOU        t19-249(SP), X3
+        MOVO         X2, X4
+        PAND         X3, X4
+        MOVOU        t34-435(SP), X2
+        MOVO         X2, X3
+        POR          X4, X3
+        MOVOU        HexDecode_const4<>(SB), X2
+        MOVOU        X3, t37-483(SP)
+        MOVOU        HexDecode_const5<>(SB), X3
+        MOVO         X6, X5
+        PAND         X2, X5
+        MOVO         X5, X4
+        PSLLW        $4, X5
+        PSRLW        $8, X4
+        POR          X4, X5
+        PAND         X3, X5
+        MOVOU        X6, t32-403(SP)
+        MOVOU        t37-483(SP), X6
+        MOVO         X6, X7
+        PAND         X2, X7
+        MOVO         X7, X4
+        PSLLW        $4, X7
+        PSRLW        $8, X4
+        POR          X4, X7
+        PAND         X3, X7
+        PACKUSWB     X7, X5
+        MOVQ         dst_base+0(FP), R15
+        MOVQ         t25-314(SP), R13
+        MOVOU        X5, (R15)(R13*1)
+        MOVQ         R13, R12
+        ADDQ         $16, R12
+        MOVQ         R12, t25-314(SP)
+        MOVQ         R12, t40-507(SP)
+        JMP block4
+block7:
+        // for.done, preds block8
+        MOVQ         BX, R15
+        MOVQ         R15, ret+48(FP)
+        RET
+block11:
+        // if.else, preds block6 block12
+        MOVBQZX      t43-524(SP), R15
+        MOVB         R15, R13
+        ORB          $32, R13
+        CMPB         R13, $97
+        JCS          block14
+block15:
+        // cond.true, preds block11
+        MOVBQZX      t43-524(SP), R15
+        MOVB         R15, R13
+        ORB          $32, R13
+        CMPB         R13, $102
+        JHI          block14
+block13:
+        // if.then, preds block15
+        MOVBQZX      t43-524(SP), R15
+        MOVB         R15, R13
+        ORB          $32, R13
+        SUBB         $97, R13
+        ADDB         $10, R13
+        MOVB         R13, t52-561(SP)
+        MOVB         R13, t59-568(SP)
+        JMP block10
+block14:
+        // if.else, preds block11 block15
+        MOVQ         t49-558(SP), R15
+        MOVQ         R15, ret+48(FP)
+        RET
+block18:
+        // if.else, preds block10 block19
+        MOVBQZX      t47-549(SP), R15
+        MOVB         R15, R13
+        ORB          $32, R13
+        CMPB         R13, $97
+        JCS          block21
+block22:
+        // cond.true, preds block18
+        MOVBQZX      t47-549(SP), R15
+        MOVB         R15, R13
+        ORB          $32, R13
+        CMPB         R13, $102
+        JHI          block21
+block20:
+        // if.then, preds block22
+        MOVBQZX      t47-549(SP), R15
+        MOVB         R15, R13
+        ORB          $32, R13
+        SUBB         $97, R13
+        ADDB         $10, R13
+        MOVB         R13, t63-572(SP)
+        MOVB         R13, t73-596(SP)
+        JMP block17
+block21:
+        // if.else, preds block18 block22
+        MOVQ         t49-558(SP), R15
+        MOVQ         R15, ret+48(FP)
+        RET
+
+
+DATA HexDecode_const0<>+0(SB)/8, $0x3030303030303030
+DATA HexDecode_const0<>+8(SB)/8, $0x3030303030303030
+GLOBL HexDecode_const0<>(SB), RODATA|NOPTR, $16
+
+DATA HexDecode_const1<>+0(SB)/8, $0x0909090909090909
+DATA HexDecode_const1<>+8(SB)/8, $0x0909090909090909
+GLOBL HexDecode_const1<>(SB), RODATA|NOPTR, $16
+
+DATA HexDecode_const2<>+0(SB)/8, $0x6161616161616161
+DATA HexDecode_const2<>+8(SB)/8, $0x6161616161616161
+GLOBL HexDecode_const2<>(SB), RODATA|NOPTR, $16
+
+DATA HexDecode_const3<>+0(SB)/8, $0x0505050505050505
+DATA HexDecode_const3<>+8(SB)/8, $0x0505050505050505
+GLOBL HexDecode_const3<>(SB), RODATA|NOPTR, $16
+
+DATA HexDecode_const4<>+0(SB)/8, $0x0f0f0f0f0f0f0f0f
+DATA HexDecode_const4<>+8(SB)/8, $0x0f0f0f0f0f0f0f0f
+GLOBL HexDecode_const4<>(SB), RODATA|NOPTR, $16
+
+DATA HexDecode_const5<>+0(SB)/8, $0x00ff00ff00ff00ff
+DATA HexDecode_const5<>+8(SB)/8, $0x00ff00ff00ff00ff
+GLOBL HexDecode_const5<>(SB), RODATA|NOPTR, $16
+
+TEXT ·HexEncode(SB),$328-56
+block0:
+        // entry
+        MOVQ         src_len+32(FP), R15
+        MOVQ         R15, R13
+        MOVQ         dst_len+8(FP), R12
+        MOVQ         R12, R11
+        MOVQ         $2, R10
+        MOVQ         R11, AX
+        MOVQ         AX, DX
+        SARQ         $63, DX
+        CMPQ         R10, $-1
+        JNE          lbl1
+        NEGQ         AX
+        XORQ         DX, DX
+        JMP          lbl2
+lbl1:
+        IDIVQ        R10
+lbl2:
+        MOVQ         AX, R11
+        CMPQ         R11, R13
+        SETLT        R9
+        MOVQ         R13, t6-58(SP)
+        MOVB         R9, t3-34(SP)
+        MOVQ         R13, t0-17(SP)
+        CMPB         R9, $0
+        JEQ          block2
+block1:
+        // if.then, preds block0
+        MOVQ         dst_len+8(FP), R15
+        MOVQ         R15, R13
+        MOVQ         $2, R12
+        MOVQ         R13, AX
+        MOVQ         AX, DX
+        SARQ         $63, DX
+        CMPQ         R12, $-1
+        JNE          lbl3
+        NEGQ         AX
+        XORQ         DX, DX
+        JMP          lbl4
+lbl3:
+        IDIVQ        R12
+lbl4:
+        MOVQ         AX, R13
+        MOVQ         R13, t6-58(SP)
+        MOVQ         R13, t5-50(SP)
+block2:
+        // if.done, preds block0 block1
+        MOVQ         t6-58(SP), BX
+        MOVB         $15, R15
+        MOVBQZX      R15, R13
+        IMUL3Q       $16843009, R13, R13
+        MOVQ         R13, X14
+        PSHUFL       $0, X14, X14
+        MOVO         X14, X0
+        MOVQ         $0, R13
+        MOVQ         R13, t21-226(SP)
+        MOVOU        X14, t7-74(SP)
+block4:
+        // for.loop, preds block2 block3
+        MOVQ         t21-226(SP), R15
+        MOVQ         R15, R13
+        ADDQ         $16, R13
+        MOVQ         BX, R12
+        CMPQ         R13, R12
+        SETLE        R11
+        MOVQ         R15, t34-272(SP)
+        MOVQ         src_base+24(FP), R10
+        LEAQ         (R10)(R15*1), R10
+        MOVQ         R10, ivptr0-8(SP)
+        MOVB         R11, t23-235(SP)
+        CMPB         R11, $0
+        JEQ          block7
+block3:
+        // for.body, preds block4
+        MOVQ         src_base+24(FP), R15
+        MOVQ         t21-226(SP), R13
+        MOVOU        (R15)(R13*1), X14
+        MOVOU        HexEncode_const0<>(SB), X12
+        MOVO         X14, X13
+        PSRLW        $4, X13
+        PAND         X12, X13
+        MOVOU        HexEncode_const1<>(SB), X12
+        PSHUFB       X13, X12
+        MOVO         X0, X11
+        MOVO         X14, X10
+        PAND         X11, X10
+        MOVOU        HexEncode_const1<>(SB), X9
+        PSHUFB       X10, X9
+        MOVQ         $2, R12
+        MOVQ         R12, R11
+        MOVQ         R11, AX
+        IMULQ        R13
+        MOVQ         AX, R11
+        MOVO         X12, X8
+        PUNPCKLBW    X9, X8
+        MOVQ         dst_base+0(FP), R10
+        MOVOU        X8, (R10)(R11*1)
+        MOVQ         R12, R9
+        MOVQ         R9, AX
+        IMULQ        R13
+        MOVQ         AX, R9
+        ADDQ         $16, R9
+        MOVO         X12, X7
+        PUNPCKHBW    X9, X7
+        MOVOU        X7, (R10)(R9*1)
+        MOVQ         R13, R8
+        ADDQ         $16, R8
+        MOVQ         R8, t21-226(SP)
+        MOVQ         R8, t20-218(SP)
+        JMP block4
+block5:
+        // for.body, preds block7
+        MOVQ         ivptr0-8(SP), R15
+        MOVQ         R15, R13
+        MOVB         (R13), R12
+        MOVB         R12, t25-9(SP)
+        MOVBQZX      t25-9(SP), R12
+        MOVB         R12, R11
+        SHRB         $4, R11
+        ADDB         $48, R11
+        MOVQ         R15, R10
+        // ssa.UnOp, t29 = *t28, same as t25
+        ANDB         $15, R12
+        ADDB         $48, R12
+        CMPB         R11, $57
+        SETHI        R9
+        MOVB         R11, t37-275(SP)
+        MOVB         R9, t32-256(SP)
+        MOVB         R11, t27-245(SP)
+        MOVB         R12, t31-255(SP)
+        CMPB         R9, $0
+        JEQ          block9
+block8:
+        // if.then, preds block5
+        MOVBQZX      t27-245(SP), R15
+        MOVB         R15, R13
+        ADDB         $39, R13
+        MOVB         R13, t37-275(SP)
+        MOVB         R13, t36-274(SP)
+block9:
+        // if.done, preds block5 block8
+        MOVBQZX      t31-255(SP), R15
+        CMPB         R15, $57
+        SETHI        R13
+        MOVB         R15, t40-278(SP)
+        MOVB         R13, t38-276(SP)
+        CMPB         R13, $0
+        JEQ          block11
+block10:
+        // if.then, preds block9
+        MOVBQZX      t31-255(SP), R15
+        MOVB         R15, R13
+        ADDB         $39, R13
+        MOVB         R13, t40-278(SP)
+        MOVB         R13, t39-277(SP)
+block11:
+        // if.done, preds block9 block10
+        MOVQ         $2, R15
+        MOVQ         t34-272(SP), R13
+        MOVQ         R15, R12
+        MOVQ         R12, AX
+        IMULQ        R13
+        MOVQ         AX, R12
+        MOVQ         dst_base+0(FP), R11
+        LEAQ         (R11)(R12*1), R11
+        MOVBQZX      t37-275(SP), R10
+        MOVB         R10, (R11)
+        MOVQ         R15, R9
+        MOVQ         R9, AX
+        IMULQ        R13
+        MOVQ         AX, R9
+        ADDQ         $1, R9
+        MOVQ         dst_base+0(FP), R8
+        LEAQ         (R8)(R9*1), R8
+        MOVBQZX      t40-278(SP), R9
+        MOVB         R9, (R8)
+        MOVQ         R13, BP
+        ADDQ         $1, BP
+        MOVQ         BP, t34-272(SP)
+        MOVQ         ivptr0-8(SP), R13
+        LEAQ         1(R13), R13
+        MOVQ         R13, ivptr0-8(SP)
+        MOVQ         BP, t46-326(SP)
+block7:
+        // for.loop, preds block4 block11
+        MOVQ         t34-272(SP), R15
+        MOVQ         BX, R13
+        CMPQ         R15, R13
+        JLT          block5
+block6:
+        // for.done, preds block7
+        MOVQ         $2, R15
+        MOVQ         BX, R13
+        MOVQ         R15, R12
+        MOVQ         R12, AX
+        IMULQ        R13
+        MOVQ         AX, R12
+        MOVQ         R12, ret+48(FP)
+        RET
+
+
+DATA HexEncode_const0<>+0(SB)/8, $0x0f0f0f0f0f0f0f0f
+DATA HexEncode_const0<>+8(SB)/8, $0x0f0f0f0f0f0f0f0f
+GLOBL HexEncode_const0<>(SB), RODATA|NOPTR, $16
+
+DATA HexEncode_const1<>+0(SB)/8, $0x3736353433323130
+DATA HexEncode_const1<>+8(SB)/8, $0x6665646362613938
+GLOBL HexEncode_const1<>(SB), RODATA|NOPTR, $16
+
+TEXT ·IndexByte(SB),$160-40
+block0:
+        // entry
+        MOVBQZX      c+24(FP), R15
+        MOVBQZX      R15, R13
+        IMUL3Q       $16843009, R13, R13
+        MOVQ         R13, X14
+        PSHUFL       $0, X14, X14
+        MOVO         X14, X0
+        MOVQ         s_len+8(FP), R13
+        MOVQ         R13, R12
+        MOVQ         R12, BX
+        MOVQ         $0, R11
+        MOVQ         R11, t5-73(SP)
+        MOVQ         R12, t7-89(SP)
+        MOVOU        X14, t0-24(SP)
+block2:
+        // for.loop, preds block0 block4
+        MOVQ         t5-73(SP), R15
+        MOVQ         R15, R13
+        ADDQ         $16, R13
+        MOVQ         BX, R12
+        CMPQ         R13, R12
+        SETLE        R11
+        MOVQ         R15, t16-140(SP)
+        MOVQ         s_base+0(FP), R10
+        LEAQ         (R10)(R15*1), R10
+        MOVQ         R10, ivptr0-8(SP)
+        MOVB         R11, t8-90(SP)
+        CMPB         R11, $0
+        JEQ          block7
+block1:
+        // for.body, preds block2
+        MOVQ         s_base+0(FP), R15
+        MOVQ         t5-73(SP), R13
+        MOVOU        (R15)(R13*1), X14
+        MOVO         X0, X13
+        MOVO         X14, X12
+        PCMPEQB      X13, X12
+        PMOVMSKB     X12, R12
+        CMPQ         R12, $0
+        MOVQ         R12, t3-64(SP)
+        JNE          block3
+block4:
+        // if.done, preds block1
+        MOVQ         t5-73(SP), R15
+        MOVQ         R15, R13
+        ADDQ         $16, R13
+        MOVQ         R13, t5-73(SP)
+        MOVQ         R13, t12-122(SP)
+        JMP block2
+block3:
+        // if.then, preds block1
+        MOVQ         t3-64(SP), R15
+        MOVQ         R15, R13
+        TZCNTQ       R13, R12
+        MOVQ         t5-73(SP), R11
+        MOVQ         R11, R10
+        ADDQ         R12, R10
+        MOVQ         R10, ret+32(FP)
+        RET
+block5:
+        // for.body, preds block7
+        MOVQ         ivptr0-8(SP), R15
+        MOVQ         R15, R11
+        MOVB         (R11), R9
+        MOVB         R9, t14-131(SP)
+        MOVBQZX      t14-131(SP), R9
+        MOVBQZX      c+24(FP), R8
+        CMPB         R9, R8
+        JEQ          block8
+block9:
+        // if.done, preds block5
+        MOVQ         t16-140(SP), R15
+        MOVQ         R15, R13
+        ADDQ         $1, R13
+        MOVQ         R13, t16-140(SP)
+        MOVQ         ivptr0-8(SP), R15
+        LEAQ         1(R15), R15
+        MOVQ         R15, ivptr0-8(SP)
+        MOVQ         R13, t19-157(SP)
+block7:
+        // for.loop, preds block2 block9
+        MOVQ         s_len+8(FP), R15
+        MOVQ         R15, R13
+        MOVQ         t16-140(SP), R12
+        CMPQ         R12, R13
+        JLT          block5
+block6:
+        // for.done, preds block7
+        MOVQ         $-1, R15
+        MOVQ         R15, ret+32(FP)
+        RET
+block8:
+        // if.then, preds block5
+        MOVQ         t16-140(SP), R13
+        MOVQ         R13, ret+32(FP)
+        RET
+
+TEXT ·IndexNonASCII(SB),$128-32
+block0:
+        // entry
+        MOVQ         s_len+8(FP), R15
+        MOVQ         R15, R13
+        MOVQ         R13, BX
+        MOVQ         $0, R12
+        MOVQ         R12, t3-41(SP)
+        MOVQ         R13, t5-57(SP)
+block2:
+        // for.loop, preds block0 block4
+        MOVQ         t3-41(SP), R15
+        MOVQ         R15, R13
+        ADDQ         $16, R13
+        MOVQ         BX, R12
+        CMPQ         R13, R12
+        SETLE        R11
+        MOVQ         R15, t14-108(SP)
+        MOVQ         s_base+0(FP), R10
+        LEAQ         (R10)(R15*1), R10
+        MOVQ         R10, ivptr0-8(SP)
+        MOVB         R11, t6-58(SP)
+        CMPB         R11, $0
+        JEQ          block7
+block1:
+        // for.body, preds block2
+        MOVQ         s_base+0(FP), R15
+        MOVQ         t3-41(SP), R13
+        MOVOU        (R15)(R13*1), X14
+        PMOVMSKB     X14, R12
+        CMPQ         R12, $0
+        MOVQ         R12, t1-32(SP)
+        JNE          block3
+block4:
+        // if.done, preds block1
+        MOVQ         t3-41(SP), R15
+        MOVQ         R15, R13
+        ADDQ         $16, R13
+        MOVQ         R13, t3-41(SP)
+        MOVQ         R13, t10-90(SP)
+        JMP block2
+block3:
+        // if.then, preds block1
+        MOVQ         t1-32(SP), R15
+        MOVQ         R15, R13
+        TZCNTQ       R13, R12
+        MOVQ         t3-41(SP), R11
+        MOVQ         R11, R10
+        ADDQ         R12, R10
+        MOVQ         R10, ret+24(FP)
+        RET
+block5:
+        // for.body, preds block7
+        MOVQ         ivptr0-8(SP), R15
+        MOVQ         R15, R11
+        MOVB         (R11), R9
+        MOVB         R9, t12-99(SP)
+        MOVBQZX      t12-99(SP), R9
+        CMPB         R9, $-128
+        JCC          block8
+block9:
+        // if.done, preds block5
+        MOVQ         t14-108(SP), R15
+        MOVQ         R15, R13
+        ADDQ         $1, R13
+        MOVQ         R13, t14-108(SP)
+        MOVQ         ivptr0-8(SP), R15
+        LEAQ         1(R15), R15
+        MOVQ         R15, ivptr0-8(SP)
+        MOVQ         R13, t17-125(SP)
+block7:
+        // for.loop, preds block2 block9
+        MOVQ         s_len+8(FP), R15
+        MOVQ         R15, R13
+        MOVQ         t14-108(SP), R12
+        CMPQ         R12, R13
+        JLT          block5
+block6:
+        // for.done, preds block7
+        MOVQ         $-1, R15
+        MOVQ         R15, ret+24(FP)
+        RET
+block8:
+        // if.then, preds block5
+        MOVQ         t14-108(SP), R13
+        MOVQ         R13, ret+24(FP)
+        RET
+
+TEXT ·MatMul4x4(SB),$400-72
+block0:
+        // entry
+        MOVQ         b_base+48(FP), R15
+        MOVQ         R15, R13
+        MOVUPS       (R13), X14
+        MOVUPS       X14, t1-40(SP)
+        MOVUPS       t1-40(SP), X0
+        MOVQ         b_base+48(FP), R13
+        ADDQ         $16, R13
+        MOVQ         R13, R12
+        MOVUPS       (R12), X14
+        MOVUPS       X14, t3-64(SP)
+        MOVUPS       t3-64(SP), X1
+        MOVQ         b_base+48(FP), R12
+        ADDQ         $32, R12
+        MOVQ         R12, R11
+        MOVUPS       (R11), X14
+        MOVUPS       X14, t5-88(SP)
+        MOVQ         b_base+48(FP), R11
+        ADDQ         $48, R11
+        MOVQ         R11, R10
+        MOVUPS       (R10), X14
+        MOVUPS       X14, t7-112(SP)
+        MOVQ         $0, R10
+        MOVQ         R10, t8-120(SP)
+        MOVQ         a_base+24(FP), R9
+        IMUL3Q       $16, R10, R8
+        ADDQ         R8, R9
+        MOVQ         R9, ivptr0-8(SP)
+        MOVQ         dst_base+0(FP), R9
+        IMUL3Q       $16, R10, R8
+        ADDQ         R8, R9
+        MOVQ         R9, ivptr1-16(SP)
+block1:
+        // for.loop, preds block0 block2
+        MOVQ         t8-120(SP), R15
+        CMPQ         R15, $4
+        JGE          block3
+block2:
+        // for.body, preds block1
+        MOVQ         ivptr0-8(SP), R15
+        MOVQ         R15, R13
+        MOVQ         R13, t10-129(SP)
+        MOVQ         t10-129(SP), R13
+        MOVSS        (R13), X14
+        MOVSS        X14, t12-141(SP)
+        MOVSS        t12-141(SP), X14
+        MOVO         X14, X13
+        SHUFPS       $0, X13, X13
+        MOVO         X0, X12
+        MOVUPS       X13, t13-157(SP)
+        MULPS        X12, X13
+        MOVQ         R15, R12
+        MOVQ         R12, t15-181(SP)
+        MOVQ         t15-181(SP), R12
+        ADDQ         $4, R12
+        MOVSS        (R12), X11
+        MOVSS        X11, t17-193(SP)
+        MOVSS        t17-193(SP), X11
+        MOVO         X11, X10
+        SHUFPS       $0, X10, X10
+        MOVO         X1, X9
+        MOVUPS       X10, t18-209(SP)
+        MULPS        X9, X10
+        MOVUPS       X13, t14-173(SP)
+        ADDPS        X10, X13
+        MOVQ         R15, R11
+        MOVQ         R11, t21-249(SP)
+        MOVQ         t21-249(SP), R11
+        ADDQ         $8, R11
+        MOVSS        (R11), X8
+        MOVSS        X8, t23-261(SP)
+        MOVSS        t23-261(SP), X8
+        MOVO         X8, X7
+        SHUFPS       $0, X7, X7
+        MOVUPS       t5-88(SP), X6
+        MOVUPS       X7, t24-277(SP)
+        MULPS        X6, X7
+        MOVQ         R15, R10
+        MOVQ         R10, t26-301(SP)
+        MOVQ         t26-301(SP), R10
+        ADDQ         $12, R10
+        MOVSS        (R10), X5
+        MOVSS        X5, t28-313(SP)
+        MOVSS        t28-313(SP), X5
+        MOVO         X5, X4
+        SHUFPS       $0, X4, X4
+        MOVUPS       t7-112(SP), X3
+        MOVUPS       X4, t29-329(SP)
+        MULPS        X3, X4
+        MOVUPS       X7, t25-293(SP)
+        ADDPS        X4, X7
+        MOVUPS       X13, t20-241(SP)
+        ADDPS        X7, X13
+        MOVQ         ivptr1-16(SP), R9
+        MOVQ         R9, R8
+        MOVUPS       X13, (R8)
+        MOVQ         t8-120(SP), BP
+        MOVQ         BP, BX
+        ADDQ         $1, BX
+        MOVQ         BX, t8-120(SP)
+        LEAQ         16(R15), R15
+        MOVQ         R15, ivptr0-8(SP)
+        LEAQ         16(R9), R9
+        MOVQ         R9, ivptr1-16(SP)
+        MOVQ         BX, t34-393(SP)
+        JMP block1
+block3:
+        // for.done, preds block1
+        RET
+
+TEXT ·MatMul8x8(SB),$1248-72
+block0:
+        // entry
+        MOVQ         $0, R15
+        MOVQ         R15, t0-24(SP)
+        MOVQ         a_base+24(FP), R13
+        IMUL3Q       $16, R15, R12
+        ADDQ         R12, R13
+        MOVQ         R13, ivptr0-8(SP)
+        MOVQ         dst_base+0(FP), R13
+        IMUL3Q       $16, R15, R12
+        ADDQ         R12, R13
+        MOVQ         R13, ivptr1-16(SP)
+block1:
+        // for.loop, preds block0 block2
+        MOVQ         t0-24(SP), R15
+        CMPQ         R15, $16
+        JGE          block3
+block2:
+        // for.body, preds block1
+        MOVQ         ivptr0-8(SP), R15
+        MOVQ         R15, R13
+        MOVQ         R13, t2-33(SP)
+        MOVQ         t2-33(SP), R13
+        MOVSS        (R13), X14
+        MOVSS        X14, t4-45(SP)
+        MOVSS        t4-45(SP), X14
+        MOVO         X14, X13
+        SHUFPS       $0, X13, X13
+        MOVQ         b_base+48(FP), R12
+        MOVQ         R12, R11
+        MOVUPS       (R11), X12
+        MOVUPS       X12, t7-85(SP)
+        MOVUPS       t7-85(SP), X12
+        MOVUPS       X13, t5-61(SP)
+        MULPS        X12, X13
+        MOVQ         b_base+48(FP), R11
+        ADDQ         $16, R11
+        MOVQ         R11, R10
+        MOVUPS       (R10), X11
+        MOVUPS       X11, t10-125(SP)
+        MOVUPS       t10-125(SP), X11
+        MOVUPS       t5-61(SP), X10
+        MULPS        X11, X10
+        MOVQ         R15, R10
+        MOVQ         R10, t12-149(SP)
+        MOVQ         t12-149(SP), R10
+        ADDQ         $4, R10
+        MOVSS        (R10), X9
+        MOVSS        X9, t14-161(SP)
+        MOVSS        t14-161(SP), X9
+        MOVO         X9, X8
+        SHUFPS       $0, X8, X8
+        MOVQ         b_base+48(FP), R9
+        ADDQ         $32, R9
+        MOVQ         R9, R8
+        MOVUPS       (R8), X7
+        MOVUPS       X7, t17-201(SP)
+        MOVUPS       t17-201(SP), X7
+        MOVUPS       X8, t15-177(SP)
+        MULPS        X7, X8
+        MOVUPS       X13, t8-101(SP)
+        ADDPS        X8, X13
+        MOVQ         b_base+48(FP), R8
+        ADDQ         $48, R8
+        MOVQ         R8, BP
+        MOVUPS       (BP), X6
+        MOVUPS       X6, t21-257(SP)
+        MOVUPS       t21-257(SP), X6
+        MOVUPS       t15-177(SP), X5
+        MULPS        X6, X5
+        MOVUPS       X10, t11-141(SP)
+        ADDPS        X5, X10
+        MOVQ         R15, BP
+        MOVQ         BP, t24-297(SP)
+        MOVQ         t24-297(SP), BP
+        ADDQ         $8, BP
+        MOVSS        (BP), X4
+        MOVSS        X4, t26-309(SP)
+        MOVSS        t26-309(SP), X4
+        MOVO         X4, X3
+        SHUFPS       $0, X3, X3
+        MOVQ         b_base+48(FP), BX
+        ADDQ         $64, BX
+        MOVQ         BX, DI
+        MOVUPS       (DI), X2
+        MOVUPS       X2, t29-349(SP)
+        MOVUPS       t29-349(SP), X2
+        MOVUPS       X3, t27-325(SP)
+        MULPS        X2, X3
+        MOVUPS       X13, t19-233(SP)
+        ADDPS        X3, X13
+        MOVQ         b_base+48(FP), DI
+        ADDQ         $80, DI
+        MOVQ         DI, SI
+        MOVUPS       (SI), X1
+        MOVUPS       X1, t33-405(SP)
+        MOVUPS       t33-405(SP), X1
+        MOVUPS       t27-325(SP), X0
+        MULPS        X1, X0
+        MOVUPS       X10, t23-289(SP)
+        ADDPS        X0, X10
+        MOVQ         R15, SI
+        MOVQ         SI, t36-445(SP)
+        MOVQ         t36-445(SP), SI
+        ADDQ         $12, SI
+        MOVQ         SI, t37-453(SP)
+        MOVQ         t37-453(SP), DI
+        MOVSS        (DI), X0
+        MOVSS        X0, t38-457(SP)
+        MOVSS        t38-457(SP), X0
+        MOVO         X0, X1
+        SHUFPS       $0, X1, X1
+        MOVQ         b_base+48(FP), SI
+        ADDQ         $96, SI
+        MOVQ         SI, t40-481(SP)
+        MOVQ         t40-481(SP), DI
+        MOVQ         DI, SI
+        MOVUPS       (SI), X0
+        MOVUPS       X0, t41-497(SP)
+        MOVUPS       t41-497(SP), X0
+        MOVUPS       X1, t39-473(SP)
+        MULPS        X0, X1
+        MOVUPS       X13, t31-381(SP)
+        ADDPS        X1, X13
+        MOVQ         b_base+48(FP), SI
+        ADDQ         $112, SI
+        MOVQ         SI, t44-537(SP)
+        MOVQ         t44-537(SP), DI
+        MOVQ         DI, SI
+        MOVUPS       (SI), X0
+        MOVUPS       X0, t45-553(SP)
+        MOVUPS       t45-553(SP), X0
+        MOVUPS       t39-473(SP), X1
+        MULPS        X0, X1
+        MOVUPS       X10, t35-437(SP)
+        ADDPS        X1, X10
+        MOVQ         t0-24(SP), SI
+        MOVQ         SI, DI
+        ADDQ         $1, DI
+        MOVQ         DI, t48-593(SP)
+        IMUL3Q       $16, DI, DI
+        MOVQ         a_base+24(FP), SI
+        ADDQ         DI, SI
+        MOVQ         SI, t49-601(SP)
+        MOVQ         t49-601(SP), DI
+        MOVSS        (DI), X0
+        MOVSS        X0, t51-613(SP)
+        MOVSS        t51-613(SP), X0
+        MOVO         X0, X1
+        SHUFPS       $0, X1, X1
+        MOVQ         b_base+48(FP), SI
+        ADDQ         $128, SI
+        MOVQ         SI, t53-637(SP)
+        MOVQ         t53-637(SP), DI
+        MOVQ         DI, SI
+        MOVUPS       (SI), X0
+        MOVUPS       X0, t54-653(SP)
+        MOVUPS       t54-653(SP), X0
+        MOVUPS       X1, t52-629(SP)
+        MULPS        X0, X1
+        MOVUPS       X13, t43-529(SP)
+        ADDPS        X1, X13
+        MOVQ         b_base+48(FP), SI
+        ADDQ         $144, SI
+        MOVQ         SI, t57-693(SP)
+        MOVQ         t57-693(SP), DI
+        MOVQ         DI, SI
+        MOVUPS       (SI), X0
+        MOVUPS       X0, t58-709(SP)
+        MOVUPS       t58-709(SP), X0
+        MOVUPS       t52-629(SP), X1
+        MULPS        X0, X1
+        MOVUPS       X10, t47-585(SP)
+        ADDPS        X1, X10
+        MOVQ         t0-24(SP), SI
+        MOVQ         SI, DI
+        ADDQ         $1, DI
+        MOVQ         DI, t61-749(SP)
+        IMUL3Q       $16, DI, DI
+        MOVQ         a_base+24(FP), SI
+        ADDQ         DI, SI
+        MOVQ         SI, t62-757(SP)
+        MOVQ         t62-757(SP), DI
+        ADDQ         $4, DI
+        MOVSS        (DI), X0
+        MOVSS        X0, t64-769(SP)
+        MOVSS        t64-769(SP), X0
+        MOVO         X0, X1
+        SHUFPS       $0, X1, X1
+        MOVQ         b_base+48(FP), SI
+        ADDQ         $160, SI
+        MOVQ         SI, t66-793(SP)
+        MOVQ         t66-793(SP), DI
+        MOVQ         DI, SI
+        MOVUPS       (SI), X0
+        MOVUPS       X0, t67-809(SP)
+        MOVUPS       t67-809(SP), X0
+        MOVUPS       X1, t65-785(SP)
+        MULPS        X0, X1
+        MOVUPS       X13, t56-685(SP)
+        ADDPS        X1, X13
+        MOVQ         b_base+48(FP), SI
+        ADDQ         $176, SI
+        MOVQ         SI, t70-849(SP)
+        MOVQ         t70-849(SP), DI
+        MOVQ         DI, SI
+        MOVUPS       (SI), X0
+        MOVUPS       X0, t71-865(SP)
+        MOVUPS       t71-865(SP), X0
+        MOVUPS       t65-785(SP), X1
+        MULPS        X0, X1
+        MOVUPS       X10, t60-741(SP)
+        ADDPS        X1, X10
+        MOVQ         t0-24(SP), SI
+        MOVQ         SI, DI
+        ADDQ         $1, DI
+        MOVQ         DI, t74-905(SP)
+        IMUL3Q       $16, DI, DI
+        MOVQ         a_base+24(FP), SI
+        ADDQ         DI, SI
+        MOVQ         SI, t75-913(SP)
+        MOVQ         t75-913(SP), DI
+        ADDQ         $8, DI
+        MOVSS        (DI), X0
+        MOVSS        X0, t77-925(SP)
+        MOVSS        t77-925(SP), X0
+        MOVO         X0, X1
+        SHUFPS       $0, X1, X1
+        MOVQ         b_base+48(FP), SI
+        ADDQ         $192, SI
+        MOVQ         SI, t79-949(SP)
+        MOVQ         t79-949(SP), DI
+        MOVQ         DI, SI
+        MOVUPS       (SI), X0
+        MOVUPS       X0, t80-965(SP)
+        MOVUPS       t80-965(SP), X0
+        MOVUPS       X1, t78-941(SP)
+        MULPS        X0, X1
+        MOVUPS       X13, t69-841(SP)
+        ADDPS        X1, X13
+        MOVQ         b_base+48(FP), SI
+        ADDQ         $208, SI
+        MOVQ         SI, t83-1005(SP)
+        MOVQ         t83-1005(SP), DI
+        MOVQ         DI, SI
+        MOVUPS       (SI), X0
+        MOVUPS       X0, t84-1021(SP)
+        MOVUPS       t84-1021(SP), X0
+        MOVUPS       t78-941(SP), X1
+        MULPS        X0, X1
+        MOVUPS       X10, t73-897(SP)
+        ADDPS        X1, X10
+        MOVQ         t0-24(SP), SI
+        MOVQ         SI, DI
+        ADDQ         $1, DI
+        MOVQ         DI, t87-1061(SP)
+        IMUL3Q       $16, DI, DI
+        MOVQ         a_base+24(FP), SI
+        ADDQ         DI, SI
+        MOVQ         SI, t88-1069(SP)
+        MOVQ         t88-1069(SP), DI
+        ADDQ         $12, DI
+        MOVSS        (DI), X0
+        MOVSS        X0, t90-1081(SP)
+        MOVSS        t90-1081(SP), X0
+        MOVO         X0, X1
+        SHUFPS       $0, X1, X1
+        MOVQ         b_base+48(FP), SI
+        ADDQ         $224, SI
+        MOVQ         SI, t92-1105(SP)
+        MOVQ         t92-1105(SP), DI
+        MOVQ         DI, SI
+        MOVUPS       (SI), X0
+        MOVUPS       X0, t93-1121(SP)
+        MOVUPS       t93-1121(SP), X0
+        MOVUPS       X1, t91-1097(SP)
+        MULPS        X0, X1
+        MOVUPS       X13, t82-997(SP)
+        ADDPS        X1, X13
+        MOVQ         b_base+48(FP), SI
+        ADDQ         $240, SI
+        MOVQ         SI, t96-1161(SP)
+        MOVQ         t96-1161(SP), DI
+        MOVQ         DI, SI
+        MOVUPS       (SI), X0
+        MOVUPS       X0, t97-1177(SP)
+        MOVUPS       t97-1177(SP), X0
+        MOVUPS       t91-1097(SP), X1
+        MULPS        X0, X1
+        MOVUPS       X10, t86-1053(SP)
+        ADDPS        X1, X10
+        MOVQ         ivptr1-16(SP), SI
+        MOVQ         SI, t100-1217(SP)
+        MOVQ         t100-1217(SP), SI
+        MOVUPS       X13, (SI)
+        MOVQ         t0-24(SP), SI
+        MOVQ         SI, DI
+        ADDQ         $1, DI
+        MOVQ         DI, t101-1225(SP)
+        IMUL3Q       $16, DI, DI
+        MOVQ         dst_base+0(FP), SI
+        ADDQ         DI, SI
+        MOVUPS       X10, (SI)
+        MOVQ         t0-24(SP), DI
+        MOVQ         DI, SI
+        ADDQ         $2, SI
+        MOVQ         SI, t0-24(SP)
+        LEAQ         32(R15), R15
+        MOVQ         R15, ivptr0-8(SP)
+        MOVQ         ivptr1-16(SP), R15
+        LEAQ         32(R15), R15
+        MOVQ         R15, ivptr1-16(SP)
+        MOVQ         SI, t103-1241(SP)
+        JMP block1
+block3:
+        // for.done, preds block1
+        RET
+
+TEXT ·MemcpyAligned(SB),$104-56
+block0:
+        // entry
+        MOVQ         src_len+32(FP), R15
+        MOVQ         R15, R13
+        MOVQ         dst_len+8(FP), R12
+        MOVQ         R12, R11
+        CMPQ         R11, R13
+        SETLT        R10
+        MOVQ         R13, t4-49(SP)
+        MOVB         R10, t2-33(SP)
+        MOVQ         R13, t0-24(SP)
+        CMPB         R10, $0
+        JEQ          block2
+block1:
+        // if.then, preds block0
+        MOVQ         dst_len+8(FP), R15
+        MOVQ         R15, R13
+        MOVQ         R13, t4-49(SP)
+        MOVQ         R13, t3-41(SP)
+block2:
+        // if.done, preds block0 block1
+        MOVQ         t4-49(SP), BX
+        MOVQ         $0, R15
+        MOVQ         R15, t5-57(SP)
+        MOVQ         src_base+24(FP), R13
+        IMUL3Q       $16, R15, R12
+        ADDQ         R12, R13
+        MOVQ         R13, ivptr0-8(SP)
+        MOVQ         dst_base+0(FP), R13
+        IMUL3Q       $16, R15, R12
+        ADDQ         R12, R13
+        MOVQ         R13, ivptr1-16(SP)
+block3:
+        // for.loop, preds block2 block4
+        MOVQ         t5-57(SP), R15
+        MOVQ         BX, R13
+        CMPQ         R15, R13
+        JGE          block5
+block4:
+        // for.body, preds block3
+        MOVQ         ivptr0-8(SP), R15
+        MOVQ         R15, R13
+        MOVO         (R13), X14
+        MOVOU        X14, t8-82(SP)
+        MOVQ         ivptr1-16(SP), R12
+        MOVQ         R12, R11
+        MOVOU        t8-82(SP), X14
+        MOVO         X14, (R11)
+        MOVQ         t5-57(SP), R10
+        MOVQ         R10, R9
+        ADDQ         $1, R9
+        MOVQ         R9, t5-57(SP)
+        LEAQ         16(R15), R15
+        MOVQ         R15, ivptr0-8(SP)
+        LEAQ         16(R12), R12
+        MOVQ         R12, ivptr1-16(SP)
+        MOVQ         R9, t10-98(SP)
+        JMP block3
+block5:
+        // for.done, preds block3
+        MOVQ         BX, R15
+        MOVQ         R15, ret+48(FP)
+        RET
+
+TEXT ·Memset32(SB),$40-28
+        MOVLQZX      v+24(FP), R8
+block0:
+        // entry
+        MOVQ         dst_len+8(FP), R15
+        MOVQ         R15, R13
+        MOVQ         R13, BX
+        MOVQ         $-1, R12
+        MOVQ         R12, t1-16(SP)
+        MOVQ         R13, t0-8(SP)
+block1:
+        // rangeindex.loop, preds block0 block2
+        MOVQ         t1-16(SP), R15
+        MOVQ         R15, R13
+        ADDQ         $1, R13
+        MOVQ         BX, R12
+        CMPQ         R13, R12
+        MOVQ         R13, t2-24(SP)
+        JGE          block3
+block2:
+        // rangeindex.body, preds block1
+        MOVQ         t2-24(SP), R13
+        MOVQ         dst_base+0(FP), R15
+        LEAQ         (R15)(R13*4), R15
+        MOVL         R8, R12
+        MOVL         R12, (R15)
+        MOVQ         R13, t1-16(SP)
+        JMP block1
+block3:
+        // rangeindex.done, preds block1
+        RET
+
+TEXT ·MulAddGF8(SB),$160-88
+        MOVUPS       lo+48(FP), X0
+        MOVUPS       hi+64(FP), X1
+block0:
+        // entry
+        MOVQ         src_len+32(FP), R15
+        MOVQ         R15, R13
+        MOVQ         dst_len+8(FP), R12
+        MOVQ         R12, R11
+        CMPQ         R11, R13
+        SETLT        R10
+        MOVQ         R13, t4-49(SP)
+        MOVB         R10, t2-33(SP)
+        MOVQ         R13, t0-24(SP)
+        CMPB         R10, $0
+        JEQ          block2
+block1:
+        // if.then, preds block0
+        MOVQ         dst_len+8(FP), R15
+        MOVQ         R15, R13
+        MOVQ         R13, t4-49(SP)
+        MOVQ         R13, t3-41(SP)
+block2:
+        // if.done, preds block0 block1
+        MOVQ         t4-49(SP), BX
+        MOVQ         $0, R15
+        MOVQ         R15, t5-57(SP)
+        MOVQ         dst_base+0(FP), R13
+        IMUL3Q       $16, R15, R12
+        ADDQ         R12, R13
+        MOVQ         R13, ivptr0-8(SP)
+        MOVQ         src_base+24(FP), R13
+        IMUL3Q       $16, R15, R12
+        ADDQ         R12, R13
+        MOVQ         R13, ivptr1-16(SP)
+block3:
+        // for.loop, preds block2 block4
+        MOVQ         t5-57(SP), R15
+        MOVQ         BX, R13
+        CMPQ         R15, R13
+        JGE          block5
+block4:
+        // for.body, preds block3
+        MOVQ         ivptr0-8(SP), R15
+        MOVQ         R15, R13
+        MOVQ         R13, R12
+        MOVOU        (R12), X14
+        MOVOU        X14, t8-82(SP)
+        MOVQ         ivptr1-16(SP), R12
+        MOVQ         R12, R11
+        MOVQ         R11, R10
+        MOVOU        (R10), X14
+        MOVOU        X14, t10-106(SP)
+        MOVO         X0, X14
+        MOVO         X14, X13
+        MOVO         X1, X12
+        MOVO         X12, X11
+        MOVOU        t10-106(SP), X10
+        MOVOU        MulAddGF8_const0<>(SB), X9
+        MOVO         X10, X8
+        PSRLW        $4, X8
+        PAND         X9, X8
+        PAND         X10, X9
+        PSHUFB       X9, X13
+        PSHUFB       X8, X11
+        PXOR         X11, X13
+        MOVOU        t8-82(SP), X11
+        MOVO         X11, X9
+        PXOR         X13, X9
+        MOVQ         R15, R10
+        MOVOU        X9, (R10)
+        MOVQ         t5-57(SP), R9
+        MOVQ         R9, R8
+        ADDQ         $1, R8
+        MOVQ         R8, t5-57(SP)
+        LEAQ         16(R15), R15
+        MOVQ         R15, ivptr0-8(SP)
+        LEAQ         16(R12), R12
+        MOVQ         R12, ivptr1-16(SP)
+        MOVQ         R8, t14-154(SP)
+        JMP block3
+block5:
+        // for.done, preds block3
+        MOVQ         BX, R15
+        MOVQ         R15, ret+80(FP)
+        RET
+
+
+DATA MulAddGF8_const0<>+0(SB)/8, $0x0f0f0f0f0f0f0f0f
+DATA MulAddGF8_const0<>+8(SB)/8, $0x0f0f0f0f0f0f0f0f
+GLOBL MulAddGF8_const0<>(SB), RODATA|NOPTR, $16
+
+TEXT ·MulGF8(SB),$120-88
+        MOVUPS       lo+48(FP), X0
+        MOVUPS       hi+64(FP), X1
+block0:
+        // entry
+        MOVQ         src_len+32(FP), R15
+        MOVQ         R15, R13
+        MOVQ         dst_len+8(FP), R12
+        MOVQ         R12, R11
+        CMPQ         R11, R13
+        SETLT        R10
+        MOVQ         R13, t4-49(SP)
+        MOVB         R10, t2-33(SP)
+        MOVQ         R13, t0-24(SP)
+        CMPB         R10, $0
+        JEQ          block2
+block1:
+        // if.then, preds block0
+        MOVQ         dst_len+8(FP), R15
+        MOVQ         R15, R13
+        MOVQ         R13, t4-49(SP)
+        MOVQ         R13, t3-41(SP)
+block2:
+        // if.done, preds block0 block1
+        MOVQ         t4-49(SP), BX
+        MOVQ         $0, R15
+        MOVQ         R15, t5-57(SP)
+        MOVQ         src_base+24(FP), R13
+        IMUL3Q       $16, R15, R12
+        ADDQ         R12, R13
+        MOVQ         R13, ivptr0-8(SP)
+        MOVQ         dst_base+0(FP), R13
+        IMUL3Q       $16, R15, R12
+        ADDQ         R12, R13
+        MOVQ         R13, ivptr1-16(SP)
+block3:
+        // for.loop, preds block2 block4
+        MOVQ         t5-57(SP), R15
+        MOVQ         BX, R13
+        CMPQ         R15, R13
+        JGE          block5
+block4:
+        // for.body, preds block3
+        MOVQ         ivptr0-8(SP), R15
+        MOVQ         R15, R13
+        MOVQ         R13, R12
+        MOVOU        (R12), X14
+        MOVOU        X14, t8-82(SP)
+        MOVO         X0, X14
+        MOVO         X14, X13
+        MOVO         X1, X12
+        MOVO         X12, X11
+        MOVOU        t8-82(SP), X10
+        MOVOU        MulGF8_const0<>(SB), X9
+        MOVO         X10, X8
+        PSRLW        $4, X8
+        PAND         X9, X8
+        PAND         X10, X9
+        PSHUFB       X9, X13
+        PSHUFB       X8, X11
+        PXOR         X11, X13
+        MOVQ         ivptr1-16(SP), R12
+        MOVQ         R12, R11
+        MOVOU        X13, (R11)
+        MOVQ         t5-57(SP), R10
+        MOVQ         R10, R9
+        ADDQ         $1, R9
+        MOVQ         R9, t5-57(SP)
+        LEAQ         16(R15), R15
+        MOVQ         R15, ivptr0-8(SP)
+        LEAQ         16(R12), R12
+        MOVQ         R12, ivptr1-16(SP)
+        MOVQ         R9, t11-114(SP)
+        JMP block3
+block5:
+        // for.done, preds block3
+        MOVQ         BX, R15
+        MOVQ         R15, ret+80(FP)
+        RET
+
+
+DATA MulGF8_const0<>+0(SB)/8, $0x0f0f0f0f0f0f0f0f
+DATA MulGF8_const0<>+8(SB)/8, $0x0f0f0f0f0f0f0f0f
+GLOBL MulGF8_const0<>(SB), RODATA|NOPTR, $16
+
+TEXT ·SelectRangeInt64(SB),$512-72
+block0:
+        // entry
+        MOVQ         col_len+32(FP), R15
+        MOVQ         R15, R13
+        MOVQ         idx_len+8(FP), R12
+        MOVQ         R12, R11
+        CMPQ         R11, R13
+        SETLT        R10
+        MOVQ         R13, t4-57(SP)
+        MOVB         R10, t2-41(SP)
+        MOVQ         R13, t0-32(SP)
+        CMPB         R10, $0
+        JEQ          block2
+block1:
+        // if.then, preds block0
+        MOVQ         idx_len+8(FP), R15
+        MOVQ         R15, R13
+        MOVQ         R13, t4-57(SP)
+        MOVQ         R13, t3-49(SP)
+block2:
+        // if.done, preds block0 block1
+        MOVQ         t4-57(SP), BX
+        MOVQ         lo+48(FP), R15
+        MOVQ         R15, X14
+        PUNPCKLQDQ    X14, X14
+        MOVO         X14, X0
+        MOVQ         hi+56(FP), R13
+        MOVQ         R13, X13
+        PUNPCKLQDQ    X13, X13
+        MOVO         X13, X1
+        LEAQ         t7-16(SP), R12
+        LEAQ         t7-16(SP), R11
+        ADDQ         $4, R11
+        LEAQ         t7-16(SP), R10
+        ADDQ         $8, R10
+        LEAQ         t7-16(SP), R9
+        ADDQ         $12, R9
+        MOVL         $0, R8
+        MOVL         R8, (R12)
+        MOVL         $1, R8
+        MOVL         R8, (R11)
+        MOVL         $2, R8
+        MOVL         R8, (R10)
+        MOVL         $3, R8
+        MOVL         R8, (R9)
+        MOVQ         $0, BP
+        MOVQ         BP, t38-421(SP)
+        MOVQ         BP, t39-429(SP)
+        MOVOU        X13, t6-89(SP)
+        MOVOU        X14, t5-73(SP)
+block4:
+        // for.loop, preds block2 block3
+        MOVQ         t39-429(SP), R15
+        MOVQ         R15, R13
+        ADDQ         $4, R13
+        MOVQ         BX, R12
+        CMPQ         R13, R12
+        SETLE        R11
+        MOVQ         t38-421(SP), R10
+        MOVQ         R10, t45-463(SP)
+        MOVQ         R15, t46-471(SP)
+        MOVQ         col_base+24(FP), R9
+        LEAQ         (R9)(R15*8), R9
+        MOVQ         R9, ivptr0-24(SP)
+        MOVB         R11, t41-438(SP)
+        CMPB         R11, $0
+        JEQ          block7
+block3:
+        // for.body, preds block4
+        MOVQ         col_base+24(FP), R15
+        MOVQ         t39-429(SP), R13
+        MOVOU        (R15)(R13*8), X14
+        MOVQ         R13, R12
+        ADDQ         $2, R12
+        MOVOU        (R15)(R12*8), X13
+        MOVO         X0, X12
+        MOVO         X12, X11
+        PCMPGTQ      X14, X11
+        MOVO         X1, X10
+        MOVO         X14, X9
+        PCMPGTQ      X10, X9
+        MOVO         X11, X8
+        POR          X9, X8
+        MOVO         X12, X7
+        PCMPGTQ      X13, X7
+        MOVO         X13, X6
+        PCMPGTQ      X10, X6
+        MOVO         X7, X5
+        POR          X6, X5
+        MOVMSKPD     X8, R11
+        MOVMSKPD     X5, R10
+        SHLQ         $2, R10
+        ORQ          R10, R11
+        XORQ         $15, R11
+        MOVL         R13, R9
+        MOVLQZX      R9, R8
+        MOVQ         R8, X4
+        PSHUFL       $0, X4, X4
+        MOVOU        t7-16(SP), X3
+        MOVO         X3, X2
+        MOVOU        X4, t27-317(SP)
+        PADDL        X2, X4
+        MOVOU        X3, t7-16(SP)
+        MOVQ         R11, R8
+        ANDQ         $15, R8
+        SHLQ         $4, R8
+        LEAQ         SelectRangeInt64_const0<>(SB), BP
+        MOVOU        (BP)(R8*1), X3
+        MOVO         X4, X2
+        PSHUFB       X3, X2
+        MOVQ         idx_base+0(FP), R8
+        MOVQ         t38-421(SP), BP
+        MOVOU        X2, (R8)(BP*4)
+        SHLQ         $2, R11
+        MOVQ         R11, DI
+        MOVQ         $4841987667533046032, SI
+        MOVL         $63, R8
+        CMPQ         DI, $64
+        CMOVQCS      DI, R8
+        SARXQ        R8, SI, BP
+        ANDQ         $15, BP
+        MOVQ         t38-421(SP), R8
+        MOVQ         R8, SI
+        ADDQ         BP, SI
+        MOVQ         SI, t36-405(SP)
+        MOVQ         R13, SI
+        ADDQ         $4, SI
+        MOVQ         SI, t37-413(SP)
+        MOVQ         t36-405(SP), SI
+        MOVQ         SI, t38-421(SP)
+        MOVQ         t37-413(SP), R8
+        MOVQ         R8, t39-429(SP)
+        JMP block4
+block5:
+        // for.body, preds block7
+        MOVQ         ivptr0-24(SP), R15
+        MOVQ         R15, R13
+        MOVQ         (R13), R12
+        MOVQ         R12, t43-454(SP)
+        MOVQ         lo+48(FP), R12
+        MOVQ         t43-454(SP), R11
+        CMPQ         R12, R11
+        SETLE        R10
+        MOVQ         t45-463(SP), R9
+        MOVQ         R9, t51-500(SP)
+        MOVB         R10, t44-455(SP)
+        CMPB         R10, $0
+        JEQ          block9
+block10:
+        // cond.true, preds block5
+        MOVQ         t43-454(SP), R15
+        MOVQ         hi+56(FP), R13
+        CMPQ         R15, R13
+        SETLE        R12
+        MOVQ         t45-463(SP), R11
+        MOVQ         R11, t51-500(SP)
+        MOVB         R12, t53-509(SP)
+        CMPB         R12, $0
+        JEQ          block9
+block8:
+        // if.then, preds block10
+        MOVQ         t46-471(SP), R15
+        MOVL         R15, R13
+        MOVQ         t45-463(SP), R11
+        MOVQ         idx_base+0(FP), R12
+        LEAQ         (R12)(R11*4), R12
+        MOVL         R13, (R12)
+        MOVQ         R11, R10
+        ADDQ         $1, R10
+        MOVQ         R10, t51-500(SP)
+        MOVQ         R10, t50-492(SP)
+block9:
+        // if.done, preds block5 block10 block8
+        MOVQ         t46-471(SP), R15
+        MOVQ         R15, R13
+        ADDQ         $1, R13
+        MOVQ         t51-500(SP), R12
+        MOVQ         R12, t45-463(SP)
+        MOVQ         R13, t46-471(SP)
+        MOVQ         ivptr0-24(SP), R15
+        LEAQ         8(R15), R15
+        MOVQ         R15, ivptr0-24(SP)
+        MOVQ         R13, t52-508(SP)
+block7:
+        // for.loop, preds block4 block9
+        MOVQ         t46-471(SP), R15
+        MOVQ         BX, R13
+        CMPQ         R15, R13
+        JLT          block5
+block6:
+        // for.done, preds block7
+        MOVQ         t45-463(SP), R15
+        MOVQ         R15, ret+64(FP)
+        RET
+
+
+DATA SelectRangeInt64_const0<>+0(SB)/8, $0x8080808080808080
+DATA SelectRangeInt64_const0<>+8(SB)/8, $0x8080808080808080
+DATA SelectRangeInt64_const0<>+16(SB)/8, $0x8080808003020100
+DATA SelectRangeInt64_const0<>+24(SB)/8, $0x8080808080808080
+DATA SelectRangeInt64_const0<>+32(SB)/8, $0x8080808007060504
+DATA SelectRangeInt64_const0<>+40(SB)/8, $0x8080808080808080
+DATA SelectRangeInt64_const0<>+48(SB)/8, $0x0706050403020100
+DATA SelectRangeInt64_const0<>+56(SB)/8, $0x8080808080808080
+DATA SelectRangeInt64_const0<>+64(SB)/8, $0x808080800b0a0908
+DATA SelectRangeInt64_const0<>+72(SB)/8, $0x8080808080808080
+DATA SelectRangeInt64_const0<>+80(SB)/8, $0x0b0a090803020100
+DATA SelectRangeInt64_const0<>+88(SB)/8, $0x8080808080808080
+DATA SelectRangeInt64_const0<>+96(SB)/8, $0x0b0a090807060504
+DATA SelectRangeInt64_const0<>+104(SB)/8, $0x8080808080808080
+DATA SelectRangeInt64_const0<>+112(SB)/8, $0x0706050403020100
+DATA SelectRangeInt64_const0<>+120(SB)/8, $0x808080800b0a0908
+DATA SelectRangeInt64_const0<>+128(SB)/8, $0x808080800f0e0d0c
+DATA SelectRangeInt64_const0<>+136(SB)/8, $0x8080808080808080
+DATA SelectRangeInt64_const0<>+144(SB)/8, $0x0f0e0d0c03020100
+DATA SelectRangeInt64_const0<>+152(SB)/8, $0x8080808080808080
+DATA SelectRangeInt64_const0<>+160(SB)/8, $0x0f0e0d0c07060504
+DATA SelectRangeInt64_const0<>+168(SB)/8, $0x8080808080808080
+DATA SelectRangeInt64_const0<>+176(SB)/8, $0x0706050403020100
+DATA SelectRangeInt64_const0<>+184(SB)/8, $0x808080800f0e0d0c
+DATA SelectRangeInt64_const0<>+192(SB)/8, $0x0f0e0d0c0b0a0908
+DATA SelectRangeInt64_const0<>+200(SB)/8, $0x8080808080808080
+DATA SelectRangeInt64_const0<>+208(SB)/8, $0x0b0a090803020100
+DATA SelectRangeInt64_const0<>+216(SB)/8, $0x808080800f0e0d0c
+DATA SelectRangeInt64_const0<>+224(SB)/8, $0x0b0a090807060504
+DATA SelectRangeInt64_const0<>+232(SB)/8, $0x808080800f0e0d0c
+DATA SelectRangeInt64_const0<>+240(SB)/8, $0x0706050403020100
+DATA SelectRangeInt64_const0<>+248(SB)/8, $0x0f0e0d0c0b0a0908
+GLOBL SelectRangeInt64_const0<>(SB), RODATA|NOPTR, $256
+
+TEXT ·SplitMix64(SB),$120-40
+block0:
+        // entry
+        MOVQ         dst_len+8(FP), R15
+        MOVQ         R15, R13
+        MOVQ         R13, BX
+        MOVQ         seed+24(FP), R12
+        MOVQ         R12, t1-16(SP)
+        MOVQ         $-1, R11
+        MOVQ         R11, t2-24(SP)
+        MOVQ         R13, t0-8(SP)
+block1:
+        // rangeindex.loop, preds block0 block2
+        MOVQ         t2-24(SP), R15
+        MOVQ         R15, R13
+        ADDQ         $1, R13
+        MOVQ         BX, R12
+        CMPQ         R13, R12
+        MOVQ         R13, t3-32(SP)
+        JGE          block3
+block2:
+        // rangeindex.body, preds block1
+        MOVQ         t1-16(SP), R15
+        MOVQ         $-7046029254386353131, R13
+        MOVQ         R15, R12
+        ADDQ         R13, R12
+        MOVQ         R12, R11
+        SHRQ         $30, R11
+        MOVQ         R11, R10
+        XORQ         R12, R10
+        MOVQ         $-4658895280553007687, R9
+        MOVQ         R10, AX
+        MULQ         R9
+        MOVQ         AX, R10
+        MOVQ         R10, R8
+        SHRQ         $27, R8
+        XORQ         R8, R10
+        MOVQ         $-7723592293110705685, BP
+        MOVQ         R10, AX
+        MULQ         BP
+        MOVQ         AX, R10
+        MOVQ         R10, DI
+        SHRQ         $31, DI
+        XORQ         DI, R10
+        MOVQ         t3-32(SP), DI
+        MOVQ         dst_base+0(FP), SI
+        LEAQ         (SI)(DI*8), SI
+        MOVQ         R10, (SI)
+        MOVQ         R12, t1-16(SP)
+        MOVQ         DI, t2-24(SP)
+        MOVQ         R12, t5-41(SP)
+        JMP block1
+block3:
+        // rangeindex.done, preds block1
+        MOVQ         t1-16(SP), R15
+        MOVQ         R15, ret+32(FP)
+        RET
+
+TEXT ·SumInt64(SB),$72-32
+block0:
+        // entry
+        MOVQ         x_len+8(FP), R15
+        MOVQ         R15, R13
+        MOVQ         R13, R8
+        MOVQ         $0, R12
+        MOVQ         R12, t0-16(SP)
+        MOVQ         $0, R11
+        MOVQ         R11, t1-24(SP)
+        MOVQ         t0-16(SP), BX
+        MOVQ         x_base+0(FP), R10
+        LEAQ         (R10)(R11*8), R10
+        MOVQ         R10, ivptr0-8(SP)
+        MOVQ         R13, t2-32(SP)
+block1:
+        // for.loop, preds block0 block2
+        MOVQ         t1-24(SP), R15
+        MOVQ         R8, R13
+        CMPQ         R15, R13
+        JGE          block3
+block2:
+        // for.body, preds block1
+        MOVQ         ivptr0-8(SP), R15
+        MOVQ         R15, R13
+        MOVQ         (R13), R12
+        MOVQ         R12, t5-49(SP)
+        MOVQ         BX, R12
+        MOVQ         t5-49(SP), R11
+        MOVQ         R12, R10
+        ADDQ         R11, R10
+        MOVQ         R10, BX
+        MOVQ         t1-24(SP), R9
+        MOVQ         R9, BP
+        ADDQ         $1, BP
+        MOVQ         BP, t1-24(SP)
+        LEAQ         8(R15), R15
+        MOVQ         R15, ivptr0-8(SP)
+        MOVQ         BP, t7-65(SP)
+        JMP block1
+block3:
+        // for.done, preds block1
+        MOVQ         BX, R15
+        MOVQ         R15, ret+24(FP)
+        RET
+
+TEXT ·ValidUTF8(SB),$208-25
+block0:
+        // entry
+        MOVQ         s_len+8(FP), R15
+        MOVQ         R15, R13
+        MOVQ         $0, R12
+        MOVQ         R12, t3-25(SP)
+        MOVQ         R13, t0-8(SP)
+block3:
+        // for.loop, preds block0 block4 block7 block32
+        MOVQ         t3-25(SP), R8
+        MOVQ         R8, R15
+        MOVQ         t0-8(SP), R13
+        CMPQ         R15, R13
+        JGE          block2
+block1:
+        // for.body, preds block3
+        MOVQ         R8, R15
+        MOVQ         R15, R13
+        ADDQ         $16, R13
+        MOVQ         t0-8(SP), R12
+        CMPQ         R13, R12
+        JGT          block5
+block6:
+        // cond.true, preds block1
+        MOVQ         s_base+0(FP), R15
+        MOVQ         R8, R13
+        MOVOU        (R15)(R13*1), X14
+        PMOVMSKB     X14, R12
+        CMPQ         R12, $0
+        JEQ          block4
+block5:
+        // if.done, preds block1 block6
+        MOVQ         R8, R13
+        MOVQ         s_base+0(FP), R15
+        LEAQ         (R15)(R13*1), R15
+        MOVB         (R15), R12
+        MOVB         R12, t7-43(SP)
+        MOVBQZX      t7-43(SP), R12
+        CMPB         R12, $-128
+        JCC          block8
+block7:
+        // if.then, preds block5
+        MOVQ         R8, R15
+        MOVQ         R15, R13
+        ADDQ         $1, R13
+        MOVQ         R13, t3-25(SP)
+        MOVQ         R13, t12-77(SP)
+        JMP block3
+block2:
+        // for.done, preds block3
+        MOVB         $1, R15
+        MOVB         R15, ret+24(FP)
+        RET
+block4:
+        // if.then, preds block6
+        MOVQ         R8, R13
+        MOVQ         R13, R12
+        ADDQ         $16, R12
+        MOVQ         R12, t3-25(SP)
+        MOVQ         R12, t5-34(SP)
+        JMP block3
+block8:
+        // if.done, preds block5
+        MOVBQZX      t7-43(SP), R15
+        CMPB         R15, $-62
+        JCS          block11
+block12:
+        // cond.true, preds block8
+        MOVBQZX      t7-43(SP), R15
+        CMPB         R15, $-33
+        JHI          block11
+block9:
+        // if.then, preds block12
+        MOVQ         $2, R15
+        MOVQ         R15, t14-86(SP)
+        MOVB         $-128, R13
+        MOVB         R13, t15-87(SP)
+        MOVB         $-65, R12
+        MOVB         R12, t16-88(SP)
+block10:
+        // if.done, preds block9 block16 block22 block17 block18 block23 block24
+        MOVQ         t14-86(SP), BX
+        MOVQ         t0-8(SP), R15
+        MOVQ         R8, R13
+        MOVQ         R15, R12
+        SUBQ         R13, R12
+        MOVQ         BX, R11
+        CMPQ         R12, R11
+        JLT          block25
+block26:
+        // if.done, preds block10
+        MOVQ         R8, R15
+        MOVQ         R15, R13
+        ADDQ         $1, R13
+        MOVQ         s_base+0(FP), R12
+        LEAQ         (R12)(R13*1), R12
+        MOVB         (R12), R11
+        MOVB         R11, t30-123(SP)
+        MOVBQZX      t30-123(SP), R11
+        MOVBQZX      t15-87(SP), R10
+        CMPB         R11, R10
+        JCS          block27
+block29:
+        // cond.false, preds block26
+        MOVQ         R8, R15
+        MOVQ         R15, R13
+        ADDQ         $1, R13
+        MOVQ         s_base+0(FP), R12
+        LEAQ         (R12)(R13*1), R12
+        MOVB         (R12), R11
+        MOVB         R11, t34-141(SP)
+        MOVBQZX      t34-141(SP), R11
+        MOVBQZX      t16-88(SP), R10
+        CMPB         R11, R10
+        JHI          block27
+block28:
+        // if.done, preds block29
+        MOVQ         $2, R15
+        MOVQ         R15, t36-150(SP)
+block30:
+        // for.loop, preds block28 block34
+        MOVQ         t36-150(SP), R15
+        MOVQ         BX, R13
+        CMPQ         R15, R13
+        JGE          block32
+block31:
+        // for.body, preds block30
+        MOVQ         R8, R15
+        MOVQ         t36-150(SP), R13
+        MOVQ         R15, R12
+        ADDQ         R13, R12
+        MOVQ         s_base+0(FP), R11
+        LEAQ         (R11)(R12*1), R11
+        MOVB         (R11), R10
+        MOVB         R10, t40-168(SP)
+        MOVBQZX      t40-168(SP), R10
+        CMPB         R10, $-128
+        JCS          block33
+block35:
+        // cond.false, preds block31
+        MOVQ         R8, R15
+        MOVQ         t36-150(SP), R13
+        MOVQ         R15, R12
+        ADDQ         R13, R12
+        MOVQ         s_base+0(FP), R11
+        LEAQ         (R11)(R12*1), R11
+        MOVB         (R11), R10
+        MOVB         R10, t46-202(SP)
+        MOVBQZX      t46-202(SP), R10
+        CMPB         R10, $-65
+        JHI          block33
+block34:
+        // if.done, preds block35
+        MOVQ         t36-150(SP), R15
+        MOVQ         R15, R13
+        ADDQ         $1, R13
+        MOVQ         R13, t36-150(SP)
+        MOVQ         R13, t43-185(SP)
+        JMP block30
+block11:
+        // if.else, preds block8 block12
+        MOVBQZX      t7-43(SP), R15
+        CMPB         R15, $-32
+        JCS          block14
+block15:
+        // cond.true, preds block11
+        MOVBQZX      t7-43(SP), R15
+        CMPB         R15, $-17
+        JHI          block14
+block13:
+        // if.then, preds block15
+        MOVBQZX      t7-43(SP), R15
+        CMPB         R15, $-32
+        JNE          block17
+block16:
+        // if.then, preds block13
+        MOVQ         $3, R15
+        MOVQ         R15, t14-86(SP)
+        MOVB         $-96, R13
+        MOVB         R13, t15-87(SP)
+        MOVB         $-65, R12
+        MOVB         R12, t16-88(SP)
+        JMP block10
+block14:
+        // if.else, preds block11 block15
+        MOVBQZX      t7-43(SP), R15
+        CMPB         R15, $-16
+        JCS          block20
+block21:
+        // cond.true, preds block14
+        MOVBQZX      t7-43(SP), R15
+        CMPB         R15, $-12
+        JHI          block20
+block19:
+        // if.then, preds block21
+        MOVBQZX      t7-43(SP), R15
+        CMPB         R15, $-16
+        JNE          block23
+block22:
+        // if.then, preds block19
+        MOVQ         $4, R15
+        MOVQ         R15, t14-86(SP)
+        MOVB         $-112, R13
+        MOVB         R13, t15-87(SP)
+        MOVB         $-65, R12
+        MOVB         R12, t16-88(SP)
+        JMP block10
+block17:
+        // if.else, preds block13
+        MOVBQZX      t7-43(SP), R15
+        CMPB         R15, $-19
+        SETEQ        R13
+        MOVQ         $3, R12
+        MOVQ         R12, t14-86(SP)
+        MOVB         $-128, R11
+        MOVB         R11, t15-87(SP)
+        MOVB         $-65, R10
+        MOVB         R10, t16-88(SP)
+        MOVB         R13, t24-103(SP)
+        CMPB         R13, $0
+        JEQ          block10
+block18:
+        // if.then, preds block17
+        MOVQ         $3, R15
+        MOVQ         R15, t14-86(SP)
+        MOVB         $-128, R13
+        MOVB         R13, t15-87(SP)
+        MOVB         $-97, R12
+        MOVB         R12, t16-88(SP)
+        JMP block10
+block20:
+        // if.else, preds block14 block21
+        MOVB         $0, R15
+        MOVB         R15, ret+24(FP)
+        RET
+block23:
+        // if.else, preds block19
+        MOVBQZX      t7-43(SP), R15
+        CMPB         R15, $-12
+        SETEQ        R13
+        MOVQ         $4, R12
+        MOVQ         R12, t14-86(SP)
+        MOVB         $-128, R11
+        MOVB         R11, t15-87(SP)
+        MOVB         $-65, R10
+        MOVB         R10, t16-88(SP)
+        MOVB         R13, t27-106(SP)
+        CMPB         R13, $0
+        JEQ          block10
+block24:
+        // if.then, preds block23
+        MOVQ         $4, R15
+        MOVQ         R15, t14-86(SP)
+        MOVB         $-128, R13
+        MOVB         R13, t15-87(SP)
+        MOVB         $-113, R12
+        MOVB         R12, t16-88(SP)
+        JMP block10
+block25:
+        // if.then, preds block10
+        MOVB         $0, R15
+        MOVB         R15, ret+24(FP)
+        RET
+block27:
+        // if.then, preds block26 block29
+        MOVB         $0, R15
+        MOVB         R15, ret+24(FP)
+        RET
+block32:
+        // for.done, preds block30
+        MOVQ         R8, R15
+        MOVQ         BX, R13
+        MOVQ         R15, R12
+        ADDQ         R13, R12
+        MOVQ         R12, t3-25(SP)
+        MOVQ         R12, t42-177(SP)
+        JMP block3
+block33:
+        // if.then, preds block31 block35
+        MOVB         $0, R15
+        MOVB         R15, ret+24(FP)
+        RET
+
+TEXT ·Xoshiro256PlusPlus(SB),$472-56
+block0:
+        // entry
+        MOVQ         state_len+32(FP), R15
+        MOVQ         R15, R13
+        CMPQ         R13, $4
+        JGE          block2
+block1:
+        // if.then, preds block0
+        MOVQ         $0, R15
+        MOVQ         R15, ret+48(FP)
+        RET
+block2:
+        // if.done, preds block0
+        MOVQ         state_base+24(FP), R15
+        MOVQ         R15, R13
+        MOVOU        (R13), X14
+        MOVOU        X14, t3-33(SP)
+        MOVQ         state_base+24(FP), R13
+        ADDQ         $16, R13
+        MOVQ         R13, R12
+        MOVOU        (R12), X14
+        MOVOU        X14, t5-57(SP)
+        MOVQ         state_base+24(FP), R12
+        ADDQ         $32, R12
+        MOVQ         R12, R11
+        MOVOU        (R11), X14
+        MOVOU        X14, t7-81(SP)
+        MOVQ         state_base+24(FP), R11
+        ADDQ         $48, R11
+        MOVQ         R11, R10
+        MOVOU        (R10), X14
+        MOVOU        X14, t9-105(SP)
+        MOVQ         dst_len+8(FP), R10
+        MOVQ         R10, R9
+        MOVQ         R9, BX
+        MOVOU        t3-33(SP), X14
+        MOVOU        X14, t11-129(SP)
+        MOVOU        t5-57(SP), X13
+        MOVOU        X13, t12-145(SP)
+        MOVOU        t7-81(SP), X12
+        MOVOU        X12, t13-161(SP)
+        MOVOU        t9-105(SP), X11
+        MOVOU        X11, t14-177(SP)
+        MOVQ         $-1, R8
+        MOVQ         R8, t15-185(SP)
+        MOVQ         R9, t10-113(SP)
+block3:
+        // rangeindex.loop, preds block2 block4
+        MOVQ         t15-185(SP), R15
+        MOVQ         R15, R13
+        ADDQ         $1, R13
+        MOVQ         BX, R12
+        CMPQ         R13, R12
+        MOVQ         R13, t16-193(SP)
+        JGE          block5
+block4:
+        // rangeindex.body, preds block3
+        MOVOU        t14-177(SP), X14
+        MOVOU        t11-129(SP), X13
+        PADDQ        X14, X13
+        MOVB         $23, R15
+        MOVBQZX      R15, R13
+        MOVQ         R13, X12
+        MOVOU        X13, t18-210(SP)
+        PSLLQ        X12, X13
+        MOVB         $41, R13
+        MOVBQZX      R13, R12
+        MOVQ         R12, X12
+        MOVOU        t18-210(SP), X11
+        PSRLQ        X12, X11
+        MOVO         X13, X12
+        POR          X11, X12
+        MOVOU        t11-129(SP), X10
+        MOVOU        X12, t21-258(SP)
+        PADDQ        X10, X12
+        MOVQ         t16-193(SP), R11
+        IMUL3Q       $16, R11, R11
+        MOVQ         dst_base+0(FP), R12
+        ADDQ         R11, R12
+        MOVOU        X12, (R12)
+        MOVB         $17, R11
+        MOVBQZX      R11, R10
+        MOVQ         R10, X9
+        MOVOU        t12-145(SP), X8
+        PSLLQ        X9, X8
+        MOVOU        t13-161(SP), X9
+        MOVO         X9, X7
+        PXOR         X10, X7
+        MOVOU        t12-145(SP), X6
+        MOVO         X14, X5
+        PXOR         X6, X5
+        MOVO         X6, X4
+        PXOR         X7, X4
+        MOVO         X10, X3
+        PXOR         X5, X3
+        MOVO         X7, X2
+        PXOR         X8, X2
+        MOVB         $45, R10
+        MOVBQZX      R10, R9
+        MOVQ         R9, X1
+        MOVOU        X5, t26-330(SP)
+        PSLLQ        X1, X5
+        MOVB         $19, R9
+        MOVBQZX      R9, R8
+        MOVQ         R8, X1
+        MOVOU        t26-330(SP), X0
+        PSRLQ        X1, X0
+        MOVO         X5, X1
+        POR          X0, X1
+        MOVOU        X3, t11-129(SP)
+        MOVOU        X4, t12-145(SP)
+        MOVOU        X2, t13-161(SP)
+        MOVOU        X1, t14-177(SP)
+        MOVQ         t16-193(SP), R8
+        MOVQ         R8, t15-185(SP)
+        MOVOU        X1, t32-426(SP)
+        MOVOU        X2, t29-378(SP)
+        MOVOU        X3, t28-362(SP)
+        MOVOU        X4, t27-346(SP)
+        JMP block3
+block5:
+        // rangeindex.done, preds block3
+        MOVQ         state_base+24(FP), R15
+        MOVOU        t11-129(SP), X14
+        MOVOU        X14, (R15)
+        MOVQ         state_base+24(FP), R13
+        ADDQ         $16, R13
+        MOVOU        t12-145(SP), X13
+        MOVOU        X13, (R13)
+        MOVQ         state_base+24(FP), R12
+        ADDQ         $32, R12
+        MOVOU        t13-161(SP), X12
+        MOVOU        X12, (R12)
+        MOVQ         state_base+24(FP), R11
+        ADDQ         $48, R11
+        MOVOU        t14-177(SP), X11
+        MOVOU        X11, (R11)
+        MOVQ         dst_len+8(FP), R10
+        MOVQ         R10, R9
+        MOVQ         R9, ret+48(FP)
+        RET
+
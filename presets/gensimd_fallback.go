@@ -0,0 +1,30 @@
+// Code generated by gensimd. DO NOT EDIT.
+// gensimd version: devel
+// gensimd command: gensimd -o . -pkg ref
+// gensimd source: ref sha256:1b94b12a9f87f9569740351175fe66cefd4251de0cec5a0c9ab54c55cd915627
+// gensimd target: avx2
+
+//go:build !(amd64 && !noasm && !appengine)
+// +build !amd64 noasm appengine
+
+package presets
+
+import "github.com/bjwbell/gensimd/simd"
+
+func ByteReverse(dst []byte, src []byte) int { return byteReverseGeneric(dst, src) }
+func FilterRangeInt64(bitmap []uint64, col []int64, lo int64, hi int64) int { return filterRangeInt64Generic(bitmap, col, lo, hi) }
+func HexDecode(dst []byte, src []byte) int { return hexDecodeGeneric(dst, src) }
+func HexEncode(dst []byte, src []byte) int { return hexEncodeGeneric(dst, src) }
+func IndexByte(s []byte, c byte) int { return indexByteGeneric(s, c) }
+func IndexNonASCII(s []byte) int { return indexNonASCIIGeneric(s) }
+func MatMul4x4(dst []simd.F32x4, a []simd.F32x4, b []simd.F32x4) { matMul4x4Generic(dst, a, b) }
+func MatMul8x8(dst []simd.F32x4, a []simd.F32x4, b []simd.F32x4) { matMul8x8Generic(dst, a, b) }
+func MemcpyAligned(dst []simd.U8x16, src []simd.U8x16) int { return memcpyAlignedGeneric(dst, src) }
+func Memset32(dst []uint32, v uint32) { memset32Generic(dst, v) }
+func MulAddGF8(dst []simd.U8x16, src []simd.U8x16, lo simd.U8x16, hi simd.U8x16) int { return mulAddGF8Generic(dst, src, lo, hi) }
+func MulGF8(dst []simd.U8x16, src []simd.U8x16, lo simd.U8x16, hi simd.U8x16) int { return mulGF8Generic(dst, src, lo, hi) }
+func SelectRangeInt64(idx []int32, col []int64, lo int64, hi int64) int { return selectRangeInt64Generic(idx, col, lo, hi) }
+func SplitMix64(dst []uint64, seed uint64) uint64 { return splitMix64Generic(dst, seed) }
+func SumInt64(x []int64) int64 { return sumInt64Generic(x) }
+func ValidUTF8(s []byte) bool { return validUTF8Generic(s) }
+func Xoshiro256PlusPlus(dst []simd.U64x2, state []simd.U64x2) int { return xoshiro256PlusPlusGeneric(dst, state) }
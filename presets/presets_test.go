@@ -0,0 +1,464 @@
+package presets
+
+import (
+	"bytes"
+	"encoding/hex"
+	"math/bits"
+	"strings"
+	"testing"
+	"unicode/utf8"
+	"unsafe"
+
+	"github.com/bjwbell/gensimd/simd"
+)
+
+// lengths cover empty slices, a single element, and odd lengths.
+var lengths = []int{0, 1, 2, 7, 16, 33}
+
+func TestMemset32(t *testing.T) {
+	for _, n := range lengths {
+		dst := make([]uint32, n+1)
+		Memset32(dst[:n], 0xdeadbeef)
+		for i := 0; i < n; i++ {
+			if dst[i] != 0xdeadbeef {
+				t.Fatalf("Memset32 of %v elements, dst[%v] = %#x, expected 0xdeadbeef", n, i, dst[i])
+			}
+		}
+		if dst[n] != 0 {
+			t.Errorf("Memset32 of %v elements wrote past the end", n)
+		}
+	}
+}
+
+func TestMemcpyAligned(t *testing.T) {
+	for _, n := range lengths {
+		src := make([]simd.U8x16, n)
+		for i := range src {
+			for j := range src[i] {
+				src[i][j] = uint8(i*16 + j)
+			}
+		}
+		for _, m := range []int{n, n / 2} {
+			dst := make([]simd.U8x16, m)
+			if m > 0 && (uintptr(unsafe.Pointer(&dst[0]))%16 != 0 || uintptr(unsafe.Pointer(&src[0]))%16 != 0) {
+				t.Skip("slices aren't 16 byte aligned")
+			}
+			if got := MemcpyAligned(dst, src); got != m {
+				t.Errorf("MemcpyAligned of %v to %v vectors returned %v, expected %v", n, m, got, m)
+			}
+			for i := range dst {
+				if dst[i] != src[i] {
+					t.Fatalf("MemcpyAligned of %v vectors, dst[%v] = %v, expected %v", n, i, dst[i], src[i])
+				}
+			}
+		}
+	}
+}
+
+func TestByteReverse(t *testing.T) {
+	for _, n := range lengths {
+		src := make([]byte, n)
+		for i := range src {
+			src[i] = byte(i + 1)
+		}
+		dst := make([]byte, n+1)
+		if got := ByteReverse(dst, src); got != n {
+			t.Errorf("ByteReverse of %v bytes returned %v", n, got)
+		}
+		expected := make([]byte, n+1)
+		byteReverseGeneric(expected, src)
+		if string(dst) != string(expected) {
+			t.Errorf("ByteReverse of %v bytes = %v, expected %v", n, dst, expected)
+		}
+	}
+}
+
+func TestSumInt64(t *testing.T) {
+	for _, n := range lengths {
+		x := make([]int64, n)
+		for i := range x {
+			x[i] = int64(i)*(1<<60) - 3
+		}
+		if got, expected := SumInt64(x), sumInt64Generic(x); got != expected {
+			t.Errorf("SumInt64 of %v elements = %v, expected %v", n, got, expected)
+		}
+	}
+}
+
+// matMul returns the product of the n x n matrices a and b stored n/4
+// vectors per row.
+func matMul(a, b []simd.F32x4, n int) []simd.F32x4 {
+	at := func(m []simd.F32x4, i, j int) float32 { return m[(i*n+j)/4][(i*n+j)%4] }
+	c := make([]simd.F32x4, n*n/4)
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			sum := float32(0)
+			for k := 0; k < n; k++ {
+				sum += at(a, i, k) * at(b, k, j)
+			}
+			c[(i*n+j)/4][(i*n+j)%4] = sum
+		}
+	}
+	return c
+}
+
+// matrix returns an n x n matrix of small integers, so the products are
+// exact in any order.
+func matrix(n, seed int) []simd.F32x4 {
+	m := make([]simd.F32x4, n*n/4)
+	for i := range m {
+		for j := range m[i] {
+			m[i][j] = float32((i*4+j)*seed%7 - 3)
+		}
+	}
+	return m
+}
+
+func TestMatMul(t *testing.T) {
+	for _, n := range []int{4, 8} {
+		a, b := matrix(n, 3), matrix(n, 5)
+		dst := make([]simd.F32x4, n*n/4)
+		if n == 4 {
+			MatMul4x4(dst, a, b)
+		} else {
+			MatMul8x8(dst, a, b)
+		}
+		expected := matMul(a, b, n)
+		for i := range dst {
+			if dst[i] != expected[i] {
+				t.Errorf("MatMul%vx%v, dst[%v] = %v, expected %v", n, n, i, dst[i], expected[i])
+			}
+		}
+	}
+}
+
+func BenchmarkMatMul4x4(b *testing.B) {
+	x, y, dst := matrix(4, 3), matrix(4, 5), make([]simd.F32x4, 4)
+	for i := 0; i < b.N; i++ {
+		MatMul4x4(dst, x, y)
+	}
+}
+
+func BenchmarkMatMul8x8(b *testing.B) {
+	x, y, dst := matrix(8, 3), matrix(8, 5), make([]simd.F32x4, 16)
+	for i := 0; i < b.N; i++ {
+		MatMul8x8(dst, x, y)
+	}
+}
+
+// gf8Vectors returns n vectors of bytes derived from seed.
+func gf8Vectors(n, seed int) []simd.U8x16 {
+	v := make([]simd.U8x16, n)
+	for i := range v {
+		for j := range v[i] {
+			v[i][j] = uint8((i*16 + j) * seed)
+		}
+	}
+	return v
+}
+
+func TestMulGF8(t *testing.T) {
+	if !simd.SSSE3() {
+		t.Skip("PSHUFB needs SSSE3")
+	}
+	for _, n := range lengths {
+		src := gf8Vectors(n, 7)
+		for _, c := range []uint8{0, 1, 2, 0x1d, 0xff} {
+			lo, hi := simd.GF8MulTables(c)
+			dst := gf8Vectors(n+1, 3)
+			if got := MulGF8(dst[:n], src, lo, hi); got != n {
+				t.Errorf("MulGF8 of %v vectors returned %v", n, got)
+			}
+			added := gf8Vectors(n, 3)
+			if got := MulAddGF8(added, src, lo, hi); got != n {
+				t.Errorf("MulAddGF8 of %v vectors returned %v", n, got)
+			}
+			for i := 0; i < n; i++ {
+				expected := simd.MulGF8U8x16(src[i], c)
+				if dst[i] != expected {
+					t.Fatalf("MulGF8 by %v, dst[%v] = %v, expected %v", c, i, dst[i], expected)
+				}
+				expected = simd.XorU8x16(gf8Vectors(n, 3)[i], expected)
+				if added[i] != expected {
+					t.Fatalf("MulAddGF8 by %v, dst[%v] = %v, expected %v", c, i, added[i], expected)
+				}
+			}
+			if dst[n] != gf8Vectors(n+1, 3)[n] {
+				t.Errorf("MulGF8 of %v vectors wrote past the end", n)
+			}
+		}
+	}
+}
+
+func BenchmarkMulAddGF8(b *testing.B) {
+	dst, src := gf8Vectors(64, 3), gf8Vectors(64, 7)
+	lo, hi := simd.GF8MulTables(0x8e)
+	b.SetBytes(64 * 16)
+	for i := 0; i < b.N; i++ {
+		MulAddGF8(dst, src, lo, hi)
+	}
+}
+
+func TestIndexByte(t *testing.T) {
+	s := make([]byte, 40)
+	for i := range s {
+		s[i] = byte(i + 1)
+	}
+	for _, n := range lengths {
+		for _, c := range []byte{0, 1, 7, 16, 17, 33, 40} {
+			if got, expected := IndexByte(s[:n], c), bytes.IndexByte(s[:n], c); got != expected {
+				t.Errorf("IndexByte(%v bytes, %v) = %v, expected %v", n, c, got, expected)
+			}
+		}
+	}
+}
+
+func TestIndexNonASCII(t *testing.T) {
+	for _, n := range lengths {
+		for _, i := range []int{-1, 0, 1, 15, 16, 32} {
+			s := bytes.Repeat([]byte("a"), n)
+			expected := -1
+			if i >= 0 && i < n {
+				s[i] = 0x80
+				expected = i
+			}
+			if got := IndexNonASCII(s); got != expected {
+				t.Errorf("IndexNonASCII(%q) = %v, expected %v", s, got, expected)
+			}
+		}
+	}
+}
+
+func TestValidUTF8(t *testing.T) {
+	ascii := "0123456789abcdefghijklmnopqrstuvwxyz"
+	for _, s := range []string{
+		"", "a", ascii, "é", "日本語", "\U0010ffff", ascii + "日本語" + ascii + "€",
+		// overlong, surrogate, above U+10FFFF, and truncated encodings
+		"\xc0\x80", "\xe0\x80\x80", "\xed\xa0\x80", "\xf4\x90\x80\x80", "\xf0\x80\x80\x80",
+		ascii + "\xe6\x97", ascii + "\x80", "\xff", "a\xc3", "\xe6\x97\xa5\xe6\x97",
+	} {
+		if got, expected := ValidUTF8([]byte(s)), utf8.ValidString(s); got != expected {
+			t.Errorf("ValidUTF8(%q) = %v, expected %v", s, got, expected)
+		}
+	}
+}
+
+func BenchmarkIndexNonASCII(b *testing.B) {
+	s := bytes.Repeat([]byte("a"), 4096)
+	b.SetBytes(int64(len(s)))
+	for i := 0; i < b.N; i++ {
+		IndexNonASCII(s)
+	}
+}
+
+func TestHex(t *testing.T) {
+	if !simd.SSSE3() {
+		t.Skip("PSHUFB needs SSSE3")
+	}
+	for _, n := range []int{0, 1, 15, 16, 17, 40} {
+		src := make([]byte, n)
+		for i := range src {
+			src[i] = byte(i * 37)
+		}
+		dst := make([]byte, 2*n+1)
+		if got := HexEncode(dst, src); got != 2*n {
+			t.Errorf("HexEncode of %v bytes returned %v", n, got)
+		}
+		expected := hex.EncodeToString(src)
+		if string(dst[:2*n]) != expected || dst[2*n] != 0 {
+			t.Errorf("HexEncode(%v) = %q, expected %q", src, dst, expected)
+		}
+		decoded := make([]byte, n)
+		for _, s := range []string{expected, strings.ToUpper(expected)} {
+			if got := HexDecode(decoded, []byte(s)); got != n || !bytes.Equal(decoded, src) {
+				t.Errorf("HexDecode(%q) = %v, %v, expected %v", s, got, decoded, src)
+			}
+		}
+		// an invalid digit stops decoding at its pair
+		for i := 0; i < 2*n; i += 7 {
+			for _, c := range []byte{'/', ':', '@', 'G', '`', 'g', 0xb0} {
+				s := []byte(expected)
+				s[i] = c
+				if got := HexDecode(decoded, s); got != i/2 {
+					t.Errorf("HexDecode(%q) = %v, expected %v", s, got, i/2)
+				}
+			}
+		}
+	}
+}
+
+func BenchmarkHexDecode(b *testing.B) {
+	src := bytes.Repeat([]byte("0123456789abcdefABCDEF"), 64)
+	dst := make([]byte, len(src)/2)
+	b.SetBytes(int64(len(src)))
+	for i := 0; i < b.N; i++ {
+		HexDecode(dst, src)
+	}
+}
+
+func TestSplitMix64(t *testing.T) {
+	// the first outputs for seed 1234567 of the reference C implementation
+	expected := []uint64{6457827717110365317, 3203168211198807973, 9817491932198370423, 4593380528125082431, 16408922859458223821}
+	dst := make([]uint64, len(expected)+1)
+	if seed := SplitMix64(dst[:len(expected)], 1234567); seed != 1234567+uint64(len(expected))*0x9e3779b97f4a7c15 {
+		t.Errorf("SplitMix64 returned the seed %#x", seed)
+	}
+	for i := range expected {
+		if dst[i] != expected[i] {
+			t.Errorf("SplitMix64 dst[%v] = %v, expected %v", i, dst[i], expected[i])
+		}
+	}
+	if dst[len(expected)] != 0 {
+		t.Errorf("SplitMix64 wrote past the end")
+	}
+}
+
+// xoshiro256pp is the scalar xoshiro256++ generator of a single lane.
+func xoshiro256pp(s *[4]uint64) uint64 {
+	result := bits.RotateLeft64(s[0]+s[3], 23) + s[0]
+	t := s[1] << 17
+	s[2] ^= s[0]
+	s[3] ^= s[1]
+	s[1] ^= s[2]
+	s[0] ^= s[3]
+	s[2] ^= t
+	s[3] = bits.RotateLeft64(s[3], 45)
+	return result
+}
+
+func TestXoshiro256PlusPlus(t *testing.T) {
+	var seeds [8]uint64
+	SplitMix64(seeds[:], 42)
+	state := make([]simd.U64x2, 4)
+	var lanes [2][4]uint64
+	for j := range state {
+		state[j] = simd.U64x2{seeds[j], seeds[4+j]}
+		lanes[0][j], lanes[1][j] = seeds[j], seeds[4+j]
+	}
+	if Xoshiro256PlusPlus(make([]simd.U64x2, 1), state[:3]) != 0 {
+		t.Errorf("Xoshiro256PlusPlus with 3 words of state didn't return 0")
+	}
+	// the sequences continue across calls
+	for _, n := range lengths {
+		dst := make([]simd.U64x2, n)
+		if got := Xoshiro256PlusPlus(dst, state); got != n {
+			t.Errorf("Xoshiro256PlusPlus of %v vectors returned %v", n, got)
+		}
+		for i := range dst {
+			if expected := (simd.U64x2{xoshiro256pp(&lanes[0]), xoshiro256pp(&lanes[1])}); dst[i] != expected {
+				t.Fatalf("Xoshiro256PlusPlus of %v vectors, dst[%v] = %#x, expected %#x", n, i, dst[i], expected)
+			}
+		}
+	}
+	for j := range state {
+		if state[j] != (simd.U64x2{lanes[0][j], lanes[1][j]}) {
+			t.Errorf("Xoshiro256PlusPlus state[%v] = %#x, expected %#x", j, state[j], simd.U64x2{lanes[0][j], lanes[1][j]})
+		}
+	}
+}
+
+func BenchmarkXoshiro256PlusPlus(b *testing.B) {
+	state := []simd.U64x2{{1, 2}, {3, 4}, {5, 6}, {7, 8}}
+	dst := make([]simd.U64x2, 1024)
+	b.SetBytes(int64(len(dst) * 16))
+	for i := 0; i < b.N; i++ {
+		Xoshiro256PlusPlus(dst, state)
+	}
+}
+
+// filterColumn returns a column with values around and at the bounds of
+// the ranges tested, and the extremes.
+func filterColumn(n int) []int64 {
+	col := make([]int64, n)
+	for i := range col {
+		switch i % 7 {
+		case 0:
+			col[i] = -1 << 63
+		case 1:
+			col[i] = 1<<63 - 1
+		default:
+			col[i] = int64(i*2654435761%41) - 20
+		}
+	}
+	return col
+}
+
+// filterRanges are the lo, hi of the ranges tested, including empty ones
+// and equality.
+var filterRanges = [][2]int64{{-5, 5}, {0, 0}, {5, -5}, {-1 << 63, 0}, {0, 1<<63 - 1}, {-1 << 63, 1<<63 - 1}}
+
+func TestFilterRangeInt64(t *testing.T) {
+	for _, n := range []int{0, 1, 63, 64, 65, 130, 200} {
+		col := filterColumn(n)
+		for _, r := range filterRanges {
+			lo, hi := r[0], r[1]
+			bitmap := make([]uint64, (n+63)/64+1)
+			for i := range bitmap {
+				bitmap[i] = ^uint64(0)
+			}
+			if got := FilterRangeInt64(bitmap[:(n+63)/64], col, lo, hi); got != n {
+				t.Errorf("FilterRangeInt64 of %v rows returned %v", n, got)
+			}
+			for i := 0; i < 64*((n+63)/64); i++ {
+				expected := i < n && lo <= col[i] && col[i] <= hi
+				if got := bitmap[i/64]>>uint(i%64)&1 == 1; got != expected {
+					t.Fatalf("FilterRangeInt64 of %v rows in [%v, %v], bit %v = %v, expected %v", n, lo, hi, i, got, expected)
+				}
+			}
+			if bitmap[len(bitmap)-1] != ^uint64(0) {
+				t.Errorf("FilterRangeInt64 of %v rows wrote past the end", n)
+			}
+		}
+	}
+	// the rows past the bitmap aren't filtered
+	if got := FilterRangeInt64(make([]uint64, 1), filterColumn(100), 0, 0); got != 64 {
+		t.Errorf("FilterRangeInt64 of 100 rows into 64 bits returned %v", got)
+	}
+}
+
+func TestSelectRangeInt64(t *testing.T) {
+	for _, n := range []int{0, 1, 3, 4, 5, 33, 200} {
+		col := filterColumn(n)
+		for _, r := range filterRanges {
+			lo, hi := r[0], r[1]
+			expected := []int32{}
+			for i, x := range col {
+				if lo <= x && x <= hi {
+					expected = append(expected, int32(i))
+				}
+			}
+			idx := make([]int32, n)
+			got := SelectRangeInt64(idx, col, lo, hi)
+			if got != len(expected) {
+				t.Fatalf("SelectRangeInt64 of %v rows in [%v, %v] returned %v, expected %v", n, lo, hi, got, len(expected))
+			}
+			for i := range expected {
+				if idx[i] != expected[i] {
+					t.Fatalf("SelectRangeInt64 of %v rows in [%v, %v], idx[%v] = %v, expected %v", n, lo, hi, i, idx[i], expected[i])
+				}
+			}
+		}
+	}
+	// the rows past idx aren't selected
+	if got := SelectRangeInt64(make([]int32, 5), make([]int64, 10), 0, 0); got != 5 {
+		t.Errorf("SelectRangeInt64 of 10 rows into 5 indexes returned %v", got)
+	}
+}
+
+func BenchmarkFilterRangeInt64(b *testing.B) {
+	col := filterColumn(4096)
+	bitmap := make([]uint64, len(col)/64)
+	b.SetBytes(int64(len(col) * 8))
+	for i := 0; i < b.N; i++ {
+		FilterRangeInt64(bitmap, col, -5, 5)
+	}
+}
+
+func BenchmarkSelectRangeInt64(b *testing.B) {
+	col := filterColumn(4096)
+	idx := make([]int32, len(col))
+	b.SetBytes(int64(len(col) * 8))
+	for i := 0; i < b.N; i++ {
+		SelectRangeInt64(idx, col, -5, 5)
+	}
+}
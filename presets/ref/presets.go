@@ -0,0 +1,412 @@
+package presets
+
+import (
+	"math/bits"
+
+	"github.com/bjwbell/gensimd/simd"
+)
+
+// Memset32 sets every element of dst to v.
+func Memset32(dst []uint32, v uint32) {
+	for i := range dst {
+		dst[i] = v
+	}
+}
+
+// MemcpyAligned copies the vectors of src to dst, both 16 byte aligned, and
+// returns the number copied, the shorter length.
+//
+//gensimd:align 16 dst src
+func MemcpyAligned(dst, src []simd.U8x16) int {
+	n := len(src)
+	if len(dst) < n {
+		n = len(dst)
+	}
+	for i := 0; i < n; i++ {
+		dst[i] = src[i]
+	}
+	return n
+}
+
+// ByteReverse copies src to dst in reverse byte order and returns the number
+// of bytes copied, the shorter length.
+func ByteReverse(dst, src []byte) int {
+	n := len(src)
+	if len(dst) < n {
+		n = len(dst)
+	}
+	for i := 0; i < n; i++ {
+		dst[i] = src[n-1-i]
+	}
+	return n
+}
+
+// SumInt64 returns the wrapping sum of x.
+func SumInt64(x []int64) int64 {
+	sum := int64(0)
+	for i := 0; i < len(x); i++ {
+		sum += x[i]
+	}
+	return sum
+}
+
+// MatMul4x4 sets dst to the product of the 4x4 matrices a and b, stored a
+// row per vector, dst, a, and b have at least 4 rows and dst doesn't
+// overlap a or b. A row of dst is the sum of the rows of b scaled by the
+// row of a, the rows of b are loaded once for the four rows of dst.
+func MatMul4x4(dst, a, b []simd.F32x4) {
+	b0, b1, b2, b3 := b[0], b[1], b[2], b[3]
+	for i := 0; i < 4; i++ {
+		x := simd.AddF32x4(simd.MulF32x4(simd.SplatF32x4(a[i][0]), b0), simd.MulF32x4(simd.SplatF32x4(a[i][1]), b1))
+		y := simd.AddF32x4(simd.MulF32x4(simd.SplatF32x4(a[i][2]), b2), simd.MulF32x4(simd.SplatF32x4(a[i][3]), b3))
+		dst[i] = simd.AddF32x4(x, y)
+	}
+}
+
+// MatMul8x8 sets dst to the product of the 8x8 matrices a and b, stored a
+// row per two vectors, dst, a, and b have at least 16 vectors and dst
+// doesn't overlap a or b. The loop over the columns of a is unrolled and
+// jammed into the two halves of a row of dst, so each splat is used twice.
+func MatMul8x8(dst, a, b []simd.F32x4) {
+	for i := 0; i < 16; i += 2 {
+		s := simd.SplatF32x4(a[i][0])
+		lo := simd.MulF32x4(s, b[0])
+		hi := simd.MulF32x4(s, b[1])
+		s = simd.SplatF32x4(a[i][1])
+		lo = simd.AddF32x4(lo, simd.MulF32x4(s, b[2]))
+		hi = simd.AddF32x4(hi, simd.MulF32x4(s, b[3]))
+		s = simd.SplatF32x4(a[i][2])
+		lo = simd.AddF32x4(lo, simd.MulF32x4(s, b[4]))
+		hi = simd.AddF32x4(hi, simd.MulF32x4(s, b[5]))
+		s = simd.SplatF32x4(a[i][3])
+		lo = simd.AddF32x4(lo, simd.MulF32x4(s, b[6]))
+		hi = simd.AddF32x4(hi, simd.MulF32x4(s, b[7]))
+		s = simd.SplatF32x4(a[i+1][0])
+		lo = simd.AddF32x4(lo, simd.MulF32x4(s, b[8]))
+		hi = simd.AddF32x4(hi, simd.MulF32x4(s, b[9]))
+		s = simd.SplatF32x4(a[i+1][1])
+		lo = simd.AddF32x4(lo, simd.MulF32x4(s, b[10]))
+		hi = simd.AddF32x4(hi, simd.MulF32x4(s, b[11]))
+		s = simd.SplatF32x4(a[i+1][2])
+		lo = simd.AddF32x4(lo, simd.MulF32x4(s, b[12]))
+		hi = simd.AddF32x4(hi, simd.MulF32x4(s, b[13]))
+		s = simd.SplatF32x4(a[i+1][3])
+		lo = simd.AddF32x4(lo, simd.MulF32x4(s, b[14]))
+		hi = simd.AddF32x4(hi, simd.MulF32x4(s, b[15]))
+		dst[i] = lo
+		dst[i+1] = hi
+	}
+}
+
+// MulGF8 sets dst to the GF(2^8) product of src and the multiplier of the
+// tables lo and hi from simd.GF8MulTables, and returns the number of vectors
+// multiplied, the shorter length. It needs SSSE3, check simd.SSSE3().
+func MulGF8(dst, src []simd.U8x16, lo, hi simd.U8x16) int {
+	n := len(src)
+	if len(dst) < n {
+		n = len(dst)
+	}
+	for i := 0; i < n; i++ {
+		dst[i] = simd.MulGF8TableU8x16(src[i], lo, hi)
+	}
+	return n
+}
+
+// MulAddGF8 adds the GF(2^8) product of src and the multiplier of the
+// tables lo and hi from simd.GF8MulTables to dst, the inner loop of
+// Reed-Solomon encoding, and returns the number of vectors added, the
+// shorter length. It needs SSSE3, check simd.SSSE3().
+func MulAddGF8(dst, src []simd.U8x16, lo, hi simd.U8x16) int {
+	n := len(src)
+	if len(dst) < n {
+		n = len(dst)
+	}
+	for i := 0; i < n; i++ {
+		dst[i] = simd.XorU8x16(dst[i], simd.MulGF8TableU8x16(src[i], lo, hi))
+	}
+	return n
+}
+
+// IndexByte returns the index of the first c in s, or -1 if there's none,
+// like bytes.IndexByte. Each 16 bytes are compared to c at once, the mask
+// of the matches has a bit per byte and its trailing zeros are the index.
+func IndexByte(s []byte, c byte) int {
+	v := simd.SplatU8x16(c)
+	i := 0
+	for ; i+16 <= len(s); i += 16 {
+		if m := simd.MoveMaskU8x16(simd.CmpEqU8x16(simd.LoadU8x16(s, i), v)); m != 0 {
+			return i + bits.TrailingZeros(uint(m))
+		}
+	}
+	for ; i < len(s); i++ {
+		if s[i] == c {
+			return i
+		}
+	}
+	return -1
+}
+
+// IndexNonASCII returns the index of the first byte of s that isn't ASCII,
+// >= 0x80, or -1 if s is all ASCII. The mask of 16 bytes is their high bits.
+func IndexNonASCII(s []byte) int {
+	i := 0
+	for ; i+16 <= len(s); i += 16 {
+		if m := simd.MoveMaskU8x16(simd.LoadU8x16(s, i)); m != 0 {
+			return i + bits.TrailingZeros(uint(m))
+		}
+	}
+	for ; i < len(s); i++ {
+		if s[i] >= 0x80 {
+			return i
+		}
+	}
+	return -1
+}
+
+// ValidUTF8 returns true if s is valid UTF-8, like utf8.Valid. ASCII is
+// skipped 16 bytes at a time, the other bytes are decoded one rune at a
+// time rejecting overlong encodings, surrogates, and runes above
+// U+10FFFF.
+func ValidUTF8(s []byte) bool {
+	n := len(s)
+	i := 0
+	for i < n {
+		if i+16 <= n && simd.MoveMaskU8x16(simd.LoadU8x16(s, i)) == 0 {
+			i += 16
+			continue
+		}
+		c := s[i]
+		if c < 0x80 {
+			i++
+			continue
+		}
+		// size is the length of the rune, lo and hi the range of its second
+		// byte
+		size := 0
+		lo, hi := byte(0x80), byte(0xbf)
+		if c >= 0xc2 && c <= 0xdf {
+			size = 2
+		} else if c >= 0xe0 && c <= 0xef {
+			size = 3
+			if c == 0xe0 {
+				lo = 0xa0
+			} else if c == 0xed {
+				hi = 0x9f
+			}
+		} else if c >= 0xf0 && c <= 0xf4 {
+			size = 4
+			if c == 0xf0 {
+				lo = 0x90
+			} else if c == 0xf4 {
+				hi = 0x8f
+			}
+		} else {
+			return false
+		}
+		if n-i < size {
+			return false
+		}
+		if s[i+1] < lo || s[i+1] > hi {
+			return false
+		}
+		for j := 2; j < size; j++ {
+			if s[i+j] < 0x80 || s[i+j] > 0xbf {
+				return false
+			}
+		}
+		i += size
+	}
+	return true
+}
+
+// HexEncode encodes src into dst as lower case hex digits, like hex.Encode,
+// and returns the number of bytes written, two per byte of src encoded.
+// Each 16 bytes are split into nibbles, the nibbles are looked up in the
+// table of digits, and the digits interleaved. It needs SSSE3, check
+// simd.SSSE3().
+func HexEncode(dst, src []byte) int {
+	n := len(src)
+	if len(dst)/2 < n {
+		n = len(dst) / 2
+	}
+	nibble := simd.SplatU8x16(15)
+	i := 0
+	for ; i+16 <= n; i += 16 {
+		x := simd.LoadU8x16(src, i)
+		hi := simd.LookupU8x16(simd.ShrU8x16(x, 4), "0123456789abcdef")
+		lo := simd.LookupU8x16(simd.AndU8x16(x, nibble), "0123456789abcdef")
+		simd.StoreU8x16(dst, 2*i, simd.InterleaveLoU8x16(hi, lo))
+		simd.StoreU8x16(dst, 2*i+16, simd.InterleaveHiU8x16(hi, lo))
+	}
+	for ; i < n; i++ {
+		hi, lo := src[i]>>4+'0', src[i]&15+'0'
+		if hi > '9' {
+			hi += 'a' - '9' - 1
+		}
+		if lo > '9' {
+			lo += 'a' - '9' - 1
+		}
+		dst[2*i] = hi
+		dst[2*i+1] = lo
+	}
+	return 2 * n
+}
+
+// HexDecode decodes the pairs of upper or lower case hex digits of src into
+// dst, like hex.Decode, and returns the number of bytes written. It stops
+// at the first pair with an invalid digit, all of src was decoded if it
+// wrote len(src)/2 bytes. Each 32 digits are classified by their ranges, a
+// letter is lower cased by or'ing 0x20, and their nibbles are packed into 16
+// bytes.
+func HexDecode(dst, src []byte) int {
+	n := len(src) / 2
+	if len(dst) < n {
+		n = len(dst)
+	}
+	digit0 := simd.SplatU8x16('0')
+	letter0 := simd.SplatU8x16('a' - 10)
+	lower := simd.SplatU8x16(0x20)
+	i := 0
+	for ; i+16 <= n; i += 16 {
+		x := simd.LoadU8x16(src, 2*i)
+		y := simd.LoadU8x16(src, 2*i+16)
+		xl, yl := simd.OrU8x16(x, lower), simd.OrU8x16(y, lower)
+		xd, yd := simd.InRangeU8x16(x, '0', '9'), simd.InRangeU8x16(y, '0', '9')
+		xa, ya := simd.InRangeU8x16(xl, 'a', 'f'), simd.InRangeU8x16(yl, 'a', 'f')
+		if simd.MoveMaskU8x16(simd.AndU8x16(simd.OrU8x16(xd, xa), simd.OrU8x16(yd, ya))) != 0xffff {
+			break
+		}
+		xv := simd.OrU8x16(simd.AndU8x16(simd.SubU8x16(x, digit0), xd), simd.AndU8x16(simd.SubU8x16(xl, letter0), xa))
+		yv := simd.OrU8x16(simd.AndU8x16(simd.SubU8x16(y, digit0), yd), simd.AndU8x16(simd.SubU8x16(yl, letter0), ya))
+		simd.StoreU8x16(dst, i, simd.PackNibblesU8x16(xv, yv))
+	}
+	for ; i < n; i++ {
+		hi, lo := src[2*i], src[2*i+1]
+		if hi >= '0' && hi <= '9' {
+			hi -= '0'
+		} else if hi|0x20 >= 'a' && hi|0x20 <= 'f' {
+			hi = hi | 0x20 - 'a' + 10
+		} else {
+			return i
+		}
+		if lo >= '0' && lo <= '9' {
+			lo -= '0'
+		} else if lo|0x20 >= 'a' && lo|0x20 <= 'f' {
+			lo = lo | 0x20 - 'a' + 10
+		} else {
+			return i
+		}
+		dst[i] = hi<<4 | lo
+	}
+	return n
+}
+
+// SplitMix64 sets dst to the splitmix64 sequence of seed and returns the
+// next seed, to continue the sequence. It's for seeding other generators,
+// e.g. Xoshiro256PlusPlus, from a single seed or simd.RdSeed64. The 64 bit
+// multiplies are scalar, there's no packed 64 bit multiply before AVX512.
+func SplitMix64(dst []uint64, seed uint64) uint64 {
+	for i := range dst {
+		seed += 0x9e3779b97f4a7c15
+		z := seed
+		z = (z ^ z>>30) * 0xbf58476d1ce4e5b9
+		z = (z ^ z>>27) * 0x94d049bb133111eb
+		dst[i] = z ^ z>>31
+	}
+	return seed
+}
+
+// Xoshiro256PlusPlus sets dst to random numbers from two xoshiro256++
+// generators, the low and high lanes, and returns the number of vectors set.
+// state holds the generators' four words, state[j][k] is word j of
+// generator k, and is updated to continue the sequences. It returns 0 if
+// state is shorter than 4. The rotates are vector shifts and ors, the steps
+// vector xors. The state mustn't be all zeros, seed it with SplitMix64.
+func Xoshiro256PlusPlus(dst, state []simd.U64x2) int {
+	if len(state) < 4 {
+		return 0
+	}
+	s0, s1, s2, s3 := state[0], state[1], state[2], state[3]
+	for i := range dst {
+		sum := simd.AddU64x2(s0, s3)
+		dst[i] = simd.AddU64x2(simd.OrU64x2(simd.ShlU64x2(sum, 23), simd.ShrU64x2(sum, 64-23)), s0)
+		t := simd.ShlU64x2(s1, 17)
+		s2 = simd.XorU64x2(s2, s0)
+		s3 = simd.XorU64x2(s3, s1)
+		s1 = simd.XorU64x2(s1, s2)
+		s0 = simd.XorU64x2(s0, s3)
+		s2 = simd.XorU64x2(s2, t)
+		s3 = simd.OrU64x2(simd.ShlU64x2(s3, 45), simd.ShrU64x2(s3, 64-45))
+	}
+	state[0], state[1], state[2], state[3] = s0, s1, s2, s3
+	return len(dst)
+}
+
+// FilterRangeInt64 sets bit j of bitmap[k] if lo <= col[64*k+j] <= hi,
+// otherwise clears it, and returns the number of rows filtered, len(col) or
+// 64*len(bitmap) if that's shorter. The bits past the last row are cleared.
+// Two rows are compared at a time, the movemask of the rows outside the
+// range is flipped into two bits of the bitmap. It needs SSE4.2, check
+// simd.HasTarget("avx").
+func FilterRangeInt64(bitmap []uint64, col []int64, lo, hi int64) int {
+	n := len(col)
+	if 64*len(bitmap) < n {
+		n = 64 * len(bitmap)
+	}
+	vlo, vhi := simd.SplatI64x2(lo), simd.SplatI64x2(hi)
+	i := 0
+	for ; i+64 <= n; i += 64 {
+		var word uint64
+		for j := 0; j < 64; j += 2 {
+			x := simd.LoadI64x2(col, i+j)
+			out := simd.OrI64x2(simd.CmpGtI64x2(vlo, x), simd.CmpGtI64x2(x, vhi))
+			word |= uint64(simd.MoveMaskI64x2(out)^3) << uint(j)
+		}
+		bitmap[i>>6] = word
+	}
+	if i < n {
+		var word uint64
+		for j := 0; i+j < n; j++ {
+			if x := col[i+j]; lo <= x && x <= hi {
+				word |= 1 << uint(j)
+			}
+		}
+		bitmap[i>>6] = word
+	}
+	return n
+}
+
+// SelectRangeInt64 stores the indexes of the rows of col with lo <= col[i]
+// <= hi to idx, in order, and returns their number. The rows past len(idx)
+// aren't selected. Four rows are compared at a time, the indexes of the
+// matches are packed with simd.CompressI32x4 and stored as a vector, the
+// count is the number of bits of the mask. It needs SSE4.2 and SSSE3, check
+// simd.HasTarget("avx").
+func SelectRangeInt64(idx []int32, col []int64, lo, hi int64) int {
+	n := len(col)
+	if len(idx) < n {
+		n = len(idx)
+	}
+	vlo, vhi := simd.SplatI64x2(lo), simd.SplatI64x2(hi)
+	offsets := simd.I32x4{0, 1, 2, 3}
+	count := 0
+	i := 0
+	// count <= i, so the 4 indexes stored at count are in idx
+	for ; i+4 <= n; i += 4 {
+		x0, x1 := simd.LoadI64x2(col, i), simd.LoadI64x2(col, i+2)
+		out0 := simd.OrI64x2(simd.CmpGtI64x2(vlo, x0), simd.CmpGtI64x2(x0, vhi))
+		out1 := simd.OrI64x2(simd.CmpGtI64x2(vlo, x1), simd.CmpGtI64x2(x1, vhi))
+		m := (simd.MoveMaskI64x2(out0) | simd.MoveMaskI64x2(out1)<<2) ^ 15
+		rows := simd.AddI32x4(simd.SplatI32x4(int32(i)), offsets)
+		simd.StoreI32x4(idx, count, simd.CompressI32x4(rows, m))
+		// nibble m of the constant is the number of bits of m
+		count += int(0x4332322132212110 >> uint(m<<2) & 15)
+	}
+	for ; i < n; i++ {
+		if x := col[i]; lo <= x && x <= hi {
+			idx[count] = int32(i)
+			count++
+		}
+	}
+	return count
+}
@@ -1,4 +1,19 @@
 #!/bin/sh
+# ./run_tests.sh matrix cross generates and assembles the test kernels for
+# every supported GOARCH and GOOS, and cross compiles the tests and presets,
+# on any host without running them
+if [ "$1" = "matrix" ]; then
+	echo "Cross assembling the test kernels"
+	go test -run TestMatrix ./tests || exit 1
+	for goos in linux darwin freebsd; do
+		echo "Cross compiling the tests for $goos/amd64"
+		for pkg in ./tests ./presets; do
+			GOOS=$goos GOARCH=amd64 go test -c -o /dev/null $pkg || exit 1
+		done
+	done
+	exit 0
+fi
+
 echo "Installing gensimd, gensimd/simd, gensimd/simd/sse2"
 go install ./simd/sse2 ./simd
 go generate
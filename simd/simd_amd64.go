@@ -8,12 +8,138 @@ func Available() bool {
 
 func CpuId(info *[4]uint32, ax uint32)
 
-// SSE2 returns true if the the CPU supports SSE2 instructions
+// SSE2 returns true if the CPU supports SSE2 instructions
 func SSE2() bool {
 	var info [4]uint32
 	CpuId(&info, 1)
 	return info[3]&(1<<26) != 0 // SSE2
 }
 
-// SSSE3 returns true if the the CPU supports SSSE3 instructions
+// SSSE3 returns true if the CPU supports SSSE3 instructions
 func SSSE3() bool
+
+// SSE41 returns true if the CPU supports SSE4.1 instructions
+func SSE41() bool {
+	var info [4]uint32
+	CpuId(&info, 1)
+	return info[2]&(1<<19) != 0 // SSE4.1
+}
+
+// SSE42 returns true if the CPU supports SSE4.2 instructions, e.g.
+// PCMPGTQ
+func SSE42() bool {
+	var info [4]uint32
+	CpuId(&info, 1)
+	return info[2]&(1<<20) != 0 // SSE4.2
+}
+
+// MOVBE returns true if the CPU supports the MOVBE instruction
+func MOVBE() bool {
+	var info [4]uint32
+	CpuId(&info, 1)
+	return info[2]&(1<<22) != 0 // MOVBE
+}
+
+// BMI1 returns true if the CPU supports BMI1 instructions, e.g. TZCNT
+func BMI1() bool {
+	info := leaf7()
+	return info[1]&(1<<3) != 0 // BMI1
+}
+
+// BMI2 returns true if the CPU supports BMI2 instructions, e.g. PEXT
+// and SHLX
+func BMI2() bool {
+	info := leaf7()
+	return info[1]&(1<<8) != 0 // BMI2
+}
+
+// F16C returns true if the CPU supports the F16C half precision float
+// conversions
+func F16C() bool {
+	var info [4]uint32
+	CpuId(&info, 1)
+	return AVX() && info[2]&(1<<29) != 0 // F16C
+}
+
+// RDRAND returns true if the CPU supports the RDRAND instruction
+func RDRAND() bool {
+	var info [4]uint32
+	CpuId(&info, 1)
+	return info[2]&(1<<30) != 0 // RDRAND
+}
+
+// RDSEED returns true if the CPU supports the RDSEED instruction
+func RDSEED() bool {
+	info := leaf7()
+	return info[1]&(1<<18) != 0 // RDSEED
+}
+
+// AVX returns true if the CPU supports AVX instructions and the OS
+// saves the AVX registers
+func AVX() bool
+
+// AVX2 returns true if the CPU supports AVX2 instructions and the OS
+// saves the AVX registers
+func AVX2() bool {
+	info := leaf7()
+	return AVX() && info[1]&(1<<5) != 0 // AVX2
+}
+
+func xcr0() uint32
+
+// leaf7 returns the extended features of CPUID leaf 7, or zeros if the CPU
+// doesn't have the leaf
+func leaf7() [4]uint32 {
+	var info [4]uint32
+	CpuId(&info, 0)
+	if info[0] < 7 {
+		return [4]uint32{}
+	}
+	CpuId(&info, 7)
+	return info
+}
+
+// AVX512VNNI returns true if the CPU supports AVX512 VNNI instructions
+// on xmm registers, AVX512VL, and the OS saves the AVX512 registers
+func AVX512VNNI() bool {
+	if !AVX() || xcr0()&0xe0 != 0xe0 {
+		return false
+	}
+	info := leaf7()
+	// AVX512F, AVX512VL, and AVX512_VNNI
+	return info[1]&(1<<16) != 0 && info[1]&(1<<31) != 0 && info[2]&(1<<11) != 0
+}
+
+// HasTarget returns true if the CPU supports the instructions of the gensimd
+// CPU feature level target, "sse2", "ssse3", "sse4.1", "avx", "avx2", or
+// "avx512vnni"
+func HasTarget(target string) bool {
+	switch target {
+	case "sse2":
+		return SSE2()
+	case "ssse3":
+		return SSSE3()
+	case "sse4.1":
+		return SSE41()
+	case "avx":
+		return AVX() && SSE42()
+	case "avx2":
+		return AVX2() && MOVBE() && BMI1() && BMI2() && F16C()
+	case "avx512vnni":
+		return HasTarget("avx2") && AVX512VNNI()
+	}
+	return false
+}
+
+// Variant returns the index of the last of targets, CPU feature levels in
+// increasing order, the CPU supports, or 0 if it supports none. It selects
+// the variant of a function generated for several targets.
+func Variant(targets ...string) int {
+	variant := 0
+	for i, target := range targets {
+		if HasTarget(target) {
+			variant = i
+		}
+	}
+	return variant
+}
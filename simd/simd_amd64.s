@@ -1,6 +1,6 @@
 // (copied from Nigel Tao's shiny library, https://github.com/golang/exp/blob/master/shiny/driver/internal/swizzle/swizzle_amd64.s)
 // func haveSSSE3() bool
-TEXT ·SSSE3(SB),$0
+TEXT ·SSSE3(SB),$0-1
         MOVQ	$1, AX
         CPUID
         SHRQ	$9, CX
@@ -11,6 +11,7 @@ TEXT ·SSSE3(SB),$0
 // (copied from the Go dist tool, https://github.com/golang/go/blob/master/src/cmd/dist/cpuid_amd64.s)
 TEXT ·CpuId(SB),$0-12
         MOVL ax+8(FP), AX
+        XORL CX, CX
         CPUID
         MOVQ info+0(FP), DI
         MOVL AX, 0(DI)
@@ -18,3 +19,30 @@ TEXT ·CpuId(SB),$0-12
         MOVL CX, 8(DI)
         MOVL DX, 12(DI)
         RET
+
+// func AVX() bool
+TEXT ·AVX(SB),$0-1
+        MOVQ	$1, AX
+        CPUID
+        // AVX (bit 28) and OSXSAVE (bit 27)
+        ANDL	$0x18000000, CX
+        CMPL	CX, $0x18000000
+        JNE	noavx
+        MOVL	$0, CX
+        XGETBV
+        // XMM and YMM state enabled by the OS
+        ANDL	$6, AX
+        CMPL	AX, $6
+        JNE	noavx
+        MOVB	$1, ret+0(FP)
+        RET
+noavx:
+        MOVB	$0, ret+0(FP)
+        RET
+
+// func xcr0() uint32, callers must check OSXSAVE first
+TEXT ·xcr0(SB),$0-4
+        MOVL	$0, CX
+        XGETBV
+        MOVL	AX, ret+0(FP)
+        RET
@@ -0,0 +1,174 @@
+package simd
+
+import "math/bits"
+
+// bit manipulation
+
+// PopCountU8x16 returns the number of one bits in each uint8 of x.
+func PopCountU8x16(x U8x16) U8x16 {
+	val := U8x16{}
+	for i := 0; i < 16; i++ {
+		for b := x[i]; b != 0; b &= b - 1 {
+			val[i]++
+		}
+	}
+	return val
+}
+
+// AndNotU8x16 returns x &^ y.
+func AndNotU8x16(x, y U8x16) U8x16 {
+	val := U8x16{}
+	for i := 0; i < 16; i++ {
+		val[i] = x[i] &^ y[i]
+	}
+	return val
+}
+
+// AndNotI32x4 returns x &^ y.
+func AndNotI32x4(x, y I32x4) I32x4 {
+	val := I32x4{}
+	for i := 0; i < 4; i++ {
+		val[i] = x[i] &^ y[i]
+	}
+	return val
+}
+
+// AndNotU32x4 returns x &^ y.
+func AndNotU32x4(x, y U32x4) U32x4 {
+	val := U32x4{}
+	for i := 0; i < 4; i++ {
+		val[i] = x[i] &^ y[i]
+	}
+	return val
+}
+
+// AndNotU64x2 returns x &^ y.
+func AndNotU64x2(x, y U64x2) U64x2 {
+	val := U64x2{}
+	for i := 0; i < 2; i++ {
+		val[i] = x[i] &^ y[i]
+	}
+	return val
+}
+
+// AndU8x16 returns x & y.
+func AndU8x16(x, y U8x16) U8x16 {
+	val := U8x16{}
+	for i := 0; i < 16; i++ {
+		val[i] = x[i] & y[i]
+	}
+	return val
+}
+
+// OrU8x16 returns x | y.
+func OrU8x16(x, y U8x16) U8x16 {
+	val := U8x16{}
+	for i := 0; i < 16; i++ {
+		val[i] = x[i] | y[i]
+	}
+	return val
+}
+
+// XorU8x16 returns x ^ y.
+func XorU8x16(x, y U8x16) U8x16 {
+	val := U8x16{}
+	for i := 0; i < 16; i++ {
+		val[i] = x[i] ^ y[i]
+	}
+	return val
+}
+
+// OrU64x2 returns x | y.
+func OrU64x2(x, y U64x2) U64x2 {
+	val := U64x2{}
+	for i := 0; i < 2; i++ {
+		val[i] = x[i] | y[i]
+	}
+	return val
+}
+
+// XorU64x2 returns x ^ y.
+func XorU64x2(x, y U64x2) U64x2 {
+	val := U64x2{}
+	for i := 0; i < 2; i++ {
+		val[i] = x[i] ^ y[i]
+	}
+	return val
+}
+
+// ShlVarI32x4 shifts each element of x left by the corresponding element of
+// counts, counts greater than 31 give zero.
+func ShlVarI32x4(x I32x4, counts U32x4) I32x4 {
+	val := I32x4{}
+	for i := 0; i < 4; i++ {
+		val[i] = x[i] << counts[i]
+	}
+	return val
+}
+
+// ShlVarU32x4 shifts each element of x left by the corresponding element of
+// counts, counts greater than 31 give zero.
+func ShlVarU32x4(x U32x4, counts U32x4) U32x4 {
+	val := U32x4{}
+	for i := 0; i < 4; i++ {
+		val[i] = x[i] << counts[i]
+	}
+	return val
+}
+
+// ShrVarI32x4 shifts each element of x right arithmetic by the
+// corresponding element of counts, counts greater than 31 fill the element
+// with the sign bit.
+func ShrVarI32x4(x I32x4, counts U32x4) I32x4 {
+	val := I32x4{}
+	for i := 0; i < 4; i++ {
+		val[i] = x[i] >> counts[i]
+	}
+	return val
+}
+
+// ShrVarU32x4 shifts each element of x right logical by the corresponding
+// element of counts, counts greater than 31 give zero.
+func ShrVarU32x4(x U32x4, counts U32x4) U32x4 {
+	val := U32x4{}
+	for i := 0; i < 4; i++ {
+		val[i] = x[i] >> counts[i]
+	}
+	return val
+}
+
+// Pext64 returns the bits of x selected by mask packed into the low bits,
+// e.g. extracting bit packed fields. Generated functions use the BMI2
+// instruction PEXT, check BMI2() first.
+func Pext64(x, mask uint64) uint64 {
+	val := uint64(0)
+	for i := uint(0); mask != 0; mask &= mask - 1 {
+		if x&(mask&-mask) != 0 {
+			val |= 1 << i
+		}
+		i++
+	}
+	return val
+}
+
+// Pdep64 returns the low bits of x deposited at the set bits of mask, the
+// inverse of Pext64. Generated functions use the BMI2 instruction PDEP,
+// check BMI2() first.
+func Pdep64(x, mask uint64) uint64 {
+	val := uint64(0)
+	for ; mask != 0; mask &= mask - 1 {
+		if x&1 != 0 {
+			val |= mask & -mask
+		}
+		x >>= 1
+	}
+	return val
+}
+
+// MulHi64 returns the high 64 bits of the 128 bit product x*y, like the hi
+// of bits.Mul64. Generated functions use MULX if the target has BMI2,
+// otherwise MUL.
+func MulHi64(x, y uint64) uint64 {
+	hi, _ := bits.Mul64(x, y)
+	return hi
+}
@@ -0,0 +1,60 @@
+package simd
+
+// byte order loads and stores, like encoding/binary's LittleEndian and
+// BigEndian, b must be at least as long as the value
+
+// LoadU32LE returns the little endian uint32 in the first 4 bytes of b.
+func LoadU32LE(b []byte) uint32 {
+	_ = b[3]
+	return uint32(b[0]) | uint32(b[1])<<8 | uint32(b[2])<<16 | uint32(b[3])<<24
+}
+
+// LoadU32BE returns the big endian uint32 in the first 4 bytes of b.
+func LoadU32BE(b []byte) uint32 {
+	_ = b[3]
+	return uint32(b[3]) | uint32(b[2])<<8 | uint32(b[1])<<16 | uint32(b[0])<<24
+}
+
+// LoadU64LE returns the little endian uint64 in the first 8 bytes of b.
+func LoadU64LE(b []byte) uint64 {
+	_ = b[7]
+	return uint64(LoadU32LE(b)) | uint64(LoadU32LE(b[4:]))<<32
+}
+
+// LoadU64BE returns the big endian uint64 in the first 8 bytes of b.
+func LoadU64BE(b []byte) uint64 {
+	_ = b[7]
+	return uint64(LoadU32BE(b[4:])) | uint64(LoadU32BE(b))<<32
+}
+
+// StoreU32LE stores v little endian in the first 4 bytes of b.
+func StoreU32LE(b []byte, v uint32) {
+	_ = b[3]
+	b[0] = byte(v)
+	b[1] = byte(v >> 8)
+	b[2] = byte(v >> 16)
+	b[3] = byte(v >> 24)
+}
+
+// StoreU32BE stores v big endian in the first 4 bytes of b.
+func StoreU32BE(b []byte, v uint32) {
+	_ = b[3]
+	b[0] = byte(v >> 24)
+	b[1] = byte(v >> 16)
+	b[2] = byte(v >> 8)
+	b[3] = byte(v)
+}
+
+// StoreU64LE stores v little endian in the first 8 bytes of b.
+func StoreU64LE(b []byte, v uint64) {
+	_ = b[7]
+	StoreU32LE(b, uint32(v))
+	StoreU32LE(b[4:], uint32(v>>32))
+}
+
+// StoreU64BE stores v big endian in the first 8 bytes of b.
+func StoreU64BE(b []byte, v uint64) {
+	_ = b[7]
+	StoreU32BE(b, uint32(v>>32))
+	StoreU32BE(b[4:], uint32(v))
+}
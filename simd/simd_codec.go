@@ -0,0 +1,71 @@
+package simd
+
+// byte classification, table lookups, and nibble packing, the building
+// blocks of hex and base64 encoding and decoding
+
+// LookupU8x16 returns table[x[i]&15] for each element i, or zero if x[i] has
+// the high bit set, table must be a constant string of 16 bytes.
+func LookupU8x16(x U8x16, table string) U8x16 {
+	val := U8x16{}
+	for i := 0; i < 16; i++ {
+		if x[i]&0x80 == 0 {
+			val[i] = table[x[i]&15]
+		}
+	}
+	return val
+}
+
+// InRangeU8x16 returns 0xff for each element of x in [lo, hi], otherwise 0,
+// lo and hi must be constants.
+func InRangeU8x16(x U8x16, lo, hi uint8) U8x16 {
+	val := U8x16{}
+	for i := 0; i < 16; i++ {
+		if lo <= x[i] && x[i] <= hi {
+			val[i] = 0xff
+		}
+	}
+	return val
+}
+
+// ShrU8x16 returns each element of x shifted right by shift, shift must be
+// a constant.
+func ShrU8x16(x U8x16, shift uint8) U8x16 {
+	val := U8x16{}
+	for i := 0; i < 16; i++ {
+		val[i] = x[i] >> shift
+	}
+	return val
+}
+
+// InterleaveLoU8x16 returns the first eight elements of x and y interleaved,
+// {x[0], y[0], x[1], y[1], ..., x[7], y[7]}.
+func InterleaveLoU8x16(x, y U8x16) U8x16 {
+	val := U8x16{}
+	for i := 0; i < 8; i++ {
+		val[2*i] = x[i]
+		val[2*i+1] = y[i]
+	}
+	return val
+}
+
+// InterleaveHiU8x16 returns the last eight elements of x and y interleaved,
+// {x[8], y[8], x[9], y[9], ..., x[15], y[15]}.
+func InterleaveHiU8x16(x, y U8x16) U8x16 {
+	val := U8x16{}
+	for i := 0; i < 8; i++ {
+		val[2*i] = x[8+i]
+		val[2*i+1] = y[8+i]
+	}
+	return val
+}
+
+// PackNibblesU8x16 returns the pairs of nibbles of x then y packed into
+// bytes, x[2*i]<<4 | x[2*i+1]&15 for the first eight elements.
+func PackNibblesU8x16(x, y U8x16) U8x16 {
+	val := U8x16{}
+	for i := 0; i < 8; i++ {
+		val[i] = x[2*i]<<4 | x[2*i+1]&15
+		val[8+i] = y[2*i]<<4 | y[2*i+1]&15
+	}
+	return val
+}
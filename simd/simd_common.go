@@ -262,17 +262,17 @@ func SubU64x2(x, y U64x2) U64x2 {
 	}
 	return val
 }
-func ShlU64x2(x, shift uint8) U64x2 {
+func ShlU64x2(x U64x2, shift uint8) U64x2 {
 	val := U64x2{}
 	for i := 0; i < 2; i++ {
-		val[i] = val[i] << shift
+		val[i] = x[i] << shift
 	}
 	return val
 }
-func ShrU64x2(x, shift uint8) U64x2 {
+func ShrU64x2(x U64x2, shift uint8) U64x2 {
 	val := U64x2{}
 	for i := 0; i < 2; i++ {
-		val[i] = val[i] >> shift
+		val[i] = x[i] >> shift
 	}
 	return val
 }
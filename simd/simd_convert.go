@@ -0,0 +1,67 @@
+package simd
+
+import "math"
+
+// conversions between SIMD types
+
+// I32x4ToF32x4 converts each int32 to the nearest float32, ties round to even.
+func I32x4ToF32x4(x I32x4) F32x4 {
+	val := F32x4{}
+	for i := 0; i < 4; i++ {
+		val[i] = float32(x[i])
+	}
+	return val
+}
+
+// F32x4ToI32x4 converts each float32 to an int32 truncating towards zero.
+// NaN and values out of the int32 range are converted to math.MinInt32.
+func F32x4ToI32x4(x F32x4) I32x4 {
+	val := I32x4{}
+	for i := 0; i < 4; i++ {
+		f := x[i]
+		if f != f || f >= -math.MinInt32 || f < math.MinInt32 {
+			val[i] = math.MinInt32
+		} else {
+			val[i] = int32(f)
+		}
+	}
+	return val
+}
+
+// PackI32x4 converts the int32s of x and y to int16s with signed saturation,
+// x is converted to the first four int16s and y to the last four.
+func PackI32x4(x, y I32x4) I16x8 {
+	val := I16x8{}
+	for i := 0; i < 4; i++ {
+		val[i] = saturateI16(x[i])
+		val[i+4] = saturateI16(y[i])
+	}
+	return val
+}
+
+func saturateI16(x int32) int16 {
+	if x > math.MaxInt16 {
+		return math.MaxInt16
+	} else if x < math.MinInt16 {
+		return math.MinInt16
+	}
+	return int16(x)
+}
+
+// UnpackLoI16x8 sign extends the first four int16s of x to int32s.
+func UnpackLoI16x8(x I16x8) I32x4 {
+	val := I32x4{}
+	for i := 0; i < 4; i++ {
+		val[i] = int32(x[i])
+	}
+	return val
+}
+
+// UnpackHiI16x8 sign extends the last four int16s of x to int32s.
+func UnpackHiI16x8(x I16x8) I32x4 {
+	val := I32x4{}
+	for i := 0; i < 4; i++ {
+		val[i] = int32(x[i+4])
+	}
+	return val
+}
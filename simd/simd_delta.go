@@ -0,0 +1,46 @@
+package simd
+
+// prefix sums and delta decoding, the building blocks of time series and
+// Parquet style decoders, e.g. decoding the deltas of a column 4 at a time:
+//
+//	prev := I32x4{}
+//	for i := range deltas {
+//		prev = DeltaDecodeI32x4(deltas[i], prev)
+//		values[i] = prev
+//	}
+//
+// the prefix sums of run lengths are the ends of the runs of run length
+// encoding.
+
+// PrefixSumI32x4 returns the inclusive prefix sums of x, element i is
+// x[0] + ... + x[i], wrapping on overflow. Generated functions add x shifted
+// by one and then two elements.
+func PrefixSumI32x4(x I32x4) I32x4 {
+	val := x
+	for i := 1; i < 4; i++ {
+		val[i] += val[i-1]
+	}
+	return val
+}
+
+// PrefixSumI64x2 returns the inclusive prefix sums of x, {x[0], x[0] + x[1]}.
+func PrefixSumI64x2(x I64x2) I64x2 {
+	return I64x2{x[0], x[0] + x[1]}
+}
+
+// DeltaDecodeI32x4 returns the values of the deltas x following the decoded
+// values prev, the prefix sums of x plus the last element of prev.
+func DeltaDecodeI32x4(x, prev I32x4) I32x4 {
+	val := PrefixSumI32x4(x)
+	for i := 0; i < 4; i++ {
+		val[i] += prev[3]
+	}
+	return val
+}
+
+// DeltaDecodeI64x2 returns the values of the deltas x following the decoded
+// values prev, the prefix sums of x plus the last element of prev.
+func DeltaDecodeI64x2(x, prev I64x2) I64x2 {
+	val := PrefixSumI64x2(x)
+	return I64x2{val[0] + prev[1], val[1] + prev[1]}
+}
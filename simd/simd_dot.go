@@ -0,0 +1,47 @@
+package simd
+
+// horizontal multiply and add
+
+// DotF32x4 returns the dot product of x and y, computed as
+// (x[0]*y[0] + x[1]*y[1]) + (x[2]*y[2] + x[3]*y[3]).
+func DotF32x4(x, y F32x4) float32 {
+	lo := float32(x[0]*y[0]) + float32(x[1]*y[1])
+	hi := float32(x[2]*y[2]) + float32(x[3]*y[3])
+	return lo + hi
+}
+
+// SumAbsDiffU8x16 returns the sums of the absolute differences of the
+// uint8s of x and y, the first eight are summed into the first uint64 and
+// the last eight into the second.
+func SumAbsDiffU8x16(x, y U8x16) U64x2 {
+	val := U64x2{}
+	for i := 0; i < 16; i++ {
+		if x[i] > y[i] {
+			val[i/8] += uint64(x[i] - y[i])
+		} else {
+			val[i/8] += uint64(y[i] - x[i])
+		}
+	}
+	return val
+}
+
+// DotU8I8x16 returns the dot products of groups of four uint8s of x and
+// int8s of y, element i is x[4i]*y[4i] + ... + x[4i+3]*y[4i+3], the sums
+// don't saturate.
+func DotU8I8x16(x U8x16, y I8x16) I32x4 {
+	val := I32x4{}
+	for i := 0; i < 16; i++ {
+		val[i/4] += int32(x[i]) * int32(y[i])
+	}
+	return val
+}
+
+// MAddI16x8 multiplies the int16s of x and y and adds adjacent pairs of
+// the int32 products, i.e. element i is x[2i]*y[2i] + x[2i+1]*y[2i+1].
+func MAddI16x8(x, y I16x8) I32x4 {
+	val := I32x4{}
+	for i := 0; i < 4; i++ {
+		val[i] = int32(x[2*i])*int32(y[2*i]) + int32(x[2*i+1])*int32(y[2*i+1])
+	}
+	return val
+}
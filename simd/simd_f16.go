@@ -0,0 +1,91 @@
+package simd
+
+import "math"
+
+// half precision float conversions, for weights stored as IEEE 754 binary16
+// in uint16s
+
+// F16ToF32x4 returns the first four elements of x, half precision floats,
+// converted to float32s, the conversion is exact.
+func F16ToF32x4(x U16x8) F32x4 {
+	val := F32x4{}
+	for i := 0; i < 4; i++ {
+		val[i] = F16ToF32(x[i])
+	}
+	return val
+}
+
+// F32ToF16x4 returns the elements of x converted to half precision floats,
+// rounded to nearest even, in the first four elements, the last four are
+// zero.
+func F32ToF16x4(x F32x4) U16x8 {
+	val := U16x8{}
+	for i := 0; i < 4; i++ {
+		val[i] = F32ToF16(x[i])
+	}
+	return val
+}
+
+// F16ToF32 returns the half precision float h converted to a float32, a
+// signaling NaN is quieted like VCVTPH2PS. It isn't translated.
+func F16ToF32(h uint16) float32 {
+	sign := uint32(h>>15) << 31
+	exp := int32(h>>10) & 0x1f
+	mant := uint32(h) & 0x3ff
+	switch {
+	case exp == 0x1f && mant != 0:
+		return math.Float32frombits(sign | 0x7fc00000 | mant<<13)
+	case exp == 0x1f:
+		return math.Float32frombits(sign | 0x7f800000)
+	case exp == 0 && mant == 0:
+		return math.Float32frombits(sign)
+	case exp == 0:
+		// subnormal, normalized for the wider exponent
+		exp = 1
+		for mant&0x400 == 0 {
+			mant <<= 1
+			exp--
+		}
+		mant &= 0x3ff
+	}
+	return math.Float32frombits(sign | uint32(exp-15+127)<<23 | mant<<13)
+}
+
+// F32ToF16 returns f converted to a half precision float, rounded to
+// nearest even, overflowing to infinity, NaNs are quieted and their payload
+// truncated like VCVTPS2PH. It isn't translated.
+func F32ToF16(f float32) uint16 {
+	b := math.Float32bits(f)
+	sign := uint16(b>>16) & 0x8000
+	exp := int32(b>>23) & 0xff
+	mant := b & 0x7fffff
+	if exp == 0xff {
+		if mant != 0 {
+			return sign | 0x7e00 | uint16(mant>>13)
+		}
+		return sign | 0x7c00
+	}
+	e := exp - 127 + 15
+	if e >= 0x1f {
+		return sign | 0x7c00
+	}
+	shift := uint32(13)
+	h := uint32(e) << 10
+	if e <= 0 {
+		// subnormal, the implicit one is shifted into the mantissa
+		if e < -10 {
+			return sign
+		}
+		shift = uint32(14 - e)
+		mant |= 0x800000
+		h = 0
+	}
+	h |= mant >> shift
+	rem, half := mant&(1<<shift-1), uint32(1)<<(shift-1)
+	if rem > half || rem == half && h&1 != 0 {
+		// a carry out of the mantissa increments the exponent, up to
+		// infinity
+		h++
+	}
+	return sign | uint16(h)
+}
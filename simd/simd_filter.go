@@ -0,0 +1,88 @@
+package simd
+
+// column filters, the building blocks of selecting the rows of an int64
+// column matching a predicate, e.g. lo <= x <= hi:
+//
+//	x := LoadI64x2(col, i)
+//	out := OrI64x2(CmpGtI64x2(SplatI64x2(lo), x), CmpGtI64x2(x, SplatI64x2(hi)))
+//	m := MoveMaskI64x2(out) ^ 3
+//
+// the bits of m are the matching rows, set in a bitmap or compressed into a
+// list of row indexes with CompressI32x4.
+
+// LoadI64x2 returns the 2 elements of s starting at i, s must have at least
+// i+2 elements.
+func LoadI64x2(s []int64, i int) I64x2 {
+	return I64x2{s[i], s[i+1]}
+}
+
+// SplatI64x2 returns an I64x2 with both elements x.
+func SplatI64x2(x int64) I64x2 {
+	return I64x2{x, x}
+}
+
+// OrI64x2 returns the bitwise or of x and y.
+func OrI64x2(x, y I64x2) I64x2 {
+	return I64x2{x[0] | y[0], x[1] | y[1]}
+}
+
+// CmpEqI64x2 returns -1, all bits set, for each element of x equal to the
+// element of y, otherwise 0. Generated functions use the SSE4.1 PCMPEQQ if
+// the target has it, otherwise 32 bit compares.
+func CmpEqI64x2(x, y I64x2) I64x2 {
+	val := I64x2{}
+	for i := 0; i < 2; i++ {
+		if x[i] == y[i] {
+			val[i] = -1
+		}
+	}
+	return val
+}
+
+// CmpGtI64x2 returns -1, all bits set, for each element of x greater than
+// the element of y, otherwise 0. Generated functions use the SSE4.2 PCMPGTQ
+// on the avx target and above, otherwise the sign of y - x corrected for
+// overflow.
+func CmpGtI64x2(x, y I64x2) I64x2 {
+	val := I64x2{}
+	for i := 0; i < 2; i++ {
+		if x[i] > y[i] {
+			val[i] = -1
+		}
+	}
+	return val
+}
+
+// MoveMaskI64x2 returns the sign bits of the elements of x, bit i is the
+// sign bit of x[i], the bits above 1 are zero.
+func MoveMaskI64x2(x I64x2) int {
+	return int(uint64(x[0])>>63) | int(uint64(x[1])>>63)<<1
+}
+
+// SplatI32x4 returns an I32x4 with every element x.
+func SplatI32x4(x int32) I32x4 {
+	return I32x4{x, x, x, x}
+}
+
+// StoreI32x4 stores x to the 4 elements of s starting at i, s must have at
+// least i+4 elements.
+func StoreI32x4(s []int32, i int, x I32x4) {
+	copy(s[i:i+4], x[:])
+}
+
+// CompressI32x4 returns the elements of x whose bit of mask is set, bit i for
+// x[i], packed into the low elements in order, the rest of the elements are
+// zero. The bits of mask above 3 are ignored. Generated functions use the
+// AVX512 VPCOMPRESSD on the avx512vnni target, otherwise SSSE3 PSHUFB with a
+// table of the 16 shuffles.
+func CompressI32x4(x I32x4, mask int) I32x4 {
+	val := I32x4{}
+	n := 0
+	for i := 0; i < 4; i++ {
+		if mask&(1<<uint(i)) != 0 {
+			val[n] = x[i]
+			n++
+		}
+	}
+	return val
+}
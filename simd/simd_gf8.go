@@ -0,0 +1,66 @@
+package simd
+
+// byte shuffles and GF(2^8) multiplication, for Reed-Solomon erasure coding
+
+// gf8Poly is the reducing polynomial of GF(2^8), x^8 + x^4 + x^3 + x^2 + 1,
+// the one used by most Reed-Solomon implementations.
+const gf8Poly = 0x11d
+
+// ShuffleVarU8x16 returns x[indices[i]&15] for each element i, or zero if
+// indices[i] has the high bit set.
+func ShuffleVarU8x16(x, indices U8x16) U8x16 {
+	val := U8x16{}
+	for i := 0; i < 16; i++ {
+		if indices[i]&0x80 == 0 {
+			val[i] = x[indices[i]&15]
+		}
+	}
+	return val
+}
+
+// MulGF8 returns the product of a and b in GF(2^8).
+func MulGF8(a, b uint8) uint8 {
+	p := uint8(0)
+	for ; b != 0; b >>= 1 {
+		if b&1 != 0 {
+			p ^= a
+		}
+		hi := a & 0x80
+		a <<= 1
+		if hi != 0 {
+			a ^= gf8Poly & 0xff
+		}
+	}
+	return p
+}
+
+// GF8MulTables returns the tables of the products of c in GF(2^8) for
+// MulGF8TableU8x16, lo[i] = c*i and hi[i] = c*(i<<4).
+func GF8MulTables(c uint8) (lo, hi U8x16) {
+	for i := 0; i < 16; i++ {
+		lo[i] = MulGF8(c, uint8(i))
+		hi[i] = MulGF8(c, uint8(i<<4))
+	}
+	return lo, hi
+}
+
+// MulGF8U8x16 returns the product of each element of x and c in GF(2^8),
+// c must be a constant.
+func MulGF8U8x16(x U8x16, c uint8) U8x16 {
+	val := U8x16{}
+	for i := 0; i < 16; i++ {
+		val[i] = MulGF8(x[i], c)
+	}
+	return val
+}
+
+// MulGF8TableU8x16 returns the product of each element of x and the
+// multiplier of the tables lo and hi from GF8MulTables, lo[x[i]&15] ^
+// hi[x[i]>>4].
+func MulGF8TableU8x16(x, lo, hi U8x16) U8x16 {
+	val := U8x16{}
+	for i := 0; i < 16; i++ {
+		val[i] = lo[x[i]&15] ^ hi[x[i]>>4]
+	}
+	return val
+}
@@ -0,0 +1,63 @@
+package simd
+
+// interleaving and transposing of 4 element vectors
+
+// InterleaveLoF32x4 interleaves the first two elements of x and y,
+// returning {x[0], y[0], x[1], y[1]}.
+func InterleaveLoF32x4(x, y F32x4) F32x4 {
+	return F32x4{x[0], y[0], x[1], y[1]}
+}
+
+// InterleaveHiF32x4 interleaves the last two elements of x and y,
+// returning {x[2], y[2], x[3], y[3]}.
+func InterleaveHiF32x4(x, y F32x4) F32x4 {
+	return F32x4{x[2], y[2], x[3], y[3]}
+}
+
+// DeinterleaveEvenF32x4 returns the even elements of x and y,
+// {x[0], x[2], y[0], y[2]}.
+func DeinterleaveEvenF32x4(x, y F32x4) F32x4 {
+	return F32x4{x[0], x[2], y[0], y[2]}
+}
+
+// DeinterleaveOddF32x4 returns the odd elements of x and y,
+// {x[1], x[3], y[1], y[3]}.
+func DeinterleaveOddF32x4(x, y F32x4) F32x4 {
+	return F32x4{x[1], x[3], y[1], y[3]}
+}
+
+// InterleaveLoI32x4 interleaves the first two elements of x and y,
+// returning {x[0], y[0], x[1], y[1]}.
+func InterleaveLoI32x4(x, y I32x4) I32x4 {
+	return I32x4{x[0], y[0], x[1], y[1]}
+}
+
+// InterleaveHiI32x4 interleaves the last two elements of x and y,
+// returning {x[2], y[2], x[3], y[3]}.
+func InterleaveHiI32x4(x, y I32x4) I32x4 {
+	return I32x4{x[2], y[2], x[3], y[3]}
+}
+
+// DeinterleaveEvenI32x4 returns the even elements of x and y,
+// {x[0], x[2], y[0], y[2]}.
+func DeinterleaveEvenI32x4(x, y I32x4) I32x4 {
+	return I32x4{x[0], x[2], y[0], y[2]}
+}
+
+// DeinterleaveOddI32x4 returns the odd elements of x and y,
+// {x[1], x[3], y[1], y[3]}.
+func DeinterleaveOddI32x4(x, y I32x4) I32x4 {
+	return I32x4{x[1], x[3], y[1], y[3]}
+}
+
+// Transpose4x4F32 transposes the 4x4 matrix with rows a, b, c, d in place.
+func Transpose4x4F32(a, b, c, d *F32x4) {
+	ac0 := InterleaveLoF32x4(*a, *c)
+	bd0 := InterleaveLoF32x4(*b, *d)
+	ac1 := InterleaveHiF32x4(*a, *c)
+	bd1 := InterleaveHiF32x4(*b, *d)
+	*a = InterleaveLoF32x4(ac0, bd0)
+	*b = InterleaveHiF32x4(ac0, bd0)
+	*c = InterleaveLoF32x4(ac1, bd1)
+	*d = InterleaveHiF32x4(ac1, bd1)
+}
@@ -0,0 +1,76 @@
+package simd
+
+// masked loads and stores, for handling the tail of a slice without a
+// scalar cleanup loop
+
+// TailMaskI32x4 returns a mask with the first n elements set (-1) and the
+// rest zero, n <= 0 returns an empty mask and n >= 4 a full mask.
+func TailMaskI32x4(n int) I32x4 {
+	val := I32x4{}
+	for i := 0; i < 4; i++ {
+		if i < n {
+			val[i] = -1
+		}
+	}
+	return val
+}
+
+// MaskedLoadF32x4 loads p[i] for each element i of mask with the sign bit
+// set, the other elements are zero. Only the elements selected by mask need
+// to be within p.
+func MaskedLoadF32x4(p []float32, mask I32x4) F32x4 {
+	val := F32x4{}
+	for i := 0; i < 4; i++ {
+		if mask[i] < 0 {
+			val[i] = p[i]
+		}
+	}
+	return val
+}
+
+// MaskedStoreF32x4 stores x[i] to p[i] for each element i of mask with the
+// sign bit set, the other elements of p are left unchanged. Only the
+// elements selected by mask need to be within p.
+func MaskedStoreF32x4(p []float32, mask I32x4, x F32x4) {
+	for i := 0; i < 4; i++ {
+		if mask[i] < 0 {
+			p[i] = x[i]
+		}
+	}
+}
+
+// TailMaskI32x8 returns a mask with the first n elements set (-1) and the
+// rest zero, n <= 0 returns an empty mask and n >= 8 a full mask.
+func TailMaskI32x8(n int) I32x8 {
+	val := I32x8{}
+	for i := 0; i < 8; i++ {
+		if i < n {
+			val[i] = -1
+		}
+	}
+	return val
+}
+
+// MaskedLoadF32x8 loads p[i] for each element i of mask with the sign bit
+// set, the other elements are zero. Only the elements selected by mask need
+// to be within p.
+func MaskedLoadF32x8(p []float32, mask I32x8) F32x8 {
+	val := F32x8{}
+	for i := 0; i < 8; i++ {
+		if mask[i] < 0 {
+			val[i] = p[i]
+		}
+	}
+	return val
+}
+
+// MaskedStoreF32x8 stores x[i] to p[i] for each element i of mask with the
+// sign bit set, the other elements of p are left unchanged. Only the
+// elements selected by mask need to be within p.
+func MaskedStoreF32x8(p []float32, mask I32x8, x F32x8) {
+	for i := 0; i < 8; i++ {
+		if mask[i] < 0 {
+			p[i] = x[i]
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package simd
+
+// Methods on the SIMD types, x.Add(y) is the same as AddXxY(x, y).
+// gensimd translates the methods to the same instructions as the functions.
+
+func (x I8x16) Add(y I8x16) I8x16 { return AddI8x16(x, y) }
+func (x I8x16) Sub(y I8x16) I8x16 { return SubI8x16(x, y) }
+
+func (x I16x8) Add(y I16x8) I16x8     { return AddI16x8(x, y) }
+func (x I16x8) Sub(y I16x8) I16x8     { return SubI16x8(x, y) }
+func (x I16x8) Mul(y I16x8) I16x8     { return MulI16x8(x, y) }
+func (x I16x8) Shl(shift uint8) I16x8 { return ShlI16x8(x, shift) }
+func (x I16x8) Shr(shift uint8) I16x8 { return ShrI16x8(x, shift) }
+
+func (x I32x4) Add(y I32x4) I32x4         { return AddI32x4(x, y) }
+func (x I32x4) Sub(y I32x4) I32x4         { return SubI32x4(x, y) }
+func (x I32x4) Mul(y I32x4) I32x4         { return MulI32x4(x, y) }
+func (x I32x4) Shl(shift uint8) I32x4     { return ShlI32x4(x, shift) }
+func (x I32x4) Shr(shift uint8) I32x4     { return ShrI32x4(x, shift) }
+func (x I32x4) Shuffle(order uint8) I32x4 { return ShuffleI32x4(x, order) }
+
+func (x I64x2) Add(y I64x2) I64x2 { return AddI64x2(x, y) }
+func (x I64x2) Sub(y I64x2) I64x2 { return SubI64x2(x, y) }
+
+func (x U8x16) Add(y U8x16) U8x16 { return AddU8x16(x, y) }
+func (x U8x16) Sub(y U8x16) U8x16 { return SubU8x16(x, y) }
+
+func (x U16x8) Add(y U16x8) U16x8     { return AddU16x8(x, y) }
+func (x U16x8) Sub(y U16x8) U16x8     { return SubU16x8(x, y) }
+func (x U16x8) Mul(y U16x8) U16x8     { return MulU16x8(x, y) }
+func (x U16x8) Shl(shift uint8) U16x8 { return ShlU16x8(x, shift) }
+func (x U16x8) Shr(shift uint8) U16x8 { return ShrU16x8(x, shift) }
+
+func (x U32x4) Add(y U32x4) U32x4         { return AddU32x4(x, y) }
+func (x U32x4) Sub(y U32x4) U32x4         { return SubU32x4(x, y) }
+func (x U32x4) Mul(y U32x4) U32x4         { return MulU32x4(x, y) }
+func (x U32x4) Shl(shift uint8) U32x4     { return ShlU32x4(x, shift) }
+func (x U32x4) Shr(shift uint8) U32x4     { return ShrU32x4(x, shift) }
+func (x U32x4) Shuffle(order uint8) U32x4 { return ShuffleU32x4(x, order) }
+
+func (x U64x2) Add(y U64x2) U64x2 { return AddU64x2(x, y) }
+func (x U64x2) Sub(y U64x2) U64x2 { return SubU64x2(x, y) }
+
+func (x F32x4) Add(y F32x4) F32x4 { return AddF32x4(x, y) }
+func (x F32x4) Sub(y F32x4) F32x4 { return SubF32x4(x, y) }
+func (x F32x4) Mul(y F32x4) F32x4 { return MulF32x4(x, y) }
+func (x F32x4) Div(y F32x4) F32x4 { return DivF32x4(x, y) }
+
+func (x F64x2) Add(y F64x2) F64x2 { return AddF64x2(x, y) }
+func (x F64x2) Sub(y F64x2) F64x2 { return SubF64x2(x, y) }
+func (x F64x2) Mul(y F64x2) F64x2 { return MulF64x2(x, y) }
+func (x F64x2) Div(y F64x2) F64x2 { return DivF64x2(x, y) }
@@ -0,0 +1,117 @@
+package simd
+
+// element wise max and min, clamp with MinF32x4(MaxF32x4(x, lo), hi)
+
+// MaxF32x4 returns the larger of each float32 of x and y, y if either is
+// NaN or both are zero.
+func MaxF32x4(x, y F32x4) F32x4 {
+	val := y
+	for i := 0; i < 4; i++ {
+		if x[i] > y[i] {
+			val[i] = x[i]
+		}
+	}
+	return val
+}
+
+// MinF32x4 returns the smaller of each float32 of x and y, y if either is
+// NaN or both are zero.
+func MinF32x4(x, y F32x4) F32x4 {
+	val := y
+	for i := 0; i < 4; i++ {
+		if x[i] < y[i] {
+			val[i] = x[i]
+		}
+	}
+	return val
+}
+
+// MaxF64x2 returns the larger of each float64 of x and y, y if either is
+// NaN or both are zero.
+func MaxF64x2(x, y F64x2) F64x2 {
+	val := y
+	for i := 0; i < 2; i++ {
+		if x[i] > y[i] {
+			val[i] = x[i]
+		}
+	}
+	return val
+}
+
+// MinF64x2 returns the smaller of each float64 of x and y, y if either is
+// NaN or both are zero.
+func MinF64x2(x, y F64x2) F64x2 {
+	val := y
+	for i := 0; i < 2; i++ {
+		if x[i] < y[i] {
+			val[i] = x[i]
+		}
+	}
+	return val
+}
+
+// MaxI16x8 returns the larger of each int16 of x and y.
+func MaxI16x8(x, y I16x8) I16x8 {
+	val := y
+	for i := 0; i < 8; i++ {
+		if x[i] > y[i] {
+			val[i] = x[i]
+		}
+	}
+	return val
+}
+
+// MinI16x8 returns the smaller of each int16 of x and y.
+func MinI16x8(x, y I16x8) I16x8 {
+	val := y
+	for i := 0; i < 8; i++ {
+		if x[i] < y[i] {
+			val[i] = x[i]
+		}
+	}
+	return val
+}
+
+// MaxU8x16 returns the larger of each uint8 of x and y.
+func MaxU8x16(x, y U8x16) U8x16 {
+	val := y
+	for i := 0; i < 16; i++ {
+		if x[i] > y[i] {
+			val[i] = x[i]
+		}
+	}
+	return val
+}
+
+// MinU8x16 returns the smaller of each uint8 of x and y.
+func MinU8x16(x, y U8x16) U8x16 {
+	val := y
+	for i := 0; i < 16; i++ {
+		if x[i] < y[i] {
+			val[i] = x[i]
+		}
+	}
+	return val
+}
+
+// MaxI32x4 returns the larger of each int32 of x and y.
+func MaxI32x4(x, y I32x4) I32x4 {
+	val := y
+	for i := 0; i < 4; i++ {
+		if x[i] > y[i] {
+			val[i] = x[i]
+		}
+	}
+	return val
+}
+
+// MinI32x4 returns the smaller of each int32 of x and y.
+func MinI32x4(x, y I32x4) I32x4 {
+	val := y
+	for i := 0; i < 4; i++ {
+		if x[i] < y[i] {
+			val[i] = x[i]
+		}
+	}
+	return val
+}
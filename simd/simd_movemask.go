@@ -0,0 +1,54 @@
+package simd
+
+// byte comparisons and mask extraction, the building blocks of scanning
+// bytes 16 at a time like bytes.IndexByte, e.g.
+//
+//	m := MoveMaskU8x16(CmpEqU8x16(LoadU8x16(s, i), SplatU8x16(c)))
+//	if m != 0 {
+//		return i + bits.TrailingZeros(uint(m))
+//	}
+
+// LoadU8x16 returns the 16 bytes of b starting at i, b must have at least
+// i+16 bytes.
+func LoadU8x16(b []byte, i int) U8x16 {
+	val := U8x16{}
+	copy(val[:], b[i:i+16])
+	return val
+}
+
+// StoreU8x16 stores x to the 16 bytes of b starting at i, b must have at
+// least i+16 bytes.
+func StoreU8x16(b []byte, i int, x U8x16) {
+	copy(b[i:i+16], x[:])
+}
+
+// SplatU8x16 returns a U8x16 with every element x.
+func SplatU8x16(x uint8) U8x16 {
+	val := U8x16{}
+	for i := 0; i < 16; i++ {
+		val[i] = x
+	}
+	return val
+}
+
+// CmpEqU8x16 returns 0xff for each element of x equal to the element of y,
+// otherwise 0.
+func CmpEqU8x16(x, y U8x16) U8x16 {
+	val := U8x16{}
+	for i := 0; i < 16; i++ {
+		if x[i] == y[i] {
+			val[i] = 0xff
+		}
+	}
+	return val
+}
+
+// MoveMaskU8x16 returns the high bits of the elements of x, bit i is the
+// high bit of x[i], the bits above 15 are zero.
+func MoveMaskU8x16(x U8x16) int {
+	m := 0
+	for i := 0; i < 16; i++ {
+		m |= int(x[i]>>7) << uint(i)
+	}
+	return m
+}
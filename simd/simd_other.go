@@ -5,3 +5,18 @@ package simd
 func Available() bool { return false }
 func SSE2() bool      { panic("unreachable") }
 func SSSE3() bool     { panic("unreachable") }
+func SSE41() bool     { panic("unreachable") }
+func SSE42() bool     { panic("unreachable") }
+func MOVBE() bool     { panic("unreachable") }
+func BMI1() bool      { panic("unreachable") }
+func BMI2() bool      { panic("unreachable") }
+func F16C() bool      { panic("unreachable") }
+func RDRAND() bool    { panic("unreachable") }
+func RDSEED() bool    { panic("unreachable") }
+func AVX() bool       { panic("unreachable") }
+func AVX2() bool      { panic("unreachable") }
+
+func AVX512VNNI() bool { panic("unreachable") }
+
+func HasTarget(target string) bool  { return false }
+func Variant(targets ...string) int { return 0 }
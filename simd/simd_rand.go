@@ -0,0 +1,34 @@
+package simd
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+)
+
+// hardware random numbers, for seeding generators, e.g. the xoshiro256++
+// preset, in generated Monte Carlo kernels
+
+// RdRand64 stores a random number from the CPU's random number generator in
+// *v and returns true, or stores 0 and returns false if none was available
+// after 10 tries. Generated functions use RDRAND, check RDRAND() first,
+// otherwise it's crypto/rand.
+func RdRand64(v *uint64) bool {
+	return readRand(v)
+}
+
+// RdSeed64 is RdRand64 for a seed from the CPU's entropy source, which
+// reseeds the RDRAND generator. Generated functions use RDSEED, check
+// RDSEED() first, it runs out more often than RDRAND.
+func RdSeed64(v *uint64) bool {
+	return readRand(v)
+}
+
+func readRand(v *uint64) bool {
+	var b [8]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		*v = 0
+		return false
+	}
+	*v = binary.LittleEndian.Uint64(b[:])
+	return true
+}
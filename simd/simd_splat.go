@@ -0,0 +1,13 @@
+package simd
+
+// broadcasting a scalar to every element, e.g. to scale the rows of a matrix
+
+// SplatF32x4 returns an F32x4 with every element x.
+func SplatF32x4(x float32) F32x4 {
+	return F32x4{x, x, x, x}
+}
+
+// SplatF64x2 returns an F64x2 with both elements x.
+func SplatF64x2(x float64) F64x2 {
+	return F64x2{x, x}
+}
@@ -0,0 +1,67 @@
+package simd
+
+import "sync/atomic"
+
+// memory ordering, for generated functions sharing buffers with other
+// goroutines or processes, e.g. ring buffers
+
+var fence uint32
+
+// Fence orders the loads and stores before it before the loads and stores
+// after it, for the CPU and the compiler. Generated functions use MFENCE.
+func Fence() {
+	atomic.AddUint32(&fence, 0)
+}
+
+// CompilerBarrier keeps the compiler from moving loads and stores across
+// it, the CPU may still reorder them. Generated functions emit no
+// instructions for it, loads aren't combined across it.
+//
+//go:noinline
+func CompilerBarrier() {
+}
+
+// atomic operations, the same as package sync/atomic's, whose functions are
+// also computed inline
+
+// AtomicAddUint32 atomically adds delta to *p and returns the new value.
+func AtomicAddUint32(p *uint32, delta uint32) uint32 {
+	return atomic.AddUint32(p, delta)
+}
+
+// AtomicAddUint64 atomically adds delta to *p and returns the new value.
+func AtomicAddUint64(p *uint64, delta uint64) uint64 {
+	return atomic.AddUint64(p, delta)
+}
+
+// AtomicLoadUint32 atomically loads *p.
+func AtomicLoadUint32(p *uint32) uint32 {
+	return atomic.LoadUint32(p)
+}
+
+// AtomicLoadUint64 atomically loads *p.
+func AtomicLoadUint64(p *uint64) uint64 {
+	return atomic.LoadUint64(p)
+}
+
+// AtomicStoreUint32 atomically stores v to *p.
+func AtomicStoreUint32(p *uint32, v uint32) {
+	atomic.StoreUint32(p, v)
+}
+
+// AtomicStoreUint64 atomically stores v to *p.
+func AtomicStoreUint64(p *uint64, v uint64) {
+	atomic.StoreUint64(p, v)
+}
+
+// CompareAndSwapUint32 atomically sets *p to v if it's old and returns
+// whether it did.
+func CompareAndSwapUint32(p *uint32, old, v uint32) bool {
+	return atomic.CompareAndSwapUint32(p, old, v)
+}
+
+// CompareAndSwapUint64 atomically sets *p to v if it's old and returns
+// whether it did.
+func CompareAndSwapUint64(p *uint64, old, v uint64) bool {
+	return atomic.CompareAndSwapUint64(p, old, v)
+}
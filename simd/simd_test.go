@@ -2,7 +2,12 @@
 
 package simd_test
 
-import "testing"
+import (
+	"math"
+	"testing"
+
+	"github.com/bjwbell/gensimd/simd"
+)
 
 func TestSimd(t *testing.T) {
 
@@ -12,3 +17,157 @@ func TestSimd(t *testing.T) {
 
 	t.Log("Test Count:", count)
 }
+
+func TestMethods(t *testing.T) {
+	x := simd.I32x4{1, -2, 3, -4}
+	y := simd.I32x4{5, 6, -7, 8}
+	if x.Add(y) != simd.AddI32x4(x, y) {
+		t.Errorf("I32x4.Add: %v != %v", x.Add(y), simd.AddI32x4(x, y))
+	}
+	if x.Mul(y).Shr(1) != simd.ShrI32x4(simd.MulI32x4(x, y), 1) {
+		t.Errorf("I32x4.Mul.Shr: %v != %v", x.Mul(y).Shr(1), simd.ShrI32x4(simd.MulI32x4(x, y), 1))
+	}
+	a := simd.F64x2{1.5, -2}
+	b := simd.F64x2{0.5, 4}
+	if a.Div(b) != simd.DivF64x2(a, b) {
+		t.Errorf("F64x2.Div: %v != %v", a.Div(b), simd.DivF64x2(a, b))
+	}
+}
+
+func TestConvert(t *testing.T) {
+	f := simd.F32x4{1.9, -1.9, float32(math.NaN()), 3e9}
+	if got, want := simd.F32x4ToI32x4(f), (simd.I32x4{1, -1, math.MinInt32, math.MinInt32}); got != want {
+		t.Errorf("F32x4ToI32x4(%v) = %v, want %v", f, got, want)
+	}
+	x := simd.I32x4{40000, -40000, 7, -7}
+	if got, want := simd.PackI32x4(x, x), (simd.I16x8{32767, -32768, 7, -7, 32767, -32768, 7, -7}); got != want {
+		t.Errorf("PackI32x4(%v, %v) = %v, want %v", x, x, got, want)
+	}
+	y := simd.I16x8{-1, 2, -3, 4, -5, 6, -7, 8}
+	if got, want := simd.UnpackHiI16x8(y), (simd.I32x4{-5, 6, -7, 8}); got != want {
+		t.Errorf("UnpackHiI16x8(%v) = %v, want %v", y, got, want)
+	}
+}
+
+func TestMasked(t *testing.T) {
+	p := []float32{1, 2, 3}
+	mask := simd.TailMaskI32x4(len(p))
+	if got, want := mask, (simd.I32x4{-1, -1, -1, 0}); got != want {
+		t.Errorf("TailMaskI32x4(%v) = %v, want %v", len(p), got, want)
+	}
+	if got, want := simd.MaskedLoadF32x4(p, mask), (simd.F32x4{1, 2, 3, 0}); got != want {
+		t.Errorf("MaskedLoadF32x4(%v, %v) = %v, want %v", p, mask, got, want)
+	}
+	simd.MaskedStoreF32x4(p, simd.I32x4{0, -1, 0, 0}, simd.F32x4{5, 6, 7, 8})
+	if p[0] != 1 || p[1] != 6 || p[2] != 3 {
+		t.Errorf("MaskedStoreF32x4 stored %v, want [1 6 3]", p)
+	}
+
+	q := []float32{1, 2, 3, 4, 5}
+	mask8 := simd.TailMaskI32x8(len(q))
+	if got, want := mask8, (simd.I32x8{-1, -1, -1, -1, -1, 0, 0, 0}); got != want {
+		t.Errorf("TailMaskI32x8(%v) = %v, want %v", len(q), got, want)
+	}
+	if got, want := simd.MaskedLoadF32x8(q, mask8), (simd.F32x8{1, 2, 3, 4, 5, 0, 0, 0}); got != want {
+		t.Errorf("MaskedLoadF32x8(%v, %v) = %v, want %v", q, mask8, got, want)
+	}
+	simd.MaskedStoreF32x8(q, simd.I32x8{0, 0, 0, 0, -1, 0, 0, 0}, simd.F32x8{9, 9, 9, 9, 6, 9, 9, 9})
+	if q[3] != 4 || q[4] != 6 {
+		t.Errorf("MaskedStoreF32x8 stored %v, want [1 2 3 4 6]", q)
+	}
+}
+
+func TestTranspose(t *testing.T) {
+	a, b := simd.F32x4{0, 1, 2, 3}, simd.F32x4{4, 5, 6, 7}
+	c, d := simd.F32x4{8, 9, 10, 11}, simd.F32x4{12, 13, 14, 15}
+	simd.Transpose4x4F32(&a, &b, &c, &d)
+	want := [4]simd.F32x4{{0, 4, 8, 12}, {1, 5, 9, 13}, {2, 6, 10, 14}, {3, 7, 11, 15}}
+	if got := [4]simd.F32x4{a, b, c, d}; got != want {
+		t.Errorf("Transpose4x4F32 = %v, want %v", got, want)
+	}
+	x := simd.I32x4{0, 1, 2, 3}
+	y := simd.I32x4{4, 5, 6, 7}
+	if got, want := simd.DeinterleaveOddI32x4(x, y), (simd.I32x4{1, 3, 5, 7}); got != want {
+		t.Errorf("DeinterleaveOddI32x4(%v, %v) = %v, want %v", x, y, got, want)
+	}
+}
+
+func TestDot(t *testing.T) {
+	x := simd.F32x4{1, 2, 3, 4}
+	if got, want := simd.DotF32x4(x, x), float32(30); got != want {
+		t.Errorf("DotF32x4(%v, %v) = %v, want %v", x, x, got, want)
+	}
+	a := simd.U8x16{0, 255, 3, 4, 5, 6, 7, 8, 1, 1, 1, 1, 1, 1, 1, 1}
+	b := simd.U8x16{255, 0, 4, 3, 5, 6, 7, 8, 0, 0, 0, 0, 0, 0, 0, 0}
+	if got, want := simd.SumAbsDiffU8x16(a, b), (simd.U64x2{512, 8}); got != want {
+		t.Errorf("SumAbsDiffU8x16(%v, %v) = %v, want %v", a, b, got, want)
+	}
+	m := simd.I16x8{-32768, -32768, 1, 2, 3, 4, -5, 6}
+	if got, want := simd.MAddI16x8(m, m), (simd.I32x4{math.MinInt32, 5, 25, 61}); got != want {
+		t.Errorf("MAddI16x8(%v, %v) = %v, want %v", m, m, got, want)
+	}
+}
+
+func TestBits(t *testing.T) {
+	x := simd.U8x16{0, 1, 3, 7, 15, 31, 63, 127, 255, 0x80, 0xaa, 0x11}
+	if got, want := simd.PopCountU8x16(x), (simd.U8x16{0, 1, 2, 3, 4, 5, 6, 7, 8, 1, 4, 2}); got != want {
+		t.Errorf("PopCountU8x16(%v) = %v, want %v", x, got, want)
+	}
+	y := simd.I32x4{-1, 0xff, -8, 1}
+	if got, want := simd.AndNotI32x4(y, simd.I32x4{0xf, 0xf, -1, 0}), (simd.I32x4{-16, 0xf0, 0, 1}); got != want {
+		t.Errorf("AndNotI32x4 = %v, want %v", got, want)
+	}
+	counts := simd.U32x4{0, 4, 31, 32}
+	if got, want := simd.ShrVarI32x4(simd.I32x4{-16, -16, -16, -16}, counts), (simd.I32x4{-16, -1, -1, -1}); got != want {
+		t.Errorf("ShrVarI32x4 = %v, want %v", got, want)
+	}
+	if got, want := simd.ShlVarU32x4(simd.U32x4{1, 1, 1, 1}, counts), (simd.U32x4{1, 16, 1 << 31, 0}); got != want {
+		t.Errorf("ShlVarU32x4 = %v, want %v", got, want)
+	}
+}
+
+func TestMaxMin(t *testing.T) {
+	nan := float32(math.NaN())
+	x := simd.F32x4{1, nan, 0, -2}
+	y := simd.F32x4{2, 3, float32(math.Copysign(0, -1)), nan}
+	got := simd.MaxF32x4(x, y)
+	if got[0] != 2 || got[1] != 3 || !math.Signbit(float64(got[2])) || got[3] == got[3] {
+		t.Errorf("MaxF32x4(%v, %v) = %v, want [2 3 -0 NaN]", x, y, got)
+	}
+	if got, want := simd.MinI16x8(simd.I16x8{-5, 5, -32768}, simd.I16x8{3, -3, 32767}), (simd.I16x8{-5, -3, -32768}); got != want {
+		t.Errorf("MinI16x8 = %v, want %v", got, want)
+	}
+	if got, want := simd.MaxU8x16(simd.U8x16{255, 1}, simd.U8x16{0, 2}), (simd.U8x16{255, 2}); got != want {
+		t.Errorf("MaxU8x16 = %v, want %v", got, want)
+	}
+	// clamp to [0, 100]
+	c := simd.MinI32x4(simd.MaxI32x4(simd.I32x4{-1, 50, 101, math.MinInt32}, simd.I32x4{}), simd.I32x4{100, 100, 100, 100})
+	if want := (simd.I32x4{0, 50, 100, 0}); c != want {
+		t.Errorf("clamp = %v, want %v", c, want)
+	}
+}
+
+func TestByteOrder(t *testing.T) {
+	b := []byte{1, 2, 3, 4, 5, 6, 7, 8}
+	if got := simd.LoadU32LE(b); got != 0x04030201 {
+		t.Errorf("LoadU32LE = %#x, want 0x04030201", got)
+	}
+	if got := simd.LoadU32BE(b); got != 0x01020304 {
+		t.Errorf("LoadU32BE = %#x, want 0x01020304", got)
+	}
+	if got := simd.LoadU64LE(b); got != 0x0807060504030201 {
+		t.Errorf("LoadU64LE = %#x, want 0x0807060504030201", got)
+	}
+	if got := simd.LoadU64BE(b); got != 0x0102030405060708 {
+		t.Errorf("LoadU64BE = %#x, want 0x0102030405060708", got)
+	}
+	s := make([]byte, 8)
+	simd.StoreU64BE(s, 0x0102030405060708)
+	if simd.LoadU64LE(s) != 0x0807060504030201 {
+		t.Errorf("StoreU64BE stored %v", s)
+	}
+	simd.StoreU32LE(s, 0x0a0b0c0d)
+	if simd.LoadU32BE(s) != 0x0d0c0b0a {
+		t.Errorf("StoreU32LE stored %v", s)
+	}
+}
@@ -1,32 +1,5 @@
 package simd
 
-import (
-	"go/ast"
-	"go/token"
-	"go/types"
-)
-
-type Error struct {
-	Err error
-	Pos token.Pos
-}
-
-// File holds a single parsed file and associated data.
-type File struct {
-	pathName string
-	ast      *ast.File // Parsed AST.
-	fs       *token.FileSet
-	Info     *types.Info
-	Pkg      *types.Package
-}
-
-func (f *File) ErrorLocation(err *Error) string {
-	if err == nil {
-		return ""
-	}
-	return f.fs.Position(err.Pos).String()
-}
-
 // SIMD types
 type I8x16 [16]int8
 type I16x8 [8]int16
@@ -43,6 +16,11 @@ type U128 [16]byte
 type F32x4 [4]float32
 type F64x2 [2]float64
 
+// 256 bit types, only used by the masked loads and stores, they're kept in
+// memory and loaded into ymm registers by each intrinsic
+type I32x8 [8]int32
+type F32x8 [8]float32
+
 // SSE2 types
 type M128i [16]byte
 type M128 [4]float32
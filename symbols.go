@@ -0,0 +1,89 @@
+package main
+
+import (
+	"go/ast"
+	"go/build"
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/bjwbell/gensimd/codegen"
+)
+
+// targetContext returns the build context of the assembly's target, the
+// GOARCH of opts and its GOOS, the host's if opts.OS is "", so the files
+// the target builds are loaded whatever the host, e.g. the amd64 only
+// files of a package on a darwin/arm64 machine. Like the go command cgo is
+// off cross compiling.
+func targetContext(opts codegen.Options) *build.Context {
+	ctxt := build.Default
+	ctxt.GOARCH = opts.Arch
+	if ctxt.GOARCH == "" {
+		ctxt.GOARCH = codegen.DefaultArch
+	}
+	if opts.OS != "" {
+		ctxt.GOOS = opts.OS
+	}
+	if ctxt.GOARCH != runtime.GOARCH || ctxt.GOOS != runtime.GOOS {
+		ctxt.CgoEnabled = false
+	}
+	return &ctxt
+}
+
+// packageSymbols returns the positions of the package level Go symbols of
+// the ctxt build of the package in dir, by name, skipping the files of
+// skip, e.g. the files gensimd writes. Functions without a body are
+// declarations of assembly functions and aren't included.
+func packageSymbols(ctxt *build.Context, dir string, skip ...string) (map[string]token.Position, error) {
+	skipped := map[string]bool{}
+	for _, file := range skip {
+		if file != "" {
+			skipped[abs(file)] = true
+		}
+	}
+	infos, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	fset := token.NewFileSet()
+	declared := map[string]token.Position{}
+	for _, info := range infos {
+		name := info.Name()
+		if info.IsDir() || !strings.HasSuffix(name, ".go") || skipped[abs(filepath.Join(dir, name))] {
+			continue
+		}
+		if match, err := ctxt.MatchFile(dir, name); err != nil || !match {
+			continue
+		}
+		file, err := parser.ParseFile(fset, filepath.Join(dir, name), nil, 0)
+		if err != nil {
+			return nil, err
+		}
+		for _, decl := range file.Decls {
+			switch decl := decl.(type) {
+			case *ast.FuncDecl:
+				if decl.Recv == nil && decl.Body != nil {
+					declared[decl.Name.Name] = fset.Position(decl.Name.Pos())
+				}
+			case *ast.GenDecl:
+				for _, spec := range decl.Specs {
+					switch spec := spec.(type) {
+					case *ast.ValueSpec:
+						for _, ident := range spec.Names {
+							declared[ident.Name] = fset.Position(ident.Pos())
+						}
+					case *ast.TypeSpec:
+						declared[spec.Name.Name] = fset.Position(spec.Name.Pos())
+					}
+				}
+			}
+		}
+	}
+	// neither is referenced by name
+	delete(declared, "_")
+	delete(declared, "init")
+	return declared, nil
+}
@@ -0,0 +1,20 @@
+package codec
+
+// Add adds a and b.
+func Add(a, b int32) int32 {
+	return a + b
+}
+
+// Scale multiplies the elements of x by k.
+func Scale(x []int32, k int32) {
+	for i := range x {
+		x[i] *= k
+	}
+}
+
+func clamp(a int32) int32 {
+	if a < 0 {
+		return 0
+	}
+	return a
+}
@@ -0,0 +1,9 @@
+//go:build amd64
+// +build amd64
+
+package target
+
+// Add is only in the amd64 build of the package.
+func Add(a, b int32) int32 {
+	return a + b
+}
@@ -0,0 +1,6 @@
+package target
+
+// Sub is in every build of the package.
+func Sub(a, b int32) int32 {
+	return a - b
+}
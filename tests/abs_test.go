@@ -0,0 +1,121 @@
+// +build amd64,gc
+
+package tests
+
+import (
+	"math"
+	"testing"
+)
+
+//go:generate gensimd -fn "abst0, abst1, abst2, abst3, abst4, abst5, abst6, abst7" -outfn "abst0s, abst1s, abst2s, abst3s, abst4s, abst5s, abst6s, abst7s" -f "$GOFILE" -o "abs_test_amd64.s"
+
+func abst0s(x int64) int64
+func abst1s(x, y int16) int16
+func abst2s(x float64) float64
+func abst3s(x, y float32) float32
+func abst4s(x float64) float64
+func abst5s(x, y float32) float32
+func abst6s(x float64) float64
+func abst7s(x int8) int8
+
+// conditional negate, NEGQ and CMOVQLT
+func abst0(x int64) int64 {
+	if x < 0 {
+		x = -x
+	}
+	return x
+}
+
+// negated if the comparison is false
+func abst1(x, y int16) int16 {
+	var v int16
+	if x < y {
+		v = x
+	} else {
+		v = -x
+	}
+	return v
+}
+
+// the sign bit of a CMPSD mask, -0 stays -0
+func abst2(x float64) float64 {
+	if x < 0 {
+		x = -x
+	}
+	return x
+}
+
+func abst3(x, y float32) float32 {
+	var v float32
+	if x > y {
+		v = x
+	} else {
+		v = -x
+	}
+	return v
+}
+
+// ANDPD with every bit but the sign
+func abst4(x float64) float64 {
+	return math.Abs(x)
+}
+
+func abst5(x, y float32) float32 {
+	return float32(math.Copysign(float64(x), float64(y)))
+}
+
+func abst6(x float64) float64 {
+	return -x
+}
+
+func abst7(x int8) int8 {
+	if x <= 0 {
+		x = -x
+	}
+	return x
+}
+
+func TestAbs(t *testing.T) {
+	ints := []int64{0, 1, -1, 127, -128, 32767, -32768, 1 << 40, -1 << 63, 1<<63 - 1}
+	for _, x := range ints {
+		if abst0s(x) != abst0(x) {
+			t.Errorf("abst0s(%v) %v != %v", x, abst0s(x), abst0(x))
+		}
+		if abst7s(int8(x)) != abst7(int8(x)) {
+			t.Errorf("abst7s(%v) %v != %v", int8(x), abst7s(int8(x)), abst7(int8(x)))
+		}
+		for _, y := range ints {
+			if abst1s(int16(x), int16(y)) != abst1(int16(x), int16(y)) {
+				t.Errorf("abst1s(%v, %v) %v != %v", int16(x), int16(y), abst1s(int16(x), int16(y)), abst1(int16(x), int16(y)))
+			}
+		}
+	}
+	// the results must have the same bits, NaNs only the same sign
+	same := func(x, y float64) bool {
+		if x != x && y != y {
+			return math.Signbit(x) == math.Signbit(y)
+		}
+		return math.Float64bits(x) == math.Float64bits(y)
+	}
+	floats := []float64{0, math.Copysign(0, -1), 1, -1.5, 1e30, -1e-300, math.Inf(1), math.Inf(-1), math.NaN(), -math.NaN()}
+	for _, x := range floats {
+		if !same(abst2s(x), abst2(x)) {
+			t.Errorf("abst2s(%v) %v != %v", x, abst2s(x), abst2(x))
+		}
+		if !same(abst4s(x), abst4(x)) {
+			t.Errorf("abst4s(%v) %v != %v", x, abst4s(x), abst4(x))
+		}
+		if !same(abst6s(x), abst6(x)) {
+			t.Errorf("abst6s(%v) %v != %v", x, abst6s(x), abst6(x))
+		}
+		for _, y := range floats {
+			x32, y32 := float32(x), float32(y)
+			if !same(float64(abst3s(x32, y32)), float64(abst3(x32, y32))) {
+				t.Errorf("abst3s(%v, %v) %v != %v", x32, y32, abst3s(x32, y32), abst3(x32, y32))
+			}
+			if !same(float64(abst5s(x32, y32)), float64(abst5(x32, y32))) {
+				t.Errorf("abst5s(%v, %v) %v != %v", x32, y32, abst5s(x32, y32), abst5(x32, y32))
+			}
+		}
+	}
+}
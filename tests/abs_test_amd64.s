@@ -0,0 +1,145 @@
+// Code generated by gensimd. DO NOT EDIT.
+// gensimd version: devel
+// gensimd command: gensimd -f abs_test.go -fn "abst0, abst1, abst2, abst3, abst4, abst5, abst6, abst7" -o abs_test_amd64.s -outfn "abst0s, abst1s, abst2s, abst3s, abst4s, abst5s, abst6s, abst7s"
+// gensimd source: abs_test.go sha256:600d70fc2a69f1b53d32ce5d2aac84401359b46f5d96e45595d62110af33e581
+// gensimd target: avx2
+
+//go:build amd64 && !noasm && !appengine
+// +build amd64,!noasm,!appengine
+
+#include "textflag.h"
+
+TEXT ·abst0s(SB),$16-16
+block0:
+        // entry
+        // ssa.BinOp, t0 = x < 0:int64, compared by the select
+        MOVQ         x+0(FP), R15
+        MOVQ         R15, R13
+        MOVQ         R15, R12
+        NEGQ         R12
+        CMPQ         R15, $0
+        CMOVQLT      R12, R13
+        MOVQ         R13, t2-8(SP)
+block2:
+        // if.done, preds block0 block1
+        MOVQ         t2-8(SP), R15
+        MOVQ         R15, ret+8(FP)
+        RET
+
+TEXT ·abst1s(SB),$8-10
+block0:
+        // entry
+        // ssa.BinOp, t0 = x < y, compared by the select
+        MOVWQZX      x+0(FP), R15
+        MOVW         R15, R13
+        MOVW         R15, R12
+        NEGW         R13
+        MOVWQZX      y+2(FP), R11
+        CMPW         R15, R11
+        CMOVLLT      R12, R13
+        MOVW         R13, t1-2(SP)
+block2:
+        // if.done, preds block1 block3
+        MOVWQZX      t1-2(SP), R15
+        MOVW         R15, ret+8(FP)
+        RET
+
+TEXT ·abst2s(SB),$16-16
+block0:
+        // entry
+        // ssa.BinOp, t0 = x < 0:float64, compared by the select
+        MOVSD        x+0(FP), X14
+        MOVO         X14, X13
+        MOVO         X14, X12
+        XORPD        X11, X11
+        CMPSD        X11, X12, $1
+        MOVQ         $-9223372036854775808, R15
+        MOVQ         R15, X10
+        ANDPS        X10, X12
+        XORPS        X12, X13
+        MOVSD        X13, t2-8(SP)
+block2:
+        // if.done, preds block0 block1
+        MOVSD        t2-8(SP), X14
+        MOVSD        X14, ret+8(FP)
+        RET
+
+TEXT ·abst3s(SB),$8-12
+block0:
+        // entry
+        // ssa.BinOp, t0 = x > y, compared by the select
+        MOVSS        x+0(FP), X14
+        MOVO         X14, X13
+        MOVSS        y+4(FP), X12
+        MOVO         X12, X11
+        CMPSS        X14, X11, $5
+        MOVQ         $2147483648, R15
+        MOVQ         R15, X10
+        ANDPS        X10, X11
+        XORPS        X11, X13
+        MOVSS        X13, t1-4(SP)
+block2:
+        // if.done, preds block1 block3
+        MOVSS        t1-4(SP), X14
+        MOVSS        X14, ret+8(FP)
+        RET
+
+TEXT ·abst4s(SB),$16-16
+block0:
+        // entry
+        MOVSD        x+0(FP), X14
+        MOVO         X14, X13
+        MOVQ         $9223372036854775807, R15
+        MOVQ         R15, X12
+        ANDPS        X12, X13
+        MOVSD        X13, ret+8(FP)
+        RET
+
+TEXT ·abst5s(SB),$32-12
+block0:
+        // entry
+        MOVSS        x+0(FP), X14
+        CVTSS2SD     X14, X13
+        MOVSS        y+4(FP), X12
+        CVTSS2SD     X12, X11
+        MOVO         X13, X10
+        MOVQ         $-9223372036854775808, R15
+        MOVQ         R15, X9
+        ANDNPS       X10, X9
+        MOVO         X9, X10
+        MOVQ         $-9223372036854775808, R15
+        MOVQ         R15, X9
+        ANDPS        X11, X9
+        ORPS         X9, X10
+        CVTSD2SS     X10, X9
+        MOVSS        X9, ret+8(FP)
+        RET
+
+TEXT ·abst6s(SB),$16-16
+block0:
+        // entry
+        MOVSD        x+0(FP), X12
+        MOVO         X12, X14
+        MOVQ         $-9223372036854775808, R15
+        MOVQ         R15, X13
+        XORPS        X13, X14
+        MOVSD        X14, ret+8(FP)
+        RET
+
+TEXT ·abst7s(SB),$8-9
+block0:
+        // entry
+        // ssa.BinOp, t0 = x <= 0:int8, compared by the select
+        MOVBQZX      x+0(FP), R15
+        MOVB         R15, R13
+        MOVB         R15, R12
+        NEGB         R12
+        CMPB         R15, $0
+        CMOVLLE      R12, R13
+        MOVB         R13, t2-1(SP)
+block2:
+        // if.done, preds block0 block1
+        MOVBQZX      t2-1(SP), R15
+        MOVB         R15, ret+8(FP)
+        RET
+
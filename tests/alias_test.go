@@ -0,0 +1,132 @@
+// +build amd64,gc
+
+package tests
+
+import (
+	"testing"
+
+	"github.com/bjwbell/gensimd/simd"
+)
+
+//go:generate gensimd -fn "aliast0, aliast1, aliast2, aliast3, aliast4, aliast5" -outfn "aliast0s, aliast1s, aliast2s, aliast3s, aliast4s, aliast5s" -f "$GOFILE" -o "alias_test_amd64.s"
+
+func aliast0s(x []int64, y []uint32) int64
+func aliast1s(x []int32) int32
+func aliast2s(dst, x []float64, k float64) float64
+func aliast3s(x, y []int64, k int64) int64
+func aliast4s(v []simd.I32x4, p *simd.I32x4) int32
+func aliast5s(v []simd.I32x4, s []int32) int32
+
+// the store to y doesn't change x[i], slices of different element types
+// don't overlap, x[i] is loaded once
+func aliast0(x []int64, y []uint32) int64 {
+	s := int64(0)
+	for i := 0; i < len(x); i++ {
+		y[i] = uint32(x[i]) ^ 0x55
+		s += x[i] * x[i]
+	}
+	return s
+}
+
+// elements of the same slice at indexes differing by a constant
+func aliast1(x []int32) int32 {
+	s := int32(0)
+	for i := 0; i+2 < len(x); i++ {
+		x[i+2] = x[i] + x[i+1]
+		s += x[i] ^ x[i+1]
+	}
+	return s
+}
+
+// slices of the same element type asserted to not overlap
+//gensimd:noalias
+func aliast2(dst, x []float64, k float64) float64 {
+	s := 0.0
+	for i := 0; i < len(x); i++ {
+		dst[i] = x[i] * k
+		s += x[i]
+	}
+	return s
+}
+
+// the stored value is forwarded to the load of x[0], the store to y may
+// change it, it's loaded again
+func aliast3(x, y []int64, k int64) int64 {
+	x[0] = k * 3
+	a := x[0]
+	y[1] = a + 1
+	b := x[0]
+	return a*10 + b
+}
+
+// p may point into v, simd.I32x4 contains the int32 elements of p, the
+// store to v[0] may change p[0], it's loaded again
+func aliast4(v []simd.I32x4, p *simd.I32x4) int32 {
+	x := p[0]
+	v[0] = simd.I32x4{9, 9, 9, 9}
+	return x + p[0]
+}
+
+// s may be a slice of an element of v
+func aliast5(v []simd.I32x4, s []int32) int32 {
+	x := s[0]
+	v[0] = simd.I32x4{9, 9, 9, 9}
+	return x + s[0]
+}
+
+func TestAlias(t *testing.T) {
+	for n := 0; n < 9; n++ {
+		x := make([]int64, n)
+		x32 := make([]int32, n)
+		f64 := make([]float64, n)
+		for i := 0; i < n; i++ {
+			x[i] = int64(i*i) - 9
+			x32[i] = int32(5 - 2*i)
+			f64[i] = float64(i) + 0.5
+		}
+		got32, expected32 := make([]uint32, n), make([]uint32, n)
+		if got, expected := aliast0s(x, got32), aliast0(x, expected32); got != expected {
+			t.Errorf("t0 n=%v %v != %v", n, got, expected)
+		}
+		for i := range got32 {
+			if got32[i] != expected32[i] {
+				t.Errorf("t0 n=%v [%v] %v != %v", n, i, got32[i], expected32[i])
+			}
+		}
+		gotx, expectedx := append([]int32{}, x32...), append([]int32{}, x32...)
+		if got, expected := aliast1s(gotx), aliast1(expectedx); got != expected {
+			t.Errorf("t1 n=%v %v != %v", n, got, expected)
+		}
+		for i := range gotx {
+			if gotx[i] != expectedx[i] {
+				t.Errorf("t1 n=%v [%v] %v != %v", n, i, gotx[i], expectedx[i])
+			}
+		}
+		gotf, expectedf := make([]float64, n), make([]float64, n)
+		if got, expected := aliast2s(gotf, f64, 1.5), aliast2(expectedf, f64, 1.5); got != expected {
+			t.Errorf("t2 n=%v %v != %v", n, got, expected)
+		}
+		for i := range gotf {
+			if gotf[i] != expectedf[i] {
+				t.Errorf("t2 n=%v [%v] %v != %v", n, i, gotf[i], expectedf[i])
+			}
+		}
+	}
+	// y[1] is x[0]
+	x, y := make([]int64, 4), make([]int64, 4)
+	if got, expected := aliast3s(y[1:], y, 7), aliast3(x[1:], x, 7); got != expected {
+		t.Errorf("t3 overlapping %v != %v", got, expected)
+	}
+	if got, expected := aliast3s(y, x, 7), aliast3(x, y, 7); got != expected {
+		t.Errorf("t3 %v != %v", got, expected)
+	}
+	// p and s are v[0]
+	v := []simd.I32x4{{1, 1, 1, 1}}
+	if got := aliast4s(v, &v[0]); got != 10 {
+		t.Errorf("t4 overlapping %v != 10", got)
+	}
+	v[0] = simd.I32x4{1, 1, 1, 1}
+	if got := aliast5s(v, v[0][:]); got != 10 {
+		t.Errorf("t5 overlapping %v != 10", got)
+	}
+}
@@ -0,0 +1,304 @@
+// Code generated by gensimd. DO NOT EDIT.
+// gensimd version: devel
+// gensimd command: gensimd -f alias_test.go -fn "aliast0, aliast1, aliast2, aliast3, aliast4, aliast5" -o alias_test_amd64.s -outfn "aliast0s, aliast1s, aliast2s, aliast3s, aliast4s, aliast5s"
+// gensimd source: alias_test.go sha256:0a732fc954a4e3e84b86efd5ee76c04dded04e290a586e7735c6d6b949d665ae
+// gensimd target: avx2
+
+//go:build amd64 && !noasm && !appengine
+// +build amd64,!noasm,!appengine
+
+#include "textflag.h"
+
+TEXT ·aliast0s(SB),$120-56
+block0:
+        // entry
+        MOVQ         x_len+8(FP), R15
+        MOVQ         R15, R13
+        MOVQ         R13, R8
+        MOVQ         $0, R12
+        MOVQ         R12, t0-32(SP)
+        MOVQ         $0, R11
+        MOVQ         R11, t1-40(SP)
+        MOVQ         t0-32(SP), BX
+        MOVQ         x_base+0(FP), R10
+        LEAQ         (R10)(R11*8), R10
+        MOVQ         R10, ivptr0-8(SP)
+        MOVQ         y_base+24(FP), R10
+        LEAQ         (R10)(R11*4), R10
+        MOVQ         R10, ivptr1-16(SP)
+        MOVQ         R13, t2-48(SP)
+block1:
+        // for.loop, preds block0 block2
+        MOVQ         t1-40(SP), R15
+        MOVQ         R8, R13
+        CMPQ         R15, R13
+        JGE          block3
+block2:
+        // for.body, preds block1
+        MOVQ         ivptr0-8(SP), R15
+        MOVQ         R15, R13
+        MOVQ         (R13), R12
+        MOVQ         R12, t5-24(SP)
+        MOVQ         t5-24(SP), R12
+        MOVL         R12, R11
+        XORL         $85, R11
+        MOVQ         ivptr1-16(SP), R10
+        MOVQ         R10, R9
+        MOVL         R11, (R9)
+        MOVQ         R15, BP
+        // ssa.UnOp, t10 = *t9, same as t5
+        MOVQ         R15, DI
+        // ssa.UnOp, t12 = *t11, same as t5
+        MOVQ         R12, SI
+        MOVQ         SI, AX
+        IMULQ        R12
+        MOVQ         AX, SI
+        MOVQ         SI, t13-97(SP)
+        MOVQ         BX, SI
+        MOVQ         t13-97(SP), R9
+        MOVQ         SI, R10
+        ADDQ         R9, R10
+        MOVQ         R10, BX
+        MOVQ         t1-40(SP), SI
+        MOVQ         SI, R9
+        ADDQ         $1, R9
+        MOVQ         R9, t1-40(SP)
+        LEAQ         8(R15), R15
+        MOVQ         R15, ivptr0-8(SP)
+        MOVQ         ivptr1-16(SP), R15
+        LEAQ         4(R15), R15
+        MOVQ         R15, ivptr1-16(SP)
+        MOVQ         R9, t15-113(SP)
+        JMP block1
+block3:
+        // for.done, preds block1
+        MOVQ         BX, R15
+        MOVQ         R15, ret+48(FP)
+        RET
+
+TEXT ·aliast1s(SB),$136-28
+block0:
+        // entry
+        MOVQ         x_len+8(FP), R15
+        MOVQ         R15, R13
+        MOVQ         R13, R8
+        MOVL         $0, R12
+        MOVL         R12, t0-20(SP)
+        MOVQ         $0, R11
+        MOVQ         R11, t1-28(SP)
+        MOVLQZX      t0-20(SP), BX
+        MOVQ         x_base+0(FP), R10
+        LEAQ         (R10)(R11*4), R10
+        MOVQ         R10, ivptr0-8(SP)
+        MOVQ         R13, t3-44(SP)
+block1:
+        // for.loop, preds block0 block2
+        MOVQ         t1-28(SP), R15
+        MOVQ         R15, R13
+        ADDQ         $2, R13
+        MOVQ         R8, R12
+        CMPQ         R13, R12
+        JGE          block3
+block2:
+        // for.body, preds block1
+        MOVQ         t1-28(SP), R15
+        MOVQ         R15, R13
+        ADDQ         $2, R13
+        MOVQ         ivptr0-8(SP), R12
+        MOVQ         R12, R11
+        MOVL         (R11), R10
+        MOVL         R10, t7-12(SP)
+        MOVQ         R15, R10
+        ADDQ         $1, R10
+        MOVQ         x_base+0(FP), R9
+        LEAQ         (R9)(R10*4), R9
+        MOVL         (R9), BP
+        MOVL         BP, t10-16(SP)
+        MOVLQZX      t7-12(SP), R9
+        MOVLQZX      t10-16(SP), R10
+        MOVL         R9, R11
+        ADDL         R10, R11
+        MOVQ         x_base+0(FP), BP
+        LEAQ         (BP)(R13*4), BP
+        MOVL         R11, (BP)
+        MOVQ         R12, DI
+        // ssa.UnOp, t14 = *t13, same as t7
+        MOVQ         R15, SI
+        ADDQ         $1, SI
+        MOVQ         SI, t15-105(SP)
+        MOVQ         t15-105(SP), BP
+        MOVQ         x_base+0(FP), SI
+        LEAQ         (SI)(BP*4), SI
+        // ssa.UnOp, t17 = *t16, same as t10
+        XORQ         R10, R9
+        MOVL         R9, t18-117(SP)
+        MOVL         BX, R9
+        MOVLQZX      t18-117(SP), R10
+        MOVL         R9, R11
+        ADDL         R10, R11
+        MOVL         R11, BX
+        MOVQ         R15, SI
+        ADDQ         $1, SI
+        MOVQ         SI, t1-28(SP)
+        LEAQ         4(R12), R12
+        MOVQ         R12, ivptr0-8(SP)
+        MOVQ         SI, t20-129(SP)
+        JMP block1
+block3:
+        // for.done, preds block1
+        MOVL         BX, R15
+        MOVL         R15, ret+24(FP)
+        RET
+
+TEXT ·aliast2s(SB),$104-64
+        MOVSD        k+48(FP), X1
+block0:
+        // entry
+        MOVQ         x_len+32(FP), R15
+        MOVQ         R15, R13
+        MOVQ         R13, BX
+        XORPD        X14, X14
+        MOVSD        X14, t0-32(SP)
+        MOVQ         $0, R12
+        MOVQ         R12, t1-40(SP)
+        MOVSD        t0-32(SP), X0
+        MOVQ         x_base+24(FP), R11
+        LEAQ         (R11)(R12*8), R11
+        MOVQ         R11, ivptr0-8(SP)
+        MOVQ         dst_base+0(FP), R11
+        LEAQ         (R11)(R12*8), R11
+        MOVQ         R11, ivptr1-16(SP)
+        MOVQ         R13, t2-48(SP)
+block1:
+        // for.loop, preds block0 block2
+        MOVQ         t1-40(SP), R15
+        MOVQ         BX, R13
+        CMPQ         R15, R13
+        JGE          block3
+block2:
+        // for.body, preds block1
+        MOVQ         ivptr0-8(SP), R15
+        MOVQ         R15, R13
+        MOVSD        (R13), X14
+        MOVSD        X14, t5-24(SP)
+        MOVSD        t5-24(SP), X14
+        MOVO         X1, X13
+        MOVO         X14, X12
+        MULSD        X13, X12
+        MOVQ         ivptr1-16(SP), R12
+        MOVQ         R12, R11
+        MOVSD        X12, (R11)
+        MOVQ         R15, R10
+        // ssa.UnOp, t9 = *t8, same as t5
+        MOVO         X0, X11
+        MOVO         X11, X10
+        ADDSD        X14, X10
+        MOVO         X10, X0
+        MOVQ         t1-40(SP), R9
+        MOVQ         R9, R8
+        ADDQ         $1, R8
+        MOVQ         R8, t1-40(SP)
+        LEAQ         8(R15), R15
+        MOVQ         R15, ivptr0-8(SP)
+        LEAQ         8(R12), R12
+        MOVQ         R12, ivptr1-16(SP)
+        MOVQ         R8, t11-97(SP)
+        JMP block1
+block3:
+        // for.done, preds block1
+        MOVO         X0, X14
+        MOVSD        X14, ret+56(FP)
+        RET
+
+TEXT ·aliast3s(SB),$80-64
+block0:
+        // entry
+        MOVQ         k+48(FP), R15
+        MOVQ         $3, R13
+        MOVQ         R15, R12
+        MOVQ         R12, AX
+        IMULQ        R13
+        MOVQ         AX, R12
+        MOVQ         x_base+0(FP), R11
+        MOVQ         R12, (R11)
+        MOVQ         x_base+0(FP), R10
+        // ssa.UnOp, t3 = *t2, same as t0
+        MOVQ         R12, R9
+        ADDQ         $1, R9
+        MOVQ         y_base+24(FP), R8
+        ADDQ         $8, R8
+        MOVQ         R9, (R8)
+        MOVQ         x_base+0(FP), BP
+        MOVQ         (BP), BX
+        MOVQ         BX, t7-56(SP)
+        MOVQ         $10, BX
+        MOVQ         R12, AX
+        IMULQ        BX
+        MOVQ         AX, R12
+        MOVQ         t7-56(SP), DI
+        ADDQ         DI, R12
+        MOVQ         R12, ret+56(FP)
+        RET
+
+TEXT ·aliast4s(SB),$104-36
+block0:
+        // entry
+        MOVQ         p+24(FP), R15
+        MOVL         (R15), R13
+        MOVL         R13, t1-28(SP)
+        LEAQ         t2-16(SP), R13
+        LEAQ         t2-16(SP), R12
+        ADDQ         $4, R12
+        LEAQ         t2-16(SP), R11
+        ADDQ         $8, R11
+        LEAQ         t2-16(SP), R10
+        ADDQ         $12, R10
+        MOVL         $9, R9
+        MOVL         R9, (R13)
+        MOVL         R9, (R12)
+        MOVL         R9, (R11)
+        MOVL         R9, (R10)
+        MOVOU        t2-16(SP), X14
+        MOVO         X14, X13
+        MOVQ         v_base+0(FP), R8
+        MOVOU        X13, (R8)
+        MOVQ         p+24(FP), BP
+        MOVL         (BP), BX
+        MOVL         BX, t10-96(SP)
+        MOVLQZX      t1-28(SP), R8
+        MOVLQZX      t10-96(SP), R9
+        ADDL         R9, R8
+        MOVL         R8, ret+32(FP)
+        RET
+
+TEXT ·aliast5s(SB),$104-52
+block0:
+        // entry
+        MOVQ         s_base+24(FP), R15
+        MOVL         (R15), R13
+        MOVL         R13, t1-28(SP)
+        LEAQ         t2-16(SP), R13
+        LEAQ         t2-16(SP), R12
+        ADDQ         $4, R12
+        LEAQ         t2-16(SP), R11
+        ADDQ         $8, R11
+        LEAQ         t2-16(SP), R10
+        ADDQ         $12, R10
+        MOVL         $9, R9
+        MOVL         R9, (R13)
+        MOVL         R9, (R12)
+        MOVL         R9, (R11)
+        MOVL         R9, (R10)
+        MOVOU        t2-16(SP), X14
+        MOVO         X14, X13
+        MOVQ         v_base+0(FP), R8
+        MOVOU        X13, (R8)
+        MOVQ         s_base+24(FP), BP
+        MOVL         (BP), BX
+        MOVL         BX, t10-96(SP)
+        MOVLQZX      t1-28(SP), R8
+        MOVLQZX      t10-96(SP), R9
+        ADDL         R9, R8
+        MOVL         R8, ret+48(FP)
+        RET
+
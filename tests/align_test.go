@@ -0,0 +1,62 @@
+// +build amd64,gc
+
+package tests
+
+import (
+	"runtime"
+	"testing"
+	"unsafe"
+
+	"github.com/bjwbell/gensimd/simd"
+)
+
+//go:generate gensimd -debug -fn "alignt0" -outfn "alignt0s" -f "$GOFILE" -o "align_test_amd64.s"
+
+func alignt0s(dst, x []simd.I32x4) int
+
+//gensimd:align 16 dst x
+func alignt0(dst, x []simd.I32x4) int {
+	for i := 0; i < len(dst); i++ {
+		dst[i] = simd.AddI32x4(dst[i], x[i])
+	}
+	return len(dst)
+}
+
+func TestAlign(t *testing.T) {
+	dst := make([]simd.I32x4, 8)
+	x := make([]simd.I32x4, 8)
+	expected := make([]simd.I32x4, 8)
+	for i := range dst {
+		dst[i] = simd.I32x4{int32(i), -1, 1 << 30, -1 << 31}
+		x[i] = simd.I32x4{1, int32(-i), 1 << 30, -1}
+		expected[i] = dst[i]
+	}
+	if uintptr(unsafe.Pointer(&dst[0]))%16 != 0 || uintptr(unsafe.Pointer(&x[0]))%16 != 0 {
+		t.Skip("slices aren't 16 byte aligned")
+	}
+	alignt0(expected, x)
+	if n := alignt0s(dst, x); n != len(dst) {
+		t.Errorf("alignt0s returned %v", n)
+	}
+	for i := range dst {
+		if dst[i] != expected[i] {
+			t.Errorf("alignt0s: dst[%v] = %v, expected %v", i, dst[i], expected[i])
+		}
+	}
+}
+
+func TestAlignFault(t *testing.T) {
+	buf := make([]simd.I32x4, 9)
+	if uintptr(unsafe.Pointer(&buf[0]))%16 != 0 {
+		t.Skip("slice isn't 16 byte aligned")
+	}
+	// dst starts 4 bytes into buf, x is aligned
+	dst := unsafe.Slice((*simd.I32x4)(unsafe.Add(unsafe.Pointer(&buf[0]), 4)), 8)
+	x := buf[:8]
+	defer func() {
+		if _, ok := recover().(runtime.Error); !ok {
+			t.Errorf("alignt0s with a misaligned dst didn't panic with a runtime error")
+		}
+	}()
+	alignt0s(dst, x)
+}
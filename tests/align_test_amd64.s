@@ -0,0 +1,197 @@
+// Code generated by gensimd. DO NOT EDIT.
+// gensimd version: devel
+// gensimd command: gensimd -debug -f align_test.go -fn alignt0 -o align_test_amd64.s -outfn alignt0s
+// gensimd source: align_test.go sha256:ee4b01a3af7c951de169bfcc5334e3007c0208b8aee105bd60cdaf394d39dea3
+// gensimd target: avx2
+
+//go:build amd64 && !noasm && !appengine
+// +build amd64,!noasm,!appengine
+
+#include "textflag.h"
+
+TEXT ·alignt0s(SB),$128-56
+        // BEGIN AlignChecks
+        MOVQ         dst_base+0(FP), R15
+        TESTQ        $15, R15
+        JNE          alignfault
+        MOVQ         x_base+24(FP), R15
+        TESTQ        $15, R15
+        JNE          alignfault
+        // END AlignChecks
+        // BEGIN ZeroRetValue
+        // END ZeroRetValue
+        // BEGIN ZeroSsaLocals
+        // END ZeroSsaLocals
+block0:
+        // entry
+        // BEGIN ssa.Jump
+        // BEGIN JumpPreamble block0 -> block1
+        // BEGIN HoistedInstrs block0 -> block1
+        // BEGIN Builtin.Len: len(dst)
+        // BEGIN SliceLen: slice (parameter dst : []github.com/bjwbell/gensimd/simd.I32x4), ident (identifier{name: t1, typ: int, local: nil, param: nil, cnst: nil, offset: -32})
+        // BEGIN LoadValue, val dst (= parameter dst : []github.com/bjwbell/gensimd/simd.I32x4), offset 8, size 8
+        MOVQ         dst_len+8(FP), R15
+        // END LoadValue, val dst (= parameter dst : []github.com/bjwbell/gensimd/simd.I32x4), offset 8, size 8
+        MOVQ         R15, R13
+        // END SliceLen: slice (parameter dst : []github.com/bjwbell/gensimd/simd.I32x4), ident ({0x34a6fc92c848 t1 0xb55f00 -32 0x34a70567e030 <nil> <nil> <nil> <nil> 0x34a6fc203980 false})
+        // END Builtin.Len: len(dst)
+        // BEGIN setPin t1, BX
+        MOVQ         R13, BX
+        // END setPin t1, BX
+        // END HoistedInstrs block0 -> block1
+        // BEGIN StoreValAddr addr name:t0, val name:0:int
+        // BEGIN LoadValue, val 0:int (= 0:int), offset 0, size 8
+        MOVQ         $0, R12
+        // END LoadValue, val 0:int (= 0:int), offset 0, size 8
+        MOVQ         R12, t0-24(SP)
+        // END StoreValAddr addr name:t0, val name:0:int
+        // BEGIN inductionInit ivptr0 = &dst[0:int]
+        // BEGIN LoadValueSimple, val: 0:int
+        // BEGIN LoadValue, val 0:int (= 0:int), offset 0, size 8
+        // END LoadValue, val 0:int (= 0:int), offset 0, size 8
+        // END LoadValueSimple, val: 0:int, reg R12
+        MOVQ         dst_base+0(FP), R11
+        IMUL3Q       $16, R12, R10
+        ADDQ         R10, R11
+        // END inductionInit ivptr0 = &dst[0:int]
+        MOVQ         R11, ivptr0-8(SP)
+        // BEGIN inductionInit ivptr1 = &x[0:int]
+        // BEGIN LoadValueSimple, val: 0:int
+        // BEGIN LoadValue, val 0:int (= 0:int), offset 0, size 8
+        // END LoadValue, val 0:int (= 0:int), offset 0, size 8
+        // END LoadValueSimple, val: 0:int, reg R12
+        MOVQ         x_base+24(FP), R11
+        IMUL3Q       $16, R12, R10
+        ADDQ         R10, R11
+        // END inductionInit ivptr1 = &x[0:int]
+        MOVQ         R11, ivptr1-16(SP)
+        MOVQ         R13, t1-32(SP)
+        // END JumpPreamble block0 -> block1
+        // END ssa.Jump
+block1:
+        // for.loop, preds block0 block2
+        // BEGIN ssa.Phi, name (t0), comment (i), value (phi [0: 0:int, 2: t9] #i)
+        // END ssa.Phi, phi [0: 0:int, 2: t9] #i
+        // BEGIN ssa.BinOp, t2 = t0 < t1
+        // BEGIN BinOpLoadXY
+        // BEGIN LoadValue, val t0 (= phi [0: 0:int, 2: t9] #i), offset 0, size 8
+        MOVQ         t0-24(SP), R15
+        // END LoadValue, val t0 (= phi [0: 0:int, 2: t9] #i), offset 0, size 8
+        // BEGIN LoadValue, val t1 (= len(dst)), offset 0, size 8
+        MOVQ         BX, R13
+        // END LoadValue, val t1 (= len(dst)), offset 0, size 8
+        // END BinOpLoadXY
+        CMPQ         R15, R13
+        // END ssa.BinOp, t2 = t0 < t1
+        // BEGIN ssa.If, if t2 goto 2 else 3
+        // BEGIN JumpPreamble block1 -> block3
+        // END JumpPreamble block1 -> block3
+        JGE          block3
+        // BEGIN JumpPreamble block1 -> block2
+        // END JumpPreamble block1 -> block2
+        // END ssa.If, if t2 goto 2 else 3
+block2:
+        // for.body, preds block1
+        // BEGIN ssa.IndexAddr: t3 = &dst[t0], ivptr0
+        // BEGIN LoadIdentSimple, ident: ivptr0
+        MOVQ         ivptr0-8(SP), R15
+        // END LoadIdentSimple, ident: ivptr0, reg R15
+        MOVQ         R15, R13
+        // END ssa.IndexAddr: t3 = &dst[t0], ivptr0
+        // BEGIN ssa.UnOp: t4 = *t3
+        // BEGIN ssa.UnOpPointer, t4 = *t3
+        MOVO         (R13), X14
+        MOVOU        X14, t4-57(SP)
+        // END ssa.UnOpPointer, t4 = *t3
+        // END ssa.UnOp: t4 = *t3
+        // BEGIN ssa.IndexAddr: t5 = &x[t0], ivptr1
+        // BEGIN LoadIdentSimple, ident: ivptr1
+        MOVQ         ivptr1-16(SP), R12
+        // END LoadIdentSimple, ident: ivptr1, reg R12
+        MOVQ         R12, R11
+        // END ssa.IndexAddr: t5 = &x[t0], ivptr1
+        // BEGIN ssa.UnOp: t6 = *t5
+        // BEGIN ssa.UnOpPointer, t6 = *t5
+        MOVO         (R11), X14
+        MOVOU        X14, t6-81(SP)
+        // END ssa.UnOpPointer, t6 = *t5
+        // END ssa.UnOp: t6 = *t5
+        // BEGIN SIMD Intrinsic github.com/bjwbell/gensimd/simd.AddI32x4(t4, t6)
+        // BEGIN LoadSimd, ident: t6
+        // BEGIN LoadIdentSimple, ident: t6
+        MOVOU        t6-81(SP), X14
+        // END LoadIdentSimple, ident: t6, reg X14
+        // END LoadSimd, ident: t6, reg X14
+        // BEGIN LoadSimd, ident: t4
+        // BEGIN LoadIdentSimple, ident: t4
+        MOVOU        t4-57(SP), X13
+        // END LoadIdentSimple, ident: t4, reg X13
+        // END LoadSimd, ident: t4, reg X13
+        PADDL        X14, X13
+        // END SIMD Intrinsic github.com/bjwbell/gensimd/simd.AddI32x4(t4, t6)
+        // BEGIN ssa.IndexAddr: t8 = &dst[t0], ivptr0
+        // BEGIN LoadIdentSimple, ident: ivptr0
+        // END LoadIdentSimple, ident: ivptr0, reg R15
+        MOVQ         R15, R10
+        // END ssa.IndexAddr: t8 = &dst[t0], ivptr0
+        // BEGIN Store *t8 = t7
+        // BEGIN StoreValPtr ptr name:t8, val name:t7
+        // BEGIN LoadValueSimple, val: github.com/bjwbell/gensimd/simd.AddI32x4(t4, t6)
+        // BEGIN LoadValue, val t7 (= github.com/bjwbell/gensimd/simd.AddI32x4(t4, t6)), offset 0, size 16
+        // END LoadValue, val t7 (= github.com/bjwbell/gensimd/simd.AddI32x4(t4, t6)), offset 0, size 16
+        // END LoadValueSimple, val: github.com/bjwbell/gensimd/simd.AddI32x4(t4, t6), reg X13
+        MOVO         X13, (R10)
+        // END StoreValPtr ptr name:t8, val name:t7
+        // END Store *t8 = t7
+        // BEGIN ssa.BinOp, t9 = t0 + 1:int
+        // BEGIN LoadValue, val t0 (= phi [0: 0:int, 2: t9] #i), offset 0, size 8
+        MOVQ         t0-24(SP), R9
+        // END LoadValue, val t0 (= phi [0: 0:int, 2: t9] #i), offset 0, size 8
+        MOVQ         R9, R8
+        ADDQ         $1, R8
+        // END ssa.BinOp, t9 = t0 + 1:int
+        // BEGIN ssa.Jump
+        // BEGIN JumpPreamble block2 -> block1
+        // BEGIN StoreValAddr addr name:t0, val name:t9
+        // BEGIN LoadValue, val t9 (= t0 + 1:int), offset 0, size 8
+        // END LoadValue, val t9 (= t0 + 1:int), offset 0, size 8
+        MOVQ         R8, t0-24(SP)
+        // END StoreValAddr addr name:t0, val name:t9
+        // BEGIN inductionStep ivptr0 += 16
+        // BEGIN LoadIdentSimple, ident: ivptr0
+        // END LoadIdentSimple, ident: ivptr0, reg R15
+        LEAQ         16(R15), R15
+        // END inductionStep ivptr0 += 16
+        MOVQ         R15, ivptr0-8(SP)
+        // BEGIN inductionStep ivptr1 += 16
+        // BEGIN LoadIdentSimple, ident: ivptr1
+        // END LoadIdentSimple, ident: ivptr1, reg R12
+        LEAQ         16(R12), R12
+        // END inductionStep ivptr1 += 16
+        MOVQ         R12, ivptr1-16(SP)
+        MOVQ         R8, t9-113(SP)
+        // END JumpPreamble block2 -> block1
+        JMP block1
+        // END ssa.Jump
+block3:
+        // for.done, preds block1
+        // BEGIN Builtin.Len: len(dst)
+        // BEGIN SliceLen: slice (parameter dst : []github.com/bjwbell/gensimd/simd.I32x4), ident (identifier{name: t10, typ: int, local: nil, param: nil, cnst: nil, offset: -121})
+        // BEGIN LoadValue, val dst (= parameter dst : []github.com/bjwbell/gensimd/simd.I32x4), offset 8, size 8
+        MOVQ         dst_len+8(FP), R15
+        // END LoadValue, val dst (= parameter dst : []github.com/bjwbell/gensimd/simd.I32x4), offset 8, size 8
+        MOVQ         R15, R13
+        // END SliceLen: slice (parameter dst : []github.com/bjwbell/gensimd/simd.I32x4), ident ({0x34a6fc92c848 t10 0xb55f00 -121 0x34a70567e390 <nil> <nil> <nil> <nil> 0x34a6fc203d00 false})
+        // END Builtin.Len: len(dst)
+        // BEGIN ssa.Return
+        // BEGIN StoreValAddr addr name:ret, val name:t10
+        // BEGIN LoadValue, val t10 (= len(dst)), offset 0, size 8
+        // END LoadValue, val t10 (= len(dst)), offset 0, size 8
+        MOVQ         R13, ret+48(FP)
+        // END StoreValAddr addr name:ret, val name:t10
+        RET
+        // END ssa.Return
+alignfault:
+        MOVQ         $0, AX
+        MOVQ         (AX), AX
+
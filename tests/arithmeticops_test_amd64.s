@@ -1,402 +1,435 @@
-// +build amd64 !noasm !appengine
+// Code generated by gensimd. DO NOT EDIT.
+// gensimd version: devel
+// gensimd command: gensimd -f arithmeticops_test.go -fn "add, sub, neg, mul, div, addint8, subint8, negint8, mulint8, divint8, addint16, subint16, negint16, mulint16, divint16, addint64, subint64, negint64, mulint64, divint64, adduint8, subuint8, muluint8, divuint8, adduint16, subuint16, muluint16, divuint16, adduint32, subuint32, muluint32, divuint32, adduint64, subuint64, muluint64, divuint64" -o arithmeticops_test_amd64.s -outfn "adds, subs, negs, muls, divs, addint8s, subint8s, negint8s, mulint8s, divint8s, addint16s, subint16s, negint16s, mulint16s, divint16s, addint64s, subint64s, negint64s, mulint64s, divint64s, adduint8s, subuint8s, muluint8s, divuint8s, adduint16s, subuint16s, muluint16s, divuint16s, adduint32s, subuint32s, muluint32s, divuint32s, adduint64s, subuint64s, muluint64s, divuint64s"
+// gensimd source: arithmeticops_test.go sha256:216b5b61cb33abda5f28c918c4a76de6af12742d530d6feb193b08224a37c7eb
+// gensimd target: avx2
+
+//go:build amd64 && !noasm && !appengine
+// +build amd64,!noasm,!appengine
 
 #include "textflag.h"
 
 TEXT ·adds(SB),$8-12
-        MOVL         $0, ret0+8(FP)
 block0:
-        MOVLQZX      x+0(FP), R14
+        // entry
+        MOVLQZX      x+0(FP), R15
         MOVLQZX      y+4(FP), R13
-        MOVL         R14, R15
-        ADDL         R13, R15
-        MOVL         R15, ret0+8(FP)
+        MOVL         R15, R12
+        ADDL         R13, R12
+        MOVL         R12, ret+8(FP)
         RET
 
 TEXT ·subs(SB),$8-12
-        MOVL         $0, ret0+8(FP)
 block0:
-        MOVLQZX      x+0(FP), R14
+        // entry
+        MOVLQZX      x+0(FP), R15
         MOVLQZX      y+4(FP), R13
-        MOVL         R14, R15
-        SUBL         R13, R15
-        MOVL         R15, ret0+8(FP)
+        MOVL         R15, R12
+        SUBL         R13, R12
+        MOVL         R12, ret+8(FP)
         RET
 
 TEXT ·negs(SB),$8-12
-        MOVL         $0, ret0+8(FP)
 block0:
-        MOVLQZX      x+0(FP), R13
-        XORQ         R14, R14
-        MOVL         R14, R15
-        SUBL         R13, R15
-        MOVL         R15, ret0+8(FP)
+        // entry
+        MOVLQZX      x+0(FP), R12
+        XORQ         R13, R13
+        MOVL         R13, R15
+        SUBL         R12, R15
+        MOVL         R15, ret+8(FP)
         RET
 
 TEXT ·muls(SB),$8-12
-        MOVL         $0, ret0+8(FP)
 block0:
-        MOVLQZX      x+0(FP), R14
+        // entry
+        MOVLQZX      x+0(FP), R15
         MOVLQZX      y+4(FP), R13
-        MOVL         R14, R15
-        MOVL         R15, AX
+        MOVL         R15, R12
+        MOVL         R12, AX
         IMULL        R13
-        MOVL         AX, R15
-        MOVL         R15, ret0+8(FP)
+        MOVL         AX, R12
+        MOVL         R12, ret+8(FP)
         RET
 
 TEXT ·divs(SB),$8-12
-        MOVL         $0, ret0+8(FP)
 block0:
-        MOVLQZX      x+0(FP), R14
+        // entry
+        MOVLQZX      x+0(FP), R15
         MOVLQZX      y+4(FP), R13
-        XORQ         AX, AX
+        MOVLQSX      R15, AX
+        MOVQ         AX, DX
+        SARQ         $63, DX
+        CMPL         R13, $-1
+        JNE          lbl1
+        NEGQ         AX
         XORQ         DX, DX
-        MOVL         R14, AX
+        JMP          lbl2
+lbl1:
         IDIVL        R13
-        MOVL         AX, R15
-        MOVL         R15, ret0+8(FP)
+lbl2:
+        MOVL         AX, R12
+        MOVL         R12, ret+8(FP)
         RET
 
 TEXT ·addint8s(SB),$8-9
-        MOVB         $0, ret0+8(FP)
 block0:
-        MOVBQZX      x+0(FP), R14
+        // entry
+        MOVBQZX      x+0(FP), R15
         MOVBQZX      y+1(FP), R13
-        MOVB         R14, R15
-        ADDB         R13, R15
-        MOVB         R15, ret0+8(FP)
+        MOVB         R15, R12
+        ADDB         R13, R12
+        MOVB         R12, ret+8(FP)
         RET
 
 TEXT ·subint8s(SB),$8-9
-        MOVB         $0, ret0+8(FP)
 block0:
-        MOVBQZX      x+0(FP), R14
+        // entry
+        MOVBQZX      x+0(FP), R15
         MOVBQZX      y+1(FP), R13
-        MOVB         R14, R15
-        SUBB         R13, R15
-        MOVB         R15, ret0+8(FP)
+        MOVB         R15, R12
+        SUBB         R13, R12
+        MOVB         R12, ret+8(FP)
         RET
 
 TEXT ·negint8s(SB),$8-9
-        MOVB         $0, ret0+8(FP)
 block0:
-        MOVBQZX      x+0(FP), R13
-        XORQ         R14, R14
-        MOVB         R14, R15
-        SUBB         R13, R15
-        MOVB         R15, ret0+8(FP)
+        // entry
+        MOVBQZX      x+0(FP), R12
+        XORQ         R13, R13
+        MOVB         R13, R15
+        SUBB         R12, R15
+        MOVB         R15, ret+8(FP)
         RET
 
 TEXT ·mulint8s(SB),$8-9
-        MOVB         $0, ret0+8(FP)
 block0:
-        MOVBQZX      x+0(FP), R14
+        // entry
+        MOVBQZX      x+0(FP), R15
         MOVBQZX      y+1(FP), R13
-        MOVB         R14, R15
-        MOVB         R15, AX
+        MOVB         R15, R12
+        MOVB         R12, AX
         IMULB        R13
-        MOVB         AX, R15
-        MOVB         R15, ret0+8(FP)
+        MOVB         AX, R12
+        MOVB         R12, ret+8(FP)
         RET
 
 TEXT ·divint8s(SB),$8-9
-        MOVB         $0, ret0+8(FP)
 block0:
-        MOVBQZX      x+0(FP), R14
+        // entry
+        MOVBQZX      x+0(FP), R15
         MOVBQZX      y+1(FP), R13
-        XORQ         AX, AX
-        MOVB         R14, AX
+        MOVBQSX      R15, AX
+        CMPB         R13, $-1
+        JNE          lbl1
+        NEGQ         AX
+        JMP          lbl2
+lbl1:
         IDIVB        R13
-        MOVB         AX, R15
-        MOVB         R15, ret0+8(FP)
+lbl2:
+        MOVB         AX, R12
+        MOVB         R12, ret+8(FP)
         RET
 
 TEXT ·addint16s(SB),$8-10
-        MOVW         $0, ret0+8(FP)
 block0:
-        MOVWQZX      x+0(FP), R14
+        // entry
+        MOVWQZX      x+0(FP), R15
         MOVWQZX      y+2(FP), R13
-        MOVW         R14, R15
-        ADDW         R13, R15
-        MOVW         R15, ret0+8(FP)
+        MOVW         R15, R12
+        ADDW         R13, R12
+        MOVW         R12, ret+8(FP)
         RET
 
 TEXT ·subint16s(SB),$8-10
-        MOVW         $0, ret0+8(FP)
 block0:
-        MOVWQZX      x+0(FP), R14
+        // entry
+        MOVWQZX      x+0(FP), R15
         MOVWQZX      y+2(FP), R13
-        MOVW         R14, R15
-        SUBW         R13, R15
-        MOVW         R15, ret0+8(FP)
+        MOVW         R15, R12
+        SUBW         R13, R12
+        MOVW         R12, ret+8(FP)
         RET
 
 TEXT ·negint16s(SB),$8-10
-        MOVW         $0, ret0+8(FP)
 block0:
-        MOVWQZX      x+0(FP), R13
-        XORQ         R14, R14
-        MOVW         R14, R15
-        SUBW         R13, R15
-        MOVW         R15, ret0+8(FP)
+        // entry
+        MOVWQZX      x+0(FP), R12
+        XORQ         R13, R13
+        MOVW         R13, R15
+        SUBW         R12, R15
+        MOVW         R15, ret+8(FP)
         RET
 
 TEXT ·mulint16s(SB),$8-10
-        MOVW         $0, ret0+8(FP)
 block0:
-        MOVWQZX      x+0(FP), R14
+        // entry
+        MOVWQZX      x+0(FP), R15
         MOVWQZX      y+2(FP), R13
-        MOVW         R14, R15
-        MOVW         R15, AX
+        MOVW         R15, R12
+        MOVW         R12, AX
         IMULW        R13
-        MOVW         AX, R15
-        MOVW         R15, ret0+8(FP)
+        MOVW         AX, R12
+        MOVW         R12, ret+8(FP)
         RET
 
 TEXT ·divint16s(SB),$8-10
-        MOVW         $0, ret0+8(FP)
 block0:
-        MOVWQZX      x+0(FP), R14
+        // entry
+        MOVWQZX      x+0(FP), R15
         MOVWQZX      y+2(FP), R13
-        XORQ         AX, AX
+        MOVWQSX      R15, AX
+        MOVQ         AX, DX
+        SARQ         $63, DX
+        CMPW         R13, $-1
+        JNE          lbl1
+        NEGQ         AX
         XORQ         DX, DX
-        MOVW         R14, AX
+        JMP          lbl2
+lbl1:
         IDIVW        R13
-        MOVW         AX, R15
-        MOVW         R15, ret0+8(FP)
+lbl2:
+        MOVW         AX, R12
+        MOVW         R12, ret+8(FP)
         RET
 
 TEXT ·addint64s(SB),$16-24
-        MOVQ         $0, ret0+16(FP)
 block0:
-        MOVQ         x+0(FP), R14
+        // entry
+        MOVQ         x+0(FP), R15
         MOVQ         y+8(FP), R13
-        MOVQ         R14, R15
-        ADDQ         R13, R15
-        MOVQ         R15, ret0+16(FP)
+        MOVQ         R15, R12
+        ADDQ         R13, R12
+        MOVQ         R12, ret+16(FP)
         RET
 
 TEXT ·subint64s(SB),$16-24
-        MOVQ         $0, ret0+16(FP)
 block0:
-        MOVQ         x+0(FP), R14
+        // entry
+        MOVQ         x+0(FP), R15
         MOVQ         y+8(FP), R13
-        MOVQ         R14, R15
-        SUBQ         R13, R15
-        MOVQ         R15, ret0+16(FP)
+        MOVQ         R15, R12
+        SUBQ         R13, R12
+        MOVQ         R12, ret+16(FP)
         RET
 
 TEXT ·negint64s(SB),$16-16
-        MOVQ         $0, ret0+8(FP)
 block0:
-        MOVQ         x+0(FP), R13
-        XORQ         R14, R14
-        MOVQ         R14, R15
-        SUBQ         R13, R15
-        MOVQ         R15, ret0+8(FP)
+        // entry
+        MOVQ         x+0(FP), R12
+        XORQ         R13, R13
+        MOVQ         R13, R15
+        SUBQ         R12, R15
+        MOVQ         R15, ret+8(FP)
         RET
 
 TEXT ·mulint64s(SB),$16-24
-        MOVQ         $0, ret0+16(FP)
 block0:
-        MOVQ         x+0(FP), R14
+        // entry
+        MOVQ         x+0(FP), R15
         MOVQ         y+8(FP), R13
-        MOVQ         R14, R15
-        MOVQ         R15, AX
+        MOVQ         R15, R12
+        MOVQ         R12, AX
         IMULQ        R13
-        MOVQ         AX, R15
-        MOVQ         R15, ret0+16(FP)
+        MOVQ         AX, R12
+        MOVQ         R12, ret+16(FP)
         RET
 
 TEXT ·divint64s(SB),$16-24
-        MOVQ         $0, ret0+16(FP)
 block0:
-        MOVQ         x+0(FP), R14
+        // entry
+        MOVQ         x+0(FP), R15
         MOVQ         y+8(FP), R13
-        XORQ         AX, AX
+        MOVQ         R15, AX
+        MOVQ         AX, DX
+        SARQ         $63, DX
+        CMPQ         R13, $-1
+        JNE          lbl1
+        NEGQ         AX
         XORQ         DX, DX
-        MOVQ         R14, AX
+        JMP          lbl2
+lbl1:
         IDIVQ        R13
-        MOVQ         AX, R15
-        MOVQ         R15, ret0+16(FP)
+lbl2:
+        MOVQ         AX, R12
+        MOVQ         R12, ret+16(FP)
         RET
 
 TEXT ·adduint8s(SB),$8-9
-        MOVB         $0, ret0+8(FP)
 block0:
-        MOVBQZX      x+0(FP), R14
+        // entry
+        MOVBQZX      x+0(FP), R15
         MOVBQZX      y+1(FP), R13
-        MOVB         R14, R15
-        ADDB         R13, R15
-        MOVB         R15, ret0+8(FP)
+        MOVB         R15, R12
+        ADDB         R13, R12
+        MOVB         R12, ret+8(FP)
         RET
 
 TEXT ·subuint8s(SB),$8-9
-        MOVB         $0, ret0+8(FP)
 block0:
-        MOVBQZX      x+0(FP), R14
+        // entry
+        MOVBQZX      x+0(FP), R15
         MOVBQZX      y+1(FP), R13
-        MOVB         R14, R15
-        SUBB         R13, R15
-        MOVB         R15, ret0+8(FP)
+        MOVB         R15, R12
+        SUBB         R13, R12
+        MOVB         R12, ret+8(FP)
         RET
 
 TEXT ·muluint8s(SB),$8-9
-        MOVB         $0, ret0+8(FP)
 block0:
-        MOVBQZX      x+0(FP), R14
+        // entry
+        MOVBQZX      x+0(FP), R15
         MOVBQZX      y+1(FP), R13
-        MOVB         R14, R15
-        MOVB         R15, AX
+        MOVB         R15, R12
+        MOVB         R12, AX
         MULB         R13
-        MOVB         AX, R15
-        MOVB         R15, ret0+8(FP)
+        MOVB         AX, R12
+        MOVB         R12, ret+8(FP)
         RET
 
 TEXT ·divuint8s(SB),$8-9
-        MOVB         $0, ret0+8(FP)
 block0:
-        MOVBQZX      x+0(FP), R14
+        // entry
+        MOVBQZX      x+0(FP), R15
         MOVBQZX      y+1(FP), R13
         XORQ         AX, AX
-        MOVB         R14, AX
+        MOVB         R15, AX
         DIVB         R13
-        MOVB         AX, R15
-        MOVB         R15, ret0+8(FP)
+        MOVB         AX, R12
+        MOVB         R12, ret+8(FP)
         RET
 
 TEXT ·adduint16s(SB),$8-10
-        MOVW         $0, ret0+8(FP)
 block0:
-        MOVWQZX      x+0(FP), R14
+        // entry
+        MOVWQZX      x+0(FP), R15
         MOVWQZX      y+2(FP), R13
-        MOVW         R14, R15
-        ADDW         R13, R15
-        MOVW         R15, ret0+8(FP)
+        MOVW         R15, R12
+        ADDW         R13, R12
+        MOVW         R12, ret+8(FP)
         RET
 
 TEXT ·subuint16s(SB),$8-10
-        MOVW         $0, ret0+8(FP)
 block0:
-        MOVWQZX      x+0(FP), R14
+        // entry
+        MOVWQZX      x+0(FP), R15
         MOVWQZX      y+2(FP), R13
-        MOVW         R14, R15
-        SUBW         R13, R15
-        MOVW         R15, ret0+8(FP)
+        MOVW         R15, R12
+        SUBW         R13, R12
+        MOVW         R12, ret+8(FP)
         RET
 
 TEXT ·muluint16s(SB),$8-10
-        MOVW         $0, ret0+8(FP)
 block0:
-        MOVWQZX      x+0(FP), R14
+        // entry
+        MOVWQZX      x+0(FP), R15
         MOVWQZX      y+2(FP), R13
-        MOVW         R14, R15
-        MOVW         R15, AX
+        MOVW         R15, R12
+        MOVW         R12, AX
         MULW         R13
-        MOVW         AX, R15
-        MOVW         R15, ret0+8(FP)
+        MOVW         AX, R12
+        MOVW         R12, ret+8(FP)
         RET
 
 TEXT ·divuint16s(SB),$8-10
-        MOVW         $0, ret0+8(FP)
 block0:
-        MOVWQZX      x+0(FP), R14
+        // entry
+        MOVWQZX      x+0(FP), R15
         MOVWQZX      y+2(FP), R13
         XORQ         AX, AX
         XORQ         DX, DX
-        MOVW         R14, AX
+        MOVW         R15, AX
         DIVW         R13
-        MOVW         AX, R15
-        MOVW         R15, ret0+8(FP)
+        MOVW         AX, R12
+        MOVW         R12, ret+8(FP)
         RET
 
 TEXT ·adduint32s(SB),$8-12
-        MOVL         $0, ret0+8(FP)
 block0:
-        MOVLQZX      x+0(FP), R14
+        // entry
+        MOVLQZX      x+0(FP), R15
         MOVLQZX      y+4(FP), R13
-        MOVL         R14, R15
-        ADDL         R13, R15
-        MOVL         R15, ret0+8(FP)
+        MOVL         R15, R12
+        ADDL         R13, R12
+        MOVL         R12, ret+8(FP)
         RET
 
 TEXT ·subuint32s(SB),$8-12
-        MOVL         $0, ret0+8(FP)
 block0:
-        MOVLQZX      x+0(FP), R14
+        // entry
+        MOVLQZX      x+0(FP), R15
         MOVLQZX      y+4(FP), R13
-        MOVL         R14, R15
-        SUBL         R13, R15
-        MOVL         R15, ret0+8(FP)
+        MOVL         R15, R12
+        SUBL         R13, R12
+        MOVL         R12, ret+8(FP)
         RET
 
 TEXT ·muluint32s(SB),$8-12
-        MOVL         $0, ret0+8(FP)
 block0:
-        MOVLQZX      x+0(FP), R14
+        // entry
+        MOVLQZX      x+0(FP), R15
         MOVLQZX      y+4(FP), R13
-        MOVL         R14, R15
-        MOVL         R15, AX
+        MOVL         R15, R12
+        MOVL         R12, AX
         MULL         R13
-        MOVL         AX, R15
-        MOVL         R15, ret0+8(FP)
+        MOVL         AX, R12
+        MOVL         R12, ret+8(FP)
         RET
 
 TEXT ·divuint32s(SB),$8-12
-        MOVL         $0, ret0+8(FP)
 block0:
-        MOVLQZX      x+0(FP), R14
+        // entry
+        MOVLQZX      x+0(FP), R15
         MOVLQZX      y+4(FP), R13
         XORQ         AX, AX
         XORQ         DX, DX
-        MOVL         R14, AX
+        MOVL         R15, AX
         DIVL         R13
-        MOVL         AX, R15
-        MOVL         R15, ret0+8(FP)
+        MOVL         AX, R12
+        MOVL         R12, ret+8(FP)
         RET
 
 TEXT ·adduint64s(SB),$16-24
-        MOVQ         $0, ret0+16(FP)
 block0:
-        MOVQ         x+0(FP), R14
+        // entry
+        MOVQ         x+0(FP), R15
         MOVQ         y+8(FP), R13
-        MOVQ         R14, R15
-        ADDQ         R13, R15
-        MOVQ         R15, ret0+16(FP)
+        MOVQ         R15, R12
+        ADDQ         R13, R12
+        MOVQ         R12, ret+16(FP)
         RET
 
 TEXT ·subuint64s(SB),$16-24
-        MOVQ         $0, ret0+16(FP)
 block0:
-        MOVQ         x+0(FP), R14
+        // entry
+        MOVQ         x+0(FP), R15
         MOVQ         y+8(FP), R13
-        MOVQ         R14, R15
-        SUBQ         R13, R15
-        MOVQ         R15, ret0+16(FP)
+        MOVQ         R15, R12
+        SUBQ         R13, R12
+        MOVQ         R12, ret+16(FP)
         RET
 
 TEXT ·muluint64s(SB),$16-24
-        MOVQ         $0, ret0+16(FP)
 block0:
-        MOVQ         x+0(FP), R14
+        // entry
+        MOVQ         x+0(FP), R15
         MOVQ         y+8(FP), R13
-        MOVQ         R14, R15
-        MOVQ         R15, AX
+        MOVQ         R15, R12
+        MOVQ         R12, AX
         MULQ         R13
-        MOVQ         AX, R15
-        MOVQ         R15, ret0+16(FP)
+        MOVQ         AX, R12
+        MOVQ         R12, ret+16(FP)
         RET
 
 TEXT ·divuint64s(SB),$16-24
-        MOVQ         $0, ret0+16(FP)
 block0:
-        MOVQ         x+0(FP), R14
+        // entry
+        MOVQ         x+0(FP), R15
         MOVQ         y+8(FP), R13
         XORQ         AX, AX
         XORQ         DX, DX
-        MOVQ         R14, AX
+        MOVQ         R15, AX
         DIVQ         R13
-        MOVQ         AX, R15
-        MOVQ         R15, ret0+16(FP)
+        MOVQ         AX, R12
+        MOVQ         R12, ret+16(FP)
         RET
 
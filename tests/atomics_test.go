@@ -0,0 +1,57 @@
+// +build amd64,gc
+
+package tests
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/bjwbell/gensimd/codegen"
+	"github.com/bjwbell/gensimd/internal/asmtest"
+)
+
+// TestAtomics runs loops updating shared counters with sync/atomic and the
+// simd atomics from several goroutines.
+func TestAtomics(t *testing.T) {
+	kernels := asmtest.Build(t, "testdata/atomics.go", codegen.DefaultOptions(), "count", "histogram", "claim", "publish")
+	b := make([]byte, 1000)
+	for i := range b {
+		b[i] = byte(i)
+	}
+	const goroutines = 8
+	var n uint64
+	hist := make([]uint32, 4)
+	var wg sync.WaitGroup
+	for g := 0; g < goroutines; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			kernels.Call(t, "count", b, byte(7), &n)
+			kernels.Call(t, "histogram", b, hist)
+		}()
+	}
+	wg.Wait()
+	// 7 is 4 of the 1000 bytes, 7, 263, 519, and 775
+	if n != 4*goroutines {
+		t.Errorf("count total %v, expected %v", n, 4*goroutines)
+	}
+	for i, h := range hist {
+		if h != 250*goroutines {
+			t.Errorf("histogram[%v] = %v, expected %v", i, h, 250*goroutines)
+		}
+	}
+
+	var owner int64
+	if !kernels.Call(t, "claim", &owner, int64(3))[0].(bool) || owner != 3 {
+		t.Errorf("claim of unowned failed, owner %v", owner)
+	}
+	if kernels.Call(t, "claim", &owner, int64(4))[0].(bool) || owner != 3 {
+		t.Errorf("claim of owned succeeded, owner %v", owner)
+	}
+
+	var p uint64
+	q := uint32(9)
+	if got := kernels.Call(t, "publish", &p, uint64(1)<<40, &q)[0].(uint32); got != 9 || p != 1<<40 {
+		t.Errorf("publish = %v, p %v, expected 9 and %v", got, p, uint64(1)<<40)
+	}
+}
@@ -0,0 +1,29 @@
+// +build amd64,gc
+
+package tests
+
+import (
+	"testing"
+
+	"github.com/bjwbell/gensimd/codegen"
+	"github.com/bjwbell/gensimd/internal/asmtest"
+)
+
+// TestBarrier runs a ring buffer writer with simd.Fence and a reader with
+// simd.CompilerBarrier between byte loads.
+func TestBarrier(t *testing.T) {
+	kernels := asmtest.Build(t, "testdata/barrier.go", codegen.DefaultOptions(), "push", "read")
+	ring := make([]uint32, 4)
+	head := []uint64{0}
+	for i := uint32(1); i <= 6; i++ {
+		if got := kernels.Call(t, "push", ring, head, i*10)[0].(uint64); got != uint64(i) {
+			t.Errorf("push(%v) = %v, expected %v", i*10, got, i)
+		}
+	}
+	if expected := []uint32{50, 60, 30, 40}; ring[0] != expected[0] || ring[1] != expected[1] || ring[2] != expected[2] || ring[3] != expected[3] {
+		t.Errorf("ring %v, expected %v", ring, expected)
+	}
+	if got := kernels.Call(t, "read", []byte{1, 2, 3, 4})[0].(uint32); got != 0x04030201 {
+		t.Errorf("read = %#x, expected 0x4030201", got)
+	}
+}
@@ -1,56 +1,62 @@
-// +build amd64 !noasm !appengine
+// Code generated by gensimd. DO NOT EDIT.
+// gensimd version: devel
+// gensimd command: gensimd -f basicUint8_test.go -fn "uint8_t0, uint8_t1, uint8_t2, uint8_t3, uint8_t4" -o basicUint8_test_amd64.s -outfn "uint8_t0_simd, uint8_t1_simd, uint8_t2_simd, uint8_t3_simd, uint8_t4_simd"
+// gensimd source: basicUint8_test.go sha256:bba8d00114c1b7e8e091dfca4d54f995b0b29b9a6d2e1822aa8e6d30bf24dca0
+// gensimd target: avx2
+
+//go:build amd64 && !noasm && !appengine
+// +build amd64,!noasm,!appengine
 
 #include "textflag.h"
 
 TEXT ·uint8_t0_simd(SB),$8-9
-        MOVB         $0, ret0+8(FP)
 block0:
+        // entry
         MOVBQZX      x+0(FP), R15
-        MOVB         R15, ret0+8(FP)
+        MOVB         R15, ret+8(FP)
         RET
 
 TEXT ·uint8_t1_simd(SB),$8-9
-        MOVB         $0, ret0+8(FP)
 block0:
-        MOVBQZX      x+0(FP), R14
-        MOVB         $1, R13
-        MOVB         R14, R15
-        ADDB         R13, R15
-        MOVB         R15, ret0+8(FP)
+        // entry
+        MOVBQZX      x+0(FP), R15
+        MOVB         R15, R13
+        ADDB         $1, R13
+        MOVB         R13, ret+8(FP)
         RET
 
 TEXT ·uint8_t2_simd(SB),$8-9
-        MOVB         $0, ret0+8(FP)
 block0:
-        MOVBQZX      x+0(FP), R14
+        // entry
+        MOVBQZX      x+0(FP), R15
         MOVB         $2, R13
-        MOVB         R14, R15
-        MOVB         R15, AX
+        MOVB         R15, R12
+        MOVB         R12, AX
         MULB         R13
-        MOVB         AX, R15
-        MOVB         R15, ret0+8(FP)
+        MOVB         AX, R12
+        MOVB         R12, ret+8(FP)
         RET
 
 TEXT ·uint8_t3_simd(SB),$8-9
-        MOVB         $0, ret0+8(FP)
 block0:
-        MOVBQZX      x+0(FP), R14
+        // entry
+        MOVBQZX      x+0(FP), R15
         MOVB         $3, R13
         XORQ         AX, AX
-        MOVB         R14, AX
+        MOVB         R15, AX
         DIVB         R13
-        MOVB         AX, R15
-        MOVB         R15, ret0+8(FP)
+        MOVB         AX, R12
+        MOVB         R12, ret+8(FP)
         RET
 
 TEXT ·uint8_t4_simd(SB),$8-9
-        MOVB         $0, ret0+8(FP)
 block0:
-        MOVBQZX      x+0(FP), R14
-        MOVB         R14, R15
-        MOVB         R15, AX
-        MULB         R14
-        MOVB         AX, R15
-        MOVB         R15, ret0+8(FP)
+        // entry
+        MOVBQZX      x+0(FP), R15
+        MOVB         R15, R13
+        MOVB         R13, AX
+        MULB         R15
+        MOVB         AX, R13
+        MOVB         R13, ret+8(FP)
         RET
 
@@ -1,39 +1,46 @@
-// +build amd64 !noasm !appengine
+// Code generated by gensimd. DO NOT EDIT.
+// gensimd version: devel
+// gensimd command: gensimd -f basic_test.go -fn "test0, test1, test2, test3, test4" -o basic_test_amd64.s -outfn "t0simd, t1simd,t2simd,t3simd,t4simd"
+// gensimd source: basic_test.go sha256:3f89fae11c49dbe6a533e25db36a11f00248fa2f6c46ac4217d8fdac121b1654
+// gensimd target: avx2
+
+//go:build amd64 && !noasm && !appengine
+// +build amd64,!noasm,!appengine
 
 #include "textflag.h"
 
 TEXT ·t0simd(SB),$8-8
-        MOVQ         $0, ret0+0(FP)
 block0:
+        // entry
         MOVQ         $0, R15
-        MOVQ         R15, ret0+0(FP)
+        MOVQ         R15, ret+0(FP)
         RET
 
 TEXT ·t1simd(SB),$8-8
-        MOVQ         $0, ret0+0(FP)
 block0:
+        // entry
         MOVQ         $1, R15
-        MOVQ         R15, ret0+0(FP)
+        MOVQ         R15, ret+0(FP)
         RET
 
 TEXT ·t2simd(SB),$8-8
-        MOVQ         $0, ret0+0(FP)
 block0:
+        // entry
         MOVQ         $2, R15
-        MOVQ         R15, ret0+0(FP)
+        MOVQ         R15, ret+0(FP)
         RET
 
 TEXT ·t3simd(SB),$8-8
-        MOVQ         $0, ret0+0(FP)
 block0:
+        // entry
         MOVQ         $256, R15
-        MOVQ         R15, ret0+0(FP)
+        MOVQ         R15, ret+0(FP)
         RET
 
 TEXT ·t4simd(SB),$8-8
-        MOVQ         $0, ret0+0(FP)
 block0:
+        // entry
         MOVQ         $9223372036854775807, R15
-        MOVQ         R15, ret0+0(FP)
+        MOVQ         R15, ret+0(FP)
         RET
 
@@ -0,0 +1,202 @@
+// Code generated by gensimd. DO NOT EDIT.
+// gensimd version: devel
+// gensimd command: gensimd -f bitloop_test.go -fn "bitloopt0, bitloopt1, bitloopt2, bitloopt3" -o bitloop_bsf_test_amd64.s -outfn "bitloopt0b, bitloopt1b, bitloopt2b, bitloopt3b" -target sse4.1
+// gensimd source: bitloop_test.go sha256:75e8aff844faf242905dac5483a2bc239e8157f5776639e7ca13c9555ecf772c
+// gensimd target: sse4.1
+
+//go:build amd64 && !noasm && !appengine
+// +build amd64,!noasm,!appengine
+
+#include "textflag.h"
+
+TEXT ·bitloopt0b(SB),$168-40
+block0:
+        // entry
+        MOVBQZX      c+24(FP), R15
+        MOVBQZX      R15, R13
+        IMUL3Q       $16843009, R13, R13
+        MOVQ         R13, X14
+        PSHUFL       $0, X14, X14
+        MOVO         X14, X0
+        MOVQ         s_len+8(FP), R13
+        MOVQ         R13, R12
+        MOVQ         R12, R9
+        MOVQ         $0, R11
+        MOVQ         R11, t1-24(SP)
+        MOVQ         R11, t2-32(SP)
+        MOVQ         R12, t4-48(SP)
+        MOVOU        X14, t0-16(SP)
+block1:
+        // for.loop, preds block0 block5
+        MOVQ         t2-32(SP), R8
+        MOVQ         R8, R15
+        MOVQ         R15, R13
+        ADDQ         $16, R13
+        MOVQ         R9, R12
+        CMPQ         R13, R12
+        JGT          block3
+block2:
+        // for.body, preds block1
+        MOVQ         s_base+0(FP), R15
+        MOVQ         R8, R13
+        MOVOU        (R15)(R13*1), X14
+        MOVO         X0, X13
+        MOVO         X14, X12
+        PCMPEQB      X13, X12
+        PMOVMSKB     X12, R12
+        MOVQ         t1-24(SP), R11
+        MOVQ         R11, t16-153(SP)
+        MOVQ         R12, t17-161(SP)
+        MOVQ         t16-153(SP), BX
+        MOVQ         R12, t8-89(SP)
+block6:
+        // for.loop, preds block2 block4
+        MOVQ         t17-161(SP), R15
+        CMPQ         R15, $0
+        JEQ          block5
+block4:
+        // for.body, preds block6
+        MOVQ         t17-161(SP), R15
+        MOVQ         R15, R13
+        BSFQ         R13, R12
+        MOVQ         R8, R11
+        MOVQ         R11, R10
+        ADDQ         R12, R10
+        MOVQ         BX, BP
+        MOVQ         BP, DI
+        ADDQ         R10, DI
+        MOVQ         DI, BX
+        MOVQ         R15, SI
+        SUBQ         $1, SI
+        MOVQ         SI, DI
+        ANDQ         R15, DI
+        MOVQ         DI, t17-161(SP)
+        MOVQ         DI, t14-137(SP)
+        JMP block6
+block3:
+        // for.done, preds block1
+        MOVQ         t1-24(SP), R15
+        MOVQ         R15, ret+32(FP)
+        RET
+block5:
+        // for.done, preds block6
+        MOVQ         R8, R15
+        MOVQ         R15, R13
+        ADDQ         $16, R13
+        MOVQ         BX, R12
+        MOVQ         R12, t1-24(SP)
+        MOVQ         R13, t2-32(SP)
+        MOVQ         R13, t15-145(SP)
+        JMP block1
+
+TEXT ·bitloopt1b(SB),$80-16
+block0:
+        // entry
+        MOVQ         mask+0(FP), R15
+        MOVQ         R15, t7-64(SP)
+        MOVQ         $0, R13
+        MOVQ         R13, t8-72(SP)
+        MOVQ         t8-72(SP), BX
+block3:
+        // for.loop, preds block0 block1
+        MOVQ         t7-64(SP), R15
+        CMPQ         R15, $0
+        JEQ          block2
+block1:
+        // for.body, preds block3
+        MOVQ         t7-64(SP), R15
+        BSFQ         R15, R13
+        MOVQ         $63, R12
+        MOVQ         R12, R11
+        SUBQ         R13, R11
+        MOVQ         R11, R10
+        MOVQ         $1, R9
+        MOVQ         R9, R8
+        MOVQ         R10, CX
+        MOVL         $63, BP
+        CMPQ         R10, $64
+        CMOVQCC      BP, CX
+        MOVBQZX      CL, CX
+        SHLQ         CX, R8
+        MOVL         $1, BP
+        XORQ         CX, CX
+        CMPQ         R10, $64
+        CMOVQCC      BP, CX
+        MOVBQZX      CL, CX
+        SHLQ         CX, R8
+        MOVQ         BX, BP
+        MOVQ         R8, DI
+        ORQ          BP, DI
+        MOVQ         DI, BX
+        MOVQ         R15, SI
+        SUBQ         $1, SI
+        ANDQ         R15, SI
+        MOVQ         SI, t7-64(SP)
+        MOVQ         SI, t6-56(SP)
+        JMP block3
+block2:
+        // for.done, preds block3
+        MOVQ         BX, R15
+        MOVQ         R15, ret+8(FP)
+        RET
+
+TEXT ·bitloopt2b(SB),$40-12
+block0:
+        // entry
+        MOVLQZX      mask+0(FP), R15
+        MOVL         R15, t0-4(SP)
+        MOVL         $0, R13
+        MOVL         R13, t1-8(SP)
+block1:
+        // for.body, preds block0 block3
+        MOVLQZX      t0-4(SP), R15
+        CMPL         R15, $0
+        JEQ          block2
+block3:
+        // if.done, preds block1
+        MOVLQZX      t1-8(SP), R15
+        MOVL         $31, R13
+        MOVL         R15, R12
+        MOVL         R12, AX
+        MULL         R13
+        MOVL         AX, R12
+        MOVLQZX      t0-4(SP), R11
+        MOVLQZX      R11, R10
+        BSFQ         R10, R10
+        MOVL         R10, R9
+        ADDL         R9, R12
+        MOVL         R11, R8
+        SUBL         $1, R8
+        MOVL         R8, R9
+        ANDL         R11, R9
+        MOVL         R9, t0-4(SP)
+        MOVL         R12, t1-8(SP)
+        MOVL         R9, t8-37(SP)
+        MOVL         R12, t6-29(SP)
+        JMP block1
+block2:
+        // if.then, preds block1
+        MOVLQZX      t1-8(SP), R15
+        MOVL         R15, ret+8(FP)
+        RET
+
+TEXT ·bitloopt3b(SB),$72-16
+block0:
+        // entry
+        MOVQ         x+0(FP), R15
+        MOVQ         R15, R13
+        SUBQ         $1, R13
+        MOVQ         R13, R12
+        ANDQ         R15, R12
+        MOVQ         R15, R11
+        SUBQ         $1, R11
+        SHLQ         $1, R11
+        XORQ         R11, R12
+        MOVQ         $64, R10
+        BSFQ         R15, R9
+        CMOVQNE      R9, R10
+        MOVQ         R10, R9
+        ADDQ         R9, R12
+        MOVQ         R12, ret+8(FP)
+        RET
+
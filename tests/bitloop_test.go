@@ -0,0 +1,101 @@
+// +build amd64,gc
+
+package tests
+
+import (
+	"math/bits"
+	"testing"
+
+	"github.com/bjwbell/gensimd/simd"
+)
+
+//go:generate gensimd -fn "bitloopt0, bitloopt1, bitloopt2, bitloopt3" -outfn "bitloopt0s, bitloopt1s, bitloopt2s, bitloopt3s" -f "$GOFILE" -o "bitloop_test_amd64.s"
+//go:generate gensimd -target sse4.1 -fn "bitloopt0, bitloopt1, bitloopt2, bitloopt3" -outfn "bitloopt0b, bitloopt1b, bitloopt2b, bitloopt3b" -f "$GOFILE" -o "bitloop_bsf_test_amd64.s"
+
+// TZCNT and BLSR, the default avx2 target has them
+func bitloopt0s(s []byte, c byte) int
+func bitloopt1s(mask uint64) uint64
+func bitloopt2s(mask uint32) uint32
+func bitloopt3s(x uint64) uint64
+
+// BSF, and SUB and AND
+func bitloopt0b(s []byte, c byte) int
+func bitloopt1b(mask uint64) uint64
+func bitloopt2b(mask uint32) uint32
+func bitloopt3b(x uint64) uint64
+
+// the sum of the indices of the bytes equal to c
+func bitloopt0(s []byte, c byte) int {
+	v := simd.SplatU8x16(c)
+	sum := 0
+	for i := 0; i+16 <= len(s); i += 16 {
+		mask := simd.MoveMaskU8x16(simd.CmpEqU8x16(simd.LoadU8x16(s, i), v))
+		for mask != 0 {
+			sum += i + bits.TrailingZeros(uint(mask))
+			mask &= mask - 1
+		}
+	}
+	return sum
+}
+
+// the bits of mask in reverse order
+func bitloopt1(mask uint64) uint64 {
+	r := uint64(0)
+	for mask != 0 {
+		r |= 1 << uint(63-bits.TrailingZeros64(mask))
+		mask = (mask - 1) & mask
+	}
+	return r
+}
+
+// the loop condition as a break
+func bitloopt2(mask uint32) uint32 {
+	r := uint32(0)
+	for {
+		if mask == 0 {
+			break
+		}
+		r = r*31 + uint32(bits.TrailingZeros32(mask))
+		mask &= mask - 1
+	}
+	return r
+}
+
+// x - 1 is also used by the xor, and x may be zero
+func bitloopt3(x uint64) uint64 {
+	return x&(x-1) ^ (x-1)<<1 + uint64(bits.TrailingZeros64(x))
+}
+
+func TestBitLoop(t *testing.T) {
+	type funcs struct {
+		name string
+		t0   func([]byte, byte) int
+		t1   func(uint64) uint64
+		t2   func(uint32) uint32
+		t3   func(uint64) uint64
+	}
+	tests := []funcs{{"bsf", bitloopt0b, bitloopt1b, bitloopt2b, bitloopt3b}}
+	if simd.HasTarget("avx2") {
+		tests = append(tests, funcs{"tzcnt", bitloopt0s, bitloopt1s, bitloopt2s, bitloopt3s})
+	}
+	s := []byte("abracadabra, alakazam, abracadabra, alakazam!!!!!!!!!!!!")
+	masks := []uint64{0, 1, 2, 0x8000000000000000, 0xffffffffffffffff, 0x00000000ffffffff, 0xdeadbeef00000000, 0x0123456789abcdef}
+	for _, fns := range tests {
+		for _, c := range []byte("a!bz") {
+			if got, expected := fns.t0(s, c), bitloopt0(s, c); got != expected {
+				t.Errorf("%v t0(%q) %v != %v", fns.name, c, got, expected)
+			}
+		}
+		for _, m := range masks {
+			if got, expected := fns.t1(m), bitloopt1(m); got != expected {
+				t.Errorf("%v t1(%#x) %#x != %#x", fns.name, m, got, expected)
+			}
+			if got, expected := fns.t2(uint32(m)), bitloopt2(uint32(m)); got != expected {
+				t.Errorf("%v t2(%#x) %#x != %#x", fns.name, uint32(m), got, expected)
+			}
+			if got, expected := fns.t3(m), bitloopt3(m); got != expected {
+				t.Errorf("%v t3(%#x) %#x != %#x", fns.name, m, got, expected)
+			}
+		}
+	}
+}
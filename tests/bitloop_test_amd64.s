@@ -0,0 +1,180 @@
+// Code generated by gensimd. DO NOT EDIT.
+// gensimd version: devel
+// gensimd command: gensimd -f bitloop_test.go -fn "bitloopt0, bitloopt1, bitloopt2, bitloopt3" -o bitloop_test_amd64.s -outfn "bitloopt0s, bitloopt1s, bitloopt2s, bitloopt3s"
+// gensimd source: bitloop_test.go sha256:75e8aff844faf242905dac5483a2bc239e8157f5776639e7ca13c9555ecf772c
+// gensimd target: avx2
+
+//go:build amd64 && !noasm && !appengine
+// +build amd64,!noasm,!appengine
+
+#include "textflag.h"
+
+TEXT ·bitloopt0s(SB),$160-40
+block0:
+        // entry
+        MOVBQZX      c+24(FP), R15
+        MOVBQZX      R15, R13
+        IMUL3Q       $16843009, R13, R13
+        MOVQ         R13, X14
+        PSHUFL       $0, X14, X14
+        MOVO         X14, X0
+        MOVQ         s_len+8(FP), R13
+        MOVQ         R13, R12
+        MOVQ         R12, R9
+        MOVQ         $0, R11
+        MOVQ         R11, t1-24(SP)
+        MOVQ         R11, t2-32(SP)
+        MOVQ         R12, t4-48(SP)
+        MOVOU        X14, t0-16(SP)
+block1:
+        // for.loop, preds block0 block5
+        MOVQ         t2-32(SP), R8
+        MOVQ         R8, R15
+        MOVQ         R15, R13
+        ADDQ         $16, R13
+        MOVQ         R9, R12
+        CMPQ         R13, R12
+        JGT          block3
+block2:
+        // for.body, preds block1
+        MOVQ         s_base+0(FP), R15
+        MOVQ         R8, R13
+        MOVOU        (R15)(R13*1), X14
+        MOVO         X0, X13
+        MOVO         X14, X12
+        PCMPEQB      X13, X12
+        PMOVMSKB     X12, R12
+        MOVQ         t1-24(SP), R11
+        MOVQ         R11, t16-145(SP)
+        MOVQ         R12, t17-153(SP)
+        MOVQ         t16-145(SP), BX
+        MOVQ         R12, t8-89(SP)
+block6:
+        // for.loop, preds block2 block4
+        MOVQ         t17-153(SP), R15
+        CMPQ         R15, $0
+        JEQ          block5
+block4:
+        // for.body, preds block6
+        MOVQ         t17-153(SP), R15
+        MOVQ         R15, R13
+        TZCNTQ       R13, R12
+        MOVQ         R8, R11
+        MOVQ         R11, R10
+        ADDQ         R12, R10
+        MOVQ         BX, BP
+        MOVQ         BP, DI
+        ADDQ         R10, DI
+        MOVQ         DI, BX
+        BLSRQ        R15, SI
+        MOVQ         SI, t17-153(SP)
+        MOVQ         SI, t14-129(SP)
+        JMP block6
+block3:
+        // for.done, preds block1
+        MOVQ         t1-24(SP), R15
+        MOVQ         R15, ret+32(FP)
+        RET
+block5:
+        // for.done, preds block6
+        MOVQ         R8, R15
+        MOVQ         R15, R13
+        ADDQ         $16, R13
+        MOVQ         BX, R12
+        MOVQ         R12, t1-24(SP)
+        MOVQ         R13, t2-32(SP)
+        MOVQ         R13, t15-137(SP)
+        JMP block1
+
+TEXT ·bitloopt1s(SB),$72-16
+block0:
+        // entry
+        MOVQ         mask+0(FP), R15
+        MOVQ         R15, t7-56(SP)
+        MOVQ         $0, R13
+        MOVQ         R13, t8-64(SP)
+        MOVQ         t8-64(SP), BX
+block3:
+        // for.loop, preds block0 block1
+        MOVQ         t7-56(SP), R15
+        CMPQ         R15, $0
+        JEQ          block2
+block1:
+        // for.body, preds block3
+        MOVQ         t7-56(SP), R15
+        TZCNTQ       R15, R13
+        MOVQ         $63, R12
+        MOVQ         R12, R11
+        SUBQ         R13, R11
+        MOVQ         R11, R10
+        MOVQ         $1, R9
+        XORQ         BP, BP
+        CMPQ         R10, $64
+        SHLXQ        R10, R9, R8
+        CMOVQCC      BP, R8
+        MOVQ         BX, BP
+        MOVQ         R8, DI
+        ORQ          BP, DI
+        MOVQ         DI, BX
+        BLSRQ        R15, SI
+        MOVQ         SI, t7-56(SP)
+        MOVQ         SI, t6-48(SP)
+        JMP block3
+block2:
+        // for.done, preds block3
+        MOVQ         BX, R15
+        MOVQ         R15, ret+8(FP)
+        RET
+
+TEXT ·bitloopt2s(SB),$40-12
+block0:
+        // entry
+        MOVLQZX      mask+0(FP), R15
+        MOVL         R15, t0-4(SP)
+        MOVL         $0, R13
+        MOVL         R13, t1-8(SP)
+block1:
+        // for.body, preds block0 block3
+        MOVLQZX      t0-4(SP), R15
+        CMPL         R15, $0
+        JEQ          block2
+block3:
+        // if.done, preds block1
+        MOVLQZX      t1-8(SP), R15
+        MOVL         $31, R13
+        MOVL         R15, R12
+        MOVL         R12, AX
+        MULL         R13
+        MOVL         AX, R12
+        MOVLQZX      t0-4(SP), R11
+        MOVLQZX      R11, R10
+        TZCNTQ       R10, R10
+        MOVL         R10, R9
+        ADDL         R9, R12
+        BLSRL        R11, R8
+        MOVL         R8, t0-4(SP)
+        MOVL         R12, t1-8(SP)
+        MOVL         R8, t8-33(SP)
+        MOVL         R12, t6-29(SP)
+        JMP block1
+block2:
+        // if.then, preds block1
+        MOVLQZX      t1-8(SP), R15
+        MOVL         R15, ret+8(FP)
+        RET
+
+TEXT ·bitloopt3s(SB),$64-16
+block0:
+        // entry
+        MOVQ         x+0(FP), R15
+        BLSRQ        R15, R13
+        MOVQ         R15, R12
+        SUBQ         $1, R12
+        SHLQ         $1, R12
+        XORQ         R12, R13
+        TZCNTQ       R15, R11
+        MOVQ         R11, R10
+        ADDQ         R10, R13
+        MOVQ         R13, ret+8(FP)
+        RET
+
@@ -1,684 +1,619 @@
-// +build amd64 !noasm !appengine
+// Code generated by gensimd. DO NOT EDIT.
+// gensimd version: devel
+// gensimd command: gensimd -f bitwiseops_test.go -fn "oruint8, anduint8, xoruint8, notuint8, andnotuint8, shluint8, shruint8, oruint16, anduint16, xoruint16, notuint16, andnotuint16, shluint16, shruint16, oruint32, anduint32, xoruint32, notuint32, andnotuint32, shluint32, shruint32, oruint64, anduint64, xoruint64, notuint64, andnotuint64, shluint64, shruint64, orint8, andint8, xorint8, notint8, andnotint8, shlint8, shrint8, orint16, andint16, xorint16, notint16, andnotint16, shlint16, shrint16, orint32, andint32, xorint32, notint32, andnotint32, shlint32, shrint32, orint64, andint64, xorint64, notint64, andnotint64, shlint64, shrint64" -o bitwiseops_test_amd64.s -outfn "oruint8s, anduint8s, xoruint8s, notuint8s, andnotuint8s, shluint8s, shruint8s, oruint16s, anduint16s, xoruint16s, notuint16s, andnotuint16s, shluint16s, shruint16s, oruint32s, anduint32s, xoruint32s, notuint32s, andnotuint32s, shluint32s, shruint32s, oruint64s, anduint64s, xoruint64s, notuint64s, andnotuint64s, shluint64s, shruint64s, orint8s, andint8s, xorint8s, notint8s, andnotint8s, shlint8s, shrint8s, orint16s, andint16s, xorint16s, notint16s, andnotint16s, shlint16s, shrint16s, orint32s, andint32s, xorint32s, notint32s, andnotint32s, shlint32s, shrint32s, orint64s, andint64s, xorint64s, notint64s, andnotint64s, shlint64s, shrint64s"
+// gensimd source: bitwiseops_test.go sha256:3f4e3a84f482bee0f512bd55409a4d08e665bdefedf2be7d274af21ccb9ef8f9
+// gensimd target: avx2
+
+//go:build amd64 && !noasm && !appengine
+// +build amd64,!noasm,!appengine
 
 #include "textflag.h"
 
 TEXT ·oruint8s(SB),$8-9
-        MOVB         $0, ret0+8(FP)
 block0:
-        MOVBQZX      a+0(FP), R14
+        // entry
+        MOVBQZX      a+0(FP), R15
         MOVBQZX      b+1(FP), R13
-        MOVB         R13, R15
-        ORQ          R14, R15
-        MOVB         R15, ret0+8(FP)
+        MOVB         R13, R12
+        ORQ          R15, R12
+        MOVB         R12, ret+8(FP)
         RET
 
 TEXT ·anduint8s(SB),$8-9
-        MOVB         $0, ret0+8(FP)
 block0:
-        MOVBQZX      a+0(FP), R14
+        // entry
+        MOVBQZX      a+0(FP), R15
         MOVBQZX      b+1(FP), R13
-        MOVB         R13, R15
-        ANDB         R14, R15
-        MOVB         R15, ret0+8(FP)
+        MOVB         R13, R12
+        ANDB         R15, R12
+        MOVB         R12, ret+8(FP)
         RET
 
 TEXT ·xoruint8s(SB),$8-9
-        MOVB         $0, ret0+8(FP)
 block0:
-        MOVBQZX      a+0(FP), R14
+        // entry
+        MOVBQZX      a+0(FP), R15
         MOVBQZX      b+1(FP), R13
-        MOVB         R13, R15
-        XORQ         R14, R15
-        MOVB         R15, ret0+8(FP)
+        MOVB         R13, R12
+        XORQ         R15, R12
+        MOVB         R12, ret+8(FP)
         RET
 
 TEXT ·notuint8s(SB),$8-9
-        MOVB         $0, ret0+8(FP)
 block0:
+        // entry
         MOVBQZX      a+0(FP), R15
         XORQ         $-1, R15
-        MOVB         R15, ret0+8(FP)
+        MOVB         R15, ret+8(FP)
         RET
 
 TEXT ·andnotuint8s(SB),$8-9
-        MOVB         $0, ret0+8(FP)
 block0:
-        MOVBQZX      a+0(FP), R14
+        // entry
+        MOVBQZX      a+0(FP), R15
         MOVBQZX      b+1(FP), R13
-        MOVB         R13, R15
-        XORB         $-1, R15
-        ANDB         R14, R15
-        MOVB         R15, ret0+8(FP)
+        MOVB         R13, R12
+        XORB         $-1, R12
+        ANDB         R15, R12
+        MOVB         R12, ret+8(FP)
         RET
 
 TEXT ·shluint8s(SB),$8-9
-        MOVB         $0, ret0+8(FP)
 block0:
-        MOVBQZX      x+0(FP), R14
+        // entry
+        MOVBQZX      x+0(FP), R15
         MOVBQZX      shift+1(FP), R13
-        MOVB         R14, R15
+        MOVB         R15, R12
         MOVB         R13, CX
-        MOVL         $8, R14
+        MOVL         $8, R11
         CMPB         R13, $8
-        CMOVWCC      R14, CX
+        CMOVWCC      R11, CX
         MOVBQZX      CL, CX
-        SHLB         CL, R15
-        MOVB         R15, ret0+8(FP)
+        SHLB         CL, R12
+        MOVB         R12, ret+8(FP)
         RET
 
 TEXT ·shruint8s(SB),$8-9
-        MOVB         $0, ret0+8(FP)
 block0:
-        MOVBQZX      x+0(FP), R14
+        // entry
+        MOVBQZX      x+0(FP), R15
         MOVBQZX      shift+1(FP), R13
-        MOVB         R14, R15
+        MOVB         R15, R12
         MOVB         R13, CX
-        MOVL         $8, R14
+        MOVL         $8, R11
         CMPB         R13, $8
-        CMOVWCC      R14, CX
+        CMOVWCC      R11, CX
         MOVBQZX      CL, CX
-        SHRB         CL, R15
-        MOVB         R15, ret0+8(FP)
+        SHRB         CL, R12
+        MOVB         R12, ret+8(FP)
         RET
 
 TEXT ·oruint16s(SB),$8-10
-        MOVW         $0, ret0+8(FP)
 block0:
-        MOVWQZX      a+0(FP), R14
+        // entry
+        MOVWQZX      a+0(FP), R15
         MOVWQZX      b+2(FP), R13
-        MOVW         R13, R15
-        ORQ          R14, R15
-        MOVW         R15, ret0+8(FP)
+        MOVW         R13, R12
+        ORQ          R15, R12
+        MOVW         R12, ret+8(FP)
         RET
 
 TEXT ·anduint16s(SB),$8-10
-        MOVW         $0, ret0+8(FP)
 block0:
-        MOVWQZX      a+0(FP), R14
+        // entry
+        MOVWQZX      a+0(FP), R15
         MOVWQZX      b+2(FP), R13
-        MOVW         R13, R15
-        ANDW         R14, R15
-        MOVW         R15, ret0+8(FP)
+        MOVW         R13, R12
+        ANDW         R15, R12
+        MOVW         R12, ret+8(FP)
         RET
 
 TEXT ·xoruint16s(SB),$8-10
-        MOVW         $0, ret0+8(FP)
 block0:
-        MOVWQZX      a+0(FP), R14
+        // entry
+        MOVWQZX      a+0(FP), R15
         MOVWQZX      b+2(FP), R13
-        MOVW         R13, R15
-        XORQ         R14, R15
-        MOVW         R15, ret0+8(FP)
+        MOVW         R13, R12
+        XORQ         R15, R12
+        MOVW         R12, ret+8(FP)
         RET
 
 TEXT ·notuint16s(SB),$8-10
-        MOVW         $0, ret0+8(FP)
 block0:
+        // entry
         MOVWQZX      a+0(FP), R15
         XORQ         $-1, R15
-        MOVW         R15, ret0+8(FP)
+        MOVW         R15, ret+8(FP)
         RET
 
 TEXT ·andnotuint16s(SB),$8-10
-        MOVW         $0, ret0+8(FP)
 block0:
-        MOVWQZX      a+0(FP), R14
+        // entry
+        MOVWQZX      a+0(FP), R15
         MOVWQZX      b+2(FP), R13
-        MOVW         R13, R15
-        XORW         $-1, R15
-        ANDW         R14, R15
-        MOVW         R15, ret0+8(FP)
+        MOVW         R13, R12
+        XORW         $-1, R12
+        ANDW         R15, R12
+        MOVW         R12, ret+8(FP)
         RET
 
 TEXT ·shluint16s(SB),$8-10
-        MOVW         $0, ret0+8(FP)
 block0:
-        MOVWQZX      x+0(FP), R14
+        // entry
+        MOVWQZX      x+0(FP), R15
         MOVBQZX      shift+2(FP), R13
-        MOVW         R14, R15
+        MOVW         R15, R12
         MOVW         R13, CX
-        MOVL         $16, R14
+        MOVL         $16, R11
         CMPB         R13, $16
-        CMOVWCC      R14, CX
+        CMOVWCC      R11, CX
         MOVBQZX      CL, CX
-        SHLW         CX, R15
-        MOVW         R15, ret0+8(FP)
+        SHLW         CX, R12
+        MOVW         R12, ret+8(FP)
         RET
 
 TEXT ·shruint16s(SB),$8-10
-        MOVW         $0, ret0+8(FP)
 block0:
-        MOVWQZX      x+0(FP), R14
+        // entry
+        MOVWQZX      x+0(FP), R15
         MOVBQZX      shift+2(FP), R13
-        MOVW         R14, R15
+        MOVW         R15, R12
         MOVW         R13, CX
-        MOVL         $16, R14
+        MOVL         $16, R11
         CMPB         R13, $16
-        CMOVWCC      R14, CX
+        CMOVWCC      R11, CX
         MOVBQZX      CL, CX
-        SHRW         CX, R15
-        MOVW         R15, ret0+8(FP)
+        SHRW         CX, R12
+        MOVW         R12, ret+8(FP)
         RET
 
 TEXT ·oruint32s(SB),$8-12
-        MOVL         $0, ret0+8(FP)
 block0:
-        MOVLQZX      a+0(FP), R14
+        // entry
+        MOVLQZX      a+0(FP), R15
         MOVLQZX      b+4(FP), R13
-        MOVL         R13, R15
-        ORQ          R14, R15
-        MOVL         R15, ret0+8(FP)
+        MOVL         R13, R12
+        ORQ          R15, R12
+        MOVL         R12, ret+8(FP)
         RET
 
 TEXT ·anduint32s(SB),$8-12
-        MOVL         $0, ret0+8(FP)
 block0:
-        MOVLQZX      a+0(FP), R14
+        // entry
+        MOVLQZX      a+0(FP), R15
         MOVLQZX      b+4(FP), R13
-        MOVL         R13, R15
-        ANDL         R14, R15
-        MOVL         R15, ret0+8(FP)
+        MOVL         R13, R12
+        ANDL         R15, R12
+        MOVL         R12, ret+8(FP)
         RET
 
 TEXT ·xoruint32s(SB),$8-12
-        MOVL         $0, ret0+8(FP)
 block0:
-        MOVLQZX      a+0(FP), R14
+        // entry
+        MOVLQZX      a+0(FP), R15
         MOVLQZX      b+4(FP), R13
-        MOVL         R13, R15
-        XORQ         R14, R15
-        MOVL         R15, ret0+8(FP)
+        MOVL         R13, R12
+        XORQ         R15, R12
+        MOVL         R12, ret+8(FP)
         RET
 
 TEXT ·notuint32s(SB),$8-12
-        MOVL         $0, ret0+8(FP)
 block0:
+        // entry
         MOVLQZX      a+0(FP), R15
         XORQ         $-1, R15
-        MOVL         R15, ret0+8(FP)
+        MOVL         R15, ret+8(FP)
         RET
 
 TEXT ·andnotuint32s(SB),$8-12
-        MOVL         $0, ret0+8(FP)
 block0:
-        MOVLQZX      a+0(FP), R14
+        // entry
+        MOVLQZX      a+0(FP), R15
         MOVLQZX      b+4(FP), R13
-        MOVL         R13, R15
-        XORL         $-1, R15
-        ANDL         R14, R15
-        MOVL         R15, ret0+8(FP)
+        MOVL         R13, R12
+        XORL         $-1, R12
+        ANDL         R15, R12
+        MOVL         R12, ret+8(FP)
         RET
 
 TEXT ·shluint32s(SB),$8-12
-        MOVL         $0, ret0+8(FP)
 block0:
-        MOVLQZX      x+0(FP), R14
+        // entry
+        MOVLQZX      x+0(FP), R15
         MOVBQZX      shift+4(FP), R13
-        MOVL         R14, R15
-        MOVL         R13, CX
-        MOVL         $31, R14
-        CMPB         R13, $32
-        CMOVLCC      R14, CX
-        MOVBQZX      CL, CX
-        SHLL         CX, R15
-        MOVL         $1, R14
-        XORQ         CX, CX
+        XORQ         R11, R11
         CMPB         R13, $32
-        CMOVLCC      R14, CX
-        MOVBQZX      CL, CX
-        SHLL         CX, R15
-        MOVL         R15, ret0+8(FP)
+        SHLXL        R13, R15, R12
+        CMOVLCC      R11, R12
+        MOVL         R12, ret+8(FP)
         RET
 
 TEXT ·shruint32s(SB),$8-12
-        MOVL         $0, ret0+8(FP)
 block0:
-        MOVLQZX      x+0(FP), R14
+        // entry
+        MOVLQZX      x+0(FP), R15
         MOVBQZX      shift+4(FP), R13
-        MOVL         R14, R15
-        MOVL         R13, CX
-        MOVL         $31, R14
-        CMPB         R13, $32
-        CMOVLCC      R14, CX
-        MOVBQZX      CL, CX
-        SHRL         CX, R15
-        MOVL         $1, R14
-        XORQ         CX, CX
+        XORQ         R11, R11
         CMPB         R13, $32
-        CMOVLCC      R14, CX
-        MOVBQZX      CL, CX
-        SHRL         CX, R15
-        MOVL         R15, ret0+8(FP)
+        SHRXL        R13, R15, R12
+        CMOVLCC      R11, R12
+        MOVL         R12, ret+8(FP)
         RET
 
 TEXT ·oruint64s(SB),$16-24
-        MOVQ         $0, ret0+16(FP)
 block0:
-        MOVQ         a+0(FP), R14
+        // entry
+        MOVQ         a+0(FP), R15
         MOVQ         b+8(FP), R13
-        MOVQ         R13, R15
-        ORQ          R14, R15
-        MOVQ         R15, ret0+16(FP)
+        MOVQ         R13, R12
+        ORQ          R15, R12
+        MOVQ         R12, ret+16(FP)
         RET
 
 TEXT ·anduint64s(SB),$16-24
-        MOVQ         $0, ret0+16(FP)
 block0:
-        MOVQ         a+0(FP), R14
+        // entry
+        MOVQ         a+0(FP), R15
         MOVQ         b+8(FP), R13
-        MOVQ         R13, R15
-        ANDQ         R14, R15
-        MOVQ         R15, ret0+16(FP)
+        MOVQ         R13, R12
+        ANDQ         R15, R12
+        MOVQ         R12, ret+16(FP)
         RET
 
 TEXT ·xoruint64s(SB),$16-24
-        MOVQ         $0, ret0+16(FP)
 block0:
-        MOVQ         a+0(FP), R14
+        // entry
+        MOVQ         a+0(FP), R15
         MOVQ         b+8(FP), R13
-        MOVQ         R13, R15
-        XORQ         R14, R15
-        MOVQ         R15, ret0+16(FP)
+        MOVQ         R13, R12
+        XORQ         R15, R12
+        MOVQ         R12, ret+16(FP)
         RET
 
 TEXT ·notuint64s(SB),$16-16
-        MOVQ         $0, ret0+8(FP)
 block0:
+        // entry
         MOVQ         a+0(FP), R15
         XORQ         $-1, R15
-        MOVQ         R15, ret0+8(FP)
+        MOVQ         R15, ret+8(FP)
         RET
 
 TEXT ·andnotuint64s(SB),$16-24
-        MOVQ         $0, ret0+16(FP)
 block0:
-        MOVQ         a+0(FP), R14
+        // entry
+        MOVQ         a+0(FP), R15
         MOVQ         b+8(FP), R13
-        MOVQ         R13, R15
-        XORQ         $-1, R15
-        ANDQ         R14, R15
-        MOVQ         R15, ret0+16(FP)
+        MOVQ         R13, R12
+        XORQ         $-1, R12
+        ANDQ         R15, R12
+        MOVQ         R12, ret+16(FP)
         RET
 
 TEXT ·shluint64s(SB),$16-24
-        MOVQ         $0, ret0+16(FP)
 block0:
-        MOVQ         x+0(FP), R14
+        // entry
+        MOVQ         x+0(FP), R15
         MOVBQZX      shift+8(FP), R13
-        MOVQ         R14, R15
-        MOVQ         R13, CX
-        MOVL         $63, R14
-        CMPB         R13, $64
-        CMOVQCC      R14, CX
-        MOVBQZX      CL, CX
-        SHLQ         CX, R15
-        MOVL         $1, R14
-        XORQ         CX, CX
+        XORQ         R11, R11
         CMPB         R13, $64
-        CMOVQCC      R14, CX
-        MOVBQZX      CL, CX
-        SHLQ         CX, R15
-        MOVQ         R15, ret0+16(FP)
+        SHLXQ        R13, R15, R12
+        CMOVQCC      R11, R12
+        MOVQ         R12, ret+16(FP)
         RET
 
 TEXT ·shruint64s(SB),$16-24
-        MOVQ         $0, ret0+16(FP)
 block0:
-        MOVQ         x+0(FP), R14
+        // entry
+        MOVQ         x+0(FP), R15
         MOVBQZX      shift+8(FP), R13
-        MOVQ         R14, R15
-        MOVQ         R13, CX
-        MOVL         $63, R14
+        XORQ         R11, R11
         CMPB         R13, $64
-        CMOVQCC      R14, CX
-        MOVBQZX      CL, CX
-        SHRQ         CX, R15
-        MOVL         $1, R14
-        XORQ         CX, CX
-        CMPB         R13, $64
-        CMOVQCC      R14, CX
-        MOVBQZX      CL, CX
-        SHRQ         CX, R15
-        MOVQ         R15, ret0+16(FP)
+        SHRXQ        R13, R15, R12
+        CMOVQCC      R11, R12
+        MOVQ         R12, ret+16(FP)
         RET
 
 TEXT ·orint8s(SB),$8-9
-        MOVB         $0, ret0+8(FP)
 block0:
-        MOVBQZX      a+0(FP), R14
+        // entry
+        MOVBQZX      a+0(FP), R15
         MOVBQZX      b+1(FP), R13
-        MOVB         R13, R15
-        ORQ          R14, R15
-        MOVB         R15, ret0+8(FP)
+        MOVB         R13, R12
+        ORQ          R15, R12
+        MOVB         R12, ret+8(FP)
         RET
 
 TEXT ·andint8s(SB),$8-9
-        MOVB         $0, ret0+8(FP)
 block0:
-        MOVBQZX      a+0(FP), R14
+        // entry
+        MOVBQZX      a+0(FP), R15
         MOVBQZX      b+1(FP), R13
-        MOVB         R13, R15
-        ANDB         R14, R15
-        MOVB         R15, ret0+8(FP)
+        MOVB         R13, R12
+        ANDB         R15, R12
+        MOVB         R12, ret+8(FP)
         RET
 
 TEXT ·xorint8s(SB),$8-9
-        MOVB         $0, ret0+8(FP)
 block0:
-        MOVBQZX      a+0(FP), R14
+        // entry
+        MOVBQZX      a+0(FP), R15
         MOVBQZX      b+1(FP), R13
-        MOVB         R13, R15
-        XORQ         R14, R15
-        MOVB         R15, ret0+8(FP)
+        MOVB         R13, R12
+        XORQ         R15, R12
+        MOVB         R12, ret+8(FP)
         RET
 
 TEXT ·notint8s(SB),$8-9
-        MOVB         $0, ret0+8(FP)
 block0:
+        // entry
         MOVBQZX      a+0(FP), R15
         XORQ         $-1, R15
-        MOVB         R15, ret0+8(FP)
+        MOVB         R15, ret+8(FP)
         RET
 
 TEXT ·andnotint8s(SB),$8-9
-        MOVB         $0, ret0+8(FP)
 block0:
-        MOVBQZX      a+0(FP), R14
+        // entry
+        MOVBQZX      a+0(FP), R15
         MOVBQZX      b+1(FP), R13
-        MOVB         R13, R15
-        XORB         $-1, R15
-        ANDB         R14, R15
-        MOVB         R15, ret0+8(FP)
+        MOVB         R13, R12
+        XORB         $-1, R12
+        ANDB         R15, R12
+        MOVB         R12, ret+8(FP)
         RET
 
 TEXT ·shlint8s(SB),$8-9
-        MOVB         $0, ret0+8(FP)
 block0:
-        MOVBQZX      x+0(FP), R14
+        // entry
+        MOVBQZX      x+0(FP), R15
         MOVBQZX      shift+1(FP), R13
-        MOVB         R14, R15
+        MOVB         R15, R12
         MOVB         R13, CX
-        MOVL         $8, R14
+        MOVL         $8, R11
         CMPB         R13, $8
-        CMOVWCC      R14, CX
+        CMOVWCC      R11, CX
         MOVBQZX      CL, CX
-        SHLB         CL, R15
-        MOVB         R15, ret0+8(FP)
+        SHLB         CL, R12
+        MOVB         R12, ret+8(FP)
         RET
 
 TEXT ·shrint8s(SB),$8-9
-        MOVB         $0, ret0+8(FP)
 block0:
-        MOVBQZX      x+0(FP), R14
+        // entry
+        MOVBQZX      x+0(FP), R15
         MOVBQZX      shift+1(FP), R13
-        MOVB         R14, R15
+        MOVB         R15, R12
         MOVB         R13, CX
-        MOVL         $8, R14
+        MOVL         $8, R11
         CMPB         R13, $8
-        CMOVWCC      R14, CX
+        CMOVWCC      R11, CX
         MOVBQZX      CL, CX
-        SARB         CL, R15
-        MOVB         R15, ret0+8(FP)
+        SARB         CL, R12
+        MOVB         R12, ret+8(FP)
         RET
 
 TEXT ·orint16s(SB),$8-10
-        MOVW         $0, ret0+8(FP)
 block0:
-        MOVWQZX      a+0(FP), R14
+        // entry
+        MOVWQZX      a+0(FP), R15
         MOVWQZX      b+2(FP), R13
-        MOVW         R13, R15
-        ORQ          R14, R15
-        MOVW         R15, ret0+8(FP)
+        MOVW         R13, R12
+        ORQ          R15, R12
+        MOVW         R12, ret+8(FP)
         RET
 
 TEXT ·andint16s(SB),$8-10
-        MOVW         $0, ret0+8(FP)
 block0:
-        MOVWQZX      a+0(FP), R14
+        // entry
+        MOVWQZX      a+0(FP), R15
         MOVWQZX      b+2(FP), R13
-        MOVW         R13, R15
-        ANDW         R14, R15
-        MOVW         R15, ret0+8(FP)
+        MOVW         R13, R12
+        ANDW         R15, R12
+        MOVW         R12, ret+8(FP)
         RET
 
 TEXT ·xorint16s(SB),$8-10
-        MOVW         $0, ret0+8(FP)
 block0:
-        MOVWQZX      a+0(FP), R14
+        // entry
+        MOVWQZX      a+0(FP), R15
         MOVWQZX      b+2(FP), R13
-        MOVW         R13, R15
-        XORQ         R14, R15
-        MOVW         R15, ret0+8(FP)
+        MOVW         R13, R12
+        XORQ         R15, R12
+        MOVW         R12, ret+8(FP)
         RET
 
 TEXT ·notint16s(SB),$8-10
-        MOVW         $0, ret0+8(FP)
 block0:
+        // entry
         MOVWQZX      a+0(FP), R15
         XORQ         $-1, R15
-        MOVW         R15, ret0+8(FP)
+        MOVW         R15, ret+8(FP)
         RET
 
 TEXT ·andnotint16s(SB),$8-10
-        MOVW         $0, ret0+8(FP)
 block0:
-        MOVWQZX      a+0(FP), R14
+        // entry
+        MOVWQZX      a+0(FP), R15
         MOVWQZX      b+2(FP), R13
-        MOVW         R13, R15
-        XORW         $-1, R15
-        ANDW         R14, R15
-        MOVW         R15, ret0+8(FP)
+        MOVW         R13, R12
+        XORW         $-1, R12
+        ANDW         R15, R12
+        MOVW         R12, ret+8(FP)
         RET
 
 TEXT ·shlint16s(SB),$8-10
-        MOVW         $0, ret0+8(FP)
 block0:
-        MOVWQZX      x+0(FP), R14
+        // entry
+        MOVWQZX      x+0(FP), R15
         MOVBQZX      shift+2(FP), R13
-        MOVW         R14, R15
+        MOVW         R15, R12
         MOVW         R13, CX
-        MOVL         $16, R14
+        MOVL         $16, R11
         CMPB         R13, $16
-        CMOVWCC      R14, CX
+        CMOVWCC      R11, CX
         MOVBQZX      CL, CX
-        SHLW         CX, R15
-        MOVW         R15, ret0+8(FP)
+        SHLW         CX, R12
+        MOVW         R12, ret+8(FP)
         RET
 
 TEXT ·shrint16s(SB),$8-10
-        MOVW         $0, ret0+8(FP)
 block0:
-        MOVWQZX      x+0(FP), R14
+        // entry
+        MOVWQZX      x+0(FP), R15
         MOVBQZX      shift+2(FP), R13
-        MOVW         R14, R15
+        MOVW         R15, R12
         MOVW         R13, CX
-        MOVL         $16, R14
+        MOVL         $16, R11
         CMPB         R13, $16
-        CMOVWCC      R14, CX
+        CMOVWCC      R11, CX
         MOVBQZX      CL, CX
-        SARW         CX, R15
-        MOVW         R15, ret0+8(FP)
+        SARW         CX, R12
+        MOVW         R12, ret+8(FP)
         RET
 
 TEXT ·orint32s(SB),$8-12
-        MOVL         $0, ret0+8(FP)
 block0:
-        MOVLQZX      a+0(FP), R14
+        // entry
+        MOVLQZX      a+0(FP), R15
         MOVLQZX      b+4(FP), R13
-        MOVL         R13, R15
-        ORQ          R14, R15
-        MOVL         R15, ret0+8(FP)
+        MOVL         R13, R12
+        ORQ          R15, R12
+        MOVL         R12, ret+8(FP)
         RET
 
 TEXT ·andint32s(SB),$8-12
-        MOVL         $0, ret0+8(FP)
 block0:
-        MOVLQZX      a+0(FP), R14
+        // entry
+        MOVLQZX      a+0(FP), R15
         MOVLQZX      b+4(FP), R13
-        MOVL         R13, R15
-        ANDL         R14, R15
-        MOVL         R15, ret0+8(FP)
+        MOVL         R13, R12
+        ANDL         R15, R12
+        MOVL         R12, ret+8(FP)
         RET
 
 TEXT ·xorint32s(SB),$8-12
-        MOVL         $0, ret0+8(FP)
 block0:
-        MOVLQZX      a+0(FP), R14
+        // entry
+        MOVLQZX      a+0(FP), R15
         MOVLQZX      b+4(FP), R13
-        MOVL         R13, R15
-        XORQ         R14, R15
-        MOVL         R15, ret0+8(FP)
+        MOVL         R13, R12
+        XORQ         R15, R12
+        MOVL         R12, ret+8(FP)
         RET
 
 TEXT ·notint32s(SB),$8-12
-        MOVL         $0, ret0+8(FP)
 block0:
+        // entry
         MOVLQZX      a+0(FP), R15
         XORQ         $-1, R15
-        MOVL         R15, ret0+8(FP)
+        MOVL         R15, ret+8(FP)
         RET
 
 TEXT ·andnotint32s(SB),$8-12
-        MOVL         $0, ret0+8(FP)
 block0:
-        MOVLQZX      a+0(FP), R14
+        // entry
+        MOVLQZX      a+0(FP), R15
         MOVLQZX      b+4(FP), R13
-        MOVL         R13, R15
-        XORL         $-1, R15
-        ANDL         R14, R15
-        MOVL         R15, ret0+8(FP)
+        MOVL         R13, R12
+        XORL         $-1, R12
+        ANDL         R15, R12
+        MOVL         R12, ret+8(FP)
         RET
 
 TEXT ·shlint32s(SB),$8-12
-        MOVL         $0, ret0+8(FP)
 block0:
-        MOVLQZX      x+0(FP), R14
+        // entry
+        MOVLQZX      x+0(FP), R15
         MOVBQZX      shift+4(FP), R13
-        MOVL         R14, R15
-        MOVL         R13, CX
-        MOVL         $31, R14
+        XORQ         R11, R11
         CMPB         R13, $32
-        CMOVLCC      R14, CX
-        MOVBQZX      CL, CX
-        SHLL         CX, R15
-        MOVL         $1, R14
-        XORQ         CX, CX
-        CMPB         R13, $32
-        CMOVLCC      R14, CX
-        MOVBQZX      CL, CX
-        SHLL         CX, R15
-        MOVL         R15, ret0+8(FP)
+        SHLXL        R13, R15, R12
+        CMOVLCC      R11, R12
+        MOVL         R12, ret+8(FP)
         RET
 
 TEXT ·shrint32s(SB),$8-12
-        MOVL         $0, ret0+8(FP)
 block0:
-        MOVLQZX      x+0(FP), R14
+        // entry
+        MOVLQZX      x+0(FP), R15
         MOVBQZX      shift+4(FP), R13
-        MOVL         R14, R15
-        MOVL         R13, CX
-        MOVL         $31, R14
+        MOVL         $31, R11
         CMPB         R13, $32
-        CMOVLCC      R14, CX
-        MOVBQZX      CL, CX
-        SARL         CX, R15
-        MOVL         $1, R14
-        XORQ         CX, CX
-        CMPB         R13, $32
-        CMOVLCC      R14, CX
-        MOVBQZX      CL, CX
-        SARL         CX, R15
-        MOVL         R15, ret0+8(FP)
+        CMOVQCS      R13, R11
+        SARXL        R11, R15, R12
+        MOVL         R12, ret+8(FP)
         RET
 
 TEXT ·orint64s(SB),$16-24
-        MOVQ         $0, ret0+16(FP)
 block0:
-        MOVQ         a+0(FP), R14
+        // entry
+        MOVQ         a+0(FP), R15
         MOVQ         b+8(FP), R13
-        MOVQ         R13, R15
-        ORQ          R14, R15
-        MOVQ         R15, ret0+16(FP)
+        MOVQ         R13, R12
+        ORQ          R15, R12
+        MOVQ         R12, ret+16(FP)
         RET
 
 TEXT ·andint64s(SB),$16-24
-        MOVQ         $0, ret0+16(FP)
 block0:
-        MOVQ         a+0(FP), R14
+        // entry
+        MOVQ         a+0(FP), R15
         MOVQ         b+8(FP), R13
-        MOVQ         R13, R15
-        ANDQ         R14, R15
-        MOVQ         R15, ret0+16(FP)
+        MOVQ         R13, R12
+        ANDQ         R15, R12
+        MOVQ         R12, ret+16(FP)
         RET
 
 TEXT ·xorint64s(SB),$16-24
-        MOVQ         $0, ret0+16(FP)
 block0:
-        MOVQ         a+0(FP), R14
+        // entry
+        MOVQ         a+0(FP), R15
         MOVQ         b+8(FP), R13
-        MOVQ         R13, R15
-        XORQ         R14, R15
-        MOVQ         R15, ret0+16(FP)
+        MOVQ         R13, R12
+        XORQ         R15, R12
+        MOVQ         R12, ret+16(FP)
         RET
 
 TEXT ·notint64s(SB),$16-16
-        MOVQ         $0, ret0+8(FP)
 block0:
+        // entry
         MOVQ         a+0(FP), R15
         XORQ         $-1, R15
-        MOVQ         R15, ret0+8(FP)
+        MOVQ         R15, ret+8(FP)
         RET
 
 TEXT ·andnotint64s(SB),$16-24
-        MOVQ         $0, ret0+16(FP)
 block0:
-        MOVQ         a+0(FP), R14
+        // entry
+        MOVQ         a+0(FP), R15
         MOVQ         b+8(FP), R13
-        MOVQ         R13, R15
-        XORQ         $-1, R15
-        ANDQ         R14, R15
-        MOVQ         R15, ret0+16(FP)
+        MOVQ         R13, R12
+        XORQ         $-1, R12
+        ANDQ         R15, R12
+        MOVQ         R12, ret+16(FP)
         RET
 
 TEXT ·shlint64s(SB),$16-24
-        MOVQ         $0, ret0+16(FP)
 block0:
-        MOVQ         x+0(FP), R14
+        // entry
+        MOVQ         x+0(FP), R15
         MOVBQZX      shift+8(FP), R13
-        MOVQ         R14, R15
-        MOVQ         R13, CX
-        MOVL         $63, R14
-        CMPB         R13, $64
-        CMOVQCC      R14, CX
-        MOVBQZX      CL, CX
-        SHLQ         CX, R15
-        MOVL         $1, R14
-        XORQ         CX, CX
+        XORQ         R11, R11
         CMPB         R13, $64
-        CMOVQCC      R14, CX
-        MOVBQZX      CL, CX
-        SHLQ         CX, R15
-        MOVQ         R15, ret0+16(FP)
+        SHLXQ        R13, R15, R12
+        CMOVQCC      R11, R12
+        MOVQ         R12, ret+16(FP)
         RET
 
 TEXT ·shrint64s(SB),$16-24
-        MOVQ         $0, ret0+16(FP)
 block0:
-        MOVQ         x+0(FP), R14
+        // entry
+        MOVQ         x+0(FP), R15
         MOVBQZX      shift+8(FP), R13
-        MOVQ         R14, R15
-        MOVQ         R13, CX
-        MOVL         $63, R14
+        MOVL         $63, R11
         CMPB         R13, $64
-        CMOVQCC      R14, CX
-        MOVBQZX      CL, CX
-        SARQ         CX, R15
-        MOVL         $1, R14
-        XORQ         CX, CX
-        CMPB         R13, $64
-        CMOVQCC      R14, CX
-        MOVBQZX      CL, CX
-        SARQ         CX, R15
-        MOVQ         R15, ret0+16(FP)
+        CMOVQCS      R13, R11
+        SARXQ        R11, R15, R12
+        MOVQ         R12, ret+16(FP)
         RET
 
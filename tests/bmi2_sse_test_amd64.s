@@ -0,0 +1,138 @@
+// Code generated by gensimd. DO NOT EDIT.
+// gensimd version: devel
+// gensimd command: gensimd -f bmi2_test.go -fn "bmi2t2, bmi2t3" -o bmi2_sse_test_amd64.s -outfn "bmi2t2b, bmi2t3b" -target sse4.1
+// gensimd source: bmi2_test.go sha256:00328eb7da0f369c8c6d7c1c1639ab8967feb2c26c4d38f75968b7f823aa8f72
+// gensimd target: sse4.1
+
+//go:build amd64 && !noasm && !appengine
+// +build amd64,!noasm,!appengine
+
+#include "textflag.h"
+
+TEXT ·bmi2t2b(SB),$16-24
+block0:
+        // entry
+        MOVQ         x+0(FP), R15
+        MOVQ         y+8(FP), R13
+        MOVQ         R15, AX
+        MULQ         R13
+        MOVQ         DX, R12
+        MOVQ         R12, ret+16(FP)
+        RET
+
+TEXT ·bmi2t3b(SB),$152-32
+block0:
+        // entry
+        MOVQ         x+0(FP), R15
+        MOVBQZX      c+16(FP), R13
+        MOVQ         R15, R12
+        MOVQ         R13, CX
+        MOVL         $63, R11
+        CMPB         R13, $64
+        CMOVQCC      R11, CX
+        MOVBQZX      CL, CX
+        SHLQ         CX, R12
+        MOVL         $1, R11
+        XORQ         CX, CX
+        CMPB         R13, $64
+        CMOVQCC      R11, CX
+        MOVBQZX      CL, CX
+        SHLQ         CX, R12
+        MOVLQZX      d+20(FP), R11
+        MOVQ         R15, R10
+        MOVQ         R11, CX
+        MOVL         $63, R9
+        CMPL         R11, $64
+        CMOVQCC      R9, CX
+        MOVBQZX      CL, CX
+        SHRQ         CX, R10
+        MOVL         $1, R9
+        XORQ         CX, CX
+        CMPL         R11, $64
+        CMOVQCC      R9, CX
+        MOVBQZX      CL, CX
+        SHRQ         CX, R10
+        XORQ         R10, R12
+        MOVQ         y+8(FP), R9
+        MOVQ         R9, R8
+        MOVQ         R13, CX
+        MOVL         $63, BP
+        CMPB         R13, $64
+        CMOVQCC      BP, CX
+        MOVBQZX      CL, CX
+        SARQ         CX, R8
+        MOVL         $1, BP
+        XORQ         CX, CX
+        CMPB         R13, $64
+        CMOVQCC      BP, CX
+        MOVBQZX      CL, CX
+        SARQ         CX, R8
+        MOVQ         R8, BP
+        MOVQ         R9, BX
+        MOVQ         R11, CX
+        MOVL         $63, DI
+        CMPL         R11, $64
+        CMOVQCC      DI, CX
+        MOVBQZX      CL, CX
+        SHLQ         CX, BX
+        MOVL         $1, DI
+        XORQ         CX, CX
+        CMPL         R11, $64
+        CMOVQCC      DI, CX
+        MOVBQZX      CL, CX
+        SHLQ         CX, BX
+        MOVQ         BX, DI
+        XORQ         DI, BP
+        ADDQ         BP, R12
+        MOVL         R15, R8
+        MOVL         R13, CX
+        MOVL         $31, SI
+        CMPB         R13, $32
+        CMOVLCC      SI, CX
+        MOVBQZX      CL, CX
+        SHLL         CX, R8
+        MOVL         $1, SI
+        XORQ         CX, CX
+        CMPB         R13, $32
+        CMOVLCC      SI, CX
+        MOVBQZX      CL, CX
+        SHLL         CX, R8
+        MOVLQZX      R8, SI
+        MOVL         R9, R8
+        MOVL         R11, CX
+        MOVL         $31, DI
+        CMPL         R11, $32
+        CMOVLCC      DI, CX
+        MOVBQZX      CL, CX
+        SARL         CX, R8
+        MOVL         $1, DI
+        XORQ         CX, CX
+        CMPL         R11, $32
+        CMOVLCC      DI, CX
+        MOVBQZX      CL, CX
+        SARL         CX, R8
+        MOVQ         SI, t11-88(SP)
+        MOVLQSX      R8, DI
+        MOVQ         t11-88(SP), SI
+        ADDQ         DI, SI
+        MOVQ         SI, t15-112(SP)
+        MOVL         R15, R8
+        MOVL         R11, CX
+        MOVL         $31, SI
+        CMPL         R11, $32
+        CMOVLCC      SI, CX
+        MOVBQZX      CL, CX
+        SHRL         CX, R8
+        MOVL         $1, SI
+        XORQ         CX, CX
+        CMPL         R11, $32
+        CMOVLCC      SI, CX
+        MOVBQZX      CL, CX
+        SHRL         CX, R8
+        MOVLQZX      R8, SI
+        MOVQ         t15-112(SP), DI
+        ADDQ         SI, DI
+        XORQ         DI, R12
+        MOVQ         R12, ret+24(FP)
+        RET
+
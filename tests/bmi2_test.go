@@ -0,0 +1,104 @@
+// +build amd64,gc
+
+package tests
+
+import (
+	"testing"
+
+	"github.com/bjwbell/gensimd/simd"
+)
+
+//go:generate gensimd -fn "bmi2t0, bmi2t1, bmi2t2, bmi2t3, bmi2t4" -outfn "bmi2t0s, bmi2t1s, bmi2t2s, bmi2t3s, bmi2t4s" -f "$GOFILE" -o "bmi2_test_amd64.s"
+//go:generate gensimd -target sse4.1 -fn "bmi2t2, bmi2t3" -outfn "bmi2t2b, bmi2t3b" -f "$GOFILE" -o "bmi2_sse_test_amd64.s"
+
+// PEXT, PDEP, MULX, and SHLX/SHRX/SARX, the default avx2 target has them
+func bmi2t0s(x, mask uint64) uint64
+func bmi2t1s(x, mask uint64) uint64
+func bmi2t2s(x, y uint64) uint64
+func bmi2t3s(x uint64, y int64, c uint8, d uint32) uint64
+func bmi2t4s(dst, src []uint64)
+
+// MUL, and SHL/SHR/SAR by CL
+func bmi2t2b(x, y uint64) uint64
+func bmi2t3b(x uint64, y int64, c uint8, d uint32) uint64
+
+func bmi2t0(x, mask uint64) uint64 {
+	return simd.Pext64(x, mask)
+}
+
+func bmi2t1(x, mask uint64) uint64 {
+	return simd.Pdep64(x, mask)
+}
+
+func bmi2t2(x, y uint64) uint64 {
+	return simd.MulHi64(x, y)
+}
+
+// each shift and size, counts of at least the width included
+func bmi2t3(x uint64, y int64, c uint8, d uint32) uint64 {
+	v := x<<c ^ x>>d
+	v += uint64(y>>c) ^ uint64(y<<d)
+	v ^= uint64(uint32(x)<<c) + uint64(int32(y)>>d) + uint64(uint32(x)>>d)
+	return v
+}
+
+// unpacks 8 3-bit values per word into bytes, a bit packed column
+func bmi2t4(dst, src []uint64) {
+	for i := range src {
+		dst[i] = simd.Pdep64(simd.Pext64(src[i], 0xffffff), 0x0707070707070707)
+	}
+}
+
+func TestBMI2(t *testing.T) {
+	xs := []uint64{0, 1, 0x8000000000000000, 0xffffffffffffffff, 0x0123456789abcdef, 0xdeadbeefcafef00d}
+	counts := []uint32{0, 1, 7, 31, 32, 33, 63, 64, 65, 255, 256, 1 << 31}
+	if simd.Pext64(0xabcd, 0xff0) != 0xbc || simd.Pdep64(0xbc, 0xff0) != 0xbc0 || simd.MulHi64(1<<63, 6) != 3 {
+		t.Errorf("Pext64, Pdep64, or MulHi64 is wrong")
+	}
+	type funcs struct {
+		name string
+		t2   func(uint64, uint64) uint64
+		t3   func(uint64, int64, uint8, uint32) uint64
+	}
+	tests := []funcs{{"mul", bmi2t2b, bmi2t3b}}
+	bmi2 := simd.HasTarget("avx2")
+	if bmi2 {
+		tests = append(tests, funcs{"mulx", bmi2t2s, bmi2t3s})
+	}
+	for _, fns := range tests {
+		for _, x := range xs {
+			for _, y := range xs {
+				if got, expected := fns.t2(x, y), bmi2t2(x, y); got != expected {
+					t.Errorf("%v t2(%#x, %#x) %#x != %#x", fns.name, x, y, got, expected)
+				}
+				for _, d := range counts {
+					c := uint8(d)
+					if got, expected := fns.t3(x, int64(y), c, d), bmi2t3(x, int64(y), c, d); got != expected {
+						t.Errorf("%v t3(%#x, %#x, %v, %v) %#x != %#x", fns.name, x, y, c, d, got, expected)
+					}
+				}
+			}
+		}
+	}
+	if !bmi2 {
+		t.Skip("PEXT and PDEP need BMI2")
+	}
+	for _, x := range xs {
+		for _, m := range xs {
+			if got, expected := bmi2t0s(x, m), bmi2t0(x, m); got != expected {
+				t.Errorf("bmi2t0s(%#x, %#x) %#x != %#x", x, m, got, expected)
+			}
+			if got, expected := bmi2t1s(x, m), bmi2t1(x, m); got != expected {
+				t.Errorf("bmi2t1s(%#x, %#x) %#x != %#x", x, m, got, expected)
+			}
+		}
+	}
+	got, expected := make([]uint64, len(xs)), make([]uint64, len(xs))
+	bmi2t4s(got, xs)
+	bmi2t4(expected, xs)
+	for i := range xs {
+		if got[i] != expected[i] {
+			t.Errorf("bmi2t4s(%#x) %#x != %#x", xs[i], got[i], expected[i])
+		}
+	}
+}
@@ -0,0 +1,134 @@
+// Code generated by gensimd. DO NOT EDIT.
+// gensimd version: devel
+// gensimd command: gensimd -f bmi2_test.go -fn "bmi2t0, bmi2t1, bmi2t2, bmi2t3, bmi2t4" -o bmi2_test_amd64.s -outfn "bmi2t0s, bmi2t1s, bmi2t2s, bmi2t3s, bmi2t4s"
+// gensimd source: bmi2_test.go sha256:00328eb7da0f369c8c6d7c1c1639ab8967feb2c26c4d38f75968b7f823aa8f72
+// gensimd target: avx2
+
+//go:build amd64 && !noasm && !appengine
+// +build amd64,!noasm,!appengine
+
+#include "textflag.h"
+
+TEXT ·bmi2t0s(SB),$16-24
+block0:
+        // entry
+        MOVQ         x+0(FP), R15
+        MOVQ         mask+8(FP), R13
+        PEXTQ        R13, R15, R12
+        MOVQ         R12, ret+16(FP)
+        RET
+
+TEXT ·bmi2t1s(SB),$16-24
+block0:
+        // entry
+        MOVQ         x+0(FP), R15
+        MOVQ         mask+8(FP), R13
+        PDEPQ        R13, R15, R12
+        MOVQ         R12, ret+16(FP)
+        RET
+
+TEXT ·bmi2t2s(SB),$16-24
+block0:
+        // entry
+        MOVQ         x+0(FP), R15
+        MOVQ         y+8(FP), R13
+        MOVQ         R15, DX
+        MULXQ        R13, R11, R12
+        MOVQ         R12, ret+16(FP)
+        RET
+
+TEXT ·bmi2t3s(SB),$152-32
+block0:
+        // entry
+        MOVQ         x+0(FP), R15
+        MOVBQZX      c+16(FP), R13
+        XORQ         R11, R11
+        CMPB         R13, $64
+        SHLXQ        R13, R15, R12
+        CMOVQCC      R11, R12
+        MOVLQZX      d+20(FP), R11
+        XORQ         R9, R9
+        CMPL         R11, $64
+        SHRXQ        R11, R15, R10
+        CMOVQCC      R9, R10
+        XORQ         R10, R12
+        MOVQ         y+8(FP), R9
+        MOVL         $63, BP
+        CMPB         R13, $64
+        CMOVQCS      R13, BP
+        SARXQ        BP, R9, R8
+        MOVQ         R8, BP
+        XORQ         DI, DI
+        CMPL         R11, $64
+        SHLXQ        R11, R9, BX
+        CMOVQCC      DI, BX
+        MOVQ         BX, DI
+        XORQ         DI, BP
+        ADDQ         BP, R12
+        MOVL         R15, R8
+        XORQ         SI, SI
+        CMPB         R13, $32
+        SHLXL        R13, R8, R8
+        CMOVLCC      SI, R8
+        MOVLQZX      R8, SI
+        MOVL         R9, R8
+        MOVL         $31, DI
+        CMPL         R11, $32
+        CMOVQCS      R11, DI
+        SARXL        DI, R8, R8
+        MOVQ         SI, t11-88(SP)
+        MOVLQSX      R8, DI
+        MOVQ         t11-88(SP), SI
+        ADDQ         DI, SI
+        MOVQ         SI, t15-112(SP)
+        MOVL         R15, R8
+        XORQ         SI, SI
+        CMPL         R11, $32
+        SHRXL        R11, R8, R8
+        CMOVLCC      SI, R8
+        MOVLQZX      R8, SI
+        MOVQ         t15-112(SP), DI
+        ADDQ         SI, DI
+        XORQ         DI, R12
+        MOVQ         R12, ret+24(FP)
+        RET
+
+TEXT ·bmi2t4s(SB),$72-48
+block0:
+        // entry
+        MOVQ         src_len+32(FP), R15
+        MOVQ         R15, R13
+        MOVQ         R13, BX
+        MOVQ         $-1, R12
+        MOVQ         R12, t1-16(SP)
+        MOVQ         R13, t0-8(SP)
+block1:
+        // rangeindex.loop, preds block0 block2
+        MOVQ         t1-16(SP), R15
+        MOVQ         R15, R13
+        ADDQ         $1, R13
+        MOVQ         BX, R12
+        CMPQ         R13, R12
+        MOVQ         R13, t2-24(SP)
+        JGE          block3
+block2:
+        // rangeindex.body, preds block1
+        MOVQ         t2-24(SP), R13
+        MOVQ         src_base+24(FP), R15
+        LEAQ         (R15)(R13*8), R15
+        MOVQ         (R15), R12
+        MOVQ         R12, t5-41(SP)
+        MOVQ         t5-41(SP), R12
+        MOVQ         $16777215, R11
+        PEXTQ        R11, R12, R10
+        MOVQ         $506381209866536711, R9
+        PDEPQ        R9, R10, R8
+        MOVQ         dst_base+0(FP), BP
+        LEAQ         (BP)(R13*8), BP
+        MOVQ         R8, (BP)
+        MOVQ         R13, t1-16(SP)
+        JMP block1
+block3:
+        // rangeindex.done, preds block1
+        RET
+
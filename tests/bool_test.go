@@ -6,12 +6,12 @@ import "testing"
 
 //go:generate gensimd -fn "boolt0, boolt1, boolt2, boolt3, boolt4, boolt5" -outfn "boolt0s, boolt1s, boolt2s, boolt3s, boolt4s, boolt5s" -f "$GOFILE" -o "bool_test_amd64.s"
 
-func boolt0s(bool) bool
-func boolt1s(bool) bool
-func boolt2s(bool, bool) bool
-func boolt3s(bool, bool) bool
-func boolt4s(bool, bool) bool
-func boolt5s(bool, bool) bool
+func boolt0s(x bool) bool
+func boolt1s(x bool) bool
+func boolt2s(x, y bool) bool
+func boolt3s(x, y bool) bool
+func boolt4s(x, y bool) bool
+func boolt5s(x, y bool) bool
 
 func boolt0(x bool) bool {
 	return x
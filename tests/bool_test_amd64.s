@@ -1,91 +1,101 @@
-// +build amd64 !noasm !appengine
+// Code generated by gensimd. DO NOT EDIT.
+// gensimd version: devel
+// gensimd command: gensimd -f bool_test.go -fn "boolt0, boolt1, boolt2, boolt3, boolt4, boolt5" -o bool_test_amd64.s -outfn "boolt0s, boolt1s, boolt2s, boolt3s, boolt4s, boolt5s"
+// gensimd source: bool_test.go sha256:ddad8c8b2e68b66a30277efc49b085296be4273a3447d749e69640dfd6d8a965
+// gensimd target: avx2
+
+//go:build amd64 && !noasm && !appengine
+// +build amd64,!noasm,!appengine
 
 #include "textflag.h"
 
 TEXT ·boolt0s(SB),$8-9
-        MOVB         $0, ret0+8(FP)
 block0:
+        // entry
         MOVBQZX      x+0(FP), R15
-        MOVB         R15, ret0+8(FP)
+        MOVB         R15, ret+8(FP)
         RET
 
 TEXT ·boolt1s(SB),$8-9
-        MOVB         $0, ret0+8(FP)
 block0:
+        // entry
         MOVBQZX      x+0(FP), R15
         XORQ         $1, R15
-        MOVB         R15, ret0+8(FP)
+        MOVB         R15, ret+8(FP)
         RET
 
 TEXT ·boolt2s(SB),$8-9
-        MOVB         $0, ret0+8(FP)
 block0:
+        // entry
         MOVBQZX      x+0(FP), R15
-        MOVB         $0, R14
-        MOVB         R14, t0-1(SP)
+        MOVB         $0, R13
+        MOVB         R13, t0-1(SP)
         CMPB         R15, $0
         JEQ          block2
-        JMP          block1
 block1:
+        // binop.rhs, preds block0
         MOVBQZX      y+1(FP), R15
         MOVB         R15, t0-1(SP)
-        JMP block2
 block2:
+        // binop.done, preds block0 block1
         MOVBQZX      t0-1(SP), R15
-        MOVB         R15, ret0+8(FP)
+        MOVB         R15, ret+8(FP)
         RET
 
 TEXT ·boolt3s(SB),$8-9
-        MOVB         $0, ret0+8(FP)
 block0:
+        // entry
         MOVBQZX      x+0(FP), R15
         CMPB         R15, $0
         JEQ          block1
         MOVB         $1, R15
         MOVB         R15, t0-1(SP)
-        JMP          block2
+block2:
+        // binop.done, preds block0 block1
+        MOVBQZX      t0-1(SP), R15
+        MOVB         R15, ret+8(FP)
+        RET
 block1:
+        // binop.rhs, preds block0
         MOVBQZX      y+1(FP), R15
         MOVB         R15, t0-1(SP)
         JMP block2
-block2:
-        MOVBQZX      t0-1(SP), R15
-        MOVB         R15, ret0+8(FP)
-        RET
 
 TEXT ·boolt4s(SB),$8-9
-        MOVB         $0, ret0+8(FP)
 block0:
+        // entry
         MOVBQZX      x+0(FP), R15
         CMPB         R15, $0
         JEQ          block1
         MOVB         $1, R15
         MOVB         R15, t0-1(SP)
-        JMP          block2
+block2:
+        // binop.done, preds block0 block1
+        MOVBQZX      t0-1(SP), R15
+        MOVB         R15, ret+8(FP)
+        RET
 block1:
+        // binop.rhs, preds block0
         MOVBQZX      y+1(FP), R15
         MOVB         R15, t0-1(SP)
         JMP block2
-block2:
-        MOVBQZX      t0-1(SP), R15
-        MOVB         R15, ret0+8(FP)
-        RET
 
 TEXT ·boolt5s(SB),$8-9
-        MOVB         $0, ret0+8(FP)
 block0:
+        // entry
         MOVBQZX      x+0(FP), R15
         CMPB         R15, $0
         JEQ          block1
         MOVB         $1, R15
         MOVB         R15, t0-1(SP)
-        JMP          block2
+block2:
+        // binop.done, preds block0 block1
+        MOVBQZX      t0-1(SP), R15
+        MOVB         R15, ret+8(FP)
+        RET
 block1:
+        // binop.rhs, preds block0
         MOVBQZX      y+1(FP), R15
         MOVB         R15, t0-1(SP)
         JMP block2
-block2:
-        MOVBQZX      t0-1(SP), R15
-        MOVB         R15, ret0+8(FP)
-        RET
 
@@ -1,35 +1,33 @@
-// +build amd64 !noasm !appengine
+// Code generated by gensimd. DO NOT EDIT.
+// gensimd version: devel
+// gensimd command: gensimd -f builtin_test.go -fn "lent0, lent1, lent2" -o builtin_test_amd64.s -outfn "lent0s, lent1s, lent2s"
+// gensimd source: builtin_test.go sha256:4b18c627918342a7b211201869687d996e8c728323c34dc5b13e2c888a189f93
+// gensimd target: avx2
+
+//go:build amd64 && !noasm && !appengine
+// +build amd64,!noasm,!appengine
 
 #include "textflag.h"
 
-TEXT ·lent0s(SB),$16-16
-        MOVQ         $0, ret0+8(FP)
-        MOVQ         $0, t0-8(SP)
+TEXT ·lent0s(SB),$8-16
 block0:
-        MOVQ         x+0(FP), R15
-        MOVQ         R15, R14
-        MOVQ         $1, R13
-        MOVQ         R13, ret0+8(FP)
+        // entry
+        MOVQ         $1, R15
+        MOVQ         R15, ret+8(FP)
         RET
 
-TEXT ·lent1s(SB),$24-24
-        MOVQ         $0, ret0+16(FP)
-        MOVQ         $0, t0-16(SP)
-        MOVQ         $0, t0-8(SP)
+TEXT ·lent1s(SB),$8-24
 block0:
-        MOVQ         x+0(FP), R15
-        MOVQ         R15, R14
-        MOVQ         x+8(FP), R13
-        MOVQ         R13, R12
-        MOVQ         $2, R11
-        MOVQ         R11, ret0+16(FP)
+        // entry
+        MOVQ         $2, R15
+        MOVQ         R15, ret+16(FP)
         RET
 
 TEXT ·lent2s(SB),$16-32
-        MOVQ         $0, ret0+24(FP)
 block0:
-        MOVQ         x+8(FP), R15
-        MOVQ         R15, R14
-        MOVQ         R14, ret0+24(FP)
+        // entry
+        MOVQ         x_len+8(FP), R15
+        MOVQ         R15, R13
+        MOVQ         R13, ret+24(FP)
         RET
 
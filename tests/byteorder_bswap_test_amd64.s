@@ -0,0 +1,103 @@
+// Code generated by gensimd. DO NOT EDIT.
+// gensimd version: devel
+// gensimd command: gensimd -boundscheck -f byteorder_test.go -fn "byteordert0, byteordert1, byteordert2, byteordert3, byteordert4" -o byteorder_bswap_test_amd64.s -outfn "byteordert0b, byteordert1b, byteordert2b, byteordert3b, byteordert4b" -target sse4.1
+// gensimd source: byteorder_test.go sha256:ce1058ac97b99b454b70621321021f3b30516e4e0e4200c55f2ba9fd27b08fb1
+// gensimd target: sse4.1
+
+//go:build amd64 && !noasm && !appengine
+// +build amd64,!noasm,!appengine
+
+#include "textflag.h"
+
+TEXT ·byteordert0b(SB),$8-28
+block0:
+        // entry
+        MOVQ         b_len+8(FP), R15
+        CMPQ         R15, $4
+        JCS          boundsfault
+        MOVQ         b_base+0(FP), R15
+        MOVL         (R15), R13
+        BSWAPL       R13
+        MOVL         R13, ret+24(FP)
+        RET
+boundsfault:
+        INT          $3
+
+TEXT ·byteordert1b(SB),$32-32
+block0:
+        // entry
+        MOVQ         b_len+8(FP), R15
+        CMPQ         R15, $8
+        JCS          boundsfault
+        MOVQ         b_base+0(FP), R15
+        MOVQ         (R15), R13
+        BSWAPQ       R13
+        MOVQ         b_len+8(FP), R12
+        CMPQ         R12, $8
+        JCS          boundsfault
+        MOVQ         (R15), R12
+        XORQ         R12, R13
+        MOVQ         R13, ret+24(FP)
+        RET
+boundsfault:
+        INT          $3
+
+TEXT ·byteordert2b(SB),$8-28
+block0:
+        // entry
+        MOVQ         b_len+8(FP), R15
+        CMPQ         R15, $4
+        JCS          boundsfault
+        MOVQ         b_base+0(FP), R15
+        MOVLQZX      v+24(FP), R13
+        MOVQ         R13, R12
+        BSWAPL       R12
+        MOVL         R12, (R15)
+        RET
+boundsfault:
+        INT          $3
+
+TEXT ·byteordert3b(SB),$16-32
+block0:
+        // entry
+        MOVQ         v+24(FP), R15
+        MOVQ         R15, R13
+        ADDQ         $1, R13
+        MOVQ         b_len+8(FP), R12
+        CMPQ         R12, $8
+        JCS          boundsfault
+        MOVQ         b_base+0(FP), R12
+        MOVQ         R13, R11
+        BSWAPQ       R11
+        MOVQ         R11, (R12)
+        MOVQ         b_len+8(FP), R11
+        CMPQ         R11, $8
+        JCS          boundsfault
+        MOVQ         R15, (R12)
+        RET
+boundsfault:
+        INT          $3
+
+TEXT ·byteordert4b(SB),$24-28
+block0:
+        // entry
+        MOVQ         b_len+8(FP), R15
+        CMPQ         R15, $4
+        JCS          boundsfault
+        MOVQ         b_base+0(FP), R15
+        MOVL         (R15), R13
+        MOVL         $31, R12
+        MOVL         R13, AX
+        MULL         R12
+        MOVL         AX, R13
+        MOVQ         b_len+8(FP), R11
+        CMPQ         R11, $4
+        JCS          boundsfault
+        MOVL         (R15), R11
+        BSWAPL       R11
+        ADDL         R11, R13
+        MOVL         R13, ret+24(FP)
+        RET
+boundsfault:
+        INT          $3
+
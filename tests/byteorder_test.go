@@ -0,0 +1,87 @@
+// +build amd64,gc
+
+package tests
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/bjwbell/gensimd/simd"
+)
+
+//go:generate gensimd -fn "byteordert0, byteordert1, byteordert2, byteordert3, byteordert4" -outfn "byteordert0s, byteordert1s, byteordert2s, byteordert3s, byteordert4s" -f "$GOFILE" -o "byteorder_test_amd64.s"
+//go:generate gensimd -target sse4.1 -boundscheck -fn "byteordert0, byteordert1, byteordert2, byteordert3, byteordert4" -outfn "byteordert0b, byteordert1b, byteordert2b, byteordert3b, byteordert4b" -f "$GOFILE" -o "byteorder_bswap_test_amd64.s"
+
+// MOVBE, the default avx2 target has it
+func byteordert0s(b []byte) uint32
+func byteordert1s(b []byte) uint64
+func byteordert2s(b []byte, v uint32)
+func byteordert3s(b []byte, v uint64)
+func byteordert4s(b []byte) uint32
+
+// BSWAP and length checks
+func byteordert0b(b []byte) uint32
+func byteordert1b(b []byte) uint64
+func byteordert2b(b []byte, v uint32)
+func byteordert3b(b []byte, v uint64)
+func byteordert4b(b []byte) uint32
+
+func byteordert0(b []byte) uint32 {
+	return simd.LoadU32BE(b)
+}
+
+func byteordert1(b []byte) uint64 {
+	return simd.LoadU64BE(b) ^ simd.LoadU64LE(b)
+}
+
+func byteordert2(b []byte, v uint32) {
+	simd.StoreU32BE(b, v)
+}
+
+func byteordert3(b []byte, v uint64) {
+	simd.StoreU64BE(b, v+1)
+	simd.StoreU64LE(b, v)
+}
+
+// a hash step, x * 31 + the next word
+func byteordert4(b []byte) uint32 {
+	return simd.LoadU32LE(b)*31 + simd.LoadU32BE(b)
+}
+
+func TestByteOrder(t *testing.T) {
+	type funcs struct {
+		name string
+		t0   func([]byte) uint32
+		t1   func([]byte) uint64
+		t2   func([]byte, uint32)
+		t3   func([]byte, uint64)
+		t4   func([]byte) uint32
+	}
+	tests := []funcs{{"bswap", byteordert0b, byteordert1b, byteordert2b, byteordert3b, byteordert4b}}
+	if simd.MOVBE() {
+		tests = append(tests, funcs{"movbe", byteordert0s, byteordert1s, byteordert2s, byteordert3s, byteordert4s})
+	}
+	b := []byte{0x01, 0x23, 0x45, 0x67, 0x89, 0xab, 0xcd, 0xef, 0xff}
+	for _, fns := range tests {
+		if got, want := fns.t0(b), byteordert0(b); got != want {
+			t.Errorf("%v t0 %#x != %#x", fns.name, got, want)
+		}
+		if got, want := fns.t1(b), byteordert1(b); got != want {
+			t.Errorf("%v t1 %#x != %#x", fns.name, got, want)
+		}
+		if got, want := fns.t4(b), byteordert4(b); got != want {
+			t.Errorf("%v t4 %#x != %#x", fns.name, got, want)
+		}
+		got, want := make([]byte, 9), make([]byte, 9)
+		fns.t2(got, 0xdeadbeef)
+		binary.BigEndian.PutUint32(want, 0xdeadbeef)
+		if string(got) != string(want) {
+			t.Errorf("%v t2 %x != %x", fns.name, got, want)
+		}
+		fns.t3(got, 0x0102030405060708)
+		byteordert3(want, 0x0102030405060708)
+		if string(got) != string(want) {
+			t.Errorf("%v t3 %x != %x", fns.name, got, want)
+		}
+	}
+}
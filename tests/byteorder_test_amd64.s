@@ -0,0 +1,62 @@
+// Code generated by gensimd. DO NOT EDIT.
+// gensimd version: devel
+// gensimd command: gensimd -f byteorder_test.go -fn "byteordert0, byteordert1, byteordert2, byteordert3, byteordert4" -o byteorder_test_amd64.s -outfn "byteordert0s, byteordert1s, byteordert2s, byteordert3s, byteordert4s"
+// gensimd source: byteorder_test.go sha256:ce1058ac97b99b454b70621321021f3b30516e4e0e4200c55f2ba9fd27b08fb1
+// gensimd target: avx2
+
+//go:build amd64 && !noasm && !appengine
+// +build amd64,!noasm,!appengine
+
+#include "textflag.h"
+
+TEXT ·byteordert0s(SB),$8-28
+block0:
+        // entry
+        MOVQ         b_base+0(FP), R15
+        MOVBELL      (R15), R13
+        MOVL         R13, ret+24(FP)
+        RET
+
+TEXT ·byteordert1s(SB),$32-32
+block0:
+        // entry
+        MOVQ         b_base+0(FP), R15
+        MOVBEQQ      (R15), R13
+        MOVQ         (R15), R12
+        XORQ         R12, R13
+        MOVQ         R13, ret+24(FP)
+        RET
+
+TEXT ·byteordert2s(SB),$8-28
+block0:
+        // entry
+        MOVQ         b_base+0(FP), R15
+        MOVLQZX      v+24(FP), R13
+        MOVBELL      R13, (R15)
+        RET
+
+TEXT ·byteordert3s(SB),$16-32
+block0:
+        // entry
+        MOVQ         v+24(FP), R15
+        MOVQ         R15, R13
+        ADDQ         $1, R13
+        MOVQ         b_base+0(FP), R12
+        MOVBEQQ      R13, (R12)
+        MOVQ         R15, (R12)
+        RET
+
+TEXT ·byteordert4s(SB),$24-28
+block0:
+        // entry
+        MOVQ         b_base+0(FP), R15
+        MOVL         (R15), R13
+        MOVL         $31, R12
+        MOVL         R13, AX
+        MULL         R12
+        MOVL         AX, R13
+        MOVBELL      (R15), R11
+        ADDL         R11, R13
+        MOVL         R13, ret+24(FP)
+        RET
+
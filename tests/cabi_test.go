@@ -0,0 +1,35 @@
+// +build amd64,gc
+
+package tests
+
+import (
+	"testing"
+
+	"github.com/bjwbell/gensimd/codegen"
+	"github.com/bjwbell/gensimd/internal/asmtest"
+)
+
+// TestCABI calls generated functions from C through their C entry points.
+func TestCABI(t *testing.T) {
+	opts := codegen.DefaultOptions()
+	opts.NoSplit = true
+	const csrc = `
+static void run(void) {
+	int32_t x[5] = {1, -2, 3, 40, 500};
+	printf("sum %d\n", cabi_sum(x, 5, 5));
+	float xs[8] = {1, 2, 3, 4, 5, 6, 7, 8}, ys[8] = {1, 1, 1, 1, 1, 1, 1, 1};
+	cabi_axpy(0.5f, xs, 2, 2, ys, 2, 2);
+	printf("axpy %g %g %g\n", ys[0], ys[3], ys[7]);
+	double f = 0;
+	double r = cabi_mix(-3, 60000, -100000, 1LL << 40, 4000000000u, &f, -7, 1, 2, 3, 4, 5, 6, 7, 8, 0.25f);
+	printf("mix %.17g %.17g\n", r, f);
+	printf("neg %d %d\n", cabi_neg(5), cabi_neg(-32768));
+	printf("less %d %d\n", cabi_less(1, 200), cabi_less(200, 1));
+}
+`
+	got := asmtest.RunC(t, "testdata/cabi.go", opts, csrc, "sum", "axpy", "mix", "neg", "less")
+	expected := "sum 542\naxpy 1.5 3 5\nmix 36.25 1103511587766\nneg -5 -32768\nless 1 0\n"
+	if got != expected {
+		t.Errorf("C program output:\n%v\nexpected:\n%v", got, expected)
+	}
+}
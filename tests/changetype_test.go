@@ -0,0 +1,40 @@
+// +build amd64,gc
+
+package tests
+
+import "testing"
+
+//go:generate gensimd -fn "changetypet0, changetypet1, changetypet2" -outfn "changetypet0s, changetypet1s, changetypet2s" -f "$GOFILE" -o "changetype_test_amd64.s"
+
+type sample int16
+type gain float32
+
+func changetypet0s(s sample) int16
+func changetypet1s(x int16) sample
+func changetypet2s(gn gain, x float32) float32
+
+func changetypet0(s sample) int16 {
+	return int16(s) * 2
+}
+
+func changetypet1(x int16) sample {
+	return sample(x + 1)
+}
+
+func changetypet2(gn gain, x float32) float32 {
+	return float32(gn) * x
+}
+
+func TestChangeType(t *testing.T) {
+	for _, s := range []sample{0, 1, -3, 1 << 14, -1 << 15} {
+		if changetypet0s(s) != changetypet0(s) {
+			t.Errorf("changetypet0s(%v) %v != %v", s, changetypet0s(s), changetypet0(s))
+		}
+		if changetypet1s(int16(s)) != changetypet1(int16(s)) {
+			t.Errorf("changetypet1s(%v) %v != %v", s, changetypet1s(int16(s)), changetypet1(int16(s)))
+		}
+	}
+	if changetypet2s(2, 1.5) != changetypet2(2, 1.5) {
+		t.Errorf("changetypet2s(2, 1.5) %v != %v", changetypet2s(2, 1.5), changetypet2(2, 1.5))
+	}
+}
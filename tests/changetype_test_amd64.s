@@ -0,0 +1,42 @@
+// Code generated by gensimd. DO NOT EDIT.
+// gensimd version: devel
+// gensimd command: gensimd -f changetype_test.go -fn "changetypet0, changetypet1, changetypet2" -o changetype_test_amd64.s -outfn "changetypet0s, changetypet1s, changetypet2s"
+// gensimd source: changetype_test.go sha256:f5798e64e47a40ca616fa60a065174ecba4dd0817f18f23719396468c4e6b2b9
+// gensimd target: avx2
+
+//go:build amd64 && !noasm && !appengine
+// +build amd64,!noasm,!appengine
+
+#include "textflag.h"
+
+TEXT ·changetypet0s(SB),$8-10
+block0:
+        // entry
+        MOVWQZX      s+0(FP), R15
+        MOVW         R15, R13
+        MOVW         $2, R12
+        MOVW         R13, AX
+        IMULW        R12
+        MOVW         AX, R13
+        MOVW         R13, ret+8(FP)
+        RET
+
+TEXT ·changetypet1s(SB),$8-10
+block0:
+        // entry
+        MOVWQZX      x+0(FP), R15
+        MOVW         R15, R13
+        ADDW         $1, R13
+        MOVW         R13, ret+8(FP)
+        RET
+
+TEXT ·changetypet2s(SB),$16-12
+block0:
+        // entry
+        MOVSS        gn+0(FP), X14
+        MOVO         X14, X13
+        MOVSS        x+4(FP), X12
+        MULSS        X12, X13
+        MOVSS        X13, ret+8(FP)
+        RET
+
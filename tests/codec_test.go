@@ -0,0 +1,117 @@
+// +build amd64,gc
+
+package tests
+
+import (
+	"testing"
+
+	"github.com/bjwbell/gensimd/simd"
+)
+
+//go:generate gensimd -fn "codect0, codect1, codect2, codect3, codect4, codect5, codect6, codect7" -outfn "codect0s, codect1s, codect2s, codect3s, codect4s, codect5s, codect6s, codect7s" -f "$GOFILE" -o "codec_test_amd64.s"
+
+func codect0s(x simd.U8x16) simd.U8x16
+func codect1s(x simd.U8x16) simd.U8x16
+func codect2s(x simd.U8x16) simd.U8x16
+func codect3s(x, y simd.U8x16) simd.U8x16
+func codect4s(x, y simd.U8x16) simd.U8x16
+func codect5s(dst, src []byte, i int)
+func codect6s(x simd.U8x16) simd.U8x16
+func codect7s(x simd.U8x16) int
+
+// PSHUFB of a constant table, the hex digits of the low nibbles
+func codect0(x simd.U8x16) simd.U8x16 {
+	return simd.LookupU8x16(simd.AndU8x16(x, simd.SplatU8x16(15)), "0123456789abcdef")
+}
+
+// the ranges of hex digits, and the empty and full ranges
+func codect1(x simd.U8x16) simd.U8x16 {
+	m := simd.OrU8x16(simd.InRangeU8x16(x, '0', '9'), simd.InRangeU8x16(x, 'a', 'f'))
+	m = simd.XorU8x16(m, simd.InRangeU8x16(x, 'f', 'a'))
+	return simd.AndU8x16(m, simd.InRangeU8x16(x, 0, 255))
+}
+
+// each shift count
+func codect2(x simd.U8x16) simd.U8x16 {
+	v := simd.XorU8x16(simd.ShrU8x16(x, 0), simd.ShrU8x16(x, 1))
+	v = simd.AddU8x16(v, simd.ShrU8x16(x, 4))
+	v = simd.XorU8x16(v, simd.ShrU8x16(x, 7))
+	return simd.OrU8x16(v, simd.ShrU8x16(x, 8))
+}
+
+func codect3(x, y simd.U8x16) simd.U8x16 {
+	return simd.XorU8x16(simd.InterleaveLoU8x16(x, y), simd.ShrU8x16(simd.InterleaveHiU8x16(y, x), 1))
+}
+
+func codect4(x, y simd.U8x16) simd.U8x16 {
+	return simd.PackNibblesU8x16(x, y)
+}
+
+func codect5(dst, src []byte, i int) {
+	simd.StoreU8x16(dst, i, simd.LoadU8x16(src, i))
+}
+
+// base64 decoding of 16 bytes, the 6 bit values
+func codect6(x simd.U8x16) simd.U8x16 {
+	v := simd.AndU8x16(simd.SubU8x16(x, simd.SplatU8x16('A')), simd.InRangeU8x16(x, 'A', 'Z'))
+	v = simd.OrU8x16(v, simd.AndU8x16(simd.SubU8x16(x, simd.SplatU8x16('a'-26)), simd.InRangeU8x16(x, 'a', 'z')))
+	v = simd.OrU8x16(v, simd.AndU8x16(simd.AddU8x16(x, simd.SplatU8x16(52-'0')), simd.InRangeU8x16(x, '0', '9')))
+	v = simd.OrU8x16(v, simd.AndU8x16(simd.SplatU8x16(62), simd.CmpEqU8x16(x, simd.SplatU8x16('+'))))
+	return simd.OrU8x16(v, simd.AndU8x16(simd.SplatU8x16(63), simd.CmpEqU8x16(x, simd.SplatU8x16('/'))))
+}
+
+// and the mask of the invalid bytes
+func codect7(x simd.U8x16) int {
+	letter := simd.OrU8x16(simd.InRangeU8x16(x, 'A', 'Z'), simd.InRangeU8x16(x, 'a', 'z'))
+	symbol := simd.OrU8x16(simd.CmpEqU8x16(x, simd.SplatU8x16('+')), simd.CmpEqU8x16(x, simd.SplatU8x16('/')))
+	valid := simd.OrU8x16(letter, simd.OrU8x16(simd.InRangeU8x16(x, '0', '9'), symbol))
+	return simd.MoveMaskU8x16(valid) ^ 0xffff
+}
+
+func TestCodec(t *testing.T) {
+	inputs := []simd.U8x16{
+		{0, 1, 2, 9, 10, 15, 16, 0x7f, 0x80, 0x8f, 0xf0, 0xff, '0', '9', 'a', 'f'},
+		{'/', '0', '9', ':', '@', 'A', 'F', 'G', '`', 'a', 'f', 'g', 'z', '{', '+', '='},
+		{'S', 'G', 'V', 's', 'b', 'G', '8', 'g', 'd', '2', '9', 'y', 'b', 'G', 'Q', 'h'},
+	}
+	for _, x := range inputs {
+		for _, y := range inputs {
+			if codect3s(x, y) != codect3(x, y) {
+				t.Errorf("codect3s(%v, %v) %v != %v", x, y, codect3s(x, y), codect3(x, y))
+			}
+			if codect4s(x, y) != codect4(x, y) {
+				t.Errorf("codect4s(%v, %v) %v != %v", x, y, codect4s(x, y), codect4(x, y))
+			}
+		}
+		if codect1s(x) != codect1(x) {
+			t.Errorf("codect1s(%v) %v != %v", x, codect1s(x), codect1(x))
+		}
+		if codect2s(x) != codect2(x) {
+			t.Errorf("codect2s(%v) %v != %v", x, codect2s(x), codect2(x))
+		}
+		if codect6s(x) != codect6(x) {
+			t.Errorf("codect6s(%v) %v != %v", x, codect6s(x), codect6(x))
+		}
+		if codect7s(x) != codect7(x) {
+			t.Errorf("codect7s(%v) %#x != %#x", x, codect7s(x), codect7(x))
+		}
+	}
+	src := []byte("0123456789abcdefghijklmnopqrstuvwxyz")
+	for i := 0; i+16 <= len(src); i += 5 {
+		dst := make([]byte, len(src))
+		codect5s(dst, src, i)
+		for j := range dst {
+			if inside := j >= i && j < i+16; inside && dst[j] != src[j] || !inside && dst[j] != 0 {
+				t.Errorf("codect5s(%v) dst[%v] = %v", i, j, dst[j])
+			}
+		}
+	}
+	if !simd.SSSE3() {
+		t.Skip("PSHUFB needs SSSE3")
+	}
+	for _, x := range inputs {
+		if codect0s(x) != codect0(x) {
+			t.Errorf("codect0s(%v) %v != %v", x, codect0s(x), codect0(x))
+		}
+	}
+}
@@ -0,0 +1,386 @@
+// Code generated by gensimd. DO NOT EDIT.
+// gensimd version: devel
+// gensimd command: gensimd -f codec_test.go -fn "codect0, codect1, codect2, codect3, codect4, codect5, codect6, codect7" -o codec_test_amd64.s -outfn "codect0s, codect1s, codect2s, codect3s, codect4s, codect5s, codect6s, codect7s"
+// gensimd source: codec_test.go sha256:6f20ebbe3c4cbef185020b8e4f51836691bce69968da7fdd15916bdda1dd52bf
+// gensimd target: avx2
+
+//go:build amd64 && !noasm && !appengine
+// +build amd64,!noasm,!appengine
+
+#include "textflag.h"
+
+TEXT ·codect0s(SB),$56-32
+block0:
+        // entry
+        MOVB         $15, R15
+        MOVBQZX      R15, R13
+        IMUL3Q       $16843009, R13, R13
+        MOVQ         R13, X14
+        PSHUFL       $0, X14, X14
+        MOVUPS       x+0(FP), X13
+        MOVO         X13, X12
+        PAND         X14, X12
+        MOVOU        codect0s_const0<>(SB), X11
+        PSHUFB       X12, X11
+        MOVUPS       X11, ret+16(FP)
+        RET
+
+
+DATA codect0s_const0<>+0(SB)/8, $0x3736353433323130
+DATA codect0s_const0<>+8(SB)/8, $0x6665646362613938
+GLOBL codect0s_const0<>(SB), RODATA|NOPTR, $16
+
+TEXT ·codect1s(SB),$120-32
+block0:
+        // entry
+        MOVUPS       x+0(FP), X14
+        MOVO         X14, X13
+        MOVOU        codect1s_const0<>(SB), X12
+        PSUBB        X12, X13
+        MOVOU        codect1s_const1<>(SB), X12
+        PMINUB       X13, X12
+        PCMPEQB      X12, X13
+        MOVO         X14, X12
+        MOVOU        codect1s_const2<>(SB), X11
+        PSUBB        X11, X12
+        MOVOU        codect1s_const3<>(SB), X11
+        PMINUB       X12, X11
+        PCMPEQB      X11, X12
+        MOVO         X13, X11
+        POR          X12, X11
+        PXOR         X10, X10
+        MOVO         X11, X9
+        PXOR         X10, X9
+        MOVO         X14, X8
+        MOVOU        codect1s_const4<>(SB), X7
+        PMINUB       X8, X7
+        PCMPEQB      X7, X8
+        MOVO         X9, X7
+        PAND         X8, X7
+        MOVUPS       X7, ret+16(FP)
+        RET
+
+
+DATA codect1s_const0<>+0(SB)/8, $0x3030303030303030
+DATA codect1s_const0<>+8(SB)/8, $0x3030303030303030
+GLOBL codect1s_const0<>(SB), RODATA|NOPTR, $16
+
+DATA codect1s_const1<>+0(SB)/8, $0x0909090909090909
+DATA codect1s_const1<>+8(SB)/8, $0x0909090909090909
+GLOBL codect1s_const1<>(SB), RODATA|NOPTR, $16
+
+DATA codect1s_const2<>+0(SB)/8, $0x6161616161616161
+DATA codect1s_const2<>+8(SB)/8, $0x6161616161616161
+GLOBL codect1s_const2<>(SB), RODATA|NOPTR, $16
+
+DATA codect1s_const3<>+0(SB)/8, $0x0505050505050505
+DATA codect1s_const3<>+8(SB)/8, $0x0505050505050505
+GLOBL codect1s_const3<>(SB), RODATA|NOPTR, $16
+
+DATA codect1s_const4<>+0(SB)/8, $0xffffffffffffffff
+DATA codect1s_const4<>+8(SB)/8, $0xffffffffffffffff
+GLOBL codect1s_const4<>(SB), RODATA|NOPTR, $16
+
+TEXT ·codect2s(SB),$152-32
+block0:
+        // entry
+        MOVUPS       x+0(FP), X14
+        MOVOU        codect2s_const0<>(SB), X12
+        MOVO         X14, X13
+        PSRLW        $0, X13
+        PAND         X12, X13
+        MOVOU        codect2s_const1<>(SB), X11
+        MOVO         X14, X12
+        PSRLW        $1, X12
+        PAND         X11, X12
+        MOVO         X13, X11
+        PXOR         X12, X11
+        MOVOU        codect2s_const2<>(SB), X9
+        MOVO         X14, X10
+        PSRLW        $4, X10
+        PAND         X9, X10
+        MOVOU        X11, t2-48(SP)
+        PADDB        X10, X11
+        MOVOU        codect2s_const3<>(SB), X8
+        MOVO         X14, X9
+        PSRLW        $7, X9
+        PAND         X8, X9
+        MOVO         X11, X8
+        PXOR         X9, X8
+        PXOR         X7, X7
+        MOVO         X8, X6
+        POR          X7, X6
+        MOVUPS       X6, ret+16(FP)
+        RET
+
+
+DATA codect2s_const0<>+0(SB)/8, $0xffffffffffffffff
+DATA codect2s_const0<>+8(SB)/8, $0xffffffffffffffff
+GLOBL codect2s_const0<>(SB), RODATA|NOPTR, $16
+
+DATA codect2s_const1<>+0(SB)/8, $0x7f7f7f7f7f7f7f7f
+DATA codect2s_const1<>+8(SB)/8, $0x7f7f7f7f7f7f7f7f
+GLOBL codect2s_const1<>(SB), RODATA|NOPTR, $16
+
+DATA codect2s_const2<>+0(SB)/8, $0x0f0f0f0f0f0f0f0f
+DATA codect2s_const2<>+8(SB)/8, $0x0f0f0f0f0f0f0f0f
+GLOBL codect2s_const2<>(SB), RODATA|NOPTR, $16
+
+DATA codect2s_const3<>+0(SB)/8, $0x0101010101010101
+DATA codect2s_const3<>+8(SB)/8, $0x0101010101010101
+GLOBL codect2s_const3<>(SB), RODATA|NOPTR, $16
+
+TEXT ·codect3s(SB),$72-48
+block0:
+        // entry
+        MOVUPS       x+0(FP), X14
+        MOVUPS       y+16(FP), X13
+        MOVO         X14, X12
+        PUNPCKLBW    X13, X12
+        MOVO         X13, X11
+        PUNPCKHBW    X14, X11
+        MOVOU        codect3s_const0<>(SB), X9
+        MOVO         X11, X10
+        PSRLW        $1, X10
+        PAND         X9, X10
+        MOVO         X12, X9
+        PXOR         X10, X9
+        MOVUPS       X9, ret+32(FP)
+        RET
+
+
+DATA codect3s_const0<>+0(SB)/8, $0x7f7f7f7f7f7f7f7f
+DATA codect3s_const0<>+8(SB)/8, $0x7f7f7f7f7f7f7f7f
+GLOBL codect3s_const0<>(SB), RODATA|NOPTR, $16
+
+TEXT ·codect4s(SB),$24-48
+block0:
+        // entry
+        MOVOU        codect4s_const0<>(SB), X14
+        MOVOU        codect4s_const1<>(SB), X13
+        MOVUPS       x+0(FP), X11
+        MOVO         X11, X10
+        PAND         X14, X10
+        MOVO         X10, X12
+        PSLLW        $4, X10
+        PSRLW        $8, X12
+        POR          X12, X10
+        PAND         X13, X10
+        MOVUPS       y+16(FP), X9
+        MOVO         X9, X8
+        PAND         X14, X8
+        MOVO         X8, X12
+        PSLLW        $4, X8
+        PSRLW        $8, X12
+        POR          X12, X8
+        PAND         X13, X8
+        PACKUSWB     X8, X10
+        MOVUPS       X10, ret+32(FP)
+        RET
+
+
+DATA codect4s_const0<>+0(SB)/8, $0x0f0f0f0f0f0f0f0f
+DATA codect4s_const0<>+8(SB)/8, $0x0f0f0f0f0f0f0f0f
+GLOBL codect4s_const0<>(SB), RODATA|NOPTR, $16
+
+DATA codect4s_const1<>+0(SB)/8, $0x00ff00ff00ff00ff
+DATA codect4s_const1<>+8(SB)/8, $0x00ff00ff00ff00ff
+GLOBL codect4s_const1<>(SB), RODATA|NOPTR, $16
+
+TEXT ·codect5s(SB),$24-56
+block0:
+        // entry
+        MOVQ         src_base+24(FP), R15
+        MOVQ         i+48(FP), R13
+        MOVOU        (R15)(R13*1), X14
+        MOVQ         dst_base+0(FP), R12
+        MOVOU        X14, (R12)(R13*1)
+        RET
+
+TEXT ·codect6s(SB),$392-32
+block0:
+        // entry
+        MOVB         $65, R15
+        MOVBQZX      R15, R13
+        IMUL3Q       $16843009, R13, R13
+        MOVQ         R13, X14
+        PSHUFL       $0, X14, X14
+        MOVUPS       x+0(FP), X13
+        PSUBB        X14, X13
+        MOVUPS       x+0(FP), X12
+        MOVO         X12, X11
+        MOVOU        codect6s_const0<>(SB), X10
+        PSUBB        X10, X11
+        MOVOU        codect6s_const1<>(SB), X10
+        PMINUB       X11, X10
+        PCMPEQB      X10, X11
+        MOVO         X13, X10
+        PAND         X11, X10
+        MOVB         $71, R13
+        MOVBQZX      R13, R12
+        IMUL3Q       $16843009, R12, R12
+        MOVQ         R12, X9
+        PSHUFL       $0, X9, X9
+        PSUBB        X9, X12
+        MOVUPS       x+0(FP), X8
+        MOVO         X8, X7
+        MOVOU        codect6s_const2<>(SB), X6
+        PSUBB        X6, X7
+        MOVOU        codect6s_const1<>(SB), X6
+        PMINUB       X7, X6
+        PCMPEQB      X6, X7
+        MOVO         X12, X6
+        PAND         X7, X6
+        MOVO         X10, X5
+        POR          X6, X5
+        MOVB         $4, R12
+        MOVBQZX      R12, R11
+        IMUL3Q       $16843009, R11, R11
+        MOVQ         R11, X4
+        PSHUFL       $0, X4, X4
+        PADDB        X4, X8
+        MOVUPS       x+0(FP), X3
+        MOVO         X3, X2
+        MOVOU        codect6s_const3<>(SB), X1
+        PSUBB        X1, X2
+        MOVOU        codect6s_const4<>(SB), X1
+        PMINUB       X2, X1
+        PCMPEQB      X1, X2
+        MOVO         X8, X1
+        PAND         X2, X1
+        MOVO         X5, X0
+        POR          X1, X0
+        MOVB         $62, R11
+        MOVBQZX      R11, R10
+        IMUL3Q       $16843009, R10, R10
+        MOVOU        X0, t13-224(SP)
+        MOVQ         R10, X0
+        PSHUFL       $0, X0, X0
+        MOVB         $43, R10
+        MOVBQZX      R10, R9
+        IMUL3Q       $16843009, R9, R9
+        MOVOU        X0, t14-240(SP)
+        MOVQ         R9, X0
+        PSHUFL       $0, X0, X0
+        MOVO         X3, X1
+        PCMPEQB      X0, X1
+        MOVOU        t14-240(SP), X0
+        MOVO         X0, X2
+        PAND         X1, X2
+        MOVOU        t13-224(SP), X0
+        MOVO         X0, X1
+        POR          X2, X1
+        MOVB         $63, R9
+        MOVBQZX      R9, R8
+        IMUL3Q       $16843009, R8, R8
+        MOVQ         R8, X0
+        PSHUFL       $0, X0, X0
+        MOVB         $47, R8
+        MOVBQZX      R8, BP
+        IMUL3Q       $16843009, BP, BP
+        MOVOU        X0, t19-320(SP)
+        MOVQ         BP, X0
+        PSHUFL       $0, X0, X0
+        MOVOU        X1, t18-304(SP)
+        MOVO         X3, X1
+        PCMPEQB      X0, X1
+        MOVOU        t19-320(SP), X0
+        MOVO         X0, X2
+        PAND         X1, X2
+        MOVOU        t18-304(SP), X0
+        MOVO         X0, X1
+        POR          X2, X1
+        MOVUPS       X1, ret+16(FP)
+        RET
+
+
+DATA codect6s_const0<>+0(SB)/8, $0x4141414141414141
+DATA codect6s_const0<>+8(SB)/8, $0x4141414141414141
+GLOBL codect6s_const0<>(SB), RODATA|NOPTR, $16
+
+DATA codect6s_const1<>+0(SB)/8, $0x1919191919191919
+DATA codect6s_const1<>+8(SB)/8, $0x1919191919191919
+GLOBL codect6s_const1<>(SB), RODATA|NOPTR, $16
+
+DATA codect6s_const2<>+0(SB)/8, $0x6161616161616161
+DATA codect6s_const2<>+8(SB)/8, $0x6161616161616161
+GLOBL codect6s_const2<>(SB), RODATA|NOPTR, $16
+
+DATA codect6s_const3<>+0(SB)/8, $0x3030303030303030
+DATA codect6s_const3<>+8(SB)/8, $0x3030303030303030
+GLOBL codect6s_const3<>(SB), RODATA|NOPTR, $16
+
+DATA codect6s_const4<>+0(SB)/8, $0x0909090909090909
+DATA codect6s_const4<>+8(SB)/8, $0x0909090909090909
+GLOBL codect6s_const4<>(SB), RODATA|NOPTR, $16
+
+TEXT ·codect7s(SB),$200-24
+block0:
+        // entry
+        MOVUPS       x+0(FP), X14
+        MOVO         X14, X13
+        MOVOU        codect7s_const0<>(SB), X12
+        PSUBB        X12, X13
+        MOVOU        codect7s_const1<>(SB), X12
+        PMINUB       X13, X12
+        PCMPEQB      X12, X13
+        MOVO         X14, X12
+        MOVOU        codect7s_const2<>(SB), X11
+        PSUBB        X11, X12
+        MOVOU        codect7s_const1<>(SB), X11
+        PMINUB       X12, X11
+        PCMPEQB      X11, X12
+        MOVO         X13, X11
+        POR          X12, X11
+        MOVB         $43, R15
+        MOVBQZX      R15, R13
+        IMUL3Q       $16843009, R13, R13
+        MOVQ         R13, X10
+        PSHUFL       $0, X10, X10
+        MOVO         X14, X9
+        PCMPEQB      X10, X9
+        MOVB         $47, R13
+        MOVBQZX      R13, R12
+        IMUL3Q       $16843009, R12, R12
+        MOVQ         R12, X8
+        PSHUFL       $0, X8, X8
+        MOVO         X14, X7
+        PCMPEQB      X8, X7
+        MOVO         X9, X6
+        POR          X7, X6
+        MOVO         X14, X5
+        MOVOU        codect7s_const3<>(SB), X4
+        PSUBB        X4, X5
+        MOVOU        codect7s_const4<>(SB), X4
+        PMINUB       X5, X4
+        PCMPEQB      X4, X5
+        MOVO         X5, X4
+        POR          X6, X4
+        MOVO         X11, X3
+        POR          X4, X3
+        PMOVMSKB     X3, R12
+        XORQ         $65535, R12
+        MOVQ         R12, ret+16(FP)
+        RET
+
+
+DATA codect7s_const0<>+0(SB)/8, $0x4141414141414141
+DATA codect7s_const0<>+8(SB)/8, $0x4141414141414141
+GLOBL codect7s_const0<>(SB), RODATA|NOPTR, $16
+
+DATA codect7s_const1<>+0(SB)/8, $0x1919191919191919
+DATA codect7s_const1<>+8(SB)/8, $0x1919191919191919
+GLOBL codect7s_const1<>(SB), RODATA|NOPTR, $16
+
+DATA codect7s_const2<>+0(SB)/8, $0x6161616161616161
+DATA codect7s_const2<>+8(SB)/8, $0x6161616161616161
+GLOBL codect7s_const2<>(SB), RODATA|NOPTR, $16
+
+DATA codect7s_const3<>+0(SB)/8, $0x3030303030303030
+DATA codect7s_const3<>+8(SB)/8, $0x3030303030303030
+GLOBL codect7s_const3<>(SB), RODATA|NOPTR, $16
+
+DATA codect7s_const4<>+0(SB)/8, $0x0909090909090909
+DATA codect7s_const4<>+8(SB)/8, $0x0909090909090909
+GLOBL codect7s_const4<>(SB), RODATA|NOPTR, $16
+
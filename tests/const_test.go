@@ -0,0 +1,72 @@
+// +build amd64,gc
+
+package tests
+
+import "testing"
+
+//go:generate gensimd -fn "constt0, constt1, constt2, constt3, constt4, constt5" -outfn "constt0s, constt1s, constt2s, constt3s, constt4s, constt5s" -f "$GOFILE" -o "const_test_amd64.s"
+
+type level int32
+type ratio float32
+
+func constt0s(x int64) int64
+func constt1s(x int8) int8
+func constt2s(x float32) float32
+func constt3s(x float64) float64
+func constt4s(x int32) bool
+func constt5s(l level, r ratio) ratio
+
+// negative constants too large for the immediates
+func constt0(x int64) int64 {
+	return x * -1 * (-1 << 40)
+}
+
+func constt1(x int8) int8 {
+	return x * -128
+}
+
+func constt2(x float32) float32 {
+	return x*0.1 - 2
+}
+
+func constt3(x float64) float64 {
+	return (x + 1e-300) * -2.5e10 / 0
+}
+
+func constt4(x int32) bool {
+	b := false
+	if x < 0 {
+		b = true
+	}
+	return b
+}
+
+func constt5(l level, r ratio) ratio {
+	if l*-3 < -6 {
+		return r * -0.5
+	}
+	return r + 0.25
+}
+
+func TestConst(t *testing.T) {
+	for _, x := range []int64{0, 1, -1, 3, -3, 100, -128, 1 << 20} {
+		if constt0s(x) != constt0(x) {
+			t.Errorf("constt0s(%v) %v != %v", x, constt0s(x), constt0(x))
+		}
+		if constt1s(int8(x)) != constt1(int8(x)) {
+			t.Errorf("constt1s(%v) %v != %v", int8(x), constt1s(int8(x)), constt1(int8(x)))
+		}
+		if constt2s(float32(x)) != constt2(float32(x)) {
+			t.Errorf("constt2s(%v) %v != %v", float32(x), constt2s(float32(x)), constt2(float32(x)))
+		}
+		if constt3s(float64(x)) != constt3(float64(x)) {
+			t.Errorf("constt3s(%v) %v != %v", float64(x), constt3s(float64(x)), constt3(float64(x)))
+		}
+		if constt4s(int32(x)) != constt4(int32(x)) {
+			t.Errorf("constt4s(%v) %v != %v", int32(x), constt4s(int32(x)), constt4(int32(x)))
+		}
+		if constt5s(level(x), 1.5) != constt5(level(x), 1.5) {
+			t.Errorf("constt5s(%v, 1.5) %v != %v", level(x), constt5s(level(x), 1.5), constt5(level(x), 1.5))
+		}
+	}
+}
@@ -0,0 +1,110 @@
+// Code generated by gensimd. DO NOT EDIT.
+// gensimd version: devel
+// gensimd command: gensimd -f const_test.go -fn "constt0, constt1, constt2, constt3, constt4, constt5" -o const_test_amd64.s -outfn "constt0s, constt1s, constt2s, constt3s, constt4s, constt5s"
+// gensimd source: const_test.go sha256:aa41fb2b7f42ba821bdbd81ad6a71b2226801e3dbcdcf2add4da03ebefb498ba
+// gensimd target: avx2
+
+//go:build amd64 && !noasm && !appengine
+// +build amd64,!noasm,!appengine
+
+#include "textflag.h"
+
+TEXT ·constt0s(SB),$24-16
+block0:
+        // entry
+        MOVQ         x+0(FP), R15
+        MOVQ         $-1, R13
+        MOVQ         R15, R12
+        MOVQ         R12, AX
+        IMULQ        R13
+        MOVQ         AX, R12
+        MOVQ         $-1099511627776, R11
+        MOVQ         R12, AX
+        IMULQ        R11
+        MOVQ         AX, R12
+        MOVQ         R12, ret+8(FP)
+        RET
+
+TEXT ·constt1s(SB),$8-9
+block0:
+        // entry
+        MOVBQZX      x+0(FP), R15
+        MOVB         $-128, R13
+        MOVB         R15, R12
+        MOVB         R12, AX
+        IMULB        R13
+        MOVB         AX, R12
+        MOVB         R12, ret+8(FP)
+        RET
+
+TEXT ·constt2s(SB),$16-12
+block0:
+        // entry
+        MOVSS        x+0(FP), X14
+        MOVSS        $(0.1), X13
+        MOVO         X14, X12
+        MULSS        X13, X12
+        MOVSS        $(2.0), X11
+        SUBSS        X11, X12
+        MOVSS        X12, ret+8(FP)
+        RET
+
+TEXT ·constt3s(SB),$32-16
+block0:
+        // entry
+        MOVSD        x+0(FP), X14
+        MOVSD        $(1e-300), X13
+        MOVO         X14, X12
+        ADDSD        X13, X12
+        MOVSD        $(-2.5e+10), X11
+        MULSD        X11, X12
+        XORPD        X10, X10
+        DIVSD        X10, X12
+        MOVSD        X12, ret+8(FP)
+        RET
+
+TEXT ·constt4s(SB),$8-9
+block0:
+        // entry
+        // ssa.BinOp, t0 = x < 0:int32, compared by the select
+        MOVB         $0, R15
+        MOVB         R15, R13
+        MOVB         $1, R12
+        MOVLQZX      x+0(FP), R11
+        CMPL         R11, $0
+        CMOVLLT      R12, R13
+        MOVB         R13, t1-1(SP)
+block2:
+        // if.done, preds block0 block1
+        MOVBQZX      t1-1(SP), R15
+        MOVB         R15, ret+8(FP)
+        RET
+
+TEXT ·constt5s(SB),$16-12
+block0:
+        // entry
+        MOVLQZX      l+0(FP), R15
+        MOVL         $-3, R13
+        MOVL         R15, R12
+        MOVL         R12, AX
+        IMULL        R13
+        MOVL         AX, R12
+        CMPL         R12, $-6
+        JGE          block2
+block1:
+        // if.then, preds block0
+        MOVSS        r+4(FP), X14
+        MOVSS        $(-0.5), X13
+        MOVO         X14, X12
+        MULSS        X13, X12
+        MOVSS        X12, ret+8(FP)
+        RET
+block2:
+        // if.done, preds block0
+        MOVSS        r+4(FP), X14
+        MOVSS        $(0.25), X11
+        MOVO         X14, X10
+        ADDSS        X11, X10
+        MOVSS        X10, ret+8(FP)
+        RET
+
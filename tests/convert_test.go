@@ -9,115 +9,115 @@ import (
 
 //go:generate gensimd -fn "U8ToU8, U8ToU16, U8ToU32, U8ToU64, U8ToI8, U8ToI16, U8ToI32, U8ToI64, U8ToF32, U8ToF64, U16ToU8, U16ToU16, U16ToU32, U16ToU64, U16ToI8, U16ToI16, U16ToI32, U16ToI64, U16ToF32, U16ToF64, U32ToU8, U32ToU16, U32ToU32, U32ToU64, U32ToI8, U32ToI16, U32ToI32, U32ToI64, U32ToF32, U32ToF64, U64ToU8, U64ToU16, U64ToU32, U64ToU64, U64ToI8, U64ToI16, U64ToI32, U64ToI64, U64ToF32, U64ToF64, I8ToU8, I8ToU16, I8ToU32, I8ToU64, I8ToI8, I8ToI16, I8ToI32, I8ToI64, I8ToF32, I8ToF64, I16ToU8, I16ToU16, I16ToU32, I16ToU64, I16ToI8, I16ToI16, I16ToI32, I16ToI64, I16ToF32, I16ToF64, I32ToU8, I32ToU16, I32ToU32, I32ToU64, I32ToI8, I32ToI16, I32ToI32, I32ToI64, I32ToF32, I32ToF64, I64ToU8, I64ToU16, I64ToU32, I64ToU64, I64ToI8, I64ToI16, I64ToI32, I64ToI64, I64ToF32, I64ToF64, F32ToU8, F32ToU16, F32ToU32, F32ToU64, F32ToI8, F32ToI16, F32ToI32, F32ToI64, F32ToF32, F32ToF64, F64ToU8, F64ToU16, F64ToU32, F64ToU64, F64ToI8, F64ToI16, F64ToI32, F64ToI64, F64ToF32, F64ToF64" -outfn "U8ToU8s, U8ToU16s, U8ToU32s, U8ToU64s, U8ToI8s, U8ToI16s, U8ToI32s, U8ToI64s, U8ToF32s, U8ToF64s, U16ToU8s, U16ToU16s, U16ToU32s, U16ToU64s, U16ToI8s, U16ToI16s, U16ToI32s, U16ToI64s, U16ToF32s, U16ToF64s, U32ToU8s, U32ToU16s, U32ToU32s, U32ToU64s, U32ToI8s, U32ToI16s, U32ToI32s, U32ToI64s, U32ToF32s, U32ToF64s, U64ToU8s, U64ToU16s, U64ToU32s, U64ToU64s, U64ToI8s, U64ToI16s, U64ToI32s, U64ToI64s, U64ToF32s, U64ToF64s, I8ToU8s, I8ToU16s, I8ToU32s, I8ToU64s, I8ToI8s, I8ToI16s, I8ToI32s, I8ToI64s, I8ToF32s, I8ToF64s, I16ToU8s, I16ToU16s, I16ToU32s, I16ToU64s, I16ToI8s, I16ToI16s, I16ToI32s, I16ToI64s, I16ToF32s, I16ToF64s, I32ToU8s, I32ToU16s, I32ToU32s, I32ToU64s, I32ToI8s, I32ToI16s, I32ToI32s, I32ToI64s, I32ToF32s, I32ToF64s, I64ToU8s, I64ToU16s, I64ToU32s, I64ToU64s, I64ToI8s, I64ToI16s, I64ToI32s, I64ToI64s, I64ToF32s, I64ToF64s, F32ToU8s, F32ToU16s, F32ToU32s, F32ToU64s, F32ToI8s, F32ToI16s, F32ToI32s, F32ToI64s, F32ToF32s, F32ToF64s, F64ToU8s, F64ToU16s, F64ToU32s, F64ToU64s, F64ToI8s, F64ToI16s, F64ToI32s, F64ToI64s, F64ToF32s, F64ToF64s" -f "$GOFILE" -o "convert_test_amd64.s"
 
-func U8ToU8s(uint8) uint8
-func U8ToU16s(uint8) uint16
-func U8ToU32s(uint8) uint32
-func U8ToU64s(uint8) uint64
-func U8ToI8s(uint8) int8
-func U8ToI16s(uint8) int16
-func U8ToI32s(uint8) int32
-func U8ToI64s(uint8) int64
-func U8ToF32s(uint8) float32
-func U8ToF64s(uint8) float64
-
-func U16ToU8s(uint16) uint8
-func U16ToU16s(uint16) uint16
-func U16ToU32s(uint16) uint32
-func U16ToU64s(uint16) uint64
-func U16ToI8s(uint16) int8
-func U16ToI16s(uint16) int16
-func U16ToI32s(uint16) int32
-func U16ToI64s(uint16) int64
-func U16ToF32s(uint16) float32
-func U16ToF64s(uint16) float64
-
-func U32ToU8s(uint32) uint8
-func U32ToU16s(uint32) uint16
-func U32ToU32s(uint32) uint32
-func U32ToU64s(uint32) uint64
-func U32ToI8s(uint32) int8
-func U32ToI16s(uint32) int16
-func U32ToI32s(uint32) int32
-func U32ToI64s(uint32) int64
-func U32ToF32s(uint32) float32
-func U32ToF64s(uint32) float64
-
-func U64ToU8s(uint64) uint8
-func U64ToU16s(uint64) uint16
-func U64ToU32s(uint64) uint32
-func U64ToU64s(uint64) uint64
-func U64ToI8s(uint64) int8
-func U64ToI16s(uint64) int16
-func U64ToI32s(uint64) int32
-func U64ToI64s(uint64) int64
-func U64ToF32s(uint64) float32
-func U64ToF64s(uint64) float64
-
-func I8ToU8s(int8) uint8
-func I8ToU16s(int8) uint16
-func I8ToU32s(int8) uint32
-func I8ToU64s(int8) uint64
-func I8ToI8s(int8) int8
-func I8ToI16s(int8) int16
-func I8ToI32s(int8) int32
-func I8ToI64s(int8) int64
-func I8ToF32s(int8) float32
-func I8ToF64s(int8) float64
-
-func I16ToU8s(int16) uint8
-func I16ToU16s(int16) uint16
-func I16ToU32s(int16) uint32
-func I16ToU64s(int16) uint64
-func I16ToI8s(int16) int8
-func I16ToI16s(int16) int16
-func I16ToI32s(int16) int32
-func I16ToI64s(int16) int64
-func I16ToF32s(int16) float32
-func I16ToF64s(int16) float64
-
-func I32ToU8s(int32) uint8
-func I32ToU16s(int32) uint16
-func I32ToU32s(int32) uint32
-func I32ToU64s(int32) uint64
-func I32ToI8s(int32) int8
-func I32ToI16s(int32) int16
-func I32ToI32s(int32) int32
-func I32ToI64s(int32) int64
-func I32ToF32s(int32) float32
-func I32ToF64s(int32) float64
-
-func I64ToU8s(int64) uint8
-func I64ToU16s(int64) uint16
-func I64ToU32s(int64) uint32
-func I64ToU64s(int64) uint64
-func I64ToI8s(int64) int8
-func I64ToI16s(int64) int16
-func I64ToI32s(int64) int32
-func I64ToI64s(int64) int64
-func I64ToF32s(int64) float32
-func I64ToF64s(int64) float64
-
-func F32ToU8s(float32) uint8
-func F32ToU16s(float32) uint16
-func F32ToU32s(float32) uint32
-func F32ToU64s(float32) uint64
-func F32ToI8s(float32) int8
-func F32ToI16s(float32) int16
-func F32ToI32s(float32) int32
-func F32ToI64s(float32) int64
-func F32ToF32s(float32) float32
-func F32ToF64s(float32) float64
-
-func F64ToU8s(float64) uint8
-func F64ToU16s(float64) uint16
-func F64ToU32s(float64) uint32
-func F64ToU64s(float64) uint64
-func F64ToI8s(float64) int8
-func F64ToI16s(float64) int16
-func F64ToI32s(float64) int32
-func F64ToI64s(float64) int64
-func F64ToF32s(float64) float32
-func F64ToF64s(float64) float64
+func U8ToU8s(x uint8) uint8
+func U8ToU16s(x uint8) uint16
+func U8ToU32s(x uint8) uint32
+func U8ToU64s(x uint8) uint64
+func U8ToI8s(x uint8) int8
+func U8ToI16s(x uint8) int16
+func U8ToI32s(x uint8) int32
+func U8ToI64s(x uint8) int64
+func U8ToF32s(x uint8) float32
+func U8ToF64s(x uint8) float64
+
+func U16ToU8s(x uint16) uint8
+func U16ToU16s(x uint16) uint16
+func U16ToU32s(x uint16) uint32
+func U16ToU64s(x uint16) uint64
+func U16ToI8s(x uint16) int8
+func U16ToI16s(x uint16) int16
+func U16ToI32s(x uint16) int32
+func U16ToI64s(x uint16) int64
+func U16ToF32s(x uint16) float32
+func U16ToF64s(x uint16) float64
+
+func U32ToU8s(x uint32) uint8
+func U32ToU16s(x uint32) uint16
+func U32ToU32s(x uint32) uint32
+func U32ToU64s(x uint32) uint64
+func U32ToI8s(x uint32) int8
+func U32ToI16s(x uint32) int16
+func U32ToI32s(x uint32) int32
+func U32ToI64s(x uint32) int64
+func U32ToF32s(x uint32) float32
+func U32ToF64s(x uint32) float64
+
+func U64ToU8s(x uint64) uint8
+func U64ToU16s(x uint64) uint16
+func U64ToU32s(x uint64) uint32
+func U64ToU64s(x uint64) uint64
+func U64ToI8s(x uint64) int8
+func U64ToI16s(x uint64) int16
+func U64ToI32s(x uint64) int32
+func U64ToI64s(x uint64) int64
+func U64ToF32s(x uint64) float32
+func U64ToF64s(x uint64) float64
+
+func I8ToU8s(x int8) uint8
+func I8ToU16s(x int8) uint16
+func I8ToU32s(x int8) uint32
+func I8ToU64s(x int8) uint64
+func I8ToI8s(x int8) int8
+func I8ToI16s(x int8) int16
+func I8ToI32s(x int8) int32
+func I8ToI64s(x int8) int64
+func I8ToF32s(x int8) float32
+func I8ToF64s(x int8) float64
+
+func I16ToU8s(x int16) uint8
+func I16ToU16s(x int16) uint16
+func I16ToU32s(x int16) uint32
+func I16ToU64s(x int16) uint64
+func I16ToI8s(x int16) int8
+func I16ToI16s(x int16) int16
+func I16ToI32s(x int16) int32
+func I16ToI64s(x int16) int64
+func I16ToF32s(x int16) float32
+func I16ToF64s(x int16) float64
+
+func I32ToU8s(x int32) uint8
+func I32ToU16s(x int32) uint16
+func I32ToU32s(x int32) uint32
+func I32ToU64s(x int32) uint64
+func I32ToI8s(x int32) int8
+func I32ToI16s(x int32) int16
+func I32ToI32s(x int32) int32
+func I32ToI64s(x int32) int64
+func I32ToF32s(x int32) float32
+func I32ToF64s(x int32) float64
+
+func I64ToU8s(x int64) uint8
+func I64ToU16s(x int64) uint16
+func I64ToU32s(x int64) uint32
+func I64ToU64s(x int64) uint64
+func I64ToI8s(x int64) int8
+func I64ToI16s(x int64) int16
+func I64ToI32s(x int64) int32
+func I64ToI64s(x int64) int64
+func I64ToF32s(x int64) float32
+func I64ToF64s(x int64) float64
+
+func F32ToU8s(x float32) uint8
+func F32ToU16s(x float32) uint16
+func F32ToU32s(x float32) uint32
+func F32ToU64s(x float32) uint64
+func F32ToI8s(x float32) int8
+func F32ToI16s(x float32) int16
+func F32ToI32s(x float32) int32
+func F32ToI64s(x float32) int64
+func F32ToF32s(x float32) float32
+func F32ToF64s(x float32) float64
+
+func F64ToU8s(x float64) uint8
+func F64ToU16s(x float64) uint16
+func F64ToU32s(x float64) uint32
+func F64ToU64s(x float64) uint64
+func F64ToI8s(x float64) int8
+func F64ToI16s(x float64) int16
+func F64ToI32s(x float64) int32
+func F64ToI64s(x float64) int64
+func F64ToF32s(x float64) float32
+func F64ToF64s(x float64) float64
 
 func U8ToU8(x uint8) uint8    { return uint8(x) }
 func U8ToU16(x uint8) uint16  { return uint16(x) }
@@ -1,310 +1,323 @@
-// +build amd64 !noasm !appengine
+// Code generated by gensimd. DO NOT EDIT.
+// gensimd version: devel
+// gensimd command: gensimd -f convert_test.go -fn "U8ToU8, U8ToU16, U8ToU32, U8ToU64, U8ToI8, U8ToI16, U8ToI32, U8ToI64, U8ToF32, U8ToF64, U16ToU8, U16ToU16, U16ToU32, U16ToU64, U16ToI8, U16ToI16, U16ToI32, U16ToI64, U16ToF32, U16ToF64, U32ToU8, U32ToU16, U32ToU32, U32ToU64, U32ToI8, U32ToI16, U32ToI32, U32ToI64, U32ToF32, U32ToF64, U64ToU8, U64ToU16, U64ToU32, U64ToU64, U64ToI8, U64ToI16, U64ToI32, U64ToI64, U64ToF32, U64ToF64, I8ToU8, I8ToU16, I8ToU32, I8ToU64, I8ToI8, I8ToI16, I8ToI32, I8ToI64, I8ToF32, I8ToF64, I16ToU8, I16ToU16, I16ToU32, I16ToU64, I16ToI8, I16ToI16, I16ToI32, I16ToI64, I16ToF32, I16ToF64, I32ToU8, I32ToU16, I32ToU32, I32ToU64, I32ToI8, I32ToI16, I32ToI32, I32ToI64, I32ToF32, I32ToF64, I64ToU8, I64ToU16, I64ToU32, I64ToU64, I64ToI8, I64ToI16, I64ToI32, I64ToI64, I64ToF32, I64ToF64, F32ToU8, F32ToU16, F32ToU32, F32ToU64, F32ToI8, F32ToI16, F32ToI32, F32ToI64, F32ToF32, F32ToF64, F64ToU8, F64ToU16, F64ToU32, F64ToU64, F64ToI8, F64ToI16, F64ToI32, F64ToI64, F64ToF32, F64ToF64" -o convert_test_amd64.s -outfn "U8ToU8s, U8ToU16s, U8ToU32s, U8ToU64s, U8ToI8s, U8ToI16s, U8ToI32s, U8ToI64s, U8ToF32s, U8ToF64s, U16ToU8s, U16ToU16s, U16ToU32s, U16ToU64s, U16ToI8s, U16ToI16s, U16ToI32s, U16ToI64s, U16ToF32s, U16ToF64s, U32ToU8s, U32ToU16s, U32ToU32s, U32ToU64s, U32ToI8s, U32ToI16s, U32ToI32s, U32ToI64s, U32ToF32s, U32ToF64s, U64ToU8s, U64ToU16s, U64ToU32s, U64ToU64s, U64ToI8s, U64ToI16s, U64ToI32s, U64ToI64s, U64ToF32s, U64ToF64s, I8ToU8s, I8ToU16s, I8ToU32s, I8ToU64s, I8ToI8s, I8ToI16s, I8ToI32s, I8ToI64s, I8ToF32s, I8ToF64s, I16ToU8s, I16ToU16s, I16ToU32s, I16ToU64s, I16ToI8s, I16ToI16s, I16ToI32s, I16ToI64s, I16ToF32s, I16ToF64s, I32ToU8s, I32ToU16s, I32ToU32s, I32ToU64s, I32ToI8s, I32ToI16s, I32ToI32s, I32ToI64s, I32ToF32s, I32ToF64s, I64ToU8s, I64ToU16s, I64ToU32s, I64ToU64s, I64ToI8s, I64ToI16s, I64ToI32s, I64ToI64s, I64ToF32s, I64ToF64s, F32ToU8s, F32ToU16s, F32ToU32s, F32ToU64s, F32ToI8s, F32ToI16s, F32ToI32s, F32ToI64s, F32ToF32s, F32ToF64s, F64ToU8s, F64ToU16s, F64ToU32s, F64ToU64s, F64ToI8s, F64ToI16s, F64ToI32s, F64ToI64s, F64ToF32s, F64ToF64s"
+// gensimd source: convert_test.go sha256:428a503b763a0fe4b94ea9496055b888ff9f3d770f7793d85b9cbcdaeae49ba6
+// gensimd target: avx2
+
+//go:build amd64 && !noasm && !appengine
+// +build amd64,!noasm,!appengine
 
 #include "textflag.h"
 
 TEXT ·U8ToU8s(SB),$8-9
-        MOVB         $0, ret0+8(FP)
 block0:
+        // entry
         MOVBQZX      x+0(FP), R15
-        MOVB         R15, ret0+8(FP)
+        MOVB         R15, ret+8(FP)
         RET
 
 TEXT ·U8ToU16s(SB),$8-10
-        MOVW         $0, ret0+8(FP)
 block0:
+        // entry
         MOVBQZX      x+0(FP), R15
-        MOVBWZX      R15, R14
-        MOVW         R14, ret0+8(FP)
+        MOVBWZX      R15, R13
+        MOVW         R13, ret+8(FP)
         RET
 
 TEXT ·U8ToU32s(SB),$8-12
-        MOVL         $0, ret0+8(FP)
 block0:
+        // entry
         MOVBQZX      x+0(FP), R15
-        MOVBLZX      R15, R14
-        MOVL         R14, ret0+8(FP)
+        MOVBLZX      R15, R13
+        MOVL         R13, ret+8(FP)
         RET
 
 TEXT ·U8ToU64s(SB),$16-16
-        MOVQ         $0, ret0+8(FP)
 block0:
+        // entry
         MOVBQZX      x+0(FP), R15
-        MOVBQZX      R15, R14
-        MOVQ         R14, ret0+8(FP)
+        MOVBQZX      R15, R13
+        MOVQ         R13, ret+8(FP)
         RET
 
 TEXT ·U8ToI8s(SB),$8-9
-        MOVB         $0, ret0+8(FP)
 block0:
+        // entry
         MOVBQZX      x+0(FP), R15
-        MOVB         R15, R14
-        MOVB         R14, ret0+8(FP)
+        MOVB         R15, R13
+        MOVB         R13, ret+8(FP)
         RET
 
 TEXT ·U8ToI16s(SB),$8-10
-        MOVW         $0, ret0+8(FP)
 block0:
+        // entry
         MOVBQZX      x+0(FP), R15
-        MOVBWZX      R15, R14
-        MOVW         R14, ret0+8(FP)
+        MOVBWZX      R15, R13
+        MOVW         R13, ret+8(FP)
         RET
 
 TEXT ·U8ToI32s(SB),$8-12
-        MOVL         $0, ret0+8(FP)
 block0:
+        // entry
         MOVBQZX      x+0(FP), R15
-        MOVBLZX      R15, R14
-        MOVL         R14, ret0+8(FP)
+        MOVBLZX      R15, R13
+        MOVL         R13, ret+8(FP)
         RET
 
 TEXT ·U8ToI64s(SB),$16-16
-        MOVQ         $0, ret0+8(FP)
 block0:
+        // entry
         MOVBQZX      x+0(FP), R15
-        MOVBQZX      R15, R14
-        MOVQ         R14, ret0+8(FP)
+        MOVBQZX      R15, R13
+        MOVQ         R13, ret+8(FP)
         RET
 
 TEXT ·U8ToF32s(SB),$8-12
-        MOVL         $0, ret0+8(FP)
 block0:
+        // entry
         MOVBQZX      x+0(FP), R15
-        CVTSL2SS     R14, X15
-        MOVSS        X15, ret0+8(FP)
+        MOVBLZX      R15, R13
+        CVTSL2SS     R13, X14
+        MOVSS        X14, ret+8(FP)
         RET
 
 TEXT ·U8ToF64s(SB),$16-16
-        MOVQ         $0, ret0+8(FP)
 block0:
+        // entry
         MOVBQZX      x+0(FP), R15
-        CVTSL2SD     R14, X15
-        MOVSD        X15, ret0+8(FP)
+        MOVBLZX      R15, R13
+        CVTSL2SD     R13, X14
+        MOVSD        X14, ret+8(FP)
         RET
 
 TEXT ·U16ToU8s(SB),$8-9
-        MOVB         $0, ret0+8(FP)
 block0:
+        // entry
         MOVWQZX      x+0(FP), R15
-        MOVB         R15, R14
-        MOVB         R14, ret0+8(FP)
+        MOVB         R15, R13
+        MOVB         R13, ret+8(FP)
         RET
 
 TEXT ·U16ToU16s(SB),$8-10
-        MOVW         $0, ret0+8(FP)
 block0:
+        // entry
         MOVWQZX      x+0(FP), R15
-        MOVW         R15, ret0+8(FP)
+        MOVW         R15, ret+8(FP)
         RET
 
 TEXT ·U16ToU32s(SB),$8-12
-        MOVL         $0, ret0+8(FP)
 block0:
+        // entry
         MOVWQZX      x+0(FP), R15
-        MOVWLZX      R15, R14
-        MOVL         R14, ret0+8(FP)
+        MOVWLZX      R15, R13
+        MOVL         R13, ret+8(FP)
         RET
 
 TEXT ·U16ToU64s(SB),$16-16
-        MOVQ         $0, ret0+8(FP)
 block0:
+        // entry
         MOVWQZX      x+0(FP), R15
-        MOVWQZX      R15, R14
-        MOVQ         R14, ret0+8(FP)
+        MOVWQZX      R15, R13
+        MOVQ         R13, ret+8(FP)
         RET
 
 TEXT ·U16ToI8s(SB),$8-9
-        MOVB         $0, ret0+8(FP)
 block0:
+        // entry
         MOVWQZX      x+0(FP), R15
-        MOVB         R15, R14
-        MOVB         R14, ret0+8(FP)
+        MOVB         R15, R13
+        MOVB         R13, ret+8(FP)
         RET
 
 TEXT ·U16ToI16s(SB),$8-10
-        MOVW         $0, ret0+8(FP)
 block0:
+        // entry
         MOVWQZX      x+0(FP), R15
-        MOVW         R15, R14
-        MOVW         R14, ret0+8(FP)
+        MOVW         R15, R13
+        MOVW         R13, ret+8(FP)
         RET
 
 TEXT ·U16ToI32s(SB),$8-12
-        MOVL         $0, ret0+8(FP)
 block0:
+        // entry
         MOVWQZX      x+0(FP), R15
-        MOVWLZX      R15, R14
-        MOVL         R14, ret0+8(FP)
+        MOVWLZX      R15, R13
+        MOVL         R13, ret+8(FP)
         RET
 
 TEXT ·U16ToI64s(SB),$16-16
-        MOVQ         $0, ret0+8(FP)
 block0:
+        // entry
         MOVWQZX      x+0(FP), R15
-        MOVWQZX      R15, R14
-        MOVQ         R14, ret0+8(FP)
+        MOVWQZX      R15, R13
+        MOVQ         R13, ret+8(FP)
         RET
 
 TEXT ·U16ToF32s(SB),$8-12
-        MOVL         $0, ret0+8(FP)
 block0:
+        // entry
         MOVWQZX      x+0(FP), R15
-        CVTSL2SS     R14, X15
-        MOVSS        X15, ret0+8(FP)
+        MOVWLZX      R15, R13
+        CVTSL2SS     R13, X14
+        MOVSS        X14, ret+8(FP)
         RET
 
 TEXT ·U16ToF64s(SB),$16-16
-        MOVQ         $0, ret0+8(FP)
 block0:
+        // entry
         MOVWQZX      x+0(FP), R15
-        CVTSL2SD     R14, X15
-        MOVSD        X15, ret0+8(FP)
+        MOVWLZX      R15, R13
+        CVTSL2SD     R13, X14
+        MOVSD        X14, ret+8(FP)
         RET
 
 TEXT ·U32ToU8s(SB),$8-9
-        MOVB         $0, ret0+8(FP)
 block0:
+        // entry
         MOVLQZX      x+0(FP), R15
-        MOVB         R15, R14
-        MOVB         R14, ret0+8(FP)
+        MOVB         R15, R13
+        MOVB         R13, ret+8(FP)
         RET
 
 TEXT ·U32ToU16s(SB),$8-10
-        MOVW         $0, ret0+8(FP)
 block0:
+        // entry
         MOVLQZX      x+0(FP), R15
-        MOVW         R15, R14
-        MOVW         R14, ret0+8(FP)
+        MOVW         R15, R13
+        MOVW         R13, ret+8(FP)
         RET
 
 TEXT ·U32ToU32s(SB),$8-12
-        MOVL         $0, ret0+8(FP)
 block0:
+        // entry
         MOVLQZX      x+0(FP), R15
-        MOVL         R15, ret0+8(FP)
+        MOVL         R15, ret+8(FP)
         RET
 
 TEXT ·U32ToU64s(SB),$16-16
-        MOVQ         $0, ret0+8(FP)
 block0:
+        // entry
         MOVLQZX      x+0(FP), R15
-        MOVLQZX      R15, R14
-        MOVQ         R14, ret0+8(FP)
+        MOVLQZX      R15, R13
+        MOVQ         R13, ret+8(FP)
         RET
 
 TEXT ·U32ToI8s(SB),$8-9
-        MOVB         $0, ret0+8(FP)
 block0:
+        // entry
         MOVLQZX      x+0(FP), R15
-        MOVB         R15, R14
-        MOVB         R14, ret0+8(FP)
+        MOVB         R15, R13
+        MOVB         R13, ret+8(FP)
         RET
 
 TEXT ·U32ToI16s(SB),$8-10
-        MOVW         $0, ret0+8(FP)
 block0:
+        // entry
         MOVLQZX      x+0(FP), R15
-        MOVW         R15, R14
-        MOVW         R14, ret0+8(FP)
+        MOVW         R15, R13
+        MOVW         R13, ret+8(FP)
         RET
 
 TEXT ·U32ToI32s(SB),$8-12
-        MOVL         $0, ret0+8(FP)
 block0:
+        // entry
         MOVLQZX      x+0(FP), R15
-        MOVL         R15, R14
-        MOVL         R14, ret0+8(FP)
+        MOVL         R15, R13
+        MOVL         R13, ret+8(FP)
         RET
 
 TEXT ·U32ToI64s(SB),$16-16
-        MOVQ         $0, ret0+8(FP)
 block0:
+        // entry
         MOVLQZX      x+0(FP), R15
-        MOVLQZX      R15, R14
-        MOVQ         R14, ret0+8(FP)
+        MOVLQZX      R15, R13
+        MOVQ         R13, ret+8(FP)
         RET
 
 TEXT ·U32ToF32s(SB),$8-12
-        MOVL         $0, ret0+8(FP)
 block0:
+        // entry
         MOVLQZX      x+0(FP), R15
-        CVTSQ2SS     R14, X15
-        MOVSS        X15, ret0+8(FP)
+        MOVLQZX      R15, R13
+        CVTSQ2SS     R13, X14
+        MOVSS        X14, ret+8(FP)
         RET
 
 TEXT ·U32ToF64s(SB),$16-16
-        MOVQ         $0, ret0+8(FP)
 block0:
+        // entry
         MOVLQZX      x+0(FP), R15
-        CVTSQ2SD     R14, X15
-        MOVSD        X15, ret0+8(FP)
+        MOVLQZX      R15, R13
+        CVTSQ2SD     R13, X14
+        MOVSD        X14, ret+8(FP)
         RET
 
 TEXT ·U64ToU8s(SB),$8-9
-        MOVB         $0, ret0+8(FP)
 block0:
+        // entry
         MOVQ         x+0(FP), R15
-        MOVB         R15, R14
-        MOVB         R14, ret0+8(FP)
+        MOVB         R15, R13
+        MOVB         R13, ret+8(FP)
         RET
 
 TEXT ·U64ToU16s(SB),$8-10
-        MOVW         $0, ret0+8(FP)
 block0:
+        // entry
         MOVQ         x+0(FP), R15
-        MOVW         R15, R14
-        MOVW         R14, ret0+8(FP)
+        MOVW         R15, R13
+        MOVW         R13, ret+8(FP)
         RET
 
 TEXT ·U64ToU32s(SB),$8-12
-        MOVL         $0, ret0+8(FP)
 block0:
+        // entry
         MOVQ         x+0(FP), R15
-        MOVL         R15, R14
-        MOVL         R14, ret0+8(FP)
+        MOVL         R15, R13
+        MOVL         R13, ret+8(FP)
         RET
 
 TEXT ·U64ToU64s(SB),$8-16
-        MOVQ         $0, ret0+8(FP)
 block0:
+        // entry
         MOVQ         x+0(FP), R15
-        MOVQ         R15, ret0+8(FP)
+        MOVQ         R15, ret+8(FP)
         RET
 
 TEXT ·U64ToI8s(SB),$8-9
-        MOVB         $0, ret0+8(FP)
 block0:
+        // entry
         MOVQ         x+0(FP), R15
-        MOVB         R15, R14
-        MOVB         R14, ret0+8(FP)
+        MOVB         R15, R13
+        MOVB         R13, ret+8(FP)
         RET
 
 TEXT ·U64ToI16s(SB),$8-10
-        MOVW         $0, ret0+8(FP)
 block0:
+        // entry
         MOVQ         x+0(FP), R15
-        MOVW         R15, R14
-        MOVW         R14, ret0+8(FP)
+        MOVW         R15, R13
+        MOVW         R13, ret+8(FP)
         RET
 
 TEXT ·U64ToI32s(SB),$8-12
-        MOVL         $0, ret0+8(FP)
 block0:
+        // entry
         MOVQ         x+0(FP), R15
-        MOVL         R15, R14
-        MOVL         R14, ret0+8(FP)
+        MOVL         R15, R13
+        MOVL         R13, ret+8(FP)
         RET
 
 TEXT ·U64ToI64s(SB),$16-16
-        MOVQ         $0, ret0+8(FP)
 block0:
+        // entry
         MOVQ         x+0(FP), R15
-        MOVQ         R15, R14
-        MOVQ         R14, ret0+8(FP)
+        MOVQ         R15, R13
+        MOVQ         R13, ret+8(FP)
         RET
 
 TEXT ·U64ToF32s(SB),$8-12
-        MOVL         $0, ret0+8(FP)
 block0:
+        // entry
         MOVQ         x+0(FP), R15
         //           U64
         CMPQ	     R15, $-1
@@ -317,33 +330,33 @@ block0:
         // rounding label
 lbl1:
         //           U64 I64
-        MOVQ	     R15, R13
+        MOVQ	     R15, R12
         //           I64
-        SHRQ	      $1, R13
+        SHRQ	      $1, R12
         //           U64 TMP
-        MOVQ	     R15, R14
+        MOVQ	     R15, R13
         //               TMP
-        ANDL	     $1, R14
+        ANDL	     $1, R13
         //           TMP I64
-        ORQ	     R14, R13
+        ORQ	     R13, R12
         //CVT        I64 XMM
-        CVTSQ2SS     R13, X15
+        CVTSQ2SS     R12, X14
         //ADD        XMM, XMM
-        ADDSS        X15, X15
+        ADDSS        X14, X14
         // jmp to end
         JMP          lbl3
         // no rounding label
 lbl2:
         //CVT        U64 XMM
-        CVTSQ2SS     R15, X15
+        CVTSQ2SS     R15, X14
         // end label
 lbl3:
-        MOVSS        X15, ret0+8(FP)
+        MOVSS        X14, ret+8(FP)
         RET
 
 TEXT ·U64ToF64s(SB),$16-16
-        MOVQ         $0, ret0+8(FP)
 block0:
+        // entry
         MOVQ         x+0(FP), R15
         //           U64
         CMPQ	     R15, $-1
@@ -356,501 +369,527 @@ block0:
         // rounding label
 lbl1:
         //           U64 I64
-        MOVQ	     R15, R13
+        MOVQ	     R15, R12
         //           I64
-        SHRQ	      $1, R13
+        SHRQ	      $1, R12
         //           U64 TMP
-        MOVQ	     R15, R14
+        MOVQ	     R15, R13
         //               TMP
-        ANDL	     $1, R14
+        ANDL	     $1, R13
         //           TMP I64
-        ORQ	     R14, R13
+        ORQ	     R13, R12
         //CVT        I64 XMM
-        CVTSQ2SD     R13, X15
+        CVTSQ2SD     R12, X14
         //ADD        XMM, XMM
-        ADDSD        X15, X15
+        ADDSD        X14, X14
         // jmp to end
         JMP          lbl3
         // no rounding label
 lbl2:
         //CVT        U64 XMM
-        CVTSQ2SD     R15, X15
+        CVTSQ2SD     R15, X14
         // end label
 lbl3:
-        MOVSD        X15, ret0+8(FP)
+        MOVSD        X14, ret+8(FP)
         RET
 
 TEXT ·I8ToU8s(SB),$8-9
-        MOVB         $0, ret0+8(FP)
 block0:
+        // entry
         MOVBQZX      x+0(FP), R15
-        MOVB         R15, R14
-        MOVB         R14, ret0+8(FP)
+        MOVB         R15, R13
+        MOVB         R13, ret+8(FP)
         RET
 
 TEXT ·I8ToU16s(SB),$8-10
-        MOVW         $0, ret0+8(FP)
 block0:
+        // entry
         MOVBQZX      x+0(FP), R15
-        MOVBWSX      R15, R14
-        MOVW         R14, ret0+8(FP)
+        MOVBWSX      R15, R13
+        MOVW         R13, ret+8(FP)
         RET
 
 TEXT ·I8ToU32s(SB),$8-12
-        MOVL         $0, ret0+8(FP)
 block0:
+        // entry
         MOVBQZX      x+0(FP), R15
-        MOVBLSX      R15, R14
-        MOVL         R14, ret0+8(FP)
+        MOVBLSX      R15, R13
+        MOVL         R13, ret+8(FP)
         RET
 
 TEXT ·I8ToU64s(SB),$16-16
-        MOVQ         $0, ret0+8(FP)
 block0:
+        // entry
         MOVBQZX      x+0(FP), R15
-        MOVBQSX      R15, R14
-        MOVQ         R14, ret0+8(FP)
+        MOVBQSX      R15, R13
+        MOVQ         R13, ret+8(FP)
         RET
 
 TEXT ·I8ToI8s(SB),$8-9
-        MOVB         $0, ret0+8(FP)
 block0:
+        // entry
         MOVBQZX      x+0(FP), R15
-        MOVB         R15, ret0+8(FP)
+        MOVB         R15, ret+8(FP)
         RET
 
 TEXT ·I8ToI16s(SB),$8-10
-        MOVW         $0, ret0+8(FP)
 block0:
+        // entry
         MOVBQZX      x+0(FP), R15
-        MOVBWSX      R15, R14
-        MOVW         R14, ret0+8(FP)
+        MOVBWSX      R15, R13
+        MOVW         R13, ret+8(FP)
         RET
 
 TEXT ·I8ToI32s(SB),$8-12
-        MOVL         $0, ret0+8(FP)
 block0:
+        // entry
         MOVBQZX      x+0(FP), R15
-        MOVBLSX      R15, R14
-        MOVL         R14, ret0+8(FP)
+        MOVBLSX      R15, R13
+        MOVL         R13, ret+8(FP)
         RET
 
 TEXT ·I8ToI64s(SB),$16-16
-        MOVQ         $0, ret0+8(FP)
 block0:
+        // entry
         MOVBQZX      x+0(FP), R15
-        MOVBQSX      R15, R14
-        MOVQ         R14, ret0+8(FP)
+        MOVBQSX      R15, R13
+        MOVQ         R13, ret+8(FP)
         RET
 
 TEXT ·I8ToF32s(SB),$8-12
-        MOVL         $0, ret0+8(FP)
 block0:
+        // entry
         MOVBQZX      x+0(FP), R15
-        CVTSL2SS     R14, X15
-        MOVSS        X15, ret0+8(FP)
+        MOVBLSX      R15, R13
+        CVTSL2SS     R13, X14
+        MOVSS        X14, ret+8(FP)
         RET
 
 TEXT ·I8ToF64s(SB),$16-16
-        MOVQ         $0, ret0+8(FP)
 block0:
+        // entry
         MOVBQZX      x+0(FP), R15
-        CVTSL2SD     R14, X15
-        MOVSD        X15, ret0+8(FP)
+        MOVBLSX      R15, R13
+        CVTSL2SD     R13, X14
+        MOVSD        X14, ret+8(FP)
         RET
 
 TEXT ·I16ToU8s(SB),$8-9
-        MOVB         $0, ret0+8(FP)
 block0:
+        // entry
         MOVWQZX      x+0(FP), R15
-        MOVB         R15, R14
-        MOVB         R14, ret0+8(FP)
+        MOVB         R15, R13
+        MOVB         R13, ret+8(FP)
         RET
 
 TEXT ·I16ToU16s(SB),$8-10
-        MOVW         $0, ret0+8(FP)
 block0:
+        // entry
         MOVWQZX      x+0(FP), R15
-        MOVW         R15, R14
-        MOVW         R14, ret0+8(FP)
+        MOVW         R15, R13
+        MOVW         R13, ret+8(FP)
         RET
 
 TEXT ·I16ToU32s(SB),$8-12
-        MOVL         $0, ret0+8(FP)
 block0:
+        // entry
         MOVWQZX      x+0(FP), R15
-        MOVWLSX      R15, R14
-        MOVL         R14, ret0+8(FP)
+        MOVWLSX      R15, R13
+        MOVL         R13, ret+8(FP)
         RET
 
 TEXT ·I16ToU64s(SB),$16-16
-        MOVQ         $0, ret0+8(FP)
 block0:
+        // entry
         MOVWQZX      x+0(FP), R15
-        MOVWQSX      R15, R14
-        MOVQ         R14, ret0+8(FP)
+        MOVWQSX      R15, R13
+        MOVQ         R13, ret+8(FP)
         RET
 
 TEXT ·I16ToI8s(SB),$8-9
-        MOVB         $0, ret0+8(FP)
 block0:
+        // entry
         MOVWQZX      x+0(FP), R15
-        MOVB         R15, R14
-        MOVB         R14, ret0+8(FP)
+        MOVB         R15, R13
+        MOVB         R13, ret+8(FP)
         RET
 
 TEXT ·I16ToI16s(SB),$8-10
-        MOVW         $0, ret0+8(FP)
 block0:
+        // entry
         MOVWQZX      x+0(FP), R15
-        MOVW         R15, ret0+8(FP)
+        MOVW         R15, ret+8(FP)
         RET
 
 TEXT ·I16ToI32s(SB),$8-12
-        MOVL         $0, ret0+8(FP)
 block0:
+        // entry
         MOVWQZX      x+0(FP), R15
-        MOVWLSX      R15, R14
-        MOVL         R14, ret0+8(FP)
+        MOVWLSX      R15, R13
+        MOVL         R13, ret+8(FP)
         RET
 
 TEXT ·I16ToI64s(SB),$16-16
-        MOVQ         $0, ret0+8(FP)
 block0:
+        // entry
         MOVWQZX      x+0(FP), R15
-        MOVWQSX      R15, R14
-        MOVQ         R14, ret0+8(FP)
+        MOVWQSX      R15, R13
+        MOVQ         R13, ret+8(FP)
         RET
 
 TEXT ·I16ToF32s(SB),$8-12
-        MOVL         $0, ret0+8(FP)
 block0:
+        // entry
         MOVWQZX      x+0(FP), R15
-        CVTSL2SS     R14, X15
-        MOVSS        X15, ret0+8(FP)
+        MOVWLSX      R15, R13
+        CVTSL2SS     R13, X14
+        MOVSS        X14, ret+8(FP)
         RET
 
 TEXT ·I16ToF64s(SB),$16-16
-        MOVQ         $0, ret0+8(FP)
 block0:
+        // entry
         MOVWQZX      x+0(FP), R15
-        CVTSL2SD     R14, X15
-        MOVSD        X15, ret0+8(FP)
+        MOVWLSX      R15, R13
+        CVTSL2SD     R13, X14
+        MOVSD        X14, ret+8(FP)
         RET
 
 TEXT ·I32ToU8s(SB),$8-9
-        MOVB         $0, ret0+8(FP)
 block0:
+        // entry
         MOVLQZX      x+0(FP), R15
-        MOVB         R15, R14
-        MOVB         R14, ret0+8(FP)
+        MOVB         R15, R13
+        MOVB         R13, ret+8(FP)
         RET
 
 TEXT ·I32ToU16s(SB),$8-10
-        MOVW         $0, ret0+8(FP)
 block0:
+        // entry
         MOVLQZX      x+0(FP), R15
-        MOVW         R15, R14
-        MOVW         R14, ret0+8(FP)
+        MOVW         R15, R13
+        MOVW         R13, ret+8(FP)
         RET
 
 TEXT ·I32ToU32s(SB),$8-12
-        MOVL         $0, ret0+8(FP)
 block0:
+        // entry
         MOVLQZX      x+0(FP), R15
-        MOVL         R15, R14
-        MOVL         R14, ret0+8(FP)
+        MOVL         R15, R13
+        MOVL         R13, ret+8(FP)
         RET
 
 TEXT ·I32ToU64s(SB),$16-16
-        MOVQ         $0, ret0+8(FP)
 block0:
+        // entry
         MOVLQZX      x+0(FP), R15
-        MOVLQSX      R15, R14
-        MOVQ         R14, ret0+8(FP)
+        MOVLQSX      R15, R13
+        MOVQ         R13, ret+8(FP)
         RET
 
 TEXT ·I32ToI8s(SB),$8-9
-        MOVB         $0, ret0+8(FP)
 block0:
+        // entry
         MOVLQZX      x+0(FP), R15
-        MOVB         R15, R14
-        MOVB         R14, ret0+8(FP)
+        MOVB         R15, R13
+        MOVB         R13, ret+8(FP)
         RET
 
 TEXT ·I32ToI16s(SB),$8-10
-        MOVW         $0, ret0+8(FP)
 block0:
+        // entry
         MOVLQZX      x+0(FP), R15
-        MOVW         R15, R14
-        MOVW         R14, ret0+8(FP)
+        MOVW         R15, R13
+        MOVW         R13, ret+8(FP)
         RET
 
 TEXT ·I32ToI32s(SB),$8-12
-        MOVL         $0, ret0+8(FP)
 block0:
+        // entry
         MOVLQZX      x+0(FP), R15
-        MOVL         R15, ret0+8(FP)
+        MOVL         R15, ret+8(FP)
         RET
 
 TEXT ·I32ToI64s(SB),$16-16
-        MOVQ         $0, ret0+8(FP)
 block0:
+        // entry
         MOVLQZX      x+0(FP), R15
-        MOVLQSX      R15, R14
-        MOVQ         R14, ret0+8(FP)
+        MOVLQSX      R15, R13
+        MOVQ         R13, ret+8(FP)
         RET
 
 TEXT ·I32ToF32s(SB),$8-12
-        MOVL         $0, ret0+8(FP)
 block0:
+        // entry
         MOVLQZX      x+0(FP), R15
-        CVTSL2SS     R15, X15
-        MOVSS        X15, ret0+8(FP)
+        CVTSL2SS     R15, X14
+        MOVSS        X14, ret+8(FP)
         RET
 
 TEXT ·I32ToF64s(SB),$16-16
-        MOVQ         $0, ret0+8(FP)
 block0:
+        // entry
         MOVLQZX      x+0(FP), R15
-        CVTSL2SD     R15, X15
-        MOVSD        X15, ret0+8(FP)
+        CVTSL2SD     R15, X14
+        MOVSD        X14, ret+8(FP)
         RET
 
 TEXT ·I64ToU8s(SB),$8-9
-        MOVB         $0, ret0+8(FP)
 block0:
+        // entry
         MOVQ         x+0(FP), R15
-        MOVB         R15, R14
-        MOVB         R14, ret0+8(FP)
+        MOVB         R15, R13
+        MOVB         R13, ret+8(FP)
         RET
 
 TEXT ·I64ToU16s(SB),$8-10
-        MOVW         $0, ret0+8(FP)
 block0:
+        // entry
         MOVQ         x+0(FP), R15
-        MOVW         R15, R14
-        MOVW         R14, ret0+8(FP)
+        MOVW         R15, R13
+        MOVW         R13, ret+8(FP)
         RET
 
 TEXT ·I64ToU32s(SB),$8-12
-        MOVL         $0, ret0+8(FP)
 block0:
+        // entry
         MOVQ         x+0(FP), R15
-        MOVL         R15, R14
-        MOVL         R14, ret0+8(FP)
+        MOVL         R15, R13
+        MOVL         R13, ret+8(FP)
         RET
 
 TEXT ·I64ToU64s(SB),$16-16
-        MOVQ         $0, ret0+8(FP)
 block0:
+        // entry
         MOVQ         x+0(FP), R15
-        MOVQ         R15, R14
-        MOVQ         R14, ret0+8(FP)
+        MOVQ         R15, R13
+        MOVQ         R13, ret+8(FP)
         RET
 
 TEXT ·I64ToI8s(SB),$8-9
-        MOVB         $0, ret0+8(FP)
 block0:
+        // entry
         MOVQ         x+0(FP), R15
-        MOVB         R15, R14
-        MOVB         R14, ret0+8(FP)
+        MOVB         R15, R13
+        MOVB         R13, ret+8(FP)
         RET
 
 TEXT ·I64ToI16s(SB),$8-10
-        MOVW         $0, ret0+8(FP)
 block0:
+        // entry
         MOVQ         x+0(FP), R15
-        MOVW         R15, R14
-        MOVW         R14, ret0+8(FP)
+        MOVW         R15, R13
+        MOVW         R13, ret+8(FP)
         RET
 
 TEXT ·I64ToI32s(SB),$8-12
-        MOVL         $0, ret0+8(FP)
 block0:
+        // entry
         MOVQ         x+0(FP), R15
-        MOVL         R15, R14
-        MOVL         R14, ret0+8(FP)
+        MOVL         R15, R13
+        MOVL         R13, ret+8(FP)
         RET
 
 TEXT ·I64ToI64s(SB),$8-16
-        MOVQ         $0, ret0+8(FP)
 block0:
+        // entry
         MOVQ         x+0(FP), R15
-        MOVQ         R15, ret0+8(FP)
+        MOVQ         R15, ret+8(FP)
         RET
 
 TEXT ·I64ToF32s(SB),$8-12
-        MOVL         $0, ret0+8(FP)
 block0:
+        // entry
         MOVQ         x+0(FP), R15
-        CVTSQ2SS     R15, X15
-        MOVSS        X15, ret0+8(FP)
+        CVTSQ2SS     R15, X14
+        MOVSS        X14, ret+8(FP)
         RET
 
 TEXT ·I64ToF64s(SB),$16-16
-        MOVQ         $0, ret0+8(FP)
 block0:
+        // entry
         MOVQ         x+0(FP), R15
-        CVTSQ2SD     R15, X15
-        MOVSD        X15, ret0+8(FP)
+        CVTSQ2SD     R15, X14
+        MOVSD        X14, ret+8(FP)
         RET
 
 TEXT ·F32ToU8s(SB),$8-9
-        MOVB         $0, ret0+8(FP)
 block0:
-        MOVSS        x+0(FP), X15
-        CVTTSS2SL    X15, R15
-        MOVB         R15, ret0+8(FP)
+        // entry
+        MOVSS        x+0(FP), X14
+        CVTTSS2SL    X14, R15
+        MOVB         R15, ret+8(FP)
         RET
 
 TEXT ·F32ToU16s(SB),$8-10
-        MOVW         $0, ret0+8(FP)
 block0:
-        MOVSS        x+0(FP), X15
-        CVTTSS2SL    X15, R15
-        MOVW         R15, ret0+8(FP)
+        // entry
+        MOVSS        x+0(FP), X14
+        CVTTSS2SL    X14, R15
+        MOVW         R15, ret+8(FP)
         RET
 
 TEXT ·F32ToU32s(SB),$8-12
-        MOVL         $0, ret0+8(FP)
 block0:
-        MOVSS        x+0(FP), X15
-        CVTTSS2SL    X15, R15
-        MOVL         R15, ret0+8(FP)
+        // entry
+        MOVSS        x+0(FP), X14
+        CVTTSS2SQ    X14, R15
+        MOVL         R15, ret+8(FP)
         RET
 
 TEXT ·F32ToU64s(SB),$16-16
-        MOVQ         $0, ret0+8(FP)
 block0:
-        MOVSS        x+0(FP), X15
-        CVTTSS2SQ    X15, R15
-        MOVQ         R15, ret0+8(FP)
+        // entry
+        MOVSS        x+0(FP), X14
+        MOVSS        $(9.223372e+18), X13
+        UCOMISS      X14, X13
+        JHI          lbl1
+        MOVO         X14, X13
+        SUBSS        $(9.223372036854776e+18), X13
+        CVTTSS2SQ    X13, R15
+        MOVQ         $-9223372036854775808, R13
+        ORQ          R13, R15
+        JMP          lbl2
+lbl1:
+        CVTTSS2SQ    X14, R15
+lbl2:
+        MOVQ         R15, ret+8(FP)
         RET
 
 TEXT ·F32ToI8s(SB),$8-9
-        MOVB         $0, ret0+8(FP)
 block0:
-        MOVSS        x+0(FP), X15
-        CVTTSS2SL    X15, R15
-        MOVB         R15, ret0+8(FP)
+        // entry
+        MOVSS        x+0(FP), X14
+        CVTTSS2SL    X14, R15
+        MOVB         R15, ret+8(FP)
         RET
 
 TEXT ·F32ToI16s(SB),$8-10
-        MOVW         $0, ret0+8(FP)
 block0:
-        MOVSS        x+0(FP), X15
-        CVTTSS2SL    X15, R15
-        MOVW         R15, ret0+8(FP)
+        // entry
+        MOVSS        x+0(FP), X14
+        CVTTSS2SL    X14, R15
+        MOVW         R15, ret+8(FP)
         RET
 
 TEXT ·F32ToI32s(SB),$8-12
-        MOVL         $0, ret0+8(FP)
 block0:
-        MOVSS        x+0(FP), X15
-        CVTTSS2SL    X15, R15
-        MOVL         R15, ret0+8(FP)
+        // entry
+        MOVSS        x+0(FP), X14
+        CVTTSS2SL    X14, R15
+        MOVL         R15, ret+8(FP)
         RET
 
 TEXT ·F32ToI64s(SB),$16-16
-        MOVQ         $0, ret0+8(FP)
 block0:
-        MOVSS        x+0(FP), X15
-        CVTTSS2SQ    X15, R15
-        MOVQ         R15, ret0+8(FP)
+        // entry
+        MOVSS        x+0(FP), X14
+        CVTTSS2SQ    X14, R15
+        MOVQ         R15, ret+8(FP)
         RET
 
 TEXT ·F32ToF32s(SB),$8-12
-        MOVL         $0, ret0+8(FP)
 block0:
-        MOVSS        x+0(FP), X15
-        MOVSS        X15, ret0+8(FP)
+        // entry
+        MOVSS        x+0(FP), X14
+        MOVSS        X14, ret+8(FP)
         RET
 
 TEXT ·F32ToF64s(SB),$16-16
-        MOVQ         $0, ret0+8(FP)
 block0:
-        MOVSS        x+0(FP), X15
-        CVTSS2SD     X15, X14
-        MOVSD        X14, ret0+8(FP)
+        // entry
+        MOVSS        x+0(FP), X14
+        CVTSS2SD     X14, X13
+        MOVSD        X13, ret+8(FP)
         RET
 
 TEXT ·F64ToU8s(SB),$8-9
-        MOVB         $0, ret0+8(FP)
 block0:
-        MOVSD        x+0(FP), X15
-        CVTTSD2SL    X15, R15
-        MOVB         R15, ret0+8(FP)
+        // entry
+        MOVSD        x+0(FP), X14
+        CVTTSD2SL    X14, R15
+        MOVB         R15, ret+8(FP)
         RET
 
 TEXT ·F64ToU16s(SB),$8-10
-        MOVW         $0, ret0+8(FP)
 block0:
-        MOVSD        x+0(FP), X15
-        CVTTSD2SL    X15, R15
-        MOVW         R15, ret0+8(FP)
+        // entry
+        MOVSD        x+0(FP), X14
+        CVTTSD2SL    X14, R15
+        MOVW         R15, ret+8(FP)
         RET
 
 TEXT ·F64ToU32s(SB),$8-12
-        MOVL         $0, ret0+8(FP)
 block0:
-        MOVSD        x+0(FP), X15
-        CVTTSD2SL    X15, R15
-        MOVL         R15, ret0+8(FP)
+        // entry
+        MOVSD        x+0(FP), X14
+        CVTTSD2SQ    X14, R15
+        MOVL         R15, ret+8(FP)
         RET
 
 TEXT ·F64ToU64s(SB),$16-16
-        MOVQ         $0, ret0+8(FP)
 block0:
-        MOVSD        x+0(FP), X15
-        CVTTSD2SQ    X15, R15
-        MOVQ         R15, ret0+8(FP)
+        // entry
+        MOVSD        x+0(FP), X14
+        MOVSD        $(9.223372036854776e+18), X13
+        UCOMISD      X14, X13
+        JHI          lbl1
+        MOVO         X14, X13
+        SUBSD        $(9.223372036854776e+18), X13
+        CVTTSD2SQ    X13, R15
+        MOVQ         $-9223372036854775808, R13
+        ORQ          R13, R15
+        JMP          lbl2
+lbl1:
+        CVTTSD2SQ    X14, R15
+lbl2:
+        MOVQ         R15, ret+8(FP)
         RET
 
 TEXT ·F64ToI8s(SB),$8-9
-        MOVB         $0, ret0+8(FP)
 block0:
-        MOVSD        x+0(FP), X15
-        CVTTSD2SL    X15, R15
-        MOVB         R15, ret0+8(FP)
+        // entry
+        MOVSD        x+0(FP), X14
+        CVTTSD2SL    X14, R15
+        MOVB         R15, ret+8(FP)
         RET
 
 TEXT ·F64ToI16s(SB),$8-10
-        MOVW         $0, ret0+8(FP)
 block0:
-        MOVSD        x+0(FP), X15
-        CVTTSD2SL    X15, R15
-        MOVW         R15, ret0+8(FP)
+        // entry
+        MOVSD        x+0(FP), X14
+        CVTTSD2SL    X14, R15
+        MOVW         R15, ret+8(FP)
         RET
 
 TEXT ·F64ToI32s(SB),$8-12
-        MOVL         $0, ret0+8(FP)
 block0:
-        MOVSD        x+0(FP), X15
-        CVTTSD2SL    X15, R15
-        MOVL         R15, ret0+8(FP)
+        // entry
+        MOVSD        x+0(FP), X14
+        CVTTSD2SL    X14, R15
+        MOVL         R15, ret+8(FP)
         RET
 
 TEXT ·F64ToI64s(SB),$16-16
-        MOVQ         $0, ret0+8(FP)
 block0:
-        MOVSD        x+0(FP), X15
-        CVTTSD2SQ    X15, R15
-        MOVQ         R15, ret0+8(FP)
+        // entry
+        MOVSD        x+0(FP), X14
+        CVTTSD2SQ    X14, R15
+        MOVQ         R15, ret+8(FP)
         RET
 
 TEXT ·F64ToF32s(SB),$8-12
-        MOVL         $0, ret0+8(FP)
 block0:
-        MOVSD        x+0(FP), X15
-        CVTSD2SS     X15, X14
-        MOVSS        X14, ret0+8(FP)
+        // entry
+        MOVSD        x+0(FP), X14
+        CVTSD2SS     X14, X13
+        MOVSS        X13, ret+8(FP)
         RET
 
 TEXT ·F64ToF64s(SB),$8-16
-        MOVQ         $0, ret0+8(FP)
 block0:
-        MOVSD        x+0(FP), X15
-        MOVSD        X15, ret0+8(FP)
+        // entry
+        MOVSD        x+0(FP), X14
+        MOVSD        X14, ret+8(FP)
         RET
 
@@ -0,0 +1,71 @@
+// +build amd64,gc
+
+package tests
+
+import "testing"
+
+//go:generate gensimd -fn "cset0, cset1, cset2" -outfn "cset0s, cset1s, cset2s" -f "$GOFILE" -o "cse_test_amd64.s"
+//go:generate gensimd -boundscheck -fn "cset0, cset1, cset2" -outfn "cset0b, cset1b, cset2b" -f "$GOFILE" -o "cse_boundscheck_test_amd64.s"
+
+func cset0s(s []int, i int) int
+func cset1s(s []uint32, i, j int) uint32
+func cset2s(s []float64, i int) float64
+
+func cset0b(s []int, i int) int
+func cset1b(s []uint32, i, j int) uint32
+func cset2b(s []float64, i int) float64
+
+// &s[i] is computed once for the three blocks using it
+func cset0(s []int, i int) int {
+	if s[i] > 10 {
+		s[i] = 10
+	}
+	return s[i]
+}
+
+// the addresses in the branches aren't available after them
+func cset1(s []uint32, i, j int) uint32 {
+	if s[i] < s[j] {
+		s[j] += s[i]
+	} else {
+		s[i] -= s[j]
+	}
+	return s[i] ^ s[j]
+}
+
+func cset2(s []float64, i int) float64 {
+	x := s[i]
+	for k := 0; k < 3; k++ {
+		s[i] = s[i]*0.5 + x
+	}
+	return s[i]
+}
+
+func TestCSE(t *testing.T) {
+	type funcs struct {
+		name string
+		t0   func([]int, int) int
+		t1   func([]uint32, int, int) uint32
+		t2   func([]float64, int) float64
+	}
+	for _, fns := range []funcs{{"s", cset0s, cset1s, cset2s}, {"b", cset0b, cset1b, cset2b}} {
+		for i := 0; i < 4; i++ {
+			for _, v := range []int{-5, 0, 10, 11, 1 << 40} {
+				got, want := []int{1, 2, 3, v}, []int{1, 2, 3, v}
+				if r, w := fns.t0(got, i), cset0(want, i); r != w || got[i] != want[i] {
+					t.Errorf("cset0%v(%v, %v) %v, %v != %v, %v", fns.name, want, i, r, got, w, want)
+				}
+			}
+			for j := 0; j < 4; j++ {
+				got, want := []uint32{7, 1 << 31, 0, 7}, []uint32{7, 1 << 31, 0, 7}
+				if r, w := fns.t1(got, i, j), cset1(want, i, j); r != w || got[i] != want[i] || got[j] != want[j] {
+					t.Errorf("cset1%v(%v, %v) %v, %v != %v, %v", fns.name, i, j, r, got, w, want)
+				}
+			}
+			got, want := []float64{1, -2.5, 1e10, 0}, []float64{1, -2.5, 1e10, 0}
+			if r, w := fns.t2(got, i), cset2(want, i); r != w || got[i] != want[i] {
+				t.Errorf("cset2%v(%v) %v, %v != %v, %v", fns.name, i, r, got, w, want)
+			}
+		}
+	}
+}
@@ -0,0 +1,152 @@
+// Code generated by gensimd. DO NOT EDIT.
+// gensimd version: devel
+// gensimd command: gensimd -f cse_test.go -fn "cset0, cset1, cset2" -o cse_test_amd64.s -outfn "cset0s, cset1s, cset2s"
+// gensimd source: cse_test.go sha256:05bd43fc7cefbbfefb96821d3a215bfbca16695e94fa59f6ff1711d3bae05708
+// gensimd target: avx2
+
+//go:build amd64 && !noasm && !appengine
+// +build amd64,!noasm,!appengine
+
+#include "textflag.h"
+
+TEXT ·cset0s(SB),$32-40
+block0:
+        // entry
+        MOVQ         i+24(FP), R13
+        MOVQ         s_base+0(FP), R15
+        LEAQ         (R15)(R13*8), R15
+        MOVQ         (R15), R12
+        MOVQ         R12, t1-16(SP)
+        MOVQ         t1-16(SP), R12
+        CMPQ         R12, $10
+        MOVQ         R15, t0-8(SP)
+        JLE          block2
+block1:
+        // if.then, preds block0
+        // ssa.IndexAddr, t3 = &s[i], same as t0
+        MOVQ         t0-8(SP), R15
+        MOVQ         $10, R13
+        MOVQ         R13, (R15)
+block2:
+        // if.done, preds block0 block1
+        // ssa.IndexAddr, t4 = &s[i], same as t0
+        MOVQ         t0-8(SP), R12
+        MOVQ         (R12), R15
+        MOVQ         R15, t5-25(SP)
+        MOVQ         t5-25(SP), R15
+        MOVQ         R15, ret+32(FP)
+        RET
+
+TEXT ·cset1s(SB),$64-44
+block0:
+        // entry
+        MOVQ         i+24(FP), R13
+        MOVQ         s_base+0(FP), R15
+        LEAQ         (R15)(R13*4), R15
+        MOVL         (R15), R12
+        MOVL         R12, t1-20(SP)
+        MOVQ         j+32(FP), R11
+        MOVQ         s_base+0(FP), R12
+        LEAQ         (R12)(R11*4), R12
+        MOVL         (R12), R10
+        MOVL         R10, t3-24(SP)
+        MOVLQZX      t1-20(SP), R10
+        MOVLQZX      t3-24(SP), R9
+        CMPL         R10, R9
+        MOVQ         R12, t2-16(SP)
+        MOVQ         R15, t0-8(SP)
+        JCC          block3
+block1:
+        // if.then, preds block0
+        // ssa.IndexAddr, t5 = &s[i], same as t0
+        MOVQ         t0-8(SP), R12
+        MOVL         (R12), R15
+        MOVL         R15, t6-29(SP)
+        // ssa.IndexAddr, t7 = &s[j], same as t2
+        MOVQ         t2-16(SP), R11
+        MOVL         (R11), R15
+        MOVL         R15, t8-33(SP)
+        MOVLQZX      t8-33(SP), R15
+        MOVLQZX      t6-29(SP), R13
+        ADDL         R13, R15
+        // ssa.IndexAddr, t10 = &s[j], same as t2
+        MOVL         R15, (R11)
+block2:
+        // if.done, preds block1 block3
+        // ssa.IndexAddr, t11 = &s[i], same as t0
+        MOVQ         t0-8(SP), R12
+        MOVL         (R12), R15
+        MOVL         R15, t12-41(SP)
+        // ssa.IndexAddr, t13 = &s[j], same as t2
+        MOVQ         t2-16(SP), R11
+        MOVL         (R11), R15
+        MOVL         R15, t14-45(SP)
+        MOVLQZX      t12-41(SP), R15
+        MOVLQZX      t14-45(SP), R13
+        XORQ         R13, R15
+        MOVL         R15, ret+40(FP)
+        RET
+block3:
+        // if.else, preds block0
+        // ssa.IndexAddr, t16 = &s[j], same as t2
+        MOVQ         t2-16(SP), R10
+        MOVL         (R10), R12
+        MOVL         R12, t17-53(SP)
+        // ssa.IndexAddr, t18 = &s[i], same as t0
+        MOVQ         t0-8(SP), R9
+        MOVL         (R9), R12
+        MOVL         R12, t19-57(SP)
+        MOVLQZX      t19-57(SP), R12
+        MOVLQZX      t17-53(SP), R11
+        SUBL         R11, R12
+        // ssa.IndexAddr, t21 = &s[i], same as t0
+        MOVL         R12, (R9)
+        JMP block2
+
+TEXT ·cset2s(SB),$72-40
+        MOVQ         i+24(FP), R8
+block0:
+        // entry
+        MOVQ         R8, R13
+        MOVQ         s_base+0(FP), R15
+        LEAQ         (R15)(R13*8), R15
+        MOVSD        (R15), X14
+        MOVSD        X14, t1-16(SP)
+        MOVSD        t1-16(SP), X0
+        MOVQ         $0, R12
+        MOVQ         R12, t2-24(SP)
+        MOVQ         t2-24(SP), BX
+        MOVQ         R15, t0-8(SP)
+block1:
+        // for.loop, preds block0 block2
+        MOVQ         BX, R15
+        CMPQ         R15, $3
+        JGE          block3
+block2:
+        // for.body, preds block1
+        // ssa.IndexAddr, t4 = &s[i], same as t0
+        MOVQ         t0-8(SP), R13
+        MOVSD        (R13), X14
+        MOVSD        X14, t5-33(SP)
+        MOVSD        t5-33(SP), X14
+        MOVSD        $(0.5), X13
+        MULSD        X13, X14
+        MOVO         X0, X12
+        ADDSD        X12, X14
+        // ssa.IndexAddr, t8 = &s[i], same as t0
+        MOVSD        X14, (R13)
+        MOVQ         BX, R15
+        MOVQ         R15, R12
+        ADDQ         $1, R12
+        MOVQ         R12, BX
+        JMP block1
+block3:
+        // for.done, preds block1
+        // ssa.IndexAddr, t10 = &s[i], same as t0
+        MOVQ         t0-8(SP), R13
+        MOVSD        (R13), X14
+        MOVSD        X14, t11-65(SP)
+        MOVSD        t11-65(SP), X14
+        MOVSD        X14, ret+32(FP)
+        RET
+
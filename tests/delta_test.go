@@ -0,0 +1,78 @@
+// +build amd64,gc
+
+package tests
+
+import (
+	"testing"
+
+	"github.com/bjwbell/gensimd/simd"
+)
+
+//go:generate gensimd -fn "deltat0, deltat1, deltat2, deltat3" -outfn "deltat0s, deltat1s, deltat2s, deltat3s" -f "$GOFILE" -o "delta_test_amd64.s"
+
+func deltat0s(x simd.I32x4) simd.I32x4
+func deltat1s(x simd.I64x2) simd.I64x2
+func deltat2s(dst, deltas []simd.I32x4, base int32)
+func deltat3s(dst, deltas []simd.I64x2, base int64)
+
+func deltat0(x simd.I32x4) simd.I32x4 {
+	return simd.PrefixSumI32x4(x)
+}
+
+func deltat1(x simd.I64x2) simd.I64x2 {
+	return simd.PrefixSumI64x2(x)
+}
+
+// decodes a column of int32 deltas starting at base
+func deltat2(dst, deltas []simd.I32x4, base int32) {
+	prev := simd.SplatI32x4(base)
+	for i := range deltas {
+		prev = simd.DeltaDecodeI32x4(deltas[i], prev)
+		dst[i] = prev
+	}
+}
+
+func deltat3(dst, deltas []simd.I64x2, base int64) {
+	prev := simd.SplatI64x2(base)
+	for i := range deltas {
+		prev = simd.DeltaDecodeI64x2(deltas[i], prev)
+		dst[i] = prev
+	}
+}
+
+func TestDelta(t *testing.T) {
+	xs := []simd.I32x4{{1, 2, 3, 4}, {-1, 1, -1, 1}, {1<<31 - 1, 1, -1 << 31, -1}, {0, 0, 0, 7}}
+	for _, x := range xs {
+		if got, expected := deltat0s(x), deltat0(x); got != expected {
+			t.Errorf("deltat0s(%v) %v != %v", x, got, expected)
+		}
+	}
+	ys := []simd.I64x2{{1, 2}, {-1, 1}, {1<<63 - 1, 1}, {1 << 40, -3}}
+	for _, y := range ys {
+		if got, expected := deltat1s(y), deltat1(y); got != expected {
+			t.Errorf("deltat1s(%v) %v != %v", y, got, expected)
+		}
+	}
+	dst, expected := make([]simd.I32x4, len(xs)), make([]simd.I32x4, len(xs))
+	deltat2s(dst, xs, 100)
+	deltat2(expected, xs, 100)
+	for i := range dst {
+		if dst[i] != expected[i] {
+			t.Errorf("deltat2s(%v, 100) %v != %v", xs, dst, expected)
+			break
+		}
+	}
+	// the values are the running sum of the deltas
+	if sum := int32(100 + 1 + 2 + 3 + 4); expected[0][3] != sum {
+		t.Errorf("deltat2(%v, 100)[0][3] %v != %v", xs, expected[0][3], sum)
+	}
+	dst64, expected64 := make([]simd.I64x2, len(ys)), make([]simd.I64x2, len(ys))
+	deltat3s(dst64, ys, -5)
+	deltat3(expected64, ys, -5)
+	for i := range dst64 {
+		if dst64[i] != expected64[i] {
+			t.Errorf("deltat3s(%v, -5) %v != %v", ys, dst64, expected64)
+			break
+		}
+	}
+}
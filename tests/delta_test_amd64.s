@@ -0,0 +1,148 @@
+// Code generated by gensimd. DO NOT EDIT.
+// gensimd version: devel
+// gensimd command: gensimd -f delta_test.go -fn "deltat0, deltat1, deltat2, deltat3" -o delta_test_amd64.s -outfn "deltat0s, deltat1s, deltat2s, deltat3s"
+// gensimd source: delta_test.go sha256:cd31682c5d984d60ca1e864af63837257c46adce754b8598dcba0c4d2dfdb9d0
+// gensimd target: avx2
+
+//go:build amd64 && !noasm && !appengine
+// +build amd64,!noasm,!appengine
+
+#include "textflag.h"
+
+TEXT ·deltat0s(SB),$24-32
+block0:
+        // entry
+        MOVUPS       x+0(FP), X14
+        MOVO         X14, X13
+        MOVO         X13, X12
+        PSLLO        $4, X12
+        PADDL        X12, X13
+        MOVO         X13, X12
+        PSLLO        $8, X12
+        PADDL        X12, X13
+        MOVUPS       X13, ret+16(FP)
+        RET
+
+TEXT ·deltat1s(SB),$24-32
+block0:
+        // entry
+        MOVUPS       x+0(FP), X14
+        MOVO         X14, X13
+        MOVO         X13, X12
+        PSLLO        $8, X12
+        PADDQ        X12, X13
+        MOVUPS       X13, ret+16(FP)
+        RET
+
+TEXT ·deltat2s(SB),$112-52
+block0:
+        // entry
+        MOVLQZX      base+48(FP), R15
+        MOVLQZX      R15, R13
+        MOVQ         R13, X14
+        PSHUFL       $0, X14, X14
+        MOVQ         deltas_len+32(FP), R13
+        MOVQ         R13, R12
+        MOVQ         R12, BX
+        MOVOU        X14, t2-40(SP)
+        MOVQ         $-1, R11
+        MOVQ         R11, t3-48(SP)
+        MOVQ         R12, t1-24(SP)
+        MOVOU        X14, t0-16(SP)
+block1:
+        // rangeindex.loop, preds block0 block2
+        MOVQ         t3-48(SP), R15
+        MOVQ         R15, R13
+        ADDQ         $1, R13
+        MOVQ         BX, R12
+        CMPQ         R13, R12
+        MOVQ         R13, t4-56(SP)
+        JGE          block3
+block2:
+        // rangeindex.body, preds block1
+        MOVQ         t4-56(SP), R13
+        IMUL3Q       $16, R13, R13
+        MOVQ         deltas_base+24(FP), R15
+        ADDQ         R13, R15
+        MOVQ         R15, R13
+        MOVOU        (R13), X14
+        MOVOU        X14, t7-81(SP)
+        MOVOU        t7-81(SP), X14
+        MOVO         X14, X13
+        MOVO         X13, X12
+        PSLLO        $4, X12
+        PADDL        X12, X13
+        MOVO         X13, X12
+        PSLLO        $8, X12
+        PADDL        X12, X13
+        MOVOU        t2-40(SP), X11
+        PSHUFL       $255, X11, X12
+        PADDL        X12, X13
+        MOVQ         t4-56(SP), R12
+        IMUL3Q       $16, R12, R12
+        MOVQ         dst_base+0(FP), R13
+        ADDQ         R12, R13
+        MOVOU        X13, (R13)
+        MOVOU        X13, t2-40(SP)
+        MOVQ         t4-56(SP), R12
+        MOVQ         R12, t3-48(SP)
+        MOVOU        X13, t8-97(SP)
+        JMP block1
+block3:
+        // rangeindex.done, preds block1
+        RET
+
+TEXT ·deltat3s(SB),$112-56
+block0:
+        // entry
+        MOVQ         base+48(FP), R15
+        MOVQ         R15, X14
+        PUNPCKLQDQ    X14, X14
+        MOVQ         deltas_len+32(FP), R13
+        MOVQ         R13, R12
+        MOVQ         R12, BX
+        MOVOU        X14, t2-40(SP)
+        MOVQ         $-1, R11
+        MOVQ         R11, t3-48(SP)
+        MOVQ         R12, t1-24(SP)
+        MOVOU        X14, t0-16(SP)
+block1:
+        // rangeindex.loop, preds block0 block2
+        MOVQ         t3-48(SP), R15
+        MOVQ         R15, R13
+        ADDQ         $1, R13
+        MOVQ         BX, R12
+        CMPQ         R13, R12
+        MOVQ         R13, t4-56(SP)
+        JGE          block3
+block2:
+        // rangeindex.body, preds block1
+        MOVQ         t4-56(SP), R13
+        IMUL3Q       $16, R13, R13
+        MOVQ         deltas_base+24(FP), R15
+        ADDQ         R13, R15
+        MOVQ         R15, R13
+        MOVOU        (R13), X14
+        MOVOU        X14, t7-81(SP)
+        MOVOU        t7-81(SP), X14
+        MOVO         X14, X13
+        MOVO         X13, X12
+        PSLLO        $8, X12
+        PADDQ        X12, X13
+        MOVOU        t2-40(SP), X11
+        PSHUFL       $238, X11, X12
+        PADDQ        X12, X13
+        MOVQ         t4-56(SP), R12
+        IMUL3Q       $16, R12, R12
+        MOVQ         dst_base+0(FP), R13
+        ADDQ         R12, R13
+        MOVOU        X13, (R13)
+        MOVOU        X13, t2-40(SP)
+        MOVQ         t4-56(SP), R12
+        MOVQ         R12, t3-48(SP)
+        MOVOU        X13, t8-97(SP)
+        JMP block1
+block3:
+        // rangeindex.done, preds block1
+        RET
+
@@ -0,0 +1,70 @@
+// +build amd64,gc
+
+package tests
+
+import (
+	"testing"
+
+	"github.com/bjwbell/gensimd/simd"
+)
+
+//go:generate gensimd -fn "dotu8t0, dotu8t1" -outfn "dotu8t0s, dotu8t1s" -f "$GOFILE" -o "dotu8_test_amd64.s"
+//go:generate gensimd -target avx512vnni -fn "dotu8t0, dotu8t1" -outfn "dotu8t0v, dotu8t1v" -f "$GOFILE" -o "dotu8_vnni_test_amd64.s"
+
+// PMADDUBSW and PMADDWD, the default avx2 target
+func dotu8t0s(x simd.U8x16, y simd.I8x16) simd.I32x4
+func dotu8t1s(a []simd.U8x16, b []simd.I8x16) simd.I32x4
+
+// VPDPBUSD
+func dotu8t0v(x simd.U8x16, y simd.I8x16) simd.I32x4
+func dotu8t1v(a []simd.U8x16, b []simd.I8x16) simd.I32x4
+
+func dotu8t0(x simd.U8x16, y simd.I8x16) simd.I32x4 {
+	return simd.DotU8I8x16(x, y)
+}
+
+// the inner loop of a quantized matrix multiply, uint8 activations and
+// int8 weights
+func dotu8t1(a []simd.U8x16, b []simd.I8x16) simd.I32x4 {
+	sum := simd.DotU8I8x16(a[0], b[0])
+	for i := 1; i < len(a); i++ {
+		sum = simd.AddI32x4(sum, simd.DotU8I8x16(a[i], b[i]))
+	}
+	return sum
+}
+
+func TestDotU8I8(t *testing.T) {
+	// the extremes saturate a single PMADDUBSW
+	xs := []simd.U8x16{
+		{255, 255, 255, 255, 255, 255, 255, 255, 0, 0, 0, 0, 1, 2, 3, 4},
+		{0, 1, 127, 128, 129, 200, 254, 255, 7, 77, 177, 250, 128, 128, 255, 255},
+	}
+	ys := []simd.I8x16{
+		{127, 127, 127, 127, -128, -128, -128, -128, 127, -128, 5, 6, 1, -1, 1, -1},
+		{-128, 127, -128, 127, 0, -1, 1, -7, 100, -100, 3, -3, -128, -128, -128, -128},
+	}
+	type funcs struct {
+		name string
+		t0   func(simd.U8x16, simd.I8x16) simd.I32x4
+		t1   func([]simd.U8x16, []simd.I8x16) simd.I32x4
+	}
+	tests := []funcs{}
+	if simd.SSSE3() {
+		tests = append(tests, funcs{"pmaddubsw", dotu8t0s, dotu8t1s})
+	}
+	if simd.AVX512VNNI() {
+		tests = append(tests, funcs{"vpdpbusd", dotu8t0v, dotu8t1v})
+	}
+	for _, fns := range tests {
+		for _, x := range xs {
+			for _, y := range ys {
+				if got, want := fns.t0(x, y), dotu8t0(x, y); got != want {
+					t.Errorf("%v t0(%v, %v) %v != %v", fns.name, x, y, got, want)
+				}
+			}
+		}
+		if got, want := fns.t1(xs, ys), dotu8t1(xs, ys); got != want {
+			t.Errorf("%v t1(%v, %v) %v != %v", fns.name, xs, ys, got, want)
+		}
+	}
+}
@@ -0,0 +1,139 @@
+// Code generated by gensimd. DO NOT EDIT.
+// gensimd version: devel
+// gensimd command: gensimd -f dotu8_test.go -fn "dotu8t0, dotu8t1" -o dotu8_test_amd64.s -outfn "dotu8t0s, dotu8t1s"
+// gensimd source: dotu8_test.go sha256:4c533addcd1aceed8db21a5097d2e98e0d396593ec395d9d8b3ed63a0b09b816
+// gensimd target: avx2
+
+//go:build amd64 && !noasm && !appengine
+// +build amd64,!noasm,!appengine
+
+#include "textflag.h"
+
+TEXT ·dotu8t0s(SB),$24-48
+block0:
+        // entry
+        MOVUPS       x+0(FP), X14
+        MOVUPS       y+16(FP), X13
+        MOVOU        dotu8t0s_const0<>(SB), X10
+        MOVO         X14, X12
+        PAND         X10, X12
+        MOVO         X10, X11
+        PANDN        X14, X11
+        PMADDUBSW    X13, X12
+        PMADDUBSW    X13, X11
+        MOVOU        dotu8t0s_const1<>(SB), X10
+        PMADDWL      X10, X12
+        PMADDWL      X10, X11
+        PADDL        X11, X12
+        MOVUPS       X12, ret+32(FP)
+        RET
+
+
+DATA dotu8t0s_const0<>+0(SB)/8, $0x7f7f7f7f7f7f7f7f
+DATA dotu8t0s_const0<>+8(SB)/8, $0x7f7f7f7f7f7f7f7f
+GLOBL dotu8t0s_const0<>(SB), RODATA|NOPTR, $16
+
+DATA dotu8t0s_const1<>+0(SB)/8, $0x0001000100010001
+DATA dotu8t0s_const1<>+8(SB)/8, $0x0001000100010001
+GLOBL dotu8t0s_const1<>(SB), RODATA|NOPTR, $16
+
+TEXT ·dotu8t1s(SB),$208-64
+block0:
+        // entry
+        MOVQ         a_base+0(FP), R15
+        MOVQ         R15, R13
+        MOVOU        (R13), X14
+        MOVOU        X14, t1-40(SP)
+        MOVQ         b_base+24(FP), R13
+        MOVQ         R13, R12
+        MOVOU        (R12), X14
+        MOVOU        X14, t3-64(SP)
+        MOVOU        t1-40(SP), X14
+        MOVOU        t3-64(SP), X13
+        MOVOU        dotu8t1s_const0<>(SB), X10
+        MOVO         X14, X12
+        PAND         X10, X12
+        MOVO         X10, X11
+        PANDN        X14, X11
+        PMADDUBSW    X13, X12
+        PMADDUBSW    X13, X11
+        MOVOU        dotu8t1s_const1<>(SB), X10
+        PMADDWL      X10, X12
+        PMADDWL      X10, X11
+        PADDL        X11, X12
+        MOVQ         a_len+8(FP), R12
+        MOVQ         R12, R11
+        MOVQ         R11, BX
+        MOVOU        X12, t5-96(SP)
+        MOVQ         $1, R10
+        MOVQ         R10, t6-104(SP)
+        MOVQ         a_base+0(FP), R9
+        IMUL3Q       $16, R10, R8
+        ADDQ         R8, R9
+        MOVQ         R9, ivptr0-8(SP)
+        MOVQ         b_base+24(FP), R9
+        IMUL3Q       $16, R10, R8
+        ADDQ         R8, R9
+        MOVQ         R9, ivptr1-16(SP)
+        MOVQ         R11, t7-112(SP)
+        MOVOU        X12, t4-80(SP)
+block1:
+        // for.loop, preds block0 block2
+        MOVQ         t6-104(SP), R15
+        MOVQ         BX, R13
+        CMPQ         R15, R13
+        JGE          block3
+block2:
+        // for.body, preds block1
+        MOVQ         ivptr0-8(SP), R15
+        MOVQ         R15, R13
+        MOVQ         R13, R12
+        MOVOU        (R12), X14
+        MOVOU        X14, t10-137(SP)
+        MOVQ         ivptr1-16(SP), R12
+        MOVQ         R12, R11
+        MOVQ         R11, R10
+        MOVOU        (R10), X14
+        MOVOU        X14, t12-161(SP)
+        MOVOU        t10-137(SP), X14
+        MOVOU        t12-161(SP), X13
+        MOVOU        dotu8t1s_const0<>(SB), X10
+        MOVO         X14, X12
+        PAND         X10, X12
+        MOVO         X10, X11
+        PANDN        X14, X11
+        PMADDUBSW    X13, X12
+        PMADDUBSW    X13, X11
+        MOVOU        dotu8t1s_const1<>(SB), X10
+        PMADDWL      X10, X12
+        PMADDWL      X10, X11
+        PADDL        X11, X12
+        MOVOU        t5-96(SP), X11
+        PADDL        X12, X11
+        MOVQ         t6-104(SP), R10
+        MOVQ         R10, R9
+        ADDQ         $1, R9
+        MOVOU        X11, t5-96(SP)
+        MOVQ         R9, t6-104(SP)
+        LEAQ         16(R15), R15
+        MOVQ         R15, ivptr0-8(SP)
+        LEAQ         16(R12), R12
+        MOVQ         R12, ivptr1-16(SP)
+        MOVQ         R9, t15-201(SP)
+        MOVOU        X11, t14-193(SP)
+        JMP block1
+block3:
+        // for.done, preds block1
+        MOVOU        t5-96(SP), X14
+        MOVUPS       X14, ret+48(FP)
+        RET
+
+
+DATA dotu8t1s_const0<>+0(SB)/8, $0x7f7f7f7f7f7f7f7f
+DATA dotu8t1s_const0<>+8(SB)/8, $0x7f7f7f7f7f7f7f7f
+GLOBL dotu8t1s_const0<>(SB), RODATA|NOPTR, $16
+
+DATA dotu8t1s_const1<>+0(SB)/8, $0x0001000100010001
+DATA dotu8t1s_const1<>+8(SB)/8, $0x0001000100010001
+GLOBL dotu8t1s_const1<>(SB), RODATA|NOPTR, $16
+
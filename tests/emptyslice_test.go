@@ -0,0 +1,53 @@
+// +build amd64,gc
+
+package tests
+
+import (
+	"testing"
+
+	"github.com/bjwbell/gensimd/codegen"
+	"github.com/bjwbell/gensimd/internal/asmtest"
+)
+
+// TestEmptySlice calls loops with nil, empty, and zero-length slices of
+// non-zero capacity, with and without optimizations and bounds checks. The
+// loops must not run, so with a nil data pointer any memory access faults.
+func TestEmptySlice(t *testing.T) {
+	optimized := codegen.DefaultOptions()
+	unoptimized := codegen.DefaultOptions()
+	unoptimized.OptLevel = 0
+	checked := codegen.DefaultOptions()
+	checked.BoundsCheck = true
+	for name, opts := range map[string]codegen.Options{"optimized": optimized, "unoptimized": unoptimized, "boundscheck": checked} {
+		kernels := asmtest.Build(t, "testdata/emptyslice.go", opts, "sum", "rangeSum", "addPairs", "last")
+		backing := []int64{1, 2, 3}
+		for _, x := range [][]int64{nil, {}, backing[:0], backing[3:]} {
+			if got := kernels.Call(t, "sum", x)[0].(int64); got != 0 {
+				t.Errorf("%v: sum(%#v) = %v, expected 0", name, x, got)
+			}
+		}
+		for _, x := range [][]float32{nil, {}} {
+			if got := kernels.Call(t, "rangeSum", x)[0].(float32); got != 0 {
+				t.Errorf("%v: rangeSum(%#v) = %v, expected 0", name, x, got)
+			}
+		}
+		dst := []int32{1, 2}
+		for _, args := range [][2][]int32{{nil, nil}, {dst, nil}, {nil, dst}, {dst[:0], dst[2:]}, {dst[:1], dst[1:]}} {
+			if got := kernels.Call(t, "addPairs", args[0], args[1])[0].(int); got != 0 {
+				t.Errorf("%v: addPairs(%#v, %#v) = %v, expected 0", name, args[0], args[1], got)
+			}
+		}
+		if dst[0] != 1 || dst[1] != 2 {
+			t.Errorf("%v: addPairs of empty slices wrote %v", name, dst)
+		}
+		for _, x := range [][]uint8{nil, {}} {
+			if got := kernels.Call(t, "last", x, uint8(0))[0].(int); got != -1 {
+				t.Errorf("%v: last(%#v, 0) = %v, expected -1", name, x, got)
+			}
+		}
+		// the loops still run for non-empty slices
+		if got := kernels.Call(t, "sum", backing)[0].(int64); got != 6 {
+			t.Errorf("%v: sum(%v) = %v, expected 6", name, backing, got)
+		}
+	}
+}
@@ -0,0 +1,75 @@
+// +build amd64,gc
+
+package tests
+
+import (
+	"math"
+	"testing"
+
+	"github.com/bjwbell/gensimd/simd"
+)
+
+//go:generate gensimd -fn "f16t0, f16t1, f16t2" -outfn "f16t0s, f16t1s, f16t2s" -f "$GOFILE" -o "f16_test_amd64.s"
+
+func f16t0s(x simd.U16x8) simd.F32x4
+func f16t1s(x simd.F32x4) simd.U16x8
+func f16t2s(w []simd.U16x8, x []simd.F32x4) simd.F32x4
+
+// VCVTPH2PS
+func f16t0(x simd.U16x8) simd.F32x4 {
+	return simd.F16ToF32x4(x)
+}
+
+// VCVTPS2PH
+func f16t1(x simd.F32x4) simd.U16x8 {
+	return simd.F32ToF16x4(x)
+}
+
+// dot product of fp16 weights and float32 inputs
+func f16t2(w []simd.U16x8, x []simd.F32x4) simd.F32x4 {
+	sum := simd.SplatF32x4(0)
+	for i := range w {
+		sum = simd.AddF32x4(sum, simd.MulF32x4(simd.F16ToF32x4(w[i]), x[i]))
+	}
+	return sum
+}
+
+func f32x4Bits(x simd.F32x4) [4]uint32 {
+	return [4]uint32{math.Float32bits(x[0]), math.Float32bits(x[1]), math.Float32bits(x[2]), math.Float32bits(x[3])}
+}
+
+func TestF16(t *testing.T) {
+	if simd.F16ToF32(0x3c00) != 1 || simd.F16ToF32(0xc000) != -2 || simd.F16ToF32(1) != 1.0/(1<<24) {
+		t.Errorf("F16ToF32 of 1, -2, and the smallest subnormal is wrong")
+	}
+	if simd.F32ToF16(65504) != 0x7bff || simd.F32ToF16(65520) != 0x7c00 || simd.F32ToF16(1+1.0/2048) != 0x3c00 {
+		t.Errorf("F32ToF16 of the largest half, overflow, and a tie is wrong")
+	}
+	if !simd.F16C() {
+		t.Skip("VCVTPH2PS needs F16C")
+	}
+	// every half, the high four elements are ignored
+	for h := 0; h < 0x10000; h += 4 {
+		x := simd.U16x8{uint16(h), uint16(h + 1), uint16(h + 2), uint16(h + 3), 0x7c01, 0xffff, 1, 2}
+		if got, expected := f32x4Bits(f16t0s(x)), f32x4Bits(f16t0(x)); got != expected {
+			t.Fatalf("f16t0s(%#x) %#x != %#x", x, got, expected)
+		}
+		if got, expected := f16t1s(f16t0(x)), f16t1(f16t0(x)); got != expected {
+			t.Fatalf("f16t1s(%v) %#x != %#x", f16t0(x), got, expected)
+		}
+	}
+	// rounding, subnormals, overflow, and NaNs
+	for i := uint32(0); i < 1<<20; i++ {
+		b := i * 4093
+		x := simd.F32x4{math.Float32frombits(b), math.Float32frombits(b ^ 0x80000000 + 0x1000),
+			math.Float32frombits(0x33000000 + i), math.Float32frombits(0x7f800000 + i)}
+		if got, expected := f16t1s(x), f16t1(x); got != expected {
+			t.Fatalf("f16t1s(%v) %#x != %#x", x, got, expected)
+		}
+	}
+	w := []simd.U16x8{{0x3c00, 0x4000, 0x3800, 0xbc00}, {0x3555, 0x7bff, 1, 0x8400}}
+	x := []simd.F32x4{{1, 2, 3, 4}, {0.5, 1.0 / 65504, 1 << 24, 1 << 14}}
+	if got, expected := f16t2s(w, x), f16t2(w, x); got != expected {
+		t.Errorf("f16t2s(%v, %v) %v != %v", w, x, got, expected)
+	}
+}
@@ -0,0 +1,84 @@
+// Code generated by gensimd. DO NOT EDIT.
+// gensimd version: devel
+// gensimd command: gensimd -f f16_test.go -fn "f16t0, f16t1, f16t2" -o f16_test_amd64.s -outfn "f16t0s, f16t1s, f16t2s"
+// gensimd source: f16_test.go sha256:cbb9326106875b95aee7d8b062b8b3a6f91766244dbe73286d72c67beb9440bf
+// gensimd target: avx2
+
+//go:build amd64 && !noasm && !appengine
+// +build amd64,!noasm,!appengine
+
+#include "textflag.h"
+
+TEXT ·f16t0s(SB),$24-32
+block0:
+        // entry
+        MOVUPS       x+0(FP), X14
+        VCVTPH2PS    X14, X13
+        MOVUPS       X13, ret+16(FP)
+        RET
+
+TEXT ·f16t1s(SB),$24-32
+block0:
+        // entry
+        MOVUPS       x+0(FP), X14
+        VCVTPS2PH    $0, X14, X13
+        MOVUPS       X13, ret+16(FP)
+        RET
+
+TEXT ·f16t2s(SB),$160-64
+block0:
+        // entry
+        XORPD        X14, X14
+        MOVO         X14, X13
+        SHUFPS       $0, X13, X13
+        MOVQ         w_len+8(FP), R15
+        MOVQ         R15, R13
+        MOVQ         R13, BX
+        MOVUPS       X13, t2-40(SP)
+        MOVQ         $-1, R12
+        MOVQ         R12, t3-48(SP)
+        MOVQ         R13, t1-24(SP)
+        MOVUPS       X13, t0-16(SP)
+block1:
+        // rangeindex.loop, preds block0 block2
+        MOVQ         t3-48(SP), R15
+        MOVQ         R15, R13
+        ADDQ         $1, R13
+        MOVQ         BX, R12
+        CMPQ         R13, R12
+        MOVQ         R13, t4-56(SP)
+        JGE          block3
+block2:
+        // rangeindex.body, preds block1
+        MOVQ         t4-56(SP), R13
+        IMUL3Q       $16, R13, R13
+        MOVQ         w_base+0(FP), R15
+        ADDQ         R13, R15
+        MOVQ         R15, R13
+        MOVOU        (R13), X14
+        MOVOU        X14, t7-81(SP)
+        MOVOU        t7-81(SP), X14
+        VCVTPH2PS    X14, X13
+        MOVQ         t4-56(SP), R12
+        IMUL3Q       $16, R12, R12
+        MOVQ         x_base+24(FP), R13
+        ADDQ         R12, R13
+        MOVQ         R13, R12
+        MOVUPS       (R12), X12
+        MOVUPS       X12, t10-121(SP)
+        MOVUPS       t10-121(SP), X12
+        MOVUPS       X13, t8-97(SP)
+        MULPS        X12, X13
+        MOVUPS       t2-40(SP), X11
+        ADDPS        X13, X11
+        MOVUPS       X11, t2-40(SP)
+        MOVQ         t4-56(SP), R12
+        MOVQ         R12, t3-48(SP)
+        MOVUPS       X11, t12-153(SP)
+        JMP block1
+block3:
+        // rangeindex.done, preds block1
+        MOVUPS       t2-40(SP), X14
+        MOVUPS       X14, ret+48(FP)
+        RET
+
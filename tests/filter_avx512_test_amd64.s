@@ -0,0 +1,21 @@
+// Code generated by gensimd. DO NOT EDIT.
+// gensimd version: devel
+// gensimd command: gensimd -f filter_test.go -fn filtert2 -o filter_avx512_test_amd64.s -outfn filtert2v -target avx512vnni
+// gensimd source: filter_test.go sha256:c8461f6518af5e5ebc411e9ad945d8d3cc35529aab23823673bb34f68411e68c
+// gensimd target: avx512vnni
+
+//go:build amd64 && !noasm && !appengine
+// +build amd64,!noasm,!appengine
+
+#include "textflag.h"
+
+TEXT ·filtert2v(SB),$24-40
+block0:
+        // entry
+        MOVUPS       x+0(FP), X14
+        MOVQ         mask+16(FP), R15
+        KMOVW        R15, K1
+        VPCOMPRESSD.Z    X14, K1, X13
+        MOVUPS       X13, ret+24(FP)
+        RET
+
@@ -0,0 +1,91 @@
+// Code generated by gensimd. DO NOT EDIT.
+// gensimd version: devel
+// gensimd command: gensimd -f filter_test.go -fn "filtert0, filtert1, filtert2" -o filter_ssse3_test_amd64.s -outfn "filtert0e, filtert1e, filtert2e" -target ssse3
+// gensimd source: filter_test.go sha256:c8461f6518af5e5ebc411e9ad945d8d3cc35529aab23823673bb34f68411e68c
+// gensimd target: ssse3
+
+//go:build amd64 && !noasm && !appengine
+// +build amd64,!noasm,!appengine
+
+#include "textflag.h"
+
+TEXT ·filtert0e(SB),$24-48
+block0:
+        // entry
+        MOVUPS       x+0(FP), X14
+        MOVUPS       y+16(FP), X13
+        MOVO         X13, X12
+        PSUBQ        X14, X12
+        MOVO         X14, X11
+        PXOR         X13, X11
+        MOVO         X12, X10
+        PXOR         X13, X10
+        PAND         X10, X11
+        PXOR         X11, X12
+        PSRAL        $31, X12
+        PSHUFL       $245, X12, X12
+        MOVUPS       X12, ret+32(FP)
+        RET
+
+TEXT ·filtert1e(SB),$24-48
+block0:
+        // entry
+        MOVUPS       x+0(FP), X14
+        MOVUPS       y+16(FP), X13
+        MOVO         X14, X12
+        PCMPEQL      X13, X12
+        PSHUFL       $177, X12, X11
+        PAND         X11, X12
+        MOVUPS       X12, ret+32(FP)
+        RET
+
+TEXT ·filtert2e(SB),$24-40
+block0:
+        // entry
+        MOVUPS       x+0(FP), X14
+        MOVQ         mask+16(FP), R15
+        MOVQ         R15, R13
+        ANDQ         $15, R13
+        SHLQ         $4, R13
+        LEAQ         filtert2e_const0<>(SB), R12
+        MOVOU        (R12)(R13*1), X12
+        MOVO         X14, X13
+        PSHUFB       X12, X13
+        MOVUPS       X13, ret+24(FP)
+        RET
+
+
+DATA filtert2e_const0<>+0(SB)/8, $0x8080808080808080
+DATA filtert2e_const0<>+8(SB)/8, $0x8080808080808080
+DATA filtert2e_const0<>+16(SB)/8, $0x8080808003020100
+DATA filtert2e_const0<>+24(SB)/8, $0x8080808080808080
+DATA filtert2e_const0<>+32(SB)/8, $0x8080808007060504
+DATA filtert2e_const0<>+40(SB)/8, $0x8080808080808080
+DATA filtert2e_const0<>+48(SB)/8, $0x0706050403020100
+DATA filtert2e_const0<>+56(SB)/8, $0x8080808080808080
+DATA filtert2e_const0<>+64(SB)/8, $0x808080800b0a0908
+DATA filtert2e_const0<>+72(SB)/8, $0x8080808080808080
+DATA filtert2e_const0<>+80(SB)/8, $0x0b0a090803020100
+DATA filtert2e_const0<>+88(SB)/8, $0x8080808080808080
+DATA filtert2e_const0<>+96(SB)/8, $0x0b0a090807060504
+DATA filtert2e_const0<>+104(SB)/8, $0x8080808080808080
+DATA filtert2e_const0<>+112(SB)/8, $0x0706050403020100
+DATA filtert2e_const0<>+120(SB)/8, $0x808080800b0a0908
+DATA filtert2e_const0<>+128(SB)/8, $0x808080800f0e0d0c
+DATA filtert2e_const0<>+136(SB)/8, $0x8080808080808080
+DATA filtert2e_const0<>+144(SB)/8, $0x0f0e0d0c03020100
+DATA filtert2e_const0<>+152(SB)/8, $0x8080808080808080
+DATA filtert2e_const0<>+160(SB)/8, $0x0f0e0d0c07060504
+DATA filtert2e_const0<>+168(SB)/8, $0x8080808080808080
+DATA filtert2e_const0<>+176(SB)/8, $0x0706050403020100
+DATA filtert2e_const0<>+184(SB)/8, $0x808080800f0e0d0c
+DATA filtert2e_const0<>+192(SB)/8, $0x0f0e0d0c0b0a0908
+DATA filtert2e_const0<>+200(SB)/8, $0x8080808080808080
+DATA filtert2e_const0<>+208(SB)/8, $0x0b0a090803020100
+DATA filtert2e_const0<>+216(SB)/8, $0x808080800f0e0d0c
+DATA filtert2e_const0<>+224(SB)/8, $0x0b0a090807060504
+DATA filtert2e_const0<>+232(SB)/8, $0x808080800f0e0d0c
+DATA filtert2e_const0<>+240(SB)/8, $0x0706050403020100
+DATA filtert2e_const0<>+248(SB)/8, $0x0f0e0d0c0b0a0908
+GLOBL filtert2e_const0<>(SB), RODATA|NOPTR, $256
+
@@ -0,0 +1,121 @@
+// +build amd64,gc
+
+package tests
+
+import (
+	"testing"
+
+	"github.com/bjwbell/gensimd/simd"
+)
+
+//go:generate gensimd -fn "filtert0, filtert1, filtert2, filtert3, filtert4" -outfn "filtert0s, filtert1s, filtert2s, filtert3s, filtert4s" -f "$GOFILE" -o "filter_test_amd64.s"
+//go:generate gensimd -target ssse3 -fn "filtert0, filtert1, filtert2" -outfn "filtert0e, filtert1e, filtert2e" -f "$GOFILE" -o "filter_ssse3_test_amd64.s"
+//go:generate gensimd -target avx512vnni -fn "filtert2" -outfn "filtert2v" -f "$GOFILE" -o "filter_avx512_test_amd64.s"
+
+// PCMPGTQ, PCMPEQQ, and PSHUFB, the default avx2 target
+func filtert0s(x, y simd.I64x2) simd.I64x2
+func filtert1s(x, y simd.I64x2) simd.I64x2
+func filtert2s(x simd.I32x4, mask int) simd.I32x4
+func filtert3s(col []int64, i int, lo, hi int64) int
+func filtert4s(dst []int32, i int, x int32)
+
+// the compares emulated with 32 bit operations
+func filtert0e(x, y simd.I64x2) simd.I64x2
+func filtert1e(x, y simd.I64x2) simd.I64x2
+func filtert2e(x simd.I32x4, mask int) simd.I32x4
+
+// VPCOMPRESSD
+func filtert2v(x simd.I32x4, mask int) simd.I32x4
+
+func filtert0(x, y simd.I64x2) simd.I64x2 {
+	return simd.CmpGtI64x2(x, y)
+}
+
+func filtert1(x, y simd.I64x2) simd.I64x2 {
+	return simd.CmpEqI64x2(x, y)
+}
+
+func filtert2(x simd.I32x4, mask int) simd.I32x4 {
+	return simd.CompressI32x4(x, mask)
+}
+
+// the mask of the rows i and i+1 in [lo, hi]
+func filtert3(col []int64, i int, lo, hi int64) int {
+	x := simd.LoadI64x2(col, i)
+	out := simd.OrI64x2(simd.CmpGtI64x2(simd.SplatI64x2(lo), x), simd.CmpGtI64x2(x, simd.SplatI64x2(hi)))
+	return simd.MoveMaskI64x2(out) ^ 3
+}
+
+func filtert4(dst []int32, i int, x int32) {
+	simd.StoreI32x4(dst, i, simd.SplatI32x4(x))
+}
+
+func TestFilter(t *testing.T) {
+	vals := []int64{-1 << 63, -1<<63 + 1, -1 << 32, -1, 0, 1, 1<<32 - 1, 1 << 32, 1<<63 - 1}
+	type funcs struct {
+		name string
+		t0   func(x, y simd.I64x2) simd.I64x2
+		t1   func(x, y simd.I64x2) simd.I64x2
+	}
+	tests := []funcs{{"emulated", filtert0e, filtert1e}}
+	if simd.HasTarget("avx") {
+		tests = append(tests, funcs{"pcmpgtq", filtert0s, filtert1s})
+	}
+	for _, fns := range tests {
+		for _, a := range vals {
+			for _, b := range vals {
+				x, y := simd.I64x2{a, b}, simd.I64x2{b, a}
+				if got, expected := fns.t0(x, y), filtert0(x, y); got != expected {
+					t.Errorf("%v t0(%v, %v) %v != %v", fns.name, x, y, got, expected)
+				}
+				if got, expected := fns.t1(x, y), filtert1(x, y); got != expected {
+					t.Errorf("%v t1(%v, %v) %v != %v", fns.name, x, y, got, expected)
+				}
+			}
+		}
+	}
+	if !simd.HasTarget("avx") {
+		t.Skip("PCMPGTQ needs SSE4.2")
+	}
+	col := []int64{-3, 5, 0, 7, 10, -3}
+	for i := 0; i+2 <= len(col); i++ {
+		if got, expected := filtert3s(col, i, -3, 7), filtert3(col, i, -3, 7); got != expected {
+			t.Errorf("filtert3s(%v, %v, -3, 7) %v != %v", col, i, got, expected)
+		}
+	}
+	dst := make([]int32, 6)
+	filtert4s(dst, 1, -7)
+	expected := []int32{0, -7, -7, -7, -7, 0}
+	for i := range dst {
+		if dst[i] != expected[i] {
+			t.Errorf("filtert4s(dst, 1, -7) %v != %v", dst, expected)
+			break
+		}
+	}
+}
+
+func TestCompressI32x4(t *testing.T) {
+	type funcs struct {
+		name string
+		t2   func(x simd.I32x4, mask int) simd.I32x4
+	}
+	tests := []funcs{}
+	if simd.SSSE3() {
+		tests = append(tests, funcs{"pshufb ssse3", filtert2e})
+	}
+	if simd.HasTarget("avx2") {
+		tests = append(tests, funcs{"pshufb", filtert2s})
+	}
+	if simd.AVX512VNNI() {
+		tests = append(tests, funcs{"vpcompressd", filtert2v})
+	}
+	x := simd.I32x4{-1, 2, -3, 4}
+	for _, fns := range tests {
+		// the bits above 3 are ignored
+		for mask := 0; mask < 64; mask++ {
+			if got, expected := fns.t2(x, mask), filtert2(x, mask); got != expected {
+				t.Errorf("%v t2(%v, %#x) %v != %v", fns.name, x, mask, got, expected)
+			}
+		}
+	}
+}
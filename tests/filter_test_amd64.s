@@ -0,0 +1,116 @@
+// Code generated by gensimd. DO NOT EDIT.
+// gensimd version: devel
+// gensimd command: gensimd -f filter_test.go -fn "filtert0, filtert1, filtert2, filtert3, filtert4" -o filter_test_amd64.s -outfn "filtert0s, filtert1s, filtert2s, filtert3s, filtert4s"
+// gensimd source: filter_test.go sha256:c8461f6518af5e5ebc411e9ad945d8d3cc35529aab23823673bb34f68411e68c
+// gensimd target: avx2
+
+//go:build amd64 && !noasm && !appengine
+// +build amd64,!noasm,!appengine
+
+#include "textflag.h"
+
+TEXT ·filtert0s(SB),$24-48
+block0:
+        // entry
+        MOVUPS       x+0(FP), X14
+        MOVUPS       y+16(FP), X13
+        MOVO         X14, X12
+        PCMPGTQ      X13, X12
+        MOVUPS       X12, ret+32(FP)
+        RET
+
+TEXT ·filtert1s(SB),$24-48
+block0:
+        // entry
+        MOVUPS       x+0(FP), X14
+        MOVUPS       y+16(FP), X13
+        MOVO         X14, X12
+        PCMPEQQ      X13, X12
+        MOVUPS       X12, ret+32(FP)
+        RET
+
+TEXT ·filtert2s(SB),$24-40
+block0:
+        // entry
+        MOVUPS       x+0(FP), X14
+        MOVQ         mask+16(FP), R15
+        MOVQ         R15, R13
+        ANDQ         $15, R13
+        SHLQ         $4, R13
+        LEAQ         filtert2s_const0<>(SB), R12
+        MOVOU        (R12)(R13*1), X12
+        MOVO         X14, X13
+        PSHUFB       X12, X13
+        MOVUPS       X13, ret+24(FP)
+        RET
+
+
+DATA filtert2s_const0<>+0(SB)/8, $0x8080808080808080
+DATA filtert2s_const0<>+8(SB)/8, $0x8080808080808080
+DATA filtert2s_const0<>+16(SB)/8, $0x8080808003020100
+DATA filtert2s_const0<>+24(SB)/8, $0x8080808080808080
+DATA filtert2s_const0<>+32(SB)/8, $0x8080808007060504
+DATA filtert2s_const0<>+40(SB)/8, $0x8080808080808080
+DATA filtert2s_const0<>+48(SB)/8, $0x0706050403020100
+DATA filtert2s_const0<>+56(SB)/8, $0x8080808080808080
+DATA filtert2s_const0<>+64(SB)/8, $0x808080800b0a0908
+DATA filtert2s_const0<>+72(SB)/8, $0x8080808080808080
+DATA filtert2s_const0<>+80(SB)/8, $0x0b0a090803020100
+DATA filtert2s_const0<>+88(SB)/8, $0x8080808080808080
+DATA filtert2s_const0<>+96(SB)/8, $0x0b0a090807060504
+DATA filtert2s_const0<>+104(SB)/8, $0x8080808080808080
+DATA filtert2s_const0<>+112(SB)/8, $0x0706050403020100
+DATA filtert2s_const0<>+120(SB)/8, $0x808080800b0a0908
+DATA filtert2s_const0<>+128(SB)/8, $0x808080800f0e0d0c
+DATA filtert2s_const0<>+136(SB)/8, $0x8080808080808080
+DATA filtert2s_const0<>+144(SB)/8, $0x0f0e0d0c03020100
+DATA filtert2s_const0<>+152(SB)/8, $0x8080808080808080
+DATA filtert2s_const0<>+160(SB)/8, $0x0f0e0d0c07060504
+DATA filtert2s_const0<>+168(SB)/8, $0x8080808080808080
+DATA filtert2s_const0<>+176(SB)/8, $0x0706050403020100
+DATA filtert2s_const0<>+184(SB)/8, $0x808080800f0e0d0c
+DATA filtert2s_const0<>+192(SB)/8, $0x0f0e0d0c0b0a0908
+DATA filtert2s_const0<>+200(SB)/8, $0x8080808080808080
+DATA filtert2s_const0<>+208(SB)/8, $0x0b0a090803020100
+DATA filtert2s_const0<>+216(SB)/8, $0x808080800f0e0d0c
+DATA filtert2s_const0<>+224(SB)/8, $0x0b0a090807060504
+DATA filtert2s_const0<>+232(SB)/8, $0x808080800f0e0d0c
+DATA filtert2s_const0<>+240(SB)/8, $0x0706050403020100
+DATA filtert2s_const0<>+248(SB)/8, $0x0f0e0d0c0b0a0908
+GLOBL filtert2s_const0<>(SB), RODATA|NOPTR, $256
+
+TEXT ·filtert3s(SB),$120-56
+block0:
+        // entry
+        MOVQ         col_base+0(FP), R15
+        MOVQ         i+24(FP), R13
+        MOVOU        (R15)(R13*8), X14
+        MOVQ         lo+32(FP), R12
+        MOVQ         R12, X13
+        PUNPCKLQDQ    X13, X13
+        MOVO         X13, X12
+        PCMPGTQ      X14, X12
+        MOVQ         hi+40(FP), R11
+        MOVQ         R11, X11
+        PUNPCKLQDQ    X11, X11
+        MOVO         X14, X10
+        PCMPGTQ      X11, X10
+        MOVO         X12, X9
+        POR          X10, X9
+        MOVMSKPD     X9, R10
+        XORQ         $3, R10
+        MOVQ         R10, ret+48(FP)
+        RET
+
+TEXT ·filtert4s(SB),$24-36
+block0:
+        // entry
+        MOVLQZX      x+32(FP), R15
+        MOVLQZX      R15, R13
+        MOVQ         R13, X14
+        PSHUFL       $0, X14, X14
+        MOVQ         dst_base+0(FP), R13
+        MOVQ         i+24(FP), R12
+        MOVOU        X14, (R13)(R12*4)
+        RET
+
@@ -1,139 +1,141 @@
-// +build amd64 !noasm !appengine
+// Code generated by gensimd. DO NOT EDIT.
+// gensimd version: devel
+// gensimd command: gensimd -f float_test.go -fn "ptrt0, ptrt1, addf32, subf32, negf32, mulf32, divf32, addf64, subf64, negf64, mulf64, divf64" -o float_test_amd64.s -outfn "ptrt0s, ptrt1s, addf32s, subf32s, negf32s, mulf32s, divf32s, addf64s, subf64s, negf64s, mulf64s, divf64s"
+// gensimd source: float_test.go sha256:ad9918e78a9a926282a0ab62ed80d7b8338934dd4dd838d4b8fa52e994a3f7ed
+// gensimd target: avx2
+
+//go:build amd64 && !noasm && !appengine
+// +build amd64,!noasm,!appengine
 
 #include "textflag.h"
 
 TEXT ·ptrt0s(SB),$16-12
-        MOVL         $0, ret0+8(FP)
 block0:
-        MOVQ         x+0(FP), R14
-        MOVSS        (R14), X15
-        MOVSS        X15, t0-4(SP)
-        //           $1073741824 = 0000000040000000 = 2(float32)
-        MOVQ         $1073741824, R15
-        MOVQ         R15, X14
+        // entry
+        MOVQ         x+0(FP), R13
+        MOVSS        (R13), X14
+        MOVSS        X14, t0-4(SP)
+        MOVSS        $(2.0), X14
         MOVSS        t0-4(SP), X13
-        MOVO         X14, X15
-        MULSS        X13, X15
-        MOVSS        X15, ret0+8(FP)
+        MOVO         X14, X12
+        MULSS        X13, X12
+        MOVSS        X12, ret+8(FP)
         RET
 
-TEXT ·ptrt1s(SB),$40-16
-        MOVQ         $0, ret0+8(FP)
+TEXT ·ptrt1s(SB),$32-16
 block0:
-        MOVQ         x+0(FP), R14
-        MOVSD        (R14), X15
-        MOVSD        X15, t0-8(SP)
-        //           $4611686018427387904 = 4000000000000000 = 2(float64)
-        MOVQ         $4611686018427387904, R15
-        MOVQ         R15, X14
+        // entry
+        MOVQ         x+0(FP), R13
+        MOVSD        (R13), X14
+        MOVSD        X14, t0-8(SP)
+        MOVSD        $(2.0), X14
         MOVSD        t0-8(SP), X13
-        MOVO         X14, X15
-        MULSD        X13, X15
-        MOVSD        (R14), X12
-        MOVSD        X12, t2-24(SP)
-        MOVSD        t2-24(SP), X11
-        MOVO         X15, X12
-        ADDSD        X11, X12
-        MOVSD        X12, ret0+8(FP)
+        MOVO         X14, X12
+        MULSD        X13, X12
+        // ssa.UnOp, t2 = *x, same as t0
+        ADDSD        X13, X12
+        MOVSD        X12, ret+8(FP)
         RET
 
 TEXT ·addf32s(SB),$8-12
-        MOVL         $0, ret0+8(FP)
 block0:
+        // entry
         MOVSS        x+0(FP), X14
         MOVSS        y+4(FP), X13
-        MOVO         X14, X15
-        ADDSS        X13, X15
-        MOVSS        X15, ret0+8(FP)
+        MOVO         X14, X12
+        ADDSS        X13, X12
+        MOVSS        X12, ret+8(FP)
         RET
 
 TEXT ·subf32s(SB),$8-12
-        MOVL         $0, ret0+8(FP)
 block0:
+        // entry
         MOVSS        x+0(FP), X14
         MOVSS        y+4(FP), X13
-        MOVO         X14, X15
-        SUBSS        X13, X15
-        MOVSS        X15, ret0+8(FP)
+        MOVO         X14, X12
+        SUBSS        X13, X12
+        MOVSS        X12, ret+8(FP)
         RET
 
 TEXT ·negf32s(SB),$8-12
-        MOVL         $0, ret0+8(FP)
 block0:
-        MOVSS        x+0(FP), X13
-        XORPD        X14, X14
-        MOVO         X14, X15
-        SUBSS        X13, X15
-        MOVSS        X15, ret0+8(FP)
+        // entry
+        MOVSS        x+0(FP), X12
+        MOVO         X12, X14
+        MOVQ         $2147483648, R15
+        MOVQ         R15, X13
+        XORPS        X13, X14
+        MOVSS        X14, ret+8(FP)
         RET
 
 TEXT ·mulf32s(SB),$8-12
-        MOVL         $0, ret0+8(FP)
 block0:
+        // entry
         MOVSS        x+0(FP), X14
         MOVSS        y+4(FP), X13
-        MOVO         X14, X15
-        MULSS        X13, X15
-        MOVSS        X15, ret0+8(FP)
+        MOVO         X14, X12
+        MULSS        X13, X12
+        MOVSS        X12, ret+8(FP)
         RET
 
 TEXT ·divf32s(SB),$8-12
-        MOVL         $0, ret0+8(FP)
 block0:
+        // entry
         MOVSS        x+0(FP), X14
         MOVSS        y+4(FP), X13
-        MOVO         X14, X15
-        DIVSS        X13, X15
-        MOVSS        X15, ret0+8(FP)
+        MOVO         X14, X12
+        DIVSS        X13, X12
+        MOVSS        X12, ret+8(FP)
         RET
 
 TEXT ·addf64s(SB),$16-24
-        MOVQ         $0, ret0+16(FP)
 block0:
+        // entry
         MOVSD        x+0(FP), X14
         MOVSD        y+8(FP), X13
-        MOVO         X14, X15
-        ADDSD        X13, X15
-        MOVSD        X15, ret0+16(FP)
+        MOVO         X14, X12
+        ADDSD        X13, X12
+        MOVSD        X12, ret+16(FP)
         RET
 
 TEXT ·subf64s(SB),$16-24
-        MOVQ         $0, ret0+16(FP)
 block0:
+        // entry
         MOVSD        x+0(FP), X14
         MOVSD        y+8(FP), X13
-        MOVO         X14, X15
-        SUBSD        X13, X15
-        MOVSD        X15, ret0+16(FP)
+        MOVO         X14, X12
+        SUBSD        X13, X12
+        MOVSD        X12, ret+16(FP)
         RET
 
 TEXT ·negf64s(SB),$16-16
-        MOVQ         $0, ret0+8(FP)
 block0:
-        MOVSD        x+0(FP), X13
-        XORPD        X14, X14
-        MOVO         X14, X15
-        SUBSD        X13, X15
-        MOVSD        X15, ret0+8(FP)
+        // entry
+        MOVSD        x+0(FP), X12
+        MOVO         X12, X14
+        MOVQ         $-9223372036854775808, R15
+        MOVQ         R15, X13
+        XORPS        X13, X14
+        MOVSD        X14, ret+8(FP)
         RET
 
 TEXT ·mulf64s(SB),$16-24
-        MOVQ         $0, ret0+16(FP)
 block0:
+        // entry
         MOVSD        x+0(FP), X14
         MOVSD        y+8(FP), X13
-        MOVO         X14, X15
-        MULSD        X13, X15
-        MOVSD        X15, ret0+16(FP)
+        MOVO         X14, X12
+        MULSD        X13, X12
+        MOVSD        X12, ret+16(FP)
         RET
 
 TEXT ·divf64s(SB),$16-24
-        MOVQ         $0, ret0+16(FP)
 block0:
+        // entry
         MOVSD        x+0(FP), X14
         MOVSD        y+8(FP), X13
-        MOVO         X14, X15
-        DIVSD        X13, X15
-        MOVSD        X15, ret0+16(FP)
+        MOVO         X14, X12
+        DIVSD        X13, X12
+        MOVSD        X12, ret+16(FP)
         RET
 
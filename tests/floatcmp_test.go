@@ -0,0 +1,67 @@
+// +build amd64,gc
+
+package tests
+
+import (
+	"math"
+	"testing"
+
+	"github.com/bjwbell/gensimd/codegen"
+	"github.com/bjwbell/gensimd/internal/asmtest"
+)
+
+// TestFloatCmp checks float comparisons against Go for NaN, signed zeros,
+// and infinities, with and without optimizations. Like Go, every ordered
+// comparison and == with a NaN is false and != is true, and 0 == -0.
+func TestFloatCmp(t *testing.T) {
+	nan, inf := math.NaN(), math.Inf(1)
+	values := []float64{nan, -nan, 0, math.Copysign(0, -1), inf, -inf, 1, -1, math.MaxFloat64, math.SmallestNonzeroFloat64}
+	cmps := map[string]func(x, y float64) bool{
+		"eq": func(x, y float64) bool { return x == y },
+		"ne": func(x, y float64) bool { return x != y },
+		"lt": func(x, y float64) bool { return x < y },
+		"le": func(x, y float64) bool { return x <= y },
+		"gt": func(x, y float64) bool { return x > y },
+		"ge": func(x, y float64) bool { return x >= y },
+	}
+	ifs := map[string]string{"ifEq": "eq", "ifNe": "ne", "ifLt": "lt", "ifLe": "le", "ifGt": "gt", "ifGe": "ge"}
+	unoptimized := codegen.DefaultOptions()
+	unoptimized.OptLevel = 0
+	for name, opts := range map[string]codegen.Options{"optimized": codegen.DefaultOptions(), "unoptimized": unoptimized} {
+		kernels := asmtest.Build(t, "testdata/floatcmp.go", opts,
+			"eq", "ne", "lt", "le", "gt", "ge", "eq32", "gt32",
+			"ifEq", "ifNe", "ifLt", "ifLe", "ifGt", "ifGe", "ifNotGe32")
+		for _, x := range values {
+			for _, y := range values {
+				for fn, cmp := range cmps {
+					if got, expected := kernels.Call(t, fn, x, y)[0].(bool), cmp(x, y); got != expected {
+						t.Errorf("%v: %v(%v, %v) = %v, expected %v", name, fn, x, y, got, expected)
+					}
+				}
+				for fn, cmp := range ifs {
+					expected := 2
+					if cmps[cmp](x, y) {
+						expected = 1
+					}
+					if got := kernels.Call(t, fn, x, y)[0].(int); got != expected {
+						t.Errorf("%v: %v(%v, %v) = %v, expected %v", name, fn, x, y, got, expected)
+					}
+				}
+				x32, y32 := float32(x), float32(y)
+				if got := kernels.Call(t, "eq32", x32, y32)[0].(bool); got != (x32 == y32) {
+					t.Errorf("%v: eq32(%v, %v) = %v, expected %v", name, x32, y32, got, x32 == y32)
+				}
+				if got := kernels.Call(t, "gt32", x32, y32)[0].(bool); got != (x32 > y32) {
+					t.Errorf("%v: gt32(%v, %v) = %v, expected %v", name, x32, y32, got, x32 > y32)
+				}
+				expected := 2
+				if !(x32 >= y32) {
+					expected = 1
+				}
+				if got := kernels.Call(t, "ifNotGe32", x32, y32)[0].(int); got != expected {
+					t.Errorf("%v: ifNotGe32(%v, %v) = %v, expected %v", name, x32, y32, got, expected)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,64 @@
+// +build amd64,gc
+
+package tests
+
+import (
+	"math"
+	"testing"
+
+	"github.com/bjwbell/gensimd/codegen"
+	"github.com/bjwbell/gensimd/internal/asmtest"
+)
+
+// floatConvValues are the boundaries of the integer types, fractions
+// truncated toward zero, and values out of range of every type.
+var floatConvValues = []float64{
+	0, math.Copysign(0, -1), 0.5, -0.5, 1.9, -1.9,
+	127.5, 128, -128.5, -129, 255.9, 256, 65535.5, 65536,
+	math.MaxInt32, math.MaxInt32 + 1, math.MinInt32, math.MinInt32 - 1,
+	math.MaxUint32, math.MaxUint32 + 1, 1 << 53,
+	math.MinInt64, 1<<63 - 1024, 1 << 63, 1<<64 - 2048, 1 << 64, -1,
+	math.MaxFloat64, -math.MaxFloat64, math.Inf(1), math.Inf(-1), math.NaN(),
+}
+
+// TestFloatConv checks float to integer conversions against the gc
+// compiler, in range values are truncated toward zero and out of range ones,
+// which Go leaves to the implementation, match amd64.
+func TestFloatConv(t *testing.T) {
+	kernels := asmtest.Build(t, "testdata/floatconv.go", codegen.DefaultOptions(),
+		"f64ToInt64", "f64ToInt32", "f64ToInt8", "f64ToUint8", "f64ToUint16", "f64ToUint32", "f64ToUint64",
+		"f32ToInt64", "f32ToUint32", "f32ToUint64")
+	for _, x := range floatConvValues {
+		if got := kernels.Call(t, "f64ToInt64", x)[0].(int64); got != int64(x) {
+			t.Errorf("f64ToInt64(%v) = %v, expected %v", x, got, int64(x))
+		}
+		if got := kernels.Call(t, "f64ToInt32", x)[0].(int32); got != int32(x) {
+			t.Errorf("f64ToInt32(%v) = %v, expected %v", x, got, int32(x))
+		}
+		if got := kernels.Call(t, "f64ToInt8", x)[0].(int8); got != int8(x) {
+			t.Errorf("f64ToInt8(%v) = %v, expected %v", x, got, int8(x))
+		}
+		if got := kernels.Call(t, "f64ToUint8", x)[0].(uint8); got != uint8(x) {
+			t.Errorf("f64ToUint8(%v) = %v, expected %v", x, got, uint8(x))
+		}
+		if got := kernels.Call(t, "f64ToUint16", x)[0].(uint16); got != uint16(x) {
+			t.Errorf("f64ToUint16(%v) = %v, expected %v", x, got, uint16(x))
+		}
+		if got := kernels.Call(t, "f64ToUint32", x)[0].(uint32); got != uint32(x) {
+			t.Errorf("f64ToUint32(%v) = %v, expected %v", x, got, uint32(x))
+		}
+		if got := kernels.Call(t, "f64ToUint64", x)[0].(uint64); got != uint64(x) {
+			t.Errorf("f64ToUint64(%v) = %#x, expected %#x", x, got, uint64(x))
+		}
+		x32 := float32(x)
+		if got := kernels.Call(t, "f32ToInt64", x32)[0].(int64); got != int64(x32) {
+			t.Errorf("f32ToInt64(%v) = %v, expected %v", x32, got, int64(x32))
+		}
+		if got := kernels.Call(t, "f32ToUint32", x32)[0].(uint32); got != uint32(x32) {
+			t.Errorf("f32ToUint32(%v) = %v, expected %v", x32, got, uint32(x32))
+		}
+		if got := kernels.Call(t, "f32ToUint64", x32)[0].(uint64); got != uint64(x32) {
+			t.Errorf("f32ToUint64(%v) = %#x, expected %#x", x32, got, uint64(x32))
+		}
+	}
+}
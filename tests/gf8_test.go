@@ -0,0 +1,63 @@
+// +build amd64,gc
+
+package tests
+
+import (
+	"testing"
+
+	"github.com/bjwbell/gensimd/simd"
+)
+
+//go:generate gensimd -fn "gf8t0, gf8t1, gf8t2, gf8t3" -outfn "gf8t0s, gf8t1s, gf8t2s, gf8t3s" -f "$GOFILE" -o "gf8_test_amd64.s"
+
+func gf8t0s(x, indices simd.U8x16) simd.U8x16
+func gf8t1s(x simd.U8x16) simd.U8x16
+func gf8t2s(x, lo, hi simd.U8x16) simd.U8x16
+func gf8t3s(dst, src simd.U8x16, lo, hi simd.U8x16) simd.U8x16
+
+// PSHUFB
+func gf8t0(x, indices simd.U8x16) simd.U8x16 {
+	return simd.ShuffleVarU8x16(x, indices)
+}
+
+// constant multipliers, the tables of 3 are shared by both multiplies
+func gf8t1(x simd.U8x16) simd.U8x16 {
+	return simd.XorU8x16(simd.MulGF8U8x16(x, 3), simd.MulGF8U8x16(simd.MulGF8U8x16(x, 0x8e), 3))
+}
+
+func gf8t2(x, lo, hi simd.U8x16) simd.U8x16 {
+	return simd.MulGF8TableU8x16(x, lo, hi)
+}
+
+// multiply and add, the Reed-Solomon inner loop, the tables are read again
+func gf8t3(dst, src simd.U8x16, lo, hi simd.U8x16) simd.U8x16 {
+	dst = simd.XorU8x16(dst, simd.MulGF8TableU8x16(src, lo, hi))
+	return simd.XorU8x16(dst, simd.MulGF8TableU8x16(dst, lo, hi))
+}
+
+func TestGF8(t *testing.T) {
+	if simd.MulGF8(0x80, 2) != 0x1d || simd.MulGF8(0x8e, 2) != 1 || simd.MulGF8(7, 1) != 7 {
+		t.Errorf("MulGF8 doesn't reduce by 0x11d")
+	}
+	if !simd.SSSE3() {
+		t.Skip("PSHUFB needs SSSE3")
+	}
+	x := simd.U8x16{0, 1, 2, 0x0f, 0x10, 0x1d, 0x80, 0x8e, 0xf0, 0xff, 7, 100, 200, 33, 0x55, 0xaa}
+	indices := simd.U8x16{15, 0, 0x80, 0x8f, 1, 16, 0x7f, 3, 5, 5, 5, 2, 0xff, 9, 10, 14}
+	if gf8t0s(x, indices) != gf8t0(x, indices) {
+		t.Errorf("gf8t0s(%v, %v) %v != %v", x, indices, gf8t0s(x, indices), gf8t0(x, indices))
+	}
+	if gf8t1s(x) != gf8t1(x) {
+		t.Errorf("gf8t1s(%v) %v != %v", x, gf8t1s(x), gf8t1(x))
+	}
+	y := simd.U8x16{9, 8, 7, 6, 5, 4, 3, 2, 1, 0, 0xff, 0xfe, 0x80, 0x40, 0x20, 0x10}
+	for _, c := range []uint8{0, 1, 2, 3, 0x1d, 0x8e, 0xff} {
+		lo, hi := simd.GF8MulTables(c)
+		if got, want := gf8t2s(x, lo, hi), simd.MulGF8U8x16(x, c); got != want || gf8t2(x, lo, hi) != want {
+			t.Errorf("gf8t2s(%v, %v) %v != %v", x, c, got, want)
+		}
+		if gf8t3s(y, x, lo, hi) != gf8t3(y, x, lo, hi) {
+			t.Errorf("gf8t3s(%v, %v, %v) %v != %v", y, x, c, gf8t3s(y, x, lo, hi), gf8t3(y, x, lo, hi))
+		}
+	}
+}
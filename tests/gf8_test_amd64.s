@@ -0,0 +1,144 @@
+// Code generated by gensimd. DO NOT EDIT.
+// gensimd version: devel
+// gensimd command: gensimd -f gf8_test.go -fn "gf8t0, gf8t1, gf8t2, gf8t3" -o gf8_test_amd64.s -outfn "gf8t0s, gf8t1s, gf8t2s, gf8t3s"
+// gensimd source: gf8_test.go sha256:c859a7c4feb6965c992fb9668e31c901225d7c54e794a50b83740b7feb76b58d
+// gensimd target: avx2
+
+//go:build amd64 && !noasm && !appengine
+// +build amd64,!noasm,!appengine
+
+#include "textflag.h"
+
+TEXT ·gf8t0s(SB),$24-48
+block0:
+        // entry
+        MOVUPS       x+0(FP), X14
+        MOVUPS       indices+16(FP), X13
+        MOVO         X14, X12
+        PSHUFB       X13, X12
+        MOVUPS       X12, ret+32(FP)
+        RET
+
+TEXT ·gf8t1s(SB),$72-32
+block0:
+        // entry
+        MOVOU        gf8t1s_const0<>(SB), X14
+        MOVOU        gf8t1s_const1<>(SB), X13
+        MOVUPS       x+0(FP), X12
+        MOVOU        gf8t1s_const2<>(SB), X11
+        MOVO         X12, X10
+        PSRLW        $4, X10
+        PAND         X11, X10
+        PAND         X12, X11
+        PSHUFB       X11, X14
+        PSHUFB       X10, X13
+        PXOR         X13, X14
+        MOVOU        gf8t1s_const3<>(SB), X13
+        MOVOU        gf8t1s_const4<>(SB), X11
+        MOVOU        gf8t1s_const2<>(SB), X10
+        MOVO         X12, X9
+        PSRLW        $4, X9
+        PAND         X10, X9
+        PAND         X12, X10
+        PSHUFB       X10, X13
+        PSHUFB       X9, X11
+        PXOR         X11, X13
+        MOVOU        gf8t1s_const0<>(SB), X11
+        MOVOU        gf8t1s_const1<>(SB), X10
+        MOVOU        gf8t1s_const2<>(SB), X9
+        MOVO         X13, X8
+        PSRLW        $4, X8
+        PAND         X9, X8
+        PAND         X13, X9
+        PSHUFB       X9, X11
+        PSHUFB       X8, X10
+        PXOR         X10, X11
+        MOVO         X14, X10
+        PXOR         X11, X10
+        MOVUPS       X10, ret+16(FP)
+        RET
+
+
+DATA gf8t1s_const0<>+0(SB)/8, $0x090a0f0c05060300
+DATA gf8t1s_const0<>+8(SB)/8, $0x111217141d1e1b18
+GLOBL gf8t1s_const0<>(SB), RODATA|NOPTR, $16
+
+DATA gf8t1s_const1<>+0(SB)/8, $0x90a0f0c050603000
+DATA gf8t1s_const1<>+8(SB)/8, $0x0d3d6d5dcdfdad9d
+GLOBL gf8t1s_const1<>(SB), RODATA|NOPTR, $16
+
+DATA gf8t1s_const2<>+0(SB)/8, $0x0f0f0f0f0f0f0f0f
+DATA gf8t1s_const2<>+8(SB)/8, $0x0f0f0f0f0f0f0f0f
+GLOBL gf8t1s_const2<>(SB), RODATA|NOPTR, $16
+
+DATA gf8t1s_const3<>+0(SB)/8, $0x8d038c028f018e00
+DATA gf8t1s_const3<>+8(SB)/8, $0x890788068b058a04
+GLOBL gf8t1s_const3<>(SB), RODATA|NOPTR, $16
+
+DATA gf8t1s_const4<>+0(SB)/8, $0x3830282018100800
+DATA gf8t1s_const4<>+8(SB)/8, $0x7870686058504840
+GLOBL gf8t1s_const4<>(SB), RODATA|NOPTR, $16
+
+TEXT ·gf8t2s(SB),$24-64
+block0:
+        // entry
+        MOVUPS       lo+16(FP), X14
+        MOVO         X14, X13
+        MOVUPS       hi+32(FP), X12
+        MOVO         X12, X11
+        MOVUPS       x+0(FP), X10
+        MOVOU        gf8t2s_const0<>(SB), X9
+        MOVO         X10, X8
+        PSRLW        $4, X8
+        PAND         X9, X8
+        PAND         X10, X9
+        PSHUFB       X9, X13
+        PSHUFB       X8, X11
+        PXOR         X11, X13
+        MOVUPS       X13, ret+48(FP)
+        RET
+
+
+DATA gf8t2s_const0<>+0(SB)/8, $0x0f0f0f0f0f0f0f0f
+DATA gf8t2s_const0<>+8(SB)/8, $0x0f0f0f0f0f0f0f0f
+GLOBL gf8t2s_const0<>(SB), RODATA|NOPTR, $16
+
+TEXT ·gf8t3s(SB),$72-80
+block0:
+        // entry
+        MOVUPS       lo+32(FP), X14
+        MOVO         X14, X13
+        MOVUPS       hi+48(FP), X12
+        MOVO         X12, X11
+        MOVUPS       src+16(FP), X10
+        MOVOU        gf8t3s_const0<>(SB), X9
+        MOVO         X10, X8
+        PSRLW        $4, X8
+        PAND         X9, X8
+        PAND         X10, X9
+        PSHUFB       X9, X13
+        PSHUFB       X8, X11
+        PXOR         X11, X13
+        MOVUPS       dst+0(FP), X11
+        MOVO         X11, X9
+        PXOR         X13, X9
+        MOVO         X14, X8
+        MOVO         X12, X7
+        MOVOU        gf8t3s_const0<>(SB), X6
+        MOVO         X9, X5
+        PSRLW        $4, X5
+        PAND         X6, X5
+        PAND         X9, X6
+        PSHUFB       X6, X8
+        PSHUFB       X5, X7
+        PXOR         X7, X8
+        MOVO         X9, X7
+        PXOR         X8, X7
+        MOVUPS       X7, ret+64(FP)
+        RET
+
+
+DATA gf8t3s_const0<>+0(SB)/8, $0x0f0f0f0f0f0f0f0f
+DATA gf8t3s_const0<>+8(SB)/8, $0x0f0f0f0f0f0f0f0f
+GLOBL gf8t3s_const0<>(SB), RODATA|NOPTR, $16
+
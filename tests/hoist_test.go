@@ -0,0 +1,89 @@
+// +build amd64,gc
+
+package tests
+
+import (
+	"testing"
+
+	"github.com/bjwbell/gensimd/simd"
+)
+
+//go:generate gensimd -fn "hoistt0, hoistt1, hoistt2, hoistt3" -outfn "hoistt0s, hoistt1s, hoistt2s, hoistt3s" -f "$GOFILE" -o "hoist_test_amd64.s"
+
+func hoistt0s(x []int64, k int64) int64
+func hoistt1s(dst []simd.I32x4, k int32)
+func hoistt2s(x []uint32, n int, k uint) uint32
+func hoistt3s(x []int64, a, b int64) int64
+
+// the length and k*3 are computed once
+func hoistt0(x []int64, k int64) int64 {
+	s := int64(0)
+	for i := 0; i+4 <= len(x); i += 4 {
+		s += x[i] * (k * 3)
+	}
+	return s
+}
+
+// the splat of k is computed once and kept in a register
+func hoistt1(dst []simd.I32x4, k int32) {
+	for i := 0; i < len(dst); i++ {
+		dst[i] = simd.AddI32x4(dst[i], simd.SplatI32x4(k))
+	}
+}
+
+// k&7 is invariant in both loops, the length only in the inner one
+func hoistt2(x []uint32, n int, k uint) uint32 {
+	s := uint32(0)
+	for i := 0; i < n; i++ {
+		for j := 0; j < len(x); j++ {
+			s = s*3 + x[j]<<(k&7) + uint32(i)
+		}
+	}
+	return s
+}
+
+// a*b is hoisted even when the loop doesn't run
+func hoistt3(x []int64, a, b int64) int64 {
+	for i := 0; i < len(x); i++ {
+		x[i] += a * b
+	}
+	return int64(len(x)) + a
+}
+
+func TestHoist(t *testing.T) {
+	for n := 0; n < 11; n++ {
+		x := make([]int64, n)
+		x32 := make([]uint32, n)
+		v := make([]simd.I32x4, n)
+		for i := 0; i < n; i++ {
+			x[i] = int64(3*i) - 4
+			x32[i] = uint32(i) * 0x01000193
+			v[i] = simd.I32x4{int32(i), -1, int32(2 * i), 7}
+		}
+		if got, expected := hoistt0s(x, -5), hoistt0(x, -5); got != expected {
+			t.Errorf("t0 n=%v %v != %v", n, got, expected)
+		}
+		got, expected := append([]simd.I32x4{}, v...), append([]simd.I32x4{}, v...)
+		hoistt1s(got, 9)
+		hoistt1(expected, 9)
+		for i := range v {
+			if got[i] != expected[i] {
+				t.Errorf("t1 n=%v [%v] %v != %v", n, i, got[i], expected[i])
+			}
+		}
+		for _, k := range []uint{0, 3, 13} {
+			if got, expected := hoistt2s(x32, n/2, k), hoistt2(x32, n/2, k); got != expected {
+				t.Errorf("t2 n=%v k=%v %v != %v", n, k, got, expected)
+			}
+		}
+		got64, expected64 := append([]int64{}, x...), append([]int64{}, x...)
+		if g, e := hoistt3s(got64, 6, -7), hoistt3(expected64, 6, -7); g != e {
+			t.Errorf("t3 n=%v %v != %v", n, g, e)
+		}
+		for i := range x {
+			if got64[i] != expected64[i] {
+				t.Errorf("t3 n=%v [%v] %v != %v", n, i, got64[i], expected64[i])
+			}
+		}
+	}
+}
@@ -0,0 +1,260 @@
+// Code generated by gensimd. DO NOT EDIT.
+// gensimd version: devel
+// gensimd command: gensimd -f hoist_test.go -fn "hoistt0, hoistt1, hoistt2, hoistt3" -o hoist_test_amd64.s -outfn "hoistt0s, hoistt1s, hoistt2s, hoistt3s"
+// gensimd source: hoist_test.go sha256:665aeef84c1ea8a680d6cf2a4547afea2ba432fc02403c34a66e160f80351a43
+// gensimd target: avx2
+
+//go:build amd64 && !noasm && !appengine
+// +build amd64,!noasm,!appengine
+
+#include "textflag.h"
+
+TEXT ·hoistt0s(SB),$96-40
+block0:
+        // entry
+        MOVQ         x_len+8(FP), R15
+        MOVQ         R15, R13
+        MOVQ         R13, R8
+        MOVQ         k+24(FP), R12
+        MOVQ         $3, R11
+        MOVQ         R12, R10
+        MOVQ         R10, AX
+        IMULQ        R11
+        MOVQ         AX, R10
+        MOVQ         R10, R9
+        MOVQ         $0, BP
+        MOVQ         BP, t0-16(SP)
+        MOVQ         $0, DI
+        MOVQ         DI, t1-24(SP)
+        MOVQ         t0-16(SP), BX
+        MOVQ         x_base+0(FP), SI
+        LEAQ         (SI)(DI*8), SI
+        MOVQ         SI, ivptr0-8(SP)
+        MOVQ         R10, t7-65(SP)
+        MOVQ         R13, t3-40(SP)
+block1:
+        // for.loop, preds block0 block2
+        MOVQ         t1-24(SP), R15
+        MOVQ         R15, R13
+        ADDQ         $4, R13
+        MOVQ         R8, R12
+        CMPQ         R13, R12
+        JGT          block3
+block2:
+        // for.body, preds block1
+        MOVQ         ivptr0-8(SP), R15
+        MOVQ         R15, R13
+        MOVQ         (R13), R12
+        MOVQ         R12, t6-57(SP)
+        MOVQ         t6-57(SP), R12
+        MOVQ         R9, R11
+        MOVQ         R12, AX
+        IMULQ        R11
+        MOVQ         AX, R12
+        MOVQ         BX, R10
+        MOVQ         R10, BP
+        ADDQ         R12, BP
+        MOVQ         BP, BX
+        MOVQ         t1-24(SP), DI
+        MOVQ         DI, SI
+        ADDQ         $4, SI
+        MOVQ         SI, t1-24(SP)
+        LEAQ         32(R15), R15
+        MOVQ         R15, ivptr0-8(SP)
+        MOVQ         SI, t10-89(SP)
+        JMP block1
+block3:
+        // for.done, preds block1
+        MOVQ         BX, R15
+        MOVQ         R15, ret+32(FP)
+        RET
+
+TEXT ·hoistt1s(SB),$104-28
+block0:
+        // entry
+        MOVQ         dst_len+8(FP), R15
+        MOVQ         R15, R13
+        MOVQ         R13, BX
+        MOVLQZX      k+24(FP), R12
+        MOVLQZX      R12, R11
+        MOVQ         R11, X14
+        PSHUFL       $0, X14, X14
+        MOVO         X14, X0
+        MOVQ         $0, R11
+        MOVQ         R11, t0-16(SP)
+        MOVQ         dst_base+0(FP), R10
+        IMUL3Q       $16, R11, R9
+        ADDQ         R9, R10
+        MOVQ         R10, ivptr0-8(SP)
+        MOVQ         R13, t1-24(SP)
+        MOVOU        X14, t5-65(SP)
+block1:
+        // for.loop, preds block0 block2
+        MOVQ         t0-16(SP), R15
+        MOVQ         BX, R13
+        CMPQ         R15, R13
+        JGE          block3
+block2:
+        // for.body, preds block1
+        MOVQ         ivptr0-8(SP), R15
+        MOVQ         R15, R13
+        MOVQ         R13, R12
+        MOVOU        (R12), X14
+        MOVOU        X14, t4-49(SP)
+        MOVO         X0, X14
+        MOVOU        t4-49(SP), X13
+        PADDL        X14, X13
+        MOVQ         R15, R12
+        MOVOU        X13, (R12)
+        MOVQ         t0-16(SP), R11
+        MOVQ         R11, R10
+        ADDQ         $1, R10
+        MOVQ         R10, t0-16(SP)
+        LEAQ         16(R15), R15
+        MOVQ         R15, ivptr0-8(SP)
+        MOVQ         R10, t8-97(SP)
+        JMP block1
+block3:
+        // for.done, preds block1
+        RET
+
+TEXT ·hoistt2s(SB),$104-44
+block0:
+        // entry
+        MOVQ         x_len+8(FP), R15
+        MOVQ         R15, R13
+        MOVQ         R13, BX
+        MOVQ         k+32(FP), R12
+        MOVQ         R12, R11
+        ANDQ         $7, R11
+        MOVQ         R11, R8
+        MOVL         $0, R10
+        MOVL         R10, t0-12(SP)
+        MOVQ         $0, R9
+        MOVQ         R9, t1-20(SP)
+        MOVQ         R11, t10-66(SP)
+        MOVQ         R13, t5-41(SP)
+block1:
+        // for.loop, preds block0 block6
+        MOVQ         t1-20(SP), R15
+        MOVQ         n+24(FP), R13
+        CMPQ         R15, R13
+        JGE          block3
+block2:
+        // for.body, preds block1
+        MOVLQZX      t0-12(SP), R15
+        MOVL         R15, t3-25(SP)
+        MOVQ         $0, R13
+        MOVQ         R13, t4-33(SP)
+        MOVQ         x_base+0(FP), R12
+        LEAQ         (R12)(R13*4), R12
+        MOVQ         R12, ivptr0-8(SP)
+block4:
+        // for.loop, preds block2 block5
+        MOVQ         t4-33(SP), R15
+        MOVQ         BX, R13
+        CMPQ         R15, R13
+        JGE          block6
+block5:
+        // for.body, preds block4
+        MOVLQZX      t3-25(SP), R15
+        MOVL         $3, R13
+        MOVL         R15, R12
+        MOVL         R12, AX
+        MULL         R13
+        MOVL         AX, R12
+        MOVQ         ivptr0-8(SP), R11
+        MOVQ         R11, R10
+        MOVL         (R10), R9
+        MOVL         R9, t9-58(SP)
+        MOVLQZX      t9-58(SP), R9
+        MOVQ         R8, BP
+        XORQ         DI, DI
+        CMPQ         BP, $32
+        SHLXL        BP, R9, R9
+        CMOVLCC      DI, R9
+        ADDL         R9, R12
+        MOVQ         t1-20(SP), DI
+        MOVL         DI, R9
+        ADDL         R9, R12
+        MOVQ         t4-33(SP), SI
+        MOVQ         SI, DI
+        ADDQ         $1, DI
+        MOVL         R12, t3-25(SP)
+        MOVQ         DI, t4-33(SP)
+        LEAQ         4(R11), R11
+        MOVQ         R11, ivptr0-8(SP)
+        MOVQ         DI, t15-90(SP)
+        MOVL         R12, t14-82(SP)
+        JMP block4
+block3:
+        // for.done, preds block1
+        MOVLQZX      t0-12(SP), R15
+        MOVL         R15, ret+40(FP)
+        RET
+block6:
+        // for.done, preds block4
+        MOVQ         t1-20(SP), R15
+        MOVQ         R15, R13
+        ADDQ         $1, R13
+        MOVLQZX      t3-25(SP), R12
+        MOVL         R12, t0-12(SP)
+        MOVQ         R13, t1-20(SP)
+        MOVQ         R13, t16-98(SP)
+        JMP block1
+
+TEXT ·hoistt3s(SB),$104-48
+block0:
+        // entry
+        MOVQ         x_len+8(FP), R15
+        MOVQ         R15, R13
+        MOVQ         R13, BX
+        MOVQ         a+24(FP), R12
+        MOVQ         b+32(FP), R11
+        MOVQ         R12, R10
+        MOVQ         R10, AX
+        IMULQ        R11
+        MOVQ         AX, R10
+        MOVQ         R10, R8
+        MOVQ         $0, R9
+        MOVQ         R9, t0-16(SP)
+        MOVQ         x_base+0(FP), BP
+        LEAQ         (BP)(R9*8), BP
+        MOVQ         BP, ivptr0-8(SP)
+        MOVQ         R10, t3-33(SP)
+        MOVQ         R13, t1-24(SP)
+block1:
+        // for.loop, preds block0 block2
+        MOVQ         t0-16(SP), R15
+        MOVQ         BX, R13
+        CMPQ         R15, R13
+        JGE          block3
+block2:
+        // for.body, preds block1
+        MOVQ         ivptr0-8(SP), R15
+        MOVQ         R15, R13
+        MOVQ         (R13), R12
+        MOVQ         R12, t5-49(SP)
+        MOVQ         t5-49(SP), R12
+        MOVQ         R8, R11
+        ADDQ         R11, R12
+        MOVQ         R15, R10
+        MOVQ         R12, (R10)
+        MOVQ         t0-16(SP), R9
+        MOVQ         R9, BP
+        ADDQ         $1, BP
+        MOVQ         BP, t0-16(SP)
+        LEAQ         8(R15), R15
+        MOVQ         R15, ivptr0-8(SP)
+        MOVQ         BP, t8-73(SP)
+        JMP block1
+block3:
+        // for.done, preds block1
+        MOVQ         x_len+8(FP), R15
+        MOVQ         R15, R13
+        MOVQ         R13, R12
+        MOVQ         a+24(FP), R11
+        ADDQ         R11, R12
+        MOVQ         R12, ret+40(FP)
+        RET
+
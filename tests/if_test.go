@@ -9,16 +9,16 @@ import (
 
 //go:generate gensimd -fn "ift0, ift1, ift2, ift3, ift4, ift5, ift6, ift7, ift8, ift9" -outfn "ift0s, ift1s, ift2s, ift3s, ift4s, ift5s, ift6s, ift7s, ift8s, ift9s" -f "$GOFILE" -o "if_test_amd64.s"
 
-func ift0s(uint8) uint8
-func ift1s(uint16) uint16
-func ift2s(uint32) uint32
-func ift3s(uint64) uint64
-func ift4s(int8) int8
-func ift5s(int16) int16
-func ift6s(int32) int32
-func ift7s(int64) int64
-func ift8s(float32) float32
-func ift9s(float64) float64
+func ift0s(x uint8) uint8
+func ift1s(x uint16) uint16
+func ift2s(x uint32) uint32
+func ift3s(x uint64) uint64
+func ift4s(x int8) int8
+func ift5s(x int16) int16
+func ift6s(x int32) int32
+func ift7s(x int64) int64
+func ift8s(x float32) float32
+func ift9s(x float64) float64
 
 func ift0(x uint8) uint8 {
 	if x < 2 {
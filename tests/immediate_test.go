@@ -0,0 +1,110 @@
+// +build amd64,gc
+
+package tests
+
+import "testing"
+
+//go:generate gensimd -fn "immt0, immt1, immt2, immt3, immt4, immt5, immt6, immt7" -outfn "immt0s, immt1s, immt2s, immt3s, immt4s, immt5s, immt6s, immt7s" -f "$GOFILE" -o "immediate_test_amd64.s"
+
+func immt0s(x int64) int64
+func immt1s(x uint64) uint64
+func immt2s(x int32) int32
+func immt3s(x uint8) uint8
+func immt4s(x int16) int16
+func immt5s(x uint32) bool
+func immt6s(x int64) bool
+func immt7s(x uint64) int64
+
+// index math with constant operands
+func immt0(x int64) int64 {
+	a := x + 7
+	b := a - -9
+	c := b << 3
+	d := 12 + c
+	return d - 1<<31
+}
+
+// immediates not representable in 32 bits and shifts by the width, the
+// shift counts are variables for vet, they're constants in the ssa
+func immt1(x uint64) uint64 {
+	a := x ^ 0xffffffff
+	b := a + 1<<40
+	c := b & 0xfffffffffffffff0
+	width := uint(64)
+	d := c >> width
+	return c | d | 0x7fffffff
+}
+
+func immt2(x int32) int32 {
+	a := x >> 31
+	n := uint(40)
+	b := x >> n
+	c := 0x55 ^ x
+	return a + b + c<<1
+}
+
+func immt3(x uint8) uint8 {
+	a := x << 7
+	n := uint(9)
+	b := x >> n
+	c := x | 0x80
+	return a + b + c - 255
+}
+
+func immt4(x int16) int16 {
+	a := x - 0x7fff
+	b := -1 & a
+	return b >> 3
+}
+
+func immt5(x uint32) bool {
+	return x < 0xffffffff && x > 3
+}
+
+func immt6(x int64) bool {
+	if x > -5 {
+		return x <= 1<<30
+	}
+	return x == -1<<31
+}
+
+// compares to constants not representable in 32 bits
+func immt7(x uint64) int64 {
+	if x >= 0x80000000 {
+		return 1
+	}
+	if x != 0xffffffffffffffff {
+		return 2
+	}
+	return 3
+}
+
+func TestImmediate(t *testing.T) {
+	for _, x := range []int64{0, 1, -1, 3, 4, -5, -6, 0x55, 0x7f, 0x80, 0xff, 0x7fff, -0x8000, 1 << 30, 1<<30 + 1,
+		0x7fffffff, -1 << 31, 0x80000000, 0xffffffff, 1 << 40, -1 << 63, 1<<63 - 1} {
+		if immt0s(x) != immt0(x) {
+			t.Errorf("immt0s(%v) %v != %v", x, immt0s(x), immt0(x))
+		}
+		if immt1s(uint64(x)) != immt1(uint64(x)) {
+			t.Errorf("immt1s(%v) %v != %v", uint64(x), immt1s(uint64(x)), immt1(uint64(x)))
+		}
+		if immt2s(int32(x)) != immt2(int32(x)) {
+			t.Errorf("immt2s(%v) %v != %v", int32(x), immt2s(int32(x)), immt2(int32(x)))
+		}
+		if immt3s(uint8(x)) != immt3(uint8(x)) {
+			t.Errorf("immt3s(%v) %v != %v", uint8(x), immt3s(uint8(x)), immt3(uint8(x)))
+		}
+		if immt4s(int16(x)) != immt4(int16(x)) {
+			t.Errorf("immt4s(%v) %v != %v", int16(x), immt4s(int16(x)), immt4(int16(x)))
+		}
+		if immt5s(uint32(x)) != immt5(uint32(x)) {
+			t.Errorf("immt5s(%v) %v != %v", uint32(x), immt5s(uint32(x)), immt5(uint32(x)))
+		}
+		if immt6s(x) != immt6(x) {
+			t.Errorf("immt6s(%v) %v != %v", x, immt6s(x), immt6(x))
+		}
+		if immt7s(uint64(x)) != immt7(uint64(x)) {
+			t.Errorf("immt7s(%v) %v != %v", uint64(x), immt7s(uint64(x)), immt7(uint64(x)))
+		}
+	}
+}
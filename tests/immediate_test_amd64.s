@@ -0,0 +1,158 @@
+// Code generated by gensimd. DO NOT EDIT.
+// gensimd version: devel
+// gensimd command: gensimd -f immediate_test.go -fn "immt0, immt1, immt2, immt3, immt4, immt5, immt6, immt7" -o immediate_test_amd64.s -outfn "immt0s, immt1s, immt2s, immt3s, immt4s, immt5s, immt6s, immt7s"
+// gensimd source: immediate_test.go sha256:ce4cfc9f792ae45ce8b12742968d5355844a655d03e82dba4ce4b465654ea344
+// gensimd target: avx2
+
+//go:build amd64 && !noasm && !appengine
+// +build amd64,!noasm,!appengine
+
+#include "textflag.h"
+
+TEXT ·immt0s(SB),$48-16
+block0:
+        // entry
+        MOVQ         x+0(FP), R15
+        MOVQ         R15, R13
+        ADDQ         $7, R13
+        SUBQ         $-9, R13
+        SHLQ         $3, R13
+        ADDQ         $12, R13
+        MOVQ         $2147483648, R12
+        SUBQ         R12, R13
+        MOVQ         R13, ret+8(FP)
+        RET
+
+TEXT ·immt1s(SB),$56-16
+block0:
+        // entry
+        MOVQ         x+0(FP), R15
+        MOVQ         $4294967295, R13
+        MOVQ         R13, R12
+        XORQ         R15, R12
+        MOVQ         $1099511627776, R11
+        ADDQ         R11, R12
+        ANDQ         $-16, R12
+        MOVQ         R12, R10
+        XORQ         R10, R10
+        ORQ          R10, R12
+        ORQ          $2147483647, R12
+        MOVQ         R12, ret+8(FP)
+        RET
+
+TEXT ·immt2s(SB),$32-12
+block0:
+        // entry
+        MOVLQZX      x+0(FP), R15
+        MOVL         R15, R13
+        SARL         $31, R13
+        MOVL         R15, R12
+        SARL         $31, R12
+        MOVL         R15, R11
+        XORL         $85, R11
+        ADDL         R12, R13
+        SHLL         $1, R11
+        ADDL         R11, R13
+        MOVL         R13, ret+8(FP)
+        RET
+
+TEXT ·immt3s(SB),$8-9
+block0:
+        // entry
+        MOVBQZX      x+0(FP), R15
+        MOVB         R15, R13
+        SHLB         $7, R13
+        MOVB         R15, R12
+        XORQ         R12, R12
+        MOVB         R15, R11
+        ORB          $-128, R11
+        ADDB         R12, R13
+        ADDB         R11, R13
+        SUBB         $-1, R13
+        MOVB         R13, ret+8(FP)
+        RET
+
+TEXT ·immt4s(SB),$8-10
+block0:
+        // entry
+        MOVWQZX      x+0(FP), R15
+        MOVW         R15, R13
+        SUBW         $32767, R13
+        ANDW         $-1, R13
+        SARW         $3, R13
+        MOVW         R13, ret+8(FP)
+        RET
+
+TEXT ·immt5s(SB),$8-9
+block0:
+        // entry
+        MOVLQZX      x+0(FP), R15
+        CMPL         R15, $-1
+        SETCS        R13
+        MOVB         $0, R12
+        MOVB         R12, t2-3(SP)
+        MOVB         R13, t0-1(SP)
+        CMPB         R13, $0
+        JEQ          block2
+block1:
+        // binop.rhs, preds block0
+        MOVLQZX      x+0(FP), R15
+        CMPL         R15, $3
+        SETHI        R13
+        MOVB         R13, t2-3(SP)
+        MOVB         R13, t1-2(SP)
+block2:
+        // binop.done, preds block0 block1
+        MOVBQZX      t2-3(SP), R15
+        MOVB         R15, ret+8(FP)
+        RET
+
+TEXT ·immt6s(SB),$8-9
+block0:
+        // entry
+        MOVQ         x+0(FP), R15
+        CMPQ         R15, $-5
+        JLE          block2
+block1:
+        // if.then, preds block0
+        MOVQ         x+0(FP), R15
+        CMPQ         R15, $1073741824
+        SETLE        R13
+        MOVB         R13, ret+8(FP)
+        RET
+block2:
+        // if.done, preds block0
+        MOVQ         x+0(FP), R15
+        CMPQ         R15, $-2147483648
+        SETEQ        R12
+        MOVB         R12, ret+8(FP)
+        RET
+
+TEXT ·immt7s(SB),$8-16
+block0:
+        // entry
+        MOVQ         x+0(FP), R15
+        MOVQ         $2147483648, R13
+        CMPQ         R15, R13
+        JCS          block2
+block1:
+        // if.then, preds block0
+        MOVQ         $1, R15
+        MOVQ         R15, ret+8(FP)
+        RET
+block2:
+        // if.done, preds block0
+        MOVQ         x+0(FP), R13
+        CMPQ         R13, $-1
+        JEQ          block4
+block3:
+        // if.then, preds block2
+        MOVQ         $2, R15
+        MOVQ         R15, ret+8(FP)
+        RET
+block4:
+        // if.done, preds block2
+        MOVQ         $3, R13
+        MOVQ         R13, ret+8(FP)
+        RET
+
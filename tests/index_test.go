@@ -0,0 +1,112 @@
+// +build amd64,gc
+
+package tests
+
+import (
+	"syscall"
+	"testing"
+	"unsafe"
+
+	"github.com/bjwbell/gensimd/codegen"
+	"github.com/bjwbell/gensimd/internal/asmtest"
+)
+
+// TestIndex indexes with integers of every width and signedness, including
+// ones narrowed from an int with other bits set above them, with and
+// without bounds checks.
+func TestIndex(t *testing.T) {
+	checked := codegen.DefaultOptions()
+	checked.BoundsCheck = true
+	for name, opts := range map[string]codegen.Options{"default": codegen.DefaultOptions(), "boundscheck": checked} {
+		kernels := asmtest.Build(t, "testdata/index.go", opts,
+			"byUint32", "byInt32", "byUint8", "byInt16", "narrowInt8", "narrowUint16", "narrowInt32", "sumIndex")
+
+		x64 := []int64{10, 11, 12, 13, 14, 15, 16, 17}
+		for i := range x64 {
+			if got := kernels.Call(t, "byUint32", x64, uint32(i))[0].(int64); got != x64[i] {
+				t.Errorf("%v: byUint32(%v) = %v, expected %v", name, i, got, x64[i])
+			}
+			if got := kernels.Call(t, "byInt32", x64, int32(i))[0].(int64); got != x64[i] {
+				t.Errorf("%v: byInt32(%v) = %v, expected %v", name, i, got, x64[i])
+			}
+			for j := 0; i+j < len(x64); j++ {
+				if got := kernels.Call(t, "sumIndex", x64, uint32(i), uint32(j))[0].(int64); got != x64[i+j] {
+					t.Errorf("%v: sumIndex(%v, %v) = %v, expected %v", name, i, j, got, x64[i+j])
+				}
+			}
+		}
+
+		x16 := make([]uint16, 256)
+		for i := range x16 {
+			x16[i] = uint16(3 * i)
+		}
+		for _, i := range []uint8{0, 1, 127, 128, 255} {
+			if got := kernels.Call(t, "byUint8", x16, i)[0].(uint16); got != x16[i] {
+				t.Errorf("%v: byUint8(%v) = %v, expected %v", name, i, got, x16[i])
+			}
+		}
+
+		f64 := make([]float64, 1<<15)
+		for i := range f64 {
+			f64[i] = float64(i) / 2
+		}
+		for _, i := range []int16{0, 1, 255, 256, 1<<15 - 1} {
+			if got := kernels.Call(t, "byInt16", f64, i)[0].(float64); got != f64[i] {
+				t.Errorf("%v: byInt16(%v) = %v, expected %v", name, i, got, f64[i])
+			}
+		}
+
+		x32 := []int32{-1, -2, -3, -4, -5, -6, -7, -8}
+		f32 := &[4]float32{0.5, 1.5, 2.5, 3.5}
+		u8 := []uint8{1, 2, 3, 4, 5, 6, 7, 8}
+		for _, high := range []int{0, 1 << 8, -1 << 16, 1 << 32, -1 << 40} {
+			for i := 0; i < 4; i++ {
+				if got := kernels.Call(t, "narrowInt8", x32, high|i)[0].(int32); got != x32[i] {
+					t.Errorf("%v: narrowInt8(%#x) = %v, expected %v", name, high|i, got, x32[i])
+				}
+				if high&0xffff == 0 {
+					if got := kernels.Call(t, "narrowUint16", f32, high|i)[0].(float32); got != f32[i] {
+						t.Errorf("%v: narrowUint16(%#x) = %v, expected %v", name, high|i, got, f32[i])
+					}
+				}
+				if high&0xffffffff == 0 {
+					if got := kernels.Call(t, "narrowInt32", u8, int64(high|i))[0].(uint8); got != u8[i] {
+						t.Errorf("%v: narrowInt32(%#x) = %v, expected %v", name, high|i, got, u8[i])
+					}
+				}
+			}
+		}
+
+	}
+}
+
+// TestFarIndex reads elements at offsets of over 2GB, and of 2GB elements,
+// in a reservation of address space backed only where it's written.
+func TestFarIndex(t *testing.T) {
+	const size = 3 << 31
+	mem, err := syscall.Mmap(-1, 0, size, syscall.PROT_READ|syscall.PROT_WRITE, syscall.MAP_ANON|syscall.MAP_PRIVATE|syscall.MAP_NORESERVE)
+	if err != nil {
+		t.Skipf("can't map %v bytes, %v", size, err)
+	}
+	defer syscall.Munmap(mem)
+	checked := codegen.DefaultOptions()
+	checked.BoundsCheck = true
+	for name, opts := range map[string]codegen.Options{"default": codegen.DefaultOptions(), "boundscheck": checked} {
+		kernels := asmtest.Build(t, "testdata/index.go", opts, "farInt64", "farArray", "hugeElem")
+		x := unsafe.Slice((*int64)(unsafe.Pointer(&mem[0])), size/8)
+		x[1<<28] = -42
+		if got := kernels.Call(t, "farInt64", x)[0].(int64); got != -42 {
+			t.Errorf("%v: farInt64 = %v, expected -42", name, got)
+		}
+		arrays := (*[1 << 20][4096]uint8)(unsafe.Pointer(&mem[0]))
+		arrays[1<<19+3][5] = 42
+		if got := kernels.Call(t, "farArray", arrays)[0].(uint8); got != 42 {
+			t.Errorf("%v: farArray = %v, expected 42", name, got)
+		}
+		huge := (*[3][1 << 31]uint8)(unsafe.Pointer(&mem[0]))
+		huge[1][7], huge[2][9] = 3, 4
+		if got := kernels.Call(t, "hugeElem", huge, 1)[0].(uint8); got != 7 {
+			t.Errorf("%v: hugeElem(1) = %v, expected 7", name, got)
+		}
+	}
+}
@@ -0,0 +1,175 @@
+// Code generated by gensimd. DO NOT EDIT.
+// gensimd version: devel
+// gensimd command: gensimd -boundscheck -f loadfuse_test.go -fn "loadfuset0, loadfuset1, loadfuset2, loadfuset3, loadfuset4" -o loadfuse_bswap_test_amd64.s -outfn "loadfuset0b, loadfuset1b, loadfuset2b, loadfuset3b, loadfuset4b" -target sse4.1
+// gensimd source: loadfuse_test.go sha256:8388c65fe24d88baa258e3b090018f95f45f7c1f0ae29b0f7bfcd153aa705d1e
+// gensimd target: sse4.1
+
+//go:build amd64 && !noasm && !appengine
+// +build amd64,!noasm,!appengine
+
+#include "textflag.h"
+
+TEXT ·loadfuset0b(SB),$8-28
+block0:
+        // entry
+        MOVQ         b_len+8(FP), R15
+        CMPQ         R15, $4
+        JCS          boundsfault
+        MOVQ         b_base+0(FP), R15
+        MOVL         (R15), R13
+        MOVL         R13, ret+24(FP)
+        RET
+boundsfault:
+        INT          $3
+
+TEXT ·loadfuset1b(SB),$16-32
+block0:
+        // entry
+        MOVQ         b_len+8(FP), R15
+        CMPQ         R15, $9
+        JCS          boundsfault
+        MOVQ         b_base+0(FP), R15
+        ADDQ         $1, R15
+        MOVQ         (R15), R13
+        BSWAPQ       R13
+        MOVQ         R13, ret+24(FP)
+        RET
+boundsfault:
+        INT          $3
+
+TEXT ·loadfuset2b(SB),$32-36
+block0:
+        // entry
+        MOVQ         i+24(FP), R15
+        MOVQ         R15, R13
+        ADDQ         $3, R13
+        MOVQ         R15, R12
+        ADDQ         $2, R12
+        MOVQ         R15, R11
+        ADDQ         $1, R11
+        MOVQ         R15, R10
+        MOVQ         b_len+8(FP), R9
+        CMPQ         R10, R9
+        JCC          boundsfault
+        ADDQ         $3, R10
+        MOVQ         b_len+8(FP), R9
+        CMPQ         R10, R9
+        JCC          boundsfault
+        SUBQ         $3, R10
+        MOVQ         b_base+0(FP), R9
+        ADDQ         R10, R9
+        MOVL         (R9), R10
+        BSWAPL       R10
+        MOVL         R10, ret+32(FP)
+        RET
+boundsfault:
+        INT          $3
+
+TEXT ·loadfuset3b(SB),$80-40
+block0:
+        // entry
+        MOVQ         i+24(FP), R15
+        MOVQ         R15, R13
+        ADDQ         $1, R13
+        MOVQ         R15, R12
+        ADDQ         $2, R12
+        MOVQ         R15, R11
+        ADDQ         $3, R11
+        MOVQ         R15, R10
+        ADDQ         $4, R10
+        MOVQ         R15, R9
+        ADDQ         $5, R9
+        MOVQ         R15, R8
+        ADDQ         $6, R8
+        MOVQ         R15, BP
+        ADDQ         $7, BP
+        MOVQ         R15, BX
+        ADDQ         $8, BX
+        MOVQ         R15, DI
+        ADDQ         $1, DI
+        MOVQ         b_len+8(FP), SI
+        CMPQ         DI, SI
+        JCC          boundsfault
+        ADDQ         $7, DI
+        MOVQ         b_len+8(FP), SI
+        CMPQ         DI, SI
+        JCC          boundsfault
+        SUBQ         $7, DI
+        MOVQ         b_base+0(FP), SI
+        ADDQ         DI, SI
+        MOVQ         (SI), DI
+        MOVQ         DI, ret+32(FP)
+        RET
+boundsfault:
+        INT          $3
+
+TEXT ·loadfuset4b(SB),$128-32
+block0:
+        // entry
+        MOVQ         b_len+8(FP), R15
+        MOVQ         R15, R13
+        MOVQ         R13, BX
+        MOVQ         $0, R12
+        MOVQ         R12, t0-8(SP)
+        MOVQ         $0, R11
+        MOVQ         R11, t1-16(SP)
+        MOVQ         R13, t3-32(SP)
+block1:
+        // for.loop, preds block0 block2
+        MOVQ         t1-16(SP), R15
+        MOVQ         R15, R13
+        ADDQ         $8, R13
+        MOVQ         BX, R12
+        CMPQ         R13, R12
+        JGT          block3
+block2:
+        // for.body, preds block1
+        MOVQ         t1-16(SP), R15
+        MOVQ         R15, R13
+        ADDQ         $1, R13
+        MOVQ         R15, R12
+        ADDQ         $2, R12
+        MOVQ         R15, R11
+        ADDQ         $3, R11
+        MOVQ         R15, R10
+        ADDQ         $4, R10
+        MOVQ         R15, R9
+        ADDQ         $5, R9
+        MOVQ         R15, R8
+        ADDQ         $6, R8
+        MOVQ         R15, BP
+        ADDQ         $7, BP
+        MOVQ         R15, DI
+        MOVQ         b_len+8(FP), SI
+        CMPQ         DI, SI
+        JCC          boundsfault
+        ADDQ         $7, DI
+        MOVQ         b_len+8(FP), SI
+        CMPQ         DI, SI
+        JCC          boundsfault
+        SUBQ         $7, DI
+        MOVQ         b_base+0(FP), SI
+        ADDQ         DI, SI
+        MOVQ         (SI), DI
+        MOVQ         t0-8(SP), SI
+        MOVQ         DI, BP
+        XORQ         SI, BP
+        MOVQ         $1099511628211, SI
+        MOVQ         BP, AX
+        MULQ         SI
+        MOVQ         AX, BP
+        MOVQ         R15, SI
+        ADDQ         $8, SI
+        MOVQ         BP, t0-8(SP)
+        MOVQ         SI, t1-16(SP)
+        MOVQ         SI, t52-121(SP)
+        MOVQ         BP, t51-113(SP)
+        JMP block1
+block3:
+        // for.done, preds block1
+        MOVQ         t0-8(SP), R15
+        MOVQ         R15, ret+24(FP)
+        RET
+boundsfault:
+        INT          $3
+
@@ -0,0 +1,96 @@
+// +build amd64,gc
+
+package tests
+
+import (
+	"encoding/binary"
+	"testing"
+)
+
+//go:generate gensimd -fn "loadfuset0, loadfuset1, loadfuset2, loadfuset3, loadfuset4" -outfn "loadfuset0s, loadfuset1s, loadfuset2s, loadfuset3s, loadfuset4s" -f "$GOFILE" -o "loadfuse_test_amd64.s"
+//go:generate gensimd -target sse4.1 -boundscheck -fn "loadfuset0, loadfuset1, loadfuset2, loadfuset3, loadfuset4" -outfn "loadfuset0b, loadfuset1b, loadfuset2b, loadfuset3b, loadfuset4b" -f "$GOFILE" -o "loadfuse_bswap_test_amd64.s"
+
+func loadfuset0s(b []byte) uint32
+func loadfuset1s(b []byte) uint64
+func loadfuset2s(b []byte, i int) uint32
+func loadfuset3s(b []byte, i int) uint64
+func loadfuset4s(b []byte) uint64
+
+// bounds checked
+func loadfuset0b(b []byte) uint32
+func loadfuset1b(b []byte) uint64
+func loadfuset2b(b []byte, i int) uint32
+func loadfuset3b(b []byte, i int) uint64
+func loadfuset4b(b []byte) uint64
+
+// little endian, a single MOVL
+func loadfuset0(b []byte) uint32 {
+	return uint32(b[0]) | uint32(b[1])<<8 | uint32(b[2])<<16 | uint32(b[3])<<24
+}
+
+// big endian at a constant offset, MOVQ and BSWAPQ or MOVBEQQ
+func loadfuset1(b []byte) uint64 {
+	return uint64(b[8]) | uint64(b[7])<<8 | uint64(b[6])<<16 | uint64(b[5])<<24 |
+		uint64(b[4])<<32 | uint64(b[3])<<40 | uint64(b[2])<<48 | uint64(b[1])<<56
+}
+
+func loadfuset2(b []byte, i int) uint32 {
+	return uint32(b[i+3]) | uint32(b[i+2])<<8 | uint32(b[i+1])<<16 | uint32(b[i])<<24
+}
+
+func loadfuset3(b []byte, i int) uint64 {
+	return uint64(b[i+1]) | uint64(b[i+2])<<8 | uint64(b[i+3])<<16 | uint64(b[i+4])<<24 |
+		uint64(b[i+5])<<32 | uint64(b[i+6])<<40 | uint64(b[i+7])<<48 | uint64(b[i+8])<<56
+}
+
+// a hash of the 8 byte words of b
+func loadfuset4(b []byte) uint64 {
+	h := uint64(0)
+	for i := 0; i+8 <= len(b); i += 8 {
+		w := uint64(b[i]) | uint64(b[i+1])<<8 | uint64(b[i+2])<<16 | uint64(b[i+3])<<24 |
+			uint64(b[i+4])<<32 | uint64(b[i+5])<<40 | uint64(b[i+6])<<48 | uint64(b[i+7])<<56
+		h = (h ^ w) * 1099511628211
+	}
+	return h
+}
+
+func TestLoadFuse(t *testing.T) {
+	b := make([]byte, 29)
+	for i := range b {
+		b[i] = byte(i*37 + 11)
+	}
+	if got, want := loadfuset0s(b), binary.LittleEndian.Uint32(b); got != want {
+		t.Errorf("loadfuset0s %#x != %#x", got, want)
+	}
+	if got, want := loadfuset1s(b), binary.BigEndian.Uint64(b[1:]); got != want {
+		t.Errorf("loadfuset1s %#x != %#x", got, want)
+	}
+	for i := 0; i+9 <= len(b); i++ {
+		if got, want := loadfuset2s(b, i), binary.BigEndian.Uint32(b[i:]); got != want {
+			t.Errorf("loadfuset2s(%v) %#x != %#x", i, got, want)
+		}
+		if got, want := loadfuset2b(b, i), loadfuset2(b, i); got != want {
+			t.Errorf("loadfuset2b(%v) %#x != %#x", i, got, want)
+		}
+		if got, want := loadfuset3s(b, i), binary.LittleEndian.Uint64(b[i+1:]); got != want {
+			t.Errorf("loadfuset3s(%v) %#x != %#x", i, got, want)
+		}
+		if got, want := loadfuset3b(b, i), loadfuset3(b, i); got != want {
+			t.Errorf("loadfuset3b(%v) %#x != %#x", i, got, want)
+		}
+	}
+	if got, want := loadfuset0b(b), loadfuset0(b); got != want {
+		t.Errorf("loadfuset0b %#x != %#x", got, want)
+	}
+	if got, want := loadfuset1b(b), loadfuset1(b); got != want {
+		t.Errorf("loadfuset1b %#x != %#x", got, want)
+	}
+	for n := 0; n <= len(b); n++ {
+		if got, want := loadfuset4s(b[:n]), loadfuset4(b[:n]); got != want {
+			t.Errorf("loadfuset4s(b[:%v]) %#x != %#x", n, got, want)
+		}
+		if got, want := loadfuset4b(b[:n]), loadfuset4(b[:n]); got != want {
+			t.Errorf("loadfuset4b(b[:%v]) %#x != %#x", n, got, want)
+		}
+	}
+}
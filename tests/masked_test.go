@@ -0,0 +1,65 @@
+// +build amd64,gc
+
+package tests
+
+import (
+	"testing"
+
+	"github.com/bjwbell/gensimd/simd"
+)
+
+//go:generate gensimd -target avx -fn "maskedt0, maskedt1, maskedt2, maskedt3" -outfn "maskedt0s, maskedt1s, maskedt2s, maskedt3s" -f "$GOFILE" -o "masked_test_amd64.s"
+
+func maskedt0s(p []float32, mask simd.I32x4) simd.F32x4
+func maskedt1s(p []float32, mask simd.I32x4, x simd.F32x4)
+func maskedt2s(p []float32, mask simd.I32x8) simd.F32x8
+func maskedt3s(p []float32, mask simd.I32x8, x simd.F32x8)
+
+func maskedt0(p []float32, mask simd.I32x4) simd.F32x4 {
+	return simd.MaskedLoadF32x4(p, mask)
+}
+
+func maskedt1(p []float32, mask simd.I32x4, x simd.F32x4) {
+	simd.MaskedStoreF32x4(p, mask, x)
+}
+
+func maskedt2(p []float32, mask simd.I32x8) simd.F32x8 {
+	return simd.MaskedLoadF32x8(p, mask)
+}
+
+func maskedt3(p []float32, mask simd.I32x8, x simd.F32x8) {
+	simd.MaskedStoreF32x8(p, mask, x)
+}
+
+func TestMaskedLoadStore(t *testing.T) {
+	if !simd.AVX() {
+		t.Skip("VMASKMOVPS needs AVX")
+	}
+	p := []float32{1, 2, 3, 4, 5, 6, 7, 8}
+	for n := 0; n <= 4; n++ {
+		mask := simd.TailMaskI32x4(n)
+		if got, expected := maskedt0s(p[:n], mask), maskedt0(p[:n], mask); got != expected {
+			t.Errorf("maskedt0s(%v) %v != %v", n, got, expected)
+		}
+		got, expected := make([]float32, 4), make([]float32, 4)
+		x := simd.F32x4{9, 9, 9, 9}
+		maskedt1s(got[:n], mask, x)
+		maskedt1(expected[:n], mask, x)
+		if simd.F32x4(got) != simd.F32x4(expected) {
+			t.Errorf("maskedt1s(%v) %v != %v", n, got, expected)
+		}
+	}
+	for n := 0; n <= 8; n++ {
+		mask := simd.TailMaskI32x8(n)
+		if got, expected := maskedt2s(p[:n], mask), maskedt2(p[:n], mask); got != expected {
+			t.Errorf("maskedt2s(%v) %v != %v", n, got, expected)
+		}
+		got, expected := make([]float32, 8), make([]float32, 8)
+		x := simd.F32x8{9, 9, 9, 9, 9, 9, 9, 9}
+		maskedt3s(got[:n], mask, x)
+		maskedt3(expected[:n], mask, x)
+		if simd.F32x8(got) != simd.F32x8(expected) {
+			t.Errorf("maskedt3s(%v) %v != %v", n, got, expected)
+		}
+	}
+}
@@ -0,0 +1,65 @@
+// Code generated by gensimd. DO NOT EDIT.
+// gensimd version: devel
+// gensimd command: gensimd -f masked_test.go -fn "maskedt0, maskedt1, maskedt2, maskedt3" -o masked_test_amd64.s -outfn "maskedt0s, maskedt1s, maskedt2s, maskedt3s" -target avx
+// gensimd source: masked_test.go sha256:1b888fb9033ca618b8b4d2faf3e72e688317c99ea28a982e9bb0facd5ec02fbd
+// gensimd target: avx
+
+//go:build amd64 && !noasm && !appengine
+// +build amd64,!noasm,!appengine
+
+#include "textflag.h"
+
+TEXT ·maskedt0s(SB),$24-56
+block0:
+        // entry
+        MOVQ         p_base+0(FP), R15
+        MOVUPS       mask+24(FP), X14
+        VMASKMOVPS    (R15), X14, X13
+        MOVUPS       X13, ret+40(FP)
+        RET
+
+TEXT ·maskedt1s(SB),$8-56
+block0:
+        // entry
+        MOVQ         p_base+0(FP), R15
+        MOVUPS       mask+24(FP), X14
+        MOVUPS       x+40(FP), X13
+        VMASKMOVPS    X13, X14, (R15)
+        RET
+
+TEXT ·maskedt2s(SB),$40-88
+block0:
+        // entry
+        MOVQ         p_base+0(FP), R15
+        VMOVDQU      mask+24(FP), Y14
+        VMASKMOVPS    (R15), Y14, Y13
+        VMOVDQU      Y13, t0-32(SP)
+        VZEROUPPER
+        MOVLQZX      t0-32(SP), R13
+        MOVL         R13, ret_0+56(FP)
+        MOVLQZX      t0-28(SP), R12
+        MOVL         R12, ret_1+60(FP)
+        MOVLQZX      t0-24(SP), R11
+        MOVL         R11, ret_2+64(FP)
+        MOVLQZX      t0-20(SP), R10
+        MOVL         R10, ret_3+68(FP)
+        MOVLQZX      t0-16(SP), R9
+        MOVL         R9, ret_4+72(FP)
+        MOVLQZX      t0-12(SP), R8
+        MOVL         R8, ret_5+76(FP)
+        MOVLQZX      t0-8(SP), R8
+        MOVL         R8, ret_6+80(FP)
+        MOVLQZX      t0-4(SP), R8
+        MOVL         R8, ret_7+84(FP)
+        RET
+
+TEXT ·maskedt3s(SB),$8-88
+block0:
+        // entry
+        MOVQ         p_base+0(FP), R15
+        VMOVDQU      mask+24(FP), Y14
+        VMOVDQU      x+56(FP), Y13
+        VMASKMOVPS    Y13, Y14, (R15)
+        VZEROUPPER
+        RET
+
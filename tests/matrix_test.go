@@ -0,0 +1,29 @@
+// +build gc
+
+package tests
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/bjwbell/gensimd/codegen"
+	"github.com/bjwbell/gensimd/internal/asmtest"
+)
+
+// TestMatrix cross generates the kernels of testdata and the presets for
+// every supported GOARCH and GOOS and assembles them for each, it runs on
+// any host so backend regressions are caught without the hardware.
+func TestMatrix(t *testing.T) {
+	files, err := filepath.Glob("testdata/*.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	files = append(files, "../presets/ref/presets.go")
+	for _, file := range files {
+		file := file
+		t.Run(filepath.Base(file), func(t *testing.T) {
+			t.Parallel()
+			asmtest.Assemble(t, asmtest.Targets(), file, codegen.DefaultOptions())
+		})
+	}
+}
@@ -0,0 +1,170 @@
+// +build amd64,gc
+
+package tests
+
+import (
+	"math"
+	"testing"
+
+	"github.com/bjwbell/gensimd/simd"
+)
+
+//go:generate gensimd -fn "minmaxt0, minmaxt1, minmaxt2, minmaxt3, minmaxt4, minmaxt5, minmaxt6, minmaxt7, minmaxt8, minmaxt9" -outfn "minmaxt0s, minmaxt1s, minmaxt2s, minmaxt3s, minmaxt4s, minmaxt5s, minmaxt6s, minmaxt7s, minmaxt8s, minmaxt9s" -f "$GOFILE" -o "minmax_test_amd64.s"
+
+func minmaxt0s(a, b int64) int64
+func minmaxt1s(x int32) int32
+func minmaxt2s(x uint8, lo, hi uint8) uint8
+func minmaxt3s(a, b float64) float64
+func minmaxt4s(x, lo, hi float32) float32
+func minmaxt5s(a, b int16, c bool) int16
+func minmaxt6s(x, y simd.F32x4, lo, hi simd.F32x4) simd.F32x4
+func minmaxt7s(x, y simd.U8x16) simd.U8x16
+func minmaxt8s(a, b simd.I16x8) simd.I16x8
+func minmaxt9s(x simd.I32x4, lo, hi simd.I32x4) simd.I32x4
+
+// max
+func minmaxt0(a, b int64) int64 {
+	if a < b {
+		a = b
+	}
+	return a
+}
+
+// clamp to the range of a uint8
+func minmaxt1(x int32) int32 {
+	if x < 0 {
+		x = 0
+	}
+	if x > 255 {
+		x = 255
+	}
+	return x
+}
+
+func minmaxt2(x uint8, lo, hi uint8) uint8 {
+	v := x
+	if x < lo {
+		v = lo
+	} else if x > hi {
+		v = hi
+	}
+	return v
+}
+
+// min, MINSD
+func minmaxt3(a, b float64) float64 {
+	if b < a {
+		a = b
+	}
+	return a
+}
+
+// clamp, MAXSS and MINSS
+func minmaxt4(x, lo, hi float32) float32 {
+	if lo > x {
+		x = lo
+	}
+	if x > hi {
+		x = hi
+	}
+	return x
+}
+
+// several phis and a bool condition
+func minmaxt5(a, b int16, c bool) int16 {
+	lo, hi := b, a
+	if a <= b {
+		lo, hi = a, b
+	}
+	if c {
+		lo = -lo
+	}
+	return hi - lo
+}
+
+func minmaxt6(x, y simd.F32x4, lo, hi simd.F32x4) simd.F32x4 {
+	return simd.MinF32x4(simd.MaxF32x4(simd.MinF32x4(x, y), lo), hi)
+}
+
+func minmaxt7(x, y simd.U8x16) simd.U8x16 {
+	return simd.MaxU8x16(simd.MinU8x16(x, y), y)
+}
+
+func minmaxt8(a, b simd.I16x8) simd.I16x8 {
+	return simd.MinI16x8(simd.MaxI16x8(a, b), b)
+}
+
+func minmaxt9(x simd.I32x4, lo, hi simd.I32x4) simd.I32x4 {
+	return simd.MinI32x4(simd.MaxI32x4(x, lo), hi)
+}
+
+func TestMinMax(t *testing.T) {
+	ints := []int64{0, 1, -1, 2, 255, 256, -256, 1 << 40, -1 << 63, 1<<63 - 1}
+	for _, a := range ints {
+		if minmaxt1s(int32(a)) != minmaxt1(int32(a)) {
+			t.Errorf("minmaxt1s(%v) %v != %v", int32(a), minmaxt1s(int32(a)), minmaxt1(int32(a)))
+		}
+		for _, b := range ints {
+			if minmaxt0s(a, b) != minmaxt0(a, b) {
+				t.Errorf("minmaxt0s(%v, %v) %v != %v", a, b, minmaxt0s(a, b), minmaxt0(a, b))
+			}
+			x, lo, hi := uint8(a), uint8(b), uint8(b+100)
+			if minmaxt2s(x, lo, hi) != minmaxt2(x, lo, hi) {
+				t.Errorf("minmaxt2s(%v, %v, %v) %v != %v", x, lo, hi, minmaxt2s(x, lo, hi), minmaxt2(x, lo, hi))
+			}
+			for _, c := range []bool{false, true} {
+				if minmaxt5s(int16(a), int16(b), c) != minmaxt5(int16(a), int16(b), c) {
+					t.Errorf("minmaxt5s(%v, %v, %v) %v != %v", int16(a), int16(b), c, minmaxt5s(int16(a), int16(b), c), minmaxt5(int16(a), int16(b), c))
+				}
+			}
+		}
+	}
+	// the results must be identical for NaNs and zeros of either sign
+	same := func(x, y float64) bool {
+		return math.Float64bits(x) == math.Float64bits(y) || x != x && y != y
+	}
+	floats := []float64{0, math.Copysign(0, -1), 1, -1.5, 1e30, math.Inf(1), math.Inf(-1), math.NaN()}
+	for _, a := range floats {
+		for _, b := range floats {
+			if !same(minmaxt3s(a, b), minmaxt3(a, b)) {
+				t.Errorf("minmaxt3s(%v, %v) %v != %v", a, b, minmaxt3s(a, b), minmaxt3(a, b))
+			}
+			x, lo, hi := float32(a), float32(b), float32(b+1)
+			if !same(float64(minmaxt4s(x, lo, hi)), float64(minmaxt4(x, lo, hi))) {
+				t.Errorf("minmaxt4s(%v, %v, %v) %v != %v", x, lo, hi, minmaxt4s(x, lo, hi), minmaxt4(x, lo, hi))
+			}
+		}
+	}
+
+	nan := float32(math.NaN())
+	x := simd.F32x4{-1, 0.5, nan, 3}
+	y := simd.F32x4{2, nan, 0.25, -4}
+	lo := simd.F32x4{0, 0, 0, 0}
+	hi := simd.F32x4{1, 1, 1, 1}
+	r0, r1 := minmaxt6s(x, y, lo, hi), minmaxt6(x, y, lo, hi)
+	for i := range r0 {
+		if !same(float64(r0[i]), float64(r1[i])) {
+			t.Errorf("minmaxt6s(%v, %v, %v, %v) %v != %v", x, y, lo, hi, r0, r1)
+			break
+		}
+	}
+	u := simd.U8x16{0, 1, 127, 128, 255, 3}
+	v := simd.U8x16{1, 0, 128, 127, 0, 3}
+	a := simd.I16x8{-32768, 32767, -1, 1, 0, 5}
+	b := simd.I16x8{32767, -32768, 1, -1, 0, 4}
+	if minmaxt7s(u, v) != minmaxt7(u, v) {
+		t.Errorf("minmaxt7s(%v, %v) %v != %v", u, v, minmaxt7s(u, v), minmaxt7(u, v))
+	}
+	if minmaxt8s(a, b) != minmaxt8(a, b) {
+		t.Errorf("minmaxt8s(%v, %v) %v != %v", a, b, minmaxt8s(a, b), minmaxt8(a, b))
+	}
+	if !simd.SSE41() {
+		t.Skip("PMAXSD needs SSE4.1")
+	}
+	i := simd.I32x4{-1, 50, 101, math.MinInt32}
+	ilo := simd.I32x4{0, 0, 0, 0}
+	ihi := simd.I32x4{100, 100, 100, 100}
+	if minmaxt9s(i, ilo, ihi) != minmaxt9(i, ilo, ihi) {
+		t.Errorf("minmaxt9s(%v, %v, %v) %v != %v", i, ilo, ihi, minmaxt9s(i, ilo, ihi), minmaxt9(i, ilo, ihi))
+	}
+}
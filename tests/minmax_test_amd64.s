@@ -0,0 +1,212 @@
+// Code generated by gensimd. DO NOT EDIT.
+// gensimd version: devel
+// gensimd command: gensimd -f minmax_test.go -fn "minmaxt0, minmaxt1, minmaxt2, minmaxt3, minmaxt4, minmaxt5, minmaxt6, minmaxt7, minmaxt8, minmaxt9" -o minmax_test_amd64.s -outfn "minmaxt0s, minmaxt1s, minmaxt2s, minmaxt3s, minmaxt4s, minmaxt5s, minmaxt6s, minmaxt7s, minmaxt8s, minmaxt9s"
+// gensimd source: minmax_test.go sha256:18945bf6139af98d0dd5d0b14c93cab2fb17214298be169b8807aafe5f78dedc
+// gensimd target: avx2
+
+//go:build amd64 && !noasm && !appengine
+// +build amd64,!noasm,!appengine
+
+#include "textflag.h"
+
+TEXT ·minmaxt0s(SB),$16-24
+block0:
+        // entry
+        // ssa.BinOp, t0 = a < b, compared by the select
+        MOVQ         a+0(FP), R15
+        MOVQ         R15, R13
+        MOVQ         b+8(FP), R12
+        CMPQ         R15, R12
+        CMOVQLT      R12, R13
+        MOVQ         R13, t1-8(SP)
+block2:
+        // if.done, preds block0 block1
+        MOVQ         t1-8(SP), R15
+        MOVQ         R15, ret+16(FP)
+        RET
+
+TEXT ·minmaxt1s(SB),$16-12
+block0:
+        // entry
+        // ssa.BinOp, t0 = x < 0:int32, compared by the select
+        MOVLQZX      x+0(FP), R15
+        MOVL         R15, R13
+        MOVL         $0, R12
+        CMPL         R15, $0
+        CMOVLLT      R12, R13
+        MOVL         R13, t1-4(SP)
+block2:
+        // if.done, preds block0 block1
+        // ssa.BinOp, t2 = t1 > 255:int32, compared by the select
+        MOVLQZX      t1-4(SP), R15
+        MOVL         R15, R13
+        MOVL         $255, R12
+        CMPL         R15, $255
+        CMOVLGT      R12, R13
+        MOVL         R13, t3-8(SP)
+block4:
+        // if.done, preds block2 block3
+        MOVLQZX      t3-8(SP), R15
+        MOVL         R15, ret+8(FP)
+        RET
+
+TEXT ·minmaxt2s(SB),$8-9
+block0:
+        // entry
+        MOVBQZX      x+0(FP), R15
+        MOVBQZX      lo+1(FP), R13
+        CMPB         R15, R13
+        JCC          block3
+block1:
+        // if.then, preds block0
+        MOVBQZX      lo+1(FP), R15
+        MOVB         R15, t1-2(SP)
+block2:
+        // if.done, preds block1 block3 block4
+        MOVBQZX      t1-2(SP), R15
+        MOVB         R15, ret+8(FP)
+        RET
+block3:
+        // if.else, preds block0
+        MOVBQZX      x+0(FP), R15
+        MOVBQZX      hi+2(FP), R13
+        CMPB         R15, R13
+        SETHI        R12
+        MOVB         R15, t1-2(SP)
+        MOVB         R12, t2-3(SP)
+        CMPB         R12, $0
+        JEQ          block2
+block4:
+        // if.then, preds block3
+        MOVBQZX      hi+2(FP), R15
+        MOVB         R15, t1-2(SP)
+        JMP block2
+
+TEXT ·minmaxt3s(SB),$16-24
+block0:
+        // entry
+        // ssa.BinOp, t0 = b < a, compared by the select
+        MOVSD        b+8(FP), X14
+        MOVO         X14, X13
+        MOVSD        a+0(FP), X12
+        MINSD        X12, X13
+        MOVSD        X13, t1-8(SP)
+block2:
+        // if.done, preds block0 block1
+        MOVSD        t1-8(SP), X14
+        MOVSD        X14, ret+16(FP)
+        RET
+
+TEXT ·minmaxt4s(SB),$16-20
+block0:
+        // entry
+        // ssa.BinOp, t0 = lo > x, compared by the select
+        MOVSS        lo+4(FP), X14
+        MOVO         X14, X13
+        MOVSS        x+0(FP), X12
+        MAXSS        X12, X13
+        MOVSS        X13, t1-4(SP)
+block2:
+        // if.done, preds block0 block1
+        // ssa.BinOp, t2 = t1 > hi, compared by the select
+        MOVSS        hi+8(FP), X14
+        MOVO         X14, X13
+        MOVSS        t1-4(SP), X12
+        MINSS        X12, X13
+        MOVSS        X13, t3-8(SP)
+block4:
+        // if.done, preds block2 block3
+        MOVSS        t3-8(SP), X14
+        MOVSS        X14, ret+16(FP)
+        RET
+
+TEXT ·minmaxt5s(SB),$16-10
+block0:
+        // entry
+        // ssa.BinOp, t0 = a <= b, compared by the select
+        MOVWQZX      b+2(FP), R15
+        MOVW         R15, R13
+        MOVWQZX      a+0(FP), R12
+        MOVW         R12, R11
+        CMPW         R12, R15
+        CMOVLLE      R12, R13
+        CMOVLLE      R15, R11
+        MOVW         R13, t1-2(SP)
+        MOVW         R11, t2-4(SP)
+block2:
+        // if.done, preds block0 block1
+        MOVBQZX      c+4(FP), R15
+        MOVWQZX      t1-2(SP), R13
+        MOVW         R13, t4-8(SP)
+        CMPB         R15, $0
+        JEQ          block4
+block3:
+        // if.then, preds block2
+        MOVWQZX      t1-2(SP), R12
+        XORQ         R13, R13
+        MOVW         R13, R15
+        SUBW         R12, R15
+        MOVW         R15, t4-8(SP)
+        MOVW         R15, t3-6(SP)
+block4:
+        // if.done, preds block2 block3
+        MOVWQZX      t2-4(SP), R15
+        MOVWQZX      t4-8(SP), R13
+        MOVW         R15, R12
+        SUBW         R13, R12
+        MOVW         R12, ret+8(FP)
+        RET
+
+TEXT ·minmaxt6s(SB),$56-80
+block0:
+        // entry
+        MOVUPS       x+0(FP), X14
+        MOVUPS       y+16(FP), X13
+        MOVO         X14, X12
+        MINPS        X13, X12
+        MOVUPS       lo+32(FP), X11
+        MOVO         X12, X10
+        MAXPS        X11, X10
+        MOVUPS       hi+48(FP), X9
+        MOVO         X10, X8
+        MINPS        X9, X8
+        MOVUPS       X8, ret+64(FP)
+        RET
+
+TEXT ·minmaxt7s(SB),$40-48
+block0:
+        // entry
+        MOVUPS       x+0(FP), X14
+        MOVUPS       y+16(FP), X13
+        MOVO         X14, X12
+        PMINUB       X13, X12
+        MOVO         X12, X11
+        PMAXUB       X13, X11
+        MOVUPS       X11, ret+32(FP)
+        RET
+
+TEXT ·minmaxt8s(SB),$40-48
+block0:
+        // entry
+        MOVUPS       a+0(FP), X14
+        MOVUPS       b+16(FP), X13
+        MOVO         X14, X12
+        PMAXSW       X13, X12
+        MOVO         X12, X11
+        PMINSW       X13, X11
+        MOVUPS       X11, ret+32(FP)
+        RET
+
+TEXT ·minmaxt9s(SB),$40-64
+block0:
+        // entry
+        MOVUPS       x+0(FP), X14
+        MOVUPS       lo+16(FP), X13
+        MOVO         X14, X12
+        PMAXSD       X13, X12
+        MOVUPS       hi+32(FP), X11
+        MOVO         X12, X10
+        PMINSD       X11, X10
+        MOVUPS       X10, ret+48(FP)
+        RET
+
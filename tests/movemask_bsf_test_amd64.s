@@ -0,0 +1,144 @@
+// Code generated by gensimd. DO NOT EDIT.
+// gensimd version: devel
+// gensimd command: gensimd -f movemask_test.go -fn "movemaskt3, movemaskt4" -o movemask_bsf_test_amd64.s -outfn "movemaskt3b, movemaskt4b" -target sse4.1
+// gensimd source: movemask_test.go sha256:6b2846922c3e04ce299dc8ac3d965436c17bd77743f9734c3438973d72c375f8
+// gensimd target: sse4.1
+
+//go:build amd64 && !noasm && !appengine
+// +build amd64,!noasm,!appengine
+
+#include "textflag.h"
+
+TEXT ·movemaskt3b(SB),$112-32
+block0:
+        // entry
+        MOVQ         a+0(FP), R15
+        MOVQ         $64, R13
+        BSFQ         R15, R12
+        CMOVQNE      R12, R13
+        MOVLQZX      b+8(FP), R12
+        MOVLQZX      R12, R11
+        BTSQ         $32, R11
+        BSFQ         R11, R11
+        SHLQ         $8, R11
+        ADDQ         R11, R13
+        MOVWQZX      c+12(FP), R10
+        MOVWQZX      R10, R9
+        BTSQ         $16, R9
+        BSFQ         R9, R9
+        SHLQ         $16, R9
+        ADDQ         R9, R13
+        MOVBQZX      d+14(FP), R8
+        MOVBQZX      R8, BP
+        BTSQ         $8, BP
+        BSFQ         BP, BP
+        SHLQ         $24, BP
+        ADDQ         BP, R13
+        MOVQ         e+16(FP), BX
+        MOVQ         $64, DI
+        BSFQ         BX, SI
+        CMOVQNE      SI, DI
+        SHLQ         $32, DI
+        ADDQ         DI, R13
+        MOVQ         R13, ret+24(FP)
+        RET
+
+TEXT ·movemaskt4b(SB),$160-40
+block0:
+        // entry
+        MOVBQZX      c+24(FP), R15
+        MOVBQZX      R15, R13
+        IMUL3Q       $16843009, R13, R13
+        MOVQ         R13, X14
+        PSHUFL       $0, X14, X14
+        MOVO         X14, X0
+        MOVQ         s_len+8(FP), R13
+        MOVQ         R13, R12
+        MOVQ         R12, BX
+        MOVQ         $0, R11
+        MOVQ         R11, t5-73(SP)
+        MOVQ         R12, t7-89(SP)
+        MOVOU        X14, t0-24(SP)
+block2:
+        // for.loop, preds block0 block4
+        MOVQ         t5-73(SP), R15
+        MOVQ         R15, R13
+        ADDQ         $16, R13
+        MOVQ         BX, R12
+        CMPQ         R13, R12
+        SETLE        R11
+        MOVQ         R15, t16-140(SP)
+        MOVQ         s_base+0(FP), R10
+        LEAQ         (R10)(R15*1), R10
+        MOVQ         R10, ivptr0-8(SP)
+        MOVB         R11, t8-90(SP)
+        CMPB         R11, $0
+        JEQ          block7
+block1:
+        // for.body, preds block2
+        MOVQ         s_base+0(FP), R15
+        MOVQ         t5-73(SP), R13
+        MOVOU        (R15)(R13*1), X14
+        MOVO         X0, X13
+        MOVO         X14, X12
+        PCMPEQB      X13, X12
+        PMOVMSKB     X12, R12
+        CMPQ         R12, $0
+        MOVQ         R12, t3-64(SP)
+        JNE          block3
+block4:
+        // if.done, preds block1
+        MOVQ         t5-73(SP), R15
+        MOVQ         R15, R13
+        ADDQ         $16, R13
+        MOVQ         R13, t5-73(SP)
+        MOVQ         R13, t12-122(SP)
+        JMP block2
+block3:
+        // if.then, preds block1
+        MOVQ         t3-64(SP), R15
+        MOVQ         R15, R13
+        BSFQ         R13, R12
+        MOVQ         t5-73(SP), R11
+        MOVQ         R11, R10
+        ADDQ         R12, R10
+        MOVQ         R10, ret+32(FP)
+        RET
+block5:
+        // for.body, preds block7
+        MOVQ         ivptr0-8(SP), R15
+        MOVQ         R15, R11
+        MOVB         (R11), R9
+        MOVB         R9, t14-131(SP)
+        MOVBQZX      t14-131(SP), R9
+        MOVBQZX      c+24(FP), R8
+        CMPB         R9, R8
+        JEQ          block8
+block9:
+        // if.done, preds block5
+        MOVQ         t16-140(SP), R15
+        MOVQ         R15, R13
+        ADDQ         $1, R13
+        MOVQ         R13, t16-140(SP)
+        MOVQ         ivptr0-8(SP), R15
+        LEAQ         1(R15), R15
+        MOVQ         R15, ivptr0-8(SP)
+        MOVQ         R13, t19-157(SP)
+block7:
+        // for.loop, preds block2 block9
+        MOVQ         s_len+8(FP), R15
+        MOVQ         R15, R13
+        MOVQ         t16-140(SP), R12
+        CMPQ         R12, R13
+        JLT          block5
+block6:
+        // for.done, preds block7
+        MOVQ         $-1, R15
+        MOVQ         R15, ret+32(FP)
+        RET
+block8:
+        // if.then, preds block5
+        MOVQ         t16-140(SP), R13
+        MOVQ         R13, ret+32(FP)
+        RET
+
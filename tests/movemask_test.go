@@ -0,0 +1,106 @@
+// +build amd64,gc
+
+package tests
+
+import (
+	"math/bits"
+	"testing"
+
+	"github.com/bjwbell/gensimd/simd"
+)
+
+//go:generate gensimd -fn "movemaskt0, movemaskt1, movemaskt2, movemaskt3, movemaskt4" -outfn "movemaskt0s, movemaskt1s, movemaskt2s, movemaskt3s, movemaskt4s" -f "$GOFILE" -o "movemask_test_amd64.s"
+//go:generate gensimd -target sse4.1 -fn "movemaskt3, movemaskt4" -outfn "movemaskt3b, movemaskt4b" -f "$GOFILE" -o "movemask_bsf_test_amd64.s"
+
+func movemaskt0s(x, y simd.U8x16) int
+func movemaskt1s(c uint8) simd.U8x16
+func movemaskt2s(s []byte, i int) simd.U8x16
+
+// TZCNT, the default avx2 target has it
+func movemaskt3s(a uint64, b uint32, c uint16, d uint8, e uint) int
+func movemaskt4s(s []byte, c byte) int
+
+// BSF
+func movemaskt3b(a uint64, b uint32, c uint16, d uint8, e uint) int
+func movemaskt4b(s []byte, c byte) int
+
+// PCMPEQB and PMOVMSKB
+func movemaskt0(x, y simd.U8x16) int {
+	return simd.MoveMaskU8x16(simd.CmpEqU8x16(x, y))
+}
+
+func movemaskt1(c uint8) simd.U8x16 {
+	return simd.SplatU8x16(c)
+}
+
+func movemaskt2(s []byte, i int) simd.U8x16 {
+	return simd.LoadU8x16(s, i)
+}
+
+// each size, the sum tells which is wrong
+func movemaskt3(a uint64, b uint32, c uint16, d uint8, e uint) int {
+	return bits.TrailingZeros64(a) + bits.TrailingZeros32(b)<<8 + bits.TrailingZeros16(c)<<16 + bits.TrailingZeros8(d)<<24 + bits.TrailingZeros(e)<<32
+}
+
+// index of c in s like bytes.IndexByte
+func movemaskt4(s []byte, c byte) int {
+	v := simd.SplatU8x16(c)
+	i := 0
+	for ; i+16 <= len(s); i += 16 {
+		if m := simd.MoveMaskU8x16(simd.CmpEqU8x16(simd.LoadU8x16(s, i), v)); m != 0 {
+			return i + bits.TrailingZeros(uint(m))
+		}
+	}
+	for ; i < len(s); i++ {
+		if s[i] == c {
+			return i
+		}
+	}
+	return -1
+}
+
+func TestMoveMask(t *testing.T) {
+	x := simd.U8x16{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 0xff}
+	y := simd.U8x16{0, 0, 2, 0, 4, 0, 6, 0, 8, 0, 10, 0, 12, 0, 0, 0xff}
+	if movemaskt0s(x, y) != movemaskt0(x, y) {
+		t.Errorf("movemaskt0s(%v, %v) %#x != %#x", x, y, movemaskt0s(x, y), movemaskt0(x, y))
+	}
+	for _, c := range []uint8{0, 1, 0x7f, 0x80, 0xff} {
+		if movemaskt1s(c) != movemaskt1(c) {
+			t.Errorf("movemaskt1s(%v) %v != %v", c, movemaskt1s(c), movemaskt1(c))
+		}
+	}
+	s := make([]byte, 40)
+	for i := range s {
+		s[i] = byte(i * 7)
+	}
+	for _, i := range []int{0, 1, 24} {
+		if movemaskt2s(s, i) != movemaskt2(s, i) {
+			t.Errorf("movemaskt2s(s, %v) %v != %v", i, movemaskt2s(s, i), movemaskt2(s, i))
+		}
+	}
+
+	type funcs struct {
+		name string
+		t3   func(uint64, uint32, uint16, uint8, uint) int
+		t4   func([]byte, byte) int
+	}
+	tests := []funcs{{"bsf", movemaskt3b, movemaskt4b}}
+	if simd.BMI1() {
+		tests = append(tests, funcs{"tzcnt", movemaskt3s, movemaskt4s})
+	}
+	for _, fns := range tests {
+		for _, v := range []uint64{0, 1, 2, 0x80, 0x100, 0x8000, 1 << 31, 1 << 63, 0xffffffffffffffff} {
+			if got, want := fns.t3(v, uint32(v), uint16(v), uint8(v), uint(v)), movemaskt3(v, uint32(v), uint16(v), uint8(v), uint(v)); got != want {
+				t.Errorf("%v t3(%#x) %#x != %#x", fns.name, v, got, want)
+			}
+		}
+		for _, c := range []byte{0, 7, 14 * 7, 35 * 7 % 256, 39 * 7 % 256, 1} {
+			for _, n := range []int{0, 15, 16, 17, 40} {
+				if got, want := fns.t4(s[:n], c), movemaskt4(s[:n], c); got != want {
+					t.Errorf("%v t4(s[:%v], %v) %v != %v", fns.name, n, c, got, want)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,69 @@
+// +build amd64,gc
+
+package tests
+
+import "testing"
+
+//go:generate gensimd -optfor size -fn "optsizet0, optsizet1, optsizet2, optsizet3" -outfn "optsizet0s, optsizet1s, optsizet2s, optsizet3s" -f "$GOFILE" -o "optsize_test_amd64.s"
+
+func optsizet0s(dst, src *[8]int)
+func optsizet1s(s []uint64, i int) uint64
+func optsizet2s(x []int32) int32
+func optsizet3s(p *[4]int, x int) int
+
+// a copy through pointers with REP MOVSQ
+func optsizet0(dst, src *[8]int) {
+	*dst = *src
+}
+
+// zeroed with REP STOSQ
+func optsizet1(s []uint64, i int) uint64 {
+	var a [6]uint64
+	a[i%6] = s[i%len(s)]
+	return a[0] + a[1] + a[5]
+}
+
+// the index and count are incremented with INC
+func optsizet2(x []int32) int32 {
+	n := int32(0)
+	for i := 0; i < len(x); i++ {
+		if x[i] > 0 {
+			n++
+		}
+	}
+	return n - 1
+}
+
+// a copy of a pointed to array to a local
+func optsizet3(p *[4]int, x int) int {
+	a := *p
+	a[x&3] = x
+	return a[0] + a[3]
+}
+
+func TestOptSize(t *testing.T) {
+	src := [8]int{1, -2, 3, 1 << 40, 5, 6, -7, 8}
+	dst := [8]int{}
+	optsizet0s(&dst, &src)
+	if dst != src {
+		t.Errorf("optsizet0s %v != %v", dst, src)
+	}
+	s := []uint64{10, 20, 30, 40, 50, 60}
+	for i := 0; i < 12; i++ {
+		if got, want := optsizet1s(s, i), optsizet1(s, i); got != want {
+			t.Errorf("optsizet1s(%v) %v != %v", i, got, want)
+		}
+	}
+	x := []int32{3, -1, 0, 7, 1 << 30, -5, 2}
+	for n := 0; n <= len(x); n++ {
+		if got, want := optsizet2s(x[:n]), optsizet2(x[:n]); got != want {
+			t.Errorf("optsizet2s(%v) %v != %v", x[:n], got, want)
+		}
+	}
+	for x := -5; x < 5; x++ {
+		got, want := [4]int{1, 2, 3, 4}, [4]int{1, 2, 3, 4}
+		if r, w := optsizet3s(&got, x), optsizet3(&want, x); r != w || got != want {
+			t.Errorf("optsizet3s(%v) %v, %v != %v, %v", x, r, got, w, want)
+		}
+	}
+}
@@ -0,0 +1,196 @@
+// Code generated by gensimd. DO NOT EDIT.
+// gensimd version: devel
+// gensimd command: gensimd -f optsize_test.go -fn "optsizet0, optsizet1, optsizet2, optsizet3" -o optsize_test_amd64.s -optfor size -outfn "optsizet0s, optsizet1s, optsizet2s, optsizet3s"
+// gensimd source: optsize_test.go sha256:0d30b7ac2b1cc631f2dba7917c37f1ab1a288a4aaf3d966af8472715cf3c1ed6
+// gensimd target: avx2
+
+//go:build amd64 && !noasm && !appengine
+// +build amd64,!noasm,!appengine
+
+#include "textflag.h"
+
+TEXT ·optsizet0s(SB),$72-16
+block0:
+        // entry
+        MOVQ         src+8(FP), R15
+        LEAQ         (R15), SI
+        LEAQ         t0-64(SP), DI
+        MOVQ         $8, CX
+        REP
+        MOVSQ
+        MOVQ         dst+0(FP), R13
+        LEAQ         t0-64(SP), SI
+        LEAQ         (R13), DI
+        MOVQ         $8, CX
+        REP
+        MOVSQ
+        RET
+
+TEXT ·optsizet1s(SB),$168-40
+        LEAQ         t0-48(SP), DI
+        MOVQ         $6, CX
+        XORL         AX, AX
+        REP
+        STOSQ
+block0:
+        // entry
+        MOVQ         i+24(FP), R15
+        MOVQ         $6, R13
+        MOVQ         R15, AX
+        MOVQ         AX, DX
+        SARQ         $63, DX
+        CMPQ         R13, $-1
+        JNE          lbl1
+        NEGQ         AX
+        XORL         DX, DX
+        JMP          lbl2
+lbl1:
+        IDIVQ        R13
+lbl2:
+        MOVQ         DX, R12
+        MOVQ         s_len+8(FP), R11
+        MOVQ         R11, R10
+        MOVQ         R15, AX
+        MOVQ         AX, DX
+        SARQ         $63, DX
+        CMPQ         R10, $-1
+        JNE          lbl3
+        NEGQ         AX
+        XORL         DX, DX
+        JMP          lbl4
+lbl3:
+        IDIVQ        R10
+lbl4:
+        MOVQ         DX, R9
+        MOVQ         R11, s_len+8(FP)
+        MOVQ         s_base+0(FP), R11
+        LEAQ         (R11)(R9*8), R11
+        MOVQ         (R11), R8
+        MOVQ         R8, t5-88(SP)
+        LEAQ         t0-48(SP), R8
+        LEAQ         (R8)(R12*8), R8
+        MOVQ         t5-88(SP), BP
+        MOVQ         BP, (R8)
+        LEAQ         t0-48(SP), BX
+        MOVQ         (BX), DI
+        MOVQ         DI, t8-112(SP)
+        LEAQ         t0-48(SP), DI
+        ADDQ         $8, DI
+        MOVQ         DI, t9-120(SP)
+        MOVQ         t9-120(SP), BX
+        MOVQ         (BX), SI
+        MOVQ         SI, t10-128(SP)
+        MOVQ         t8-112(SP), DI
+        MOVQ         t10-128(SP), SI
+        ADDQ         SI, DI
+        LEAQ         t0-48(SP), SI
+        ADDQ         $40, SI
+        MOVQ         SI, t12-144(SP)
+        MOVQ         DI, t11-136(SP)
+        MOVQ         t12-144(SP), BX
+        MOVQ         (BX), SI
+        MOVQ         SI, t13-152(SP)
+        MOVQ         t11-136(SP), DI
+        MOVQ         t13-152(SP), SI
+        ADDQ         SI, DI
+        MOVQ         DI, ret+32(FP)
+        RET
+
+TEXT ·optsizet2s(SB),$64-28
+block0:
+        // entry
+        MOVQ         x_len+8(FP), R15
+        MOVQ         R15, R13
+        MOVQ         R13, BX
+        MOVL         $0, R12
+        MOVL         R12, t0-12(SP)
+        MOVQ         $0, R11
+        MOVQ         R11, t1-20(SP)
+        MOVQ         x_base+0(FP), R10
+        LEAQ         (R10)(R11*4), R10
+        MOVQ         R10, ivptr0-8(SP)
+        MOVQ         R13, t2-28(SP)
+block1:
+        // for.loop, preds block0 block5
+        MOVQ         t1-20(SP), R15
+        MOVQ         BX, R13
+        CMPQ         R15, R13
+        JGE          block3
+block2:
+        // for.body, preds block1
+        MOVQ         ivptr0-8(SP), R15
+        MOVQ         R15, R13
+        MOVL         (R13), R12
+        MOVL         R12, t5-41(SP)
+        MOVLQZX      t5-41(SP), R12
+        CMPL         R12, $0
+        SETGT        R11
+        MOVLQZX      t0-12(SP), R10
+        MOVL         R10, t9-54(SP)
+        MOVB         R11, t6-42(SP)
+        CMPB         R11, $0
+        JEQ          block5
+block4:
+        // if.then, preds block2
+        MOVLQZX      t0-12(SP), R15
+        MOVL         R15, R13
+        INCL         R13
+        MOVL         R13, t9-54(SP)
+        MOVL         R13, t8-50(SP)
+block5:
+        // if.done, preds block2 block4
+        MOVQ         t1-20(SP), R15
+        MOVQ         R15, R13
+        INCQ         R13
+        MOVLQZX      t9-54(SP), R12
+        MOVL         R12, t0-12(SP)
+        MOVQ         R13, t1-20(SP)
+        MOVQ         ivptr0-8(SP), R15
+        LEAQ         4(R15), R15
+        MOVQ         R15, ivptr0-8(SP)
+        MOVQ         R13, t10-62(SP)
+        JMP block1
+block3:
+        // for.done, preds block1
+        MOVLQZX      t0-12(SP), R15
+        MOVL         R15, R13
+        DECL         R13
+        MOVL         R13, ret+24(FP)
+        RET
+
+TEXT ·optsizet3s(SB),$128-24
+block0:
+        // entry
+        MOVQ         p+0(FP), R15
+        LEAQ         (R15), SI
+        LEAQ         t1-64(SP), DI
+        MOVQ         $4, CX
+        REP
+        MOVSQ
+        MOVQ         t1-64(SP), R13
+        MOVQ         t1-56(SP), R12
+        MOVQ         t1-48(SP), R11
+        MOVQ         t1-40(SP), R10
+        MOVQ         x+8(FP), R9
+        MOVQ         R9, R8
+        ANDQ         $3, R8
+        MOVQ         R13, t0-32(SP)
+        MOVQ         R12, t0-24(SP)
+        MOVQ         R11, t0-16(SP)
+        MOVQ         R10, t0-8(SP)
+        LEAQ         t0-32(SP), R13
+        LEAQ         (R13)(R8*8), R13
+        MOVQ         R9, (R13)
+        LEAQ         t0-32(SP), R12
+        MOVQ         (R12), R11
+        MOVQ         R11, t5-96(SP)
+        LEAQ         t0-32(SP), R11
+        ADDQ         $24, R11
+        MOVQ         (R11), R10
+        MOVQ         R10, t7-112(SP)
+        MOVQ         t5-96(SP), R10
+        MOVQ         t7-112(SP), BP
+        ADDQ         BP, R10
+        MOVQ         R10, ret+16(FP)
+        RET
+
@@ -0,0 +1,121 @@
+// +build amd64,gc
+
+package tests
+
+import (
+	"math"
+	"testing"
+
+	"github.com/bjwbell/gensimd/codegen"
+	"github.com/bjwbell/gensimd/internal/asmtest"
+)
+
+// TestOverflow checks the integer operations Go defines for all inputs
+// against Go, the negation and division of the most negative integers,
+// shifts by at least the width, and conversions between signed and unsigned
+// integers and floats.
+func TestOverflow(t *testing.T) {
+	kernels := asmtest.Build(t, "testdata/overflow.go", codegen.DefaultOptions(),
+		"negInt64", "negInt8", "divInt64", "remInt64", "divInt32", "remInt16", "divInt8",
+		"shlUint64", "shrInt64", "shrInt32", "shlUint8", "shrInt8",
+		"int8ToUint64", "uint32ToInt64", "uint64ToInt32", "int64ToInt8",
+		"int8ToFloat64", "uint16ToFloat32", "uint32ToFloat64")
+
+	for _, x := range []int64{math.MinInt64, math.MinInt64 + 1, -1, 0, math.MaxInt64} {
+		if got := kernels.Call(t, "negInt64", x)[0].(int64); got != -x {
+			t.Errorf("negInt64(%v) = %v, expected %v", x, got, -x)
+		}
+		for _, y := range []int64{-1, 1, 7, math.MinInt64} {
+			if got := kernels.Call(t, "divInt64", x, y)[0].(int64); got != x/y {
+				t.Errorf("divInt64(%v, %v) = %v, expected %v", x, y, got, x/y)
+			}
+			if got := kernels.Call(t, "remInt64", x, y)[0].(int64); got != x%y {
+				t.Errorf("remInt64(%v, %v) = %v, expected %v", x, y, got, x%y)
+			}
+		}
+	}
+	for _, x := range []int8{math.MinInt8, -1, 5, math.MaxInt8} {
+		if got := kernels.Call(t, "negInt8", x)[0].(int8); got != -x {
+			t.Errorf("negInt8(%v) = %v, expected %v", x, got, -x)
+		}
+		for _, y := range []int8{-1, 3} {
+			if got := kernels.Call(t, "divInt8", x, y)[0].(int8); got != x/y {
+				t.Errorf("divInt8(%v, %v) = %v, expected %v", x, y, got, x/y)
+			}
+		}
+	}
+	for _, x := range []int32{math.MinInt32, -7, math.MaxInt32} {
+		if got := kernels.Call(t, "divInt32", x, int32(-1))[0].(int32); got != x/-1 {
+			t.Errorf("divInt32(%v, -1) = %v, expected %v", x, got, x/-1)
+		}
+	}
+	for _, x := range []int16{math.MinInt16, -7, math.MaxInt16} {
+		if got := kernels.Call(t, "remInt16", x, int16(-1))[0].(int16); got != 0 {
+			t.Errorf("remInt16(%v, -1) = %v, expected 0", x, got)
+		}
+	}
+
+	for _, s := range []uint64{0, 1, 63, 64, 65, 256, math.MaxUint64} {
+		x := uint64(0x8000000000000001)
+		if got := kernels.Call(t, "shlUint64", x, s)[0].(uint64); got != x<<s {
+			t.Errorf("shlUint64(%#x, %v) = %#x, expected %#x", x, s, got, x<<s)
+		}
+		for _, y := range []int64{math.MinInt64, -5, 5} {
+			if got := kernels.Call(t, "shrInt64", y, s)[0].(int64); got != y>>s {
+				t.Errorf("shrInt64(%v, %v) = %v, expected %v", y, s, got, y>>s)
+			}
+		}
+	}
+	for _, s := range []uint16{0, 31, 32, 33, 256, 257, math.MaxUint16} {
+		for _, x := range []int32{math.MinInt32, -5, 5} {
+			if got := kernels.Call(t, "shrInt32", x, s)[0].(int32); got != x>>s {
+				t.Errorf("shrInt32(%v, %v) = %v, expected %v", x, s, got, x>>s)
+			}
+		}
+	}
+	for _, s := range []uint8{0, 7, 8, 9, math.MaxUint8} {
+		x := uint8(0x81)
+		if got := kernels.Call(t, "shlUint8", x, s)[0].(uint8); got != x<<s {
+			t.Errorf("shlUint8(%#x, %v) = %#x, expected %#x", x, s, got, x<<s)
+		}
+	}
+	for _, s := range []uint32{0, 7, 8, 256, math.MaxUint32} {
+		for _, x := range []int8{math.MinInt8, -5, 5} {
+			if got := kernels.Call(t, "shrInt8", x, s)[0].(int8); got != x>>s {
+				t.Errorf("shrInt8(%v, %v) = %v, expected %v", x, s, got, x>>s)
+			}
+		}
+	}
+
+	for _, x := range []int8{math.MinInt8, -1, 0, math.MaxInt8} {
+		if got := kernels.Call(t, "int8ToUint64", x)[0].(uint64); got != uint64(x) {
+			t.Errorf("int8ToUint64(%v) = %#x, expected %#x", x, got, uint64(x))
+		}
+		if got := kernels.Call(t, "int8ToFloat64", x)[0].(float64); got != float64(x) {
+			t.Errorf("int8ToFloat64(%v) = %v, expected %v", x, got, float64(x))
+		}
+	}
+	for _, x := range []uint32{0, math.MaxInt32 + 1, math.MaxUint32} {
+		if got := kernels.Call(t, "uint32ToInt64", x)[0].(int64); got != int64(x) {
+			t.Errorf("uint32ToInt64(%v) = %v, expected %v", x, got, int64(x))
+		}
+		if got := kernels.Call(t, "uint32ToFloat64", x)[0].(float64); got != float64(x) {
+			t.Errorf("uint32ToFloat64(%v) = %v, expected %v", x, got, float64(x))
+		}
+	}
+	for _, x := range []uint64{math.MaxInt32 + 1, math.MaxUint64, 0x1ffffffff} {
+		if got := kernels.Call(t, "uint64ToInt32", x)[0].(int32); got != int32(x) {
+			t.Errorf("uint64ToInt32(%#x) = %v, expected %v", x, got, int32(x))
+		}
+	}
+	for _, x := range []int64{math.MinInt64, 0x17f, 0x180, -129} {
+		if got := kernels.Call(t, "int64ToInt8", x)[0].(int8); got != int8(x) {
+			t.Errorf("int64ToInt8(%v) = %v, expected %v", x, got, int8(x))
+		}
+	}
+	for _, x := range []uint16{0, math.MaxInt16 + 1, math.MaxUint16} {
+		if got := kernels.Call(t, "uint16ToFloat32", x)[0].(float32); got != float32(x) {
+			t.Errorf("uint16ToFloat32(%v) = %v, expected %v", x, got, float32(x))
+		}
+	}
+}
@@ -0,0 +1,76 @@
+// +build amd64,gc
+
+package tests
+
+import (
+	"testing"
+
+	"github.com/bjwbell/gensimd/simd"
+)
+
+//go:generate gensimd -fn "pint0, pint1, pint2" -outfn "pint0s, pint1s, pint2s" -f "$GOFILE" -o "pin_test_amd64.s"
+
+func pint0s(x []simd.U32x4, k simd.U32x4) simd.U32x4
+func pint1s(x []simd.U32x4)
+func pint2s(x []int64, n int64, bias float64) float64
+
+// pint0 mixes x with the round key k, pinned to X5 for the whole loop.
+//
+//gensimd:reg k X5
+func pint0(x []simd.U32x4, k simd.U32x4) simd.U32x4 {
+	acc := simd.U32x4{1, 2, 3, 4}
+	for i := 0; i < len(x); i++ {
+		v := simd.AddU32x4(x[i], k)
+		acc = simd.AddU32x4(simd.AndNotU32x4(acc, v), k)
+	}
+	return acc
+}
+
+// pint1 adds a vector of round constants, pinned to X9, to each element of x.
+//
+//gensimd:reg rc X9
+func pint1(x []simd.U32x4) {
+	rc := simd.U32x4{0x9e3779b9, 0x7f4a7c15, 0xf39cc060, 0x5ced1a2b}
+	for i := 0; i < len(x); i++ {
+		x[i] = simd.AddU32x4(x[i], rc)
+	}
+}
+
+// pint2 sums x times a multiplier computed once and pinned to R12, with the
+// bias pinned to X7.
+//
+//gensimd:reg m R12
+//gensimd:reg bias X7
+func pint2(x []int64, n int64, bias float64) float64 {
+	m := n*3 + 1
+	s := int64(0)
+	for i := 0; i < len(x); i++ {
+		s += x[i] * m
+	}
+	return float64(s) + bias
+}
+
+func TestPin(t *testing.T) {
+	x := make([]simd.U32x4, 9)
+	for i := range x {
+		x[i] = simd.U32x4{uint32(i), 0xffffffff - uint32(i), uint32(i) << 28, 0x01010101 * uint32(i)}
+	}
+	k := simd.U32x4{0x243f6a88, 0x85a308d3, 0x13198a2e, 0x03707344}
+	if got, expected := pint0s(x, k), pint0(x, k); got != expected {
+		t.Errorf("pint0s = %#x, expected %#x", got, expected)
+	}
+
+	got, expected := append([]simd.U32x4{}, x...), append([]simd.U32x4{}, x...)
+	pint1s(got)
+	pint1(expected)
+	for i := range got {
+		if got[i] != expected[i] {
+			t.Errorf("pint1s: x[%v] = %#x, expected %#x", i, got[i], expected[i])
+		}
+	}
+
+	z := []int64{1, -2, 3, 40, -500}
+	if got, expected := pint2s(z, 7, 0.5), pint2(z, 7, 0.5); got != expected {
+		t.Errorf("pint2s = %v, expected %v", got, expected)
+	}
+}
@@ -0,0 +1,208 @@
+// Code generated by gensimd. DO NOT EDIT.
+// gensimd version: devel
+// gensimd command: gensimd -f pin_test.go -fn "pint0, pint1, pint2" -o pin_test_amd64.s -outfn "pint0s, pint1s, pint2s"
+// gensimd source: pin_test.go sha256:87dc95d746edafe7e0d8da0d60fa3a75d7a05d6766f281a2d02a64f953dff638
+// gensimd target: avx2
+
+//go:build amd64 && !noasm && !appengine
+// +build amd64,!noasm,!appengine
+
+#include "textflag.h"
+
+TEXT ·pint0s(SB),$192-56
+        MOVUPS       k+24(FP), X5
+block0:
+        // entry
+        LEAQ         t0-16(SP), R15
+        LEAQ         t0-16(SP), R13
+        ADDQ         $4, R13
+        LEAQ         t0-16(SP), R12
+        ADDQ         $8, R12
+        LEAQ         t0-16(SP), R11
+        ADDQ         $12, R11
+        MOVL         $1, R10
+        MOVL         R10, (R15)
+        MOVL         $2, R9
+        MOVL         R9, (R13)
+        MOVL         $3, R8
+        MOVL         R8, (R12)
+        MOVL         $4, R8
+        MOVL         R8, (R11)
+        MOVQ         x_len+8(FP), BP
+        MOVQ         BP, DI
+        MOVQ         DI, BX
+        MOVQ         $0, SI
+        MOVQ         SI, t5-64(SP)
+        MOVQ         $0, BP
+        MOVQ         x_base+0(FP), SI
+        IMUL3Q       $16, BP, R8
+        ADDQ         R8, SI
+        MOVQ         SI, ivptr0-24(SP)
+        MOVQ         DI, t6-72(SP)
+block1:
+        // for.loop, preds block0 block2
+        MOVQ         t5-64(SP), R15
+        MOVQ         BX, R13
+        CMPQ         R15, R13
+        JGE          block3
+block2:
+        // for.body, preds block1
+        MOVQ         ivptr0-24(SP), R15
+        MOVQ         R15, R13
+        MOVQ         R13, R12
+        MOVOU        (R12), X14
+        MOVOU        X14, t9-97(SP)
+        MOVO         X5, X14
+        MOVOU        t9-97(SP), X13
+        PADDL        X14, X13
+        MOVOU        t0-16(SP), X12
+        MOVO         X12, X11
+        MOVO         X13, X10
+        PANDN        X11, X10
+        MOVOU        X10, t12-145(SP)
+        PADDL        X14, X10
+        MOVO         X10, X12
+        MOVQ         t5-64(SP), R12
+        MOVQ         R12, R11
+        ADDQ         $1, R11
+        MOVQ         R11, t5-64(SP)
+        LEAQ         16(R15), R15
+        MOVQ         R15, ivptr0-24(SP)
+        MOVQ         R11, t14-169(SP)
+        MOVOU        X12, t0-16(SP)
+        JMP block1
+block3:
+        // for.done, preds block1
+        MOVOU        t0-16(SP), X14
+        MOVO         X14, X13
+        MOVUPS       X13, ret+40(FP)
+        RET
+
+TEXT ·pint1s(SB),$152-24
+block0:
+        // entry
+        MOVOU        t0-16(SP), X9
+        LEAQ         t0-16(SP), R15
+        LEAQ         t0-16(SP), R13
+        ADDQ         $4, R13
+        LEAQ         t0-16(SP), R12
+        ADDQ         $8, R12
+        LEAQ         t0-16(SP), R11
+        ADDQ         $12, R11
+        MOVL         $-1640531527, R10
+        MOVL         R10, (R15)
+        MOVOU        t0-16(SP), X9
+        MOVL         $2135587861, R9
+        MOVL         R9, (R13)
+        MOVOU        t0-16(SP), X9
+        MOVL         $-207830944, R8
+        MOVL         R8, (R12)
+        MOVOU        t0-16(SP), X9
+        MOVL         $1559042603, R8
+        MOVL         R8, (R11)
+        MOVOU        t0-16(SP), X9
+        MOVQ         x_len+8(FP), BP
+        MOVQ         BP, DI
+        MOVQ         DI, BX
+        MOVQ         $0, SI
+        MOVQ         SI, t5-64(SP)
+        MOVQ         $0, BP
+        MOVQ         x_base+0(FP), SI
+        IMUL3Q       $16, BP, R8
+        ADDQ         R8, SI
+        MOVQ         SI, ivptr0-24(SP)
+        MOVQ         DI, t6-72(SP)
+block1:
+        // for.loop, preds block0 block2
+        MOVQ         t5-64(SP), R15
+        MOVQ         BX, R13
+        CMPQ         R15, R13
+        JGE          block3
+block2:
+        // for.body, preds block1
+        MOVQ         ivptr0-24(SP), R15
+        MOVQ         R15, R13
+        MOVQ         R13, R12
+        MOVOU        (R12), X14
+        MOVOU        X14, t9-97(SP)
+        MOVO         X9, X14
+        MOVO         X14, X13
+        MOVOU        t9-97(SP), X12
+        PADDL        X13, X12
+        MOVQ         R15, R12
+        MOVOU        X12, (R12)
+        MOVQ         t5-64(SP), R11
+        MOVQ         R11, R10
+        ADDQ         $1, R10
+        MOVQ         R10, t5-64(SP)
+        LEAQ         16(R15), R15
+        MOVQ         R15, ivptr0-24(SP)
+        MOVQ         R10, t13-145(SP)
+        JMP block1
+block3:
+        // for.done, preds block1
+        RET
+
+TEXT ·pint2s(SB),$112-48
+        MOVSD        bias+32(FP), X7
+block0:
+        // entry
+        MOVQ         n+24(FP), R15
+        MOVQ         $3, R13
+        MOVQ         R15, R11
+        MOVQ         R11, AX
+        IMULQ        R13
+        MOVQ         AX, R11
+        ADDQ         $1, R11
+        MOVQ         R11, R12
+        MOVQ         x_len+8(FP), R10
+        MOVQ         R10, R9
+        MOVQ         R9, R8
+        MOVQ         $0, BP
+        MOVQ         BP, t2-32(SP)
+        MOVQ         $0, DI
+        MOVQ         DI, t3-40(SP)
+        MOVQ         t2-32(SP), BX
+        MOVQ         x_base+0(FP), SI
+        LEAQ         (SI)(DI*8), SI
+        MOVQ         SI, ivptr0-8(SP)
+        MOVQ         R9, t4-48(SP)
+        MOVQ         R11, t1-24(SP)
+block1:
+        // for.loop, preds block0 block2
+        MOVQ         t3-40(SP), R15
+        MOVQ         R8, R13
+        CMPQ         R15, R13
+        JGE          block3
+block2:
+        // for.body, preds block1
+        MOVQ         ivptr0-8(SP), R15
+        MOVQ         R15, R13
+        MOVQ         (R13), R11
+        MOVQ         R11, t7-65(SP)
+        MOVQ         t7-65(SP), R11
+        MOVQ         R12, R10
+        MOVQ         R11, AX
+        IMULQ        R10
+        MOVQ         AX, R11
+        MOVQ         BX, R9
+        MOVQ         R9, BP
+        ADDQ         R11, BP
+        MOVQ         BP, BX
+        MOVQ         t3-40(SP), DI
+        MOVQ         DI, SI
+        ADDQ         $1, SI
+        MOVQ         SI, t3-40(SP)
+        LEAQ         8(R15), R15
+        MOVQ         R15, ivptr0-8(SP)
+        MOVQ         SI, t10-89(SP)
+        JMP block1
+block3:
+        // for.done, preds block1
+        MOVQ         BX, R15
+        CVTSQ2SD     R15, X14
+        MOVO         X7, X13
+        ADDSD        X13, X14
+        MOVSD        X14, ret+40(FP)
+        RET
+
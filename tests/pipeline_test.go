@@ -0,0 +1,125 @@
+// +build amd64,gc
+
+package tests
+
+import (
+	"testing"
+
+	"github.com/bjwbell/gensimd/simd"
+)
+
+//go:generate gensimd -pipeline -fn "pipelinet0, pipelinet1, pipelinet2, pipelinet3, pipelinet4, pipelinet5" -outfn "pipelinet0s, pipelinet1s, pipelinet2s, pipelinet3s, pipelinet4s, pipelinet5s" -f "$GOFILE" -o "pipeline_test_amd64.s"
+
+func pipelinet0s(x []int64) int64
+func pipelinet1s(x []int32)
+func pipelinet2s(dst, x []simd.I32x4, k simd.I32x4)
+func pipelinet3s(x []uint8) uint32
+func pipelinet4s(a, b []int64) int64
+func pipelinet5s(x []int32) int32
+
+// a range loop, the index is the phi plus one
+func pipelinet0(x []int64) int64 {
+	s := int64(0)
+	for i := range x {
+		s += x[i]
+	}
+	return s
+}
+
+// stores to the element it loaded
+func pipelinet1(x []int32) {
+	for i := 0; i < len(x); i++ {
+		x[i] = x[i]*3 - 1
+	}
+}
+
+// stores to another slice, pipelined only because they don't overlap
+//gensimd:noalias
+func pipelinet2(dst, x []simd.I32x4, k simd.I32x4) {
+	for i := 0; i < len(x); i++ {
+		dst[i] = simd.AddI32x4(simd.MulI32x4(x[i], k), k)
+	}
+}
+
+// odd bytes, a step of two from one
+func pipelinet3(x []uint8) uint32 {
+	s := uint32(0)
+	for i := 1; i < len(x); i += 2 {
+		s = s*31 + uint32(x[i])
+	}
+	return s
+}
+
+// only the loads of a, the loop is counted to its length
+func pipelinet4(a, b []int64) int64 {
+	s := int64(0)
+	for i := 0; i < len(a); i++ {
+		s += a[i] * b[i]
+	}
+	return s
+}
+
+// a scratch array in the loop body, zeroed each iteration, the registers
+// loading ahead spill the values they hold
+func pipelinet5(x []int32) int32 {
+	s := int32(0)
+	for i := 0; i < len(x); i++ {
+		var tmp [4]byte
+		s += int32(tmp[i&3]) + x[i]
+		tmp[i&3] = byte(x[i])
+		s += int32(tmp[i&3])
+	}
+	return s
+}
+
+func TestPipeline(t *testing.T) {
+	for n := 0; n < 8; n++ {
+		x := make([]int64, n, n+1)
+		x32 := make([]int32, n)
+		x8 := make([]uint8, n)
+		for i := range x {
+			x[i] = int64(i*i) - 7
+			x32[i] = int32(i) - 3
+			x8[i] = uint8(200 + i*11)
+		}
+		// an element past the length isn't loaded into the sum
+		x[:n+1][n] = 1000
+		if got, expected := pipelinet0s(x), pipelinet0(x); got != expected {
+			t.Errorf("t0 n=%v %v != %v", n, got, expected)
+		}
+		got32 := append([]int32{}, x32...)
+		pipelinet1s(got32)
+		pipelinet1(x32)
+		for i := range x32 {
+			if got32[i] != x32[i] {
+				t.Errorf("t1 n=%v [%v] %v != %v", n, i, got32[i], x32[i])
+			}
+		}
+		if got, expected := pipelinet3s(x8), pipelinet3(x8); got != expected {
+			t.Errorf("t3 n=%v %v != %v", n, got, expected)
+		}
+		b := make([]int64, n+3)
+		for i := range b {
+			b[i] = int64(5 - i)
+		}
+		if got, expected := pipelinet4s(x, b), pipelinet4(x, b); got != expected {
+			t.Errorf("t4 n=%v %v != %v", n, got, expected)
+		}
+		if got, expected := pipelinet5s(x32), pipelinet5(x32); got != expected {
+			t.Errorf("t5 n=%v %v != %v", n, got, expected)
+		}
+		v := make([]simd.I32x4, n)
+		for i := range v {
+			v[i] = simd.I32x4{int32(i), int32(-i), int32(i * 7), 3}
+		}
+		k := simd.I32x4{2, 3, -1, 5}
+		got, expected := make([]simd.I32x4, n), make([]simd.I32x4, n)
+		pipelinet2s(got, v, k)
+		pipelinet2(expected, v, k)
+		for i := range v {
+			if got[i] != expected[i] {
+				t.Errorf("t2 n=%v [%v] %v != %v", n, i, got[i], expected[i])
+			}
+		}
+	}
+}
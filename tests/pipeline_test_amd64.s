@@ -0,0 +1,440 @@
+// Code generated by gensimd. DO NOT EDIT.
+// gensimd version: devel
+// gensimd command: gensimd -f pipeline_test.go -fn "pipelinet0, pipelinet1, pipelinet2, pipelinet3, pipelinet4, pipelinet5" -o pipeline_test_amd64.s -outfn "pipelinet0s, pipelinet1s, pipelinet2s, pipelinet3s, pipelinet4s, pipelinet5s" -pipeline
+// gensimd source: pipeline_test.go sha256:0ac269335a4ba79627d030930dcadf6b5a23557a15eb0ce30f5637bfb2caf779
+// gensimd target: avx2
+
+//go:build amd64 && !noasm && !appengine
+// +build amd64,!noasm,!appengine
+
+#include "textflag.h"
+
+TEXT ·pipelinet0s(SB),$64-32
+block0:
+        // entry
+        MOVQ         x_len+8(FP), R15
+        MOVQ         R15, R13
+        MOVQ         R13, R8
+        MOVQ         $0, R12
+        MOVQ         R12, t1-24(SP)
+        MOVQ         $-1, R11
+        MOVQ         R11, t2-32(SP)
+        MOVQ         t1-24(SP), BX
+        MOVQ         R11, R10
+        ADDQ         $1, R10
+        MOVQ         x_len+8(FP), R9
+        CMPQ         R10, R9
+        JCC          lbl1
+        MOVQ         x_base+0(FP), R9
+        LEAQ         (R9)(R10*8), R9
+        MOVQ         (R9), BP
+        MOVQ         BP, pipe0-8(SP)
+lbl1:
+        MOVQ         R13, t0-16(SP)
+block1:
+        // rangeindex.loop, preds block0 block2
+        MOVQ         t2-32(SP), R15
+        MOVQ         R15, R13
+        ADDQ         $1, R13
+        MOVQ         R8, R12
+        CMPQ         R13, R12
+        MOVQ         R13, t3-40(SP)
+        JGE          block3
+block2:
+        // rangeindex.body, preds block1
+        MOVQ         pipe0-8(SP), R15
+        MOVQ         t3-40(SP), R13
+        MOVQ         R13, R12
+        ADDQ         $1, R12
+        MOVQ         x_len+8(FP), R11
+        CMPQ         R12, R11
+        JCC          lbl2
+        MOVQ         x_base+0(FP), R11
+        LEAQ         (R11)(R12*8), R11
+        MOVQ         (R11), R10
+        MOVQ         R10, pipe0-8(SP)
+lbl2:
+        MOVQ         BX, R12
+        MOVQ         R12, R11
+        ADDQ         R15, R11
+        MOVQ         R11, BX
+        MOVQ         R13, t2-32(SP)
+        JMP block1
+block3:
+        // rangeindex.done, preds block1
+        MOVQ         BX, R15
+        MOVQ         R15, ret+24(FP)
+        RET
+
+TEXT ·pipelinet1s(SB),$64-24
+block0:
+        // entry
+        MOVQ         x_len+8(FP), R15
+        MOVQ         R15, R13
+        MOVQ         R13, BX
+        MOVQ         $0, R12
+        MOVQ         R12, t0-20(SP)
+        MOVQ         x_base+0(FP), R11
+        LEAQ         (R11)(R12*4), R11
+        MOVQ         R11, ivptr0-12(SP)
+        MOVQ         R12, R11
+        MOVQ         x_len+8(FP), R10
+        CMPQ         R11, R10
+        JCC          lbl1
+        MOVQ         x_base+0(FP), R10
+        LEAQ         (R10)(R11*4), R10
+        MOVLQZX      (R10), R9
+        MOVL         R9, pipe0-4(SP)
+lbl1:
+        MOVQ         R13, t1-28(SP)
+block1:
+        // for.loop, preds block0 block2
+        MOVQ         t0-20(SP), R15
+        MOVQ         BX, R13
+        CMPQ         R15, R13
+        JGE          block3
+block2:
+        // for.body, preds block1
+        MOVLQZX      pipe0-4(SP), R15
+        MOVQ         t0-20(SP), R13
+        MOVQ         R13, R12
+        ADDQ         $1, R12
+        MOVQ         x_len+8(FP), R11
+        CMPQ         R12, R11
+        JCC          lbl2
+        MOVQ         x_base+0(FP), R11
+        LEAQ         (R11)(R12*4), R11
+        MOVLQZX      (R11), R10
+        MOVL         R10, pipe0-4(SP)
+lbl2:
+        MOVL         $3, R12
+        MOVL         R15, AX
+        IMULL        R12
+        MOVL         AX, R15
+        SUBL         $1, R15
+        MOVQ         ivptr0-12(SP), R11
+        MOVQ         R11, R10
+        MOVL         R15, (R10)
+        MOVQ         R13, R9
+        ADDQ         $1, R9
+        MOVQ         R9, t0-20(SP)
+        LEAQ         4(R11), R11
+        MOVQ         R11, ivptr0-12(SP)
+        MOVQ         R9, t8-57(SP)
+        JMP block1
+block3:
+        // for.done, preds block1
+        RET
+
+TEXT ·pipelinet2s(SB),$112-64
+        MOVUPS       k+48(FP), X0
+block0:
+        // entry
+        MOVQ         x_len+32(FP), R15
+        MOVQ         R15, R13
+        MOVQ         R13, BX
+        MOVQ         $0, R12
+        MOVQ         R12, t0-32(SP)
+        MOVQ         dst_base+0(FP), R11
+        IMUL3Q       $16, R12, R10
+        ADDQ         R10, R11
+        MOVQ         R11, ivptr0-24(SP)
+        MOVQ         R12, R11
+        MOVQ         x_len+32(FP), R10
+        CMPQ         R11, R10
+        JCC          lbl1
+        MOVQ         x_base+24(FP), R10
+        IMUL3Q       $16, R11, R11
+        ADDQ         R11, R10
+        MOVOU        (R10), X14
+        MOVOU        X14, pipe0-16(SP)
+lbl1:
+        MOVQ         R13, t1-40(SP)
+block1:
+        // for.loop, preds block0 block2
+        MOVQ         t0-32(SP), R15
+        MOVQ         BX, R13
+        CMPQ         R15, R13
+        JGE          block3
+block2:
+        // for.body, preds block1
+        MOVOU        pipe0-16(SP), X14
+        MOVQ         t0-32(SP), R15
+        MOVQ         R15, R13
+        ADDQ         $1, R13
+        MOVQ         x_len+32(FP), R12
+        CMPQ         R13, R12
+        JCC          lbl2
+        MOVQ         x_base+24(FP), R12
+        IMUL3Q       $16, R13, R13
+        ADDQ         R13, R12
+        MOVOU        (R12), X13
+        MOVOU        X13, pipe0-16(SP)
+lbl2:
+        MOVO         X0, X12
+        MOVO         X12, X13
+        PMULULQ      X14, X13
+        MOVOU        X14, t4-57(SP)
+        PSRLO        $4, X14
+        PSRLO        $4, X12
+        MOVO         X12, X11
+        PMULULQ      X14, X11
+        PSHUFD       $8, X13, X10
+        PSHUFD       $8, X11, X9
+        PUNPCKLLQ    X9, X10
+        MOVO         X0, X14
+        MOVOU        X10, t5-73(SP)
+        PADDL        X14, X10
+        MOVQ         ivptr0-24(SP), R13
+        MOVQ         R13, R12
+        MOVOU        X10, (R12)
+        MOVQ         R15, R11
+        ADDQ         $1, R11
+        MOVQ         R11, t0-32(SP)
+        LEAQ         16(R13), R13
+        MOVQ         R13, ivptr0-24(SP)
+        MOVQ         R11, t8-105(SP)
+        JMP block1
+block3:
+        // for.done, preds block1
+        RET
+
+TEXT ·pipelinet3s(SB),$48-28
+block0:
+        // entry
+        MOVQ         x_len+8(FP), R15
+        MOVQ         R15, R13
+        MOVQ         R13, BX
+        MOVL         $0, R12
+        MOVL         R12, t0-5(SP)
+        MOVQ         $1, R11
+        MOVQ         R11, t1-13(SP)
+        MOVQ         R11, R10
+        MOVQ         x_len+8(FP), R9
+        CMPQ         R10, R9
+        JCC          lbl1
+        MOVQ         x_base+0(FP), R9
+        LEAQ         (R9)(R10*1), R9
+        MOVBQZX      (R9), R8
+        MOVB         R8, pipe0-1(SP)
+lbl1:
+        MOVQ         R13, t2-21(SP)
+block1:
+        // for.loop, preds block0 block2
+        MOVQ         t1-13(SP), R15
+        MOVQ         BX, R13
+        CMPQ         R15, R13
+        JGE          block3
+block2:
+        // for.body, preds block1
+        MOVLQZX      t0-5(SP), R15
+        MOVL         $31, R13
+        MOVL         R15, R12
+        MOVL         R12, AX
+        MULL         R13
+        MOVL         AX, R12
+        MOVBQZX      pipe0-1(SP), R11
+        MOVQ         t1-13(SP), R10
+        MOVQ         R10, R9
+        ADDQ         $2, R9
+        MOVQ         x_len+8(FP), R8
+        CMPQ         R9, R8
+        JCC          lbl2
+        MOVQ         x_base+0(FP), R8
+        LEAQ         (R8)(R9*1), R8
+        MOVBQZX      (R8), BP
+        MOVB         BP, pipe0-1(SP)
+lbl2:
+        MOVBLZX      R11, R9
+        ADDL         R9, R12
+        MOVQ         R10, R8
+        ADDQ         $2, R8
+        MOVL         R12, t0-5(SP)
+        MOVQ         R8, t1-13(SP)
+        MOVQ         R8, t9-43(SP)
+        MOVL         R12, t8-35(SP)
+        JMP block1
+block3:
+        // for.done, preds block1
+        MOVLQZX      t0-5(SP), R15
+        MOVL         R15, ret+24(FP)
+        RET
+
+TEXT ·pipelinet4s(SB),$96-56
+block0:
+        // entry
+        MOVQ         a_len+8(FP), R15
+        MOVQ         R15, R13
+        MOVQ         R13, R8
+        MOVQ         $0, R12
+        MOVQ         R12, t0-24(SP)
+        MOVQ         $0, R11
+        MOVQ         R11, t1-32(SP)
+        MOVQ         t0-24(SP), BX
+        MOVQ         b_base+24(FP), R10
+        LEAQ         (R10)(R11*8), R10
+        MOVQ         R10, ivptr0-16(SP)
+        MOVQ         R11, R10
+        MOVQ         a_len+8(FP), R9
+        CMPQ         R10, R9
+        JCC          lbl1
+        MOVQ         a_base+0(FP), R9
+        LEAQ         (R9)(R10*8), R9
+        MOVQ         (R9), BP
+        MOVQ         BP, pipe0-8(SP)
+lbl1:
+        MOVQ         R13, t2-40(SP)
+block1:
+        // for.loop, preds block0 block2
+        MOVQ         t1-32(SP), R15
+        MOVQ         R8, R13
+        CMPQ         R15, R13
+        JGE          block3
+block2:
+        // for.body, preds block1
+        MOVQ         pipe0-8(SP), R15
+        MOVQ         t1-32(SP), R13
+        MOVQ         R13, R12
+        ADDQ         $1, R12
+        MOVQ         a_len+8(FP), R11
+        CMPQ         R12, R11
+        JCC          lbl2
+        MOVQ         a_base+0(FP), R11
+        LEAQ         (R11)(R12*8), R11
+        MOVQ         (R11), R10
+        MOVQ         R10, pipe0-8(SP)
+lbl2:
+        MOVQ         ivptr0-16(SP), R12
+        MOVQ         R12, R11
+        MOVQ         (R11), R10
+        MOVQ         R10, t7-65(SP)
+        MOVQ         t7-65(SP), R10
+        MOVQ         R15, AX
+        IMULQ        R10
+        MOVQ         AX, R15
+        MOVQ         BX, R9
+        MOVQ         R9, BP
+        ADDQ         R15, BP
+        MOVQ         BP, BX
+        MOVQ         R13, DI
+        ADDQ         $1, DI
+        MOVQ         DI, t1-32(SP)
+        LEAQ         8(R12), R12
+        MOVQ         R12, ivptr0-16(SP)
+        MOVQ         DI, t10-89(SP)
+        JMP block1
+block3:
+        // for.done, preds block1
+        MOVQ         BX, R15
+        MOVQ         R15, ret+48(FP)
+        RET
+
+TEXT ·pipelinet5s(SB),$128-28
+block0:
+        // entry
+        MOVQ         x_len+8(FP), R15
+        MOVQ         R15, R13
+        MOVQ         R13, BX
+        MOVL         $0, R12
+        MOVL         R12, t0-16(SP)
+        MOVQ         $0, R11
+        MOVQ         R11, t1-24(SP)
+        MOVQ         R11, R10
+        MOVQ         x_len+8(FP), R9
+        CMPQ         R10, R9
+        JCC          lbl1
+        MOVQ         x_base+0(FP), R9
+        LEAQ         (R9)(R10*4), R9
+        MOVLQZX      (R9), R8
+        MOVL         R8, pipe0-8(SP)
+lbl1:
+        MOVQ         R11, R10
+        MOVQ         x_len+8(FP), R9
+        CMPQ         R10, R9
+        JCC          lbl2
+        MOVQ         x_base+0(FP), R9
+        LEAQ         (R9)(R10*4), R9
+        MOVLQZX      (R9), R8
+        MOVL         R8, pipe1-12(SP)
+lbl2:
+        MOVQ         R13, t2-32(SP)
+block1:
+        // for.loop, preds block0 block2
+        MOVQ         t1-24(SP), R15
+        MOVQ         BX, R13
+        CMPQ         R15, R13
+        JGE          block3
+block2:
+        // for.body, preds block1
+        MOVL         $0, t4-4(SP)
+        MOVQ         t1-24(SP), R15
+        MOVQ         R15, R13
+        ANDQ         $3, R13
+        LEAQ         t4-4(SP), R12
+        LEAQ         (R12)(R13*1), R12
+        MOVB         (R12), R11
+        MOVB         R11, t7-50(SP)
+        MOVBQZX      t7-50(SP), R11
+        MOVBLZX      R11, R10
+        MOVLQZX      pipe0-8(SP), R9
+        MOVQ         R15, R8
+        ADDQ         $1, R8
+        MOVQ         x_len+8(FP), BP
+        CMPQ         R8, BP
+        JCC          lbl3
+        MOVQ         x_base+0(FP), BP
+        LEAQ         (BP)(R8*4), BP
+        MOVLQZX      (BP), DI
+        MOVL         DI, pipe0-8(SP)
+lbl3:
+        ADDL         R9, R10
+        MOVLQZX      t0-16(SP), R8
+        MOVL         R8, R9
+        ADDL         R10, R9
+        MOVQ         R15, BP
+        ANDQ         $3, BP
+        MOVLQZX      pipe1-12(SP), R8
+        MOVQ         BP, t13-74(SP)
+        MOVQ         R15, DI
+        ADDQ         $1, DI
+        MOVQ         x_len+8(FP), SI
+        CMPQ         DI, SI
+        JCC          lbl4
+        MOVQ         x_base+0(FP), SI
+        LEAQ         (SI)(DI*4), SI
+        MOVLQZX      (SI), BP
+        MOVL         BP, pipe1-12(SP)
+lbl4:
+        MOVL         R9, t12-66(SP)
+        MOVB         R8, R9
+        MOVQ         t13-74(SP), DI
+        LEAQ         t4-4(SP), BP
+        LEAQ         (BP)(DI*1), BP
+        MOVB         R9, (BP)
+        MOVQ         R15, SI
+        ANDQ         $3, SI
+        MOVQ         SI, t18-95(SP)
+        MOVQ         t18-95(SP), DI
+        LEAQ         t4-4(SP), SI
+        LEAQ         (SI)(DI*1), SI
+        MOVQ         SI, t19-103(SP)
+        MOVQ         t19-103(SP), BP
+        MOVB         (BP), SI
+        MOVB         SI, t20-104(SP)
+        MOVBQZX      t20-104(SP), R8
+        MOVBLZX      R8, R9
+        MOVLQZX      t12-66(SP), R8
+        ADDL         R9, R8
+        MOVQ         R15, DI
+        ADDQ         $1, DI
+        MOVL         R8, t0-16(SP)
+        MOVQ         DI, t1-24(SP)
+        MOVQ         DI, t23-120(SP)
+        MOVL         R8, t22-112(SP)
+        JMP block1
+block3:
+        // for.done, preds block1
+        MOVLQZX      t0-16(SP), R15
+        MOVL         R15, ret+24(FP)
+        RET
+
@@ -0,0 +1,69 @@
+// +build amd64,gc
+
+package tests
+
+import (
+	"testing"
+
+	"github.com/bjwbell/gensimd/codegen"
+	"github.com/bjwbell/gensimd/internal/asmtest"
+)
+
+// TestNestedPointers passes pointers to slice headers and pointers to
+// pointers, loading and storing through every level.
+func TestNestedPointers(t *testing.T) {
+	kernels := asmtest.Build(t, "testdata/pointers.go", codegen.DefaultOptions(),
+		"sum", "set", "add", "length", "total", "load2", "store2", "load3", "store3", "double", "ends")
+
+	f64 := []float64{1, 2, 3.5}
+	kernels.Call(t, "set", &f64, 1, 9.0)
+	if f64[1] != 9 {
+		t.Errorf("set(1, 9) stored %v", f64)
+	}
+	if got := kernels.Call(t, "sum", &f64)[0].(float64); got != 13.5 {
+		t.Errorf("sum = %v, expected 13.5", got)
+	}
+	i32 := []int32{4, 5}
+	kernels.Call(t, "add", &i32, int32(10))
+	if i32[0] != 14 || i32[1] != 15 {
+		t.Errorf("add(10) = %v, expected [14 15]", i32)
+	}
+	if got := kernels.Call(t, "length", &i32)[0].(int); got != 2 {
+		t.Errorf("length = %v, expected 2", got)
+	}
+	a, b := int32(2), int32(40)
+	ptrs := []*int32{&a, &b}
+	if got := kernels.Call(t, "total", &ptrs)[0].(int32); got != 42 {
+		t.Errorf("total = %v, expected 42", got)
+	}
+
+	v := int32(7)
+	pv := &v
+	if got := kernels.Call(t, "load2", &pv)[0].(int32); got != 7 {
+		t.Errorf("load2 = %v, expected 7", got)
+	}
+	kernels.Call(t, "store2", &pv, int32(11))
+	if v != 11 {
+		t.Errorf("store2(11) stored %v", v)
+	}
+	i64 := int64(3)
+	pi := &i64
+	ppi := &pi
+	if got := kernels.Call(t, "load3", &ppi)[0].(int64); got != 3 {
+		t.Errorf("load3 = %v, expected 3", got)
+	}
+	kernels.Call(t, "store3", &ppi, int64(-5))
+	if i64 != -5 {
+		t.Errorf("store3(-5) stored %v", i64)
+	}
+	f := 1.25
+	pf := &f
+	if got := kernels.Call(t, "double", &pf)[0].(float64); got != 2.5 {
+		t.Errorf("double = %v, expected 2.5", got)
+	}
+	arr := [4]float32{1, 2, 3, 4.5}
+	parr := &arr
+	if got := kernels.Call(t, "ends", &parr)[0].(float32); got != 5.5 {
+		t.Errorf("ends = %v, expected 5.5", got)
+	}
+}
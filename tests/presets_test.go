@@ -0,0 +1,42 @@
+// +build amd64,gc
+
+package tests
+
+import (
+	"math"
+	"testing"
+
+	"github.com/bjwbell/gensimd/codegen"
+	"github.com/bjwbell/gensimd/internal/asmtest"
+	"github.com/bjwbell/gensimd/simd"
+)
+
+// TestPresets generates the presets with the current generator, the
+// presets package tests the checked in assembly.
+func TestPresets(t *testing.T) {
+	kernels := asmtest.Build(t, "../presets/ref/presets.go", codegen.DefaultOptions(),
+		"Memset32", "MemcpyAligned", "ByteReverse", "SumInt64")
+
+	dst := make([]uint32, 9)
+	kernels.Call(t, "Memset32", dst[:8], uint32(7))
+	if dst[0] != 7 || dst[7] != 7 || dst[8] != 0 {
+		t.Errorf("Memset32 = %v, expected 8 7s and a 0", dst)
+	}
+
+	src := []simd.U8x16{{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}, {17}}
+	vectors := make([]simd.U8x16, 2)
+	if n := kernels.Call(t, "MemcpyAligned", vectors, src)[0].(int); n != 2 || vectors[0] != src[0] || vectors[1] != src[1] {
+		t.Errorf("MemcpyAligned = %v, %v, expected 2, %v", n, vectors, src)
+	}
+
+	b := make([]byte, 3)
+	if n := kernels.Call(t, "ByteReverse", b, []byte{1, 2, 3, 4})[0].(int); n != 3 || string(b) != "\x03\x02\x01" {
+		t.Errorf("ByteReverse = %v, %v, expected 3, [3 2 1]", n, b)
+	}
+
+	// the sum wraps like Go's
+	x := []int64{1 << 62, 1 << 62, -5, 3}
+	if got := kernels.Call(t, "SumInt64", x)[0].(int64); got != math.MaxInt64-1 {
+		t.Errorf("SumInt64(%v) = %v, expected %v", x, got, math.MaxInt64-1)
+	}
+}
@@ -0,0 +1,103 @@
+// +build amd64,gc
+
+package tests
+
+import (
+	"testing"
+
+	"github.com/bjwbell/gensimd/simd"
+)
+
+//go:generate gensimd -fn "randt0, randt1, randt2, randt3" -outfn "randt0s, randt1s, randt2s, randt3s" -f "$GOFILE" -o "rand_test_amd64.s"
+
+func randt0s(v *uint64) bool
+func randt1s(v *uint64) bool
+func randt2s(x, y simd.U64x2) simd.U64x2
+func randt3s(dst []uint64) int
+
+// RDRAND
+func randt0(v *uint64) bool {
+	return simd.RdRand64(v)
+}
+
+// RDSEED
+func randt1(v *uint64) bool {
+	return simd.RdSeed64(v)
+}
+
+// rotate left by 23 and by 64-23, and the shifts by 0 and 64
+func randt2(x, y simd.U64x2) simd.U64x2 {
+	r := simd.OrU64x2(simd.ShlU64x2(x, 23), simd.ShrU64x2(x, 64-23))
+	r = simd.XorU64x2(r, simd.OrU64x2(simd.ShlU64x2(y, 64-23), simd.ShrU64x2(y, 23)))
+	r = simd.AddU64x2(r, simd.ShlU64x2(x, 0))
+	return simd.XorU64x2(r, simd.ShrU64x2(y, 64))
+}
+
+// the number of random numbers stored
+func randt3(dst []uint64) int {
+	n := 0
+	for i := range dst {
+		if simd.RdRand64(&dst[i]) {
+			n++
+		}
+	}
+	return n
+}
+
+func TestRand(t *testing.T) {
+	xs := []simd.U64x2{{0, 1}, {0x8000000000000001, 0xffffffffffffffff}, {0x0123456789abcdef, 0xfedcba9876543210}}
+	for _, x := range xs {
+		for _, y := range xs {
+			if got, expected := randt2s(x, y), randt2(x, y); got != expected {
+				t.Errorf("randt2s(%#x, %#x) %#x != %#x", x, y, got, expected)
+			}
+		}
+	}
+	if !simd.RDRAND() {
+		t.Skip("RdRand64 needs RDRAND")
+	}
+	// the chance of 64 equal random numbers is negligible
+	var v, first uint64
+	same := true
+	for i := 0; i < 64; i++ {
+		if !randt0s(&v) {
+			t.Fatalf("randt0s(%v) failed", i)
+		}
+		if i == 0 {
+			first = v
+		}
+		same = same && v == first
+	}
+	if same {
+		t.Errorf("randt0s returned %#x 64 times", first)
+	}
+	dst := make([]uint64, 100)
+	if n := randt3s(dst); n != len(dst) {
+		t.Errorf("randt3s(%v) stored %v random numbers", len(dst), n)
+	}
+	zeros := 0
+	for _, v := range dst {
+		if v == 0 {
+			zeros++
+		}
+	}
+	if zeros > 1 {
+		t.Errorf("randt3s stored %v zeros", zeros)
+	}
+	if !simd.RDSEED() {
+		t.Skip("RdSeed64 needs RDSEED")
+	}
+	// RDSEED may run out, it's retried 10 times
+	ok := 0
+	for i := 0; i < 64; i++ {
+		v = 1
+		if randt1s(&v) {
+			ok++
+		} else if v != 0 {
+			t.Errorf("randt1s failed and stored %#x", v)
+		}
+	}
+	if ok == 0 {
+		t.Errorf("randt1s always failed")
+	}
+}
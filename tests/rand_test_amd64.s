@@ -0,0 +1,145 @@
+// Code generated by gensimd. DO NOT EDIT.
+// gensimd version: devel
+// gensimd command: gensimd -f rand_test.go -fn "randt0, randt1, randt2, randt3" -o rand_test_amd64.s -outfn "randt0s, randt1s, randt2s, randt3s"
+// gensimd source: rand_test.go sha256:6608fd74e5905b4d78b6bcc22912aa4639fded127443239c30ba7ddff8b0c5a7
+// gensimd target: avx2
+
+//go:build amd64 && !noasm && !appengine
+// +build amd64,!noasm,!appengine
+
+#include "textflag.h"
+
+TEXT ·randt0s(SB),$8-9
+block0:
+        // entry
+        MOVQ         v+0(FP), R15
+        XORQ         R11, R11
+        MOVQ         $10, R12
+lbl1:
+        RDRANDQ      R13
+        JCS          lbl2
+        DECQ         R12
+        JNE          lbl1
+lbl2:
+        SETCS        R11
+        MOVQ         R13, (R15)
+        MOVB         R11, ret+8(FP)
+        RET
+
+TEXT ·randt1s(SB),$8-9
+block0:
+        // entry
+        MOVQ         v+0(FP), R15
+        XORQ         R11, R11
+        MOVQ         $10, R12
+lbl1:
+        RDSEEDQ      R13
+        JCS          lbl2
+        PAUSE
+        DECQ         R12
+        JNE          lbl1
+lbl2:
+        SETCS        R11
+        MOVQ         R13, (R15)
+        MOVB         R11, ret+8(FP)
+        RET
+
+TEXT ·randt2s(SB),$184-48
+block0:
+        // entry
+        MOVB         $23, R15
+        MOVBQZX      R15, R13
+        MOVQ         R13, X14
+        MOVUPS       x+0(FP), X13
+        PSLLQ        X14, X13
+        MOVB         $41, R13
+        MOVBQZX      R13, R12
+        MOVQ         R12, X14
+        MOVUPS       x+0(FP), X12
+        PSRLQ        X14, X12
+        MOVO         X13, X14
+        POR          X12, X14
+        MOVBQZX      R13, R12
+        MOVQ         R12, X11
+        MOVUPS       y+16(FP), X10
+        PSLLQ        X11, X10
+        MOVBQZX      R15, R12
+        MOVQ         R12, X11
+        MOVUPS       y+16(FP), X9
+        PSRLQ        X11, X9
+        MOVO         X10, X11
+        POR          X9, X11
+        MOVO         X14, X8
+        PXOR         X11, X8
+        MOVB         $0, R12
+        MOVBQZX      R12, R11
+        MOVQ         R11, X7
+        MOVUPS       x+0(FP), X6
+        PSLLQ        X7, X6
+        MOVOU        X8, t6-112(SP)
+        PADDQ        X6, X8
+        MOVB         $64, R11
+        MOVBQZX      R11, R10
+        MOVQ         R10, X7
+        MOVUPS       y+16(FP), X5
+        PSRLQ        X7, X5
+        MOVO         X8, X7
+        PXOR         X5, X7
+        MOVUPS       X7, ret+32(FP)
+        RET
+
+TEXT ·randt3s(SB),$56-32
+block0:
+        // entry
+        MOVQ         dst_len+8(FP), R15
+        MOVQ         R15, R13
+        MOVQ         $0, R12
+        MOVQ         R12, t1-16(SP)
+        MOVQ         $-1, R11
+        MOVQ         R11, t2-24(SP)
+        MOVQ         R13, t0-8(SP)
+block1:
+        // rangeindex.loop, preds block0 block2 block4
+        MOVQ         t2-24(SP), R15
+        MOVQ         R15, R13
+        ADDQ         $1, R13
+        MOVQ         t0-8(SP), R12
+        CMPQ         R13, R12
+        MOVQ         R13, t3-32(SP)
+        JGE          block3
+block2:
+        // rangeindex.body, preds block1
+        MOVQ         t3-32(SP), R13
+        MOVQ         dst_base+0(FP), R15
+        LEAQ         (R15)(R13*8), R15
+        XORQ         R10, R10
+        MOVQ         $10, R11
+lbl1:
+        RDRANDQ      R12
+        JCS          lbl2
+        DECQ         R11
+        JNE          lbl1
+lbl2:
+        SETCS        R10
+        MOVQ         R12, (R15)
+        MOVQ         t1-16(SP), R12
+        MOVQ         R13, t2-24(SP)
+        MOVB         R10, t6-42(SP)
+        CMPB         R10, $0
+        JEQ          block1
+block4:
+        // if.then, preds block2
+        MOVQ         t1-16(SP), R15
+        MOVQ         R15, R13
+        ADDQ         $1, R13
+        MOVQ         R13, t1-16(SP)
+        MOVQ         t3-32(SP), R15
+        MOVQ         R15, t2-24(SP)
+        MOVQ         R13, t7-50(SP)
+        JMP block1
+block3:
+        // rangeindex.done, preds block1
+        MOVQ         t1-16(SP), R15
+        MOVQ         R15, ret+24(FP)
+        RET
+
@@ -0,0 +1,117 @@
+// +build amd64,gc
+
+package tests
+
+import "testing"
+
+//go:generate gensimd -fn "reductiont0, reductiont1, reductiont2, reductiont3, reductiont4, reductiont5" -outfn "reductiont0s, reductiont1s, reductiont2s, reductiont3s, reductiont4s, reductiont5s" -f "$GOFILE" -o "reduction_test_amd64.s"
+
+func reductiont0s(x, y []float32) float32
+func reductiont1s(x []int64) int64
+func reductiont2s(x []uint32) uint32
+func reductiont3s(x []int64) int64
+func reductiont4s(x []float64, limit float64) int
+func reductiont5s(x []int32) int32
+
+// a float dot product
+func reductiont0(x, y []float32) float32 {
+	s := float32(0)
+	for i := range x {
+		s += x[i] * y[i]
+	}
+	return s
+}
+
+// several accumulators of the same loop
+func reductiont1(x []int64) int64 {
+	s, p, m := int64(0), int64(1), int64(0)
+	for i := 0; i < len(x); i++ {
+		s += x[i]
+		p *= x[i] | 1
+		m = x[i] - m
+	}
+	return s ^ p ^ m
+}
+
+// the accumulator on the right of the op
+func reductiont2(x []uint32) uint32 {
+	h, a := uint32(0), uint32(0xffffffff)
+	for i := 0; i < len(x); i++ {
+		h = x[i] ^ h
+		a = x[i] & a
+	}
+	return h + a
+}
+
+// nested loops, the inner accumulator is set again each outer iteration
+func reductiont3(x []int64) int64 {
+	s := int64(0)
+	for i := 0; i < len(x); i++ {
+		t := int64(0)
+		for j := 0; j < i; j++ {
+			t += x[j]
+		}
+		s += t * x[i]
+	}
+	return s
+}
+
+// the accumulator is read by the loop condition
+func reductiont4(x []float64, limit float64) int {
+	s := 0.0
+	i := 0
+	for s < limit && i < len(x) {
+		s -= x[i]
+		i++
+	}
+	return i
+}
+
+// the accumulator is also read after the update, it isn't kept in a register
+func reductiont5(x []int32) int32 {
+	s, t := int32(0), int32(0)
+	for i := 0; i < len(x); i++ {
+		s += x[i]
+		t += s
+	}
+	return s - t
+}
+
+func TestReduction(t *testing.T) {
+	for n := 0; n < 9; n++ {
+		x32 := make([]float32, n)
+		y32 := make([]float32, n)
+		x64 := make([]int64, n)
+		u32 := make([]uint32, n)
+		f64 := make([]float64, n)
+		i32 := make([]int32, n)
+		for i := 0; i < n; i++ {
+			x32[i] = float32(i) + 0.25
+			y32[i] = float32(3 - i)
+			x64[i] = int64(i*i) - 5
+			u32[i] = uint32(i*0x9e3779b9) ^ 0xf0f0f0f0
+			f64[i] = -float64(i) - 0.5
+			i32[i] = int32(7 - 3*i)
+		}
+		if got, expected := reductiont0s(x32, y32), reductiont0(x32, y32); got != expected {
+			t.Errorf("t0 n=%v %v != %v", n, got, expected)
+		}
+		if got, expected := reductiont1s(x64), reductiont1(x64); got != expected {
+			t.Errorf("t1 n=%v %v != %v", n, got, expected)
+		}
+		if got, expected := reductiont2s(u32), reductiont2(u32); got != expected {
+			t.Errorf("t2 n=%v %v != %v", n, got, expected)
+		}
+		if got, expected := reductiont3s(x64), reductiont3(x64); got != expected {
+			t.Errorf("t3 n=%v %v != %v", n, got, expected)
+		}
+		for _, limit := range []float64{-1, 0, 2, 10} {
+			if got, expected := reductiont4s(f64, limit), reductiont4(f64, limit); got != expected {
+				t.Errorf("t4 n=%v limit=%v %v != %v", n, limit, got, expected)
+			}
+		}
+		if got, expected := reductiont5s(i32), reductiont5(i32); got != expected {
+			t.Errorf("t5 n=%v %v != %v", n, got, expected)
+		}
+	}
+}
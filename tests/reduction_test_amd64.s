@@ -0,0 +1,381 @@
+// Code generated by gensimd. DO NOT EDIT.
+// gensimd version: devel
+// gensimd command: gensimd -f reduction_test.go -fn "reductiont0, reductiont1, reductiont2, reductiont3, reductiont4, reductiont5" -o reduction_test_amd64.s -outfn "reductiont0s, reductiont1s, reductiont2s, reductiont3s, reductiont4s, reductiont5s"
+// gensimd source: reduction_test.go sha256:e2e24dfc3797e231955152c7267123d36212ea345cd9e936018d1152150e5b70
+// gensimd target: avx2
+
+//go:build amd64 && !noasm && !appengine
+// +build amd64,!noasm,!appengine
+
+#include "textflag.h"
+
+TEXT ·reductiont0s(SB),$64-52
+block0:
+        // entry
+        MOVQ         x_len+8(FP), R15
+        MOVQ         R15, R13
+        MOVQ         R13, BX
+        XORPD        X14, X14
+        MOVSS        X14, t1-12(SP)
+        MOVQ         $-1, R12
+        MOVQ         R12, t2-20(SP)
+        MOVSS        t1-12(SP), X0
+        MOVQ         R13, t0-8(SP)
+block1:
+        // rangeindex.loop, preds block0 block2
+        MOVQ         t2-20(SP), R15
+        MOVQ         R15, R13
+        ADDQ         $1, R13
+        MOVQ         BX, R12
+        CMPQ         R13, R12
+        MOVQ         R13, t3-28(SP)
+        JGE          block3
+block2:
+        // rangeindex.body, preds block1
+        MOVQ         t3-28(SP), R13
+        MOVQ         x_base+0(FP), R15
+        LEAQ         (R15)(R13*4), R15
+        MOVSS        (R15), X14
+        MOVSS        X14, t6-41(SP)
+        MOVQ         y_base+24(FP), R12
+        LEAQ         (R12)(R13*4), R12
+        MOVSS        (R12), X14
+        MOVSS        X14, t8-53(SP)
+        MOVSS        t6-41(SP), X14
+        MOVSS        t8-53(SP), X13
+        MULSS        X13, X14
+        MOVO         X0, X12
+        MOVO         X12, X11
+        ADDSS        X14, X11
+        MOVO         X11, X0
+        MOVQ         R13, t2-20(SP)
+        JMP block1
+block3:
+        // rangeindex.done, preds block1
+        MOVO         X0, X14
+        MOVSS        X14, ret+48(FP)
+        RET
+
+TEXT ·reductiont1s(SB),$144-32
+block0:
+        // entry
+        MOVQ         x_len+8(FP), R15
+        MOVQ         R15, R13
+        MOVQ         R13, R9
+        MOVQ         $0, R12
+        MOVQ         R12, t0-24(SP)
+        MOVQ         $1, R11
+        MOVQ         R11, t1-32(SP)
+        MOVQ         R12, t2-40(SP)
+        MOVQ         $0, R10
+        MOVQ         R10, t3-48(SP)
+        MOVQ         t0-24(SP), BX
+        MOVQ         t1-32(SP), R8
+        MOVQ         x_base+0(FP), BP
+        LEAQ         (BP)(R10*8), BP
+        MOVQ         BP, ivptr0-8(SP)
+        MOVQ         R13, t4-56(SP)
+block1:
+        // for.loop, preds block0 block2
+        MOVQ         t3-48(SP), R15
+        MOVQ         R9, R13
+        CMPQ         R15, R13
+        JGE          block3
+block2:
+        // for.body, preds block1
+        MOVQ         ivptr0-8(SP), R15
+        MOVQ         R15, R13
+        MOVQ         (R13), R12
+        MOVQ         R12, t7-16(SP)
+        MOVQ         BX, R12
+        MOVQ         t7-16(SP), R11
+        MOVQ         R12, R10
+        ADDQ         R11, R10
+        MOVQ         R10, BX
+        MOVQ         R15, BP
+        // ssa.UnOp, t10 = *t9, same as t7
+        MOVQ         R11, DI
+        ORQ          $1, DI
+        MOVQ         R8, SI
+        MOVQ         SI, R10
+        MOVQ         R10, AX
+        IMULQ        DI
+        MOVQ         AX, R10
+        MOVQ         R10, R8
+        MOVQ         R15, t13-105(SP)
+        // ssa.UnOp, t14 = *t13, same as t7
+        MOVQ         t2-40(SP), SI
+        SUBQ         SI, R11
+        MOVQ         t3-48(SP), SI
+        MOVQ         SI, DI
+        ADDQ         $1, DI
+        MOVQ         R11, t2-40(SP)
+        MOVQ         DI, t3-48(SP)
+        LEAQ         8(R15), R15
+        MOVQ         R15, ivptr0-8(SP)
+        MOVQ         DI, t16-121(SP)
+        MOVQ         R11, t15-113(SP)
+        JMP block1
+block3:
+        // for.done, preds block1
+        MOVQ         BX, R15
+        MOVQ         R8, R13
+        MOVQ         R13, R12
+        XORQ         R15, R12
+        MOVQ         t2-40(SP), R11
+        XORQ         R11, R12
+        MOVQ         R12, ret+24(FP)
+        RET
+
+TEXT ·reductiont2s(SB),$80-28
+block0:
+        // entry
+        MOVQ         x_len+8(FP), R15
+        MOVQ         R15, R13
+        MOVQ         R13, R9
+        MOVL         $0, R12
+        MOVL         R12, t0-16(SP)
+        MOVL         $-1, R11
+        MOVL         R11, t1-20(SP)
+        MOVQ         $0, R10
+        MOVQ         R10, t2-28(SP)
+        MOVLQZX      t0-16(SP), BX
+        MOVLQZX      t1-20(SP), R8
+        MOVQ         x_base+0(FP), BP
+        LEAQ         (BP)(R10*4), BP
+        MOVQ         BP, ivptr0-8(SP)
+        MOVQ         R13, t3-36(SP)
+block1:
+        // for.loop, preds block0 block2
+        MOVQ         t2-28(SP), R15
+        MOVQ         R9, R13
+        CMPQ         R15, R13
+        JGE          block3
+block2:
+        // for.body, preds block1
+        MOVQ         ivptr0-8(SP), R15
+        MOVQ         R15, R13
+        MOVL         (R13), R12
+        MOVL         R12, t6-12(SP)
+        MOVLQZX      t6-12(SP), R12
+        MOVL         BX, R11
+        MOVL         R11, R10
+        XORQ         R12, R10
+        MOVL         R10, BX
+        MOVQ         R15, BP
+        // ssa.UnOp, t9 = *t8, same as t6
+        MOVL         R8, R10
+        ANDL         R10, R12
+        MOVL         R12, R8
+        MOVQ         t2-28(SP), DI
+        MOVQ         DI, SI
+        ADDQ         $1, SI
+        MOVQ         SI, t2-28(SP)
+        LEAQ         4(R15), R15
+        MOVQ         R15, ivptr0-8(SP)
+        MOVQ         SI, t11-69(SP)
+        JMP block1
+block3:
+        // for.done, preds block1
+        MOVL         BX, R15
+        MOVL         R8, R13
+        MOVL         R15, R12
+        ADDL         R13, R12
+        MOVL         R12, ret+24(FP)
+        RET
+
+TEXT ·reductiont3s(SB),$136-32
+block0:
+        // entry
+        MOVQ         x_len+8(FP), R15
+        MOVQ         R15, R13
+        MOVQ         R13, R10
+        MOVQ         $0, R12
+        MOVQ         R12, t0-24(SP)
+        MOVQ         $0, R11
+        MOVQ         R11, t1-32(SP)
+        MOVQ         t0-24(SP), R8
+        MOVQ         x_base+0(FP), BP
+        LEAQ         (BP)(R11*8), BP
+        MOVQ         BP, ivptr1-16(SP)
+        MOVQ         R13, t2-40(SP)
+block1:
+        // for.loop, preds block0 block6
+        MOVQ         t1-32(SP), R9
+        MOVQ         R9, R15
+        MOVQ         R10, R13
+        CMPQ         R15, R13
+        JGE          block3
+block2:
+        // for.body, preds block1
+        MOVQ         $0, R15
+        MOVQ         R15, t4-49(SP)
+        MOVQ         $0, R13
+        MOVQ         R13, t5-57(SP)
+        MOVQ         t4-49(SP), BX
+        MOVQ         x_base+0(FP), R12
+        LEAQ         (R12)(R13*8), R12
+        MOVQ         R12, ivptr0-8(SP)
+block4:
+        // for.loop, preds block2 block5
+        MOVQ         t5-57(SP), R15
+        MOVQ         R9, R13
+        CMPQ         R15, R13
+        JGE          block6
+block5:
+        // for.body, preds block4
+        MOVQ         ivptr0-8(SP), R15
+        MOVQ         R15, R13
+        MOVQ         (R13), R12
+        MOVQ         R12, t8-74(SP)
+        MOVQ         BX, R12
+        MOVQ         t8-74(SP), R11
+        MOVQ         R12, BP
+        ADDQ         R11, BP
+        MOVQ         BP, BX
+        MOVQ         t5-57(SP), DI
+        MOVQ         DI, SI
+        ADDQ         $1, SI
+        MOVQ         SI, t5-57(SP)
+        LEAQ         8(R15), R15
+        MOVQ         R15, ivptr0-8(SP)
+        MOVQ         SI, t10-90(SP)
+        JMP block4
+block3:
+        // for.done, preds block1
+        MOVQ         R8, R15
+        MOVQ         R15, ret+24(FP)
+        RET
+block6:
+        // for.done, preds block4
+        MOVQ         ivptr1-16(SP), R15
+        MOVQ         R15, R13
+        MOVQ         (R13), R12
+        MOVQ         R12, t12-106(SP)
+        MOVQ         BX, R12
+        MOVQ         t12-106(SP), R11
+        MOVQ         R12, BP
+        MOVQ         BP, AX
+        IMULQ        R11
+        MOVQ         AX, BP
+        MOVQ         R8, DI
+        MOVQ         DI, SI
+        ADDQ         BP, SI
+        MOVQ         SI, R8
+        MOVQ         R9, SI
+        MOVQ         SI, DI
+        ADDQ         $1, DI
+        MOVQ         DI, t1-32(SP)
+        LEAQ         8(R15), R15
+        MOVQ         R15, ivptr1-16(SP)
+        MOVQ         DI, t15-130(SP)
+        JMP block1
+
+TEXT ·reductiont4s(SB),$72-40
+        MOVSD        limit+24(FP), X1
+block0:
+        // entry
+        MOVQ         x_len+8(FP), R15
+        MOVQ         R15, R13
+        MOVQ         R13, BX
+        XORPD        X14, X14
+        MOVSD        X14, t4-48(SP)
+        MOVQ         $0, R12
+        MOVQ         R12, t5-56(SP)
+        MOVSD        t4-48(SP), X0
+        MOVQ         x_base+0(FP), R11
+        LEAQ         (R11)(R12*8), R11
+        MOVQ         R11, ivptr0-8(SP)
+        MOVQ         R13, t7-65(SP)
+block3:
+        // for.loop, preds block0 block1
+        MOVO         X0, X14
+        MOVO         X1, X13
+        UCOMISD      X14, X13
+        JLS          block2
+block4:
+        // cond.true, preds block3
+        MOVQ         t5-56(SP), R15
+        MOVQ         BX, R13
+        CMPQ         R15, R13
+        JGE          block2
+block1:
+        // for.body, preds block4
+        MOVQ         ivptr0-8(SP), R15
+        MOVQ         R15, R13
+        MOVSD        (R13), X14
+        MOVSD        X14, t1-24(SP)
+        MOVO         X0, X14
+        MOVSD        t1-24(SP), X13
+        MOVO         X14, X12
+        SUBSD        X13, X12
+        MOVO         X12, X0
+        MOVQ         t5-56(SP), R12
+        MOVQ         R12, R11
+        ADDQ         $1, R11
+        MOVQ         R11, t5-56(SP)
+        LEAQ         8(R15), R15
+        MOVQ         R15, ivptr0-8(SP)
+        MOVQ         R11, t3-40(SP)
+        JMP block3
+block2:
+        // for.done, preds block3 block4
+        MOVQ         t5-56(SP), R15
+        MOVQ         R15, ret+32(FP)
+        RET
+
+TEXT ·reductiont5s(SB),$72-28
+block0:
+        // entry
+        MOVQ         x_len+8(FP), R15
+        MOVQ         R15, R13
+        MOVQ         R13, R8
+        MOVL         $0, R12
+        MOVL         R12, t0-12(SP)
+        MOVL         R12, t1-16(SP)
+        MOVQ         $0, R11
+        MOVQ         R11, t2-24(SP)
+        MOVLQZX      t1-16(SP), BX
+        MOVQ         x_base+0(FP), R10
+        LEAQ         (R10)(R11*4), R10
+        MOVQ         R10, ivptr0-8(SP)
+        MOVQ         R13, t3-32(SP)
+block1:
+        // for.loop, preds block0 block2
+        MOVQ         t2-24(SP), R15
+        MOVQ         R8, R13
+        CMPQ         R15, R13
+        JGE          block3
+block2:
+        // for.body, preds block1
+        MOVQ         ivptr0-8(SP), R15
+        MOVQ         R15, R13
+        MOVL         (R13), R12
+        MOVL         R12, t6-45(SP)
+        MOVLQZX      t0-12(SP), R12
+        MOVLQZX      t6-45(SP), R11
+        MOVL         R12, R10
+        ADDL         R11, R10
+        MOVL         BX, R9
+        MOVL         R9, R11
+        ADDL         R10, R11
+        MOVL         R11, BX
+        MOVQ         t2-24(SP), BP
+        MOVQ         BP, DI
+        ADDQ         $1, DI
+        MOVL         R10, t0-12(SP)
+        MOVQ         DI, t2-24(SP)
+        LEAQ         4(R15), R15
+        MOVQ         R15, ivptr0-8(SP)
+        MOVQ         DI, t9-61(SP)
+        MOVL         R10, t7-49(SP)
+        JMP block1
+block3:
+        // for.done, preds block1
+        MOVLQZX      t0-12(SP), R15
+        MOVL         BX, R13
+        MOVL         R15, R12
+        SUBL         R13, R12
+        MOVL         R12, ret+24(FP)
+        RET
+
@@ -0,0 +1,52 @@
+// +build amd64,gc
+
+package tests
+
+import (
+	"testing"
+
+	"github.com/bjwbell/gensimd/codegen"
+	"github.com/bjwbell/gensimd/internal/asmtest"
+)
+
+// TestScratchArrays uses local arrays as scratch space, indexing them,
+// slicing them, and passing their slices to simd loads and stores, with
+// and without bounds checks.
+func TestScratchArrays(t *testing.T) {
+	checked := codegen.DefaultOptions()
+	checked.BoundsCheck = true
+	for name, opts := range map[string]codegen.Options{"default": codegen.DefaultOptions(), "boundscheck": checked} {
+		kernels := asmtest.Build(t, "testdata/scratch.go", opts,
+			"histo", "fold", "window", "words", "ends", "swap", "fresh")
+
+		x := []byte{1, 65, 129, 3, 2, 1, 63, 127}
+		if got := kernels.Call(t, "histo", x)[0].(int32); got != 4 {
+			t.Errorf("%v: histo = %v, expected 4", name, got)
+		}
+		i32 := []int32{1, 2, 3, 4, 5, 6, 7, 8, 10, 20, 30, 40, 50, 60, 70, 80}
+		if got := kernels.Call(t, "fold", i32)[0].(int32); got != 99 {
+			t.Errorf("%v: fold = %v, expected 99", name, got)
+		}
+		for lo := 0; lo < 16; lo++ {
+			for hi := lo; hi < 16; hi++ {
+				expected := 100*(hi-lo) + (lo+hi-1)*(hi-lo)/2 + 10000*hi
+				if got := kernels.Call(t, "window", lo, hi)[0].(int); got != expected {
+					t.Errorf("%v: window(%v, %v) = %v, expected %v", name, lo, hi, got, expected)
+				}
+			}
+		}
+		b := []byte{1, 2, 3, 4, 5, 6, 7, 8, 8, 7, 6, 5, 4, 3, 2, 1}
+		if got := kernels.Call(t, "words", b)[0].(uint32); got != 0x12121212 {
+			t.Errorf("%v: words = %#x, expected 0x12121212", name, got)
+		}
+		if got := kernels.Call(t, "ends", b)[0].(byte); got != 2 {
+			t.Errorf("%v: ends = %v, expected 2", name, got)
+		}
+		if got := kernels.Call(t, "swap", uint32(0x01020304))[0].(uint32); got != 0x04030201 {
+			t.Errorf("%v: swap = %#x, expected 0x04030201", name, got)
+		}
+		if got := kernels.Call(t, "fresh", 50)[0].(int); got != 0 {
+			t.Errorf("%v: fresh = %v, expected 0", name, got)
+		}
+	}
+}
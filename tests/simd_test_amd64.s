@@ -1,388 +1,367 @@
-// +build amd64 !noasm !appengine
+// Code generated by gensimd. DO NOT EDIT.
+// gensimd version: devel
+// gensimd command: gensimd -f simd_test.go -fn "addi8x16, subi8x16, addu8x16, subu8x16, addi16x8, subi16x8, muli16x8, shli16x8, shri16x8, addu16x8, subu16x8, mulu16x8, shlu16x8, shru16x8, addi32x4, subi32x4, muli32x4, shli32x4, shri32x4, addu32x4, subu32x4, mulu32x4, shlu32x4, shru32x4, addi64x2, subi64x2, addu64x2, subu64x2, addf32x4, subf32x4, mulf32x4, divf32x4, addf64x2, subf64x2, mulf64x2, divf64x2" -o simd_test_amd64.s -outfn "addi8x16s, subi8x16s, addu8x16s, subu8x16s, addi16x8s, subi16x8s, muli16x8s, shli16x8s, shri16x8s, addu16x8s, subu16x8s, mulu16x8s, shlu16x8s, shru16x8s, addi32x4s, subi32x4s, muli32x4s, shli32x4s, shri32x4s, addu32x4s, subu32x4s, mulu32x4s, shlu32x4s, shru32x4s, addi64x2s, subi64x2s, addu64x2s, subu64x2s, addf32x4s, subf32x4s, mulf32x4s, divf32x4s, addf64x2s, subf64x2s, mulf64x2s, divf64x2s"
+// gensimd source: simd_test.go sha256:48c086a66aef9b3b82485a044181a57b3a4265398a4596ad78d2c458f1ed72b5
+// gensimd target: avx2
+
+//go:build amd64 && !noasm && !appengine
+// +build amd64,!noasm,!appengine
 
 #include "textflag.h"
 
 TEXT ·addi8x16s(SB),$24-48
-        MOVQ         $0, ret0+32(FP)
-        MOVQ         $0, ret0+40(FP)
 block0:
-        MOVOU        y+16(FP), X15
-        MOVOU        x+0(FP), X14
-        PADDB        X15, X14
-        MOVOU        X14, ret0+32(FP)
+        // entry
+        MOVUPS       y+16(FP), X14
+        MOVUPS       x+0(FP), X13
+        PADDB        X14, X13
+        MOVUPS       X13, ret+32(FP)
         RET
 
 TEXT ·subi8x16s(SB),$24-48
-        MOVQ         $0, ret0+32(FP)
-        MOVQ         $0, ret0+40(FP)
 block0:
-        MOVOU        y+16(FP), X15
-        MOVOU        x+0(FP), X14
-        PSUBB        X15, X14
-        MOVOU        X14, ret0+32(FP)
+        // entry
+        MOVUPS       y+16(FP), X14
+        MOVUPS       x+0(FP), X13
+        PSUBB        X14, X13
+        MOVUPS       X13, ret+32(FP)
         RET
 
 TEXT ·addu8x16s(SB),$24-48
-        MOVQ         $0, ret0+32(FP)
-        MOVQ         $0, ret0+40(FP)
 block0:
-        MOVOU        y+16(FP), X15
-        MOVOU        x+0(FP), X14
-        PADDB        X15, X14
-        MOVOU        X14, ret0+32(FP)
+        // entry
+        MOVUPS       y+16(FP), X14
+        MOVUPS       x+0(FP), X13
+        PADDB        X14, X13
+        MOVUPS       X13, ret+32(FP)
         RET
 
 TEXT ·subu8x16s(SB),$24-48
-        MOVQ         $0, ret0+32(FP)
-        MOVQ         $0, ret0+40(FP)
 block0:
-        MOVOU        y+16(FP), X15
-        MOVOU        x+0(FP), X14
-        PSUBB        X15, X14
-        MOVOU        X14, ret0+32(FP)
+        // entry
+        MOVUPS       y+16(FP), X14
+        MOVUPS       x+0(FP), X13
+        PSUBB        X14, X13
+        MOVUPS       X13, ret+32(FP)
         RET
 
 TEXT ·addi16x8s(SB),$24-48
-        MOVQ         $0, ret0+32(FP)
-        MOVQ         $0, ret0+40(FP)
 block0:
-        MOVOU        y+16(FP), X15
-        MOVOU        x+0(FP), X14
-        PADDW        X15, X14
-        MOVOU        X14, ret0+32(FP)
+        // entry
+        MOVUPS       y+16(FP), X14
+        MOVUPS       x+0(FP), X13
+        PADDW        X14, X13
+        MOVUPS       X13, ret+32(FP)
         RET
 
 TEXT ·subi16x8s(SB),$24-48
-        MOVQ         $0, ret0+32(FP)
-        MOVQ         $0, ret0+40(FP)
 block0:
-        MOVOU        y+16(FP), X15
-        MOVOU        x+0(FP), X14
-        PSUBW        X15, X14
-        MOVOU        X14, ret0+32(FP)
+        // entry
+        MOVUPS       y+16(FP), X14
+        MOVUPS       x+0(FP), X13
+        PSUBW        X14, X13
+        MOVUPS       X13, ret+32(FP)
         RET
 
 TEXT ·muli16x8s(SB),$24-48
-        MOVQ         $0, ret0+32(FP)
-        MOVQ         $0, ret0+40(FP)
 block0:
-        MOVOU        y+16(FP), X15
-        MOVOU        x+0(FP), X14
-        PMULLW       X15, X14
-        MOVOU        X14, ret0+32(FP)
+        // entry
+        MOVUPS       y+16(FP), X14
+        MOVUPS       x+0(FP), X13
+        PMULLW       X14, X13
+        MOVUPS       X13, ret+32(FP)
         RET
 
 TEXT ·shli16x8s(SB),$24-40
-        MOVQ         $0, ret0+24(FP)
-        MOVQ         $0, ret0+32(FP)
 block0:
+        // entry
         MOVBQZX      shift+16(FP), R15
-        MOVQ         R15, X15
-        MOVOU        x+0(FP), X14
-        PSLLW        X15, X14
-        MOVOU        X14, ret0+24(FP)
+        MOVBQZX      R15, R13
+        MOVQ         R13, X14
+        MOVUPS       x+0(FP), X13
+        PSLLW        X14, X13
+        MOVUPS       X13, ret+24(FP)
         RET
 
 TEXT ·shri16x8s(SB),$24-40
-        MOVQ         $0, ret0+24(FP)
-        MOVQ         $0, ret0+32(FP)
 block0:
+        // entry
         MOVBQZX      shift+16(FP), R15
-        MOVQ         R15, X15
-        MOVOU        x+0(FP), X14
-        PSRAW        X15, X14
-        MOVOU        X14, ret0+24(FP)
+        MOVBQZX      R15, R13
+        MOVQ         R13, X14
+        MOVUPS       x+0(FP), X13
+        PSRAW        X14, X13
+        MOVUPS       X13, ret+24(FP)
         RET
 
 TEXT ·addu16x8s(SB),$24-48
-        MOVQ         $0, ret0+32(FP)
-        MOVQ         $0, ret0+40(FP)
 block0:
-        MOVOU        y+16(FP), X15
-        MOVOU        x+0(FP), X14
-        PADDW        X15, X14
-        MOVOU        X14, ret0+32(FP)
+        // entry
+        MOVUPS       y+16(FP), X14
+        MOVUPS       x+0(FP), X13
+        PADDW        X14, X13
+        MOVUPS       X13, ret+32(FP)
         RET
 
 TEXT ·subu16x8s(SB),$24-48
-        MOVQ         $0, ret0+32(FP)
-        MOVQ         $0, ret0+40(FP)
 block0:
-        MOVOU        y+16(FP), X15
-        MOVOU        x+0(FP), X14
-        PSUBW        X15, X14
-        MOVOU        X14, ret0+32(FP)
+        // entry
+        MOVUPS       y+16(FP), X14
+        MOVUPS       x+0(FP), X13
+        PSUBW        X14, X13
+        MOVUPS       X13, ret+32(FP)
         RET
 
 TEXT ·mulu16x8s(SB),$24-48
-        MOVQ         $0, ret0+32(FP)
-        MOVQ         $0, ret0+40(FP)
 block0:
-        MOVOU        y+16(FP), X15
-        MOVOU        x+0(FP), X14
-        PMULLW       X15, X14
-        MOVOU        X14, ret0+32(FP)
+        // entry
+        MOVUPS       y+16(FP), X14
+        MOVUPS       x+0(FP), X13
+        PMULLW       X14, X13
+        MOVUPS       X13, ret+32(FP)
         RET
 
 TEXT ·shlu16x8s(SB),$24-40
-        MOVQ         $0, ret0+24(FP)
-        MOVQ         $0, ret0+32(FP)
 block0:
+        // entry
         MOVBQZX      shift+16(FP), R15
-        MOVQ         R15, X15
-        MOVOU        x+0(FP), X14
-        PSLLW        X15, X14
-        MOVOU        X14, ret0+24(FP)
+        MOVBQZX      R15, R13
+        MOVQ         R13, X14
+        MOVUPS       x+0(FP), X13
+        PSLLW        X14, X13
+        MOVUPS       X13, ret+24(FP)
         RET
 
 TEXT ·shru16x8s(SB),$24-40
-        MOVQ         $0, ret0+24(FP)
-        MOVQ         $0, ret0+32(FP)
 block0:
+        // entry
         MOVBQZX      shift+16(FP), R15
-        MOVQ         R15, X15
-        MOVOU        x+0(FP), X14
-        PSRLW        X15, X14
-        MOVOU        X14, ret0+24(FP)
+        MOVBQZX      R15, R13
+        MOVQ         R13, X14
+        MOVUPS       x+0(FP), X13
+        PSRLW        X14, X13
+        MOVUPS       X13, ret+24(FP)
         RET
 
 TEXT ·addi32x4s(SB),$24-48
-        MOVQ         $0, ret0+32(FP)
-        MOVQ         $0, ret0+40(FP)
 block0:
-        MOVOU        y+16(FP), X15
-        MOVOU        x+0(FP), X14
-        PADDL        X15, X14
-        MOVOU        X14, ret0+32(FP)
+        // entry
+        MOVUPS       y+16(FP), X14
+        MOVUPS       x+0(FP), X13
+        PADDL        X14, X13
+        MOVUPS       X13, ret+32(FP)
         RET
 
 TEXT ·subi32x4s(SB),$24-48
-        MOVQ         $0, ret0+32(FP)
-        MOVQ         $0, ret0+40(FP)
 block0:
-        MOVOU        y+16(FP), X15
-        MOVOU        x+0(FP), X14
-        PSUBL        X15, X14
-        MOVOU        X14, ret0+32(FP)
+        // entry
+        MOVUPS       y+16(FP), X14
+        MOVUPS       x+0(FP), X13
+        PSUBL        X14, X13
+        MOVUPS       X13, ret+32(FP)
         RET
 
 TEXT ·muli32x4s(SB),$24-48
-        MOVQ         $0, ret0+32(FP)
-        MOVQ         $0, ret0+40(FP)
-block0:
-        MOVOU        x+0(FP), X14
-        MOVOU        y+16(FP), X13
-        MOVO         X13, X15
-        PMULULQ      X14, X15
-        PSRLO        $4, X14
+block0:
+        // entry
+        MOVUPS       x+0(FP), X13
+        MOVUPS       y+16(FP), X12
+        MOVO         X12, X14
+        PMULULQ      X13, X14
         PSRLO        $4, X13
-        MOVO         X13, X12
-        PMULULQ      X14, X12
-        PSHUFD       $8, X15, X11
-        PSHUFD       $8, X12, X10
-        PUNPCKLLQ    X10, X11
-        MOVOU        X11, ret0+32(FP)
+        PSRLO        $4, X12
+        MOVO         X12, X11
+        PMULULQ      X13, X11
+        PSHUFD       $8, X14, X10
+        PSHUFD       $8, X11, X9
+        PUNPCKLLQ    X9, X10
+        MOVUPS       X10, ret+32(FP)
         RET
 
 TEXT ·shli32x4s(SB),$24-40
-        MOVQ         $0, ret0+24(FP)
-        MOVQ         $0, ret0+32(FP)
 block0:
+        // entry
         MOVBQZX      shift+16(FP), R15
-        MOVQ         R15, X15
-        MOVOU        x+0(FP), X14
-        PSLLL        X15, X14
-        MOVOU        X14, ret0+24(FP)
+        MOVBQZX      R15, R13
+        MOVQ         R13, X14
+        MOVUPS       x+0(FP), X13
+        PSLLL        X14, X13
+        MOVUPS       X13, ret+24(FP)
         RET
 
 TEXT ·shri32x4s(SB),$24-40
-        MOVQ         $0, ret0+24(FP)
-        MOVQ         $0, ret0+32(FP)
 block0:
+        // entry
         MOVBQZX      shift+16(FP), R15
-        MOVQ         R15, X15
-        MOVOU        x+0(FP), X14
-        PSRAL        X15, X14
-        MOVOU        X14, ret0+24(FP)
+        MOVBQZX      R15, R13
+        MOVQ         R13, X14
+        MOVUPS       x+0(FP), X13
+        PSRAL        X14, X13
+        MOVUPS       X13, ret+24(FP)
         RET
 
 TEXT ·addu32x4s(SB),$24-48
-        MOVQ         $0, ret0+32(FP)
-        MOVQ         $0, ret0+40(FP)
 block0:
-        MOVOU        y+16(FP), X15
-        MOVOU        x+0(FP), X14
-        PADDL        X15, X14
-        MOVOU        X14, ret0+32(FP)
+        // entry
+        MOVUPS       y+16(FP), X14
+        MOVUPS       x+0(FP), X13
+        PADDL        X14, X13
+        MOVUPS       X13, ret+32(FP)
         RET
 
 TEXT ·subu32x4s(SB),$24-48
-        MOVQ         $0, ret0+32(FP)
-        MOVQ         $0, ret0+40(FP)
 block0:
-        MOVOU        y+16(FP), X15
-        MOVOU        x+0(FP), X14
-        PSUBL        X15, X14
-        MOVOU        X14, ret0+32(FP)
+        // entry
+        MOVUPS       y+16(FP), X14
+        MOVUPS       x+0(FP), X13
+        PSUBL        X14, X13
+        MOVUPS       X13, ret+32(FP)
         RET
 
 TEXT ·mulu32x4s(SB),$24-48
-        MOVQ         $0, ret0+32(FP)
-        MOVQ         $0, ret0+40(FP)
-block0:
-        MOVOU        x+0(FP), X14
-        MOVOU        y+16(FP), X13
-        MOVO         X13, X15
-        PMULULQ      X14, X15
-        PSRLO        $4, X14
+block0:
+        // entry
+        MOVUPS       x+0(FP), X13
+        MOVUPS       y+16(FP), X12
+        MOVO         X12, X14
+        PMULULQ      X13, X14
         PSRLO        $4, X13
-        MOVO         X13, X12
-        PMULULQ      X14, X12
-        PSHUFD       $8, X15, X11
-        PSHUFD       $8, X12, X10
-        PUNPCKLLQ    X10, X11
-        MOVOU        X11, ret0+32(FP)
+        PSRLO        $4, X12
+        MOVO         X12, X11
+        PMULULQ      X13, X11
+        PSHUFD       $8, X14, X10
+        PSHUFD       $8, X11, X9
+        PUNPCKLLQ    X9, X10
+        MOVUPS       X10, ret+32(FP)
         RET
 
 TEXT ·shlu32x4s(SB),$24-40
-        MOVQ         $0, ret0+24(FP)
-        MOVQ         $0, ret0+32(FP)
 block0:
+        // entry
         MOVBQZX      shift+16(FP), R15
-        MOVQ         R15, X15
-        MOVOU        x+0(FP), X14
-        PSLLL        X15, X14
-        MOVOU        X14, ret0+24(FP)
+        MOVBQZX      R15, R13
+        MOVQ         R13, X14
+        MOVUPS       x+0(FP), X13
+        PSLLL        X14, X13
+        MOVUPS       X13, ret+24(FP)
         RET
 
 TEXT ·shru32x4s(SB),$24-40
-        MOVQ         $0, ret0+24(FP)
-        MOVQ         $0, ret0+32(FP)
 block0:
+        // entry
         MOVBQZX      shift+16(FP), R15
-        MOVQ         R15, X15
-        MOVOU        x+0(FP), X14
-        PSRLL        X15, X14
-        MOVOU        X14, ret0+24(FP)
+        MOVBQZX      R15, R13
+        MOVQ         R13, X14
+        MOVUPS       x+0(FP), X13
+        PSRLL        X14, X13
+        MOVUPS       X13, ret+24(FP)
         RET
 
 TEXT ·addi64x2s(SB),$24-48
-        MOVQ         $0, ret0+32(FP)
-        MOVQ         $0, ret0+40(FP)
 block0:
-        MOVOU        y+16(FP), X15
-        MOVOU        x+0(FP), X14
-        PADDQ        X15, X14
-        MOVOU        X14, ret0+32(FP)
+        // entry
+        MOVUPS       y+16(FP), X14
+        MOVUPS       x+0(FP), X13
+        PADDQ        X14, X13
+        MOVUPS       X13, ret+32(FP)
         RET
 
 TEXT ·subi64x2s(SB),$24-48
-        MOVQ         $0, ret0+32(FP)
-        MOVQ         $0, ret0+40(FP)
 block0:
-        MOVOU        y+16(FP), X15
-        MOVOU        x+0(FP), X14
-        PSUBQ        X15, X14
-        MOVOU        X14, ret0+32(FP)
+        // entry
+        MOVUPS       y+16(FP), X14
+        MOVUPS       x+0(FP), X13
+        PSUBQ        X14, X13
+        MOVUPS       X13, ret+32(FP)
         RET
 
 TEXT ·addu64x2s(SB),$24-48
-        MOVQ         $0, ret0+32(FP)
-        MOVQ         $0, ret0+40(FP)
 block0:
-        MOVOU        y+16(FP), X15
-        MOVOU        x+0(FP), X14
-        PADDQ        X15, X14
-        MOVOU        X14, ret0+32(FP)
+        // entry
+        MOVUPS       y+16(FP), X14
+        MOVUPS       x+0(FP), X13
+        PADDQ        X14, X13
+        MOVUPS       X13, ret+32(FP)
         RET
 
 TEXT ·subu64x2s(SB),$24-48
-        MOVQ         $0, ret0+32(FP)
-        MOVQ         $0, ret0+40(FP)
 block0:
-        MOVOU        y+16(FP), X15
-        MOVOU        x+0(FP), X14
-        PSUBQ        X15, X14
-        MOVOU        X14, ret0+32(FP)
+        // entry
+        MOVUPS       y+16(FP), X14
+        MOVUPS       x+0(FP), X13
+        PSUBQ        X14, X13
+        MOVUPS       X13, ret+32(FP)
         RET
 
 TEXT ·addf32x4s(SB),$24-48
-        MOVQ         $0, ret0+32(FP)
-        MOVQ         $0, ret0+40(FP)
 block0:
-        MOVUPS       y+16(FP), X15
-        MOVUPS       x+0(FP), X14
-        ADDPS        X15, X14
-        MOVUPS       X14, ret0+32(FP)
+        // entry
+        MOVUPS       y+16(FP), X14
+        MOVUPS       x+0(FP), X13
+        ADDPS        X14, X13
+        MOVUPS       X13, ret+32(FP)
         RET
 
 TEXT ·subf32x4s(SB),$24-48
-        MOVQ         $0, ret0+32(FP)
-        MOVQ         $0, ret0+40(FP)
 block0:
-        MOVUPS       y+16(FP), X15
-        MOVUPS       x+0(FP), X14
-        SUBPS        X15, X14
-        MOVUPS       X14, ret0+32(FP)
+        // entry
+        MOVUPS       y+16(FP), X14
+        MOVUPS       x+0(FP), X13
+        SUBPS        X14, X13
+        MOVUPS       X13, ret+32(FP)
         RET
 
 TEXT ·mulf32x4s(SB),$24-48
-        MOVQ         $0, ret0+32(FP)
-        MOVQ         $0, ret0+40(FP)
 block0:
-        MOVUPS       y+16(FP), X15
-        MOVUPS       x+0(FP), X14
-        MULPS        X15, X14
-        MOVUPS       X14, ret0+32(FP)
+        // entry
+        MOVUPS       y+16(FP), X14
+        MOVUPS       x+0(FP), X13
+        MULPS        X14, X13
+        MOVUPS       X13, ret+32(FP)
         RET
 
 TEXT ·divf32x4s(SB),$24-48
-        MOVQ         $0, ret0+32(FP)
-        MOVQ         $0, ret0+40(FP)
 block0:
-        MOVUPS       y+16(FP), X15
-        MOVUPS       x+0(FP), X14
-        DIVPS        X15, X14
-        MOVUPS       X14, ret0+32(FP)
+        // entry
+        MOVUPS       y+16(FP), X14
+        MOVUPS       x+0(FP), X13
+        DIVPS        X14, X13
+        MOVUPS       X13, ret+32(FP)
         RET
 
 TEXT ·addf64x2s(SB),$24-48
-        MOVQ         $0, ret0+32(FP)
-        MOVQ         $0, ret0+40(FP)
 block0:
-        MOVUPD       y+16(FP), X15
-        MOVUPD       x+0(FP), X14
-        ADDPD        X15, X14
-        MOVUPD       X14, ret0+32(FP)
+        // entry
+        MOVUPS       y+16(FP), X14
+        MOVUPS       x+0(FP), X13
+        ADDPD        X14, X13
+        MOVUPS       X13, ret+32(FP)
         RET
 
 TEXT ·subf64x2s(SB),$24-48
-        MOVQ         $0, ret0+32(FP)
-        MOVQ         $0, ret0+40(FP)
 block0:
-        MOVUPD       y+16(FP), X15
-        MOVUPD       x+0(FP), X14
-        SUBPD        X15, X14
-        MOVUPD       X14, ret0+32(FP)
+        // entry
+        MOVUPS       y+16(FP), X14
+        MOVUPS       x+0(FP), X13
+        SUBPD        X14, X13
+        MOVUPS       X13, ret+32(FP)
         RET
 
 TEXT ·mulf64x2s(SB),$24-48
-        MOVQ         $0, ret0+32(FP)
-        MOVQ         $0, ret0+40(FP)
 block0:
-        MOVUPD       y+16(FP), X15
-        MOVUPD       x+0(FP), X14
-        MULPD        X15, X14
-        MOVUPD       X14, ret0+32(FP)
+        // entry
+        MOVUPS       y+16(FP), X14
+        MOVUPS       x+0(FP), X13
+        MULPD        X14, X13
+        MOVUPS       X13, ret+32(FP)
         RET
 
 TEXT ·divf64x2s(SB),$24-48
-        MOVQ         $0, ret0+32(FP)
-        MOVQ         $0, ret0+40(FP)
 block0:
-        MOVUPD       y+16(FP), X15
-        MOVUPD       x+0(FP), X14
-        DIVPD        X15, X14
-        MOVUPD       X14, ret0+32(FP)
+        // entry
+        MOVUPS       y+16(FP), X14
+        MOVUPS       x+0(FP), X13
+        DIVPD        X14, X13
+        MOVUPS       X13, ret+32(FP)
         RET
 
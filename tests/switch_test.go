@@ -0,0 +1,156 @@
+// +build amd64,gc
+
+package tests
+
+import "testing"
+
+//go:generate gensimd -fn "switcht0, switcht1, switcht2, switcht3, switcht4" -outfn "switcht0s, switcht1s, switcht2s, switcht3s, switcht4s" -f "$GOFILE" -o "switch_test_amd64.s"
+
+func switcht0s(x int) int
+func switcht1s(x int8) int32
+func switcht2s(b []byte) int
+func switcht3s(x uint32) uint32
+func switcht4s(x uint64) int
+
+// a jump table with a phi set on each edge
+func switcht0(x int) int {
+	v := 0
+	switch x {
+	case 0:
+		v = 10
+	case 1:
+		v = 11
+	case 2, 3:
+		v = 23
+	case 5:
+		v = 15
+	case 6:
+		v = 16
+	case 8:
+		v = 18
+	case 9:
+		v = 19
+	case 10:
+		v = 20
+	default:
+		v = -1
+	}
+	return v
+}
+
+// negative cases and returns from the cases
+func switcht1(x int8) int32 {
+	switch x {
+	case -3:
+		return 1
+	case -2:
+		return 2
+	case -1:
+		return 3
+	case 0:
+		return 4
+	case 1:
+		return 5
+	case 2:
+		return 6
+	case 4:
+		return 7
+	case 5:
+		return 8
+	}
+	return 0
+}
+
+// a byte classifier counting hex letters, digits, and colons
+func switcht2(b []byte) int {
+	n := 0
+	for i := 0; i < len(b); i++ {
+		switch b[i] {
+		case 'a', 'b', 'c', 'd', 'e', 'f', 'A', 'B', 'C', 'D', 'E', 'F':
+			n += 1
+		case '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
+			n += 100
+		case ':':
+			n += 10000
+		}
+	}
+	return n
+}
+
+func switcht3(x uint32) uint32 {
+	y := x
+	switch x {
+	case 100:
+		y = x * 2
+	case 101:
+		y = x + 7
+	case 102:
+		y = x - 3
+	case 103:
+		y = 0
+	case 104:
+		y = x << 3
+	case 105:
+		y = x >> 1
+	case 106:
+		y = x | 1
+	case 107:
+		y = 1
+	}
+	return y
+}
+
+// too sparse for a jump table
+func switcht4(x uint64) int {
+	switch x {
+	case 1:
+		return 1
+	case 1000:
+		return 2
+	case 1 << 40:
+		return 3
+	case 1<<64 - 1:
+		return 4
+	case 2:
+		return 5
+	case 3:
+		return 6
+	case 4:
+		return 7
+	case 5:
+		return 8
+	}
+	return 0
+}
+
+func TestSwitch(t *testing.T) {
+	for x := -300; x < 300; x++ {
+		if got, want := switcht0s(x), switcht0(x); got != want {
+			t.Errorf("switcht0s(%v) %v != %v", x, got, want)
+		}
+		if got, want := switcht1s(int8(x)), switcht1(int8(x)); got != want {
+			t.Errorf("switcht1s(%v) %v != %v", int8(x), got, want)
+		}
+		if got, want := switcht3s(uint32(x)), switcht3(uint32(x)); got != want {
+			t.Errorf("switcht3s(%v) %v != %v", uint32(x), got, want)
+		}
+	}
+	for _, x := range []uint64{0, 1, 2, 3, 4, 5, 6, 999, 1000, 1 << 40, 1<<64 - 1, 1<<64 - 2} {
+		if got, want := switcht4s(x), switcht4(x); got != want {
+			t.Errorf("switcht4s(%v) %v != %v", x, got, want)
+		}
+	}
+	b := []byte("fe80::1ff:FE23:4567:890a, G:@`/")
+	for n := 0; n <= len(b); n++ {
+		if got, want := switcht2s(b[:n]), switcht2(b[:n]); got != want {
+			t.Errorf("switcht2s(%q) %v != %v", b[:n], got, want)
+		}
+	}
+	all := make([]byte, 256)
+	for i := range all {
+		all[i] = byte(i)
+	}
+	if got, want := switcht2s(all), switcht2(all); got != want {
+		t.Errorf("switcht2s(all bytes) %v != %v", got, want)
+	}
+}
@@ -0,0 +1,518 @@
+// Code generated by gensimd. DO NOT EDIT.
+// gensimd version: devel
+// gensimd command: gensimd -f switch_test.go -fn "switcht0, switcht1, switcht2, switcht3, switcht4" -o switch_test_amd64.s -outfn "switcht0s, switcht1s, switcht2s, switcht3s, switcht4s"
+// gensimd source: switch_test.go sha256:fa08052030426b5d4e1ae234ae7d13d3d906a800cf54e902fa0fa5f821e5432a
+// gensimd target: avx2
+
+//go:build amd64 && !noasm && !appengine
+// +build amd64,!noasm,!appengine
+
+#include "textflag.h"
+
+TEXT ·switcht0s(SB),$24-16
+block0:
+        // entry
+        // ssa.BinOp, t0 = x == 0:int, compared by the jump table
+        MOVQ         x+0(FP), R15
+        MOVQ         R15, R13
+        CMPQ         R13, $11
+        JCC          block18
+        LEAQ         switcht0s_switch0<>(SB), R15
+        MOVBQZX      (R15)(R13*1), R13
+        MOVQ         R13, 0(SP)
+        CALL         switcht0s_jumptable<>(SB)
+        PCALIGN      $8
+        JMP          block2
+        PCALIGN      $8
+        JMP          block3
+        PCALIGN      $8
+        JMP          block5
+        PCALIGN      $8
+        JMP          block18
+        PCALIGN      $8
+        JMP          block7
+        PCALIGN      $8
+        JMP          block10
+        PCALIGN      $8
+        JMP          block12
+        PCALIGN      $8
+        JMP          block14
+        PCALIGN      $8
+        JMP          block16
+block2:
+        // switch.body, preds block0
+        MOVQ         $10, R15
+        MOVQ         R15, t1-8(SP)
+block1:
+        // switch.done, preds block2 block3 block5 block7 block10 block12 block14 block16 block18
+        MOVQ         t1-8(SP), R15
+        MOVQ         R15, ret+8(FP)
+        RET
+block3:
+        // switch.body, preds block4
+        MOVQ         $11, R15
+        MOVQ         R15, t1-8(SP)
+        JMP block1
+block5:
+        // switch.body, preds block6 block8
+        MOVQ         $23, R15
+        MOVQ         R15, t1-8(SP)
+        JMP block1
+block7:
+        // switch.body, preds block9
+        MOVQ         $15, R15
+        MOVQ         R15, t1-8(SP)
+        JMP block1
+block10:
+        // switch.body, preds block11
+        MOVQ         $16, R15
+        MOVQ         R15, t1-8(SP)
+        JMP block1
+block12:
+        // switch.body, preds block13
+        MOVQ         $18, R15
+        MOVQ         R15, t1-8(SP)
+        JMP block1
+block14:
+        // switch.body, preds block15
+        MOVQ         $19, R15
+        MOVQ         R15, t1-8(SP)
+        JMP block1
+block16:
+        // switch.body, preds block17
+        MOVQ         $20, R15
+        MOVQ         R15, t1-8(SP)
+        JMP block1
+block18:
+        // switch.next, preds block17
+        MOVQ         $-1, R15
+        MOVQ         R15, t1-8(SP)
+        JMP block1
+
+
+DATA switcht0s_switch0<>+0(SB)/8, $0x0305040302020100
+DATA switcht0s_switch0<>+8(SB)/2, $0x0706
+DATA switcht0s_switch0<>+10(SB)/1, $0x08
+GLOBL switcht0s_switch0<>(SB), RODATA|NOPTR, $11
+
+TEXT switcht0s_jumptable<>(SB),NOSPLIT|NOFRAME,$0-0
+        PUSHQ        AX
+        MOVQ         8(SP), AX
+        ADDQ         $7, AX
+        ANDQ         $-8, AX
+        MOVQ         AX, 8(SP)
+        MOVQ         16(SP), AX
+        SHLQ         $3, AX
+        ADDQ         AX, 8(SP)
+        POPQ         AX
+        RET
+
+TEXT ·switcht1s(SB),$16-12
+block0:
+        // entry
+        // ssa.BinOp, t0 = x == -3:int8, compared by the jump table
+        MOVBQZX      x+0(FP), R15
+        MOVBQSX      R15, R13
+        SUBQ         $-3, R13
+        CMPQ         R13, $9
+        JCC          block16
+        LEAQ         switcht1s_switch0<>(SB), R15
+        MOVBQZX      (R15)(R13*1), R13
+        MOVQ         R13, 0(SP)
+        CALL         switcht1s_jumptable<>(SB)
+        PCALIGN      $8
+        JMP          block1
+        PCALIGN      $8
+        JMP          block2
+        PCALIGN      $8
+        JMP          block4
+        PCALIGN      $8
+        JMP          block6
+        PCALIGN      $8
+        JMP          block8
+        PCALIGN      $8
+        JMP          block10
+        PCALIGN      $8
+        JMP          block16
+        PCALIGN      $8
+        JMP          block12
+        PCALIGN      $8
+        JMP          block14
+block1:
+        // switch.body, preds block0
+        MOVL         $1, R15
+        MOVL         R15, ret+8(FP)
+        RET
+block2:
+        // switch.body, preds block3
+        MOVL         $2, R13
+        MOVL         R13, ret+8(FP)
+        RET
+block4:
+        // switch.body, preds block5
+        MOVL         $3, R12
+        MOVL         R12, ret+8(FP)
+        RET
+block6:
+        // switch.body, preds block7
+        MOVL         $4, R11
+        MOVL         R11, ret+8(FP)
+        RET
+block8:
+        // switch.body, preds block9
+        MOVL         $5, R10
+        MOVL         R10, ret+8(FP)
+        RET
+block10:
+        // switch.body, preds block11
+        MOVL         $6, R9
+        MOVL         R9, ret+8(FP)
+        RET
+block12:
+        // switch.body, preds block13
+        MOVL         $7, R8
+        MOVL         R8, ret+8(FP)
+        RET
+block14:
+        // switch.body, preds block15
+        MOVL         $8, R8
+        MOVL         R8, ret+8(FP)
+        RET
+block16:
+        // switch.next, preds block15
+        MOVL         $0, R8
+        MOVL         R8, ret+8(FP)
+        RET
+
+
+DATA switcht1s_switch0<>+0(SB)/8, $0x0706050403020100
+DATA switcht1s_switch0<>+8(SB)/1, $0x08
+GLOBL switcht1s_switch0<>(SB), RODATA|NOPTR, $9
+
+TEXT switcht1s_jumptable<>(SB),NOSPLIT|NOFRAME,$0-0
+        PUSHQ        AX
+        MOVQ         8(SP), AX
+        ADDQ         $7, AX
+        ANDQ         $-8, AX
+        MOVQ         AX, 8(SP)
+        MOVQ         16(SP), AX
+        SHLQ         $3, AX
+        ADDQ         AX, 8(SP)
+        POPQ         AX
+        RET
+
+TEXT ·switcht2s(SB),$96-32
+block0:
+        // entry
+        MOVQ         b_len+8(FP), R15
+        MOVQ         R15, R13
+        MOVQ         R13, BX
+        MOVQ         $0, R12
+        MOVQ         R12, t0-16(SP)
+        MOVQ         R12, t1-24(SP)
+        MOVQ         b_base+0(FP), R11
+        LEAQ         (R11)(R12*1), R11
+        MOVQ         R11, ivptr0-8(SP)
+        MOVQ         R13, t2-32(SP)
+block1:
+        // for.loop, preds block0 block4
+        MOVQ         t1-24(SP), R15
+        MOVQ         BX, R13
+        CMPQ         R15, R13
+        JGE          block3
+block2:
+        // for.body, preds block1
+        MOVQ         ivptr0-8(SP), R15
+        MOVQ         R15, R13
+        MOVB         (R13), R12
+        MOVB         R12, t5-42(SP)
+        // ssa.BinOp, t6 = t5 == 97:byte, compared by the jump table
+        MOVBQZX      t5-42(SP), R15
+        MOVBQZX      R15, R13
+        SUBQ         $48, R13
+        CMPQ         R13, $55
+        JCC          lbl1
+        LEAQ         switcht2s_switch0<>(SB), R15
+        MOVBQZX      (R15)(R13*1), R13
+        MOVQ         R13, 0(SP)
+        CALL         switcht2s_jumptable<>(SB)
+        PCALIGN      $8
+        JMP          block6
+        PCALIGN      $8
+        JMP          block19
+        PCALIGN      $8
+        JMP          lbl1
+        PCALIGN      $8
+        JMP          block5
+lbl1:
+        MOVQ         t0-16(SP), R12
+        MOVQ         R12, t7-50(SP)
+        JMP          block4
+block5:
+        // switch.body, preds block2 block7 block8 block9 block10 block11 block12 block13 block14 block15 block16 block17
+        MOVQ         t0-16(SP), R15
+        MOVQ         R15, R13
+        ADDQ         $1, R13
+        MOVQ         R13, t7-50(SP)
+        MOVQ         R13, t9-66(SP)
+block4:
+        // switch.done, preds block5 block6 block19 block29
+        MOVQ         t1-24(SP), R15
+        MOVQ         R15, R13
+        ADDQ         $1, R13
+        MOVQ         t7-50(SP), R12
+        MOVQ         R12, t0-16(SP)
+        MOVQ         R13, t1-24(SP)
+        MOVQ         ivptr0-8(SP), R15
+        LEAQ         1(R15), R15
+        MOVQ         R15, ivptr0-8(SP)
+        MOVQ         R13, t8-58(SP)
+        JMP block1
+block3:
+        // for.done, preds block1
+        MOVQ         t0-16(SP), R15
+        MOVQ         R15, ret+24(FP)
+        RET
+block6:
+        // switch.body, preds block18 block20 block21 block22 block23 block24 block25 block26 block27 block28
+        MOVQ         t0-16(SP), R15
+        MOVQ         R15, R13
+        ADDQ         $100, R13
+        MOVQ         R13, t7-50(SP)
+        MOVQ         R13, t10-74(SP)
+        JMP block4
+block19:
+        // switch.body, preds block29
+        MOVQ         t0-16(SP), R15
+        MOVQ         R15, R13
+        ADDQ         $10000, R13
+        MOVQ         R13, t7-50(SP)
+        MOVQ         R13, t23-82(SP)
+        JMP block4
+
+
+DATA switcht2s_switch0<>+0(SB)/8, $0x0000000000000000
+DATA switcht2s_switch0<>+8(SB)/8, $0x0202020202010000
+DATA switcht2s_switch0<>+16(SB)/8, $0x0203030303030302
+DATA switcht2s_switch0<>+24(SB)/8, $0x0202020202020202
+DATA switcht2s_switch0<>+32(SB)/8, $0x0202020202020202
+DATA switcht2s_switch0<>+40(SB)/8, $0x0202020202020202
+DATA switcht2s_switch0<>+48(SB)/4, $0x03030302
+DATA switcht2s_switch0<>+52(SB)/2, $0x0303
+DATA switcht2s_switch0<>+54(SB)/1, $0x03
+GLOBL switcht2s_switch0<>(SB), RODATA|NOPTR, $55
+
+TEXT switcht2s_jumptable<>(SB),NOSPLIT|NOFRAME,$0-0
+        PUSHQ        AX
+        MOVQ         8(SP), AX
+        ADDQ         $7, AX
+        ANDQ         $-8, AX
+        MOVQ         AX, 8(SP)
+        MOVQ         16(SP), AX
+        SHLQ         $3, AX
+        ADDQ         AX, 8(SP)
+        POPQ         AX
+        RET
+
+TEXT ·switcht3s(SB),$40-12
+block0:
+        // entry
+        // ssa.BinOp, t0 = x == 100:uint32, compared by the jump table
+        MOVLQZX      x+0(FP), R15
+        MOVLQZX      R15, R13
+        SUBQ         $100, R13
+        CMPQ         R13, $8
+        JCC          lbl1
+        LEAQ         switcht3s_switch0<>(SB), R15
+        MOVBQZX      (R15)(R13*1), R13
+        MOVQ         R13, 0(SP)
+        CALL         switcht3s_jumptable<>(SB)
+        PCALIGN      $8
+        JMP          block2
+        PCALIGN      $8
+        JMP          block3
+        PCALIGN      $8
+        JMP          block5
+        PCALIGN      $8
+        JMP          block7
+        PCALIGN      $8
+        JMP          block9
+        PCALIGN      $8
+        JMP          block11
+        PCALIGN      $8
+        JMP          block13
+        PCALIGN      $8
+        JMP          block15
+lbl1:
+        MOVL         R15, t1-4(SP)
+        JMP          block1
+block2:
+        // switch.body, preds block0
+        MOVLQZX      x+0(FP), R15
+        MOVL         $2, R13
+        MOVL         R15, R12
+        MOVL         R12, AX
+        MULL         R13
+        MOVL         AX, R12
+        MOVL         R12, t1-4(SP)
+        MOVL         R12, t2-8(SP)
+block1:
+        // switch.done, preds block2 block3 block5 block7 block9 block11 block13 block15 block16
+        MOVLQZX      t1-4(SP), R15
+        MOVL         R15, ret+8(FP)
+        RET
+block3:
+        // switch.body, preds block4
+        MOVLQZX      x+0(FP), R15
+        MOVL         R15, R13
+        ADDL         $7, R13
+        MOVL         R13, t1-4(SP)
+        MOVL         R13, t3-12(SP)
+        JMP block1
+block5:
+        // switch.body, preds block6
+        MOVLQZX      x+0(FP), R15
+        MOVL         R15, R13
+        SUBL         $3, R13
+        MOVL         R13, t1-4(SP)
+        MOVL         R13, t5-16(SP)
+        JMP block1
+block7:
+        // switch.body, preds block8
+        MOVL         $0, R15
+        MOVL         R15, t1-4(SP)
+        JMP block1
+block9:
+        // switch.body, preds block10
+        MOVLQZX      x+0(FP), R15
+        MOVL         R15, R13
+        SHLL         $3, R13
+        MOVL         R13, t1-4(SP)
+        MOVL         R13, t8-20(SP)
+        JMP block1
+block11:
+        // switch.body, preds block12
+        MOVLQZX      x+0(FP), R15
+        MOVL         R15, R13
+        SHRL         $1, R13
+        MOVL         R13, t1-4(SP)
+        MOVL         R13, t10-24(SP)
+        JMP block1
+block13:
+        // switch.body, preds block14
+        MOVLQZX      x+0(FP), R15
+        MOVL         R15, R13
+        ORL          $1, R13
+        MOVL         R13, t1-4(SP)
+        MOVL         R13, t12-28(SP)
+        JMP block1
+block15:
+        // switch.body, preds block16
+        MOVL         $1, R15
+        MOVL         R15, t1-4(SP)
+        JMP block1
+
+
+DATA switcht3s_switch0<>+0(SB)/8, $0x0706050403020100
+GLOBL switcht3s_switch0<>(SB), RODATA|NOPTR, $8
+
+TEXT switcht3s_jumptable<>(SB),NOSPLIT|NOFRAME,$0-0
+        PUSHQ        AX
+        MOVQ         8(SP), AX
+        ADDQ         $7, AX
+        ANDQ         $-8, AX
+        MOVQ         AX, 8(SP)
+        MOVQ         16(SP), AX
+        SHLQ         $3, AX
+        ADDQ         AX, 8(SP)
+        POPQ         AX
+        RET
+
+TEXT ·switcht4s(SB),$16-16
+block0:
+        // entry
+        MOVQ         x+0(FP), R15
+        CMPQ         R15, $1
+        JNE          block3
+block1:
+        // switch.body, preds block0
+        MOVQ         $1, R15
+        MOVQ         R15, ret+8(FP)
+        RET
+block2:
+        // switch.body, preds block3
+        MOVQ         $2, R13
+        MOVQ         R13, ret+8(FP)
+        RET
+block3:
+        // switch.next, preds block0
+        MOVQ         x+0(FP), R12
+        CMPQ         R12, $1000
+        JEQ          block2
+block5:
+        // switch.next, preds block3
+        MOVQ         x+0(FP), R15
+        MOVQ         $1099511627776, R13
+        CMPQ         R15, R13
+        JNE          block7
+block4:
+        // switch.body, preds block5
+        MOVQ         $3, R15
+        MOVQ         R15, ret+8(FP)
+        RET
+block6:
+        // switch.body, preds block7
+        MOVQ         $4, R13
+        MOVQ         R13, ret+8(FP)
+        RET
+block7:
+        // switch.next, preds block5
+        MOVQ         x+0(FP), R12
+        CMPQ         R12, $-1
+        JEQ          block6
+block9:
+        // switch.next, preds block7
+        MOVQ         x+0(FP), R15
+        CMPQ         R15, $2
+        JNE          block11
+block8:
+        // switch.body, preds block9
+        MOVQ         $5, R15
+        MOVQ         R15, ret+8(FP)
+        RET
+block10:
+        // switch.body, preds block11
+        MOVQ         $6, R13
+        MOVQ         R13, ret+8(FP)
+        RET
+block11:
+        // switch.next, preds block9
+        MOVQ         x+0(FP), R12
+        CMPQ         R12, $3
+        JEQ          block10
+block13:
+        // switch.next, preds block11
+        MOVQ         x+0(FP), R15
+        CMPQ         R15, $4
+        JNE          block15
+block12:
+        // switch.body, preds block13
+        MOVQ         $7, R15
+        MOVQ         R15, ret+8(FP)
+        RET
+block14:
+        // switch.body, preds block15
+        MOVQ         $8, R13
+        MOVQ         R13, ret+8(FP)
+        RET
+block15:
+        // switch.next, preds block13
+        MOVQ         x+0(FP), R12
+        CMPQ         R12, $5
+        JEQ          block14
+block16:
+        // switch.next, preds block15
+        MOVQ         $0, R15
+        MOVQ         R15, ret+8(FP)
+        RET
+
@@ -0,0 +1,67 @@
+// +build amd64,gc
+
+package tests
+
+import (
+	"testing"
+
+	"github.com/bjwbell/gensimd/simd"
+)
+
+//go:generate gensimd -fn "tailt0, tailt1" -outfn "tailt0s, tailt1s" -f "$GOFILE" -o "tail_test_amd64.s"
+
+func tailt0s(dst, src []byte, k byte)
+func tailt1s(dst, src []byte, k byte)
+
+// the last partial vector handled by a scalar loop
+func tailt0(dst, src []byte, k byte) {
+	v := simd.SplatU8x16(k)
+	i := 0
+	for ; i+16 <= len(src); i += 16 {
+		simd.StoreU8x16(dst, i, simd.XorU8x16(simd.LoadU8x16(src, i), v))
+	}
+	for ; i < len(src); i++ {
+		dst[i] = src[i] ^ k
+	}
+}
+
+// the last vector ending at len(src), overlapping the previous one, its
+// bytes are stored again with the same values
+func tailt1(dst, src []byte, k byte) {
+	if len(src) < 16 {
+		for i := 0; i < len(src); i++ {
+			dst[i] = src[i] ^ k
+		}
+		return
+	}
+	v := simd.SplatU8x16(k)
+	i := 0
+	for ; i+16 <= len(src); i += 16 {
+		simd.StoreU8x16(dst, i, simd.XorU8x16(simd.LoadU8x16(src, i), v))
+	}
+	if i < len(src) {
+		last := len(src) - 16
+		simd.StoreU8x16(dst, last, simd.XorU8x16(simd.LoadU8x16(src, last), v))
+	}
+}
+
+func TestTail(t *testing.T) {
+	for n := 0; n < 50; n++ {
+		src := make([]byte, n)
+		for i := range src {
+			src[i] = byte(i*37 + 5)
+		}
+		expected := make([]byte, n)
+		tailt0(expected, src, 0x5a)
+		for _, fn := range []struct {
+			name string
+			f    func(dst, src []byte, k byte)
+		}{{"scalar", tailt0s}, {"overlapped", tailt1s}} {
+			got := make([]byte, n)
+			fn.f(got, src, 0x5a)
+			if string(got) != string(expected) {
+				t.Errorf("%v n=%v %v != %v", fn.name, n, got, expected)
+			}
+		}
+	}
+}
@@ -0,0 +1,205 @@
+// Code generated by gensimd. DO NOT EDIT.
+// gensimd version: devel
+// gensimd command: gensimd -f tail_test.go -fn "tailt0, tailt1" -o tail_test_amd64.s -outfn "tailt0s, tailt1s"
+// gensimd source: tail_test.go sha256:a385a9d4a84d95da56cd71a3a84824eee614d9adfe95733e60ee9e64c7de2d90
+// gensimd target: avx2
+
+//go:build amd64 && !noasm && !appengine
+// +build amd64,!noasm,!appengine
+
+#include "textflag.h"
+
+TEXT ·tailt0s(SB),$144-49
+block0:
+        // entry
+        MOVBQZX      k+48(FP), R15
+        MOVBQZX      R15, R13
+        IMUL3Q       $16843009, R13, R13
+        MOVQ         R13, X14
+        PSHUFL       $0, X14, X14
+        MOVO         X14, X0
+        MOVQ         src_len+32(FP), R13
+        MOVQ         R13, R12
+        MOVQ         R12, BX
+        MOVQ         $0, R11
+        MOVQ         R11, t5-80(SP)
+        MOVQ         R12, t7-96(SP)
+        MOVOU        X14, t0-32(SP)
+block2:
+        // for.loop, preds block0 block1
+        MOVQ         t5-80(SP), R15
+        MOVQ         R15, R13
+        ADDQ         $16, R13
+        MOVQ         BX, R12
+        CMPQ         R13, R12
+        SETLE        R11
+        MOVQ         R15, t14-131(SP)
+        MOVQ         src_base+24(FP), R10
+        LEAQ         (R10)(R15*1), R10
+        MOVQ         R10, ivptr0-8(SP)
+        MOVQ         dst_base+0(FP), R10
+        LEAQ         (R10)(R15*1), R10
+        MOVQ         R10, ivptr1-16(SP)
+        MOVB         R11, t8-97(SP)
+        CMPB         R11, $0
+        JEQ          block5
+block1:
+        // for.body, preds block2
+        MOVQ         src_base+24(FP), R15
+        MOVQ         t5-80(SP), R13
+        MOVOU        (R15)(R13*1), X14
+        MOVO         X0, X13
+        MOVO         X14, X12
+        PXOR         X13, X12
+        MOVQ         dst_base+0(FP), R12
+        MOVOU        X12, (R12)(R13*1)
+        MOVQ         R13, R11
+        ADDQ         $16, R11
+        MOVQ         R11, t5-80(SP)
+        MOVQ         R11, t4-72(SP)
+        JMP block2
+block3:
+        // for.body, preds block5
+        MOVQ         ivptr0-8(SP), R15
+        MOVQ         R15, R13
+        MOVB         (R13), R12
+        MOVB         R12, t10-106(SP)
+        MOVBQZX      t10-106(SP), R12
+        MOVBQZX      k+48(FP), R11
+        XORQ         R11, R12
+        MOVQ         ivptr1-16(SP), R10
+        MOVQ         R10, R9
+        MOVB         R12, (R9)
+        MOVQ         t14-131(SP), R8
+        MOVQ         R8, BP
+        ADDQ         $1, BP
+        MOVQ         BP, t14-131(SP)
+        LEAQ         1(R15), R15
+        MOVQ         R15, ivptr0-8(SP)
+        LEAQ         1(R10), R10
+        MOVQ         R10, ivptr1-16(SP)
+        MOVQ         BP, t13-123(SP)
+block5:
+        // for.loop, preds block2 block3
+        MOVQ         src_len+32(FP), R15
+        MOVQ         R15, R13
+        MOVQ         t14-131(SP), R12
+        CMPQ         R12, R13
+        JLT          block3
+block4:
+        // for.done, preds block5
+        RET
+
+TEXT ·tailt1s(SB),$208-49
+        MOVBQZX      k+48(FP), R8
+block0:
+        // entry
+        MOVQ         src_len+32(FP), R15
+        MOVQ         R15, R13
+        CMPQ         R13, $16
+        JGE          block2
+block1:
+        // if.then, preds block0
+        MOVQ         src_len+32(FP), R15
+        MOVQ         R15, R13
+        MOVQ         R13, BX
+        MOVQ         $0, R12
+        MOVQ         R12, t3-49(SP)
+        MOVQ         src_base+24(FP), R11
+        LEAQ         (R11)(R12*1), R11
+        MOVQ         R11, ivptr0-8(SP)
+        MOVQ         dst_base+0(FP), R11
+        LEAQ         (R11)(R12*1), R11
+        MOVQ         R11, ivptr1-16(SP)
+        MOVQ         R13, t4-57(SP)
+block3:
+        // for.loop, preds block1 block4
+        MOVQ         t3-49(SP), R15
+        MOVQ         BX, R13
+        CMPQ         R15, R13
+        JGE          block5
+block4:
+        // for.body, preds block3
+        MOVQ         ivptr0-8(SP), R15
+        MOVQ         R15, R13
+        MOVB         (R13), R12
+        MOVB         R12, t7-67(SP)
+        MOVBQZX      t7-67(SP), R12
+        MOVB         R8, R11
+        XORQ         R11, R12
+        MOVQ         ivptr1-16(SP), R10
+        MOVQ         R10, R9
+        MOVB         R12, (R9)
+        MOVQ         t3-49(SP), BP
+        MOVQ         BP, DI
+        ADDQ         $1, DI
+        MOVQ         DI, t3-49(SP)
+        LEAQ         1(R15), R15
+        MOVQ         R15, ivptr0-8(SP)
+        LEAQ         1(R10), R10
+        MOVQ         R10, ivptr1-16(SP)
+        MOVQ         DI, t10-84(SP)
+        JMP block3
+block2:
+        // if.done, preds block0
+        MOVB         R8, R15
+        MOVBQZX      R15, R13
+        IMUL3Q       $16843009, R13, R13
+        MOVQ         R13, X14
+        PSHUFL       $0, X14, X14
+        MOVO         X14, X0
+        MOVQ         src_len+32(FP), R13
+        MOVQ         R13, R12
+        MOVQ         $0, R11
+        MOVQ         R11, t17-141(SP)
+        MOVQ         R12, t19-157(SP)
+        MOVOU        X14, t2-41(SP)
+block8:
+        // for.loop, preds block2 block6
+        MOVQ         t17-141(SP), R15
+        MOVQ         R15, R13
+        ADDQ         $16, R13
+        MOVQ         t19-157(SP), R12
+        CMPQ         R13, R12
+        JGT          block7
+block6:
+        // for.body, preds block8
+        MOVQ         src_base+24(FP), R15
+        MOVQ         t17-141(SP), R13
+        MOVOU        (R15)(R13*1), X14
+        MOVO         X0, X13
+        MOVO         X14, X12
+        PXOR         X13, X12
+        MOVQ         dst_base+0(FP), R12
+        MOVOU        X12, (R12)(R13*1)
+        MOVQ         R13, R11
+        ADDQ         $16, R11
+        MOVQ         R11, t17-141(SP)
+        MOVQ         R11, t14-124(SP)
+        JMP block8
+block5:
+        // for.done, preds block3
+        RET
+block7:
+        // for.done, preds block8
+        MOVQ         src_len+32(FP), R15
+        MOVQ         R15, R13
+        MOVQ         t17-141(SP), R12
+        CMPQ         R12, R13
+        JGE          block10
+block9:
+        // if.then, preds block7
+        MOVQ         src_len+32(FP), R15
+        MOVQ         R15, R13
+        SUBQ         $16, R13
+        MOVQ         src_base+24(FP), R12
+        MOVOU        (R12)(R13*1), X14
+        MOVO         X0, X13
+        MOVO         X14, X12
+        PXOR         X13, X12
+        MOVQ         dst_base+0(FP), R11
+        MOVOU        X12, (R11)(R13*1)
+block10:
+        // if.done, preds block7 block9
+        RET
+
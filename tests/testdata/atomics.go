@@ -0,0 +1,38 @@
+// Package atomics has loops updating counters shared between goroutines
+// with sync/atomic and the simd atomics, for the atomics tests.
+package atomics
+
+import (
+	"sync/atomic"
+
+	"github.com/bjwbell/gensimd/simd"
+)
+
+// count adds the number of bytes of b equal to c to *n.
+func count(b []byte, c byte, n *uint64) uint64 {
+	total := uint64(0)
+	for i := 0; i < len(b); i++ {
+		if b[i] == c {
+			total = atomic.AddUint64(n, 1)
+		}
+	}
+	return total
+}
+
+// histogram adds 1 to hist[b[i]&3] for each byte of b.
+func histogram(b []byte, hist []uint32) {
+	for i := 0; i < len(b); i++ {
+		simd.AtomicAddUint32(&hist[b[i]&3], 1)
+	}
+}
+
+// claim sets *owner to id if it's 0 and returns whether it did.
+func claim(owner *int64, id int64) bool {
+	return atomic.CompareAndSwapInt64(owner, 0, id)
+}
+
+// publish stores v to *p and then returns the value loaded from *q.
+func publish(p *uint64, v uint64, q *uint32) uint32 {
+	simd.AtomicStoreUint64(p, v)
+	return simd.AtomicLoadUint32(q)
+}
@@ -0,0 +1,23 @@
+// Package barrier has a ring buffer writer and readers with memory
+// barriers for the barrier tests.
+package barrier
+
+import "github.com/bjwbell/gensimd/simd"
+
+// push writes v to the next slot of ring and then publishes it by storing
+// the new head, returning the head.
+func push(ring []uint32, head []uint64, v uint32) uint64 {
+	h := head[0]
+	ring[h%uint64(len(ring))] = v
+	simd.Fence()
+	head[0] = h + 1
+	return h + 1
+}
+
+// read loads the low half of a little endian uint32 before the barrier and
+// the high half after it, they mustn't be combined into one load.
+func read(b []byte) uint32 {
+	lo := uint32(b[0]) | uint32(b[1])<<8
+	simd.CompilerBarrier()
+	return lo | uint32(b[2])<<16 | uint32(b[3])<<24
+}
@@ -0,0 +1,36 @@
+// Package cabi has functions called through their C entry points by the C
+// ABI tests, with integer, float, pointer, and slice parameters, more than
+// fit in the argument registers, and narrow results.
+package cabi
+
+import "github.com/bjwbell/gensimd/simd"
+
+func sum(x []int32) int32 {
+	s := int32(0)
+	for i := 0; i < len(x); i++ {
+		s += x[i]
+	}
+	return s
+}
+
+// axpy sets y to a*x+y four floats at a time.
+func axpy(a float32, x, y []simd.F32x4) {
+	av := simd.F32x4{a, a, a, a}
+	for i := 0; i < len(x); i++ {
+		y[i] = simd.AddF32x4(simd.MulF32x4(av, x[i]), y[i])
+	}
+}
+
+// mix has 7 integer words and 9 floats, the last of each are on the C stack.
+func mix(a int8, b uint16, c int32, d int64, e uint32, f *float64, h int, x0, x1, x2, x3, x4, x5, x6, x7 float64, x8 float32) float64 {
+	*f = float64(a) + float64(b) + float64(c) + float64(d) + float64(e) + float64(h)
+	return x0 + x1 + x2 + x3 + x4 + x5 + x6 + x7 + float64(x8)
+}
+
+func neg(x int16) int16 {
+	return -x
+}
+
+func less(x, y uint8) bool {
+	return x < y
+}
@@ -0,0 +1,45 @@
+// Package emptyslice has loops over slices for the zero-length and nil
+// slice tests, they must return without reading memory for empty inputs.
+package emptyslice
+
+// a loop bounded by len
+func sum(x []int64) int64 {
+	s := int64(0)
+	for i := 0; i < len(x); i++ {
+		s += x[i]
+	}
+	return s
+}
+
+// a range loop
+func rangeSum(x []float32) float32 {
+	s := float32(0)
+	for _, v := range x {
+		s += v
+	}
+	return s
+}
+
+// a loop over two slices with element pointers advanced by 2 elements
+func addPairs(dst, src []int32) int {
+	n := len(dst)
+	if len(src) < n {
+		n = len(src)
+	}
+	i := 0
+	for ; i+1 < n; i += 2 {
+		dst[i] += src[i]
+		dst[i+1] += src[i+1]
+	}
+	return i
+}
+
+// a loop counting down from the last element
+func last(x []uint8, b uint8) int {
+	for i := len(x) - 1; i >= 0; i-- {
+		if x[i] == b {
+			return i
+		}
+	}
+	return -1
+}
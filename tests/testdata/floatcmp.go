@@ -0,0 +1,86 @@
+// Package floatcmp has float comparisons for the NaN, signed zero, and
+// infinity tests, stored as bools and branched on.
+package floatcmp
+
+func eq(x, y float64) bool {
+	return x == y
+}
+
+func ne(x, y float64) bool {
+	return x != y
+}
+
+func lt(x, y float64) bool {
+	return x < y
+}
+
+func le(x, y float64) bool {
+	return x <= y
+}
+
+func gt(x, y float64) bool {
+	return x > y
+}
+
+func ge(x, y float64) bool {
+	return x >= y
+}
+
+func eq32(x, y float32) bool {
+	return x == y
+}
+
+func gt32(x, y float32) bool {
+	return x > y
+}
+
+// the comparisons in ifs jump on the flags
+
+func ifEq(x, y float64) int {
+	if x == y {
+		return 1
+	}
+	return 2
+}
+
+func ifNe(x, y float64) int {
+	if x != y {
+		return 1
+	}
+	return 2
+}
+
+func ifLt(x, y float64) int {
+	if x < y {
+		return 1
+	}
+	return 2
+}
+
+func ifLe(x, y float64) int {
+	if x <= y {
+		return 1
+	}
+	return 2
+}
+
+func ifGt(x, y float64) int {
+	if x > y {
+		return 1
+	}
+	return 2
+}
+
+func ifGe(x, y float64) int {
+	if x >= y {
+		return 1
+	}
+	return 2
+}
+
+func ifNotGe32(x, y float32) int {
+	if !(x >= y) {
+		return 1
+	}
+	return 2
+}
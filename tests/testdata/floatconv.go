@@ -0,0 +1,43 @@
+// Package floatconv has float to integer conversions for the truncation
+// and out of range tests.
+package floatconv
+
+func f64ToInt64(x float64) int64 {
+	return int64(x)
+}
+
+func f64ToInt32(x float64) int32 {
+	return int32(x)
+}
+
+func f64ToInt8(x float64) int8 {
+	return int8(x)
+}
+
+func f64ToUint8(x float64) uint8 {
+	return uint8(x)
+}
+
+func f64ToUint16(x float64) uint16 {
+	return uint16(x)
+}
+
+func f64ToUint32(x float64) uint32 {
+	return uint32(x)
+}
+
+func f64ToUint64(x float64) uint64 {
+	return uint64(x)
+}
+
+func f32ToInt64(x float32) int64 {
+	return int64(x)
+}
+
+func f32ToUint32(x float32) uint32 {
+	return uint32(x)
+}
+
+func f32ToUint64(x float32) uint64 {
+	return uint64(x)
+}
@@ -0,0 +1,51 @@
+// Package index has slices and arrays indexed by integers of every width
+// and signedness, the indexes must be extended to 64 bits before scaling.
+package index
+
+func byUint32(x []int64, i uint32) int64 {
+	return x[i]
+}
+
+func byInt32(x []int64, i int32) int64 {
+	return x[i]
+}
+
+func byUint8(x []uint16, i uint8) uint16 {
+	return x[i]
+}
+
+func byInt16(x []float64, i int16) float64 {
+	return x[i]
+}
+
+// indexes narrowed from an int, the bytes above them are the int's
+func narrowInt8(x []int32, i int) int32 {
+	return x[int8(i)]
+}
+
+func narrowUint16(x *[4]float32, i int) float32 {
+	return x[uint16(i)&3]
+}
+
+func narrowInt32(x []uint8, i int64) uint8 {
+	return x[int32(i)]
+}
+
+// a 32 bit index from 32 bit arithmetic
+func sumIndex(x []int64, i, j uint32) int64 {
+	return x[i+j]
+}
+
+// constant indexes whose offsets don't fit in a 32 bit displacement
+func farInt64(x []int64) int64 {
+	return x[1<<28]
+}
+
+func farArray(x *[1 << 20][4096]uint8) uint8 {
+	return x[1<<19+3][5]
+}
+
+// elements of 2GB, whose size doesn't fit in a 32 bit immediate
+func hugeElem(x *[3][1 << 31]uint8, i int) uint8 {
+	return x[i][7] + x[2][9]
+}
@@ -0,0 +1,82 @@
+// Package overflow has integer operations whose results are defined by Go
+// for all inputs, for the overflow tests: negations and divisions of the
+// most negative integers, shifts by counts of at least the width, and
+// conversions between signed and unsigned integers and floats.
+package overflow
+
+func negInt64(x int64) int64 {
+	return -x
+}
+
+func negInt8(x int8) int8 {
+	return -x
+}
+
+func divInt64(x, y int64) int64 {
+	return x / y
+}
+
+func remInt64(x, y int64) int64 {
+	return x % y
+}
+
+func divInt32(x, y int32) int32 {
+	return x / y
+}
+
+func remInt16(x, y int16) int16 {
+	return x % y
+}
+
+func divInt8(x, y int8) int8 {
+	return x / y
+}
+
+func shlUint64(x, s uint64) uint64 {
+	return x << s
+}
+
+func shrInt64(x int64, s uint64) int64 {
+	return x >> s
+}
+
+// the count is wider than x, its low byte alone may be less than the width
+func shrInt32(x int32, s uint16) int32 {
+	return x >> s
+}
+
+func shlUint8(x, s uint8) uint8 {
+	return x << s
+}
+
+func shrInt8(x int8, s uint32) int8 {
+	return x >> s
+}
+
+func int8ToUint64(x int8) uint64 {
+	return uint64(x)
+}
+
+func uint32ToInt64(x uint32) int64 {
+	return int64(x)
+}
+
+func uint64ToInt32(x uint64) int32 {
+	return int32(x)
+}
+
+func int64ToInt8(x int64) int8 {
+	return int8(x)
+}
+
+func int8ToFloat64(x int8) float64 {
+	return float64(x)
+}
+
+func uint16ToFloat32(x uint16) float32 {
+	return float32(x)
+}
+
+func uint32ToFloat64(x uint32) float64 {
+	return float64(x)
+}
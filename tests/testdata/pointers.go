@@ -0,0 +1,71 @@
+// Package pointers has kernels taking pointers to slice headers and
+// pointers to pointers for the nested pointer tests.
+package pointers
+
+// sum adds the elements of the slice p points to.
+func sum(p *[]float64) float64 {
+	s := 0.0
+	for _, v := range *p {
+		s += v
+	}
+	return s
+}
+
+// set stores v to element i of the slice p points to.
+func set(p *[]float64, i int, v float64) {
+	(*p)[i] = v
+}
+
+// add adds v to every element of the slice p points to.
+func add(p *[]int32, v int32) {
+	x := *p
+	for i := range x {
+		x[i] += v
+	}
+}
+
+// length returns the length of the slice p points to.
+func length(p *[]int32) int {
+	return len(*p)
+}
+
+// total adds the values the pointers of the slice p points to point to.
+func total(p *[]*int32) int32 {
+	s := int32(0)
+	for _, v := range *p {
+		s += *v
+	}
+	return s
+}
+
+// load2 loads through two pointers.
+func load2(p **int32) int32 {
+	return **p
+}
+
+// store2 stores v through two pointers.
+func store2(p **int32, v int32) {
+	**p = v
+}
+
+// load3 loads through three pointers.
+func load3(p ***int64) int64 {
+	return ***p
+}
+
+// store3 stores v through three pointers.
+func store3(p ***int64, v int64) {
+	***p = v
+}
+
+// double loads a float through two pointers and doubles it.
+func double(p **float64) float64 {
+	return **p * 2
+}
+
+// ends adds the first and last elements of the array p points to through
+// two pointers.
+func ends(p **[4]float32) float32 {
+	a := **p
+	return a[0] + a[3]
+}
@@ -0,0 +1,90 @@
+// Package scratch has kernels using local arrays as scratch space for the
+// scratch array tests.
+package scratch
+
+import "github.com/bjwbell/gensimd/simd"
+
+// histo counts the low 6 bits of x in a scratch array and returns the
+// largest count.
+func histo(x []byte) int32 {
+	var tmp [64]int32
+	for i := range x {
+		tmp[x[i]&63]++
+	}
+	m := int32(0)
+	for i := range tmp {
+		if tmp[i] > m {
+			m = tmp[i]
+		}
+	}
+	return m
+}
+
+// fold adds x into 8 sums through a slice of a scratch array.
+func fold(x []int32) int32 {
+	var tmp [8]int32
+	s := tmp[:]
+	for i := range x {
+		s[i&7] += x[i]
+	}
+	return s[0] + s[7]
+}
+
+// window sums tmp[lo:hi] of a scratch array holding 0, 1, ..., 15 plus 100
+// times its length, and 10000 times the element after it, reslicing the
+// window up to its capacity.
+func window(lo, hi int) int {
+	var tmp [16]int
+	for i := range tmp {
+		tmp[i] = i
+	}
+	w := tmp[lo:hi]
+	n := len(w) * 100
+	for i := range w {
+		n += w[i]
+	}
+	e := w[:len(w)+1]
+	return n + 10000*e[len(e)-1]
+}
+
+// words folds x into 8 bytes and loads their halves as little endian
+// words.
+func words(x []byte) uint32 {
+	var tmp [8]byte
+	for i := range x {
+		tmp[i&7] += x[i]
+	}
+	s := tmp[:]
+	return simd.LoadU32LE(s) + simd.LoadU32LE(s[4:])
+}
+
+// ends stores 16 bytes of x to a scratch array and adds the first and
+// last.
+func ends(x []byte) byte {
+	var tmp [16]byte
+	simd.StoreU8x16(tmp[:], 0, simd.LoadU8x16(x, 0))
+	return tmp[0] + tmp[15]
+}
+
+// swap reverses the bytes of x by storing it little endian and loading it
+// big endian.
+func swap(x uint32) uint32 {
+	var tmp [4]byte
+	simd.StoreU32LE(tmp[:], x)
+	return simd.LoadU32BE(tmp[:])
+}
+
+// fresh reads the scratch arrays of a loop body before writing them, they're
+// zeroed every iteration so the sum is 0.
+func fresh(n int) int {
+	s := 0
+	for i := 0; i < n; i++ {
+		var tmp [4]byte
+		var wide [40]byte
+		s += int(tmp[i&3]) + int(wide[i%40])
+		tmp[i&3] = byte(i + 1)
+		wide[i%40] = byte(i + 1)
+		s += int(tmp[i&3]) - int(wide[i%40])
+	}
+	return s
+}
@@ -0,0 +1,17 @@
+// Package variants has a function generated for several targets for the
+// dispatch tests.
+package variants
+
+func sum(x []int32) int32 {
+	total := int32(0)
+	for i := 0; i < len(x); i++ {
+		total += x[i]
+	}
+	return total
+}
+
+func scale(x []float32, s float32) {
+	for i := range x {
+		x[i] *= s
+	}
+}
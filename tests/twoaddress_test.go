@@ -0,0 +1,96 @@
+// +build amd64,gc
+
+package tests
+
+import "testing"
+
+//go:generate gensimd -fn "twoaddrt0, twoaddrt1, twoaddrt2, twoaddrt3, twoaddrt4, twoaddrt5" -outfn "twoaddrt0s, twoaddrt1s, twoaddrt2s, twoaddrt3s, twoaddrt4s, twoaddrt5s" -f "$GOFILE" -o "twoaddress_test_amd64.s"
+
+// The intermediate values die at their last use, so each result is computed
+// in the register of its x operand.
+
+func twoaddrt0s(x, y int64) int64
+func twoaddrt1s(x, y int32) int32
+func twoaddrt2s(x, y int8) int8
+func twoaddrt3s(x uint32, s uint8) uint32
+func twoaddrt4s(x, y uint16) uint16
+func twoaddrt5s(x, y float64) float64
+
+func twoaddrt0(x, y int64) int64 {
+	a := x - y
+	b := a - x
+	c := b * y
+	return c - a
+}
+
+func twoaddrt1(x, y int32) int32 {
+	a := x + y
+	b := a - x
+	c := b * a
+	d := c / y
+	e := d % y
+	return e - b
+}
+
+func twoaddrt2(x, y int8) int8 {
+	a := x - y
+	b := a * y
+	c := b / y
+	return c - a
+}
+
+func twoaddrt3(x uint32, s uint8) uint32 {
+	a := x + 1
+	b := a << s
+	c := b >> s
+	d := c ^ x
+	return d | a
+}
+
+func twoaddrt4(x, y uint16) uint16 {
+	a := x | y
+	b := a &^ y
+	c := y &^ b
+	d := c & a
+	return d + b
+}
+
+func twoaddrt5(x, y float64) float64 {
+	a := x - y
+	b := a / y
+	c := b - a
+	return c * x
+}
+
+func TestTwoAddress(t *testing.T) {
+	for _, x := range []int64{0, 1, -7, 1000, 1 << 40, -1 << 62} {
+		for _, y := range []int64{1, -1, 3, -13, 1 << 20} {
+			if twoaddrt0s(x, y) != twoaddrt0(x, y) {
+				t.Errorf("twoaddrt0s(%v, %v) %v != %v", x, y, twoaddrt0s(x, y), twoaddrt0(x, y))
+			}
+			x32, y32 := int32(x), int32(y)
+			if twoaddrt1s(x32, y32) != twoaddrt1(x32, y32) {
+				t.Errorf("twoaddrt1s(%v, %v) %v != %v", x32, y32, twoaddrt1s(x32, y32), twoaddrt1(x32, y32))
+			}
+			x8, y8 := int8(x), int8(y)
+			if y8 != 0 && twoaddrt2s(x8, y8) != twoaddrt2(x8, y8) {
+				t.Errorf("twoaddrt2s(%v, %v) %v != %v", x8, y8, twoaddrt2s(x8, y8), twoaddrt2(x8, y8))
+			}
+			x16, y16 := uint16(x), uint16(y)
+			if twoaddrt4s(x16, y16) != twoaddrt4(x16, y16) {
+				t.Errorf("twoaddrt4s(%v, %v) %v != %v", x16, y16, twoaddrt4s(x16, y16), twoaddrt4(x16, y16))
+			}
+			xf, yf := float64(x), float64(y)
+			if twoaddrt5s(xf, yf) != twoaddrt5(xf, yf) {
+				t.Errorf("twoaddrt5s(%v, %v) %v != %v", xf, yf, twoaddrt5s(xf, yf), twoaddrt5(xf, yf))
+			}
+		}
+	}
+	for _, x := range []uint32{0, 1, 0xff, 0x80000000, 0xffffffff} {
+		for _, s := range []uint8{0, 1, 5, 31, 32, 200} {
+			if twoaddrt3s(x, s) != twoaddrt3(x, s) {
+				t.Errorf("twoaddrt3s(%v, %v) %v != %v", x, s, twoaddrt3s(x, s), twoaddrt3(x, s))
+			}
+		}
+	}
+}
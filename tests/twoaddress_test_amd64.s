@@ -0,0 +1,144 @@
+// Code generated by gensimd. DO NOT EDIT.
+// gensimd version: devel
+// gensimd command: gensimd -f twoaddress_test.go -fn "twoaddrt0, twoaddrt1, twoaddrt2, twoaddrt3, twoaddrt4, twoaddrt5" -o twoaddress_test_amd64.s -outfn "twoaddrt0s, twoaddrt1s, twoaddrt2s, twoaddrt3s, twoaddrt4s, twoaddrt5s"
+// gensimd source: twoaddress_test.go sha256:b7761731f91c991ab9ccf40f6d7faa56e6140d42478e31ec1842aeaff752fb41
+// gensimd target: avx2
+
+//go:build amd64 && !noasm && !appengine
+// +build amd64,!noasm,!appengine
+
+#include "textflag.h"
+
+TEXT ·twoaddrt0s(SB),$40-24
+block0:
+        // entry
+        MOVQ         x+0(FP), R15
+        MOVQ         y+8(FP), R13
+        MOVQ         R15, R12
+        SUBQ         R13, R12
+        MOVQ         R12, R11
+        SUBQ         R15, R11
+        MOVQ         R11, AX
+        IMULQ        R13
+        MOVQ         AX, R11
+        SUBQ         R12, R11
+        MOVQ         R11, ret+16(FP)
+        RET
+
+TEXT ·twoaddrt1s(SB),$32-12
+block0:
+        // entry
+        MOVLQZX      x+0(FP), R15
+        MOVLQZX      y+4(FP), R13
+        MOVL         R15, R12
+        ADDL         R13, R12
+        MOVL         R12, R11
+        SUBL         R15, R11
+        MOVL         R11, R10
+        MOVL         R10, AX
+        IMULL        R12
+        MOVL         AX, R10
+        MOVLQSX      R10, AX
+        MOVQ         AX, DX
+        SARQ         $63, DX
+        CMPL         R13, $-1
+        JNE          lbl1
+        NEGQ         AX
+        XORQ         DX, DX
+        JMP          lbl2
+lbl1:
+        IDIVL        R13
+lbl2:
+        MOVL         AX, R10
+        MOVLQSX      R10, AX
+        MOVQ         AX, DX
+        SARQ         $63, DX
+        CMPL         R13, $-1
+        JNE          lbl3
+        NEGQ         AX
+        XORQ         DX, DX
+        JMP          lbl4
+lbl3:
+        IDIVL        R13
+lbl4:
+        MOVL         DX, R10
+        SUBL         R11, R10
+        MOVL         R10, ret+8(FP)
+        RET
+
+TEXT ·twoaddrt2s(SB),$8-9
+block0:
+        // entry
+        MOVBQZX      x+0(FP), R15
+        MOVBQZX      y+1(FP), R13
+        MOVB         R15, R12
+        SUBB         R13, R12
+        MOVB         R12, R11
+        MOVB         R11, AX
+        IMULB        R13
+        MOVB         AX, R11
+        MOVBQSX      R11, AX
+        CMPB         R13, $-1
+        JNE          lbl1
+        NEGQ         AX
+        JMP          lbl2
+lbl1:
+        IDIVB        R13
+lbl2:
+        MOVB         AX, R11
+        SUBB         R12, R11
+        MOVB         R11, ret+8(FP)
+        RET
+
+TEXT ·twoaddrt3s(SB),$24-12
+block0:
+        // entry
+        MOVLQZX      x+0(FP), R15
+        MOVL         R15, R13
+        ADDL         $1, R13
+        MOVBQZX      s+4(FP), R12
+        XORQ         R10, R10
+        CMPB         R12, $32
+        SHLXL        R12, R13, R11
+        CMOVLCC      R10, R11
+        XORQ         R10, R10
+        CMPB         R12, $32
+        SHRXL        R12, R11, R11
+        CMOVLCC      R10, R11
+        XORQ         R15, R11
+        ORQ          R13, R11
+        MOVL         R11, ret+8(FP)
+        RET
+
+TEXT ·twoaddrt4s(SB),$16-10
+block0:
+        // entry
+        MOVWQZX      x+0(FP), R15
+        MOVWQZX      y+2(FP), R13
+        MOVW         R13, R12
+        ORQ          R15, R12
+        MOVW         R13, R11
+        XORW         $-1, R11
+        ANDW         R12, R11
+        MOVW         R11, R10
+        XORW         $-1, R10
+        ANDW         R13, R10
+        ANDW         R12, R10
+        ADDW         R11, R10
+        MOVW         R10, ret+8(FP)
+        RET
+
+TEXT ·twoaddrt5s(SB),$40-24
+block0:
+        // entry
+        MOVSD        x+0(FP), X14
+        MOVSD        y+8(FP), X13
+        MOVO         X14, X12
+        SUBSD        X13, X12
+        MOVO         X12, X11
+        DIVSD        X13, X11
+        SUBSD        X12, X11
+        MULSD        X14, X11
+        MOVSD        X11, ret+16(FP)
+        RET
+
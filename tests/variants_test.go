@@ -0,0 +1,27 @@
+// +build amd64,gc
+
+package tests
+
+import (
+	"testing"
+
+	"github.com/bjwbell/gensimd/codegen"
+	"github.com/bjwbell/gensimd/internal/asmtest"
+)
+
+// TestVariants checks functions generated with Options.Variants jump to a
+// variant with the stub's arguments and results.
+func TestVariants(t *testing.T) {
+	opts := codegen.DefaultOptions()
+	opts.Variants = []string{codegen.TargetAVX2, codegen.TargetSSE2, codegen.TargetSSE41}
+	kernels := asmtest.Build(t, "testdata/variants.go", opts, "sum", "scale")
+	x := []int32{1, -2, 3, 1 << 30, 5}
+	if got := kernels.Call(t, "sum", x)[0].(int32); got != 1<<30+7 {
+		t.Errorf("sum(%v) = %v, expected %v", x, got, 1<<30+7)
+	}
+	f := []float32{1, 2.5, -3}
+	kernels.Call(t, "scale", f, float32(2))
+	if f[0] != 2 || f[1] != 5 || f[2] != -6 {
+		t.Errorf("scale by 2 = %v, expected [2 5 -6]", f)
+	}
+}
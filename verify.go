@@ -0,0 +1,61 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+
+	"github.com/bjwbell/gensimd/codegen"
+)
+
+// diffContext is the number of unchanged lines around each change of the
+// -verify diffs.
+const diffContext = 3
+
+// outputs writes the generated files with header at the top, or with
+// -verify compares them to the files on disk and prints a unified diff of
+// each one that differs.
+type outputs struct {
+	verify bool
+	header codegen.Header
+	stale  []string
+}
+
+// write writes contents to filename, or compares them with -verify.
+func (o *outputs) write(filename, contents string) {
+	if !o.verify {
+		writeFile(filename, o.header.String()+contents)
+		return
+	}
+	old, err := ioutil.ReadFile(filename)
+	if err != nil && !os.IsNotExist(err) {
+		log.Fatalf("Cannot read file \"%v\" to verify, error \"%v\"\n", filename, err)
+	}
+	// a file generated by another gensimd version is up to date if only
+	// the version differs
+	header := o.header
+	if oldHeader, ok := codegen.ParseHeader(string(old)); ok && oldHeader.Version != header.Version {
+		log.Printf("\"%v\" was generated by gensimd %v, verifying with %v\n", filename, oldHeader.Version, header.Version)
+		header.Version = oldHeader.Version
+	}
+	contents = header.String() + contents
+	if diff := codegen.UnifiedDiff(filename, filename+" (generated)", string(old), contents, diffContext); diff != "" {
+		fmt.Print(diff)
+		o.stale = append(o.stale, filename)
+	}
+}
+
+// finish exits with status 1 if -verify found generated files that differ
+// from the files on disk.
+func (o *outputs) finish() {
+	if len(o.stale) > 0 {
+		log.Fatalf("Error %v generated file(s) out of date, %q, run go generate\n", len(o.stale), o.stale)
+	}
+}
+
+func writeFile(filename, contents string) {
+	if err := ioutil.WriteFile(filename, []byte(contents), 0644); err != nil {
+		log.Fatalf("Cannot write to file \"%v\", error \"%v\"\n", filename, err)
+	}
+}
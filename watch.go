@@ -0,0 +1,117 @@
+package main
+
+import (
+	"io/ioutil"
+	"log"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// watchInterval is how often -watch checks the input files for changes.
+const watchInterval = 250 * time.Millisecond
+
+// watch runs gensimd with args, the command line without -watch, every time
+// one of files is saved, until interrupted. Failures are printed and
+// watching continues. Without a -cache directory one is used for the
+// session, so only the functions that changed are generated again.
+func watch(files []string, args []string) {
+	if !hasFlag(args, "cache") {
+		dir, err := ioutil.TempDir("", "gensimd-watch")
+		if err != nil {
+			log.Fatalf("Error creating the -watch cache directory, \"%v\"\n", err)
+		}
+		defer os.RemoveAll(dir)
+		args = append([]string{"-cache", dir}, args...)
+	}
+	exe, err := os.Executable()
+	if err != nil {
+		log.Fatalf("Error finding the gensimd executable to watch with, \"%v\"\n", err)
+	}
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt)
+	modTimes := map[string]time.Time{}
+	for {
+		changed := false
+		for _, file := range files {
+			info, err := os.Stat(file)
+			if err != nil {
+				// editors replace files on save, it's back on the next check
+				continue
+			}
+			if !info.ModTime().Equal(modTimes[file]) {
+				modTimes[file] = info.ModTime()
+				changed = true
+			}
+		}
+		if changed {
+			start := time.Now()
+			log.Printf("Generating \"%v\"\n", strings.Join(files, "\", \""))
+			cmd := exec.Command(exe, args...)
+			cmd.Stdout = os.Stdout
+			cmd.Stderr = os.Stderr
+			if err := cmd.Run(); err != nil {
+				log.Printf("Error generating, %v, watching for changes\n", err)
+			} else {
+				log.Printf("Generated in %v, watching for changes\n", time.Since(start).Round(time.Millisecond))
+			}
+		}
+		select {
+		case <-interrupt:
+			return
+		case <-time.After(watchInterval):
+		}
+	}
+}
+
+// withoutFlag returns args without the boolean flag name.
+func withoutFlag(args []string, name string) []string {
+	out := []string{}
+	for _, arg := range args {
+		if flagName(arg) != name {
+			out = append(out, arg)
+		}
+	}
+	return out
+}
+
+// hasFlag returns whether args set the flag name.
+func hasFlag(args []string, name string) bool {
+	for _, arg := range args {
+		if flagName(arg) == name {
+			return true
+		}
+	}
+	return false
+}
+
+// flagName returns the name of the flag arg, e.g. "cache" for "--cache=dir",
+// or "" if arg isn't a flag.
+func flagName(arg string) string {
+	if !strings.HasPrefix(arg, "-") {
+		return ""
+	}
+	name := strings.TrimPrefix(strings.TrimPrefix(arg, "-"), "-")
+	if i := strings.Index(name, "="); i >= 0 {
+		name = name[:i]
+	}
+	return name
+}
+
+// watchedFiles returns the absolute paths of the files -watch checks.
+func watchedFiles(files ...string) []string {
+	out := []string{}
+	for _, file := range files {
+		if file == "" {
+			continue
+		}
+		if abs, err := filepath.Abs(file); err == nil {
+			file = abs
+		}
+		out = append(out, file)
+	}
+	return out
+}